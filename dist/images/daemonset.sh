@@ -90,6 +90,7 @@ OVNKUBE_METRICS_SCALE_ENABLE=
 OVN_STATELESS_NETPOL_ENABLE="false"
 OVN_ENABLE_INTERCONNECT=
 OVN_ENABLE_OVNKUBE_IDENTITY="true"
+OVN_ENABLE_CRD_VALIDATION="false"
 # IN_UPGRADE is true only if called by upgrade-ovn.sh during the upgrade test,
 # it will render only the parts in ovn-setup.yaml related to RBAC permissions.
 IN_UPGRADE=
@@ -335,6 +336,9 @@ while [ "$1" != "" ]; do
   --enable-ovnkube-identity)
     OVN_ENABLE_OVNKUBE_IDENTITY=$VALUE
     ;;
+  --enable-crd-validation)
+    OVN_ENABLE_CRD_VALIDATION=$VALUE
+    ;;
   --ovn-northd-backoff-interval)
     OVN_NORTHD_BACKOFF_INTERVAL=$VALUE
     ;;
@@ -514,6 +518,9 @@ echo "ovn_enable_multi_external_gateway: ${ovn_enable_multi_external_gateway}"
 ovn_enable_ovnkube_identity=${OVN_ENABLE_OVNKUBE_IDENTITY}
 echo "ovn_enable_ovnkube_identity: ${ovn_enable_ovnkube_identity}"
 
+ovn_enable_crd_validation=${OVN_ENABLE_CRD_VALIDATION}
+echo "ovn_enable_crd_validation: ${ovn_enable_crd_validation}"
+
 ovn_northd_backoff_interval=${OVN_NORTHD_BACKOFF_INTERVAL}
 echo "ovn_northd_backoff_interval: ${ovn_northd_backoff_interval}"
 
@@ -914,6 +921,7 @@ ovn_image=${ovnkube_image} \
   webhook_cert=$(cat "${path_prefix}.crt" | base64 -w0) \
   ovn_enable_multi_node_zone=${ovn_enable_multi_node_zone} \
   ovn_hybrid_overlay_enable=${ovn_hybrid_overlay_enable} \
+  ovn_enable_crd_validation=${ovn_enable_crd_validation} \
   jinjanate ../templates/ovnkube-identity.yaml.j2 -o ${output_dir}/ovnkube-identity.yaml
 
 if ${enable_ipsec}; then
@@ -954,6 +962,8 @@ cp ../templates/k8s.ovn.org_egressfirewalls.yaml.j2 ${output_dir}/k8s.ovn.org_eg
 cp ../templates/k8s.ovn.org_egressips.yaml.j2 ${output_dir}/k8s.ovn.org_egressips.yaml
 cp ../templates/k8s.ovn.org_egressqoses.yaml.j2 ${output_dir}/k8s.ovn.org_egressqoses.yaml
 cp ../templates/k8s.ovn.org_egressservices.yaml.j2 ${output_dir}/k8s.ovn.org_egressservices.yaml
+cp ../templates/k8s.ovn.org_ipamclaims.yaml.j2 ${output_dir}/k8s.ovn.org_ipamclaims.yaml
+cp ../templates/k8s.ovn.org_clusternetworks.yaml.j2 ${output_dir}/k8s.ovn.org_clusternetworks.yaml
 cp ../templates/k8s.ovn.org_adminpolicybasedexternalroutes.yaml.j2 ${output_dir}/k8s.ovn.org_adminpolicybasedexternalroutes.yaml
 cp ../templates/policy.networking.k8s.io_adminnetworkpolicies.yaml ${output_dir}/policy.networking.k8s.io_adminnetworkpolicies.yaml
 cp ../templates/policy.networking.k8s.io_baselineadminnetworkpolicies.yaml ${output_dir}/policy.networking.k8s.io_baselineadminnetworkpolicies.yaml
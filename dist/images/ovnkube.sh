@@ -280,6 +280,8 @@ ovn_enable_interconnect=${OVN_ENABLE_INTERCONNECT:-false}
 ovn_enable_multi_external_gateway=${OVN_ENABLE_MULTI_EXTERNAL_GATEWAY:-false}
 #OVN_ENABLE_OVNKUBE_IDENTITY - enable per node cert
 ovn_enable_ovnkube_identity=${OVN_ENABLE_OVNKUBE_IDENTITY:-true}
+#OVN_ENABLE_CRD_VALIDATION - enable the ovnkube-identity validation of the OVN-Kubernetes CRDs
+ovn_enable_crd_validation=${OVN_ENABLE_CRD_VALIDATION:-false}
 
 # OVNKUBE_NODE_MODE - is the mode which ovnkube node operates
 ovnkube_node_mode=${OVNKUBE_NODE_MODE:-"full"}
@@ -1059,6 +1061,11 @@ ovnkube-identity() {
       ovnkube_enable_hybrid_overlay_flag="--enable-hybrid-overlay"
     fi
 
+    ovnkube_enable_crd_validation_flags=
+    if [[ ${ovn_enable_crd_validation} == "true" ]]; then
+      ovnkube_enable_crd_validation_flags="--enable-crd-validation --cluster-subnets=${net_cidr} --k8s-service-cidrs=${svc_cidr}"
+    fi
+
     # extra-allowed-user:
     #   ovnkube-master service account - required for compact mode
     #   ovnkube-cluster-manager service account - required for multi-homing
@@ -1066,6 +1073,7 @@ ovnkube-identity() {
     --webhook-cert-dir="/etc/webhook-cert" \
     ${ovnkube_enable_interconnect_flag} \
     ${ovnkube_enable_hybrid_overlay_flag} \
+    ${ovnkube_enable_crd_validation_flags} \
     --extra-allowed-user="system:serviceaccount:ovn-kubernetes:ovnkube-cluster-manager" \
     --extra-allowed-user="system:serviceaccount:ovn-kubernetes:ovnkube-master" \
     --loglevel="${ovnkube_loglevel}"
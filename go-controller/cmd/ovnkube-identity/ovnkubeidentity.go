@@ -18,6 +18,7 @@ import (
 
 	certificatesv1 "k8s.io/api/certificates/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
@@ -37,7 +38,22 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
+	ovnconfig "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	adminpolicybasedroutev1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1"
+	adminpolicybasedrouteclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/clientset/versioned"
+	adminpolicybasedrouteinformerfactory "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/informers/externalversions"
+	adminpolicybasedroutelisters "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/listers/adminpolicybasedroute/v1"
+	clusternetworkclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1/apis/clientset/versioned"
+	clusternetworkinformerfactory "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1/apis/informers/externalversions"
+	clusternetworklister "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1/apis/listers/clusternetwork/v1"
+	egressfirewallv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1"
+	egressipv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+	egressipclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/clientset/versioned"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/clientset/versioned/scheme"
+	egressipinformerfactory "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/informers/externalversions"
+	egressiplisters "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/listers/egressip/v1"
+	egressqosv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1"
+	egressservicev1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/csrapprover"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovnwebhook"
 	"github.com/urfave/cli/v2"
@@ -64,6 +80,11 @@ type config struct {
 	csrAcceptanceConditions    []csrapprover.CSRAcceptanceCondition
 	podAdmissionConditionFile  string
 	podAdmissionConditions     []ovnwebhook.PodAdmissionConditionOption
+	enableCRDValidation        bool
+	rawClusterSubnets          string
+	clusterSubnets             []*net.IPNet
+	rawServiceCIDRs            string
+	serviceCIDRs               []*net.IPNet
 }
 
 var cliCfg config
@@ -148,6 +169,25 @@ func main() {
 			return err
 		}
 
+		if cliCfg.rawClusterSubnets != "" {
+			clusterSubnets, err := ovnconfig.ParseClusterSubnetEntries(cliCfg.rawClusterSubnets)
+			if err != nil {
+				return fmt.Errorf("cluster subnet invalid: %v", err)
+			}
+			for _, entry := range clusterSubnets {
+				cliCfg.clusterSubnets = append(cliCfg.clusterSubnets, entry.CIDR)
+			}
+		}
+		if cliCfg.rawServiceCIDRs != "" {
+			for _, rawCIDR := range strings.Split(cliCfg.rawServiceCIDRs, ",") {
+				_, serviceCIDR, err := net.ParseCIDR(strings.TrimSpace(rawCIDR))
+				if err != nil {
+					return fmt.Errorf("service CIDR %q invalid: %v", rawCIDR, err)
+				}
+				cliCfg.serviceCIDRs = append(cliCfg.serviceCIDRs, serviceCIDR)
+			}
+		}
+
 		runWg := &sync.WaitGroup{}
 
 		ctx, cancel := context.WithCancel(c.Context)
@@ -275,6 +315,22 @@ func main() {
 			Usage:       "Configure additional pod validate admission conditions",
 			Destination: &cliCfg.podAdmissionConditionFile,
 		},
+		&cli.BoolFlag{
+			Name:        "enable-crd-validation",
+			Usage:       "Configure to enable the validation of EgressIP, EgressFirewall, EgressQoS, EgressService and AdminPolicyBasedExternalRoute objects",
+			Destination: &cliCfg.enableCRDValidation,
+			Value:       false,
+		},
+		&cli.StringFlag{
+			Name:        "cluster-subnets",
+			Usage:       "A comma separated set of IP subnets and the associated hostsubnet prefix lengths used for the cluster (eg, \"10.128.0.0/14/23,10.0.0.0/14/23\"), egress IPs cannot be part of them",
+			Destination: &cliCfg.rawClusterSubnets,
+		},
+		&cli.StringFlag{
+			Name:        "k8s-service-cidrs",
+			Usage:       "A comma separated set of CIDR notation IP ranges from which k8s assigns service cluster IPs, egress IPs cannot be part of them",
+			Destination: &cliCfg.rawServiceCIDRs,
+		},
 	}
 	ctx := context.Background()
 
@@ -370,6 +426,12 @@ func runWebhook(ctx context.Context, restCfg *rest.Config) error {
 		webhookMux.Handle("/pod", podHandler)
 	}
 
+	if cliCfg.enableCRDValidation {
+		if err := setupCRDWebhooks(ctx, restCfg, client, stopCh, webhookMux); err != nil {
+			return err
+		}
+	}
+
 	cfg := &tls.Config{
 		NextProtos: []string{"h2"},
 		MinVersion: tls.VersionTLS10,
@@ -431,6 +493,119 @@ func runWebhook(ctx context.Context, restCfg *rest.Config) error {
 	return srv.Serve(listener)
 }
 
+// setupCRDWebhooks registers the validating admission webhooks of the OVN-Kubernetes CRDs
+func setupCRDWebhooks(ctx context.Context, restCfg *rest.Config, client kubernetes.Interface, stopCh <-chan struct{}, webhookMux *http.ServeMux) error {
+	eipClient, err := egressipclientset.NewForConfig(restCfg)
+	if err != nil {
+		return fmt.Errorf("error creating egressip clientset: %v", err)
+	}
+	apbClient, err := adminpolicybasedrouteclientset.NewForConfig(restCfg)
+	if err != nil {
+		return fmt.Errorf("error creating adminpolicybasedroute clientset: %v", err)
+	}
+	clusterNetworkClient, err := clusternetworkclientset.NewForConfig(restCfg)
+	if err != nil {
+		return fmt.Errorf("error creating clusternetwork clientset: %v", err)
+	}
+	informerFactory := informers.NewSharedInformerFactory(client, 10*time.Minute)
+	nodeInformer := informerFactory.Core().V1().Nodes()
+	namespaceInformer := informerFactory.Core().V1().Namespaces()
+	eipInformerFactory := egressipinformerfactory.NewSharedInformerFactory(eipClient, 10*time.Minute)
+	eipInformer := eipInformerFactory.K8s().V1().EgressIPs()
+	apbInformerFactory := adminpolicybasedrouteinformerfactory.NewSharedInformerFactory(apbClient, 10*time.Minute)
+	apbInformer := apbInformerFactory.K8s().V1().AdminPolicyBasedExternalRoutes()
+	clusterNetworkInformerFactory := clusternetworkinformerfactory.NewSharedInformerFactory(clusterNetworkClient, 10*time.Minute)
+	clusterNetworkInformer := clusterNetworkInformerFactory.K8s().V1().ClusterNetworks()
+	// the informers must be requested before the factories are started
+	nodeInformer.Informer()
+	namespaceInformer.Informer()
+	eipInformer.Informer()
+	apbInformer.Informer()
+	clusterNetworkInformer.Informer()
+	informerFactory.Start(stopCh)
+	eipInformerFactory.Start(stopCh)
+	apbInformerFactory.Start(stopCh)
+	clusterNetworkInformerFactory.Start(stopCh)
+	klog.Infof("Waiting for CRD webhooks caches to sync")
+	if !cache.WaitForCacheSync(ctx.Done(), nodeInformer.Informer().HasSynced, namespaceInformer.Informer().HasSynced,
+		eipInformer.Informer().HasSynced, apbInformer.Informer().HasSynced, clusterNetworkInformer.Informer().HasSynced) {
+		return fmt.Errorf("failed to sync the CRD webhooks caches")
+	}
+
+	return registerCRDWebhooks(webhookMux, newCRDWebhooks(eipInformer.Lister(), nodeInformer.Lister(),
+		namespaceInformer.Lister(), clusterNetworkInformer.Lister(), apbInformer.Lister()))
+}
+
+// crdWebhook is a validating webhook served at "/"+name for the CRD of obj
+type crdWebhook struct {
+	name      string
+	obj       runtime.Object
+	validator admission.CustomValidator
+}
+
+func newCRDWebhooks(eipLister egressiplisters.EgressIPLister, nodeLister listers.NodeLister,
+	namespaceLister listers.NamespaceLister, clusterNetworkLister clusternetworklister.ClusterNetworkLister,
+	apbLister adminpolicybasedroutelisters.AdminPolicyBasedExternalRouteLister) []crdWebhook {
+	return []crdWebhook{
+		{
+			name: "egressip",
+			obj:  &egressipv1.EgressIP{},
+			validator: ovnwebhook.NewEgressIPAdmissionWebhook(eipLister, nodeLister, clusterNetworkLister,
+				cliCfg.clusterSubnets, cliCfg.serviceCIDRs, cliCfg.extraAllowedUsers.Value()...),
+		},
+		{
+			name:      "egressfirewall",
+			obj:       &egressfirewallv1.EgressFirewall{},
+			validator: ovnwebhook.NewEgressFirewallAdmissionWebhook(),
+		},
+		{
+			name:      "egressqos",
+			obj:       &egressqosv1.EgressQoS{},
+			validator: ovnwebhook.NewEgressQoSAdmissionWebhook(),
+		},
+		{
+			name:      "egressservice",
+			obj:       &egressservicev1.EgressService{},
+			validator: ovnwebhook.NewEgressServiceAdmissionWebhook(),
+		},
+		{
+			name:      "adminpolicybasedexternalroute",
+			obj:       &adminpolicybasedroutev1.AdminPolicyBasedExternalRoute{},
+			validator: ovnwebhook.NewAdminPolicyBasedExternalRouteAdmissionWebhook(apbLister, namespaceLister),
+		},
+	}
+}
+
+func registerCRDWebhooks(webhookMux *http.ServeMux, crdWebhooks []crdWebhook) error {
+	crdScheme := runtime.NewScheme()
+	for _, addToScheme := range []func(*runtime.Scheme) error{
+		egressipv1.AddToScheme,
+		egressfirewallv1.AddToScheme,
+		egressqosv1.AddToScheme,
+		egressservicev1.AddToScheme,
+		adminpolicybasedroutev1.AddToScheme,
+	} {
+		if err := addToScheme(crdScheme); err != nil {
+			return fmt.Errorf("failed to setup the CRD webhooks scheme: %w", err)
+		}
+	}
+	for _, crdWebhook := range crdWebhooks {
+		validatingWebhook := admission.WithCustomValidator(crdScheme, crdWebhook.obj, crdWebhook.validator).WithRecoverPanic(true)
+		handler, err := admission.StandaloneWebhook(
+			validatingWebhook,
+			admission.StandaloneOptions{
+				Logger:      logger.WithName(crdWebhook.name + ".validation"),
+				MetricsPath: crdWebhook.name + ".validation",
+			},
+		)
+		if err != nil {
+			return fmt.Errorf("failed to setup the %s admission webhook: %w", crdWebhook.name, err)
+		}
+		webhookMux.Handle("/"+crdWebhook.name, handler)
+	}
+	return nil
+}
+
 func runCSRApproverManager(ctx context.Context, leaderID string, restCfg *rest.Config) error {
 	mgr, err := ctrl.NewManager(restCfg, ctrl.Options{
 		Metrics: server.Options{
@@ -0,0 +1,213 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+
+	adminpolicybasedroutelisters "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/listers/adminpolicybasedroute/v1"
+	clusternetworkv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1"
+	clusternetworklister "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1/apis/listers/clusternetwork/v1"
+	egressfirewallv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1"
+	egressipv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+	egressiplisters "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/listers/egressip/v1"
+	egressqosv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1"
+)
+
+const allowedUser = "system:serviceaccount:ovn-kubernetes:ovnkube-cluster-manager"
+
+func newCRDWebhookServerForTest(t *testing.T) *httptest.Server {
+	_, clusterSubnet, _ := net.ParseCIDR("10.128.0.0/14")
+	_, serviceCIDR, _ := net.ParseCIDR("172.30.0.0/16")
+	cliCfg.clusterSubnets = []*net.IPNet{clusterSubnet}
+	cliCfg.serviceCIDRs = []*net.IPNet{serviceCIDR}
+	if err := cliCfg.extraAllowedUsers.Set(allowedUser); err != nil {
+		t.Fatalf("failed to set the extra allowed users: %v", err)
+	}
+	t.Cleanup(func() { cliCfg = config{} })
+
+	clusterNetworkIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	err := clusterNetworkIndexer.Add(&clusternetworkv1.ClusterNetwork{
+		ObjectMeta: metav1.ObjectMeta{Name: "expansion"},
+		Spec: clusternetworkv1.ClusterNetworkSpec{
+			ClusterSubnets: []clusternetworkv1.ClusterSubnet{{CIDR: "10.132.0.0/14"}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to add ClusterNetwork: %v", err)
+	}
+	newIndexer := func() cache.Indexer {
+		return cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	}
+
+	webhookMux := http.NewServeMux()
+	err = registerCRDWebhooks(webhookMux, newCRDWebhooks(
+		egressiplisters.NewEgressIPLister(newIndexer()),
+		listers.NewNodeLister(newIndexer()),
+		listers.NewNamespaceLister(newIndexer()),
+		clusternetworklister.NewClusterNetworkLister(clusterNetworkIndexer),
+		adminpolicybasedroutelisters.NewAdminPolicyBasedExternalRouteLister(newIndexer()),
+	))
+	if err != nil {
+		t.Fatalf("failed to register the CRD webhooks: %v", err)
+	}
+	server := httptest.NewServer(webhookMux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newEgressIP(ips ...string) *egressipv1.EgressIP {
+	return &egressipv1.EgressIP{
+		TypeMeta:   metav1.TypeMeta{APIVersion: egressipv1.SchemeGroupVersion.String(), Kind: "EgressIP"},
+		ObjectMeta: metav1.ObjectMeta{Name: "eip"},
+		Spec:       egressipv1.EgressIPSpec{EgressIPs: ips},
+	}
+}
+
+func TestCRDWebhooks(t *testing.T) {
+	tests := []struct {
+		name            string
+		path            string
+		operation       admissionv1.Operation
+		username        string
+		obj             runtime.Object
+		oldObj          runtime.Object
+		expectedStatus  int
+		expectedAllowed bool
+		expectedMessage string
+	}{
+		{
+			name:            "egressip allows a valid EgressIP",
+			path:            "/egressip",
+			operation:       admissionv1.Create,
+			obj:             newEgressIP("192.168.126.20"),
+			expectedStatus:  http.StatusOK,
+			expectedAllowed: true,
+		},
+		{
+			name:            "egressip denies an egress IP in the configured cluster subnet",
+			path:            "/egressip",
+			operation:       admissionv1.Create,
+			obj:             newEgressIP("10.129.0.5"),
+			expectedStatus:  http.StatusOK,
+			expectedMessage: "egress IP 10.129.0.5 collides with the cluster subnet 10.128.0.0/14",
+		},
+		{
+			name:            "egressip denies an egress IP in the cluster subnet of a ClusterNetwork",
+			path:            "/egressip",
+			operation:       admissionv1.Create,
+			obj:             newEgressIP("10.133.0.5"),
+			expectedStatus:  http.StatusOK,
+			expectedMessage: "egress IP 10.133.0.5 collides with the cluster subnet 10.132.0.0/14",
+		},
+		{
+			name:            "egressip allows any update by an extra allowed user",
+			path:            "/egressip",
+			operation:       admissionv1.Update,
+			username:        allowedUser,
+			obj:             newEgressIP("172.30.0.5"),
+			oldObj:          newEgressIP("192.168.126.20"),
+			expectedStatus:  http.StatusOK,
+			expectedAllowed: true,
+		},
+		{
+			name:            "egressip denies an update adding a colliding egress IP",
+			path:            "/egressip",
+			operation:       admissionv1.Update,
+			obj:             newEgressIP("192.168.126.20", "172.30.0.5"),
+			oldObj:          newEgressIP("192.168.126.20"),
+			expectedStatus:  http.StatusOK,
+			expectedMessage: "egress IP 172.30.0.5 collides with the service subnet 172.30.0.0/16",
+		},
+		{
+			name:      "egressfirewall denies an invalid rule type",
+			path:      "/egressfirewall",
+			operation: admissionv1.Create,
+			obj: &egressfirewallv1.EgressFirewall{
+				TypeMeta:   metav1.TypeMeta{APIVersion: egressfirewallv1.SchemeGroupVersion.String(), Kind: "EgressFirewall"},
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "ns"},
+				Spec: egressfirewallv1.EgressFirewallSpec{
+					Egress: []egressfirewallv1.EgressFirewallRule{
+						{Type: "Drop", To: egressfirewallv1.EgressFirewallDestination{CIDRSelector: "1.2.3.0/24"}},
+					},
+				},
+			},
+			expectedStatus:  http.StatusOK,
+			expectedMessage: "spec.egress[0]: type must be one of Allow or Deny",
+		},
+		{
+			name:      "egressqos denies a name other than default",
+			path:      "/egressqos",
+			operation: admissionv1.Create,
+			obj: &egressqosv1.EgressQoS{
+				TypeMeta:   metav1.TypeMeta{APIVersion: egressqosv1.SchemeGroupVersion.String(), Kind: "EgressQoS"},
+				ObjectMeta: metav1.ObjectMeta{Name: "qos", Namespace: "ns"},
+			},
+			expectedStatus:  http.StatusOK,
+			expectedMessage: "name must be default",
+		},
+		{
+			name:           "unknown resources are not served",
+			path:           "/egressips",
+			operation:      admissionv1.Create,
+			obj:            newEgressIP("192.168.126.20"),
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+	server := newCRDWebhookServerForTest(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			review := admissionv1.AdmissionReview{
+				TypeMeta: metav1.TypeMeta{APIVersion: admissionv1.SchemeGroupVersion.String(), Kind: "AdmissionReview"},
+				Request: &admissionv1.AdmissionRequest{
+					UID:       "uid",
+					Operation: tt.operation,
+					UserInfo:  authenticationv1.UserInfo{Username: tt.username},
+					Object:    runtime.RawExtension{Object: tt.obj},
+				},
+			}
+			if tt.oldObj != nil {
+				review.Request.OldObject = runtime.RawExtension{Object: tt.oldObj}
+			}
+			body, err := json.Marshal(review)
+			if err != nil {
+				t.Fatalf("failed to marshal the admission review: %v", err)
+			}
+			resp, err := http.Post(server.URL+tt.path, "application/json", bytes.NewReader(body))
+			if err != nil {
+				t.Fatalf("failed to post the admission review: %v", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.expectedStatus {
+				t.Fatalf("status code = %d, expectedStatus %d", resp.StatusCode, tt.expectedStatus)
+			}
+			if resp.StatusCode != http.StatusOK {
+				return
+			}
+			var result admissionv1.AdmissionReview
+			if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+				t.Fatalf("failed to decode the admission review: %v", err)
+			}
+			if result.Response == nil {
+				t.Fatalf("admission review has no response")
+			}
+			if result.Response.Allowed != tt.expectedAllowed {
+				t.Errorf("allowed = %v, expectedAllowed %v: %v", result.Response.Allowed, tt.expectedAllowed, result.Response.Result)
+			}
+			if tt.expectedMessage != "" && (result.Response.Result == nil || !strings.Contains(result.Response.Result.Message, tt.expectedMessage)) {
+				t.Errorf("result = %v, expectedMessage %q", result.Response.Result, tt.expectedMessage)
+			}
+		})
+	}
+}
@@ -14,8 +14,11 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	types "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	util "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	nadclientset "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/clientset/versioned"
 	kapi "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -38,6 +41,10 @@ const (
 
 	// OVN related.
 	ovnNodeL3GatewayConfig = "k8s.ovn.org/l3-gateway-config"
+
+	// externalClientMAC is the source MAC address used for the packets of external clients, the node's external
+	// bridge forwards them to OVN regardless of their source MAC address.
+	externalClientMAC = "02:00:00:00:00:01"
 )
 
 const (
@@ -74,6 +81,10 @@ type SvcInfo struct {
 	SvcName      string   // The service's name
 	SvcNamespace string   // The service's namespace
 	ClusterIP    string   // The service's cluster IP address
+	NodePort     string   // The service's node port for the traced service port, if any
+	ExternalIPs  []string // The service's load balancer ingress IPs followed by its external IPs
+	ITPLocal     bool     // if the service's internal traffic policy is Local
+	ETPLocal     bool     // if the service's external traffic policy is Local
 	PodInfo      *PodInfo // The endpoint pod associated with the service
 	PodPort      string   // Endpoint target port used to reach the pod in PodName
 }
@@ -87,31 +98,42 @@ type NodeInfo struct {
 	NodeName               string // The name of the node that the pod runs on
 	OvnKubePodName         string // The OvnKube pod on the same node as this pod
 	RoutingViaHost         bool   // The gateway mode, true for 'routingViaHost' or false for 'routingViaOVN'
+	NodeIP                 string // The node's gateway IP address of the traced address family
+	ExternalPortName       string // The logical port of the external bridge on the external switch, e.g. breth0_ovn-worker
+	GatewayRouterMAC       string // The MAC address of the gateway router port on the external switch
+	MgmtPortIP             string // The IP address of the node's management port of the traced address family
+	MgmtPortMAC            string // The MAC address of the node's management port
 }
 
 // PodInfo contains pod information.
 type PodInfo struct {
 	NodeInfo
-	PrimaryInterfaceName string // primary pod interface name inside the pod
-	IP                   string // the primary interface's primary IP address
-	IPVer                string // the address family of the primary IP address
-	MAC                  string // the primary interface's MAC address
-	VethName             string // veth peer of the primary interface of the pod
-	OfportNum            string // ofport number of veth interface or for host net pods of ovn-k8s-mp0
-	PodName              string // name of the pod
-	PodNamespace         string // the pod's namespace
-	ContainerName        string // the pod's principal container name (the first container found atm)
-	OvnKubeContainerName string // name of the container running ovnkube-node component
-	RtosMAC              string // router to switch mac address, the L2 address of the first hop router of the pod
-	RtotsMAC             string // router to transit switch port mac address
-	HostNetwork          bool   // if this pod is host networked or not
-	IsInterConnect       bool   // indicates if the pod is running on ovn interconnect environment or not
-	InterConnectZoneName string // contains interconnect zone name of the pod's hosting node.
-	NbURI                string // pod's ovn nb db uri string
-	SbURI                string // pod's ovn sb db uri string
-	SslCertKeys          string // ssl cert keys string to access ovn nbdb/sbdb
-	NbCommand            string // contains subset of nb command string to execute on ovn nbdb
-	SbCommand            string // contains subset of sb command string to execute on ovn sbdb
+	PrimaryInterfaceName string       // primary pod interface name inside the pod
+	IP                   string       // the primary interface's primary IP address
+	IPVer                string       // the address family of the primary IP address
+	MAC                  string       // the primary interface's MAC address
+	VethName             string       // veth peer of the primary interface of the pod
+	OfportNum            string       // ofport number of veth interface or for host net pods of ovn-k8s-mp0
+	PodName              string       // name of the pod
+	PodNamespace         string       // the pod's namespace
+	ContainerName        string       // the pod's principal container name (the first container found atm)
+	OvnKubeContainerName string       // name of the container running ovnkube-node component
+	RtosMAC              string       // router to switch mac address, the L2 address of the first hop router of the pod
+	RtotsMAC             string       // router to transit switch port mac address
+	HostNetwork          bool         // if this pod is host networked or not
+	NetInfo              util.NetInfo // the traced network, the default network unless -network is set
+	NADName              string       // network attachment definition of the traced network, <namespace>/<name>, or "default"
+	LogicalSwitch        string       // the pod's logical switch on the traced network
+	LogicalPort          string       // the pod's logical switch port on the traced network
+	LocalnetBridgeName   string       // the OVS bridge of the traced localnet network on the pod's node
+	External             bool         // if this is an external client sending traffic through the node's external bridge
+	IsInterConnect       bool         // indicates if the pod is running on ovn interconnect environment or not
+	InterConnectZoneName string       // contains interconnect zone name of the pod's hosting node.
+	NbURI                string       // pod's ovn nb db uri string
+	SbURI                string       // pod's ovn sb db uri string
+	SslCertKeys          string       // ssl cert keys string to access ovn nbdb/sbdb
+	NbCommand            string       // contains subset of nb command string to execute on ovn nbdb
+	SbCommand            string       // contains subset of sb command string to execute on ovn sbdb
 }
 
 // String returns a JSON representation of the SvcInfo object, or "" on failure.
@@ -149,6 +171,15 @@ func (pi *PodInfo) FullyQualifiedPodName() string {
 	return fmt.Sprintf("%s_%s", pi.PodNamespace, pi.PodName)
 }
 
+// firstHopMAC returns the destination MAC address of the packets sent by the pod to dst: the MAC address of its first
+// hop router, or the MAC address of dst itself on the layer2 and localnet networks, which have no router.
+func (pi *PodInfo) firstHopMAC(dst *PodInfo) string {
+	if pi.NetInfo == nil || pi.NetInfo.TopologyType() == types.Layer3Topology {
+		return pi.RtosMAC
+	}
+	return dst.MAC
+}
+
 // execInPod runs a command inside the given container. Requires bash. Returns Stdout, Stderr, err.
 func execInPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, namespace string, podName string, containerName string, cmd string, in string) (string, string, error) {
 	klog.V(5).Infof(
@@ -162,7 +193,7 @@ func execInPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, n
 
 	scheme := runtime.NewScheme()
 	if err := kapi.AddToScheme(scheme); err != nil {
-		klog.Exitf("Error adding to scheme: %v", err)
+		exitf("Error adding to scheme: %v", err)
 	}
 	parameterCodec := runtime.NewParameterCodec(scheme)
 
@@ -357,7 +388,8 @@ func getPodOvsInterfaceNameAndOfport(coreclient *corev1client.CoreV1Client, rest
 }
 
 // getSvcInfo builds the SvcInfo object for this service. PodName/PodNamespace/PodIP are for the first valid endpoint pod that can be found for this service.
-func getSvcInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, svcName string, ovnNamespace string, namespace, addressFamily string) (svcInfo *SvcInfo, err error) {
+// NodePort is the node port of the service port dstPort.
+func getSvcInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, svcName string, ovnNamespace string, namespace, addressFamily, dstPort string) (svcInfo *SvcInfo, err error) {
 	// Get service with the name supplied by svcName
 	svc, err := coreclient.Services(namespace).Get(context.TODO(), svcName, metav1.GetOptions{})
 	if err != nil {
@@ -377,6 +409,9 @@ func getSvcInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 		SvcNamespace: namespace,
 		ClusterIP:    clusterIPStr,
 	}
+	svcInfo.NodePort, svcInfo.ExternalIPs = getSvcExternalEntries(svc, addressFamily, dstPort)
+	svcInfo.ITPLocal = util.ServiceInternalTrafficPolicyLocal(svc)
+	svcInfo.ETPLocal = util.ServiceExternalTrafficPolicyLocal(svc)
 
 	ep, err := coreclient.Endpoints(namespace).Get(context.TODO(), svcName, metav1.GetOptions{})
 	if err != nil {
@@ -392,6 +427,29 @@ func getSvcInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 	return svcInfo, err
 }
 
+// getSvcExternalEntries returns the node port of the service port dstPort, if any, and the load balancer ingress IPs
+// followed by the external IPs of the service of the given address family.
+func getSvcExternalEntries(svc *kapi.Service, addressFamily, dstPort string) (string, []string) {
+	var nodePort string
+	for _, port := range svc.Spec.Ports {
+		if strconv.Itoa(int(port.Port)) == dstPort && port.NodePort != 0 {
+			nodePort = strconv.Itoa(int(port.NodePort))
+		}
+	}
+	var externalIPs, familyExternalIPs []string
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		externalIPs = append(externalIPs, ingress.IP)
+	}
+	externalIPs = append(externalIPs, svc.Spec.ExternalIPs...)
+	for _, externalIP := range externalIPs {
+		ip := utilnet.ParseIPSloppy(externalIP)
+		if ip != nil && getIPVer(ip) == addressFamily {
+			familyExternalIPs = append(familyExternalIPs, ip.String())
+		}
+	}
+	return nodePort, familyExternalIPs
+}
+
 // extractSubsetInfo copies information from the endpoint subsets into the SvcInfo object.
 // Modifies the svcInfo object the pointer of which is passed to it.
 func extractSubsetInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, subsets []kapi.EndpointSubset, svcInfo *SvcInfo, ovnNamespace, addressFamily string) error {
@@ -428,9 +486,10 @@ func extractSubsetInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.C
 			}
 
 			// Get info needed for the src Pod
-			svcPodInfo, err := getPodInfo(coreclient, restconfig, epAddress.TargetRef.Name, ovnNamespace, epAddress.TargetRef.Namespace, addressFamily)
+			svcPodInfo, err := getPodInfo(coreclient, restconfig, epAddress.TargetRef.Name, ovnNamespace, epAddress.TargetRef.Namespace, addressFamily,
+				&util.DefaultNetInfo{}, types.DefaultNetworkName)
 			if err != nil {
-				klog.Exitf("Failed to get information from pod %s: %v", epAddress.TargetRef.Name, err)
+				exitf("Failed to get information from pod %s: %v", epAddress.TargetRef.Name, err)
 			}
 			klog.V(5).Infof("svcPodInfo is %s\n", svcPodInfo)
 
@@ -446,8 +505,10 @@ func extractSubsetInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.C
 	return fmt.Errorf("could not extract pod and port information from endpoints for service %s in namespace %s", svcInfo.SvcName, svcInfo.SvcNamespace)
 }
 
-// getPodInfo returns a pointer to a fully populated PodInfo struct, or error on failure.
-func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podName string, ovnNamespace string, namespace, addressFamily string) (podInfo *PodInfo, err error) {
+// getPodInfo returns a pointer to a fully populated PodInfo struct for the pod's interface on the network of netInfo
+// and nadName, or error on failure.
+func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podName string, ovnNamespace string, namespace, addressFamily string,
+	netInfo util.NetInfo, nadName string) (podInfo *PodInfo, err error) {
 	// Create a PodInfo object with the base information already added, such as
 	// IP, PodName, ContainerName, NodeName, HostNetwork, Namespace, PrimaryInterfaceName
 	pod, err := coreclient.Pods(namespace).Get(context.TODO(), podName, metav1.GetOptions{})
@@ -456,7 +517,12 @@ func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 		return nil, err
 	}
 
-	podIP, err := getDesiredPodIP(pod, addressFamily)
+	var podIP, podMAC string
+	if netInfo.IsSecondary() {
+		podIP, podMAC, err = getSecondaryNetworkPodAddresses(pod, nadName, addressFamily)
+	} else {
+		podIP, err = getDesiredPodIP(pod, addressFamily)
+	}
 	if err != nil {
 		klog.V(1).Infof("Pod %s in namespace %s doesn't have desired ip address configured\n", podName, namespace)
 		return nil, err
@@ -465,12 +531,16 @@ func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 	podInfo = &PodInfo{
 		IP:            podIP,
 		IPVer:         addressFamily,
+		MAC:           podMAC,
 		PodName:       pod.Name,
 		ContainerName: pod.Spec.Containers[0].Name,
 		HostNetwork:   pod.Spec.HostNetwork,
 		PodNamespace:  pod.Namespace,
+		NetInfo:       netInfo,
+		NADName:       nadName,
 	}
 	podInfo.NodeName = pod.Spec.NodeName
+	podInfo.LogicalSwitch, podInfo.LogicalPort = getPodLogicalSwitchAndPort(podInfo)
 
 	// Get the pod's ovnkubePod.
 	podInfo.OvnKubePodName, err = getOvnKubePodOnNode(coreclient, ovnNamespace, podInfo.NodeName)
@@ -486,40 +556,42 @@ func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 	}
 
 	// Get the pod's MAC address.
-	podInfo.MAC, err = getPodMAC(coreclient, pod)
-	if err != nil {
-		klog.V(1).Infof("Problem obtaining Ethernet address of Pod %s in namespace %s\n", podName, namespace)
-		return nil, err
+	if !netInfo.IsSecondary() {
+		podInfo.MAC, err = getPodMAC(coreclient, pod)
+		if err != nil {
+			klog.V(1).Infof("Problem obtaining Ethernet address of Pod %s in namespace %s\n", podName, namespace)
+			return nil, err
+		}
 	}
 
 	podInfo, err = getDatabaseURIs(coreclient, restconfig, ovnNamespace, podInfo)
 	if err != nil {
-		klog.Exitf("Failed to get database URIs: %v\n", err)
-	}
-
-	// Find rtos MAC (this is the pod's first hop router).
-	podInfo.RtosMAC, err = getRouterPortMacAddress(coreclient, restconfig, podInfo, ovnNamespace, types.RouterToSwitchPrefix)
-	if err != nil {
-		return nil, err
+		exitf("Failed to get database URIs: %v\n", err)
 	}
 
-	// Find rtots MAC (this is the pod's first hop router when ovn is in interconnected zone).
-	if podInfo.IsInterConnect {
-		podInfo.RtotsMAC, err = getRouterPortMacAddress(coreclient, restconfig, podInfo, ovnNamespace, types.RouterToTransitSwitchPrefix)
+	// Layer2 and localnet networks have no router, the pods reach each other directly.
+	if netInfo.TopologyType() == types.Layer3Topology {
+		// Find rtos MAC (this is the pod's first hop router).
+		podInfo.RtosMAC, err = getRouterPortMacAddress(coreclient, restconfig, podInfo, ovnNamespace, types.RouterToSwitchPrefix+podInfo.LogicalSwitch)
 		if err != nil {
 			return nil, err
 		}
+
+		// Find rtots MAC (this is the pod's first hop router when ovn is in interconnected zone).
+		if podInfo.IsInterConnect {
+			podInfo.RtotsMAC, err = getRouterPortMacAddress(coreclient, restconfig, podInfo, ovnNamespace,
+				netInfo.GetNetworkScopedName(types.RouterToTransitSwitchPrefix+podInfo.NodeName))
+			if err != nil {
+				return nil, err
+			}
+		}
 	}
 
 	// Set information specific to ovn-k8s-mp0. This info is required for routingViaHost gateway mode traffic to an external IP
 	// destination.
-	podInfo.OvnK8sMp0PortName = types.K8sMgmtIntfName
-	portCmd := fmt.Sprintf("ovs-vsctl get Interface %s ofport", podInfo.OvnK8sMp0PortName)
-	localOutput, localError, err := execInPod(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.OvnKubeContainerName, portCmd, "")
-	if err != nil {
-		return nil, fmt.Errorf("execInPod() failed. err: %s, stderr: %s, stdout: %s, podInfo: %v", err, localError, localOutput, podInfo)
+	if err = setOvnK8sMp0Info(coreclient, restconfig, ovnNamespace, podInfo); err != nil {
+		return nil, err
 	}
-	podInfo.OvnK8sMp0OfportNum = strings.Replace(localOutput, "\n", "", -1)
 
 	// Set information specific to host networked pods or non-host networked pods.
 	if podInfo.HostNetwork {
@@ -529,7 +601,11 @@ func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 		podInfo.OfportNum = podInfo.OvnK8sMp0OfportNum
 	} else {
 		// Get the pod's interface information
-		ovsInterfaceInformation, err := getPodOvsInterfaceNameAndOfport(coreclient, restconfig, podInfo, ovnNamespace, podInfo.FullyQualifiedPodName())
+		ifaceID := util.GetIfaceId(podInfo.PodNamespace, podInfo.PodName)
+		if netInfo.IsSecondary() {
+			ifaceID = util.GetSecondaryNetworkIfaceId(podInfo.PodNamespace, podInfo.PodName, nadName)
+		}
+		ovsInterfaceInformation, err := getPodOvsInterfaceNameAndOfport(coreclient, restconfig, podInfo, ovnNamespace, ifaceID)
 		if err != nil {
 			return nil, err
 		}
@@ -543,11 +619,232 @@ func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 		return nil, err
 	}
 
+	if netInfo.TopologyType() == types.LocalnetTopology {
+		podInfo.LocalnetBridgeName, err = getLocalnetBridgeName(coreclient, restconfig, ovnNamespace, podInfo)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return podInfo, err
 }
 
-func getRouterPortMacAddress(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podInfo *PodInfo, ovnNamespace, portPrefix string) (string, error) {
-	tspCmd := "ovn-sbctl --no-leader-only " + podInfo.SbCommand + " --bare --no-heading --column=mac list Port_Binding " + portPrefix + podInfo.NodeName
+// getSecondaryNetworkPodAddresses returns the IP address of the given address family and the MAC address of the pod's
+// interface on the secondary network nadName.
+func getSecondaryNetworkPodAddresses(pod *kapi.Pod, nadName, addressFamily string) (string, string, error) {
+	if pod.Spec.HostNetwork {
+		return "", "", fmt.Errorf("host networked pods are not attached to secondary networks")
+	}
+	podAnnotation, err := util.UnmarshalPodAnnotation(pod.Annotations, nadName)
+	if err != nil {
+		return "", "", fmt.Errorf("pod %s/%s is not attached to network %s: %v", pod.Namespace, pod.Name, nadName, err)
+	}
+	for _, ipNet := range podAnnotation.IPs {
+		if getIPVer(ipNet.IP) == addressFamily {
+			return ipNet.IP.String(), podAnnotation.MAC.String(), nil
+		}
+	}
+	return "", "", fmt.Errorf("could not find desired pod ip address on network %s for the given address family", nadName)
+}
+
+// getPodLogicalSwitchAndPort returns the names of the logical switch and of the logical switch port of the pod on its
+// traced network.
+func getPodLogicalSwitchAndPort(podInfo *PodInfo) (string, string) {
+	if !podInfo.NetInfo.IsSecondary() {
+		return podInfo.NodeName, podInfo.FullyQualifiedPodName()
+	}
+	logicalPort := util.GetSecondaryNetworkLogicalPortName(podInfo.PodNamespace, podInfo.PodName, podInfo.NADName)
+	switch podInfo.NetInfo.TopologyType() {
+	case types.Layer2Topology:
+		return podInfo.NetInfo.GetNetworkScopedName(types.OVNLayer2Switch), logicalPort
+	case types.LocalnetTopology:
+		return podInfo.NetInfo.GetNetworkScopedName(types.OVNLocalnetSwitch), logicalPort
+	default:
+		return podInfo.NetInfo.GetNetworkScopedName(podInfo.NodeName), logicalPort
+	}
+}
+
+// getLocalnetBridgeName returns the OVS bridge mapped to the physical network of the traced localnet network on the
+// pod's node, from the ovn-bridge-mappings of the node, e.g. "physnet:breth0,localnet1:br-localnet".
+func getLocalnetBridgeName(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, ovnNamespace string, podInfo *PodInfo) (string, error) {
+	cmd := "ovs-vsctl --if-exists get Open_vSwitch . external_ids:ovn-bridge-mappings"
+	stdout, stderr, err := execInPod(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.OvnKubeContainerName, cmd, "")
+	if err != nil {
+		return "", fmt.Errorf("execInPod() failed with %s stderr %s stdout %s", err, stderr, stdout)
+	}
+	for _, mapping := range strings.Split(strings.Trim(strings.TrimSpace(stdout), "\""), ",") {
+		physnetBridge := strings.Split(mapping, ":")
+		if len(physnetBridge) == 2 && physnetBridge[0] == podInfo.NetInfo.GetNetworkName() {
+			return physnetBridge[1], nil
+		}
+	}
+	return "", fmt.Errorf("could not find the bridge of network %s in the bridge mappings %q of node %s",
+		podInfo.NetInfo.GetNetworkName(), stdout, podInfo.NodeName)
+}
+
+// getNetworkInfo returns the network information and the full NAD name of the network attachment definition given with
+// -network, <namespace>/<name> or <name> in the given namespace. The default network is returned if network is empty.
+func getNetworkInfo(nadClient nadclientset.Interface, network, namespace string) (util.NetInfo, string, error) {
+	if network == "" {
+		return &util.DefaultNetInfo{}, types.DefaultNetworkName, nil
+	}
+	nadNamespace, nadName := namespace, network
+	if parts := strings.Split(network, "/"); len(parts) == 2 {
+		nadNamespace, nadName = parts[0], parts[1]
+	} else if len(parts) > 2 {
+		return nil, "", fmt.Errorf("invalid network %q, expected <namespace>/<name>", network)
+	}
+	nad, err := nadClient.K8sCniCncfIoV1().NetworkAttachmentDefinitions(nadNamespace).Get(context.TODO(), nadName, metav1.GetOptions{})
+	if err != nil {
+		return nil, "", fmt.Errorf("network attachment definition %s/%s not found, err: %v", nadNamespace, nadName, err)
+	}
+	netInfo, err := util.ParseNADInfo(nad)
+	if err != nil {
+		return nil, "", fmt.Errorf("network attachment definition %s/%s is not an OVN-Kubernetes network: %v", nadNamespace, nadName, err)
+	}
+	if !netInfo.IsSecondary() {
+		return &util.DefaultNetInfo{}, types.DefaultNetworkName, nil
+	}
+	return netInfo, util.GetNADName(nadNamespace, nadName), nil
+}
+
+// setOvnK8sMp0Info sets the name and the ofport number of the management port of the node.
+func setOvnK8sMp0Info(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, ovnNamespace string, podInfo *PodInfo) error {
+	podInfo.OvnK8sMp0PortName = types.K8sMgmtIntfName
+	portCmd := fmt.Sprintf("ovs-vsctl get Interface %s ofport", podInfo.OvnK8sMp0PortName)
+	localOutput, localError, err := execInPod(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.OvnKubeContainerName, portCmd, "")
+	if err != nil {
+		return fmt.Errorf("execInPod() failed. err: %s, stderr: %s, stdout: %s, podInfo: %v", err, localError, localOutput, podInfo)
+	}
+	podInfo.OvnK8sMp0OfportNum = strings.Replace(localOutput, "\n", "", -1)
+	return nil
+}
+
+// getNodeSourceInfo returns a PodInfo struct describing the source of the traffic on the given node: the node's host
+// network or, if srcIP is set, an external client whose traffic enters the cluster through the node's external bridge.
+func getNodeSourceInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, nodeName string, srcIP net.IP, ovnNamespace, addressFamily string) (*PodInfo, error) {
+	node, err := coreclient.Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("node %s not found, err: %v", nodeName, err)
+	}
+	l3GatewayConfig, err := util.ParseNodeL3GatewayAnnotation(node)
+	if err != nil {
+		return nil, err
+	}
+
+	srcInfo := &PodInfo{
+		IPVer:         addressFamily,
+		HostNetwork:   true,
+		External:      srcIP != nil,
+		NetInfo:       &util.DefaultNetInfo{},
+		NADName:       types.DefaultNetworkName,
+		LogicalSwitch: nodeName,
+	}
+	srcInfo.NodeName = nodeName
+	srcInfo.ExternalPortName = l3GatewayConfig.InterfaceID
+	srcInfo.GatewayRouterMAC = l3GatewayConfig.MACAddress.String()
+	for _, ipNet := range l3GatewayConfig.IPAddresses {
+		if getIPVer(ipNet.IP) == addressFamily {
+			srcInfo.NodeIP = ipNet.IP.String()
+			break
+		}
+	}
+	if srcInfo.NodeIP == "" {
+		return nil, fmt.Errorf("could not find a gateway IP address of family %s on node %s", addressFamily, nodeName)
+	}
+
+	srcInfo.OvnKubePodName, err = getOvnKubePodOnNode(coreclient, ovnNamespace, nodeName)
+	if err != nil {
+		return nil, err
+	}
+	srcInfo.RoutingViaHost, err = isRoutingViaHost(coreclient, restconfig, ovnNamespace, srcInfo.OvnKubePodName, nodeName)
+	if err != nil {
+		return nil, err
+	}
+	srcInfo, err = getDatabaseURIs(coreclient, restconfig, ovnNamespace, srcInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database URIs: %v", err)
+	}
+	srcInfo.RtosMAC, err = getRouterPortMacAddress(coreclient, restconfig, srcInfo, ovnNamespace, types.RouterToSwitchPrefix+nodeName)
+	if err != nil {
+		return nil, err
+	}
+	if srcInfo.IsInterConnect {
+		srcInfo.RtotsMAC, err = getRouterPortMacAddress(coreclient, restconfig, srcInfo, ovnNamespace, types.RouterToTransitSwitchPrefix+nodeName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err = setOvnK8sMp0Info(coreclient, restconfig, ovnNamespace, srcInfo); err != nil {
+		return nil, err
+	}
+	srcInfo.K8sNodeNamePort = types.K8sPrefix + nodeName
+	srcInfo.VethName = srcInfo.OvnK8sMp0PortName
+	srcInfo.OfportNum = srcInfo.OvnK8sMp0OfportNum
+	srcInfo.NodeExternalBridgeName, err = getNodeExternalBridgeName(coreclient, restconfig, ovnNamespace, srcInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	// The host forwards the traffic to the ITP=local services, and in routingViaHost gateway mode the external traffic
+	// to the ETP=local services, through the management port.
+	mgmtPortMAC, err := util.ParseNodeManagementPortMACAddress(node)
+	if err != nil {
+		return nil, err
+	}
+	srcInfo.MgmtPortMAC = mgmtPortMAC.String()
+	nodeSubnets, err := util.ParseNodeHostSubnetAnnotation(node, types.DefaultNetworkName)
+	if err != nil {
+		return nil, err
+	}
+	for _, nodeSubnet := range nodeSubnets {
+		if getIPVer(nodeSubnet.IP) == addressFamily {
+			srcInfo.MgmtPortIP = util.GetNodeManagementIfAddr(nodeSubnet).IP.String()
+			break
+		}
+	}
+	if srcInfo.MgmtPortIP == "" {
+		return nil, fmt.Errorf("could not find a subnet of family %s on node %s", addressFamily, nodeName)
+	}
+
+	if srcInfo.External {
+		if getIPVer(srcIP) != addressFamily {
+			return nil, fmt.Errorf("source IP %s is not of address family %s", srcIP, addressFamily)
+		}
+		srcInfo.PodName = fmt.Sprintf("external client %s", srcIP)
+		srcInfo.IP = srcIP.String()
+		srcInfo.MAC = externalClientMAC
+		return srcInfo, nil
+	}
+
+	// The host sends the traffic from its own IP address. The bridge MAC address is used as both source and
+	// destination, as the gateway router shares it.
+	srcInfo.PodName = fmt.Sprintf("host network of node %s", nodeName)
+	srcInfo.IP = srcInfo.NodeIP
+	srcInfo.MAC = srcInfo.GatewayRouterMAC
+	return srcInfo, nil
+}
+
+// getBridgeUplinkPort returns the name of the port connecting the external bridge to the physical network.
+func getBridgeUplinkPort(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, ovnNamespace string, podInfo *PodInfo) (string, error) {
+	cmd := "ovs-vsctl list-ports " + podInfo.NodeExternalBridgeName
+	stdout, stderr, err := execInPod(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.OvnKubeContainerName, cmd, "")
+	if err != nil {
+		return "", fmt.Errorf("execInPod() failed with %s stderr %s stdout %s", err, stderr, stdout)
+	}
+	scanner := bufio.NewScanner(strings.NewReader(stdout))
+	for scanner.Scan() {
+		port := strings.TrimSpace(scanner.Text())
+		// the other ports are the patch ports to br-int
+		if port != "" && !strings.HasPrefix(port, "patch-") {
+			return port, nil
+		}
+	}
+	return "", fmt.Errorf("could not find the uplink port of bridge %s on node %s", podInfo.NodeExternalBridgeName, podInfo.NodeName)
+}
+
+func getRouterPortMacAddress(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podInfo *PodInfo, ovnNamespace, portName string) (string, error) {
+	tspCmd := "ovn-sbctl --no-leader-only " + podInfo.SbCommand + " --bare --no-heading --column=mac list Port_Binding " + portName
 	ipOutput, ipError, err := execInPod(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.OvnKubeContainerName, tspCmd, "")
 	if err != nil {
 		return "", fmt.Errorf("execInPod() failed. err: %s, stderr: %s, stdout: %s, podInfo: %v", err, ipError, ipOutput, podInfo)
@@ -687,35 +984,54 @@ func getDatabaseURIs(coreclient *corev1client.CoreV1Client, restconfig *rest.Con
 	return podInfo, nil
 }
 
-// printSuccessOrFailure will print a success or failure message. If searchString is set, then we expect to find a match for the
-// regexp given in searchString.
-func printSuccessOrFailure(commandDescription, src, dst, commandStdout, commandStderr string, err error, searchString string) {
+// printSuccessOrFailure will print a success or failure message for the hop. If searchString is set, then we expect to find
+// a match for the regexp given in searchString. With -output json, the hop is added to the report instead.
+func printSuccessOrFailure(hop *traceHop, commandStdout, commandStderr string, err error, searchString string) {
+	commandDescription := hop.description()
+	hop.Expected = searchString
 	if err != nil {
-		klog.Exitf("%s error %v stdOut: %s\n stdErr: %s", commandDescription, err, commandStdout, commandStderr)
+		if report != nil {
+			hop.Verdict = verdictError
+			hop.Error = fmt.Sprintf("%v, stdErr: %s", err, commandStderr)
+			report.addHop(hop)
+			report.exit(1)
+		}
+		exitf("%s error %v stdOut: %s\n stdErr: %s", commandDescription, err, commandStdout, commandStderr)
 	}
 	klog.V(2).Infof("%s Output:\n%s%s%s\n", commandDescription, italic, commandStdout, reset)
 
-	if searchString != "" {
-		match, err := regexp.MatchString(searchString, commandStdout)
-		if err != nil {
-			klog.Exitf("Unexpected failure matching regex '%s' to commandStdout '%s', err: %s", searchString, commandStdout, err)
+	hop.Verdict, err = getHopVerdict(commandStdout, searchString)
+	if err != nil {
+		if report != nil {
+			hop.Error = err.Error()
+			report.addHop(hop)
 		}
-		if match {
-			// Write the result to stdout.
-			fmt.Printf("%s%s%s indicates success from %s to %s%s\n", green, bold, commandDescription, src, dst, reset)
-			// Log further info on log level 1.
+		exitf("Unexpected failure matching regex '%s' to commandStdout '%s', err: %s", searchString, commandStdout, err)
+	}
+	if searchString != "" {
+		// Log further info on log level 1.
+		if hop.Verdict == verdictSuccess {
 			klog.V(1).Infof("%sSearch string matched:\n%s%s\n", green, searchString, reset)
 		} else {
-			// Write the result to stdout.
-			fmt.Printf("%s%s%s indicates failure from %s to %s%s\n", red, bold, commandDescription, src, dst, reset)
-			// Log further info on log level 1.
 			klog.V(1).Infof("%sSearch string not matched:\n%s%s\n", red, searchString, reset)
-			os.Exit(-1)
 		}
-	} else {
-		// Write the result to stdout.
-		fmt.Printf("%s%s%s indicates success from %s to %s%s\n", green, bold, commandDescription, src, dst, reset)
 	}
+
+	if report != nil {
+		hop.parseOutput(commandStdout)
+		report.addHop(hop)
+		if hop.Verdict == verdictFailure {
+			report.exit(-1)
+		}
+		return
+	}
+
+	// Write the result to stdout.
+	if hop.Verdict == verdictFailure {
+		fmt.Printf("%s%s%s indicates failure from %s to %s%s\n", red, bold, commandDescription, hop.Source, hop.Destination, reset)
+		os.Exit(-1)
+	}
+	fmt.Printf("%s%s%s indicates success from %s to %s%s\n", green, bold, commandDescription, hop.Source, hop.Destination, reset)
 }
 
 // runOvnTraceToService runs an ovntrace from src pod to dst service. If dstSvcInfo == nil, then skip all steps.
@@ -727,7 +1043,7 @@ func runOvnTraceToService(coreclient *corev1client.CoreV1Client, restconfig *res
 	}
 	svcL3Ver := dstSvcInfo.getL3Ver()
 	if srcPodInfo.IPVer != svcL3Ver {
-		klog.Exitf("Pod src IP address family (address: %s) and service IP address family (address: %s) do not match",
+		exitf("Pod src IP address family (address: %s) and service IP address family (address: %s) do not match",
 			srcPodInfo.IP, dstSvcInfo.ClusterIP)
 	}
 	cmd := fmt.Sprintf(`ovn-trace --no-leader-only %[1]s %[2]s --ct=new `+
@@ -756,22 +1072,167 @@ func runOvnTraceToService(coreclient *corev1client.CoreV1Client, restconfig *res
 		successString = fmt.Sprintf(`output to "tstor-%s"`, dstSvcInfo.PodInfo.NodeName)
 	}
 	direction := "source pod to service clusterIP"
-	printSuccessOrFailure("ovn-trace "+direction, srcPodInfo.PodName, dstSvcInfo.SvcName, ovnSrcDstOut, ovnSrcDstErr, err, successString)
+	hop := &traceHop{Tool: ovnTraceTool, Direction: direction, Node: srcPodInfo.NodeName,
+		Source: srcPodInfo.PodName, Destination: dstSvcInfo.SvcName, Command: cmd}
+	printSuccessOrFailure(hop, ovnSrcDstOut, ovnSrcDstErr, err, successString)
 	runOvnTraceToRemotePod(coreclient, restconfig, direction, srcPodInfo, dstSvcInfo.PodInfo, ovnNamespace, protocol, dstPort)
 
 }
 
+// serviceEntry describes how the traffic of a node source to a service enters OVN.
+type serviceEntry struct {
+	// viaMgmtPort is set when the host forwards the traffic to the node switch through the management port, otherwise
+	// it enters the gateway router from the external bridge
+	viaMgmtPort bool
+	// fromUplink is set when the traffic enters the external bridge from its uplink port rather than from the host
+	fromUplink bool
+	// the source addresses of the traffic entering the external bridge
+	bridgeSrcIP  string
+	bridgeSrcMAC string
+	// the addresses of the traffic handed over to OVN
+	srcIP   string
+	srcMAC  string
+	dstIP   string
+	dstPort string
+}
+
+// ovnSource returns a copy of the node source with the addresses of the traffic handed over to OVN.
+func (e *serviceEntry) ovnSource(srcInfo *PodInfo) *PodInfo {
+	ovnSrcInfo := *srcInfo
+	ovnSrcInfo.IP = e.srcIP
+	ovnSrcInfo.MAC = e.srcMAC
+	return &ovnSrcInfo
+}
+
+// getServiceEntry returns how the traffic of the given node source to the service enters OVN. In both gateway modes,
+// the external bridge SNATs the traffic the host sends to a service cluster IP to the host masquerade IP:
+//   - the node's host network reaches the cluster IP through the external bridge, except for ITP=local services whose
+//     traffic the host forwards through the management port
+//   - external clients reach the first load balancer or external IP, or the node port when useNodePort is set. In
+//     routingViaOVN gateway mode the external bridge forwards their traffic to the gateway router unchanged. In
+//     routingViaHost gateway mode the host DNATs it to the cluster IP and sends it back through the external bridge, or
+//     for ETP=local services DNATs it to the node port of the ETP=local masquerade IP, or to a local endpoint if the
+//     service has no node port, and forwards it through the management port with the client IP preserved.
+func getServiceEntry(srcInfo *PodInfo, dstSvcInfo *SvcInfo, dstPort string, useNodePort bool) (*serviceEntry, error) {
+	hostMasqueradeIP := config.Gateway.MasqueradeIPs.V4HostMasqueradeIP.String()
+	etpLocalMasqueradeIP := config.Gateway.MasqueradeIPs.V4HostETPLocalMasqueradeIP.String()
+	if srcInfo.IPVer == ip6 {
+		hostMasqueradeIP = config.Gateway.MasqueradeIPs.V6HostMasqueradeIP.String()
+		etpLocalMasqueradeIP = config.Gateway.MasqueradeIPs.V6HostETPLocalMasqueradeIP.String()
+	}
+	entry := &serviceEntry{
+		fromUplink:   srcInfo.External,
+		bridgeSrcIP:  srcInfo.IP,
+		bridgeSrcMAC: srcInfo.MAC,
+	}
+	viaMgmtPort := func(dstIP, dstPort string) *serviceEntry {
+		entry.viaMgmtPort = true
+		entry.srcIP, entry.srcMAC = srcInfo.IP, srcInfo.MgmtPortMAC
+		entry.dstIP, entry.dstPort = dstIP, dstPort
+		return entry
+	}
+	viaHostMasquerade := func() *serviceEntry {
+		entry.srcIP, entry.srcMAC = hostMasqueradeIP, srcInfo.GatewayRouterMAC
+		entry.dstIP, entry.dstPort = dstSvcInfo.ClusterIP, dstPort
+		return entry
+	}
+
+	if !srcInfo.External {
+		if dstSvcInfo.ITPLocal {
+			entry = viaMgmtPort(dstSvcInfo.ClusterIP, dstPort)
+			// the management port SNATs the traffic it forwards to its own IP address
+			entry.srcIP = srcInfo.MgmtPortIP
+			return entry, nil
+		}
+		return viaHostMasquerade(), nil
+	}
+
+	entry.dstIP, entry.dstPort = srcInfo.NodeIP, dstSvcInfo.NodePort
+	if !useNodePort && len(dstSvcInfo.ExternalIPs) > 0 {
+		entry.dstIP, entry.dstPort = dstSvcInfo.ExternalIPs[0], dstPort
+	} else if dstSvcInfo.NodePort == "" {
+		return nil, fmt.Errorf("service %s has no node port for port %s", dstSvcInfo.SvcName, dstPort)
+	}
+	if !srcInfo.RoutingViaHost {
+		entry.srcIP, entry.srcMAC = srcInfo.IP, srcInfo.MAC
+		return entry, nil
+	}
+	// The host received the traffic from the uplink and sends it back through the external bridge from its local port.
+	entry.fromUplink = false
+	entry.bridgeSrcMAC = srcInfo.GatewayRouterMAC
+	if !dstSvcInfo.ETPLocal {
+		klog.V(1).Infof("Node %s is in routingViaHost gateway mode, tracing the traffic of %s to the service cluster IP",
+			srcInfo.NodeName, srcInfo.PodName)
+		return viaHostMasquerade(), nil
+	}
+	if dstSvcInfo.NodePort == "" {
+		klog.V(1).Infof("Node %s is in routingViaHost gateway mode, tracing the traffic of %s to the service endpoint",
+			srcInfo.NodeName, srcInfo.PodName)
+		return viaMgmtPort(dstSvcInfo.PodInfo.IP, dstSvcInfo.PodPort), nil
+	}
+	klog.V(1).Infof("Node %s is in routingViaHost gateway mode, tracing the traffic of %s to the ETP=local masquerade IP",
+		srcInfo.NodeName, srcInfo.PodName)
+	return viaMgmtPort(etpLocalMasqueradeIP, dstSvcInfo.NodePort), nil
+}
+
+// runOvnTraceFromNodeToService runs an ovntrace from the host network of a node or from an external client to the dst
+// service, from the external bridge port to the gateway router or from the management port to the node switch.
+func runOvnTraceFromNodeToService(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcInfo *PodInfo, dstSvcInfo *SvcInfo,
+	entry *serviceEntry, ovnNamespace, protocol string) {
+	l3ver := getIPVer(net.ParseIP(entry.dstIP))
+	if srcInfo.IPVer != l3ver {
+		exitf("Source IP address family (address: %s) and service IP address family (address: %s) do not match",
+			entry.srcIP, entry.dstIP)
+	}
+	datapath := types.ExternalSwitchPrefix + srcInfo.NodeName
+	inport := srcInfo.ExternalPortName
+	ethDst := srcInfo.GatewayRouterMAC
+	if entry.viaMgmtPort {
+		datapath = srcInfo.NodeName
+		inport = srcInfo.K8sNodeNamePort
+		ethDst = srcInfo.RtosMAC
+	}
+	cmd := fmt.Sprintf(`ovn-trace --no-leader-only %[1]s %[2]s --ct=new `+
+		`'inport=="%[3]s" && eth.src==%[4]s && eth.dst==%[5]s && %[6]s.src==%[7]s && %[6]s.dst==%[8]s && ip.ttl==64 && %[9]s.dst==%[10]s && %[9]s.src==52888' --lb-dst %[11]s:%[12]s`,
+		srcInfo.SbCommand,     // 1
+		datapath,              // 2
+		inport,                // 3
+		entry.srcMAC,          // 4
+		ethDst,                // 5
+		l3ver,                 // 6
+		entry.srcIP,           // 7
+		entry.dstIP,           // 8
+		protocol,              // 9
+		entry.dstPort,         // 10
+		dstSvcInfo.PodInfo.IP, // 11
+		dstSvcInfo.PodPort,    // 12
+	)
+	klog.V(4).Infof("ovn-trace command from %s is %s", direction, cmd)
+
+	ovnSrcDstOut, ovnSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcInfo.OvnKubePodName, srcInfo.OvnKubeContainerName, cmd, "")
+	var successString string
+	if !srcInfo.IsInterConnect || podsInSameInterconnectZone(srcInfo, dstSvcInfo.PodInfo) {
+		successString = fmt.Sprintf(`output to "%s"`, dstSvcInfo.FullyQualifiedPodName())
+	} else {
+		successString = fmt.Sprintf(`output to "tstor-%s"`, dstSvcInfo.PodInfo.NodeName)
+	}
+	hop := &traceHop{Tool: ovnTraceTool, Direction: direction, Node: srcInfo.NodeName,
+		Source: srcInfo.PodName, Destination: dstSvcInfo.SvcName, Command: cmd}
+	printSuccessOrFailure(hop, ovnSrcDstOut, ovnSrcDstErr, err, successString)
+	runOvnTraceToRemotePod(coreclient, restconfig, direction, entry.ovnSource(srcInfo), dstSvcInfo.PodInfo, ovnNamespace, protocol, dstSvcInfo.PodPort)
+}
+
 // runOvnTraceToIP runs an ovntrace from src pod to dst IP address (should be external to the cluster).
 // Returns the node that the trace will exit on.
 func runOvnTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, srcPodInfo *PodInfo, parsedDstIP net.IP, ovnNamespace, protocol, dstPort string) (string, string) {
 	if srcPodInfo.HostNetwork {
-		klog.Exitf("Pod cannot be on Host Network when tracing to an IP address; use ping\n")
+		exitf("Pod cannot be on Host Network when tracing to an IP address; use ping\n")
 	}
 
 	l3ver := getIPVer(parsedDstIP)
 
 	if srcPodInfo.IPVer != l3ver {
-		klog.Exitf("Pod src IP address family (address: %s) and destination IP address family (address: %s) do not match",
+		exitf("Pod src IP address family (address: %s) and destination IP address family (address: %s) do not match",
 			srcPodInfo.IP, parsedDstIP)
 	}
 
@@ -798,7 +1259,9 @@ func runOvnTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Con
 	successString := fmt.Sprintf(`output to "(.*)_(.*)", type "localnet"|output to "k8s-%s"|remote`, srcPodInfo.NodeName)
 	// Run the command and check if succesString was found.
 	ovnSrcDstOut, ovnSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, "")
-	printSuccessOrFailure("ovn-trace from pod to IP", srcPodInfo.PodName, parsedDstIP.String(), ovnSrcDstOut, ovnSrcDstErr, err, successString)
+	hop := &traceHop{Tool: ovnTraceTool, Direction: "from pod to IP", Node: srcPodInfo.NodeName,
+		Source: srcPodInfo.PodName, Destination: parsedDstIP.String(), Command: cmd}
+	printSuccessOrFailure(hop, ovnSrcDstOut, ovnSrcDstErr, err, successString)
 
 	// Print some additional information about the node where this request leaves from as well
 	// as the SNAT IP address.
@@ -812,7 +1275,7 @@ func runOvnTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Con
 		subMatches = re.FindSubmatch([]byte(ovnSrcDstOut))
 		// We should never hit this (printSuccessOrFailure checks the same already above).
 		if len(subMatches) < 3 {
-			klog.Exitf("Could not determine the output port for this trace command, subMatches: %q\n", subMatches)
+			exitf("Could not determine the output port for this trace command, subMatches: %q\n", subMatches)
 		}
 		node := subMatches[len(subMatches)-1]
 		bridgeName := subMatches[len(subMatches)-2]
@@ -836,7 +1299,7 @@ func runOvnTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Con
 	re = regexp.MustCompile(nodeNameRegex)
 	subMatches = re.FindSubmatch([]byte(ovnSrcDstOut))
 	if len(subMatches) < 2 {
-		klog.Exitf("Could not determine node name / bridge name of egress node in runOvnTraceToIP()")
+		exitf("Could not determine node name / bridge name of egress node in runOvnTraceToIP()")
 	}
 	node := subMatches[len(subMatches)-1]
 	klog.V(1).Infof("%sout on node %s%s\n", green, node, reset)
@@ -846,23 +1309,23 @@ func runOvnTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Con
 // runOvnTraceToPod runs an ovntrace from src pod to dst pod.
 func runOvnTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcPodInfo, dstPodInfo *PodInfo, ovnNamespace, protocol, dstPort string) {
 	var inport string
-	inport = srcPodInfo.FullyQualifiedPodName()
+	inport = srcPodInfo.LogicalPort
 	if srcPodInfo.HostNetwork {
 		inport = srcPodInfo.K8sNodeNamePort
 	}
 	cmd := fmt.Sprintf(`ovn-trace --no-leader-only %[1]s %[2]s `+
 		`'inport=="%[3]s" && eth.src==%[4]s && eth.dst==%[5]s && %[6]s.src==%[7]s && %[8]s.dst==%[9]s && ip.ttl==64 && %[10]s.dst==%[11]s && %[10]s.src==52888'`,
-		srcPodInfo.SbCommand, // 1
-		srcPodInfo.NodeName,  // 2
-		inport,               // 3
-		srcPodInfo.MAC,       // 4
-		srcPodInfo.RtosMAC,   // 5
-		srcPodInfo.IPVer,     // 6
-		srcPodInfo.IP,        // 7
-		dstPodInfo.IPVer,     // 8
-		dstPodInfo.IP,        // 9
-		protocol,             // 10
-		dstPort,              // 11
+		srcPodInfo.SbCommand,               // 1
+		srcPodInfo.LogicalSwitch,           // 2
+		inport,                             // 3
+		srcPodInfo.MAC,                     // 4
+		srcPodInfo.firstHopMAC(dstPodInfo), // 5
+		srcPodInfo.IPVer,                   // 6
+		srcPodInfo.IP,                      // 7
+		dstPodInfo.IPVer,                   // 8
+		dstPodInfo.IP,                      // 9
+		protocol,                           // 10
+		dstPort,                            // 11
 	)
 	klog.V(4).Infof("ovn-trace command from %s is %s", direction, cmd)
 
@@ -877,12 +1340,23 @@ func runOvnTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Co
 			successString = fmt.Sprintf(`output to "%s_%s"`, srcPodInfo.NodeExternalBridgeName, srcPodInfo.NodeName)
 		}
 	} else if !srcPodInfo.IsInterConnect || podsInSameInterconnectZone(srcPodInfo, dstPodInfo) {
-		successString = fmt.Sprintf(`output to "%s"`, dstPodInfo.FullyQualifiedPodName())
+		successString = fmt.Sprintf(`output to "%s"`, dstPodInfo.LogicalPort)
 	} else {
-		successString = fmt.Sprintf(`output to "tstor-%s"`, dstPodInfo.NodeName)
+		switch srcPodInfo.NetInfo.TopologyType() {
+		case types.Layer2Topology:
+			// The pods of the other zones are remote ports of the layer2 switch.
+			successString = fmt.Sprintf(`output to "%s"`, dstPodInfo.LogicalPort)
+		case types.LocalnetTopology:
+			// The pods of the other zones are reached through the physical network.
+			successString = fmt.Sprintf(`output to "(%s|%s)"`, dstPodInfo.LogicalPort, srcPodInfo.NetInfo.GetNetworkScopedName(types.OVNLocalnetPort))
+		default:
+			successString = fmt.Sprintf(`output to "%s"`, srcPodInfo.NetInfo.GetNetworkScopedName(types.TransitSwitchToRouterPrefix+dstPodInfo.NodeName))
+		}
 	}
 	ovnSrcDstOut, ovnSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, "")
-	printSuccessOrFailure("ovn-trace "+direction, srcPodInfo.PodName, dstPodInfo.PodName, ovnSrcDstOut, ovnSrcDstErr, err, successString)
+	hop := &traceHop{Tool: ovnTraceTool, Direction: direction, Node: srcPodInfo.NodeName,
+		Source: srcPodInfo.PodName, Destination: dstPodInfo.PodName, Command: cmd}
+	printSuccessOrFailure(hop, ovnSrcDstOut, ovnSrcDstErr, err, successString)
 	runOvnTraceToRemotePod(coreclient, restconfig, direction, srcPodInfo, dstPodInfo, ovnNamespace, protocol, dstPort)
 }
 
@@ -890,23 +1364,40 @@ func runOvnTraceToRemotePod(coreclient *corev1client.CoreV1Client, restconfig *r
 	if dstPodInfo.HostNetwork || !srcPodInfo.IsInterConnect || podsInSameInterconnectZone(srcPodInfo, dstPodInfo) {
 		return
 	}
+	// The packet enters the zone of the destination pod from the transit switch on layer3 networks and from the
+	// remote port of the source pod on layer2 networks. On localnet networks it comes from the physical network,
+	// which cannot be traced.
+	var inport, ethDst string
+	switch srcPodInfo.NetInfo.TopologyType() {
+	case types.LocalnetTopology:
+		klog.V(4).Infof("Skipping the ovn-trace on the destination pod node for the localnet network %s", srcPodInfo.NADName)
+		return
+	case types.Layer2Topology:
+		inport = srcPodInfo.LogicalPort
+		ethDst = dstPodInfo.MAC
+	default:
+		inport = srcPodInfo.NetInfo.GetNetworkScopedName(types.TransitSwitchToRouterPrefix + srcPodInfo.NodeName)
+		ethDst = dstPodInfo.RtotsMAC
+	}
 	cmd := fmt.Sprintf(`ovn-trace --no-leader-only %[1]s `+
 		`'inport=="%[2]s" && eth.src==%[3]s && eth.dst==%[4]s && %[5]s.src==%[6]s && %[7]s.dst==%[8]s && ip.ttl==64 && %[9]s.dst==%[10]s && %[9]s.src==52888'`,
 		dstPodInfo.SbCommand, // 1
-		types.TransitSwitchToRouterPrefix+srcPodInfo.NodeName, // 2
-		srcPodInfo.MAC,      // 3
-		dstPodInfo.RtotsMAC, // 4
-		srcPodInfo.IPVer,    // 5
-		srcPodInfo.IP,       // 6
-		dstPodInfo.IPVer,    // 7
-		dstPodInfo.IP,       // 8
-		protocol,            // 9
-		dstPort,             // 10
+		inport,               // 2
+		srcPodInfo.MAC,       // 3
+		ethDst,               // 4
+		srcPodInfo.IPVer,     // 5
+		srcPodInfo.IP,        // 6
+		dstPodInfo.IPVer,     // 7
+		dstPodInfo.IP,        // 8
+		protocol,             // 9
+		dstPort,              // 10
 	)
 	klog.V(4).Infof("ovn-trace command on destination pod node is %s", cmd)
-	successString := fmt.Sprintf(`output to "%s"`, dstPodInfo.FullyQualifiedPodName())
+	successString := fmt.Sprintf(`output to "%s"`, dstPodInfo.LogicalPort)
 	ovnSrcDstOut, ovnSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, dstPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, "")
-	printSuccessOrFailure("ovn-trace (remote) "+direction, srcPodInfo.PodName, dstPodInfo.PodName, ovnSrcDstOut, ovnSrcDstErr, err, successString)
+	hop := &traceHop{Tool: ovnTraceTool, Direction: direction, Remote: true, Node: dstPodInfo.NodeName,
+		Source: srcPodInfo.PodName, Destination: dstPodInfo.PodName, Command: cmd}
+	printSuccessOrFailure(hop, ovnSrcDstOut, ovnSrcDstErr, err, successString)
 }
 
 func podsInSameInterconnectZone(srcPodInfo, dstPodInfo *PodInfo) bool {
@@ -919,17 +1410,17 @@ func runOfprotoTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *res
 	protocolSelector, nwSrc, nwDst := getOfprotoIPFamilyArgs(protocol, net.ParseIP(dstPodInfo.IP))
 	cmd := fmt.Sprintf(`ovs-appctl ofproto/trace br-int `+
 		`"in_port=%[1]s, %[9]s, dl_src=%[3]s, dl_dst=%[4]s, %[10]s=%[5]s, %[11]s=%[6]s, nw_ttl=64, %[7]s_dst=%[8]s, %[7]s_src=12345"`,
-		srcPodInfo.VethName, // 1
-		protocol,            // 2
-		srcPodInfo.MAC,      // 3
-		srcPodInfo.RtosMAC,  // 4
-		srcPodInfo.IP,       // 5
-		dstPodInfo.IP,       // 6
-		protocol,            // 7
-		dstPort,             // 8
-		protocolSelector,    // 9
-		nwSrc,               // 10
-		nwDst,               // 11
+		srcPodInfo.VethName,                // 1
+		protocol,                           // 2
+		srcPodInfo.MAC,                     // 3
+		srcPodInfo.firstHopMAC(dstPodInfo), // 4
+		srcPodInfo.IP,                      // 5
+		dstPodInfo.IP,                      // 6
+		protocol,                           // 7
+		dstPort,                            // 8
+		protocolSelector,                   // 9
+		nwSrc,                              // 10
+		nwDst,                              // 11
 	)
 	klog.V(4).Infof("ovs-appctl ofproto/trace command from %s is %s", direction, cmd)
 
@@ -939,6 +1430,10 @@ func runOfprotoTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *res
 		// Trace will end at the ovs port number of the dest pod.
 		// For host networked pods, getPodInfo sets OfportNum to the number of OvnK8sMp0OfportNum, see getPodInfo.
 		successString = "output:" + dstPodInfo.OfportNum + "\n\nFinal flow:"
+	} else if dstPodInfo.LocalnetBridgeName != "" {
+		klog.V(5).Infof("Pods are on node: %s and node %s, connected by the localnet network %s", srcPodInfo.NodeName, dstPodInfo.NodeName, dstPodInfo.NADName)
+		// Trace will leave br-int through the patch port to the bridge mapped to the localnet network.
+		successString = fmt.Sprintf(`bridge\("%s"\)`, srcPodInfo.LocalnetBridgeName)
 	} else if dstPodInfo.HostNetwork {
 		klog.V(5).Infof("Pod %s is on host network on node %s", dstPodInfo.PodName, dstPodInfo.NodeName)
 		// Different paths for routingViaHost gateway mode and routingViaOVN gateway mode.
@@ -954,7 +1449,9 @@ func runOfprotoTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *res
 		successString = "-> output to kernel tunnel"
 	}
 	appSrcDstOut, appSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, "")
-	printSuccessOrFailure("ovs-appctl ofproto/trace "+direction, srcPodInfo.PodName, dstPodInfo.PodName, appSrcDstOut, appSrcDstErr, err, successString)
+	hop := &traceHop{Tool: ofprotoTraceTool, Direction: direction, Node: srcPodInfo.NodeName,
+		Source: srcPodInfo.PodName, Destination: dstPodInfo.PodName, Command: cmd}
+	printSuccessOrFailure(hop, appSrcDstOut, appSrcDstErr, err, successString)
 
 	return appSrcDstOut
 }
@@ -997,7 +1494,54 @@ func runOfprotoTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest
 		}
 	}
 	appSrcDstOut, appSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, "")
-	printSuccessOrFailure(fmt.Sprintf("ovs-appctl ofproto/trace %s", direction), srcPodInfo.PodName, dstIP.String(), appSrcDstOut, appSrcDstErr, err, successString)
+	hop := &traceHop{Tool: ofprotoTraceTool, Direction: direction, Node: srcPodInfo.NodeName,
+		Source: srcPodInfo.PodName, Destination: dstIP.String(), Command: cmd}
+	printSuccessOrFailure(hop, appSrcDstOut, appSrcDstErr, err, successString)
+
+	return appSrcDstOut
+}
+
+// runOfprotoTraceFromNodeToService runs an ofproto/trace command from the host network of a node or from an external
+// client to the dst service. When the traffic enters OVN from the external bridge, the packet is traced from the uplink
+// port (external client in routingViaOVN gateway mode) or the local port (host) of the external bridge until it is
+// handed over to br-int. When the host forwards it through the management port, it is traced from the management port
+// to the endpoint pod.
+func runOfprotoTraceFromNodeToService(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcInfo *PodInfo, dstSvcInfo *SvcInfo,
+	entry *serviceEntry, ovnNamespace, protocol string) string {
+	if entry.viaMgmtPort {
+		return runOfprotoTraceToPod(coreclient, restconfig, direction, entry.ovnSource(srcInfo), dstSvcInfo.PodInfo, ovnNamespace, protocol, dstSvcInfo.PodPort)
+	}
+	inPort := "LOCAL"
+	if entry.fromUplink {
+		var err error
+		inPort, err = getBridgeUplinkPort(coreclient, restconfig, ovnNamespace, srcInfo)
+		if err != nil {
+			exitf("Failed to get the uplink port of node %s: %v", srcInfo.NodeName, err)
+		}
+	}
+	protocolSelector, nwSrc, nwDst := getOfprotoIPFamilyArgs(protocol, net.ParseIP(entry.dstIP))
+	cmd := fmt.Sprintf(`ovs-appctl ofproto/trace %[1]s `+
+		`"in_port=%[2]s, %[3]s, dl_src=%[4]s, dl_dst=%[5]s, %[6]s=%[7]s, %[8]s=%[9]s, nw_ttl=64, %[10]s_dst=%[11]s, %[10]s_src=12345"`,
+		srcInfo.NodeExternalBridgeName, // 1
+		inPort,                         // 2
+		protocolSelector,               // 3
+		entry.bridgeSrcMAC,             // 4
+		srcInfo.GatewayRouterMAC,       // 5
+		nwSrc,                          // 6
+		entry.bridgeSrcIP,              // 7
+		nwDst,                          // 8
+		entry.dstIP,                    // 9
+		protocol,                       // 10
+		entry.dstPort,                  // 11
+	)
+	klog.V(4).Infof("ovs-appctl ofproto/trace command from %s is %s", direction, cmd)
+
+	// The external bridge hands the packet over to OVN through its patch port to br-int.
+	successString := `bridge\("br-int"\)`
+	appSrcDstOut, appSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcInfo.OvnKubePodName, srcInfo.OvnKubeContainerName, cmd, "")
+	hop := &traceHop{Tool: ofprotoTraceTool, Direction: direction, Node: srcInfo.NodeName,
+		Source: srcInfo.PodName, Destination: dstSvcInfo.SvcName, Command: cmd}
+	printSuccessOrFailure(hop, appSrcDstOut, appSrcDstErr, err, successString)
 
 	return appSrcDstOut
 }
@@ -1065,13 +1609,24 @@ func verifyDependency(coreclient *corev1client.CoreV1Client, restconfig *rest.Co
 // Returns error if dependencies are not met (allows for graceful handling of those issues).
 func runOvnDetrace(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcPodInfo *PodInfo,
 	dstName string, appSrcDstOut, ovnNamespace string) error {
+	hop := &traceHop{Tool: ovnDetraceTool, Direction: direction, Node: srcPodInfo.NodeName,
+		Source: srcPodInfo.PodName, Destination: dstName}
+	// skip records in the report that ovn-detrace was not run.
+	skip := func(err error) error {
+		if report != nil {
+			hop.Verdict = verdictSkipped
+			hop.Error = err.Error()
+			report.addHop(hop)
+		}
+		return err
+	}
 	// If NBDB connectivity is not available do not run ovn-detrace.
 	if _, stdErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, fmt.Sprintf("ovn-nbctl %s get-connection", srcPodInfo.NbCommand), ""); err != nil {
-		return fmt.Errorf("nbdb is not available %q", stdErr)
+		return skip(fmt.Errorf("nbdb is not available %q", stdErr))
 	}
 	// If dependencies aren't satisfied do not run ovn-detrace.
 	if err := installOvnDetraceDependencies(coreclient, restconfig, srcPodInfo, ovnNamespace); err != nil {
-		return fmt.Errorf("dependencies check failed: %q", err)
+		return skip(fmt.Errorf("dependencies check failed: %q", err))
 	}
 
 	cmd := fmt.Sprintf(`ovn-detrace --ovnnb=%[1]s --ovnsb=%[2]s %[3]s --ovsdb=unix:/var/run/openvswitch/db.sock`,
@@ -1082,7 +1637,8 @@ func runOvnDetrace(coreclient *corev1client.CoreV1Client, restconfig *rest.Confi
 	klog.V(4).Infof("ovn-detrace command from %s is %s", direction, cmd)
 
 	dtraceSrcDstOut, dtraceSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, appSrcDstOut)
-	printSuccessOrFailure("ovn-detrace "+direction, srcPodInfo.PodName, dstName, dtraceSrcDstOut, dtraceSrcDstErr, err, "")
+	hop.Command = cmd
+	printSuccessOrFailure(hop, dtraceSrcDstOut, dtraceSrcDstErr, err, "")
 
 	return nil
 }
@@ -1092,7 +1648,7 @@ func displayNodeInfo(coreclient *corev1client.CoreV1Client) {
 	// List all Nodes.
 	nodes, err := coreclient.Nodes().List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
-		klog.Exitf(" Unexpected error: %v", err)
+		exitf(" Unexpected error: %v", err)
 	}
 
 	masters := make(map[string]string)
@@ -1155,7 +1711,7 @@ func setLogLevel(loglevel string) {
 	klog.SetOutput(os.Stderr)
 	err := level.Set(loglevel)
 	if err != nil {
-		klog.Exitf("fatal: cannot set logging level\n")
+		exitf("fatal: cannot set logging level\n")
 	}
 	klog.V(1).Infof("Log level set to: %s", loglevel)
 }
@@ -1171,36 +1727,57 @@ func main() {
 	srcNamespace := flag.String("src-namespace", "default", "k8s namespace of source pod")
 	dstNamespace := flag.String("dst-namespace", "default", "k8s namespace of dest pod")
 	srcPodName := flag.String("src", "", "src: source pod name")
+	srcNodeName := flag.String("src-node", "", "src-node: trace from the host network of this node, or from -src-ip through the external bridge of this node")
+	srcIP := flag.String("src-ip", "", "source IP address of an external client (requires -src-node)")
 	dstPodName := flag.String("dst", "", "dest: destination pod name")
 	dstSvcName := flag.String("service", "", "service: destination service name")
+	svcNodePort := flag.Bool("service-nodeport", false, "trace from -src-ip to the node port of the service instead of its load balancer or external IP")
 	dstIP := flag.String("dst-ip", "", "destination IP address (meant for tests to external targets)")
 	dstPort := flag.String("dst-port", "80", "dst-port: destination port")
 	tcp := flag.Bool("tcp", false, "use tcp transport protocol")
 	udp := flag.Bool("udp", false, "use udp transport protocol")
 	addressFamily := flag.String("addr-family", ip4, "Address family (ip4 or ip6) to be used for tracing")
+	network := flag.String("network", "", "network: trace on the secondary network of this network attachment definition, <namespace>/<name> or <name> in -src-namespace")
 	skipOvnDetrace := flag.Bool("skip-detrace", false, "skip ovn-detrace command")
 	loglevel := flag.String("loglevel", "0", "loglevel: klog level")
+	output := flag.String("output", outputText, "output format (text or json); json prints a report of all the traces to stdout")
 	flag.Parse()
 
 	// Set the application's log level.
 	setLogLevel(*loglevel)
 
 	// Verify CLI flags.
-	if *srcPodName == "" {
-		klog.Exitf("Usage: source pod must be specified")
+	if (*srcPodName == "") == (*srcNodeName == "") {
+		exitf("Usage: exactly one of -src or -src-node must be specified")
+	}
+	var parsedSrcIP net.IP
+	if *srcIP != "" {
+		if *srcNodeName == "" {
+			exitf("Usage: -src-ip requires -src-node")
+		}
+		parsedSrcIP = net.ParseIP(*srcIP)
+		if parsedSrcIP == nil {
+			exitf("Usage: cannot parse IP address provided in -src-ip")
+		}
+	}
+	if *srcNodeName != "" && *dstSvcName == "" {
+		exitf("Usage: -src-node requires -service")
+	}
+	if *svcNodePort && parsedSrcIP == nil {
+		exitf("Usage: -service-nodeport requires -src-ip")
 	}
 	if !*tcp && !*udp {
-		klog.Exitf("Usage: either tcp or udp must be specified")
+		exitf("Usage: either tcp or udp must be specified")
 	}
 	if *udp && *tcp {
-		klog.Exitf("Usage: Both tcp and udp cannot be specified at the same time")
+		exitf("Usage: Both tcp and udp cannot be specified at the same time")
 	}
 	if *tcp {
 		protocol = "tcp"
 	}
 	if *udp {
 		if *dstSvcName != "" {
-			klog.Exitf("Usage: udp option is not compatible with destination service trace")
+			exitf("Usage: udp option is not compatible with destination service trace")
 		}
 		protocol = "udp"
 	}
@@ -1215,11 +1792,42 @@ func main() {
 		targetOptions++
 		parsedDstIP = net.ParseIP(*dstIP)
 		if parsedDstIP == nil {
-			klog.Exitf("Usage: cannot parse IP address provided in -dst-ip")
+			exitf("Usage: cannot parse IP address provided in -dst-ip")
 		}
 	}
 	if targetOptions != 1 {
-		klog.Exitf("Usage: exactly one of -dst, -service or -dst-ip must be set")
+		exitf("Usage: exactly one of -dst, -service or -dst-ip must be set")
+	}
+	if *network != "" && (*srcPodName == "" || *dstPodName == "") {
+		exitf("Usage: -network is only supported between pods (-src and -dst)")
+	}
+	switch *output {
+	case outputText:
+	case outputJSON:
+		report = &traceReport{
+			Source:   *srcNamespace + "/" + *srcPodName,
+			Network:  *network,
+			Protocol: protocol,
+			DstPort:  *dstPort,
+		}
+		switch {
+		case parsedSrcIP != nil:
+			report.Source = fmt.Sprintf("%s via node %s", parsedSrcIP, *srcNodeName)
+		case *srcNodeName != "":
+			report.Source = "node " + *srcNodeName
+		}
+		switch {
+		case *dstPodName != "":
+			report.Destination = *dstNamespace + "/" + *dstPodName
+		case *dstSvcName != "":
+			report.Destination = *dstNamespace + "/" + *dstSvcName
+		default:
+			report.Destination = parsedDstIP.String()
+		}
+		// The report is printed when the trace completes, failed traces print it before exiting.
+		defer report.print()
+	default:
+		exitf("Usage: -output must be one of %s or %s", outputText, outputJSON)
 	}
 
 	// Get the ClientConfig.
@@ -1230,7 +1838,7 @@ func main() {
 		// use the current context in kubeconfig
 		restconfig, err = clientcmd.BuildConfigFromFlags("", *cliConfig)
 		if err != nil {
-			klog.Exitf(" Unexpected error: %v", err)
+			exitf(" Unexpected error: %v", err)
 		}
 	} else {
 		// Instantiate loader for kubeconfig file.
@@ -1243,20 +1851,20 @@ func main() {
 		// the client objects we create.
 		restconfig, err = kubeconfig.ClientConfig()
 		if err != nil {
-			klog.Exitf(" Unexpected error: %v", err)
+			exitf(" Unexpected error: %v", err)
 		}
 	}
 
 	// Create a Kubernetes core/v1 client.
 	coreclient, err := corev1client.NewForConfig(restconfig)
 	if err != nil {
-		klog.Exitf(" Unexpected error: %v", err)
+		exitf(" Unexpected error: %v", err)
 	}
 
 	// Get the namespace that OVN pods reside in.
 	ovnNamespace, err := getOvnNamespace(coreclient, *cfgNamespace)
 	if err != nil {
-		klog.Exitf(" Unexpected error: %v", err)
+		exitf(" Unexpected error: %v", err)
 	}
 	klog.V(5).Infof("OVN Kubernetes namespace is %s", ovnNamespace)
 
@@ -1265,10 +1873,61 @@ func main() {
 		displayNodeInfo(coreclient)
 	}
 
+	// Run a trace from a node's host network or from an external client to the destination service and return.
+	if *srcNodeName != "" {
+		srcInfo, err := getNodeSourceInfo(coreclient, restconfig, *srcNodeName, parsedSrcIP, ovnNamespace, *addressFamily)
+		if err != nil {
+			exitf("Failed to get information from node %s: %v", *srcNodeName, err)
+		}
+		klog.V(5).Infof("srcInfo is %s\n", srcInfo)
+		dstSvcInfo, err := getSvcInfo(coreclient, restconfig, *dstSvcName, ovnNamespace, *dstNamespace, *addressFamily, *dstPort)
+		if err != nil {
+			exitf("Failed to get information from service %s: %v", *dstSvcName, err)
+		}
+		klog.V(5).Infof("dstSvcInfo is %s\n", dstSvcInfo)
+		entry, err := getServiceEntry(srcInfo, dstSvcInfo, *dstPort, *svcNodePort)
+		if err != nil {
+			exitf("Failed to determine the destination of service %s: %v", *dstSvcName, err)
+		}
+		direction := "node host network to service"
+		if srcInfo.External {
+			direction = "external client to service"
+		}
+		klog.V(1).Infof("Tracing %s from %s to %s:%s, using pod %s in service %s", direction, srcInfo.PodName,
+			entry.dstIP, entry.dstPort, dstSvcInfo.PodInfo.PodName, *dstSvcName)
+
+		runOvnTraceFromNodeToService(coreclient, restconfig, direction, srcInfo, dstSvcInfo, entry, ovnNamespace, protocol)
+		appSrcDstOut := runOfprotoTraceFromNodeToService(coreclient, restconfig, direction, srcInfo, dstSvcInfo, entry, ovnNamespace, protocol)
+		if *skipOvnDetrace {
+			return
+		}
+		err = runOvnDetrace(coreclient, restconfig, direction, srcInfo, dstSvcInfo.SvcName, appSrcDstOut, ovnNamespace)
+		if err != nil {
+			klog.Infof("Skipped ovn-detrace due to: %q", err)
+		}
+		return
+	}
+
+	// Otherwise, get the network to trace on, the default network unless -network is set.
+	nadClient, err := nadclientset.NewForConfig(restconfig)
+	if err != nil {
+		exitf("Failed to create the network attachment definition client: %v", err)
+	}
+	netInfo, nadName, err := getNetworkInfo(nadClient, *network, *srcNamespace)
+	if err != nil {
+		exitf("Failed to get information from network %s: %v", *network, err)
+	}
+	if netInfo.IsSecondary() {
+		klog.V(1).Infof("Tracing on the %s network %s (%s)", netInfo.TopologyType(), netInfo.GetNetworkName(), nadName)
+		if report != nil {
+			report.Network = nadName
+		}
+	}
+
 	// Get info needed for the src Pod
-	srcPodInfo, err := getPodInfo(coreclient, restconfig, *srcPodName, ovnNamespace, *srcNamespace, *addressFamily)
+	srcPodInfo, err := getPodInfo(coreclient, restconfig, *srcPodName, ovnNamespace, *srcNamespace, *addressFamily, netInfo, nadName)
 	if err != nil {
-		klog.Exitf("Failed to get information from pod %s: %v", *srcPodName, err)
+		exitf("Failed to get information from pod %s: %v", *srcPodName, err)
 	}
 	klog.V(5).Infof("srcPodInfo is %s\n", srcPodInfo)
 
@@ -1293,9 +1952,9 @@ func main() {
 	var dstSvcInfo *SvcInfo
 	if *dstSvcName != "" {
 		// Get dst service
-		dstSvcInfo, err = getSvcInfo(coreclient, restconfig, *dstSvcName, ovnNamespace, *dstNamespace, *addressFamily)
+		dstSvcInfo, err = getSvcInfo(coreclient, restconfig, *dstSvcName, ovnNamespace, *dstNamespace, *addressFamily, *dstPort)
 		if err != nil {
-			klog.Exitf("Failed to get information from service %s: %v", *dstSvcName, err)
+			exitf("Failed to get information from service %s: %v", *dstSvcName, err)
 		}
 		klog.V(5).Infof("dstSvcInfo is %s\n", dstSvcInfo)
 		// Set dst pod name, we'll use this to run through pod-pod tests as if use supplied this pod
@@ -1304,15 +1963,15 @@ func main() {
 	}
 
 	// Now get info needed for the dst Pod
-	dstPodInfo, err := getPodInfo(coreclient, restconfig, *dstPodName, ovnNamespace, *dstNamespace, *addressFamily)
+	dstPodInfo, err := getPodInfo(coreclient, restconfig, *dstPodName, ovnNamespace, *dstNamespace, *addressFamily, netInfo, nadName)
 	if err != nil {
-		klog.Exitf("Failed to get information from pod %s: %v", *dstPodName, err)
+		exitf("Failed to get information from pod %s: %v", *dstPodName, err)
 	}
 	klog.V(5).Infof("dstPodInfo is %s\n", dstPodInfo)
 
 	// At least one pod must not be on the Host Network
 	if srcPodInfo.HostNetwork && dstPodInfo.HostNetwork {
-		klog.Exitf("Both pods cannot be on Host Network; use ping")
+		exitf("Both pods cannot be on Host Network; use ping")
 	}
 
 	// ovn-trace commands
@@ -0,0 +1,462 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+	nadapi "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	nadfake "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/clientset/versioned/fake"
+	kapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+func TestGetSvcExternalEntries(t *testing.T) {
+	svc := &kapi.Service{
+		Spec: kapi.ServiceSpec{
+			Ports: []kapi.ServicePort{
+				{Port: 80, NodePort: 30080},
+				{Port: 443},
+			},
+			ExternalIPs: []string{"192.168.10.20", "fd00:10::20"},
+		},
+		Status: kapi.ServiceStatus{
+			LoadBalancer: kapi.LoadBalancerStatus{
+				Ingress: []kapi.LoadBalancerIngress{{IP: "192.168.10.10"}, {Hostname: "lb.example.com"}, {IP: "fd00:10::10"}},
+			},
+		},
+	}
+	tests := []struct {
+		name                string
+		addressFamily       string
+		dstPort             string
+		expectedNodePort    string
+		expectedExternalIPs []string
+	}{
+		{
+			name:                "IPv4 service port with a node port",
+			addressFamily:       ip4,
+			dstPort:             "80",
+			expectedNodePort:    "30080",
+			expectedExternalIPs: []string{"192.168.10.10", "192.168.10.20"},
+		},
+		{
+			name:                "IPv6 service port with a node port",
+			addressFamily:       ip6,
+			dstPort:             "80",
+			expectedNodePort:    "30080",
+			expectedExternalIPs: []string{"fd00:10::10", "fd00:10::20"},
+		},
+		{
+			name:                "service port without a node port",
+			addressFamily:       ip4,
+			dstPort:             "443",
+			expectedExternalIPs: []string{"192.168.10.10", "192.168.10.20"},
+		},
+		{
+			name:                "unknown service port",
+			addressFamily:       ip4,
+			dstPort:             "8080",
+			expectedExternalIPs: []string{"192.168.10.10", "192.168.10.20"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodePort, externalIPs := getSvcExternalEntries(svc, tt.addressFamily, tt.dstPort)
+			if nodePort != tt.expectedNodePort {
+				t.Errorf("getSvcExternalEntries() nodePort = %q, expected %q", nodePort, tt.expectedNodePort)
+			}
+			if !reflect.DeepEqual(externalIPs, tt.expectedExternalIPs) {
+				t.Errorf("getSvcExternalEntries() externalIPs = %v, expected %v", externalIPs, tt.expectedExternalIPs)
+			}
+		})
+	}
+}
+
+func TestGetServiceEntry(t *testing.T) {
+	const (
+		nodeIP      = "172.18.0.2"
+		clientIP    = "192.168.100.5"
+		bridgeMAC   = "02:42:ac:12:00:02"
+		mgmtPortIP  = "10.244.1.2"
+		mgmtPortMAC = "0a:58:0a:f4:01:02"
+		hostMasqIP  = "169.254.169.2"
+		etpMasqIP   = "169.254.169.3"
+	)
+	endpoint := &PodInfo{IP: "10.244.2.3"}
+	svcInfo := &SvcInfo{
+		SvcName:     "web",
+		ClusterIP:   "10.96.0.10",
+		NodePort:    "30080",
+		ExternalIPs: []string{"192.168.10.10"},
+		PodInfo:     endpoint,
+		PodPort:     "8080",
+	}
+	svcInfoWithoutExternalIPs := &SvcInfo{SvcName: "web", ClusterIP: "10.96.0.10", NodePort: "30080", PodInfo: endpoint, PodPort: "8080"}
+	svcInfoWithoutNodePort := &SvcInfo{SvcName: "web", ClusterIP: "10.96.0.10", PodInfo: endpoint, PodPort: "8080"}
+	svcInfoITPLocal := &SvcInfo{SvcName: "web", ClusterIP: "10.96.0.10", ITPLocal: true, PodInfo: endpoint, PodPort: "8080"}
+	svcInfoETPLocal := &SvcInfo{SvcName: "web", ClusterIP: "10.96.0.10", NodePort: "30080", ETPLocal: true, PodInfo: endpoint, PodPort: "8080"}
+	svcInfoETPLocalWithoutNodePort := &SvcInfo{SvcName: "web", ClusterIP: "10.96.0.10", ExternalIPs: []string{"192.168.10.10"},
+		ETPLocal: true, PodInfo: endpoint, PodPort: "8080"}
+
+	nodeInfo := NodeInfo{NodeName: "ovn-worker", NodeIP: nodeIP, GatewayRouterMAC: bridgeMAC, MgmtPortIP: mgmtPortIP, MgmtPortMAC: mgmtPortMAC}
+	nodeInfoViaHost := nodeInfo
+	nodeInfoViaHost.RoutingViaHost = true
+	hostNetwork := &PodInfo{NodeInfo: nodeInfo, IPVer: ip4, IP: nodeIP, MAC: bridgeMAC}
+	hostNetworkViaHost := &PodInfo{NodeInfo: nodeInfoViaHost, IPVer: ip4, IP: nodeIP, MAC: bridgeMAC}
+	externalClient := &PodInfo{NodeInfo: nodeInfo, IPVer: ip4, IP: clientIP, MAC: externalClientMAC, External: true}
+	externalClientViaHost := &PodInfo{NodeInfo: nodeInfoViaHost, IPVer: ip4, IP: clientIP, MAC: externalClientMAC, External: true}
+
+	tests := []struct {
+		name        string
+		srcInfo     *PodInfo
+		svcInfo     *SvcInfo
+		useNodePort bool
+		expected    *serviceEntry
+		expectErr   bool
+	}{
+		{
+			name:        "node host network reaches the cluster IP through the external bridge",
+			srcInfo:     hostNetwork,
+			svcInfo:     svcInfo,
+			useNodePort: true,
+			expected: &serviceEntry{bridgeSrcIP: nodeIP, bridgeSrcMAC: bridgeMAC,
+				srcIP: hostMasqIP, srcMAC: bridgeMAC, dstIP: "10.96.0.10", dstPort: "80"},
+		},
+		{
+			name:    "node host network in routingViaHost gateway mode reaches the cluster IP through the external bridge",
+			srcInfo: hostNetworkViaHost,
+			svcInfo: svcInfo,
+			expected: &serviceEntry{bridgeSrcIP: nodeIP, bridgeSrcMAC: bridgeMAC,
+				srcIP: hostMasqIP, srcMAC: bridgeMAC, dstIP: "10.96.0.10", dstPort: "80"},
+		},
+		{
+			name:    "node host network reaches an ITP=local service through the management port",
+			srcInfo: hostNetworkViaHost,
+			svcInfo: svcInfoITPLocal,
+			expected: &serviceEntry{viaMgmtPort: true, bridgeSrcIP: nodeIP, bridgeSrcMAC: bridgeMAC,
+				srcIP: mgmtPortIP, srcMAC: mgmtPortMAC, dstIP: "10.96.0.10", dstPort: "80"},
+		},
+		{
+			name:    "external client reaches the first external IP",
+			srcInfo: externalClient,
+			svcInfo: svcInfo,
+			expected: &serviceEntry{fromUplink: true, bridgeSrcIP: clientIP, bridgeSrcMAC: externalClientMAC,
+				srcIP: clientIP, srcMAC: externalClientMAC, dstIP: "192.168.10.10", dstPort: "80"},
+		},
+		{
+			name:        "external client reaches the node port when asked",
+			srcInfo:     externalClient,
+			svcInfo:     svcInfo,
+			useNodePort: true,
+			expected: &serviceEntry{fromUplink: true, bridgeSrcIP: clientIP, bridgeSrcMAC: externalClientMAC,
+				srcIP: clientIP, srcMAC: externalClientMAC, dstIP: nodeIP, dstPort: "30080"},
+		},
+		{
+			name:    "external client reaches the node port without external IPs",
+			srcInfo: externalClient,
+			svcInfo: svcInfoWithoutExternalIPs,
+			expected: &serviceEntry{fromUplink: true, bridgeSrcIP: clientIP, bridgeSrcMAC: externalClientMAC,
+				srcIP: clientIP, srcMAC: externalClientMAC, dstIP: nodeIP, dstPort: "30080"},
+		},
+		{
+			name:    "external client reaches an ETP=local service through the external bridge in routingViaOVN gateway mode",
+			srcInfo: externalClient,
+			svcInfo: svcInfoETPLocal,
+			expected: &serviceEntry{fromUplink: true, bridgeSrcIP: clientIP, bridgeSrcMAC: externalClientMAC,
+				srcIP: clientIP, srcMAC: externalClientMAC, dstIP: nodeIP, dstPort: "30080"},
+		},
+		{
+			name:    "external client in routingViaHost gateway mode is DNATed to the cluster IP by the host",
+			srcInfo: externalClientViaHost,
+			svcInfo: svcInfo,
+			expected: &serviceEntry{bridgeSrcIP: clientIP, bridgeSrcMAC: bridgeMAC,
+				srcIP: hostMasqIP, srcMAC: bridgeMAC, dstIP: "10.96.0.10", dstPort: "80"},
+		},
+		{
+			name:    "external client in routingViaHost gateway mode reaches an ETP=local service through the management port",
+			srcInfo: externalClientViaHost,
+			svcInfo: svcInfoETPLocal,
+			expected: &serviceEntry{viaMgmtPort: true, bridgeSrcIP: clientIP, bridgeSrcMAC: bridgeMAC,
+				srcIP: clientIP, srcMAC: mgmtPortMAC, dstIP: etpMasqIP, dstPort: "30080"},
+		},
+		{
+			name:    "external client in routingViaHost gateway mode reaches the endpoint of an ETP=local service without node port",
+			srcInfo: externalClientViaHost,
+			svcInfo: svcInfoETPLocalWithoutNodePort,
+			expected: &serviceEntry{viaMgmtPort: true, bridgeSrcIP: clientIP, bridgeSrcMAC: bridgeMAC,
+				srcIP: clientIP, srcMAC: mgmtPortMAC, dstIP: "10.244.2.3", dstPort: "8080"},
+		},
+		{
+			name:      "external client cannot reach a service without node port nor external IPs",
+			srcInfo:   externalClient,
+			svcInfo:   svcInfoWithoutNodePort,
+			expectErr: true,
+		},
+		{
+			name:      "external client in routingViaHost gateway mode cannot reach a service without node port nor external IPs",
+			srcInfo:   externalClientViaHost,
+			svcInfo:   svcInfoWithoutNodePort,
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry, err := getServiceEntry(tt.srcInfo, tt.svcInfo, "80", tt.useNodePort)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("getServiceEntry() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if !reflect.DeepEqual(entry, tt.expected) {
+				t.Errorf("getServiceEntry() = %+v, expected %+v", entry, tt.expected)
+			}
+		})
+	}
+}
+
+func newNADForTest(namespace, name, config string) *nadapi.NetworkAttachmentDefinition {
+	return &nadapi.NetworkAttachmentDefinition{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       nadapi.NetworkAttachmentDefinitionSpec{Config: config},
+	}
+}
+
+func newSecondaryNetInfoForTest(t *testing.T, name, topology, nadName string) util.NetInfo {
+	netInfo, err := util.NewNetInfo(&ovncnitypes.NetConf{
+		NetConf:  cnitypes.NetConf{Name: name},
+		Topology: topology,
+		NADName:  nadName,
+		Subnets:  "10.100.0.0/16",
+	})
+	if err != nil {
+		t.Fatalf("failed to create the network info of %s: %v", name, err)
+	}
+	return netInfo
+}
+
+func TestGetNetworkInfo(t *testing.T) {
+	nadClient := nadfake.NewSimpleClientset()
+	for _, nad := range []*nadapi.NetworkAttachmentDefinition{
+		newNADForTest("ns1", "blue", `{"cniVersion": "0.4.0", "name": "tenant-blue", "type": "ovn-k8s-cni-overlay", `+
+			`"topology": "layer2", "subnets": "10.100.0.0/16", "netAttachDefName": "ns1/blue"}`),
+		newNADForTest("ns2", "red", `{"cniVersion": "0.4.0", "name": "tenant-red", "type": "ovn-k8s-cni-overlay", `+
+			`"topology": "layer3", "subnets": "10.200.0.0/16/24", "netAttachDefName": "ns2/red"}`),
+		newNADForTest("ns1", "macvlan", `{"cniVersion": "0.4.0", "name": "macvlan", "type": "macvlan"}`),
+	} {
+		// the object tracker of the fake clientset does not guess the resource name of the NADs right, they
+		// have to be created through the client
+		_, err := nadClient.K8sCniCncfIoV1().NetworkAttachmentDefinitions(nad.Namespace).Create(context.TODO(), nad, metav1.CreateOptions{})
+		if err != nil {
+			t.Fatalf("failed to create NAD %s/%s: %v", nad.Namespace, nad.Name, err)
+		}
+	}
+	tests := []struct {
+		name             string
+		network          string
+		namespace        string
+		expectedNetwork  string
+		expectedTopology string
+		expectedNADName  string
+		expectErr        bool
+	}{
+		{
+			name:             "default network without -network",
+			namespace:        "ns1",
+			expectedNetwork:  types.DefaultNetworkName,
+			expectedTopology: types.Layer3Topology,
+			expectedNADName:  types.DefaultNetworkName,
+		},
+		{
+			name:             "network name in the pod namespace",
+			network:          "blue",
+			namespace:        "ns1",
+			expectedNetwork:  "tenant-blue",
+			expectedTopology: types.Layer2Topology,
+			expectedNADName:  "ns1/blue",
+		},
+		{
+			name:             "network name in another namespace",
+			network:          "ns2/red",
+			namespace:        "ns1",
+			expectedNetwork:  "tenant-red",
+			expectedTopology: types.Layer3Topology,
+			expectedNADName:  "ns2/red",
+		},
+		{
+			name:      "network name in another namespace without namespace",
+			network:   "red",
+			namespace: "ns1",
+			expectErr: true,
+		},
+		{
+			name:      "invalid network name",
+			network:   "ns1/blue/extra",
+			namespace: "ns1",
+			expectErr: true,
+		},
+		{
+			name:      "network not managed by OVN-Kubernetes",
+			network:   "macvlan",
+			namespace: "ns1",
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			netInfo, nadName, err := getNetworkInfo(nadClient, tt.network, tt.namespace)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("getNetworkInfo() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if tt.expectErr {
+				return
+			}
+			if netInfo.GetNetworkName() != tt.expectedNetwork || netInfo.TopologyType() != tt.expectedTopology || nadName != tt.expectedNADName {
+				t.Errorf("getNetworkInfo() = %s %s %s, expected %s %s %s", netInfo.GetNetworkName(), netInfo.TopologyType(), nadName,
+					tt.expectedNetwork, tt.expectedTopology, tt.expectedNADName)
+			}
+		})
+	}
+}
+
+func TestGetPodLogicalSwitchAndPort(t *testing.T) {
+	tests := []struct {
+		name           string
+		netInfo        util.NetInfo
+		nadName        string
+		expectedSwitch string
+		expectedPort   string
+	}{
+		{
+			name:           "default network",
+			netInfo:        &util.DefaultNetInfo{},
+			nadName:        types.DefaultNetworkName,
+			expectedSwitch: "ovn-worker",
+			expectedPort:   "ns1_client",
+		},
+		{
+			name:           "layer3 network",
+			netInfo:        newSecondaryNetInfoForTest(t, "tenant-red", types.Layer3Topology, "ns1/red"),
+			nadName:        "ns1/red",
+			expectedSwitch: "tenant.red_ovn-worker",
+			expectedPort:   "ns1.red_ns1_client",
+		},
+		{
+			name:           "layer2 network",
+			netInfo:        newSecondaryNetInfoForTest(t, "tenant-blue", types.Layer2Topology, "ns1/blue"),
+			nadName:        "ns1/blue",
+			expectedSwitch: "tenant.blue_" + types.OVNLayer2Switch,
+			expectedPort:   "ns1.blue_ns1_client",
+		},
+		{
+			name:           "localnet network",
+			netInfo:        newSecondaryNetInfoForTest(t, "physnet", types.LocalnetTopology, "ns1/physnet"),
+			nadName:        "ns1/physnet",
+			expectedSwitch: "physnet_" + types.OVNLocalnetSwitch,
+			expectedPort:   "ns1.physnet_ns1_client",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			podInfo := &PodInfo{
+				NodeInfo:     NodeInfo{NodeName: "ovn-worker"},
+				PodName:      "client",
+				PodNamespace: "ns1",
+				NetInfo:      tt.netInfo,
+				NADName:      tt.nadName,
+			}
+			logicalSwitch, logicalPort := getPodLogicalSwitchAndPort(podInfo)
+			if logicalSwitch != tt.expectedSwitch || logicalPort != tt.expectedPort {
+				t.Errorf("getPodLogicalSwitchAndPort() = %s %s, expected %s %s", logicalSwitch, logicalPort, tt.expectedSwitch, tt.expectedPort)
+			}
+		})
+	}
+}
+
+func TestGetSecondaryNetworkPodAddresses(t *testing.T) {
+	annotations := map[string]string{
+		util.OvnPodAnnotationName: `{"default": {"ip_addresses": ["10.244.1.3/24"], "mac_address": "0a:58:0a:f4:01:03"}, ` +
+			`"ns1/blue": {"ip_addresses": ["10.100.0.5/16", "fd00:100::5/64"], "mac_address": "0a:58:0a:64:00:05"}}`,
+	}
+	tests := []struct {
+		name          string
+		pod           *kapi.Pod
+		nadName       string
+		addressFamily string
+		expectedIP    string
+		expectedMAC   string
+		expectErr     bool
+	}{
+		{
+			name:          "IPv4 address on the network",
+			pod:           &kapi.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}},
+			nadName:       "ns1/blue",
+			addressFamily: ip4,
+			expectedIP:    "10.100.0.5",
+			expectedMAC:   "0a:58:0a:64:00:05",
+		},
+		{
+			name:          "IPv6 address on the network",
+			pod:           &kapi.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}},
+			nadName:       "ns1/blue",
+			addressFamily: ip6,
+			expectedIP:    "fd00:100::5",
+			expectedMAC:   "0a:58:0a:64:00:05",
+		},
+		{
+			name:          "pod not attached to the network",
+			pod:           &kapi.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: annotations}},
+			nadName:       "ns1/red",
+			addressFamily: ip4,
+			expectErr:     true,
+		},
+		{
+			name:          "host networked pod",
+			pod:           &kapi.Pod{Spec: kapi.PodSpec{HostNetwork: true}},
+			nadName:       "ns1/blue",
+			addressFamily: ip4,
+			expectErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip, mac, err := getSecondaryNetworkPodAddresses(tt.pod, tt.nadName, tt.addressFamily)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("getSecondaryNetworkPodAddresses() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if ip != tt.expectedIP || mac != tt.expectedMAC {
+				t.Errorf("getSecondaryNetworkPodAddresses() = %s %s, expected %s %s", ip, mac, tt.expectedIP, tt.expectedMAC)
+			}
+		})
+	}
+}
+
+func TestPodInfo_firstHopMAC(t *testing.T) {
+	dst := &PodInfo{MAC: "0a:58:0a:64:00:06"}
+	tests := []struct {
+		netInfo  util.NetInfo
+		expected string
+	}{
+		{netInfo: nil, expected: "0a:58:0a:f4:01:01"},
+		{netInfo: &util.DefaultNetInfo{}, expected: "0a:58:0a:f4:01:01"},
+		{netInfo: newSecondaryNetInfoForTest(t, "tenant-red", types.Layer3Topology, "ns1/red"), expected: "0a:58:0a:f4:01:01"},
+		{netInfo: newSecondaryNetInfoForTest(t, "tenant-blue", types.Layer2Topology, "ns1/blue"), expected: dst.MAC},
+		{netInfo: newSecondaryNetInfoForTest(t, "physnet", types.LocalnetTopology, "ns1/physnet"), expected: dst.MAC},
+	}
+	for _, tt := range tests {
+		name := "no network"
+		if tt.netInfo != nil {
+			name = fmt.Sprintf("%s network", tt.netInfo.TopologyType())
+		}
+		t.Run(name, func(t *testing.T) {
+			src := &PodInfo{RtosMAC: "0a:58:0a:f4:01:01", NetInfo: tt.netInfo}
+			if mac := src.firstHopMAC(dst); mac != tt.expected {
+				t.Errorf("firstHopMAC() = %s, expected %s", mac, tt.expected)
+			}
+		})
+	}
+}
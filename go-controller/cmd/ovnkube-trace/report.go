@@ -0,0 +1,268 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"k8s.io/klog/v2"
+	"k8s.io/utils/strings/slices"
+)
+
+const (
+	// Output formats.
+	outputText = "text"
+	outputJSON = "json"
+)
+
+// traceTool is the command used for one hop of a trace.
+type traceTool string
+
+const (
+	ovnTraceTool     traceTool = "ovn-trace"
+	ofprotoTraceTool traceTool = "ovs-appctl ofproto/trace"
+	ovnDetraceTool   traceTool = "ovn-detrace"
+)
+
+// traceVerdict is the result of a hop or of the whole trace.
+type traceVerdict string
+
+const (
+	// verdictSuccess means the packet reached the expected output.
+	verdictSuccess traceVerdict = "success"
+	// verdictFailure means the packet did not reach the expected output.
+	verdictFailure traceVerdict = "failure"
+	// verdictError means the trace command itself could not be run, or for the whole trace that it could
+	// not be set up.
+	verdictError traceVerdict = "error"
+	// verdictSkipped means the trace command was not run, e.g. because of missing dependencies.
+	verdictSkipped traceVerdict = "skipped"
+)
+
+// traceReport is the machine readable result of ovnkube-trace, printed with -output json.
+type traceReport struct {
+	Source      string       `json:"source"`
+	Destination string       `json:"destination"`
+	Network     string       `json:"network,omitempty"` // the network attachment definition given with -network
+	Protocol    string       `json:"protocol"`
+	DstPort     string       `json:"dstPort"`
+	Verdict     traceVerdict `json:"verdict"`
+	Error       string       `json:"error,omitempty"` // the error that stopped the trace before its hops completed
+	Hops        []*traceHop  `json:"hops"`
+}
+
+// traceHop describes the run of one trace command.
+type traceHop struct {
+	Tool        traceTool `json:"tool"`
+	Direction   string    `json:"direction"`
+	Remote      bool      `json:"remote,omitempty"` // the trace ran on the interconnect zone of the destination
+	Node        string    `json:"node,omitempty"`   // the node whose ovnkube pod ran the trace
+	Source      string    `json:"source"`
+	Destination string    `json:"destination"`
+	Command     string    `json:"command,omitempty"`
+	// Expected is the regexp searched in the output to determine the verdict, if any
+	Expected      string       `json:"expected,omitempty"`
+	Verdict       traceVerdict `json:"verdict"`
+	Datapaths     []string     `json:"datapaths,omitempty"`
+	ACLs          []string     `json:"acls,omitempty"`
+	LoadBalancers []string     `json:"loadBalancers,omitempty"`
+	Output        string       `json:"output,omitempty"`
+	DropReasons   []string     `json:"dropReasons,omitempty"`
+	Error         string       `json:"error,omitempty"`
+}
+
+// report collects the hops when -output json is set, it is nil with the default text output.
+var report *traceReport
+
+// description returns the human readable description of the hop used in the text output.
+func (h *traceHop) description() string {
+	if h.Remote {
+		return fmt.Sprintf("%s (remote) %s", h.Tool, h.Direction)
+	}
+	return fmt.Sprintf("%s %s", h.Tool, h.Direction)
+}
+
+// parseOutput fills the datapaths, ACLs, load balancers, output and drop reasons of the hop from the
+// output of its trace command.
+func (h *traceHop) parseOutput(out string) {
+	switch h.Tool {
+	case ovnTraceTool:
+		parseOvnTrace(h, out)
+	case ofprotoTraceTool:
+		parseOfprotoTrace(h, out)
+	case ovnDetraceTool:
+		parseOvnDetrace(h, out)
+	}
+}
+
+var (
+	// ingress(dp="ovn-worker", inport="default_client")
+	ovnTraceDatapathRegex = regexp.MustCompile(`^(?:ingress|egress)\(dp="([^"]+)"`)
+	// 4. ls_in_acl_eval (northd.c:6900): ip4 && tcp.dst == 80, priority 2001, uuid 8f1e9b2a
+	ovnTraceStageRegex = regexp.MustCompile(`^\s*\d+\.\s+(\S+)\s+\([^)]*\):\s+(.*), priority (\d+), uuid [0-9a-f]+$`)
+	// the stages evaluating the ACLs, as opposed to the pre and hint stages
+	ovnTraceACLStageRegex = regexp.MustCompile(`^l[rs]_(in|out)_acl(_after_lb)?(_eval)?$`)
+	// ct_lb_mark(backends=10.244.1.3:8080);
+	ovnTraceLBActionRegex = regexp.MustCompile(`ct_lb(?:_mark)?\((backends=[^)]*)\)`)
+	// /* output to "default_server", type "" */
+	ovnTraceOutputRegex = regexp.MustCompile(`/\* output to "([^"]+)"`)
+)
+
+// parseOvnTrace parses the detailed output of ovn-trace.
+func parseOvnTrace(h *traceHop, out string) {
+	var stage, match string
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := ovnTraceDatapathRegex.FindStringSubmatch(line); m != nil {
+			if !slices.Contains(h.Datapaths, m[1]) {
+				h.Datapaths = append(h.Datapaths, m[1])
+			}
+			continue
+		}
+		if m := ovnTraceStageRegex.FindStringSubmatch(line); m != nil {
+			stage, match = m[1], m[2]
+			if ovnTraceACLStageRegex.MatchString(stage) {
+				h.ACLs = append(h.ACLs, fmt.Sprintf("%s: %s, priority %s", stage, match, m[3]))
+			}
+			continue
+		}
+		action := strings.TrimSpace(line)
+		if m := ovnTraceLBActionRegex.FindStringSubmatch(action); m != nil {
+			h.LoadBalancers = append(h.LoadBalancers, fmt.Sprintf("%s: %s, %s", stage, match, m[1]))
+			continue
+		}
+		if m := ovnTraceOutputRegex.FindStringSubmatch(action); m != nil {
+			h.Output = m[1]
+			continue
+		}
+		if action == "drop;" {
+			h.DropReasons = append(h.DropReasons, fmt.Sprintf("%s: %s", stage, match))
+		}
+	}
+}
+
+// parseOfprotoTrace parses the output of ofproto/trace. Drops in resubmitted tables are part of the
+// normal pipeline, the packet is only dropped when the final datapath actions say so.
+func parseOfprotoTrace(h *traceHop, out string) {
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, `bridge("`):
+			bridge := strings.TrimSuffix(strings.TrimPrefix(line, `bridge("`), `")`)
+			if !slices.Contains(h.Datapaths, bridge) {
+				h.Datapaths = append(h.Datapaths, bridge)
+			}
+		case strings.HasPrefix(line, "Datapath actions:"):
+			h.Output = strings.TrimSpace(strings.TrimPrefix(line, "Datapath actions:"))
+		case strings.HasPrefix(line, ">>>>"):
+			// errors found while translating the flows, e.g. ">>>> received packet on unknown port 5 <<<<"
+			h.DropReasons = append(h.DropReasons, strings.TrimSpace(strings.Trim(line, "<>")))
+		}
+	}
+	if h.Output == "drop" {
+		h.DropReasons = append(h.DropReasons, "datapath actions: drop")
+	}
+}
+
+var (
+	// * Logical datapath: "ovn-worker" (3a0e46f5-...) [ingress]
+	ovnDetraceDatapathRegex = regexp.MustCompile(`\* Logical datapaths?:\s+"([^"]+)"`)
+	// * ACL: to-lport, priority=1001, match=(ip4.src == 10.244.1.3), allow-related
+	ovnDetraceACLRegex = regexp.MustCompile(`\* ACL: (.*)$`)
+	// * Load Balancer: Service_default/web_TCP_cluster protocol ['tcp'] vips {...} ips [...]
+	ovnDetraceLBRegex = regexp.MustCompile(`\* Load Balancer: (.*)$`)
+)
+
+// parseOvnDetrace parses the ofproto/trace output annotated by ovn-detrace.
+func parseOvnDetrace(h *traceHop, out string) {
+	parseOfprotoTrace(h, out)
+	// ovn-detrace annotates the logical datapaths in place of the bridges
+	h.Datapaths = nil
+	scanner := bufio.NewScanner(strings.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := ovnDetraceDatapathRegex.FindStringSubmatch(line); m != nil {
+			if !slices.Contains(h.Datapaths, m[1]) {
+				h.Datapaths = append(h.Datapaths, m[1])
+			}
+		} else if m := ovnDetraceACLRegex.FindStringSubmatch(line); m != nil {
+			if !slices.Contains(h.ACLs, m[1]) {
+				h.ACLs = append(h.ACLs, m[1])
+			}
+		} else if m := ovnDetraceLBRegex.FindStringSubmatch(line); m != nil {
+			if !slices.Contains(h.LoadBalancers, m[1]) {
+				h.LoadBalancers = append(h.LoadBalancers, m[1])
+			}
+		}
+	}
+}
+
+// addHop adds a hop to the report.
+func (r *traceReport) addHop(h *traceHop) {
+	r.Hops = append(r.Hops, h)
+}
+
+// getHopVerdict returns the verdict of a hop whose trace command ran: success unless searchString is set and the
+// regexp it holds does not match the output of the command.
+func getHopVerdict(commandStdout, searchString string) (traceVerdict, error) {
+	if searchString == "" {
+		return verdictSuccess, nil
+	}
+	match, err := regexp.MatchString(searchString, commandStdout)
+	if err != nil {
+		return verdictError, err
+	}
+	if !match {
+		return verdictFailure, nil
+	}
+	return verdictSuccess, nil
+}
+
+// verdict returns the verdict of the whole trace, it succeeds if none of the hops failed. It is an error if the
+// trace could not be set up.
+func (r *traceReport) verdict() traceVerdict {
+	if r.Error != "" {
+		return verdictError
+	}
+	for _, h := range r.Hops {
+		if h.Verdict == verdictFailure || h.Verdict == verdictError {
+			return verdictFailure
+		}
+	}
+	return verdictSuccess
+}
+
+// print writes the report as JSON to stdout.
+func (r *traceReport) print() {
+	r.Verdict = r.verdict()
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	// keep the logical flow matches readable
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(r); err != nil {
+		klog.Exitf("Failed to encode the trace report: %v", err)
+	}
+}
+
+// exit prints the report and exits with the given code.
+func (r *traceReport) exit(code int) {
+	r.print()
+	os.Exit(code)
+}
+
+// exitf logs the error and exits with code 1. With -output json, the error is first added to the report, which is
+// printed with verdictError.
+func exitf(format string, args ...interface{}) {
+	if report == nil {
+		klog.Exitf(format, args...)
+	}
+	klog.Errorf(format, args...)
+	klog.Flush()
+	report.Error = strings.TrimSpace(fmt.Sprintf(format, args...))
+	report.exit(1)
+}
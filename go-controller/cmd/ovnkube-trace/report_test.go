@@ -0,0 +1,273 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+const ovnTraceOutput = `# tcp,reg14=0x2,vlan_tci=0x0000,dl_src=0a:58:0a:f4:01:03,dl_dst=0a:58:0a:f4:01:01,nw_src=10.244.1.3,nw_dst=10.96.0.10
+ingress(dp="ovn-worker", inport="default_client")
+-------------------------------------------------
+ 0. ls_in_check_port_sec (northd.c:8591): 1, priority 50, uuid 2b5a3c11
+    reg0[15] = check_in_port_sec();
+    next;
+ 4. ls_in_pre_acl (northd.c:5992): ip, priority 100, uuid 9c1d2e3f
+    reg0[0] = 1;
+    next;
+ 8. ls_in_acl_eval (northd.c:6900): ip4 && tcp.dst == 80, priority 2001, uuid 8f1e9b2a
+    reg8[16] = 1;
+    next;
+ 12. ls_in_lb (northd.c:7200): ct.new && ip4.dst == 10.96.0.10 && tcp.dst == 80, priority 120, uuid 4e5f6a7b
+    reg0[1] = 0;
+    ct_lb_mark(backends=10.244.2.3:8080);
+
+ct_lb_mark
+----------
+ 27. ls_in_l2_lkup (northd.c:9500): eth.dst == 0a:58:0a:f4:02:03, priority 50, uuid 1a2b3c4d
+    outport = "default_server";
+    output;
+
+egress(dp="ovn-worker", inport="default_client", outport="default_server")
+--------------------------------------------------------------------------
+ 9. ls_out_check_port_sec (northd.c:5900): 1, priority 0, uuid 5d6e7f80
+    reg0[15] = check_out_port_sec();
+    next;
+ 10. ls_out_apply_port_sec (northd.c:5950): 1, priority 0, uuid 6e7f8091
+    output;
+    /* output to "default_server", type "" */
+`
+
+const ovnTraceDropOutput = `ingress(dp="ovn-worker", inport="default_client")
+-------------------------------------------------
+ 8. ls_in_acl_eval (northd.c:6900): ip4 && ip4.dst == 10.244.2.3, priority 1001, uuid 8f1e9b2a
+    reg8[17] = 1;
+    next;
+ 9. ls_in_acl_action (northd.c:6950): reg8[17] == 1, priority 1000, uuid 3c4d5e6f
+    reg8[16] = 0;
+    reg8[17] = 0;
+    drop;
+`
+
+const ofprotoTraceOutput = `Flow: tcp,in_port=5,vlan_tci=0x0000,dl_src=0a:58:0a:f4:01:03,dl_dst=0a:58:0a:f4:01:01,nw_src=10.244.1.3,nw_dst=10.244.2.3
+
+bridge("br-int")
+----------------
+ 0. in_port=5, priority 100, cookie 0x2b5a3c11
+    set_field:0x2->reg14
+    resubmit(,8)
+ 8. reg14=0x2,metadata=0x3, priority 50, cookie 0x2b5a3c11
+    drop
+
+bridge("breth0")
+----------------
+ 0. in_port=2, priority 50
+    output:1
+
+Final flow: unchanged
+Megaflow: recirc_id=0,eth,tcp,in_port=5,nw_frag=no
+Datapath actions: ct(zone=7,nat),recirc(0x5)
+`
+
+const ofprotoTraceDropOutput = `Flow: tcp,in_port=9,vlan_tci=0x0000,nw_src=10.244.1.3,nw_dst=10.244.2.3
+
+bridge("br-int")
+----------------
+>>>> received packet on unknown port 9 <<<<
+
+Final flow: unchanged
+Datapath actions: drop
+`
+
+const ovnDetraceOutput = `bridge("br-int")
+----------------
+ 0. in_port=5, priority 100, cookie 0x2b5a3c11
+    * Logical datapath: "ovn-worker" (3a0e46f5-1234-5678-9abc-def012345678) [ingress]
+    * Logical flow: table=8 (ls_in_acl_eval), priority=1001, match=(ip4.src == 10.244.1.3), actions=(next;)
+    * ACL: to-lport, priority=1001, match=(ip4.src == 10.244.1.3), allow-related
+ 8. reg14=0x2,metadata=0x3, priority 50, cookie 0x4e5f6a7b
+    * Logical datapaths:  "ovn-worker" (3a0e46f5-1234-5678-9abc-def012345678) [ingress]
+    * Load Balancer: Service_default/web_TCP_cluster protocol ['tcp'] vips {'10.96.0.10:80': '10.244.2.3:8080'} ips ['10.244.2.3']
+ 9. metadata=0x4, priority 50, cookie 0x5d6e7f80
+    * Logical datapath: "ovn_cluster_router" (4b1f57a6-1234-5678-9abc-def012345678) [ingress]
+    * ACL: to-lport, priority=1001, match=(ip4.src == 10.244.1.3), allow-related
+
+Datapath actions: 3
+`
+
+func TestTraceHop_parseOutput(t *testing.T) {
+	tests := []struct {
+		name     string
+		tool     traceTool
+		out      string
+		expected traceHop
+	}{
+		{
+			name: "ovn-trace to a service backend",
+			tool: ovnTraceTool,
+			out:  ovnTraceOutput,
+			expected: traceHop{
+				Datapaths:     []string{"ovn-worker"},
+				ACLs:          []string{"ls_in_acl_eval: ip4 && tcp.dst == 80, priority 2001"},
+				LoadBalancers: []string{"ls_in_lb: ct.new && ip4.dst == 10.96.0.10 && tcp.dst == 80, backends=10.244.2.3:8080"},
+				Output:        "default_server",
+			},
+		},
+		{
+			name: "ovn-trace dropped by an ACL",
+			tool: ovnTraceTool,
+			out:  ovnTraceDropOutput,
+			expected: traceHop{
+				Datapaths:   []string{"ovn-worker"},
+				ACLs:        []string{"ls_in_acl_eval: ip4 && ip4.dst == 10.244.2.3, priority 1001"},
+				DropReasons: []string{"ls_in_acl_action: reg8[17] == 1"},
+			},
+		},
+		{
+			name: "ofproto/trace ignores the drops of resubmitted tables",
+			tool: ofprotoTraceTool,
+			out:  ofprotoTraceOutput,
+			expected: traceHop{
+				Datapaths: []string{"br-int", "breth0"},
+				Output:    "ct(zone=7,nat),recirc(0x5)",
+			},
+		},
+		{
+			name: "ofproto/trace dropped by the datapath",
+			tool: ofprotoTraceTool,
+			out:  ofprotoTraceDropOutput,
+			expected: traceHop{
+				Datapaths:   []string{"br-int"},
+				Output:      "drop",
+				DropReasons: []string{"received packet on unknown port 9", "datapath actions: drop"},
+			},
+		},
+		{
+			name: "ovn-detrace reports the logical datapaths instead of the bridges",
+			tool: ovnDetraceTool,
+			out:  ovnDetraceOutput,
+			expected: traceHop{
+				Datapaths:     []string{"ovn-worker", "ovn_cluster_router"},
+				ACLs:          []string{"to-lport, priority=1001, match=(ip4.src == 10.244.1.3), allow-related"},
+				LoadBalancers: []string{"Service_default/web_TCP_cluster protocol ['tcp'] vips {'10.96.0.10:80': '10.244.2.3:8080'} ips ['10.244.2.3']"},
+				Output:        "3",
+			},
+		},
+		{
+			name: "empty output",
+			tool: ovnTraceTool,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &traceHop{Tool: tt.tool}
+			h.parseOutput(tt.out)
+			tt.expected.Tool = tt.tool
+			if !reflect.DeepEqual(*h, tt.expected) {
+				t.Errorf("parseOutput() = %+v, expected %+v", *h, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetHopVerdict(t *testing.T) {
+	tests := []struct {
+		name         string
+		out          string
+		searchString string
+		expected     traceVerdict
+		expectErr    bool
+	}{
+		{
+			name:     "success without a search string",
+			out:      ovnTraceDropOutput,
+			expected: verdictSuccess,
+		},
+		{
+			name:         "success when the search string matches",
+			out:          ovnTraceOutput,
+			searchString: `output to "default_server"`,
+			expected:     verdictSuccess,
+		},
+		{
+			name:         "failure when the search string does not match",
+			out:          ovnTraceDropOutput,
+			searchString: `output to "default_server"`,
+			expected:     verdictFailure,
+		},
+		{
+			name:         "success when the datapath actions regexp matches",
+			out:          ofprotoTraceOutput,
+			searchString: `Datapath actions: ct\(zone=\d+`,
+			expected:     verdictSuccess,
+		},
+		{
+			name:         "error on an invalid regexp",
+			out:          ovnTraceOutput,
+			searchString: `output to "(`,
+			expected:     verdictError,
+			expectErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict, err := getHopVerdict(tt.out, tt.searchString)
+			if (err != nil) != tt.expectErr {
+				t.Fatalf("getHopVerdict() error = %v, expectErr %v", err, tt.expectErr)
+			}
+			if verdict != tt.expected {
+				t.Errorf("getHopVerdict() = %s, expected %s", verdict, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTraceReport_verdict(t *testing.T) {
+	tests := []struct {
+		name     string
+		hops     []traceVerdict
+		err      string
+		expected traceVerdict
+	}{
+		{
+			name:     "success without hops",
+			expected: verdictSuccess,
+		},
+		{
+			name:     "success when all hops succeed",
+			hops:     []traceVerdict{verdictSuccess, verdictSuccess},
+			expected: verdictSuccess,
+		},
+		{
+			name:     "success with skipped hops",
+			hops:     []traceVerdict{verdictSuccess, verdictSkipped},
+			expected: verdictSuccess,
+		},
+		{
+			name:     "failure when a hop fails",
+			hops:     []traceVerdict{verdictSuccess, verdictFailure},
+			expected: verdictFailure,
+		},
+		{
+			name:     "failure when a hop could not run",
+			hops:     []traceVerdict{verdictError, verdictSkipped},
+			expected: verdictFailure,
+		},
+		{
+			name:     "error when the trace could not be set up",
+			hops:     []traceVerdict{verdictSuccess},
+			err:      "Failed to get information from pod client: not found",
+			expected: verdictError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &traceReport{Error: tt.err}
+			for _, verdict := range tt.hops {
+				r.addHop(&traceHop{Verdict: verdict})
+			}
+			if verdict := r.verdict(); verdict != tt.expected {
+				t.Errorf("verdict() = %s, expected %s", verdict, tt.expected)
+			}
+		})
+	}
+}
@@ -103,3 +103,7 @@ echo "Copying adminpolicybasedexternalroutes CRD"
 cp _output/crds/k8s.ovn.org_adminpolicybasedexternalroutes.yaml ../dist/templates/k8s.ovn.org_adminpolicybasedexternalroutes.yaml.j2
 echo "Copying egressService CRD"
 cp _output/crds/k8s.ovn.org_egressservices.yaml ../dist/templates/k8s.ovn.org_egressservices.yaml.j2
+echo "Copying ipamClaim CRD"
+cp _output/crds/k8s.ovn.org_ipamclaims.yaml ../dist/templates/k8s.ovn.org_ipamclaims.yaml.j2
+echo "Copying clusterNetwork CRD"
+cp _output/crds/k8s.ovn.org_clusternetworks.yaml ../dist/templates/k8s.ovn.org_clusternetworks.yaml.j2
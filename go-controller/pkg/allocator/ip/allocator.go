@@ -38,6 +38,10 @@ type Interface interface {
 	Reserved(ip net.IP) bool
 }
 
+// MaxIPv6RangeSize is the maximum number of addresses of an IPv6 range, only the first addresses of larger
+// IPv6 subnets are allocated
+const MaxIPv6RangeSize = 65536
+
 var (
 	ErrFull      = errors.New("range is full")
 	ErrAllocated = errors.New("provided IP is already allocated")
@@ -90,8 +94,8 @@ func NewAllocatorCIDRRange(cidr *net.IPNet, allocatorFactory allocator.Allocator
 
 	if utilnet.IsIPv6CIDR(cidr) {
 		// Limit the max size, since the allocator keeps a bitmap of that size.
-		if max > 65536 {
-			max = 65536
+		if max > MaxIPv6RangeSize {
+			max = MaxIPv6RangeSize
 		}
 	} else {
 		// Don't use the IPv4 network's broadcast address.
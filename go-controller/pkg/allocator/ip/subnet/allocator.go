@@ -11,13 +11,16 @@ import (
 	bitmapallocator "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/bitmap"
 	ipallocator "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/ip"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
 )
 
 // Allocator manages the allocation of IP within specific set of subnets
 // identified by a name. Allocator should be threadsafe.
 type Allocator interface {
 	AddOrUpdateSubnet(name string, subnets []*net.IPNet, excludeSubnets ...*net.IPNet) error
+	ExpandSubnet(name string, subnets []*net.IPNet, excludeSubnets ...*net.IPNet) error
 	DeleteSubnet(name string)
 	GetSubnets(name string) ([]*net.IPNet, error)
 	AllocateUntilFull(name string) error
@@ -39,6 +42,20 @@ type NamedAllocator interface {
 // ErrSubnetNotFound is used to inform the subnet is not being managed
 var ErrSubnetNotFound = errors.New("subnet not found")
 
+// SubnetsFullError is returned by an IP family allocator when all the subnets
+// of an IP family of a subnet set have no IP left to allocate.
+type SubnetsFullError struct {
+	Subnets []*net.IPNet
+}
+
+func (e *SubnetsFullError) Error() string {
+	return fmt.Sprintf("subnets %v are full", util.StringSlice(e.Subnets))
+}
+
+func (e *SubnetsFullError) Unwrap() error {
+	return ipallocator.ErrFull
+}
+
 // subnetInfo contains information corresponding to the subnet. It holds the
 // allocations (v4 and v6) as well as the IPAM allocator instances for each
 // of the managed subnets
@@ -56,6 +73,8 @@ type allocator struct {
 	// A RW mutex which holds subnet information
 	sync.RWMutex
 	ipamFunc ipamFactoryFunc
+	// allocate a single IP per IP family instead of one per subnet
+	perIPFamily bool
 }
 
 // newIPAMAllocator provides an ipam interface which can be used for IPAM
@@ -77,6 +96,15 @@ func NewAllocator() *allocator {
 	}
 }
 
+// NewIPFamilyAllocator initializes a new subnet IP allocator that allocates a
+// single IP per IP family, out of the first subnet of that family with IPs
+// available, instead of one IP out of each subnet of the set.
+func NewIPFamilyAllocator() *allocator {
+	allocator := NewAllocator()
+	allocator.perIPFamily = true
+	return allocator
+}
+
 // AddOrUpdateSubnet set to the allocator for IPAM management, or update it.
 func (allocator *allocator) AddOrUpdateSubnet(name string, subnets []*net.IPNet, excludeSubnets ...*net.IPNet) error {
 	allocator.Lock()
@@ -115,6 +143,63 @@ func (allocator *allocator) AddOrUpdateSubnet(name string, subnets []*net.IPNet,
 	return nil
 }
 
+// ExpandSubnet updates an existing subnet set with additional subnets and
+// excluded subnets. Unlike AddOrUpdateSubnet, IPs already allocated from the
+// subnets that remain in the set are kept allocated. Excluded IPs that are
+// already allocated stay allocated to their current owner.
+func (allocator *allocator) ExpandSubnet(name string, subnets []*net.IPNet, excludeSubnets ...*net.IPNet) error {
+	allocator.Lock()
+	defer allocator.Unlock()
+	current, ok := allocator.cache[name]
+	if !ok {
+		return fmt.Errorf("failed to expand subnets of %s: %w", name, ErrSubnetNotFound)
+	}
+
+	currentIPAMs := make(map[string]ipallocator.Interface, len(current.ipams))
+	for i, subnet := range current.subnets {
+		currentIPAMs[subnet.String()] = current.ipams[i]
+	}
+
+	ipams := make([]ipallocator.Interface, 0, len(subnets))
+	for _, subnet := range subnets {
+		if ipam, ok := currentIPAMs[subnet.String()]; ok {
+			ipams = append(ipams, ipam)
+			delete(currentIPAMs, subnet.String())
+			continue
+		}
+		ipam, err := allocator.ipamFunc(subnet)
+		if err != nil {
+			return fmt.Errorf("failed to initialize IPAM of subnet %s for %s: %w", subnet, name, err)
+		}
+		ipams = append(ipams, ipam)
+	}
+	if len(currentIPAMs) > 0 {
+		return fmt.Errorf("failed to expand subnets of %s: subnets %v would be removed", name, sets.List(sets.KeySet(currentIPAMs)))
+	}
+
+	for _, excludeSubnet := range excludeSubnets {
+		var excluded bool
+		for i, subnet := range subnets {
+			if util.ContainsCIDR(subnet, excludeSubnet) {
+				err := reserveSubnets(excludeSubnet, ipams[i])
+				if err != nil {
+					return fmt.Errorf("failed to exclude subnet %s for %s: %w", excludeSubnet, name, err)
+				}
+				excluded = true
+			}
+		}
+		if !excluded {
+			return fmt.Errorf("failed to exclude subnet %s for %s: not contained in any of the subnets", excludeSubnet, name)
+		}
+	}
+
+	allocator.cache[name] = subnetInfo{
+		subnets: subnets,
+		ipams:   ipams,
+	}
+	return nil
+}
+
 // DeleteSubnet from the allocator
 func (allocator *allocator) DeleteSubnet(name string) {
 	allocator.Lock()
@@ -208,11 +293,11 @@ func (allocator *allocator) AllocateIPs(name string, ips []*net.IPNet) error {
 	return nil
 }
 
-// reserveSubnets reserves subnet IPs
+// reserveSubnets reserves subnet IPs, skipping those already allocated
 func reserveSubnets(subnet *net.IPNet, ipam ipallocator.Interface) error {
 	// FIXME: allocate IP ranges when https://github.com/ovn-org/ovn-kubernetes/issues/3369 is fixed
 	for ip := subnet.IP; subnet.Contains(ip); ip = iputils.NextIP(ip) {
-		if ipam.Reserved(ip) {
+		if ipam.Reserved(ip) || ipam.Has(ip) {
 			continue
 		}
 		err := ipam.Allocate(ip)
@@ -245,6 +330,10 @@ func (allocator *allocator) AllocateNextIPs(name string) ([]*net.IPNet, error) {
 			" don't match number of ipam instances %d", name, len(subnetInfo.subnets), len(subnetInfo.ipams))
 	}
 
+	if allocator.perIPFamily {
+		return allocateNextIPFamilyIPs(name, subnetInfo)
+	}
+
 	defer func() {
 		if err != nil {
 			// iterate over range of already allocated indices and release
@@ -272,6 +361,60 @@ func (allocator *allocator) AllocateNextIPs(name string) ([]*net.IPNet, error) {
 	return ipnets, nil
 }
 
+// allocateNextIPFamilyIPs allocates one IP address per IP family of the given
+// subnet set, out of the first subnet of each family with IPs available. If
+// all the subnets of a family are full, a SubnetsFullError is returned.
+func allocateNextIPFamilyIPs(name string, subnetInfo subnetInfo) ([]*net.IPNet, error) {
+	var ipnets []*net.IPNet
+	var ipams []ipallocator.Interface
+	var err error
+	defer func() {
+		if err != nil {
+			// release ips allocated before the error occurred.
+			for i, relIPNet := range ipnets {
+				ipams[i].Release(relIPNet.IP)
+				klog.Warningf("Reserved IP %s was released for %s", relIPNet.IP, name)
+			}
+		}
+	}()
+
+	// keep the IP families in the order of the subnets
+	var families []bool
+	for _, subnet := range subnetInfo.subnets {
+		ipv6 := utilnet.IsIPv6CIDR(subnet)
+		if len(families) == 0 || (len(families) == 1 && families[0] != ipv6) {
+			families = append(families, ipv6)
+		}
+	}
+
+	for _, ipv6 := range families {
+		var familySubnets []*net.IPNet
+		var ipnet *net.IPNet
+		for idx, subnet := range subnetInfo.subnets {
+			if utilnet.IsIPv6CIDR(subnet) != ipv6 {
+				continue
+			}
+			familySubnets = append(familySubnets, subnet)
+			var ip net.IP
+			ip, err = subnetInfo.ipams[idx].AllocateNext()
+			if errors.Is(err, ipallocator.ErrFull) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			ipnet = &net.IPNet{IP: ip, Mask: subnet.Mask}
+			ipnets = append(ipnets, ipnet)
+			ipams = append(ipams, subnetInfo.ipams[idx])
+			break
+		}
+		if ipnet == nil {
+			err = &SubnetsFullError{Subnets: familySubnets}
+			return nil, err
+		}
+	}
+	return ipnets, nil
+}
+
 // ReleaseIPs marks the IPs in ipnets slice as available for allocation by
 // releasing them from the IPAM pool of allocated IPs of the given subnet set.
 // If there aren't IPs to release the method does not return an error.
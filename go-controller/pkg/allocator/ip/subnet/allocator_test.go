@@ -1,10 +1,12 @@
 package subnet
 
 import (
+	"errors"
 	"testing"
 
 	ipam "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/ip"
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
 	"github.com/onsi/ginkgo"
 	"github.com/onsi/gomega"
@@ -91,6 +93,62 @@ var _ = ginkgo.Describe("Subnet IP allocator operations", func() {
 
 	})
 
+	ginkgo.Context("when expanding subnets", func() {
+		ginkgo.It("retains the existing allocations", func() {
+			subnetName := "subnet1"
+			err := allocator.AddOrUpdateSubnet(subnetName, ovntest.MustParseIPNets("10.1.1.0/30"))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			ips, err := allocator.AllocateNextIPs(subnetName)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ips[0].IP.String()).To(gomega.Equal("10.1.1.1"))
+
+			err = allocator.ExpandSubnet(subnetName, ovntest.MustParseIPNets("10.1.1.0/30", "10.1.2.0/30"))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			err = allocator.AllocateIPs(subnetName, ovntest.MustParseIPNets("10.1.1.1/30"))
+			gomega.Expect(err).To(gomega.MatchError(ipam.ErrAllocated))
+
+			expectedIPs := []string{"10.1.1.2", "10.1.2.1"}
+			ips, err = allocator.AllocateNextIPs(subnetName)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ips).To(gomega.HaveLen(len(expectedIPs)))
+			for i, ip := range ips {
+				gomega.Expect(ip.IP.String()).To(gomega.Equal(expectedIPs[i]))
+			}
+		})
+
+		ginkgo.It("excludes additional subnets keeping already allocated IPs", func() {
+			subnetName := "subnet1"
+			err := allocator.AddOrUpdateSubnet(subnetName, ovntest.MustParseIPNets("10.1.1.0/24"))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			err = allocator.AllocateIPs(subnetName, ovntest.MustParseIPNets("10.1.1.2/24"))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			err = allocator.ExpandSubnet(subnetName, ovntest.MustParseIPNets("10.1.1.0/24"), ovntest.MustParseIPNets("10.1.1.0/29")...)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			ips, err := allocator.AllocateNextIPs(subnetName)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ips[0].IP.String()).To(gomega.Equal("10.1.1.8"))
+		})
+
+		ginkgo.It("fails to remove subnets", func() {
+			subnetName := "subnet1"
+			err := allocator.AddOrUpdateSubnet(subnetName, ovntest.MustParseIPNets("10.1.1.0/24", "2000::/64"))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			err = allocator.ExpandSubnet(subnetName, ovntest.MustParseIPNets("10.1.1.0/24"))
+			gomega.Expect(err).To(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("fails for unknown subnets", func() {
+			err := allocator.ExpandSubnet("subnet1", ovntest.MustParseIPNets("10.1.1.0/24"))
+			gomega.Expect(err).To(gomega.MatchError(ErrSubnetNotFound))
+		})
+	})
+
 	ginkgo.Context("when allocating IP addresses", func() {
 		ginkgo.It("IPAM for each subnet allocates IPs contiguously", func() {
 			subnetName := "subnet1"
@@ -209,6 +267,71 @@ var _ = ginkgo.Describe("Subnet IP allocator operations", func() {
 
 	})
 
+	ginkgo.Context("when allocating IPs per IP family", func() {
+		ginkgo.BeforeEach(func() {
+			allocator = NewIPFamilyAllocator()
+		})
+
+		ginkgo.It("allocates a single IP per IP family from the first subnet with IPs available", func() {
+			subnetName := "subnet1"
+			subnets := []string{
+				"10.1.1.0/30",
+				"2000::/64",
+				"10.1.2.0/24",
+			}
+
+			expectedIPAllocations := [][]string{
+				{"10.1.1.1/30", "2000::1/64"},
+				{"10.1.1.2/30", "2000::2/64"},
+				{"10.1.2.1/24", "2000::3/64"},
+			}
+
+			err := allocator.AddOrUpdateSubnet(subnetName, ovntest.MustParseIPNets(subnets...))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			for _, expectedIPs := range expectedIPAllocations {
+				ips, err := allocator.AllocateNextIPs(subnetName)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(ips).To(gomega.HaveLen(len(expectedIPs)))
+				for i, ip := range ips {
+					gomega.Expect(ip.String()).To(gomega.Equal(expectedIPs[i]))
+				}
+			}
+		})
+
+		ginkgo.It("reports the subnets of an IP family once they are all full", func() {
+			subnetName := "subnet1"
+			subnets := []string{
+				"2000::/64",
+				"10.1.1.0/30",
+				"10.1.2.0/30",
+			}
+
+			err := allocator.AddOrUpdateSubnet(subnetName, ovntest.MustParseIPNets(subnets...))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			for i := 0; i < 4; i++ {
+				_, err := allocator.AllocateNextIPs(subnetName)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			}
+
+			ips, err := allocator.AllocateNextIPs(subnetName)
+			gomega.Expect(err).To(gomega.MatchError(ipam.ErrFull))
+			var fullErr *SubnetsFullError
+			gomega.Expect(errors.As(err, &fullErr)).To(gomega.BeTrue())
+			gomega.Expect(fullErr.Subnets).To(gomega.Equal(ovntest.MustParseIPNets("10.1.1.0/30", "10.1.2.0/30")))
+			gomega.Expect(ips).To(gomega.BeEmpty())
+
+			// the IPv6 IP allocated before the failure was released
+			err = allocator.AllocateIPs(subnetName, ovntest.MustParseIPNets("2000::5/64"))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			err = allocator.ExpandSubnet(subnetName, ovntest.MustParseIPNets(append(subnets, "10.1.3.0/24")...))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			ips, err = allocator.AllocateNextIPs(subnetName)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(util.StringSlice(ips)).To(gomega.Equal([]string{"2000::6/64", "10.1.3.1/24"}))
+		})
+	})
+
 })
 
 func TestSubnetIPAllocator(t *testing.T) {
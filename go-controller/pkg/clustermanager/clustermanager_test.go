@@ -16,6 +16,8 @@ import (
 
 	hotypes "github.com/ovn-org/ovn-kubernetes/go-controller/hybrid-overlay/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	clusternetworkapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1"
+	clusternetworkfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1/apis/clientset/versioned/fake"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
 	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
@@ -427,6 +429,96 @@ var _ = ginkgo.Describe("Cluster Manager", func() {
 		})
 	})
 
+	ginkgo.Context("Node subnet expansion", func() {
+		ginkgo.It("holds the deletion of a ClusterNetwork while host subnets are allocated from it", func() {
+			app.Action = func(ctx *cli.Context) error {
+				nodes := []v1.Node{
+					{ObjectMeta: metav1.ObjectMeta{Name: "node1"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "node2"}},
+					{ObjectMeta: metav1.ObjectMeta{Name: "node3"}},
+				}
+				clusterNetwork := &clusternetworkapi.ClusterNetwork{
+					ObjectMeta: metav1.ObjectMeta{Name: "extra"},
+					Spec: clusternetworkapi.ClusterNetworkSpec{
+						ClusterSubnets: []clusternetworkapi.ClusterSubnet{{CIDR: "10.2.0.0/16", HostSubnetLength: 24}},
+					},
+				}
+				fakeClient := &util.OVNClusterManagerClientset{
+					KubeClient:           fake.NewSimpleClientset(&v1.NodeList{Items: nodes}),
+					ClusterNetworkClient: clusternetworkfake.NewSimpleClientset(clusterNetwork),
+				}
+
+				_, err := config.InitConfig(ctx, nil, nil)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				config.Kubernetes.HostNetworkNamespace = ""
+
+				f, err = factory.NewClusterManagerWatchFactory(fakeClient)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				err = f.Start()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				c, cancel := context.WithCancel(ctx.Context)
+				defer cancel()
+				clusterManager, err := NewClusterManager(fakeClient, f, "identity", wg, nil)
+				gomega.Expect(clusterManager).NotTo(gomega.BeNil())
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				err = clusterManager.Start(c)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				defer clusterManager.Stop()
+
+				getFinalizers := func() ([]string, error) {
+					clusterNetwork, err := fakeClient.ClusterNetworkClient.K8sV1().ClusterNetworks().Get(context.TODO(), "extra", metav1.GetOptions{})
+					if err != nil {
+						return nil, err
+					}
+					return clusterNetwork.Finalizers, nil
+				}
+				gomega.Eventually(getFinalizers).Should(gomega.ConsistOf(util.ClusterNetworkFinalizer))
+
+				// the configured cluster subnets fit two nodes, the third one
+				// gets a host subnet from the ClusterNetwork
+				_, extraSubnet, _ := net.ParseCIDR("10.2.0.0/16")
+				var extraNode string
+				gomega.Eventually(func() (string, error) {
+					for _, n := range nodes {
+						updatedNode, err := fakeClient.KubeClient.CoreV1().Nodes().Get(context.TODO(), n.Name, metav1.GetOptions{})
+						if err != nil {
+							return "", err
+						}
+						subnets, err := util.ParseNodeHostSubnetAnnotation(updatedNode, ovntypes.DefaultNetworkName)
+						if err != nil {
+							continue
+						}
+						if len(subnets) == 1 && extraSubnet.Contains(subnets[0].IP) {
+							extraNode = n.Name
+						}
+					}
+					return extraNode, nil
+				}, 2).ShouldNot(gomega.BeEmpty())
+
+				deleting := clusterNetwork.DeepCopy()
+				deleting.Finalizers = []string{util.ClusterNetworkFinalizer}
+				now := metav1.Now()
+				deleting.DeletionTimestamp = &now
+				_, err = fakeClient.ClusterNetworkClient.K8sV1().ClusterNetworks().Update(context.TODO(), deleting, metav1.UpdateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Consistently(getFinalizers).Should(gomega.ConsistOf(util.ClusterNetworkFinalizer))
+
+				err = fakeClient.KubeClient.CoreV1().Nodes().Delete(context.TODO(), extraNode, metav1.DeleteOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(getFinalizers).Should(gomega.BeEmpty())
+				return nil
+			}
+
+			err := app.Run([]string{
+				app.Name,
+				"-cluster-subnets=10.1.0.0/23",
+				"-enable-node-subnet-expansion",
+			})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+	})
+
 	ginkgo.Context("Node Id allocations", func() {
 		ginkgo.It("check for node id allocations", func() {
 			app.Action = func(ctx *cli.Context) error {
@@ -0,0 +1,149 @@
+package clustermanager
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ovn-org/libovsdb/cache"
+	libovsdbclient "github.com/ovn-org/libovsdb/client"
+	"github.com/ovn-org/libovsdb/model"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+)
+
+// egressNodeBFDMonitor follows the status of the BFD sessions configured by
+// ovnkube-controller between the gateway routers of the egress nodes, as
+// found in the northbound database.
+type egressNodeBFDMonitor struct {
+	sync.Mutex
+	nbClient libovsdbclient.Client
+	// established holds the UUIDs of the sessions which have been up since
+	// they were created. The other sessions are still starting and are not
+	// considered down.
+	established sets.Set[string]
+	// status holds the status of the sessions by node and peer node name. A
+	// peer is up if any of the sessions of the node towards it is up.
+	status map[string]map[string]string
+	// statusChanged is signaled when the status of a session changes
+	statusChanged chan struct{}
+}
+
+func newEgressNodeBFDMonitor(nbClient libovsdbclient.Client) *egressNodeBFDMonitor {
+	return &egressNodeBFDMonitor{
+		nbClient:      nbClient,
+		established:   sets.New[string](),
+		status:        map[string]map[string]string{},
+		statusChanged: make(chan struct{}, 1),
+	}
+}
+
+// start follows the status of the sessions until stopChan is closed, calling
+// onStatusChanged after each change
+func (m *egressNodeBFDMonitor) start(stopChan <-chan struct{}, wg *sync.WaitGroup, onStatusChanged func()) {
+	m.nbClient.Cache().AddEventHandler(&cache.EventHandlerFuncs{
+		AddFunc: func(table string, model model.Model) {
+			m.onBFDEvent(table, model, false)
+		},
+		UpdateFunc: func(table string, old, new model.Model) {
+			m.onBFDEvent(table, new, false)
+		},
+		DeleteFunc: func(table string, model model.Model) {
+			m.onBFDEvent(table, model, true)
+		},
+	})
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-m.statusChanged:
+			case <-stopChan:
+				klog.V(5).Infof("Stop channel got triggered: will stop following the egress IP BFD status")
+				return
+			}
+			if err := m.updateStatus(); err != nil {
+				klog.Errorf("Failed to update the status of the egress IP BFD sessions: %v", err)
+				continue
+			}
+			onStatusChanged()
+		}
+	}()
+	m.signalStatusChanged()
+}
+
+func (m *egressNodeBFDMonitor) onBFDEvent(table string, mdl model.Model, deleted bool) {
+	if table != nbdb.BFDTable {
+		return
+	}
+	bfd := mdl.(*nbdb.BFD)
+	if !isEgressIPBFD(bfd) {
+		return
+	}
+	m.Lock()
+	if deleted {
+		m.established.Delete(bfd.UUID)
+	} else if bfd.Status != nil && *bfd.Status == nbdb.BFDStatusUp {
+		m.established.Insert(bfd.UUID)
+	}
+	m.Unlock()
+	m.signalStatusChanged()
+}
+
+func (m *egressNodeBFDMonitor) signalStatusChanged() {
+	select {
+	case m.statusChanged <- struct{}{}:
+	default:
+	}
+}
+
+func isEgressIPBFD(bfd *nbdb.BFD) bool {
+	_, ok := bfd.ExternalIDs[types.EgressIPBFDNodeExternalID]
+	return ok
+}
+
+// updateStatus computes the status of the sessions from the northbound
+// database. Sessions which have not been up since they were created are
+// considered init rather than down, so that egress nodes being added are not
+// considered failed.
+func (m *egressNodeBFDMonitor) updateStatus() error {
+	bfds, err := libovsdbops.FindBFDsWithPredicate(m.nbClient, isEgressIPBFD)
+	if err != nil {
+		return fmt.Errorf("failed to find the egress IP BFD sessions: %w", err)
+	}
+	m.Lock()
+	defer m.Unlock()
+	status := map[string]map[string]string{}
+	for _, bfd := range bfds {
+		nodeName := bfd.ExternalIDs[types.EgressIPBFDNodeExternalID]
+		peerName := bfd.ExternalIDs[types.EgressIPBFDPeerExternalID]
+		sessionStatus := nbdb.BFDStatusInit
+		if bfd.Status != nil {
+			sessionStatus = *bfd.Status
+		}
+		if sessionStatus == nbdb.BFDStatusUp {
+			m.established.Insert(bfd.UUID)
+		} else if sessionStatus == nbdb.BFDStatusDown && !m.established.Has(bfd.UUID) {
+			sessionStatus = nbdb.BFDStatusInit
+		}
+		if status[nodeName] == nil {
+			status[nodeName] = map[string]string{}
+		}
+		if status[nodeName][peerName] != nbdb.BFDStatusUp {
+			status[nodeName][peerName] = sessionStatus
+		}
+	}
+	m.status = status
+	return nil
+}
+
+// getStatus returns the status of the sessions of the node towards the peer
+// node, empty if there are none
+func (m *egressNodeBFDMonitor) getStatus(nodeName, peerName string) string {
+	m.Lock()
+	defer m.Unlock()
+	return m.status[nodeName][peerName]
+}
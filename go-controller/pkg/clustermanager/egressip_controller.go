@@ -15,17 +15,21 @@ import (
 	"time"
 
 	ocpcloudnetworkapi "github.com/openshift/api/cloudnetwork/v1"
+	libovsdbclient "github.com/ovn-org/libovsdb/client"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	egressipv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/healthcheck"
 	objretry "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/retry"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -105,6 +109,9 @@ type egressNode struct {
 	isReachable        bool
 	isEgressAssignable bool
 	name               string
+	// failureDomain is the value of the node label configured with
+	// EgressIPTopologySpreadKey, if any
+	failureDomain string
 }
 
 func (e *egressNode) getAllocationCountForEgressIP(name string) (count int) {
@@ -116,6 +123,25 @@ func (e *egressNode) getAllocationCountForEgressIP(name string) (count int) {
 	return
 }
 
+// getWeight returns the preference weight of the node for egress IP
+// assignment, defaulting to 1
+func (e *egressNode) getWeight() int {
+	if e.egressIPConfig == nil || e.egressIPConfig.Weight < 1 {
+		return 1
+	}
+	return e.egressIPConfig.Weight
+}
+
+// getNodeFailureDomain returns the failure domain of the node across which
+// the egress IPs of an EgressIP are spread, or an empty string if spreading is
+// disabled or the node does not belong to any
+func getNodeFailureDomain(node *v1.Node) string {
+	if config.OVNKubernetesFeature.EgressIPTopologySpreadKey == "" {
+		return ""
+	}
+	return node.Labels[config.OVNKubernetesFeature.EgressIPTopologySpreadKey]
+}
+
 type EgressIPPatchStatus struct {
 	Op    string                    `json:"op"`
 	Path  string                    `json:"path"`
@@ -132,13 +158,17 @@ type EgressIPPatchStatus struct {
 func (eIPC *egressIPClusterController) patchReplaceEgressIPStatus(name string, statusItems []egressipv1.EgressIPStatusItem) error {
 	klog.Infof("Patching status on EgressIP %s: %v", name, statusItems)
 	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		status := egressipv1.EgressIPStatus{
+			Items: statusItems,
+		}
+		if eIP, err := eIPC.watchFactory.GetEgressIP(name); err == nil {
+			status, _ = eIPC.getEgressIPStatus(eIP, statusItems)
+		}
 		t := []EgressIPPatchStatus{
 			{
-				Op:   "replace",
-				Path: "/status",
-				Value: egressipv1.EgressIPStatus{
-					Items: statusItems,
-				},
+				Op:    "replace",
+				Path:  "/status",
+				Value: status,
 			},
 		}
 		op, err := json.Marshal(&t)
@@ -149,6 +179,225 @@ func (eIPC *egressIPClusterController) patchReplaceEgressIPStatus(name string, s
 	})
 }
 
+// newEgressIPStatus returns the status of an EgressIP with the given spec and
+// assigned egress IPs
+func newEgressIPStatus(spec *egressipv1.EgressIPSpec, statusItems []egressipv1.EgressIPStatusItem) egressipv1.EgressIPStatus {
+	return egressipv1.EgressIPStatus{
+		Items:           statusItems,
+		LoadBalancing:   util.GetEgressIPLoadBalancing(spec),
+		ActiveEgressIPs: util.GetActiveEgressIPs(spec, statusItems),
+	}
+}
+
+// getEgressIPStatus returns the status of the EgressIP with the given assigned
+// egress IPs, including the reasons why the other egress IPs of its spec are
+// not assigned and the conditions summarizing them. The second return value is
+// true if the conditions differ from the ones in the current status.
+func (eIPC *egressIPClusterController) getEgressIPStatus(eIP *egressipv1.EgressIP, statusItems []egressipv1.EgressIPStatusItem) (egressipv1.EgressIPStatus, bool) {
+	status := newEgressIPStatus(&eIP.Spec, statusItems)
+	status.UnassignedEgressIPs = eIPC.getUnassignedEgressIPs(eIP.Name, eIP.Spec.EgressIPs, statusItems)
+	var conditionsChanged bool
+	status.Conditions, conditionsChanged = newEgressIPConditions(eIP, status.UnassignedEgressIPs)
+	return status, conditionsChanged
+}
+
+// isEgressIPStatusStale returns true if the status of the EgressIP does not
+// reflect its spec, the given assigned egress IPs and the reasons why the
+// others are not assigned
+func (eIPC *egressIPClusterController) isEgressIPStatusStale(eIP *egressipv1.EgressIP, statusItems []egressipv1.EgressIPStatusItem) bool {
+	expected, conditionsChanged := eIPC.getEgressIPStatus(eIP, statusItems)
+	loadBalancing := eIP.Status.LoadBalancing
+	if loadBalancing == "" {
+		// statuses patched before load balancing modes were introduced
+		loadBalancing = egressipv1.EgressIPLoadBalancingPerConnection
+	}
+	if loadBalancing != expected.LoadBalancing ||
+		!sets.New(eIP.Status.ActiveEgressIPs...).Equal(sets.New(expected.ActiveEgressIPs...)) {
+		return true
+	}
+	if len(eIP.Status.UnassignedEgressIPs) != len(expected.UnassignedEgressIPs) ||
+		(len(expected.UnassignedEgressIPs) > 0 && !reflect.DeepEqual(eIP.Status.UnassignedEgressIPs, expected.UnassignedEgressIPs)) {
+		return true
+	}
+	return conditionsChanged
+}
+
+// setUnassignedEgressIP records the reason why an egress IP of the EgressIP
+// could not be assigned, for it to be reported in its status
+func (eIPC *egressIPClusterController) setUnassignedEgressIP(name, egressIP, reason, message string) {
+	eIPC.unassignedEgressIPsMutex.Lock()
+	defer eIPC.unassignedEgressIPsMutex.Unlock()
+	if _, exists := eIPC.unassignedEgressIPs[name]; !exists {
+		eIPC.unassignedEgressIPs[name] = make(map[string]egressipv1.EgressIPUnassignedStatusItem)
+	}
+	eIPC.unassignedEgressIPs[name][egressIP] = egressipv1.EgressIPUnassignedStatusItem{
+		EgressIP: egressIP,
+		Reason:   reason,
+		Message:  message,
+	}
+}
+
+// clearUnassignedEgressIP forgets the reason why an egress IP of the EgressIP
+// could not be assigned
+func (eIPC *egressIPClusterController) clearUnassignedEgressIP(name, egressIP string) {
+	eIPC.unassignedEgressIPsMutex.Lock()
+	defer eIPC.unassignedEgressIPsMutex.Unlock()
+	delete(eIPC.unassignedEgressIPs[name], egressIP)
+	if len(eIPC.unassignedEgressIPs[name]) == 0 {
+		delete(eIPC.unassignedEgressIPs, name)
+	}
+}
+
+// deleteUnassignedEgressIPs forgets the reasons why the egress IPs of the
+// EgressIP could not be assigned
+func (eIPC *egressIPClusterController) deleteUnassignedEgressIPs(name string) {
+	eIPC.unassignedEgressIPsMutex.Lock()
+	defer eIPC.unassignedEgressIPsMutex.Unlock()
+	delete(eIPC.unassignedEgressIPs, name)
+}
+
+// getUnassignedEgressIPs returns the egress IPs of the spec of the EgressIP
+// which are not assigned, in the order of the spec, along with the reason why
+func (eIPC *egressIPClusterController) getUnassignedEgressIPs(name string, egressIPs []string, statusItems []egressipv1.EgressIPStatusItem) []egressipv1.EgressIPUnassignedStatusItem {
+	assigned := sets.New[string]()
+	for _, statusItem := range statusItems {
+		assigned.Insert(statusItem.EgressIP)
+	}
+	pendingCloudAssignments := sets.New[string]()
+	if util.PlatformTypeIsEgressIPCloudProvider() {
+		eIPC.pendingCloudPrivateIPConfigsMutex.Lock()
+		for egressIP, op := range eIPC.pendingCloudPrivateIPConfigsOps[name] {
+			if op.toAdd != "" {
+				pendingCloudAssignments.Insert(egressIP)
+			}
+		}
+		eIPC.pendingCloudPrivateIPConfigsMutex.Unlock()
+	}
+	eIPC.unassignedEgressIPsMutex.Lock()
+	defer eIPC.unassignedEgressIPsMutex.Unlock()
+	var unassigned []egressipv1.EgressIPUnassignedStatusItem
+	for _, egressIP := range egressIPs {
+		if ip := net.ParseIP(egressIP); ip != nil {
+			egressIP = ip.String()
+		}
+		if assigned.Has(egressIP) {
+			continue
+		}
+		item, exists := eIPC.unassignedEgressIPs[name][egressIP]
+		switch {
+		case exists:
+		case net.ParseIP(egressIP) == nil:
+			item = egressipv1.EgressIPUnassignedStatusItem{
+				Reason:  egressipv1.EgressIPReasonInvalidEgressIP,
+				Message: "not a valid IP address",
+			}
+		case pendingCloudAssignments.Has(egressIP):
+			item = egressipv1.EgressIPUnassignedStatusItem{
+				Reason:  egressipv1.EgressIPReasonCloudAssignmentPending,
+				Message: "waiting for the cloud provider to assign the IP to the node",
+			}
+		default:
+			item = egressipv1.EgressIPUnassignedStatusItem{
+				Reason:  egressipv1.EgressIPReasonAssignmentPending,
+				Message: "waiting to be assigned",
+			}
+		}
+		item.EgressIP = egressIP
+		unassigned = append(unassigned, item)
+	}
+	return unassigned
+}
+
+// newEgressIPConditions returns the conditions of the EgressIP given its
+// unassigned egress IPs, keeping the transition times of the conditions in its
+// current status which did not change. The second return value is true if the
+// conditions differ from the ones in the current status.
+func newEgressIPConditions(eIP *egressipv1.EgressIP, unassigned []egressipv1.EgressIPUnassignedStatusItem) ([]metav1.Condition, bool) {
+	conditions := make([]metav1.Condition, 0, len(eIP.Status.Conditions))
+	for _, condition := range eIP.Status.Conditions {
+		conditions = append(conditions, *condition.DeepCopy())
+	}
+	// unassigned egress IPs by condition type
+	byType := map[string][]egressipv1.EgressIPUnassignedStatusItem{}
+	for _, item := range unassigned {
+		switch item.Reason {
+		case egressipv1.EgressIPReasonNoMatchingNodes:
+			byType[egressipv1.EgressIPConditionNoMatchingNodes] = append(byType[egressipv1.EgressIPConditionNoMatchingNodes], item)
+		case egressipv1.EgressIPReasonAddressConflict, egressipv1.EgressIPReasonInvalidEgressIP:
+			byType[egressipv1.EgressIPConditionAddressConflict] = append(byType[egressipv1.EgressIPConditionAddressConflict], item)
+		case egressipv1.EgressIPReasonCloudAssignmentPending, egressipv1.EgressIPReasonCloudAssignmentFailed:
+			byType[egressipv1.EgressIPConditionCloudAssignmentPending] = append(byType[egressipv1.EgressIPConditionCloudAssignmentPending], item)
+		}
+	}
+	describe := func(items []egressipv1.EgressIPUnassignedStatusItem) string {
+		messages := make([]string, 0, len(items))
+		for _, item := range items {
+			messages = append(messages, fmt.Sprintf("%s: %s", item.EgressIP, item.Message))
+		}
+		return strings.Join(messages, "; ")
+	}
+	newCondition := func(conditionType string, items []egressipv1.EgressIPUnassignedStatusItem, falseReason, falseMessage string) metav1.Condition {
+		if len(items) == 0 {
+			return metav1.Condition{
+				Type:    conditionType,
+				Status:  metav1.ConditionFalse,
+				Reason:  falseReason,
+				Message: falseMessage,
+			}
+		}
+		reason := items[0].Reason
+		for _, item := range items {
+			// failures take precedence over the other reasons
+			if item.Reason == egressipv1.EgressIPReasonCloudAssignmentFailed ||
+				item.Reason == egressipv1.EgressIPReasonInvalidEgressIP {
+				reason = item.Reason
+			}
+		}
+		return metav1.Condition{
+			Type:    conditionType,
+			Status:  metav1.ConditionTrue,
+			Reason:  reason,
+			Message: describe(items),
+		}
+	}
+
+	var assigned metav1.Condition
+	if len(unassigned) == 0 {
+		assigned = metav1.Condition{
+			Type:    egressipv1.EgressIPConditionAssigned,
+			Status:  metav1.ConditionTrue,
+			Reason:  "EgressIPsAssigned",
+			Message: "all the egress IPs are assigned",
+		}
+	} else {
+		assigned = metav1.Condition{
+			Type:   egressipv1.EgressIPConditionAssigned,
+			Status: metav1.ConditionFalse,
+			Reason: "EgressIPsNotAssigned",
+			Message: fmt.Sprintf("%d out of %d egress IPs are not assigned: %s",
+				len(unassigned), len(eIP.Spec.EgressIPs), describe(unassigned)),
+		}
+	}
+	newConditions := []metav1.Condition{
+		assigned,
+		newCondition(egressipv1.EgressIPConditionNoMatchingNodes, byType[egressipv1.EgressIPConditionNoMatchingNodes],
+			"NodesAvailable", "egress nodes are available for all the egress IPs"),
+		newCondition(egressipv1.EgressIPConditionAddressConflict, byType[egressipv1.EgressIPConditionAddressConflict],
+			"NoConflict", "no egress IP conflicts with another address"),
+	}
+	if util.PlatformTypeIsEgressIPCloudProvider() {
+		newConditions = append(newConditions,
+			newCondition(egressipv1.EgressIPConditionCloudAssignmentPending, byType[egressipv1.EgressIPConditionCloudAssignmentPending],
+				"NoPendingAssignment", "no egress IP is waiting for the cloud provider"))
+	}
+	var changed bool
+	for _, condition := range newConditions {
+		condition.ObservedGeneration = eIP.Generation
+		changed = meta.SetStatusCondition(&conditions, condition) || changed
+	}
+	return conditions, changed
+}
+
 func (eIPC *egressIPClusterController) getAllocationTotalCount() float64 {
 	count := 0
 	eIPC.allocator.Lock()
@@ -365,6 +614,12 @@ type egressIPClusterController struct {
 	// - On update: once we finish processing the add - which comes after the
 	// delete.
 	pendingCloudPrivateIPConfigsOps map[string]map[string]*cloudPrivateIPConfigOp
+	// unassignedEgressIPsMutex is used to ensure synchronized access to
+	// unassignedEgressIPs
+	unassignedEgressIPsMutex *sync.Mutex
+	// unassignedEgressIPs is a cache, per EgressIP, of the reason why each
+	// egress IP could not be assigned, which is reported in the EgressIP status
+	unassignedEgressIPs map[string]map[string]egressipv1.EgressIPUnassignedStatusItem
 	// allocator is a cache of egress IP centric data needed to when both route
 	// health-checking and tracking allocations made
 	allocator allocator
@@ -388,6 +643,9 @@ type egressIPClusterController struct {
 	egressIPHandler *factory.Handler
 	// cloudPrivateIPConfig events factory handler
 	cloudPrivateIPConfigHandler *factory.Handler
+	// bfdMonitor follows the status of the BFD sessions between the egress
+	// nodes, nil unless egress IP BFD is enabled
+	bfdMonitor *egressNodeBFDMonitor
 }
 
 func newEgressIPController(ovnClient *util.OVNClusterManagerClientset, wf *factory.WatchFactory, recorder record.EventRecorder) *egressIPClusterController {
@@ -403,6 +661,8 @@ func newEgressIPController(ovnClient *util.OVNClusterManagerClientset, wf *facto
 		egressIPAssignmentMutex:           &sync.Mutex{},
 		pendingCloudPrivateIPConfigsMutex: &sync.Mutex{},
 		pendingCloudPrivateIPConfigsOps:   make(map[string]map[string]*cloudPrivateIPConfigOp),
+		unassignedEgressIPsMutex:          &sync.Mutex{},
+		unassignedEgressIPs:               make(map[string]map[string]egressipv1.EgressIPUnassignedStatusItem),
 		allocator:                         allocator{&sync.Mutex{}, make(map[string]*egressNode)},
 		watchFactory:                      wf,
 		recorder:                          recorder,
@@ -453,6 +713,13 @@ func (eIPC *egressIPClusterController) Start() error {
 			return err
 		}
 	}
+	if config.OVNKubernetesFeature.EnableEgressIPBFD {
+		nbClient, err := libovsdb.NewNBBFDClient(eIPC.stopChan)
+		if err != nil {
+			return fmt.Errorf("failed to initialize libovsdb NB client for egress IP BFD: %w", err)
+		}
+		eIPC.startEgressNodeBFDMonitor(nbClient)
+	}
 	if config.OVNKubernetesFeature.EgressIPReachabiltyTotalTimeout == 0 {
 		klog.V(2).Infof("EgressIP node reachability check disabled")
 	} else if config.OVNKubernetesFeature.EgressIPNodeHealthCheckPort != 0 {
@@ -462,6 +729,14 @@ func (eIPC *egressIPClusterController) Start() error {
 	return nil
 }
 
+// startEgressNodeBFDMonitor follows the status of the BFD sessions between the
+// egress nodes from the northbound database, to remove the failed egress nodes
+// from egress assignment as soon as their sessions go down
+func (eIPC *egressIPClusterController) startEgressNodeBFDMonitor(nbClient libovsdbclient.Client) {
+	eIPC.bfdMonitor = newEgressNodeBFDMonitor(nbClient)
+	eIPC.bfdMonitor.start(eIPC.stopChan, eIPC.wg, eIPC.checkEgressNodesBFD)
+}
+
 // WatchEgressNodes starts the watching of egress assignable nodes and calls
 // back the appropriate handler logic.
 func (eIPC *egressIPClusterController) WatchEgressNodes() (*factory.Handler, error) {
@@ -501,7 +776,7 @@ type egressIPNodeStatus struct {
 }
 
 // getSortedEgressData returns a sorted slice of all egressNodes based on the
-// amount of allocations found in the cache
+// amount of allocations found in the cache and the weight of the nodes
 func (eIPC *egressIPClusterController) getSortedEgressData() ([]*egressNode, map[string]egressIPNodeStatus) {
 	assignableNodes := []*egressNode{}
 	allAllocations := make(map[string]egressIPNodeStatus)
@@ -513,8 +788,15 @@ func (eIPC *egressIPClusterController) getSortedEgressData() ([]*egressNode, map
 			allAllocations[ip] = egressIPNodeStatus{Node: eNode.name, Name: eipName}
 		}
 	}
+	// nodes are sorted by their amount of allocations relative to their
+	// weight, preferring the nodes with the highest weight on equality
 	sort.Slice(assignableNodes, func(i, j int) bool {
-		return len(assignableNodes[i].allocations) < len(assignableNodes[j].allocations)
+		weightI, weightJ := assignableNodes[i].getWeight(), assignableNodes[j].getWeight()
+		loadI, loadJ := len(assignableNodes[i].allocations)*weightJ, len(assignableNodes[j].allocations)*weightI
+		if loadI != loadJ {
+			return loadI < loadJ
+		}
+		return weightI > weightJ
 	})
 	return assignableNodes, allAllocations
 }
@@ -610,7 +892,8 @@ func checkEgressNodesReachabilityIterate(eIPC *egressIPClusterController) {
 	for _, eNode := range eIPC.allocator.cache {
 		if eNode.isEgressAssignable && eNode.isReady {
 			wasReachable := eNode.isReachable
-			isReachable := eIPC.isReachable(eNode.name, eNode.mgmtIPs, eNode.healthClient)
+			isReachable := eIPC.isReachable(eNode.name, eNode.mgmtIPs, eNode.healthClient) &&
+				!eIPC.isEgressNodeBFDDown(eNode.name)
 			if wasReachable && !isReachable {
 				reAddOrDelete[eNode.name] = true
 			} else if !wasReachable && isReachable {
@@ -661,7 +944,8 @@ func (eIPC *egressIPClusterController) isEgressNodeReachable(egressNode *v1.Node
 	eIPC.allocator.Lock()
 	defer eIPC.allocator.Unlock()
 	if eNode, exists := eIPC.allocator.cache[egressNode.Name]; exists {
-		return eNode.isReachable || eIPC.isReachable(eNode.name, eNode.mgmtIPs, eNode.healthClient)
+		return (eNode.isReachable || eIPC.isReachable(eNode.name, eNode.mgmtIPs, eNode.healthClient)) &&
+			!eIPC.isEgressNodeBFDDown(eNode.name)
 	}
 	return false
 }
@@ -826,6 +1110,18 @@ func (eIPC *egressIPClusterController) initEgressIPAllocator(node *v1.Node) (err
 	if err != nil {
 		return fmt.Errorf("failed to get egress IP config for node %s: %w", node.Name, err)
 	}
+	if err := util.ParseNodeEgressIPAssignmentConfig(node, parsedEgressIPConfig); err != nil {
+		// a malformed annotation must not keep the node from hosting egress
+		// IPs, the defaults are used instead
+		klog.Warningf("Using the default egress IP assignment config for node %s: %v", node.Name, err)
+		nodeRef := v1.ObjectReference{
+			Kind: "Node",
+			Name: node.Name,
+			UID:  node.UID,
+		}
+		eIPC.recorder.Eventf(&nodeRef, v1.EventTypeWarning, "InvalidEgressIPAssignmentConfig",
+			"Ignoring the malformed egress IP assignment annotations of node %s: %v", node.Name, err)
+	}
 	nodeSubnets, err := util.ParseNodeHostSubnetAnnotation(node, types.DefaultNetworkName)
 	if err != nil {
 		return fmt.Errorf("failed to parse node %s subnets annotation %v", node.Name, err)
@@ -843,14 +1139,66 @@ func (eIPC *egressIPClusterController) initEgressIPAllocator(node *v1.Node) (err
 			mgmtIPs:        mgmtIPs,
 			allocations:    make(map[string]string),
 			healthClient:   hccAllocator.allocate(node.Name),
+			failureDomain:  getNodeFailureDomain(node),
 		}
 	} else {
 		eNode.egressIPConfig = parsedEgressIPConfig
 		eNode.mgmtIPs = mgmtIPs
+		eNode.failureDomain = getNodeFailureDomain(node)
 	}
 	return nil
 }
 
+// isEgressNodeBFDDown returns true if egress IP BFD is enabled and the BFD
+// sessions of the other egress nodes report the node as down, without any
+// reporting it up: a node that is only unreachable from some of its peers is
+// still used. The allocator lock must be held.
+func (eIPC *egressIPClusterController) isEgressNodeBFDDown(nodeName string) bool {
+	if eIPC.bfdMonitor == nil {
+		return false
+	}
+	isDown := false
+	for _, eNode := range eIPC.allocator.cache {
+		if eNode.name == nodeName || !eNode.isEgressAssignable || !eNode.isReady || !eNode.isReachable {
+			continue
+		}
+		switch eIPC.bfdMonitor.getStatus(eNode.name, nodeName) {
+		case nbdb.BFDStatusUp:
+			return false
+		case nbdb.BFDStatusDown:
+			isDown = true
+		}
+	}
+	return isDown
+}
+
+// checkEgressNodesBFD removes from egress assignment the egress nodes which
+// the BFD sessions of their peers report as down, without waiting for the
+// next reachability check. Nodes are added back by the reachability checks
+// once their BFD sessions are up again.
+func (eIPC *egressIPClusterController) checkEgressNodesBFD() {
+	var toDelete []string
+	eIPC.allocator.Lock()
+	for _, eNode := range eIPC.allocator.cache {
+		if eNode.isEgressAssignable && eNode.isReady && eNode.isReachable && eIPC.isEgressNodeBFDDown(eNode.name) {
+			toDelete = append(toDelete, eNode.name)
+		}
+	}
+	// mark all of them first, so that a node is not reported down by another
+	// failed node
+	for _, nodeName := range toDelete {
+		eIPC.allocator.cache[nodeName].isReachable = false
+	}
+	eIPC.allocator.Unlock()
+	for _, nodeName := range toDelete {
+		metrics.RecordEgressIPUnreachableNode()
+		klog.Warningf("Node: %s is detected as down by BFD, deleting it from egress assignment", nodeName)
+		if err := eIPC.deleteEgressNode(nodeName); err != nil {
+			klog.Errorf("Node: %s is detected as down by BFD, but could not re-assign egress IPs, err: %v", nodeName, err)
+		}
+	}
+}
+
 // deleteAllocatorEgressIPAssignments deletes the allocation as to keep the
 // cache state correct, also see addAllocatorEgressIPAssignments
 func (eIPC *egressIPClusterController) deleteAllocatorEgressIPAssignments(statusAssignments []egressipv1.EgressIPStatusItem) {
@@ -933,8 +1281,18 @@ func (eIPC *egressIPClusterController) reconcileEgressIP(old, new *egressipv1.Eg
 	// addresses, which would break us.
 	validSpecIPs, err := eIPC.validateEgressIPSpec(name, newEIP.Spec.EgressIPs)
 	if err != nil {
+		// Report the invalid egress IPs in the status, the assignments
+		// themselves are left untouched until the spec is fixed.
+		if new != nil && eIPC.isEgressIPStatusStale(new, new.Status.Items) {
+			if err := eIPC.patchReplaceEgressIPStatus(name, new.Status.Items); err != nil {
+				klog.Errorf("Unable to report the invalid spec in the status of EgressIP %s: %v", name, err)
+			}
+		}
 		return fmt.Errorf("invalid EgressIP spec, err: %v", err)
 	}
+	if new == nil {
+		eIPC.deleteUnassignedEgressIPs(name)
+	}
 
 	// Validate the status, on restart it could be the case that what might have
 	// been assigned when ovnkube-master last ran is not a valid assignment
@@ -1019,7 +1377,8 @@ func (eIPC *egressIPClusterController) reconcileEgressIP(old, new *egressipv1.Eg
 		eIPC.addAllocatorEgressIPAssignments(name, statusToKeep)
 		// Update the object only on an ADD/UPDATE. If we are processing a
 		// DELETE, new will be nil and we should not update the object.
-		if len(statusToAdd) > 0 || (len(statusToRemove) > 0 && new != nil) {
+		if len(statusToAdd) > 0 || (len(statusToRemove) > 0 && new != nil) ||
+			(new != nil && eIPC.isEgressIPStatusStale(new, statusToKeep)) {
 			if err := eIPC.patchReplaceEgressIPStatus(name, statusToKeep); err != nil {
 				return err
 			}
@@ -1089,6 +1448,14 @@ func (eIPC *egressIPClusterController) reconcileEgressIP(old, new *egressipv1.Eg
 		if err := eIPC.executeCloudPrivateIPConfigChange(name, statusToAdd, statusToRemove); err != nil {
 			return err
 		}
+		// The assignments are patched once the cloud has processed the
+		// changes, patch the status now only to report the load balancing
+		// mode and the egress IPs which are not assigned.
+		if len(statusToRemove) == 0 && new != nil && eIPC.isEgressIPStatusStale(new, new.Status.Items) {
+			if err := eIPC.patchReplaceEgressIPStatus(name, new.Status.Items); err != nil {
+				return err
+			}
+		}
 	}
 
 	// Record the egress IP allocator count
@@ -1163,12 +1530,17 @@ func (eIPC *egressIPClusterController) getCloudPrivateIPConfigMap(objs []interfa
 // the IP cannot already be assigned and reference by another EgressIP object d)
 // no two egress IPs for the same EgressIP object can be assigned to the same
 // node e) (for public clouds) the amount of egress IPs assigned to one node
-// must respect its assignment capacity. Moreover there is a soft constraint:
-// the assignments need to be balanced across all cluster nodes, so that no node
-// becomes a bottleneck. The balancing is achieved by sorting the nodes in
-// ascending order following their existing amount of allocations, and trying to
+// must respect its assignment capacity, as well as the capacity set by the
+// user on the node f) if EgressIPTopologySpreadKey is configured, no two egress
+// IPs for the same EgressIP object can be assigned to nodes of the same failure
+// domain. Moreover there is a soft constraint: the assignments need to be
+// balanced across all cluster nodes, so that no node becomes a bottleneck. The
+// balancing is achieved by sorting the nodes in ascending order following their
+// existing amount of allocations relative to their weight, and trying to
 // assign the egress IP to the node with the lowest amount of allocations every
-// time, this does not guarantee complete balance, but mostly complete.
+// time, this does not guarantee complete balance, but mostly complete. The
+// reasons why an egress IP could not be assigned to each node are reported in
+// the events emitted for unassigned egress IPs.
 // For Egress IPs that are hosted by secondary host networks, there must be at least
 // one node that hosts the network and exposed via the nodes host-cidrs annotation.
 func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []string) []egressipv1.EgressIPStatusItem {
@@ -1183,9 +1555,14 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 		}
 		eIPC.recorder.Eventf(&eIPRef, v1.EventTypeWarning, "NoMatchingNodeFound", "no assignable nodes for EgressIP: %s, please tag at least one node with label: %s", name, util.GetNodeEgressLabel())
 		klog.Errorf("No assignable nodes found for EgressIP: %s and requested IPs: %v", name, egressIPs)
+		for _, egressIP := range egressIPs {
+			eIPC.setUnassignedEgressIP(name, egressIP, egressipv1.EgressIPReasonNoMatchingNodes,
+				fmt.Sprintf("no assignable nodes, please tag at least one node with label: %s", util.GetNodeEgressLabel()))
+		}
 		return assignments
 	}
 	klog.V(5).Infof("Current assignments are: %+v", existingAllocations)
+	var unassigned []string
 	for _, egressIP := range egressIPs {
 		klog.V(5).Infof("Will attempt assignment for egress IP: %s", egressIP)
 		eIP := net.ParseIP(egressIP)
@@ -1203,6 +1580,8 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 			eIPC.recorder.Eventf(&eIPRef, v1.EventTypeWarning, "EgressIPConflict", "Egress IP %s with IP "+
 				"%v is conflicting with a host (%s) IP address and will not be assigned", name, eIP, conflictedHost)
 			klog.Errorf("Egress IP: %v address is already assigned on an interface on node %s", eIP, conflictedHost)
+			eIPC.setUnassignedEgressIP(name, eIP.String(), egressipv1.EgressIPReasonAddressConflict,
+				fmt.Sprintf("conflicts with a host IP address of node %s", conflictedHost))
 			return assignments
 		}
 		if status, exists := existingAllocations[eIP.String()]; exists {
@@ -1255,6 +1634,8 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 					"IP: %q for EgressIP: %s is already allocated for EgressIP: %s on %s", egressIP, name, status.Name, status.Node,
 				)
 				klog.Errorf("IP: %q for EgressIP: %s is already allocated for EgressIP: %s on %s", egressIP, name, status.Name, status.Node)
+				eIPC.setUnassignedEgressIP(name, eIP.String(), egressipv1.EgressIPReasonAddressConflict,
+					fmt.Sprintf("already allocated for EgressIP %s on node %s", status.Name, status.Node))
 				return assignments
 			}
 		}
@@ -1293,11 +1674,20 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 		}
 
 		var assignmentSuccessful bool
+		var reasons []string
 		for i := 0; i < len(assignableNodes) && !assignmentSuccessful; i++ {
 			eNode := assignableNodes[i]
 			klog.V(5).Infof("Attempting assignment on egress node: %+v", eNode)
 			if eNode.getAllocationCountForEgressIP(name) > 0 {
 				klog.V(5).Infof("Node: %s is already in use by another egress IP for this EgressIP: %s, trying another node", eNode.name, name)
+				reasons = append(reasons, fmt.Sprintf("%s: already hosts another egress IP of the EgressIP", eNode.name))
+				continue
+			}
+			if eIPC.isFailureDomainInUse(name, eNode.failureDomain) {
+				klog.V(5).Infof("Failure domain: %s of node: %s is already in use by another egress IP for this EgressIP: %s, trying another node",
+					eNode.failureDomain, eNode.name, name)
+				reasons = append(reasons, fmt.Sprintf("%s: failure domain %s already hosts another egress IP of the EgressIP",
+					eNode.name, eNode.failureDomain))
 				continue
 			}
 			node, err := eIPC.watchFactory.GetNode(eNode.name)
@@ -1312,23 +1702,27 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 				continue
 			}
 			if egressIPNetwork == "" {
+				reasons = append(reasons, fmt.Sprintf("%s: no network can host the IP", eNode.name))
 				continue
 			}
 			if eNode.egressIPConfig.Capacity.IP < util.UnlimitedNodeCapacity {
 				if eNode.egressIPConfig.Capacity.IP-len(eNode.allocations) <= 0 {
 					klog.V(5).Infof("Additional allocation on Node: %s exhausts it's IP capacity, trying another node", eNode.name)
+					reasons = append(reasons, fmt.Sprintf("%s: capacity of %d egress IPs reached", eNode.name, eNode.egressIPConfig.Capacity.IP))
 					continue
 				}
 			}
 			if eNode.egressIPConfig.Capacity.IPv4 < util.UnlimitedNodeCapacity && utilnet.IsIPv4(eIP) {
 				if eNode.egressIPConfig.Capacity.IPv4-getIPFamilyAllocationCount(eNode.allocations, false) <= 0 {
 					klog.V(5).Infof("Additional allocation on Node: %s exhausts it's IPv4 capacity, trying another node", eNode.name)
+					reasons = append(reasons, fmt.Sprintf("%s: capacity of %d IPv4 egress IPs reached", eNode.name, eNode.egressIPConfig.Capacity.IPv4))
 					continue
 				}
 			}
 			if eNode.egressIPConfig.Capacity.IPv6 < util.UnlimitedNodeCapacity && utilnet.IsIPv6(eIP) {
 				if eNode.egressIPConfig.Capacity.IPv6-getIPFamilyAllocationCount(eNode.allocations, true) <= 0 {
 					klog.V(5).Infof("Additional allocation on Node: %s exhausts it's IPv6 capacity, trying another node", eNode.name)
+					reasons = append(reasons, fmt.Sprintf("%s: capacity of %d IPv6 egress IPs reached", eNode.name, eNode.egressIPConfig.Capacity.IPv6))
 					continue
 				}
 			}
@@ -1337,18 +1731,27 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 				EgressIP: eIP.String(),
 			})
 			eNode.allocations[eIP.String()] = name
+			eIPC.clearUnassignedEgressIP(name, eIP.String())
 			assignmentSuccessful = true
 			klog.Infof("Successful assignment of egress IP: %s to network %s on node: %+v", egressIP, egressIPNetwork, eNode)
 			break
 		}
+		if !assignmentSuccessful {
+			if len(reasons) == 0 {
+				reasons = append(reasons, "no assignable node")
+			}
+			unassigned = append(unassigned, fmt.Sprintf("%s (%s)", eIP.String(), strings.Join(reasons, "; ")))
+			eIPC.setUnassignedEgressIP(name, eIP.String(), egressipv1.EgressIPReasonNoMatchingNodes, strings.Join(reasons, "; "))
+		}
 	}
 	if len(assignments) == 0 {
 		eIPRef := v1.ObjectReference{
 			Kind: "EgressIP",
 			Name: name,
 		}
-		eIPC.recorder.Eventf(&eIPRef, v1.EventTypeWarning, "NoMatchingNodeFound", "No matching nodes found, which can host any of the egress IPs: %v for object EgressIP: %s", egressIPs, name)
-		klog.Errorf("No matching host found for EgressIP: %s", name)
+		eIPC.recorder.Eventf(&eIPRef, v1.EventTypeWarning, "NoMatchingNodeFound", "No matching nodes found, which can host any of the egress IPs: %v for object EgressIP: %s, unassigned egress IPs: %s",
+			egressIPs, name, strings.Join(unassigned, ", "))
+		klog.Errorf("No matching host found for EgressIP: %s, unassigned egress IPs: %s", name, strings.Join(unassigned, ", "))
 		return assignments
 	}
 	if len(assignments) < len(egressIPs) {
@@ -1356,11 +1759,27 @@ func (eIPC *egressIPClusterController) assignEgressIPs(name string, egressIPs []
 			Kind: "EgressIP",
 			Name: name,
 		}
-		eIPC.recorder.Eventf(&eIPRef, v1.EventTypeWarning, "UnassignedRequest", "Not all egress IPs for EgressIP: %s could be assigned, please tag more nodes", name)
+		eIPC.recorder.Eventf(&eIPRef, v1.EventTypeWarning, "UnassignedRequest", "Not all egress IPs for EgressIP: %s could be assigned, please tag more nodes, unassigned egress IPs: %s",
+			name, strings.Join(unassigned, ", "))
 	}
 	return assignments
 }
 
+// isFailureDomainInUse returns true if an egress IP of the EgressIP is
+// assigned to a node of the given failure domain. The allocator lock must be
+// held.
+func (eIPC *egressIPClusterController) isFailureDomainInUse(name, failureDomain string) bool {
+	if failureDomain == "" {
+		return false
+	}
+	for _, eNode := range eIPC.allocator.cache {
+		if eNode.failureDomain == failureDomain && eNode.getAllocationCountForEgressIP(name) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 func getIPFamilyAllocationCount(allocations map[string]string, isIPv6 bool) (count int) {
 	for allocation := range allocations {
 		if utilnet.IsIPv4String(allocation) && !isIPv6 {
@@ -1383,6 +1802,7 @@ func (eIPC *egressIPClusterController) validateEgressIPSpec(name string, egressI
 				Name: name,
 			}
 			eIPC.recorder.Eventf(&eIPRef, v1.EventTypeWarning, "InvalidEgressIP", "egress IP: %s for object EgressIP: %s is not a valid IP address", egressIP, name)
+			eIPC.setUnassignedEgressIP(name, egressIP, egressipv1.EgressIPReasonInvalidEgressIP, "not a valid IP address")
 			return nil, fmt.Errorf("unable to parse provided EgressIP: %s, invalid", egressIP)
 		}
 		validatedEgressIPs.Insert(ip.String())
@@ -1602,6 +2022,7 @@ func (eIPC *egressIPClusterController) reconcileCloudPrivateIPConfig(old, new *o
 				break
 			}
 		}
+		eIPC.clearUnassignedEgressIP(egressIPName, egressIPString)
 		if !hasStatus {
 			statusToKeep := append(egressIP.Status.Items, statusItem)
 			if err := eIPC.patchReplaceEgressIPStatus(egressIP.Name, statusToKeep); err != nil {
@@ -1639,9 +2060,51 @@ func (eIPC *egressIPClusterController) reconcileCloudPrivateIPConfig(old, new *o
 			delete(eIPC.pendingCloudPrivateIPConfigsOps, egressIPName)
 		}
 	}
+	if new != nil && !shouldAdd && newCloudPrivateIPConfig.Spec.Node != "" &&
+		newCloudPrivateIPConfig.GetDeletionTimestamp().IsZero() &&
+		ocpcloudnetworkapi.CloudPrivateIPConfigConditionType(newCloudPrivateIPConfig.Status.Conditions[0].Type) == ocpcloudnetworkapi.Assigned &&
+		v1.ConditionStatus(newCloudPrivateIPConfig.Status.Conditions[0].Status) != v1.ConditionTrue {
+		return eIPC.reportCloudPrivateIPConfigAssignment(newCloudPrivateIPConfig)
+	}
 	return nil
 }
 
+// reportCloudPrivateIPConfigAssignment reports, in the status of the EgressIP
+// owning the CloudPrivateIPConfig, that the cloud assignment of its egress IP
+// is still pending or has failed
+func (eIPC *egressIPClusterController) reportCloudPrivateIPConfigAssignment(cloudPrivateIPConfig *ocpcloudnetworkapi.CloudPrivateIPConfig) error {
+	egressIPName, exists := cloudPrivateIPConfig.Annotations[util.OVNEgressIPOwnerRefLabel]
+	if !exists {
+		return nil
+	}
+	egressIP, err := eIPC.kube.GetEgressIP(egressIPName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	egressIPString := cloudPrivateIPConfigNameToIPString(cloudPrivateIPConfig.Name)
+	condition := cloudPrivateIPConfig.Status.Conditions[0]
+	if v1.ConditionStatus(condition.Status) == v1.ConditionFalse {
+		eIPRef := v1.ObjectReference{
+			Kind: "EgressIP",
+			Name: egressIPName,
+		}
+		eIPC.recorder.Eventf(&eIPRef, v1.EventTypeWarning, "CloudAssignmentFailed", "egress IP: %s for object EgressIP: %s could not be assigned to node %s by the cloud provider, reason: %s, message: %s",
+			egressIPString, egressIPName, cloudPrivateIPConfig.Spec.Node, condition.Reason, condition.Message)
+		eIPC.setUnassignedEgressIP(egressIPName, egressIPString, egressipv1.EgressIPReasonCloudAssignmentFailed,
+			fmt.Sprintf("the cloud provider failed to assign the IP to node %s: %s", cloudPrivateIPConfig.Spec.Node, condition.Message))
+	} else {
+		eIPC.setUnassignedEgressIP(egressIPName, egressIPString, egressipv1.EgressIPReasonCloudAssignmentPending,
+			fmt.Sprintf("waiting for the cloud provider to assign the IP to node %s", cloudPrivateIPConfig.Spec.Node))
+	}
+	if !eIPC.isEgressIPStatusStale(egressIP, egressIP.Status.Items) {
+		return nil
+	}
+	return eIPC.patchReplaceEgressIPStatus(egressIP.Name, egressIP.Status.Items)
+}
+
 // cloudPrivateIPConfigNameToIPString converts the resource name to the string
 // representation of net.IP. Given a limitation in the Kubernetes API server
 // (see: https://github.com/kubernetes/kubernetes/pull/100950)
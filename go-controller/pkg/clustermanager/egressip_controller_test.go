@@ -14,10 +14,15 @@ import (
 	ocpconfigapi "github.com/openshift/api/config/v1"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	egressipv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/healthcheck"
+	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	"github.com/urfave/cli/v2"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -1666,6 +1671,509 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 		})
 	})
 
+	newEgressNode := func(name, ipv4 string, labels, annotations map[string]string) v1.Node {
+		nodeLabels := map[string]string{
+			"k8s.ovn.org/egress-assignable": "",
+		}
+		for k, v := range labels {
+			nodeLabels[k] = v
+		}
+		nodeAnnotations := map[string]string{
+			"k8s.ovn.org/node-primary-ifaddr": fmt.Sprintf("{\"ipv4\": \"%s\", \"ipv6\": \"%s\"}", ipv4, ""),
+			"k8s.ovn.org/node-subnets":        fmt.Sprintf("{\"default\":[\"%s\", \"%s\"]}", v4NodeSubnet, v6NodeSubnet),
+			util.OVNNodeHostCIDRs:             fmt.Sprintf("[\"%s\"]", ipv4),
+		}
+		for k, v := range annotations {
+			nodeAnnotations[k] = v
+		}
+		return v1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Annotations: nodeAnnotations,
+				Labels:      nodeLabels,
+			},
+			Status: v1.NodeStatus{
+				Conditions: []v1.NodeCondition{
+					{
+						Type:   v1.NodeReady,
+						Status: v1.ConditionTrue,
+					},
+				},
+			},
+		}
+	}
+
+	ginkgo.Context("Weighted and capacity-aware assignment", func() {
+
+		// initEgressNodes initializes the allocator cache from the node
+		// objects, as the egress node handler does, marking the nodes
+		// assignable, ready and reachable
+		initEgressNodes := func(nodes ...v1.Node) {
+			for i := range nodes {
+				err := fakeClusterManagerOVN.eIPC.initEgressIPAllocator(&nodes[i])
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				eNode := fakeClusterManagerOVN.eIPC.allocator.cache[nodes[i].Name]
+				eNode.isEgressAssignable = true
+				eNode.isReady = true
+				eNode.isReachable = true
+			}
+		}
+
+		ginkgo.It("should prefer nodes with a higher weight", func() {
+			app.Action = func(ctx *cli.Context) error {
+				egressIP := "192.168.126.101"
+
+				node1 := newEgressNode(node1Name, "192.168.126.12/24", nil, map[string]string{"k8s.ovn.org/egress-ip-weight": "3"})
+				node2 := newEgressNode(node2Name, "192.168.126.51/24", nil, nil)
+				fakeClusterManagerOVN.start(&v1.NodeList{Items: []v1.Node{node1, node2}})
+				initEgressNodes(node1, node2)
+
+				// node1 hosts twice as many egress IPs as node2, but with three
+				// times its weight it is still the least loaded one
+				fakeClusterManagerOVN.eIPC.allocator.cache[node1Name].allocations = map[string]string{"192.168.126.68": "bogus1", "192.168.126.69": "bogus2"}
+				fakeClusterManagerOVN.eIPC.allocator.cache[node2Name].allocations = map[string]string{"192.168.126.70": "bogus3"}
+
+				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(egressIPName, []string{egressIP})
+				gomega.Expect(assignedStatuses).To(gomega.HaveLen(1))
+				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(node1Name))
+				gomega.Expect(assignedStatuses[0].EgressIP).To(gomega.Equal(egressIP))
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("should not assign more egress IPs than the capacity of a node and explain why", func() {
+			app.Action = func(ctx *cli.Context) error {
+				egressIP1 := "192.168.126.101"
+				egressIP2 := "192.168.126.102"
+
+				node1 := newEgressNode(node1Name, "192.168.126.12/24", nil, map[string]string{"k8s.ovn.org/egress-ip-capacity": "1"})
+				node2 := newEgressNode(node2Name, "192.168.126.51/24", nil, map[string]string{"k8s.ovn.org/egress-ip-capacity": "0"})
+				fakeClusterManagerOVN.start(&v1.NodeList{Items: []v1.Node{node1, node2}})
+				initEgressNodes(node1, node2)
+
+				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(egressIPName, []string{egressIP1})
+				gomega.Expect(assignedStatuses).To(gomega.HaveLen(1))
+				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(node1Name))
+
+				assignedStatuses = fakeClusterManagerOVN.eIPC.assignEgressIPs(egressIPName2, []string{egressIP2})
+				gomega.Expect(assignedStatuses).To(gomega.BeEmpty())
+				recordedEvent := <-fakeClusterManagerOVN.fakeRecorder.Events
+				gomega.Expect(recordedEvent).To(gomega.ContainSubstring("NoMatchingNodeFound"))
+				gomega.Expect(recordedEvent).To(gomega.ContainSubstring("%s: capacity of 1 egress IPs reached", node1Name))
+				gomega.Expect(recordedEvent).To(gomega.ContainSubstring("%s: capacity of 0 egress IPs reached", node2Name))
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("should fall back to the defaults for malformed capacity and weight annotations", func() {
+			app.Action = func(ctx *cli.Context) error {
+				egressIP := "192.168.126.101"
+
+				node1 := newEgressNode(node1Name, "192.168.126.12/24", nil,
+					map[string]string{"k8s.ovn.org/egress-ip-capacity": "none", "k8s.ovn.org/egress-ip-weight": "-2"})
+				fakeClusterManagerOVN.start(&v1.NodeList{Items: []v1.Node{node1}})
+				initEgressNodes(node1)
+				recordedEvent := <-fakeClusterManagerOVN.fakeRecorder.Events
+				gomega.Expect(recordedEvent).To(gomega.ContainSubstring("InvalidEgressIPAssignmentConfig"))
+				gomega.Expect(recordedEvent).To(gomega.ContainSubstring("k8s.ovn.org/egress-ip-capacity"))
+				gomega.Expect(recordedEvent).To(gomega.ContainSubstring("k8s.ovn.org/egress-ip-weight"))
+
+				eNode := fakeClusterManagerOVN.eIPC.allocator.cache[node1Name]
+				gomega.Expect(eNode.egressIPConfig.Capacity.IP).To(gomega.Equal(util.UnlimitedNodeCapacity))
+				gomega.Expect(eNode.getWeight()).To(gomega.Equal(1))
+
+				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(egressIPName, []string{egressIP})
+				gomega.Expect(assignedStatuses).To(gomega.HaveLen(1))
+				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(node1Name))
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("should spread the egress IPs of an EgressIP across failure domains", func() {
+			app.Action = func(ctx *cli.Context) error {
+				config.OVNKubernetesFeature.EgressIPTopologySpreadKey = "topology.kubernetes.io/zone"
+				egressIP1 := "192.168.126.101"
+				egressIP2 := "192.168.126.102"
+
+				node1 := newEgressNode(node1Name, "192.168.126.12/24", map[string]string{"topology.kubernetes.io/zone": "zone-a"}, nil)
+				node2 := newEgressNode(node2Name, "192.168.126.51/24", map[string]string{"topology.kubernetes.io/zone": "zone-a"}, nil)
+				fakeClusterManagerOVN.start(&v1.NodeList{Items: []v1.Node{node1, node2}})
+				initEgressNodes(node1, node2)
+
+				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(egressIPName, []string{egressIP1, egressIP2})
+				gomega.Expect(assignedStatuses).To(gomega.HaveLen(1))
+				recordedEvent := <-fakeClusterManagerOVN.fakeRecorder.Events
+				gomega.Expect(recordedEvent).To(gomega.ContainSubstring("Not all egress IPs for EgressIP: %s could be assigned", egressIPName))
+				gomega.Expect(recordedEvent).To(gomega.ContainSubstring("failure domain zone-a already hosts another egress IP of the EgressIP"))
+
+				// a node of another failure domain can host the second egress IP
+				node3 := newEgressNode("node3", "192.168.126.60/24", map[string]string{"topology.kubernetes.io/zone": "zone-b"}, nil)
+				_, err := fakeClusterManagerOVN.fakeClient.KubeClient.CoreV1().Nodes().Create(context.TODO(), &node3, metav1.CreateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(func() error {
+					_, err := fakeClusterManagerOVN.watcher.GetNode(node3.Name)
+					return err
+				}).Should(gomega.Succeed())
+				initEgressNodes(node3)
+				assignedStatuses = fakeClusterManagerOVN.eIPC.assignEgressIPs(egressIPName, []string{egressIP1, egressIP2})
+				gomega.Expect(assignedStatuses).To(gomega.HaveLen(2))
+				gomega.Expect(assignedStatuses[1].Node).To(gomega.Equal(node3.Name))
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+	})
+
+	ginkgo.Context("BFD-based failure detection", func() {
+
+		ginkgo.It("should re-assign the egress IPs of a node reported down by the BFD sessions of its peers", func() {
+			app.Action = func(ctx *cli.Context) error {
+				config.OVNKubernetesFeature.EnableEgressIPBFD = true
+				egressIP := "192.168.126.101"
+				node3Name := "node3"
+
+				node1 := newEgressNode(node1Name, "192.168.126.12/24", nil, nil)
+				node2 := newEgressNode(node2Name, "192.168.126.51/24", nil, nil)
+				node3 := newEgressNode(node3Name, "192.168.126.60/24", nil, nil)
+				eIP := egressipv1.EgressIP{
+					ObjectMeta: newEgressIPMeta(egressIPName),
+					Spec: egressipv1.EgressIPSpec{
+						EgressIPs: []string{egressIP},
+					},
+					Status: egressipv1.EgressIPStatus{
+						Items: []egressipv1.EgressIPStatusItem{
+							{
+								Node:     node1Name,
+								EgressIP: egressIP,
+							},
+						},
+					},
+				}
+				// the sessions of the gateway routers of node2 and node3
+				// towards node1, as configured by ovnkube-controller
+				bfdSession := func(nodeName, peerName, peerIP string) *nbdb.BFD {
+					status := nbdb.BFDStatusUp
+					return &nbdb.BFD{
+						UUID:        "bfd-" + nodeName + "-" + peerName + "-UUID",
+						LogicalPort: types.GWRouterToExtSwitchPrefix + types.GWRouterPrefix + nodeName,
+						DstIP:       peerIP,
+						Status:      &status,
+						ExternalIDs: map[string]string{
+							types.EgressIPBFDNodeExternalID: nodeName,
+							types.EgressIPBFDPeerExternalID: peerName,
+						},
+					}
+				}
+				nbClient, cleanup, err := libovsdbtest.NewNBTestHarness(libovsdbtest.TestSetup{
+					NBData: []libovsdbtest.TestData{
+						bfdSession(node2Name, node1Name, "192.168.126.12"),
+						bfdSession(node3Name, node1Name, "192.168.126.12"),
+					},
+				}, nil)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				defer cleanup.Cleanup()
+
+				fakeClusterManagerOVN.start(
+					&egressipv1.EgressIPList{Items: []egressipv1.EgressIP{eIP}},
+					&v1.NodeList{Items: []v1.Node{node1, node2, node3}},
+				)
+				_, err = fakeClusterManagerOVN.eIPC.WatchEgressNodes()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				_, err = fakeClusterManagerOVN.eIPC.WatchEgressIP()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(getEgressIPAllocatorSizeSafely).Should(gomega.Equal(3))
+				gomega.Eventually(getEgressIPStatusLen(egressIPName)).Should(gomega.Equal(1))
+				fakeClusterManagerOVN.eIPC.startEgressNodeBFDMonitor(nbClient)
+				bfdMonitor := fakeClusterManagerOVN.eIPC.bfdMonitor
+
+				setBFDStatus := func(nodeName, status string) {
+					bfds, err := libovsdbops.FindBFDsWithPredicate(nbClient, func(item *nbdb.BFD) bool {
+						return item.ExternalIDs[types.EgressIPBFDNodeExternalID] == nodeName
+					})
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					gomega.Expect(bfds).To(gomega.HaveLen(1))
+					bfds[0].Status = &status
+					ops, err := nbClient.Where(bfds[0]).Update(bfds[0], &bfds[0].Status)
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					_, err = libovsdbops.TransactAndCheck(nbClient, ops)
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				}
+				gomega.Eventually(func() string {
+					return bfdMonitor.getStatus(node2Name, node1Name)
+				}).Should(gomega.Equal(nbdb.BFDStatusUp))
+
+				// node1 is still up from node3
+				setBFDStatus(node2Name, nbdb.BFDStatusDown)
+				gomega.Eventually(func() string {
+					return bfdMonitor.getStatus(node2Name, node1Name)
+				}).Should(gomega.Equal(nbdb.BFDStatusDown))
+				gomega.Consistently(func() []string {
+					_, nodes := getEgressIPStatus(egressIPName)
+					return nodes
+				}).Should(gomega.Equal([]string{node1Name}))
+				gomega.Expect(getEgressIPAllocatorReachableSafely(node1Name)).To(gomega.BeTrue())
+
+				// node1 is down from all its peers
+				setBFDStatus(node3Name, nbdb.BFDStatusDown)
+				gomega.Eventually(func() []string {
+					_, nodes := getEgressIPStatus(egressIPName)
+					return nodes
+				}).Should(gomega.ConsistOf(gomega.BeElementOf(node2Name, node3Name)))
+				gomega.Expect(getEgressIPAllocatorReachableSafely(node1Name)).To(gomega.BeFalse())
+
+				// the reachability checks do not use node1 until its BFD
+				// sessions are up again
+				checkEgressNodesReachabilityIterate(fakeClusterManagerOVN.eIPC)
+				gomega.Expect(getEgressIPAllocatorReachableSafely(node1Name)).To(gomega.BeFalse())
+				setBFDStatus(node2Name, nbdb.BFDStatusUp)
+				gomega.Eventually(func() string {
+					return bfdMonitor.getStatus(node2Name, node1Name)
+				}).Should(gomega.Equal(nbdb.BFDStatusUp))
+				checkEgressNodesReachabilityIterate(fakeClusterManagerOVN.eIPC)
+				gomega.Expect(getEgressIPAllocatorReachableSafely(node1Name)).To(gomega.BeTrue())
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("should not report down the sessions which were never up", func() {
+			app.Action = func(ctx *cli.Context) error {
+				status := nbdb.BFDStatusDown
+				nbClient, cleanup, err := libovsdbtest.NewNBTestHarness(libovsdbtest.TestSetup{
+					NBData: []libovsdbtest.TestData{
+						&nbdb.BFD{
+							UUID:        "bfd-UUID",
+							LogicalPort: types.GWRouterToExtSwitchPrefix + types.GWRouterPrefix + node2Name,
+							DstIP:       "192.168.126.12",
+							Status:      &status,
+							ExternalIDs: map[string]string{
+								types.EgressIPBFDNodeExternalID: node2Name,
+								types.EgressIPBFDPeerExternalID: node1Name,
+							},
+						},
+					},
+				}, nil)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				defer cleanup.Cleanup()
+				fakeClusterManagerOVN.start()
+
+				bfdMonitor := newEgressNodeBFDMonitor(nbClient)
+				gomega.Expect(bfdMonitor.updateStatus()).To(gomega.Succeed())
+				gomega.Expect(bfdMonitor.getStatus(node2Name, node1Name)).To(gomega.Equal(nbdb.BFDStatusInit))
+				gomega.Expect(bfdMonitor.getStatus(node2Name, "node3")).To(gomega.BeEmpty())
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+	})
+
+	ginkgo.Context("Status conditions", func() {
+
+		getEgressIPObject := func(name string) *egressipv1.EgressIP {
+			eIP, err := fakeClusterManagerOVN.fakeClient.EgressIPClient.K8sV1().EgressIPs().Get(context.TODO(), name, metav1.GetOptions{})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			return eIP
+		}
+
+		getConditionStatus := func(name, conditionType string) func() metav1.ConditionStatus {
+			return func() metav1.ConditionStatus {
+				condition := meta.FindStatusCondition(getEgressIPObject(name).Status.Conditions, conditionType)
+				if condition == nil {
+					return ""
+				}
+				return condition.Status
+			}
+		}
+
+		ginkgo.It("should report why egress IPs are not assigned", func() {
+			app.Action = func(ctx *cli.Context) error {
+				egressIP1 := "192.168.126.101"
+				egressIP2 := "192.168.126.102"
+
+				node1 := newEgressNode(node1Name, "192.168.126.12/24", nil, nil)
+				eIP := egressipv1.EgressIP{
+					ObjectMeta: newEgressIPMeta(egressIPName),
+					Spec: egressipv1.EgressIPSpec{
+						EgressIPs: []string{egressIP1, egressIP2},
+					},
+				}
+				fakeClusterManagerOVN.start(
+					&egressipv1.EgressIPList{Items: []egressipv1.EgressIP{eIP}},
+					&v1.NodeList{Items: []v1.Node{node1}},
+				)
+				_, err := fakeClusterManagerOVN.eIPC.WatchEgressNodes()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				_, err = fakeClusterManagerOVN.eIPC.WatchEgressIP()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				// a single node can only host one of the egress IPs
+				gomega.Eventually(getEgressIPStatusLen(egressIPName)).Should(gomega.Equal(1))
+				gomega.Eventually(getConditionStatus(egressIPName, egressipv1.EgressIPConditionAssigned)).Should(gomega.Equal(metav1.ConditionFalse))
+				status := getEgressIPObject(egressIPName).Status
+				gomega.Expect(status.UnassignedEgressIPs).To(gomega.HaveLen(1))
+				gomega.Expect(status.UnassignedEgressIPs[0].EgressIP).To(gomega.BeElementOf(egressIP1, egressIP2))
+				gomega.Expect(status.UnassignedEgressIPs[0].EgressIP).NotTo(gomega.Equal(status.Items[0].EgressIP))
+				gomega.Expect(status.UnassignedEgressIPs[0].Reason).To(gomega.Equal(egressipv1.EgressIPReasonNoMatchingNodes))
+				gomega.Expect(status.UnassignedEgressIPs[0].Message).To(gomega.ContainSubstring("%s: already hosts another egress IP of the EgressIP", node1Name))
+				gomega.Expect(getConditionStatus(egressIPName, egressipv1.EgressIPConditionNoMatchingNodes)()).To(gomega.Equal(metav1.ConditionTrue))
+				gomega.Expect(getConditionStatus(egressIPName, egressipv1.EgressIPConditionAddressConflict)()).To(gomega.Equal(metav1.ConditionFalse))
+				gomega.Expect(meta.FindStatusCondition(status.Conditions, egressipv1.EgressIPConditionCloudAssignmentPending)).To(gomega.BeNil())
+
+				// a second node can host the other egress IP
+				node2 := newEgressNode(node2Name, "192.168.126.51/24", nil, nil)
+				_, err = fakeClusterManagerOVN.fakeClient.KubeClient.CoreV1().Nodes().Create(context.TODO(), &node2, metav1.CreateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(getEgressIPStatusLen(egressIPName)).Should(gomega.Equal(2))
+				gomega.Eventually(getConditionStatus(egressIPName, egressipv1.EgressIPConditionAssigned)).Should(gomega.Equal(metav1.ConditionTrue))
+				gomega.Expect(getEgressIPObject(egressIPName).Status.UnassignedEgressIPs).To(gomega.BeEmpty())
+				gomega.Expect(getConditionStatus(egressIPName, egressipv1.EgressIPConditionNoMatchingNodes)()).To(gomega.Equal(metav1.ConditionFalse))
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("should report egress IPs conflicting with the egress IP of another EgressIP", func() {
+			app.Action = func(ctx *cli.Context) error {
+				egressIP := "192.168.126.101"
+
+				node1 := newEgressNode(node1Name, "192.168.126.12/24", nil, nil)
+				node2 := newEgressNode(node2Name, "192.168.126.51/24", nil, nil)
+				eIP1 := egressipv1.EgressIP{
+					ObjectMeta: newEgressIPMeta(egressIPName),
+					Spec: egressipv1.EgressIPSpec{
+						EgressIPs: []string{egressIP},
+					},
+					Status: egressipv1.EgressIPStatus{
+						Items: []egressipv1.EgressIPStatusItem{
+							{
+								Node:     node1Name,
+								EgressIP: egressIP,
+							},
+						},
+					},
+				}
+				eIP2 := egressipv1.EgressIP{
+					ObjectMeta: newEgressIPMeta(egressIPName2),
+					Spec: egressipv1.EgressIPSpec{
+						EgressIPs: []string{egressIP},
+					},
+				}
+				fakeClusterManagerOVN.start(
+					&egressipv1.EgressIPList{Items: []egressipv1.EgressIP{eIP1}},
+					&v1.NodeList{Items: []v1.Node{node1, node2}},
+				)
+				_, err := fakeClusterManagerOVN.eIPC.WatchEgressNodes()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				_, err = fakeClusterManagerOVN.eIPC.WatchEgressIP()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(getConditionStatus(egressIPName, egressipv1.EgressIPConditionAssigned)).Should(gomega.Equal(metav1.ConditionTrue))
+
+				_, err = fakeClusterManagerOVN.fakeClient.EgressIPClient.K8sV1().EgressIPs().Create(context.TODO(), &eIP2, metav1.CreateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(getConditionStatus(egressIPName2, egressipv1.EgressIPConditionAddressConflict)).Should(gomega.Equal(metav1.ConditionTrue))
+				status := getEgressIPObject(egressIPName2).Status
+				gomega.Expect(status.Items).To(gomega.BeEmpty())
+				gomega.Expect(status.UnassignedEgressIPs).To(gomega.Equal([]egressipv1.EgressIPUnassignedStatusItem{
+					{
+						EgressIP: egressIP,
+						Reason:   egressipv1.EgressIPReasonAddressConflict,
+						Message:  fmt.Sprintf("already allocated for EgressIP %s on node %s", egressIPName, node1Name),
+					},
+				}))
+				gomega.Expect(getConditionStatus(egressIPName2, egressipv1.EgressIPConditionAssigned)()).To(gomega.Equal(metav1.ConditionFalse))
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
+		ginkgo.It("should report pending and failed cloud assignments", func() {
+			app.Action = func(ctx *cli.Context) error {
+				config.Kubernetes.PlatformType = string(ocpconfigapi.AWSPlatformType)
+				egressIP := "192.168.126.101"
+
+				node1 := newEgressNode(node1Name, "192.168.126.12/24", nil, nil)
+				eIP := egressipv1.EgressIP{
+					ObjectMeta: newEgressIPMeta(egressIPName),
+					Spec: egressipv1.EgressIPSpec{
+						EgressIPs: []string{egressIP},
+					},
+				}
+				fakeClusterManagerOVN.start(
+					&egressipv1.EgressIPList{Items: []egressipv1.EgressIP{eIP}},
+					&v1.NodeList{Items: []v1.Node{node1}},
+				)
+				egressNode1 := setupNode(node1Name, []string{"192.168.126.12/24"}, map[string]string{})
+				fakeClusterManagerOVN.eIPC.allocator.cache[egressNode1.name] = &egressNode1
+				_, err := fakeClusterManagerOVN.eIPC.WatchEgressIP()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				// the egress IP is not assigned until the cloud confirms it
+				gomega.Eventually(getConditionStatus(egressIPName, egressipv1.EgressIPConditionCloudAssignmentPending)).Should(gomega.Equal(metav1.ConditionTrue))
+				status := getEgressIPObject(egressIPName).Status
+				gomega.Expect(status.Items).To(gomega.BeEmpty())
+				gomega.Expect(status.UnassignedEgressIPs).To(gomega.HaveLen(1))
+				gomega.Expect(status.UnassignedEgressIPs[0].Reason).To(gomega.Equal(egressipv1.EgressIPReasonCloudAssignmentPending))
+
+				cloudPrivateIPConfig, err := fakeClusterManagerOVN.fakeClient.CloudNetworkClient.CloudV1().CloudPrivateIPConfigs().Get(
+					context.TODO(), ipStringToCloudPrivateIPConfigName(egressIP), metav1.GetOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(cloudPrivateIPConfig.Spec.Node).To(gomega.Equal(node1Name))
+				pending := cloudPrivateIPConfig.DeepCopy()
+				pending.Status.Conditions = []metav1.Condition{{
+					Type:   string(ocpcloudnetworkapi.Assigned),
+					Status: metav1.ConditionUnknown,
+					Reason: "CloudResponsePending",
+				}}
+				failed := cloudPrivateIPConfig.DeepCopy()
+				failed.Status.Conditions = []metav1.Condition{{
+					Type:    string(ocpcloudnetworkapi.Assigned),
+					Status:  metav1.ConditionFalse,
+					Reason:  "CloudResponseError",
+					Message: "private IP address limit exceeded",
+				}}
+				err = fakeClusterManagerOVN.eIPC.reconcileCloudPrivateIPConfig(pending, failed)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				gomega.Eventually(func() []egressipv1.EgressIPUnassignedStatusItem {
+					return getEgressIPObject(egressIPName).Status.UnassignedEgressIPs
+				}).Should(gomega.Equal([]egressipv1.EgressIPUnassignedStatusItem{
+					{
+						EgressIP: egressIP,
+						Reason:   egressipv1.EgressIPReasonCloudAssignmentFailed,
+						Message:  fmt.Sprintf("the cloud provider failed to assign the IP to node %s: private IP address limit exceeded", node1Name),
+					},
+				}))
+				condition := meta.FindStatusCondition(getEgressIPObject(egressIPName).Status.Conditions, egressipv1.EgressIPConditionCloudAssignmentPending)
+				gomega.Expect(condition).NotTo(gomega.BeNil())
+				gomega.Expect(condition.Status).To(gomega.Equal(metav1.ConditionTrue))
+				gomega.Expect(condition.Reason).To(gomega.Equal(egressipv1.EgressIPReasonCloudAssignmentFailed))
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+	})
+
 	ginkgo.Context("IPv6 assignment", func() {
 
 		ginkgo.It("should be able to allocate non-conflicting IP on node with lowest amount of allocations", func() {
@@ -3074,6 +3582,107 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 			err := app.Run([]string{app.Name})
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		})
+
+		ginkgo.It("should report the active egress IPs for ActiveStandby load balancing", func() {
+			app.Action = func(ctx *cli.Context) error {
+				egressIP1 := "192.168.126.10"
+				egressIP2 := "192.168.126.11"
+				node1IPv4 := "192.168.126.12/24"
+				node2IPv4 := "192.168.126.51/24"
+
+				node1 := v1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: node1Name,
+						Annotations: map[string]string{
+							"k8s.ovn.org/node-primary-ifaddr": fmt.Sprintf("{\"ipv4\": \"%s\", \"ipv6\": \"%s\"}", node1IPv4, ""),
+							"k8s.ovn.org/node-subnets":        fmt.Sprintf("{\"default\":[\"%s\", \"%s\"]}", v4NodeSubnet, v6NodeSubnet),
+							util.OVNNodeHostCIDRs:             fmt.Sprintf("[\"%s\"]", node1IPv4),
+						},
+						Labels: map[string]string{
+							"k8s.ovn.org/egress-assignable": "",
+						},
+					},
+					Status: v1.NodeStatus{
+						Conditions: []v1.NodeCondition{
+							{
+								Type:   v1.NodeReady,
+								Status: v1.ConditionTrue,
+							},
+						},
+					},
+				}
+				node2 := v1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: node2Name,
+						Annotations: map[string]string{
+							"k8s.ovn.org/node-primary-ifaddr": fmt.Sprintf("{\"ipv4\": \"%s\", \"ipv6\": \"%s\"}", node2IPv4, ""),
+							"k8s.ovn.org/node-subnets":        fmt.Sprintf("{\"default\": [\"%s\",\"%s\"]}", v4NodeSubnet, v6NodeSubnet),
+							util.OVNNodeHostCIDRs:             fmt.Sprintf("[\"%s\"]", node2IPv4),
+						},
+						Labels: map[string]string{
+							"k8s.ovn.org/egress-assignable": "",
+						},
+					},
+					Status: v1.NodeStatus{
+						Conditions: []v1.NodeCondition{
+							{
+								Type:   v1.NodeReady,
+								Status: v1.ConditionTrue,
+							},
+						},
+					},
+				}
+
+				eIP := egressipv1.EgressIP{
+					ObjectMeta: newEgressIPMeta(egressIPName),
+					Spec: egressipv1.EgressIPSpec{
+						EgressIPs:     []string{egressIP1, egressIP2},
+						LoadBalancing: egressipv1.EgressIPLoadBalancingActiveStandby,
+						NamespaceSelector: metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"name": "does-not-exist",
+							},
+						},
+					},
+				}
+
+				fakeClusterManagerOVN.start(
+					&v1.NodeList{Items: []v1.Node{node1, node2}},
+					&egressipv1.EgressIPList{Items: []egressipv1.EgressIP{eIP}},
+				)
+
+				egressNode1 := setupNode(node1Name, []string{node1IPv4}, map[string]string{})
+				egressNode2 := setupNode(node2Name, []string{node2IPv4}, map[string]string{})
+
+				fakeClusterManagerOVN.eIPC.allocator.cache[egressNode1.name] = &egressNode1
+				fakeClusterManagerOVN.eIPC.allocator.cache[egressNode2.name] = &egressNode2
+
+				_, err := fakeClusterManagerOVN.eIPC.WatchEgressIP()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				getStatus := func() egressipv1.EgressIPStatus {
+					tmp, err := fakeClusterManagerOVN.fakeClient.EgressIPClient.K8sV1().EgressIPs().Get(context.TODO(), egressIPName, metav1.GetOptions{})
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					return tmp.Status
+				}
+				gomega.Eventually(getEgressIPStatusLen(egressIPName)).Should(gomega.Equal(2))
+				gomega.Eventually(func() []string { return getStatus().ActiveEgressIPs }).Should(gomega.Equal([]string{egressIP1}))
+				gomega.Expect(getStatus().LoadBalancing).To(gomega.Equal(egressipv1.EgressIPLoadBalancingActiveStandby))
+
+				// changing the order of the egress IPs changes the active one
+				eIPUpdate, err := fakeClusterManagerOVN.fakeClient.EgressIPClient.K8sV1().EgressIPs().Get(context.TODO(), egressIPName, metav1.GetOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				eIPUpdate.Spec.EgressIPs = []string{egressIP2, egressIP1}
+				_, err = fakeClusterManagerOVN.fakeClient.EgressIPClient.K8sV1().EgressIPs().Update(context.TODO(), eIPUpdate, metav1.UpdateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(func() []string { return getStatus().ActiveEgressIPs }).Should(gomega.Equal([]string{egressIP2}))
+				gomega.Expect(getStatus().Items).To(gomega.HaveLen(2))
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
 	})
 
 	ginkgo.Context("syncEgressIP for dual-stack", func() {
@@ -119,6 +119,10 @@ func (h *egressIPClusterControllerEventHandler) UpdateResource(oldObj, newObj in
 		isNewReady := h.eIPC.isEgressNodeReady(newNode)
 		isNewReachable := h.eIPC.isEgressNodeReachable(newNode)
 		isHostCIDRsAltered := util.NodeHostCIDRsAnnotationChanged(oldNode, newNode)
+		// the capacity, weight or failure domain of the node changed: egress
+		// IPs which are not fully assigned might now fit on the node
+		isAssignmentConfigAltered := util.NodeEgressIPAssignmentAnnotationsChanged(oldNode, newNode) ||
+			getNodeFailureDomain(oldNode) != getNodeFailureDomain(newNode)
 		h.eIPC.setNodeEgressReady(newNode.Name, isNewReady)
 		if !oldHadEgressLabel && newHasEgressLabel {
 			klog.Infof("Node: %s has been labeled, adding it for egress assignment", newNode.Name)
@@ -133,7 +137,7 @@ func (h *egressIPClusterControllerEventHandler) UpdateResource(oldObj, newObj in
 			}
 			return nil
 		}
-		if isOldReady == isNewReady && !isHostCIDRsAltered {
+		if isOldReady == isNewReady && !isHostCIDRsAltered && !isAssignmentConfigAltered {
 			return nil
 		}
 		if !isNewReady {
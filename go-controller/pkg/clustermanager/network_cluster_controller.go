@@ -4,9 +4,13 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"slices"
 	"sync"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	corev1 "k8s.io/api/core/v1"
 	cache "k8s.io/client-go/tools/cache"
@@ -17,6 +21,8 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/clustermanager/node"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/clustermanager/pod"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	clusternetworkapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1"
+	ipamclaimapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/ipamclaim/v1"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
 	objretry "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/retry"
@@ -31,6 +37,7 @@ import (
 type networkClusterController struct {
 	watchFactory *factory.WatchFactory
 	kube         kube.Interface
+	ovnClient    *util.OVNClusterManagerClientset
 	stopChan     chan struct{}
 	wg           *sync.WaitGroup
 
@@ -44,6 +51,13 @@ type networkClusterController struct {
 	podHandler *factory.Handler
 	retryPods  *objretry.RetryFramework
 
+	// retry framework for the IPAMClaims persisting pod IPs
+	ipamClaimHandler *factory.Handler
+	retryIPAMClaims  *objretry.RetryFramework
+
+	// ClusterNetwork events factory handler, adding cluster subnets at runtime
+	clusterNetworkHandler *factory.Handler
+
 	podAllocator       *pod.PodAllocator
 	nodeAllocator      *node.NodeAllocator
 	networkIDAllocator idallocator.NamedAllocator
@@ -62,6 +76,7 @@ func newNetworkClusterController(networkIDAllocator idallocator.NamedAllocator,
 		NetInfo:            netInfo,
 		watchFactory:       wf,
 		kube:               kube,
+		ovnClient:          ovnClient,
 		stopChan:           make(chan struct{}),
 		wg:                 wg,
 		networkIDAllocator: networkIDAllocator,
@@ -116,12 +131,25 @@ func (ncc *networkClusterController) hasNodeAllocation() bool {
 	}
 }
 
+func (ncc *networkClusterController) hasNodeSubnetExpansion() bool {
+	// cluster subnets can only be added at runtime to the default network
+	return config.OVNKubernetesFeature.EnableNodeSubnetExpansion && !ncc.IsSecondary()
+}
+
 func (ncc *networkClusterController) init() error {
 	networkID, err := ncc.networkIDAllocator.AllocateID()
 	if err != nil {
 		return err
 	}
 
+	if ncc.hasNodeSubnetExpansion() {
+		// cluster subnets added at runtime need to be known before the
+		// existing host subnets are synced
+		if err := util.SyncClusterNetworkSubnets(ncc.watchFactory.ClusterNetworkInformer().Lister()); err != nil {
+			return err
+		}
+	}
+
 	if ncc.hasNodeAllocation() {
 		ncc.retryNodes = ncc.newRetryFramework(factory.NodeType, true)
 
@@ -135,7 +163,17 @@ func (ncc *networkClusterController) init() error {
 	if ncc.hasPodAllocation() {
 		ncc.retryPods = ncc.newRetryFramework(factory.PodType, true)
 
-		ncc.podAllocator = pod.NewPodAllocator(ncc.NetInfo, ncc.watchFactory.PodCoreInformer().Lister(), ncc.kube)
+		var ipamClaims *pod.IPAMClaims
+		if util.AllowsPersistentIPs(ncc.NetInfo) {
+			ncc.retryIPAMClaims = ncc.newRetryFramework(factory.IPAMClaimType, false)
+			ipamClaims = pod.NewIPAMClaims(
+				ncc.ovnClient.IPAMClaimClient,
+				ncc.watchFactory.IPAMClaimInformer().Lister(),
+				ncc.watchFactory.VirtualMachineMetadataLister(),
+			)
+		}
+
+		ncc.podAllocator = pod.NewPodAllocator(ncc.NetInfo, ncc.watchFactory.PodCoreInformer().Lister(), ncc.kube, ipamClaims)
 		err := ncc.podAllocator.Init()
 		if err != nil {
 			return fmt.Errorf("failed to initialize pod ip allocator: %w", err)
@@ -163,6 +201,28 @@ func (ncc *networkClusterController) Start(ctx context.Context) error {
 		ncc.nodeHandler = nodeHandler
 	}
 
+	if ncc.hasNodeSubnetExpansion() {
+		clusterNetworkHandler, err := ncc.watchFactory.AddClusterNetworkHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: ncc.addClusterNetwork,
+			UpdateFunc: func(_, newObj interface{}) {
+				ncc.addClusterNetwork(newObj)
+			},
+		}, nil)
+		if err != nil {
+			return fmt.Errorf("unable to watch cluster networks: %w", err)
+		}
+		ncc.clusterNetworkHandler = clusterNetworkHandler
+	}
+
+	if ncc.retryIPAMClaims != nil {
+		// IPs of existing claims need to be reserved before syncing pods
+		ipamClaimHandler, err := ncc.retryIPAMClaims.WatchResource()
+		if err != nil {
+			return fmt.Errorf("unable to watch ipam claims: %w", err)
+		}
+		ncc.ipamClaimHandler = ipamClaimHandler
+	}
+
 	if ncc.hasPodAllocation() {
 		podHandler, err := ncc.retryPods.WatchResource()
 		if err != nil {
@@ -185,6 +245,162 @@ func (ncc *networkClusterController) Stop() {
 	if ncc.podHandler != nil {
 		ncc.watchFactory.RemovePodHandler(ncc.podHandler)
 	}
+
+	if ncc.ipamClaimHandler != nil {
+		ncc.watchFactory.RemoveIPAMClaimHandler(ncc.ipamClaimHandler)
+	}
+
+	if ncc.clusterNetworkHandler != nil {
+		ncc.watchFactory.RemoveClusterNetworkHandler(ncc.clusterNetworkHandler)
+	}
+}
+
+// addClusterNetwork adds the cluster subnets of the given ClusterNetwork to
+// the default network and makes them available for allocation.
+func (ncc *networkClusterController) addClusterNetwork(obj interface{}) {
+	clusterNetwork, ok := obj.(*clusternetworkapi.ClusterNetwork)
+	if !ok {
+		klog.Errorf("Could not cast %T object to *clusternetworkapi.ClusterNetwork", obj)
+		return
+	}
+	if clusterNetwork.DeletionTimestamp != nil {
+		ncc.deleteClusterNetwork(clusterNetwork)
+		return
+	}
+	if err := ncc.updateClusterNetworkFinalizer(clusterNetwork.Name, true); err != nil {
+		klog.Errorf("Failed to add finalizer to ClusterNetwork %s: %v", clusterNetwork.Name, err)
+	}
+	added, err := util.AddClusterNetworkSubnets(clusterNetwork)
+	if err != nil {
+		klog.Errorf("Failed to add cluster subnets: %v", err)
+		return
+	}
+	if len(added) > 0 {
+		klog.Infof("Added cluster subnets %v from ClusterNetwork %s", added, clusterNetwork.Name)
+	}
+	if err := ncc.updateClusterSubnets(); err != nil {
+		klog.Errorf("Failed to update cluster subnets of network %s: %v", ncc.GetNetworkName(), err)
+	}
+}
+
+// deleteClusterNetwork stops the allocation of host subnets out of the cluster
+// subnet ranges only provided by the given ClusterNetwork, which is being
+// deleted, and lets its deletion proceed once no host subnet allocated out of
+// them is left. The ranges otherwise remain part of the network until
+// ovnkube is restarted.
+func (ncc *networkClusterController) deleteClusterNetwork(clusterNetwork *clusternetworkapi.ClusterNetwork) {
+	if !slices.Contains(clusterNetwork.Finalizers, util.ClusterNetworkFinalizer) {
+		return
+	}
+	clusterSubnets, err := ncc.getClusterNetworkOwnSubnets(clusterNetwork)
+	if err != nil {
+		klog.Errorf("Failed to get cluster subnets of ClusterNetwork %s being deleted: %v", clusterNetwork.Name, err)
+		return
+	}
+	if ncc.nodeAllocator != nil {
+		if used := ncc.nodeAllocator.DrainClusterSubnets(clusterSubnets); used > 0 {
+			klog.Infof("Deletion of ClusterNetwork %s is pending: %d host subnets are allocated out of its cluster subnets %v",
+				clusterNetwork.Name, used, clusterSubnets)
+			return
+		}
+	}
+	if err := ncc.updateClusterNetworkFinalizer(clusterNetwork.Name, false); err != nil {
+		klog.Errorf("Failed to remove finalizer from ClusterNetwork %s: %v", clusterNetwork.Name, err)
+	}
+}
+
+// syncDeletingClusterNetworks lets the deletion of the ClusterNetworks being
+// deleted proceed if host subnets have been released.
+func (ncc *networkClusterController) syncDeletingClusterNetworks() {
+	clusterNetworks, err := ncc.watchFactory.ClusterNetworkInformer().Lister().List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Failed to list ClusterNetworks: %v", err)
+		return
+	}
+	for _, clusterNetwork := range clusterNetworks {
+		if clusterNetwork.DeletionTimestamp != nil {
+			ncc.deleteClusterNetwork(clusterNetwork)
+		}
+	}
+}
+
+// getClusterNetworkOwnSubnets returns the cluster subnet ranges of the given
+// ClusterNetwork that are neither configured nor provided by another
+// ClusterNetwork not being deleted.
+func (ncc *networkClusterController) getClusterNetworkOwnSubnets(clusterNetwork *clusternetworkapi.ClusterNetwork) ([]config.CIDRNetworkEntry, error) {
+	entries, err := util.ParseClusterNetworkSubnets(clusterNetwork)
+	if err != nil {
+		// its ranges were never added
+		return nil, nil
+	}
+	others := sets.New[string]()
+	for _, entry := range config.Default.ClusterSubnets {
+		others.Insert(entry.String())
+	}
+	clusterNetworks, err := ncc.watchFactory.ClusterNetworkInformer().Lister().List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ClusterNetworks: %w", err)
+	}
+	for _, other := range clusterNetworks {
+		if other.Name == clusterNetwork.Name || other.DeletionTimestamp != nil {
+			continue
+		}
+		otherEntries, err := util.ParseClusterNetworkSubnets(other)
+		if err != nil {
+			continue
+		}
+		for _, entry := range otherEntries {
+			others.Insert(entry.String())
+		}
+	}
+	var ownEntries []config.CIDRNetworkEntry
+	for _, entry := range entries {
+		if !others.Has(entry.String()) {
+			ownEntries = append(ownEntries, entry)
+		}
+	}
+	return ownEntries, nil
+}
+
+// updateClusterNetworkFinalizer adds or removes the finalizer of the given
+// ClusterNetwork.
+func (ncc *networkClusterController) updateClusterNetworkFinalizer(name string, add bool) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		clusterNetwork, err := ncc.ovnClient.ClusterNetworkClient.K8sV1().ClusterNetworks().Get(context.TODO(), name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if slices.Contains(clusterNetwork.Finalizers, util.ClusterNetworkFinalizer) == add {
+			return nil
+		}
+		if add {
+			clusterNetwork.Finalizers = append(clusterNetwork.Finalizers, util.ClusterNetworkFinalizer)
+		} else {
+			clusterNetwork.Finalizers = slices.DeleteFunc(clusterNetwork.Finalizers, func(finalizer string) bool {
+				return finalizer == util.ClusterNetworkFinalizer
+			})
+		}
+		_, err = ncc.ovnClient.ClusterNetworkClient.K8sV1().ClusterNetworks().Update(context.TODO(), clusterNetwork, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// updateClusterSubnets makes the cluster subnets added to the network since
+// it was started available for host subnet allocation
+func (ncc *networkClusterController) updateClusterSubnets() error {
+	if ncc.nodeAllocator == nil {
+		return nil
+	}
+	if err := ncc.nodeAllocator.UpdateClusterSubnets(); err != nil {
+		return fmt.Errorf("failed to update host subnet allocator of network %s: %w", ncc.GetNetworkName(), err)
+	}
+	// nodes might have failed to get a subnet because the cluster
+	// subnets were exhausted, retry them now
+	ncc.retryNodes.RequestRetryObjs()
+	return nil
 }
 
 func (ncc *networkClusterController) newRetryFramework(objectType reflect.Type, hasUpdateFunc bool) *objretry.RetryFramework {
@@ -201,6 +417,27 @@ func (ncc *networkClusterController) newRetryFramework(objectType reflect.Type,
 	return objretry.NewRetryFramework(ncc.stopChan, ncc.wg, ncc.watchFactory, resourceHandler)
 }
 
+// UpdateNetInfo applies in place the compatible changes of the given network
+// information, making any additional subnets available for allocation
+func (ncc *networkClusterController) UpdateNetInfo(netInfo util.BasicNetInfo) error {
+	if err := util.UpdateNetInfo(ncc.NetInfo, netInfo); err != nil {
+		return err
+	}
+
+	if err := ncc.updateClusterSubnets(); err != nil {
+		return err
+	}
+
+	if ncc.podAllocator != nil {
+		if err := ncc.podAllocator.UpdateSubnets(); err != nil {
+			return fmt.Errorf("failed to update pod ip allocator of network %s: %w", ncc.GetNetworkName(), err)
+		}
+		ncc.retryPods.RequestRetryObjs()
+	}
+
+	return nil
+}
+
 // Cleanup the subnet annotations from the node for the secondary networks
 func (ncc *networkClusterController) Cleanup(netName string) error {
 	if !ncc.IsSecondary() {
@@ -257,6 +494,8 @@ func (h *networkClusterControllerEventHandler) AddResource(obj interface{}, from
 			return err
 		}
 		h.clearInitialNodeNetworkUnavailableCondition(node)
+	case factory.IPAMClaimType:
+		// IPAMClaims are created by the pod allocator, nothing to do
 	default:
 		return fmt.Errorf("no add function for object type %s", h.objType)
 	}
@@ -320,7 +559,20 @@ func (h *networkClusterControllerEventHandler) DeleteResource(obj, cachedObj int
 		if !ok {
 			return fmt.Errorf("could not cast obj of type %T to *knet.Node", obj)
 		}
-		return h.ncc.nodeAllocator.HandleDeleteNode(node)
+		if err := h.ncc.nodeAllocator.HandleDeleteNode(node); err != nil {
+			return err
+		}
+		if h.ncc.hasNodeSubnetExpansion() {
+			// the host subnets of the node might have been the last ones
+			// held by a ClusterNetwork being deleted
+			h.ncc.syncDeletingClusterNetworks()
+		}
+	case factory.IPAMClaimType:
+		claim, ok := obj.(*ipamclaimapi.IPAMClaim)
+		if !ok {
+			return fmt.Errorf("could not cast %T object to *ipamclaimapi.IPAMClaim", obj)
+		}
+		return h.ncc.podAllocator.ReleaseIPAMClaim(claim)
 	}
 	return nil
 }
@@ -337,6 +589,8 @@ func (h *networkClusterControllerEventHandler) SyncFunc(objs []interface{}) erro
 			syncFunc = h.ncc.podAllocator.Sync
 		case factory.NodeType:
 			syncFunc = h.ncc.nodeAllocator.Sync
+		case factory.IPAMClaimType:
+			syncFunc = h.ncc.podAllocator.SyncIPAMClaims
 
 		default:
 			return fmt.Errorf("no sync function for object type %s", h.objType)
@@ -385,6 +639,8 @@ func (h *networkClusterControllerEventHandler) GetResourceFromInformerCache(key
 		obj, err = h.ncc.watchFactory.GetNode(name)
 	case factory.PodType:
 		obj, err = h.ncc.watchFactory.GetPod(namespace, name)
+	case factory.IPAMClaimType:
+		obj, err = h.ncc.watchFactory.IPAMClaimInformer().Lister().IPAMClaims(namespace).Get(name)
 	default:
 		err = fmt.Errorf("object type %s not supported, cannot retrieve it from informers cache",
 			h.objType)
@@ -6,6 +6,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
 	listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
@@ -34,6 +35,9 @@ type NodeAllocator struct {
 	clusterSubnetAllocator       SubnetAllocator
 	hybridOverlaySubnetAllocator SubnetAllocator
 
+	// cluster subnets already added to clusterSubnetAllocator
+	clusterSubnets sets.Set[string]
+
 	// unique id of the network
 	networkID int
 
@@ -66,13 +70,8 @@ func (na *NodeAllocator) Init() error {
 		return nil
 	}
 
-	clusterSubnets := na.netInfo.Subnets()
-
-	for _, clusterSubnet := range clusterSubnets {
-		if err := na.clusterSubnetAllocator.AddNetworkRange(clusterSubnet.CIDR, clusterSubnet.HostSubnetLength); err != nil {
-			return err
-		}
-		klog.V(5).Infof("Added network range %s to cluster subnet allocator", clusterSubnet.CIDR)
+	if err := na.addClusterSubnets(); err != nil {
+		return err
 	}
 
 	if na.hasHybridOverlayAllocation() {
@@ -90,6 +89,55 @@ func (na *NodeAllocator) Init() error {
 	return nil
 }
 
+// UpdateClusterSubnets adds to the cluster subnet allocator the network
+// ranges that were added to the network since it was initialized.
+func (na *NodeAllocator) UpdateClusterSubnets() error {
+	if !na.hasNodeSubnetAllocation() {
+		return nil
+	}
+
+	if err := na.addClusterSubnets(); err != nil {
+		return err
+	}
+
+	na.recordSubnetCount()
+
+	return nil
+}
+
+// DrainClusterSubnets stops allocating host subnets out of the given cluster
+// subnets and returns the number of host subnets still allocated from them.
+func (na *NodeAllocator) DrainClusterSubnets(clusterSubnets []config.CIDRNetworkEntry) uint64 {
+	if !na.hasNodeSubnetAllocation() {
+		return 0
+	}
+	var used uint64
+	for _, clusterSubnet := range clusterSubnets {
+		if !na.clusterSubnets.Has(clusterSubnet.String()) {
+			continue
+		}
+		used += na.clusterSubnetAllocator.DrainNetworkRange(clusterSubnet.CIDR)
+	}
+	return used
+}
+
+func (na *NodeAllocator) addClusterSubnets() error {
+	if na.clusterSubnets == nil {
+		na.clusterSubnets = sets.New[string]()
+	}
+	for _, clusterSubnet := range na.netInfo.Subnets() {
+		if na.clusterSubnets.Has(clusterSubnet.String()) {
+			continue
+		}
+		if err := na.clusterSubnetAllocator.AddNetworkRange(clusterSubnet.CIDR, clusterSubnet.HostSubnetLength); err != nil {
+			return err
+		}
+		na.clusterSubnets.Insert(clusterSubnet.String())
+		klog.V(5).Infof("Added network range %s to cluster subnet allocator", clusterSubnet.CIDR)
+	}
+	return nil
+}
+
 func (na *NodeAllocator) hasHybridOverlayAllocation() bool {
 	// When config.HybridOverlay.ClusterSubnets is empty, assume the subnet allocation will be managed by an external component.
 	return config.HybridOverlay.Enabled && !na.netInfo.IsSecondary() && len(config.HybridOverlay.ClusterSubnets) > 0
@@ -193,6 +241,15 @@ func (na *NodeAllocator) syncNodeNetworkAnnotations(node *corev1.Node) error {
 			klog.Warningf("Failed to get node %s host subnets annotations for network %s : %v", node.Name, networkName, err)
 		}
 
+		// With node subnet expansion, only the first subnet of each IP
+		// family is subject to the regular allocation, the additional ones
+		// are handled by expandNodeSubnets.
+		existingCount := len(existingSubnets)
+		var extraSubnets []*net.IPNet
+		if na.hasNodeSubnetExpansion() {
+			existingSubnets, extraSubnets = splitPrimaryHostSubnets(existingSubnets)
+		}
+
 		// On return validExistingSubnets will contain any valid subnets that
 		// were already assigned to the node. allocatedSubnets will contain
 		// any newly allocated subnets required to ensure that the node has one subnet
@@ -203,12 +260,25 @@ func (na *NodeAllocator) syncNodeNetworkAnnotations(node *corev1.Node) error {
 			return err
 		}
 
+		if na.hasNodeSubnetExpansion() {
+			validExtraSubnets, expandedSubnets, err := na.expandNodeSubnets(node, validExistingSubnets, extraSubnets)
+			if err != nil {
+				if errR := na.clusterSubnetAllocator.ReleaseNetworks(node.Name, allocatedSubnets...); errR != nil {
+					klog.Warningf("Error releasing node %s subnets: %v", node.Name, errR)
+				}
+				return err
+			}
+			validExistingSubnets = append(append(validExistingSubnets, validExtraSubnets...), expandedSubnets...)
+			allocatedSubnets = append(allocatedSubnets, expandedSubnets...)
+		}
+
 		// If the existing subnets weren't OK, or new ones were allocated, update the node annotation.
 		// This happens in a couple cases:
 		// 1) new node: no existing subnets and one or more new subnets were allocated
 		// 2) dual-stack to single-stack conversion: two existing subnets but only one will be valid, and no allocated subnets
 		// 3) bad subnet annotation: one more existing subnets will be invalid and might have allocated a correct one
-		if len(existingSubnets) != len(validExistingSubnets) || len(allocatedSubnets) > 0 {
+		// 4) node subnet expansion: an additional subnet was allocated to a node whose subnets are exhausted
+		if existingCount != len(validExistingSubnets) || len(allocatedSubnets) > 0 {
 			updatedSubnetsMap[networkName] = validExistingSubnets
 		}
 	}
@@ -464,6 +534,105 @@ func (na *NodeAllocator) allocateNodeSubnets(allocator SubnetAllocator, nodeName
 	return hostSubnets, allocatedSubnets, nil
 }
 
+// expandNodeSubnets validates the host subnets assigned to the node on top of
+// the first one of each IP family, releasing the invalid ones, and allocates
+// one more host subnet for each IP family whose subnets have all been
+// reported exhausted on the node. It returns the valid additional subnets and
+// the newly allocated ones.
+func (na *NodeAllocator) expandNodeSubnets(node *corev1.Node, hostSubnets, extraSubnets []*net.IPNet) ([]*net.IPNet, []*net.IPNet, error) {
+	validExtraSubnets := make([]*net.IPNet, 0, len(extraSubnets))
+	for _, subnet := range extraSubnets {
+		// an additional subnet is only valid next to a first one of the same IP family
+		if len(util.MatchAllIPNetFamily(utilnet.IsIPv6CIDR(subnet), hostSubnets)) > 0 {
+			if err := na.clusterSubnetAllocator.MarkAllocatedNetworks(node.Name, subnet); err == nil {
+				validExtraSubnets = append(validExtraSubnets, subnet)
+				continue
+			}
+		}
+		klog.Infof("Releasing unused or invalid additional subnet %v on node %s", subnet, node.Name)
+		if err := na.clusterSubnetAllocator.ReleaseNetworks(node.Name, subnet); err != nil {
+			klog.Warningf("Failed to release subnet %v on node %s: %v", subnet, node.Name, err)
+		}
+	}
+
+	networkName := na.netInfo.GetNetworkName()
+	exhaustedSubnets, err := util.ParseNodeExhaustedSubnetsAnnotation(node, networkName)
+	if err != nil {
+		if !util.IsAnnotationNotSetError(err) {
+			klog.Warningf("Failed to get node %s exhausted subnets annotation for network %s: %v", node.Name, networkName, err)
+		}
+		return validExtraSubnets, nil, nil
+	}
+	exhausted := sets.New[string]()
+	for _, subnet := range exhaustedSubnets {
+		exhausted.Insert(subnet.String())
+	}
+
+	allSubnets := append(append([]*net.IPNet{}, hostSubnets...), validExtraSubnets...)
+	var expandedSubnets []*net.IPNet
+	for _, ipv6 := range []bool{false, true} {
+		familySubnets := util.MatchAllIPNetFamily(ipv6, allSubnets)
+		if len(familySubnets) == 0 {
+			continue
+		}
+		allExhausted := true
+		for _, subnet := range familySubnets {
+			if !exhausted.Has(subnet.String()) {
+				allExhausted = false
+				break
+			}
+		}
+		if !allExhausted {
+			continue
+		}
+
+		var subnet *net.IPNet
+		if ipv6 {
+			subnet, err = na.clusterSubnetAllocator.AllocateIPv6Network(node.Name)
+		} else {
+			subnet, err = na.clusterSubnetAllocator.AllocateIPv4Network(node.Name)
+		}
+		if err != nil {
+			if errR := na.clusterSubnetAllocator.ReleaseNetworks(node.Name, expandedSubnets...); errR != nil {
+				klog.Warningf("Error releasing node %s subnets: %v", node.Name, errR)
+			}
+			return nil, nil, fmt.Errorf("error allocating an additional network for node %s with exhausted subnets %v: %w",
+				node.Name, familySubnets, err)
+		}
+		if subnet != nil {
+			klog.Infof("Allocated additional subnet %v on node %s with exhausted subnets %v", subnet, node.Name, familySubnets)
+			expandedSubnets = append(expandedSubnets, subnet)
+		}
+	}
+
+	return validExtraSubnets, expandedSubnets, nil
+}
+
+// splitPrimaryHostSubnets splits the given host subnets between the first one
+// of each IP family and the remaining ones.
+func splitPrimaryHostSubnets(subnets []*net.IPNet) ([]*net.IPNet, []*net.IPNet) {
+	var primarySubnets, extraSubnets []*net.IPNet
+	foundIPv4, foundIPv6 := false, false
+	for _, subnet := range subnets {
+		switch {
+		case utilnet.IsIPv6CIDR(subnet) && !foundIPv6:
+			foundIPv6 = true
+			primarySubnets = append(primarySubnets, subnet)
+		case !utilnet.IsIPv6CIDR(subnet) && !foundIPv4:
+			foundIPv4 = true
+			primarySubnets = append(primarySubnets, subnet)
+		default:
+			extraSubnets = append(extraSubnets, subnet)
+		}
+	}
+	return primarySubnets, extraSubnets
+}
+
+func (na *NodeAllocator) hasNodeSubnetExpansion() bool {
+	// additional host subnets are only allocated for the default network
+	return config.OVNKubernetesFeature.EnableNodeSubnetExpansion && !na.netInfo.IsSecondary()
+}
+
 func (na *NodeAllocator) hasNodeSubnetAllocation() bool {
 	// we only allocate subnets for L3 secondary network or default network
 	return na.netInfo.TopologyType() == types.Layer3Topology || !na.netInfo.IsSecondary()
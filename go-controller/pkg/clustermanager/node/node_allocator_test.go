@@ -6,6 +6,9 @@ import (
 	"reflect"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	cnitypes "github.com/containernetworking/cni/pkg/types"
 
 	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
@@ -323,3 +326,128 @@ func TestController_allocateNodeSubnets_ReleaseOnError(t *testing.T) {
 		t.Fatalf("Expected %d v6 allocated subnets, but got %d", v6usedBefore, v6usedAfter)
 	}
 }
+
+func TestController_expandNodeSubnets(t *testing.T) {
+	tests := []struct {
+		name             string
+		networkRanges    []string
+		networkLens      []int
+		hostSubnets      []string
+		extraSubnets     []string
+		exhaustedSubnets string
+		wantExtra        []string
+		wantExpanded     []string
+		wantErr          bool
+	}{
+		{
+			name:          "keeps valid additional subnets",
+			networkRanges: []string{"172.16.0.0/16"},
+			networkLens:   []int{24},
+			hostSubnets:   []string{"172.16.0.0/24"},
+			extraSubnets:  []string{"172.16.1.0/24"},
+			wantExtra:     []string{"172.16.1.0/24"},
+		},
+		{
+			name:          "releases additional subnets of an IP family the node has no subnet of",
+			networkRanges: []string{"172.16.0.0/16", "2001:db2:1::/56"},
+			networkLens:   []int{24, 64},
+			hostSubnets:   []string{"172.16.0.0/24"},
+			extraSubnets:  []string{"2001:db2:1:2::/64"},
+			wantExtra:     []string{},
+		},
+		{
+			name:             "allocates an additional subnet when all subnets are exhausted",
+			networkRanges:    []string{"172.16.0.0/16"},
+			networkLens:      []int{24},
+			hostSubnets:      []string{"172.16.0.0/24"},
+			extraSubnets:     []string{"172.16.1.0/24"},
+			exhaustedSubnets: `{"default":["172.16.0.0/24","172.16.1.0/24"]}`,
+			wantExtra:        []string{"172.16.1.0/24"},
+			wantExpanded:     []string{"172.16.2.0/24"},
+		},
+		{
+			name:             "does not allocate an additional subnet when some subnets are not exhausted",
+			networkRanges:    []string{"172.16.0.0/16"},
+			networkLens:      []int{24},
+			hostSubnets:      []string{"172.16.0.0/24"},
+			extraSubnets:     []string{"172.16.1.0/24"},
+			exhaustedSubnets: `{"default":["172.16.0.0/24"]}`,
+			wantExtra:        []string{"172.16.1.0/24"},
+		},
+		{
+			name:             "only expands the exhausted IP family",
+			networkRanges:    []string{"172.16.0.0/16", "2001:db2:1::/56"},
+			networkLens:      []int{24, 64},
+			hostSubnets:      []string{"172.16.0.0/24", "2001:db2:1::/64"},
+			exhaustedSubnets: `{"default":["2001:db2:1::/64"]}`,
+			wantExtra:        []string{},
+			wantExpanded:     []string{"2001:db2:1:1::/64"},
+		},
+		{
+			name:             "fails when the cluster subnets are exhausted too",
+			networkRanges:    []string{"172.16.0.0/23"},
+			networkLens:      []int{24},
+			hostSubnets:      []string{"172.16.0.0/24"},
+			extraSubnets:     []string{"172.16.1.0/24"},
+			exhaustedSubnets: `{"default":["172.16.0.0/24","172.16.1.0/24"]}`,
+			wantErr:          true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranges, err := rangesFromStrings(tt.networkRanges, tt.networkLens)
+			if err != nil {
+				t.Fatal(err)
+			}
+			config.Default.ClusterSubnets = ranges
+
+			netInfo, err := util.NewNetInfo(
+				&ovncnitypes.NetConf{
+					NetConf: cnitypes.NetConf{Name: types.DefaultNetworkName},
+				},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			na := &NodeAllocator{
+				netInfo:                netInfo,
+				clusterSubnetAllocator: NewSubnetAllocator(),
+			}
+			if err := na.Init(); err != nil {
+				t.Fatalf("Failed to initialize node allocator: %v", err)
+			}
+
+			node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "testnode", Annotations: map[string]string{}}}
+			if tt.exhaustedSubnets != "" {
+				node.Annotations["k8s.ovn.org/node-exhausted-subnets"] = tt.exhaustedSubnets
+			}
+			hostSubnets := ovntest.MustParseIPNets(tt.hostSubnets...)
+			if err := na.clusterSubnetAllocator.MarkAllocatedNetworks(node.Name, hostSubnets...); err != nil {
+				t.Fatal(err)
+			}
+			v4usedBefore, v6usedBefore := na.clusterSubnetAllocator.Usage()
+
+			extra, expanded, err := na.expandNodeSubnets(node, hostSubnets, ovntest.MustParseIPNets(tt.extraSubnets...))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expandNodeSubnets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(extra)+len(tt.wantExtra) > 0 && !reflect.DeepEqual(extra, ovntest.MustParseIPNets(tt.wantExtra...)) {
+				t.Fatalf("expandNodeSubnets() additional subnets = %v, want %v", extra, tt.wantExtra)
+			}
+			if len(expanded)+len(tt.wantExpanded) > 0 && !reflect.DeepEqual(expanded, ovntest.MustParseIPNets(tt.wantExpanded...)) {
+				t.Fatalf("expandNodeSubnets() allocated subnets = %v, want %v", expanded, tt.wantExpanded)
+			}
+
+			v4usedAfter, v6usedAfter := na.clusterSubnetAllocator.Usage()
+			if v4usedAfter+v6usedAfter != v4usedBefore+v6usedBefore+uint64(len(tt.wantExtra)+len(tt.wantExpanded)) {
+				t.Fatalf("Expected %d additional allocated subnets, but got %d",
+					len(tt.wantExtra)+len(tt.wantExpanded), v4usedAfter+v6usedAfter-v4usedBefore-v6usedBefore)
+			}
+		})
+	}
+}
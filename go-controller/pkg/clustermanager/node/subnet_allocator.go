@@ -13,6 +13,9 @@ var ErrSubnetAllocatorFull = fmt.Errorf("no subnets available")
 
 type SubnetAllocator interface {
 	AddNetworkRange(network *net.IPNet, hostSubnetLen int) error
+	// DrainNetworkRange stops allocating networks out of the given range and
+	// returns the number of networks still allocated from it
+	DrainNetworkRange(network *net.IPNet) uint64
 	MarkAllocatedNetworks(string, ...*net.IPNet) error
 	// Usage returns the number of used/allocated v4 and v6 subnets
 	Usage() (uint64, uint64)
@@ -84,6 +87,23 @@ func (sna *BaseSubnetAllocator) AddNetworkRange(network *net.IPNet, hostSubnetLe
 	return nil
 }
 
+// DrainNetworkRange stops allocating networks out of the given range, the
+// networks already allocated from it remain allocated and can still be marked
+// as allocated. It returns the number of networks still allocated from the
+// range, 0 if the range is unknown.
+func (sna *BaseSubnetAllocator) DrainNetworkRange(network *net.IPNet) uint64 {
+	sna.Lock()
+	defer sna.Unlock()
+
+	for _, snr := range append(append([]*subnetAllocatorRange{}, sna.v4ranges...), sna.v6ranges...) {
+		if snr.network.String() == network.String() {
+			snr.draining = true
+			return snr.usage()
+		}
+	}
+	return 0
+}
+
 // MarkAllocatedNetworks will mark the given subnets as already allocated by
 // the given owner. Marking is all-or-nothing; if marking one of the subnets
 // fails then none of them are marked as allocated.
@@ -253,6 +273,8 @@ type subnetAllocatorRange struct {
 	next       uint32
 	allocMap   map[string]string
 	used       uint32
+	// no more networks are allocated out of a draining range
+	draining bool
 
 	// IPv4-only address-alignment hackery; see below
 	leftShift  uint32
@@ -357,8 +379,11 @@ func (snr *subnetAllocatorRange) markAllocatedNetwork(owner string, network *net
 	return false, alreadyOwnedError{str, existingOwner}
 }
 
-// allocateNetwork returns a new subnet, or nil if the range is full
+// allocateNetwork returns a new subnet, or nil if the range is full or draining
 func (snr *subnetAllocatorRange) allocateNetwork(owner string) *net.IPNet {
+	if snr.draining {
+		return nil
+	}
 	netMaskSize, addrLen := snr.network.Mask.Size()
 	numSubnets := uint32(1) << snr.subnetBits
 	if snr.subnetBits > 24 {
@@ -532,6 +532,46 @@ func TestMultipleSubnets(t *testing.T) {
 	}
 }
 
+func TestDrainNetworkRange(t *testing.T) {
+	sna, err := newSubnetAllocator("10.1.0.0/16", 18)
+	if err != nil {
+		t.Fatal("Failed to initialize subnet allocator: ", err)
+	}
+	err = sna.AddNetworkRange(ovntest.MustParseIPNet("10.2.0.0/16"), 18)
+	if err != nil {
+		t.Fatal("Failed to add network range: ", err)
+	}
+
+	if err := allocateExpected(sna, 0, "10.1.0.0/18"); err != nil {
+		t.Fatal(err)
+	}
+	if used := sna.DrainNetworkRange(ovntest.MustParseIPNet("10.1.0.0/16")); used != 1 {
+		t.Fatalf("Expected 1 network allocated from the drained range, got %d", used)
+	}
+	if used := sna.DrainNetworkRange(ovntest.MustParseIPNet("10.3.0.0/16")); used != 0 {
+		t.Fatalf("Expected no network allocated from an unknown range, got %d", used)
+	}
+
+	// networks are no longer allocated from the drained range
+	if err := allocateExpected(sna, 1, "10.2.0.0/18"); err != nil {
+		t.Fatal(err)
+	}
+
+	// networks allocated from the drained range can still be marked and released
+	if err := sna.MarkAllocatedNetworks(testNodeName, ovntest.MustParseIPNet("10.1.0.0/18")); err != nil {
+		t.Fatal(err)
+	}
+	if err := sna.ReleaseNetworks(testNodeName, ovntest.MustParseIPNet("10.1.0.0/18")); err != nil {
+		t.Fatal(err)
+	}
+	if used := sna.DrainNetworkRange(ovntest.MustParseIPNet("10.1.0.0/16")); used != 0 {
+		t.Fatalf("Expected no network allocated from the drained range, got %d", used)
+	}
+	if err := allocateExpected(sna, 2, "10.2.64.0/18"); err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestDualStack(t *testing.T) {
 	sna, err := newSubnetAllocator("10.1.0.0/16", 18)
 	if err != nil {
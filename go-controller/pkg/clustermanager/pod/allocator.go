@@ -13,8 +13,10 @@ import (
 	nettypes "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/id"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/ip"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/ip/subnet"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/pod"
+	ipamclaimapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/ipamclaim/v1"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
@@ -39,10 +41,15 @@ type PodAllocator struct {
 	// release more than once
 	releasedPods      map[string]sets.Set[string]
 	releasedPodsMutex sync.Mutex
+
+	// ipamClaims persists the IPs of workloads across pod restarts, nil if
+	// the network does not allow persistent IPs
+	ipamClaims *IPAMClaims
 }
 
-// NewPodAllocator builds a new PodAllocator
-func NewPodAllocator(netInfo util.NetInfo, podLister listers.PodLister, kube kube.Interface) *PodAllocator {
+// NewPodAllocator builds a new PodAllocator. ipamClaims can be nil if the
+// network does not allow persistent IPs.
+func NewPodAllocator(netInfo util.NetInfo, podLister listers.PodLister, kube kube.Interface, ipamClaims *IPAMClaims) *PodAllocator {
 	podAnnotationAllocator := pod.NewPodAnnotationAllocator(
 		netInfo,
 		podLister,
@@ -54,6 +61,7 @@ func NewPodAllocator(netInfo util.NetInfo, podLister listers.PodLister, kube kub
 		releasedPods:           map[string]sets.Set[string]{},
 		releasedPodsMutex:      sync.Mutex{},
 		podAnnotationAllocator: podAnnotationAllocator,
+		ipamClaims:             ipamClaims,
 	}
 
 	// this network might not have IPAM, we will just allocate MAC addresses
@@ -92,6 +100,22 @@ func (a *PodAllocator) Init() error {
 	return nil
 }
 
+// UpdateSubnets updates the allocator with the subnets and excluded subnets
+// currently configured for the network, retaining the existing allocations
+func (a *PodAllocator) UpdateSubnets() error {
+	if !util.DoesNetworkRequireIPAM(a.netInfo) {
+		return nil
+	}
+
+	subnets := a.netInfo.Subnets()
+	ipNets := make([]*net.IPNet, 0, len(subnets))
+	for _, subnet := range subnets {
+		ipNets = append(ipNets, subnet.CIDR)
+	}
+
+	return a.ipAllocator.ExpandSubnet(a.netInfo.GetNetworkName(), ipNets, a.netInfo.ExcludeSubnets()...)
+}
+
 // Reconcile allocates or releases IPs for pods updating the pod annotation
 // as necessary with all the additional information derived from those IPs
 func (a *PodAllocator) Reconcile(old, new *corev1.Pod) error {
@@ -204,6 +228,20 @@ func (a *PodAllocator) releasePodOnNAD(pod *corev1.Pod, nad string, podDeleted,
 	doReleaseIDs := doRelease && hasIDAllocation
 	doReleaseIPs := doRelease && hasIPAM
 
+	if doReleaseIPs && a.ipamClaims != nil {
+		// IPs claimed by the workload of the pod are not released
+		claimed, err := a.ipamClaims.releasePod(pod, nad)
+		if err != nil {
+			return fmt.Errorf("failed to release ipam claim of pod %s/%s and nad %s: %w",
+				pod.Namespace,
+				pod.Name,
+				nad,
+				err,
+			)
+		}
+		doReleaseIPs = !claimed
+	}
+
 	if doReleaseIDs {
 		name := podIdAllocationName(nad, uid)
 		a.idAllocator.ReleaseID(name)
@@ -248,6 +286,22 @@ func (a *PodAllocator) allocatePodOnNAD(pod *corev1.Pod, nad string, network *ne
 	// don't reallocate to new IPs if currently annotated IPs fail to alloccate
 	reallocate := false
 
+	claimed := a.ipamClaims != nil && a.ipamClaims.isClaimUser(nad, pod)
+	if a.ipamClaims != nil && !claimed && len(network.IPRequest) == 0 {
+		// request the IPs previously claimed by the workload of the pod, if
+		// any, falling back to new IPs if they can't be allocated anymore
+		claim, err := a.ipamClaims.getClaim(pod, nad)
+		if err != nil {
+			return err
+		}
+		if claim != nil && len(claim.Status.IPs) > 0 {
+			claimedNetwork := *network
+			claimedNetwork.IPRequest = claim.Status.IPs
+			network = &claimedNetwork
+			reallocate = true
+		}
+	}
+
 	updatedPod, podAnnotation, err := a.podAnnotationAllocator.AllocatePodAnnotationWithTunnelID(
 		ipAllocator,
 		idAllocator,
@@ -260,6 +314,13 @@ func (a *PodAllocator) allocatePodOnNAD(pod *corev1.Pod, nad string, network *ne
 		return err
 	}
 
+	if a.ipamClaims != nil && !claimed {
+		err = a.ipamClaims.ensureClaim(pod, a.netInfo.GetNetworkName(), nad, podAnnotation.IPs)
+		if err != nil {
+			return err
+		}
+	}
+
 	if updatedPod != nil {
 		klog.V(5).Infof("Allocated IP addresses %v, mac address %s, gateways %v, routes %s and tunnel id %d for pod %s/%s on nad %s",
 			util.StringSlice(podAnnotation.IPs),
@@ -274,6 +335,55 @@ func (a *PodAllocator) allocatePodOnNAD(pod *corev1.Pod, nad string, network *ne
 	return err
 }
 
+// SyncIPAMClaims reserves the IPs claimed by existing IPAMClaims of the network
+// so that they are not allocated to other pods. It needs to happen before pods
+// are synced.
+func (a *PodAllocator) SyncIPAMClaims(objs []interface{}) error {
+	for _, obj := range objs {
+		claim, ok := obj.(*ipamclaimapi.IPAMClaim)
+		if !ok {
+			klog.Errorf("Could not cast %T object to *ipamclaimapi.IPAMClaim", obj)
+			continue
+		}
+		if claim.Spec.Network != a.netInfo.GetNetworkName() || len(claim.Status.IPs) == 0 {
+			continue
+		}
+		ips, err := util.ParseIPNets(claim.Status.IPs)
+		if err != nil {
+			klog.Errorf("Failed to parse IPs %v of IPAMClaim %s/%s: %v", claim.Status.IPs, claim.Namespace, claim.Name, err)
+			continue
+		}
+		err = a.ipAllocator.AllocateIPs(a.netInfo.GetNetworkName(), ips)
+		if err != nil && !ip.IsErrAllocated(err) {
+			klog.Errorf("Failed to reserve IPs %v of IPAMClaim %s/%s: %v", claim.Status.IPs, claim.Namespace, claim.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// ReleaseIPAMClaim releases the IPs of a deleted IPAMClaim of the network
+// unless they are still in use by a pod, in which case they are released when
+// the pod is
+func (a *PodAllocator) ReleaseIPAMClaim(claim *ipamclaimapi.IPAMClaim) error {
+	if a.ipamClaims == nil || claim.Spec.Network != a.netInfo.GetNetworkName() {
+		return nil
+	}
+	if !a.ipamClaims.releaseClaim(claim) || len(claim.Status.IPs) == 0 {
+		return nil
+	}
+	ips, err := util.ParseIPNets(claim.Status.IPs)
+	if err != nil {
+		return fmt.Errorf("failed to parse IPs %v of IPAMClaim %s/%s: %w", claim.Status.IPs, claim.Namespace, claim.Name, err)
+	}
+	err = a.ipAllocator.ReleaseIPs(a.netInfo.GetNetworkName(), ips)
+	if err != nil {
+		return fmt.Errorf("failed to release IPs %v of IPAMClaim %s/%s: %w", claim.Status.IPs, claim.Namespace, claim.Name, err)
+	}
+	klog.V(5).Infof("Released IPs %v of IPAMClaim %s/%s", claim.Status.IPs, claim.Namespace, claim.Name)
+	return nil
+}
+
 func (a *PodAllocator) addReleasedPod(nad, uid string) {
 	a.releasedPodsMutex.Lock()
 	defer a.releasedPodsMutex.Unlock()
@@ -1,19 +1,27 @@
 package pod
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net"
+	"reflect"
 	"sync"
 	"testing"
 
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+
 	"github.com/stretchr/testify/mock"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/id"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/ip"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/ip/subnet"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/pod"
 	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ipamclaimapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/ipamclaim/v1"
+	ipamclaimfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/ipamclaim/v1/apis/clientset/versioned/fake"
+	ipamclaimlister "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/ipamclaim/v1/apis/listers/ipamclaim/v1"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
@@ -21,8 +29,14 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	apitypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/metadata/metadatalister"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	kubevirtv1 "kubevirt.io/api/core/v1"
 
 	kubemocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube/mocks"
 	v1mocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/k8s.io/client-go/listers/core/v1"
@@ -75,6 +89,10 @@ func (a *ipAllocatorStub) AddOrUpdateSubnet(name string, subnets []*net.IPNet, e
 	panic("not implemented") // TODO: Implement
 }
 
+func (a *ipAllocatorStub) ExpandSubnet(name string, subnets []*net.IPNet, excludeSubnets ...*net.IPNet) error {
+	panic("not implemented") // TODO: Implement
+}
+
 func (a ipAllocatorStub) DeleteSubnet(name string) {
 	panic("not implemented") // TODO: Implement
 }
@@ -480,3 +498,161 @@ func TestPodAllocator_reconcileForNAD(t *testing.T) {
 		})
 	}
 }
+
+func TestPodAllocator_persistentIPs(t *testing.T) {
+	config.OVNKubernetesFeature.EnableInterconnect = false
+
+	netInfo, err := util.NewNetInfo(&ovncnitypes.NetConf{
+		NetConf:            cnitypes.NetConf{Name: "tenantred"},
+		Topology:           types.Layer2Topology,
+		Subnets:            "10.1.130.0/24",
+		AllowPersistentIPs: true,
+	})
+	if err != nil {
+		t.Fatalf("Invalid netConf: %v", err)
+	}
+	netInfo.AddNAD("namespace/nad")
+
+	// the pod lister returns the last pod handed to the allocator
+	var current *corev1.Pod
+	podListerMock := &v1mocks.PodLister{}
+	podNamespaceLister := &v1mocks.PodNamespaceLister{}
+	podListerMock.On("Pods", mock.AnythingOfType("string")).Return(podNamespaceLister)
+	podNamespaceLister.On("Get", mock.AnythingOfType("string")).Return(
+		func(string) *corev1.Pod { return current },
+		func(string) error { return nil },
+	)
+	kubeMock := &kubemocks.Interface{}
+	kubeMock.On("UpdatePodStatus", mock.AnythingOfType(fmt.Sprintf("%T", &corev1.Pod{}))).Run(
+		func(args mock.Arguments) {
+			current = args.Get(0).(*corev1.Pod)
+		},
+	).Return(nil)
+
+	claimClient := ipamclaimfake.NewSimpleClientset()
+	claimIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	claimClient.PrependReactor("*", "ipamclaims", func(action clienttesting.Action) (bool, runtime.Object, error) {
+		// keep the lister in sync with the client
+		switch action := action.(type) {
+		case clienttesting.CreateAction:
+			claim := action.GetObject().(*ipamclaimapi.IPAMClaim)
+			claim.UID = apitypes.UID(claim.Name)
+			_ = claimIndexer.Add(claim)
+		case clienttesting.UpdateAction:
+			_ = claimIndexer.Update(action.GetObject())
+		}
+		return false, nil, nil
+	})
+	vmIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	_ = vmIndexer.Add(&metav1.PartialObjectMetadata{
+		TypeMeta:   metav1.TypeMeta{APIVersion: kubevirtv1.GroupVersion.String(), Kind: "VirtualMachine"},
+		ObjectMeta: metav1.ObjectMeta{Name: "vm", Namespace: "namespace", UID: "vm"},
+	})
+	vmLister := metadatalister.New(vmIndexer, kubevirtv1.GroupVersion.WithResource("virtualmachines"))
+	ipamClaims := NewIPAMClaims(claimClient, ipamclaimlister.NewIPAMClaimLister(claimIndexer), vmLister)
+
+	a := NewPodAllocator(netInfo, podListerMock, kubeMock, ipamClaims)
+	if err := a.Init(); err != nil {
+		t.Fatalf("Failed to init allocator: %v", err)
+	}
+
+	network := &nadapi.NetworkSelectionElement{Namespace: "namespace", Name: "nad"}
+	statefulSetPod := func(uid string) *corev1.Pod {
+		pod := testPod{scheduled: true, network: network}.getPod(t)
+		pod.UID = apitypes.UID(uid)
+		pod.OwnerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(&metav1.ObjectMeta{Name: "sts", UID: "sts"}, schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "StatefulSet"}),
+		}
+		return pod
+	}
+	virtLauncherPod := func(uid, vmName string) *corev1.Pod {
+		pod := testPod{scheduled: true, network: network}.getPod(t)
+		pod.UID = apitypes.UID(uid)
+		pod.Labels = map[string]string{kubevirtv1.VirtualMachineNameLabel: vmName}
+		pod.OwnerReferences = []metav1.OwnerReference{
+			*metav1.NewControllerRef(&metav1.ObjectMeta{Name: vmName, UID: apitypes.UID(vmName + "-vmi")}, kubevirtv1.VirtualMachineInstanceGroupVersionKind),
+		}
+		return pod
+	}
+	allocate := func(pod *corev1.Pod) []*net.IPNet {
+		current = pod
+		if err := a.Reconcile(nil, pod); err != nil {
+			t.Fatalf("Failed to allocate pod %s: %v", pod.UID, err)
+		}
+		podAnnotation, err := util.UnmarshalPodAnnotation(current.Annotations, "namespace/nad")
+		if err != nil {
+			t.Fatalf("Failed to get annotation of pod %s: %v", pod.UID, err)
+		}
+		return podAnnotation.IPs
+	}
+	isAllocated := func(ips []*net.IPNet) bool {
+		err := a.ipAllocator.AllocateIPs(netInfo.GetNetworkName(), ips)
+		if err == nil {
+			_ = a.ipAllocator.ReleaseIPs(netInfo.GetNetworkName(), ips)
+		}
+		return ip.IsErrAllocated(err)
+	}
+
+	first := statefulSetPod("first")
+	ips := allocate(first)
+	claim, err := claimClient.K8sV1().IPAMClaims("namespace").Get(context.TODO(), "statefulset.pod.namespace.nad", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected IPAMClaim to be created: %v", err)
+	}
+	if !reflect.DeepEqual(claim.Status.IPs, util.StringSlice(ips)) {
+		t.Errorf("Expected IPAMClaim to claim IPs %v but it claims %v", util.StringSlice(ips), claim.Status.IPs)
+	}
+	if len(claim.OwnerReferences) != 1 || claim.OwnerReferences[0].UID != "sts" {
+		t.Errorf("Expected IPAMClaim to be owned by the StatefulSet but it was owned by %v", claim.OwnerReferences)
+	}
+
+	first = current
+	if err := a.Reconcile(first, nil); err != nil {
+		t.Fatalf("Failed to release pod: %v", err)
+	}
+	if !isAllocated(ips) {
+		t.Errorf("Expected claimed IPs %v to remain allocated after pod deletion", util.StringSlice(ips))
+	}
+
+	second := statefulSetPod("second")
+	if reused := allocate(second); !reflect.DeepEqual(reused, ips) {
+		t.Errorf("Expected claimed IPs %v to be reused but got %v", util.StringSlice(ips), util.StringSlice(reused))
+	}
+
+	second = current
+
+	// the claim is deleted while in use, IPs are released with the last pod
+	if err := a.ReleaseIPAMClaim(claim); err != nil {
+		t.Fatalf("Failed to release IPAMClaim: %v", err)
+	}
+	_ = claimIndexer.Delete(claim)
+	if !isAllocated(ips) {
+		t.Errorf("Expected claimed IPs %v to remain allocated while in use", util.StringSlice(ips))
+	}
+	if err := a.Reconcile(second, nil); err != nil {
+		t.Fatalf("Failed to release pod: %v", err)
+	}
+	if isAllocated(ips) {
+		t.Errorf("Expected claimed IPs %v to be released", util.StringSlice(ips))
+	}
+
+	// the IPs of a VM are claimed for the VM
+	allocate(virtLauncherPod("vm-pod", "vm"))
+	claim, err = claimClient.K8sV1().IPAMClaims("namespace").Get(context.TODO(), "virtualmachine.vm.namespace.nad", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected IPAMClaim of the VM to be created: %v", err)
+	}
+	if len(claim.OwnerReferences) != 1 || claim.OwnerReferences[0].UID != "vm" {
+		t.Errorf("Expected IPAMClaim to be owned by the VM but it was owned by %v", claim.OwnerReferences)
+	}
+
+	// the IPs of a standalone VMI are claimed for the VMI
+	allocate(virtLauncherPod("vmi-pod", "vmi"))
+	claim, err = claimClient.K8sV1().IPAMClaims("namespace").Get(context.TODO(), "virtualmachineinstance.vmi.namespace.nad", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Expected IPAMClaim of the VMI to be created: %v", err)
+	}
+	if len(claim.OwnerReferences) != 1 || claim.OwnerReferences[0].UID != "vmi-vmi" {
+		t.Errorf("Expected IPAMClaim to be owned by the VMI but it was owned by %v", claim.OwnerReferences)
+	}
+}
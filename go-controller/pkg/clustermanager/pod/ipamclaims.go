@@ -0,0 +1,254 @@
+package pod
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/metadata/metadatalister"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	kubevirtv1 "kubevirt.io/api/core/v1"
+
+	ipamclaimapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/ipamclaim/v1"
+	ipamclaimclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/ipamclaim/v1/apis/clientset/versioned"
+	ipamclaimlister "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/ipamclaim/v1/apis/listers/ipamclaim/v1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// IPAMClaims persists the IPs allocated to workloads (StatefulSet pods and
+// KubeVirt VMs) on a network in IPAMClaims, so that they survive the deletion
+// of the workload pods and are reused when the workload returns. Claims are
+// owned by the workload and garbage collected along with it; their IPs are
+// released once the claim is deleted and no pod uses them anymore.
+type IPAMClaims struct {
+	client ipamclaimclientset.Interface
+	lister ipamclaimlister.IPAMClaimLister
+	// vmLister lists the metadata of KubeVirt VMs, nil if KubeVirt is not
+	// installed
+	vmLister metadatalister.Lister
+
+	// track the pods using each claim, and the claims that have been deleted
+	// while still in use, so that their IPs are released only once by
+	// whichever comes last
+	claimUsers    map[ktypes.UID]sets.Set[string]
+	podClaims     map[string]ktypes.UID
+	deletedClaims sets.Set[ktypes.UID]
+	lock          sync.Mutex
+}
+
+// NewIPAMClaims builds a new IPAMClaims. vmLister can be nil if KubeVirt is
+// not installed.
+func NewIPAMClaims(client ipamclaimclientset.Interface, lister ipamclaimlister.IPAMClaimLister, vmLister metadatalister.Lister) *IPAMClaims {
+	return &IPAMClaims{
+		client:        client,
+		lister:        lister,
+		vmLister:      vmLister,
+		claimUsers:    map[ktypes.UID]sets.Set[string]{},
+		podClaims:     map[string]ktypes.UID{},
+		deletedClaims: sets.New[ktypes.UID](),
+	}
+}
+
+// claimOwner returns the name of the workload the pod belongs to and the
+// reference to the object that owns the IPAMClaims of that workload, or nil if
+// the pod does not belong to a workload with persistent IPs. The VM of a
+// KubeVirt pod is looked up by the name of the VMI controlling the pod, which
+// is the same.
+func (c *IPAMClaims) claimOwner(pod *corev1.Pod) (string, *metav1.OwnerReference, error) {
+	controller := metav1.GetControllerOf(pod)
+	if controller == nil {
+		return "", nil, nil
+	}
+
+	if vmName, ok := pod.Labels[kubevirtv1.VirtualMachineNameLabel]; ok {
+		vm, err := c.getVirtualMachine(pod.Namespace, vmName)
+		if apierrors.IsNotFound(err) {
+			// a standalone VMI, claim the IPs for the lifetime of the VMI
+			if controller.Kind != kubevirtv1.VirtualMachineInstanceGroupVersionKind.Kind {
+				return "", nil, nil
+			}
+			return vmName, ownerReference(controller.APIVersion, controller.Kind, controller.Name, controller.UID), nil
+		}
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to get virtual machine %s/%s: %w", pod.Namespace, vmName, err)
+		}
+		gvk := kubevirtv1.VirtualMachineGroupVersionKind
+		return vmName, ownerReference(gvk.GroupVersion().String(), gvk.Kind, vm.Name, vm.UID), nil
+	}
+
+	if controller.Kind == "StatefulSet" {
+		// StatefulSet pods have a stable name across restarts
+		return pod.Name, ownerReference(controller.APIVersion, controller.Kind, controller.Name, controller.UID), nil
+	}
+
+	return "", nil, nil
+}
+
+func ownerReference(apiVersion, kind, name string, uid ktypes.UID) *metav1.OwnerReference {
+	blockOwnerDeletion := false
+	return &metav1.OwnerReference{
+		APIVersion:         apiVersion,
+		Kind:               kind,
+		Name:               name,
+		UID:                uid,
+		BlockOwnerDeletion: &blockOwnerDeletion,
+	}
+}
+
+func (c *IPAMClaims) getVirtualMachine(namespace, name string) (*metav1.PartialObjectMetadata, error) {
+	if c.vmLister == nil {
+		return nil, apierrors.NewNotFound(kubevirtv1.Resource("virtualmachines"), name)
+	}
+	return c.vmLister.Namespace(namespace).Get(name)
+}
+
+// claimName returns the name of the IPAMClaim of the given workload, owned by
+// an object of the given kind, on the given NAD. All of them are part of the
+// name so that workloads of different kinds, or NADs of different namespaces,
+// don't share a claim.
+func claimName(workload string, owner *metav1.OwnerReference, nad string) string {
+	nadNamespace, nadName, _ := cache.SplitMetaNamespaceKey(nad)
+	return fmt.Sprintf("%s.%s.%s.%s", strings.ToLower(owner.Kind), workload, nadNamespace, nadName)
+}
+
+// getClaim returns the IPAMClaim of the given pod on the given NAD, or nil if
+// the pod does not belong to a workload with persistent IPs or its claim does
+// not exist yet
+func (c *IPAMClaims) getClaim(pod *corev1.Pod, nad string) (*ipamclaimapi.IPAMClaim, error) {
+	workload, owner, err := c.claimOwner(pod)
+	if err != nil || owner == nil {
+		return nil, err
+	}
+	claim, err := c.lister.IPAMClaims(pod.Namespace).Get(claimName(workload, owner, nad))
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if claim.DeletionTimestamp != nil {
+		return nil, nil
+	}
+	return claim, nil
+}
+
+// ensureClaim ensures the IPAMClaim of the given pod on the given NAD exists
+// and claims the given IPs, tracking the pod as a user of the claim
+func (c *IPAMClaims) ensureClaim(pod *corev1.Pod, network, nad string, ips []*net.IPNet) error {
+	workload, owner, err := c.claimOwner(pod)
+	if err != nil || owner == nil {
+		return err
+	}
+
+	name := claimName(workload, owner, nad)
+	claim, err := c.lister.IPAMClaims(pod.Namespace).Get(name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get IPAMClaim %s/%s: %w", pod.Namespace, name, err)
+	}
+
+	if claim == nil {
+		claim = &ipamclaimapi.IPAMClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       pod.Namespace,
+				OwnerReferences: []metav1.OwnerReference{*owner},
+			},
+			Spec: ipamclaimapi.IPAMClaimSpec{
+				Network:                     network,
+				NetworkAttachmentDefinition: nad,
+			},
+		}
+		claim, err = c.client.K8sV1().IPAMClaims(pod.Namespace).Create(context.TODO(), claim, metav1.CreateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to create IPAMClaim %s/%s: %w", pod.Namespace, name, err)
+		}
+		klog.V(5).Infof("Created IPAMClaim %s/%s for pod %s/%s", pod.Namespace, name, pod.Namespace, pod.Name)
+	}
+
+	claimedIPs := util.StringSlice(ips)
+	if !sets.New(claim.Status.IPs...).Equal(sets.New(claimedIPs...)) {
+		claim = claim.DeepCopy()
+		claim.Status.IPs = claimedIPs
+		_, err = c.client.K8sV1().IPAMClaims(pod.Namespace).UpdateStatus(context.TODO(), claim, metav1.UpdateOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to update IPAMClaim %s/%s with IPs %v: %w", pod.Namespace, name, claimedIPs, err)
+		}
+	}
+
+	c.addClaimUser(claim.UID, podIdAllocationName(nad, string(pod.UID)))
+	return nil
+}
+
+// isClaimUser returns whether the given pod is tracked as a user of its
+// IPAMClaim on the given NAD
+func (c *IPAMClaims) isClaimUser(nad string, pod *corev1.Pod) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, tracked := c.podClaims[podIdAllocationName(nad, string(pod.UID))]
+	return tracked
+}
+
+func (c *IPAMClaims) addClaimUser(claim ktypes.UID, podKey string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.claimUsers[claim] == nil {
+		c.claimUsers[claim] = sets.New[string]()
+	}
+	c.claimUsers[claim].Insert(podKey)
+	c.podClaims[podKey] = claim
+}
+
+// releasePod stops tracking the given pod as a user of its IPAMClaim on the
+// given NAD. Returns whether the IPs of the pod are claimed, and as such should
+// not be released, or else should be released as usual.
+func (c *IPAMClaims) releasePod(pod *corev1.Pod, nad string) (bool, error) {
+	podKey := podIdAllocationName(nad, string(pod.UID))
+
+	c.lock.Lock()
+	claim, tracked := c.podClaims[podKey]
+	if tracked {
+		delete(c.podClaims, podKey)
+		users := c.claimUsers[claim]
+		users.Delete(podKey)
+		if users.Len() > 0 || !c.deletedClaims.Has(claim) {
+			c.lock.Unlock()
+			return true, nil
+		}
+		// last user of a deleted claim, release the IPs
+		delete(c.claimUsers, claim)
+		c.deletedClaims.Delete(claim)
+		c.lock.Unlock()
+		return false, nil
+	}
+	c.lock.Unlock()
+
+	// the pod might not be tracked if its claim was found on a previous run,
+	// keep its IPs as long as the claim exists
+	existing, err := c.getClaim(pod, nad)
+	if err != nil {
+		return false, err
+	}
+	return existing != nil, nil
+}
+
+// releaseClaim handles the deletion of an IPAMClaim. Returns whether the
+// claimed IPs should be released, which happens if no pod uses them anymore.
+func (c *IPAMClaims) releaseClaim(claim *ipamclaimapi.IPAMClaim) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.claimUsers[claim.UID].Len() > 0 {
+		// the last pod to go releases the IPs
+		c.deletedClaims.Insert(claim.UID)
+		return false
+	}
+	delete(c.claimUsers, claim.UID)
+	return true
+}
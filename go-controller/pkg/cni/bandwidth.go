@@ -8,7 +8,8 @@ import (
 	"github.com/pkg/errors"
 )
 
-func clearPodBandwidth(sandboxID string) error {
+// ClearPodBandwidth removes the QoS configured on the OVS ports of the given sandbox
+func ClearPodBandwidth(sandboxID string) error {
 	// interfaces will have the same name as ports
 	portList, err := ovsFind("interface", "name", "external-ids:sandbox="+sandboxID)
 	if err != nil {
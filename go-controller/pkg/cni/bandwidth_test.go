@@ -105,7 +105,7 @@ func TestClearPodBandwidth(t *testing.T) {
 			// note runner is defined in pkg/cni/ovs.go file
 			runner = tc.runnerInstance
 
-			e := clearPodBandwidth("sandboxID")
+			e := ClearPodBandwidth("sandboxID")
 
 			if tc.expectedErr {
 				assert.Error(t, e)
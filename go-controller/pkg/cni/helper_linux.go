@@ -455,7 +455,7 @@ func ConfigureOVS(ctx context.Context, namespace, podName, hostIfaceName string,
 		return fmt.Errorf("failure in plugging pod interface: %v\n  %q", err, out)
 	}
 
-	if err := clearPodBandwidth(sandboxID); err != nil {
+	if err := ClearPodBandwidth(sandboxID); err != nil {
 		return err
 	}
 
@@ -649,8 +649,8 @@ func (pr *PodRequest) UnconfigureInterface(ifInfo *PodInterfaceInfo) error {
 	ifName := pr.SandboxID[:(15-len(ifnameSuffix))] + ifnameSuffix
 	pr.deletePorts(ifName, pr.PodNamespace, pr.PodName)
 
-	if err := clearPodBandwidth(pr.SandboxID); err != nil {
-		klog.Warningf("Failed to clearPodBandwidth sandbox %v %s: %v", pr.SandboxID, podDesc, err)
+	if err := ClearPodBandwidth(pr.SandboxID); err != nil {
+		klog.Warningf("Failed to clear pod bandwidth of sandbox %v %s: %v", pr.SandboxID, podDesc, err)
 	}
 	pr.deletePodConntrack()
 	return nil
@@ -27,6 +27,14 @@ type NetConf struct {
 	ExcludeSubnets string `json:"excludeSubnets,omitempty"`
 	// VLANID, valid in localnet topology network only
 	VLANID int `json:"vlanID,omitempty"`
+	// AllowPersistentIPs persists the IPs of the workloads (StatefulSet pods
+	// or KubeVirt VMs) attached to the network across pod restarts in
+	// IPAMClaims, valid for layer2 and localnet network topologies only
+	AllowPersistentIPs bool `json:"allowPersistentIPs,omitempty"`
+	// EnableGateway creates per-node gateway routers for the network that
+	// provide north-south egress through the node's external bridge, valid for
+	// layer3 network topology only
+	EnableGateway bool `json:"enableGateway,omitempty"`
 
 	// PciAddrs in case of using sriov or Auxiliry device name in case of SF
 	DeviceID string `json:"deviceID,omitempty"`
@@ -124,7 +124,11 @@ var (
 
 	// OVNKubernetesFeatureConfig holds OVN-Kubernetes feature enhancement config file parameters and command-line overrides
 	OVNKubernetesFeature = OVNKubernetesFeatureConfig{
-		EgressIPReachabiltyTotalTimeout: 1,
+		EgressIPReachabiltyTotalTimeout:  1,
+		EgressFirewallDNSMaxLearnedNames: 1000,
+		EgressFirewallDNSService:         "kube-system/kube-dns",
+		EgressIPBFDInterval:              100,
+		EgressIPBFDMultiplier:            3,
 	}
 
 	// OvnNorth holds northbound OVN database client and server authentication and location details
@@ -139,6 +143,7 @@ var (
 		V6JoinSubnet:       "fd98::/64",
 		V4MasqueradeSubnet: "169.254.169.0/29",
 		V6MasqueradeSubnet: "fd69::/125",
+		RuleBackend:        GatewayRuleBackendIPTables,
 		MasqueradeIPs: MasqueradeIPsConfig{
 			V4OVNMasqueradeIP:               net.ParseIP("169.254.169.1"),
 			V6OVNMasqueradeIP:               net.ParseIP("fd69::1"),
@@ -196,6 +201,12 @@ var (
 		V4TransitSwitchSubnet: "100.88.0.0/16",
 		V6TransitSwitchSubnet: "fd97::/64",
 	}
+
+	// COPP holds the control plane protection meters configuration
+	COPP = COPPConfig{
+		Rate: 25,
+		Fair: true,
+	}
 )
 
 const (
@@ -400,9 +411,33 @@ type OVNKubernetesFeatureConfig struct {
 	EgressIPNodeHealthCheckPort     int  `gcfg:"egressip-node-healthcheck-port"`
 	EnableMultiNetwork              bool `gcfg:"enable-multi-network"`
 	EnableMultiNetworkPolicy        bool `gcfg:"enable-multi-networkpolicy"`
+	EnableMultiNetworkServices      bool `gcfg:"enable-multi-network-services"`
+	EnablePersistentIPs             bool `gcfg:"enable-persistent-ips"`
+	EnableNodeSubnetExpansion       bool `gcfg:"enable-node-subnet-expansion"`
 	EnableStatelessNetPol           bool `gcfg:"enable-stateless-netpol"`
 	EnableInterconnect              bool `gcfg:"enable-interconnect"`
 	EnableMultiExternalGateway      bool `gcfg:"enable-multi-external-gateway"`
+
+	// EgressFirewallDNSResponseAddress is the TCP address on which the dnstap messages of the cluster DNS pods are
+	// received to learn the names matching wildcard EgressFirewall dnsNames
+	EgressFirewallDNSResponseAddress string `gcfg:"egressfirewall-dns-response-address"`
+	// EgressFirewallDNSService is the namespace/name of the cluster DNS service, only its endpoints are allowed
+	// to send dnstap messages
+	EgressFirewallDNSService string `gcfg:"egressfirewall-dns-service"`
+	// EgressFirewallDNSMaxLearnedNames is the maximum number of learned names cached per wildcard dnsName
+	EgressFirewallDNSMaxLearnedNames int `gcfg:"egressfirewall-dns-max-learned-names"`
+	// EgressIPTopologySpreadKey is the node label whose values define the failure domains across which the egress
+	// IPs of an EgressIP are spread, at most one per failure domain. Spreading is disabled when empty.
+	EgressIPTopologySpreadKey string `gcfg:"egressip-topology-spread-key"`
+	// EnableEgressIPBFD enables BFD sessions between the gateway routers of the egress nodes to detect egress node
+	// failures in sub-second time. It is not supported with interconnect.
+	EnableEgressIPBFD bool `gcfg:"enable-egressip-bfd"`
+	// EgressIPBFDInterval is the transmit and receive interval of the egress node BFD sessions, in milliseconds
+	EgressIPBFDInterval int `gcfg:"egressip-bfd-interval"`
+	// EgressIPBFDMultiplier is the number of BFD packets an egress node can miss before being considered down
+	EgressIPBFDMultiplier int `gcfg:"egressip-bfd-multiplier"`
+	// EnableServiceHealthChecks allows services to opt in to OVN load balancer health checks
+	EnableServiceHealthChecks bool `gcfg:"enable-service-health-checks"`
 }
 
 // GatewayMode holds the node gateway mode
@@ -417,6 +452,20 @@ const (
 	GatewayModeLocal GatewayMode = "local"
 )
 
+// GatewayRuleBackend holds the backend used to program the node service and egress service NAT
+// rules. The other host rules, such as the masquerade and forwarding rules, the management port
+// SNAT chain and the rules of the node iptables controller, are always programmed with iptables,
+// so a node using the nftables backend programs rules with both.
+// TODO: move the remaining host rules behind the rule backend.
+type GatewayRuleBackend string
+
+const (
+	// GatewayRuleBackendIPTables programs the service and egress service NAT rules with iptables
+	GatewayRuleBackendIPTables GatewayRuleBackend = "iptables"
+	// GatewayRuleBackendNFTables programs the service and egress service NAT rules with native nftables transactions
+	GatewayRuleBackendNFTables GatewayRuleBackend = "nftables"
+)
+
 // GatewayConfig holds node gateway-related parsed config file parameters and command-line overrides
 type GatewayConfig struct {
 	// Mode is the gateway mode; if may be either empty (disabled), "shared", or "local"
@@ -458,6 +507,8 @@ type GatewayConfig struct {
 	DisableForwarding bool `gcfg:"disable-forwarding"`
 	// AllowNoUplink (disabled by default) controls if the external gateway bridge without an uplink port is allowed in local gateway mode.
 	AllowNoUplink bool `gcfg:"allow-no-uplink"`
+	// RuleBackend is the backend used for the node service and egress service rules; either "iptables" (default) or "nftables"
+	RuleBackend GatewayRuleBackend `gcfg:"rule-backend"`
 }
 
 // OvnAuthConfig holds client authentication and location details for
@@ -515,6 +566,53 @@ type ClusterManagerConfig struct {
 	V6TransitSwitchSubnet string `gcfg:"v6-transit-switch-subnet"`
 }
 
+// COPPConfig holds the configuration of the control plane protection (COPP)
+// meters set on the OVN routers. Each protocol (arp, bfd, reject, ...) gets its
+// own meter using the default values unless overridden for that protocol.
+type COPPConfig struct {
+	// Rate is the default rate, in packets per second, of the protocol meters
+	Rate int `gcfg:"rate"`
+	// Burst is the default burst size, in packets, of the protocol meters
+	Burst int `gcfg:"burst"`
+	// Fair indicates whether the protocol meters are fair by default, i.e. if
+	// the rate is enforced per logical flow instead of being shared by all of them
+	Fair bool `gcfg:"fair"`
+	// RawProtocolMeters holds the unparsed per protocol overrides.
+	// Should only be used inside config module.
+	RawProtocolMeters string `gcfg:"protocol-meters"`
+	// ProtocolMeters holds the parsed per protocol overrides, keyed by protocol
+	ProtocolMeters map[string]COPPMeterConfig
+}
+
+// COPPProtocols are the protocols of the OVN control plane protection that get
+// a meter in the default COPP of the gateway routers, as named by OVN
+var COPPProtocols = []string{
+	"arp",
+	"arp-resolve",
+	"bfd",
+	"event-elb",
+	"icmp4-error",
+	"icmp6-error",
+	"reject",
+	"tcp-reset",
+	"svc-monitor",
+}
+
+// COPPMeterConfig holds the configuration of the COPP meter of a protocol
+type COPPMeterConfig struct {
+	Rate  int
+	Burst int
+	Fair  bool
+}
+
+// GetMeterConfig returns the configuration of the COPP meter of the given protocol
+func (c *COPPConfig) GetMeterConfig(protocol string) COPPMeterConfig {
+	if meter, ok := c.ProtocolMeters[protocol]; ok {
+		return meter
+	}
+	return COPPMeterConfig{Rate: c.Rate, Burst: c.Burst, Fair: c.Fair}
+}
+
 // OvnDBScheme describes the OVN database connection transport method
 type OvnDBScheme string
 
@@ -545,6 +643,7 @@ type config struct {
 	HybridOverlay        HybridOverlayConfig
 	OvnKubeNode          OvnKubeNodeConfig
 	ClusterManager       ClusterManagerConfig
+	COPP                 COPPConfig
 }
 
 var (
@@ -564,6 +663,7 @@ var (
 	savedHybridOverlay        HybridOverlayConfig
 	savedOvnKubeNode          OvnKubeNodeConfig
 	savedClusterManager       ClusterManagerConfig
+	savedCOPP                 COPPConfig
 
 	// legacy service-cluster-ip-range CLI option
 	serviceClusterIPRange string
@@ -595,6 +695,7 @@ func init() {
 	savedHybridOverlay = HybridOverlay
 	savedOvnKubeNode = OvnKubeNode
 	savedClusterManager = ClusterManager
+	savedCOPP = COPP
 	cli.VersionPrinter = func(c *cli.Context) {
 		fmt.Printf("Version: %s\n", Version)
 		fmt.Printf("Git commit: %s\n", Commit)
@@ -625,6 +726,7 @@ func PrepareTestConfig() error {
 	HybridOverlay = savedHybridOverlay
 	OvnKubeNode = savedOvnKubeNode
 	ClusterManager = savedClusterManager
+	COPP = savedCOPP
 	EnableMulticast = false
 
 	if err := completeConfig(); err != nil {
@@ -994,6 +1096,24 @@ var OVNK8sFeatureFlags = []cli.Flag{
 		Destination: &cliConfig.OVNKubernetesFeature.EnableEgressFirewall,
 		Value:       OVNKubernetesFeature.EnableEgressFirewall,
 	},
+	&cli.StringFlag{
+		Name: "egressfirewall-dns-response-address",
+		Usage: "TCP address (host:port) on which the dnstap messages of the cluster DNS pods are received. " +
+			"Names matching wildcard EgressFirewall dnsNames are learned from the responses to their client queries.",
+		Destination: &cliConfig.OVNKubernetesFeature.EgressFirewallDNSResponseAddress,
+	},
+	&cli.StringFlag{
+		Name:        "egressfirewall-dns-service",
+		Usage:       "Namespace/name of the cluster DNS service whose endpoints send dnstap messages (default: kube-system/kube-dns)",
+		Destination: &cliConfig.OVNKubernetesFeature.EgressFirewallDNSService,
+		Value:       OVNKubernetesFeature.EgressFirewallDNSService,
+	},
+	&cli.IntFlag{
+		Name:        "egressfirewall-dns-max-learned-names",
+		Usage:       "Maximum number of names learned and cached for each wildcard EgressFirewall dnsName (default: 1000)",
+		Destination: &cliConfig.OVNKubernetesFeature.EgressFirewallDNSMaxLearnedNames,
+		Value:       OVNKubernetesFeature.EgressFirewallDNSMaxLearnedNames,
+	},
 	&cli.BoolFlag{
 		Name:        "enable-egress-qos",
 		Usage:       "Configure to use EgressQoS CRD feature with ovn-kubernetes.",
@@ -1005,6 +1125,31 @@ var OVNK8sFeatureFlags = []cli.Flag{
 		Usage:       "Configure EgressIP node reachability using gRPC on this TCP port.",
 		Destination: &cliConfig.OVNKubernetesFeature.EgressIPNodeHealthCheckPort,
 	},
+	&cli.StringFlag{
+		Name: "egressip-topology-spread-key",
+		Usage: "Node label (e.g. topology.kubernetes.io/zone) whose values define the failure domains across which " +
+			"the egress IPs of an EgressIP are spread, at most one per failure domain. Disabled when empty.",
+		Destination: &cliConfig.OVNKubernetesFeature.EgressIPTopologySpreadKey,
+	},
+	&cli.BoolFlag{
+		Name: "enable-egressip-bfd",
+		Usage: "Detect egress node failures with BFD sessions between the gateway routers of the egress nodes, " +
+			"on top of the EgressIP node reachability checks.",
+		Destination: &cliConfig.OVNKubernetesFeature.EnableEgressIPBFD,
+		Value:       OVNKubernetesFeature.EnableEgressIPBFD,
+	},
+	&cli.IntFlag{
+		Name:        "egressip-bfd-interval",
+		Usage:       "Transmit and receive interval of the egress node BFD sessions in milliseconds (default: 100)",
+		Destination: &cliConfig.OVNKubernetesFeature.EgressIPBFDInterval,
+		Value:       OVNKubernetesFeature.EgressIPBFDInterval,
+	},
+	&cli.IntFlag{
+		Name:        "egressip-bfd-multiplier",
+		Usage:       "Number of BFD packets an egress node can miss before being considered down (default: 3)",
+		Destination: &cliConfig.OVNKubernetesFeature.EgressIPBFDMultiplier,
+		Value:       OVNKubernetesFeature.EgressIPBFDMultiplier,
+	},
 	&cli.BoolFlag{
 		Name:        "enable-multi-network",
 		Usage:       "Configure to use multiple NetworkAttachmentDefinition CRD feature with ovn-kubernetes.",
@@ -1017,6 +1162,26 @@ var OVNK8sFeatureFlags = []cli.Flag{
 		Destination: &cliConfig.OVNKubernetesFeature.EnableMultiNetworkPolicy,
 		Value:       OVNKubernetesFeature.EnableMultiNetworkPolicy,
 	},
+	&cli.BoolFlag{
+		Name:        "enable-multi-network-services",
+		Usage:       "Configure to expose services annotated with k8s.v1.cni.cncf.io/service-network on secondary layer2 and layer3 networks.",
+		Destination: &cliConfig.OVNKubernetesFeature.EnableMultiNetworkServices,
+		Value:       OVNKubernetesFeature.EnableMultiNetworkServices,
+	},
+	&cli.BoolFlag{
+		Name: "enable-persistent-ips",
+		Usage: "Configure to persist in IPAMClaims the IPs of StatefulSet pods and KubeVirt VMs attached to " +
+			"secondary layer2 and localnet networks with allowPersistentIPs set.",
+		Destination: &cliConfig.OVNKubernetesFeature.EnablePersistentIPs,
+		Value:       OVNKubernetesFeature.EnablePersistentIPs,
+	},
+	&cli.BoolFlag{
+		Name: "enable-node-subnet-expansion",
+		Usage: "Configure to add cluster subnet ranges at runtime through ClusterNetwork CRDs and to allocate " +
+			"additional host subnets to nodes whose pod range is exhausted.",
+		Destination: &cliConfig.OVNKubernetesFeature.EnableNodeSubnetExpansion,
+		Value:       OVNKubernetesFeature.EnableNodeSubnetExpansion,
+	},
 	&cli.BoolFlag{
 		Name:        "enable-stateless-netpol",
 		Usage:       "Configure to use stateless network policy feature with ovn-kubernetes.",
@@ -1041,6 +1206,13 @@ var OVNK8sFeatureFlags = []cli.Flag{
 		Destination: &cliConfig.OVNKubernetesFeature.EnableMultiExternalGateway,
 		Value:       OVNKubernetesFeature.EnableMultiExternalGateway,
 	},
+	&cli.BoolFlag{
+		Name: "enable-service-health-checks",
+		Usage: "Configure to allow services annotated with k8s.ovn.org/lb-health-check to use OVN load balancer " +
+			"health checks. A well known address of each node subnet is reserved as the health check source.",
+		Destination: &cliConfig.OVNKubernetesFeature.EnableServiceHealthChecks,
+		Value:       OVNKubernetesFeature.EnableServiceHealthChecks,
+	},
 }
 
 // K8sFlags capture Kubernetes-related options
@@ -1341,6 +1513,12 @@ var OVNGatewayFlags = []cli.Flag{
 		Usage:       "Disable forwarding on OVNK controlled interfaces.",
 		Destination: &cliConfig.Gateway.DisableForwarding,
 	},
+	&cli.StringFlag{
+		Name: "gateway-rule-backend",
+		Usage: "The backend used to program the node service and egress service NAT rules. " +
+			"One of \"iptables\" or \"nftables\". The other host rules are always programmed with iptables.",
+		Value: string(Gateway.RuleBackend),
+	},
 	&cli.StringFlag{
 		Name:        "gateway-v4-join-subnet",
 		Usage:       "The v4 join subnet used for assigning join switch IPv4 addresses",
@@ -1518,6 +1696,36 @@ var ClusterManagerFlags = []cli.Flag{
 	},
 }
 
+// COPPFlags captures the control plane protection meters configurations
+var COPPFlags = []cli.Flag{
+	&cli.IntFlag{
+		Name:        "copp-rate",
+		Usage:       "Default rate, in packets per second, of the control plane protection meter of each protocol (default: 25)",
+		Destination: &cliConfig.COPP.Rate,
+		Value:       COPP.Rate,
+	},
+	&cli.IntFlag{
+		Name:        "copp-burst",
+		Usage:       "Default burst size, in packets, of the control plane protection meter of each protocol (default: 0)",
+		Destination: &cliConfig.COPP.Burst,
+		Value:       COPP.Burst,
+	},
+	&cli.BoolFlag{
+		Name:        "copp-fair",
+		Usage:       "Whether the control plane protection meter of each protocol is fair by default (default: true)",
+		Destination: &cliConfig.COPP.Fair,
+		Value:       COPP.Fair,
+	},
+	&cli.StringFlag{
+		Name: "copp-protocol-meters",
+		Usage: "A comma separated list of per protocol overrides of the control plane protection meters, " +
+			"each of the form <protocol>:<rate>[:<burst>[:<fair>]] (eg, \"arp:100:200,bfd:500,reject:10:0:false\"). " +
+			"Supported protocols are arp, arp-resolve, bfd, event-elb, icmp4-error, icmp6-error, reject, tcp-reset and svc-monitor",
+		Destination: &cliConfig.COPP.RawProtocolMeters,
+		Value:       COPP.RawProtocolMeters,
+	},
+}
+
 // Flags are general command-line flags. Apps should add these flags to their
 // own urfave/cli flags and call InitConfig() early in the application.
 var Flags []cli.Flag
@@ -1540,6 +1748,7 @@ func GetFlags(customFlags []cli.Flag) []cli.Flag {
 	flags = append(flags, IPFIXFlags...)
 	flags = append(flags, OvnKubeNodeFlags...)
 	flags = append(flags, ClusterManagerFlags...)
+	flags = append(flags, COPPFlags...)
 	flags = append(flags, customFlags...)
 	return flags
 }
@@ -1780,6 +1989,7 @@ func buildGatewayConfig(ctx *cli.Context, cli, file *config) error {
 			}
 		}
 	}
+	cli.Gateway.RuleBackend = GatewayRuleBackend(ctx.String("gateway-rule-backend"))
 	// And CLI overrides over config file and default values
 	if err := overrideFields(&Gateway, &cli.Gateway, &savedGateway); err != nil {
 		return err
@@ -1813,6 +2023,13 @@ func buildGatewayConfig(ctx *cli.Context, cli, file *config) error {
 		return fmt.Errorf("gateway VLAN ID option: %d is supported only in shared gateway mode", Gateway.VLANID)
 	}
 
+	switch Gateway.RuleBackend {
+	case GatewayRuleBackendIPTables, GatewayRuleBackendNFTables:
+	default:
+		return fmt.Errorf("invalid gateway rule backend %q: expect one of %s,%s", Gateway.RuleBackend,
+			GatewayRuleBackendIPTables, GatewayRuleBackendNFTables)
+	}
+
 	return nil
 }
 
@@ -1862,6 +2079,24 @@ func buildOVNKubernetesFeatureConfig(ctx *cli.Context, cli, file *config) error
 	if err := overrideFields(&OVNKubernetesFeature, &cli.OVNKubernetesFeature, &savedOVNKubernetesFeature); err != nil {
 		return err
 	}
+	if OVNKubernetesFeature.EnableEgressIPBFD {
+		if OVNKubernetesFeature.EgressIPBFDInterval < 1 || OVNKubernetesFeature.EgressIPBFDMultiplier < 1 {
+			return fmt.Errorf("invalid egress IP BFD interval %d or multiplier %d, both must be positive",
+				OVNKubernetesFeature.EgressIPBFDInterval, OVNKubernetesFeature.EgressIPBFDMultiplier)
+		}
+		// ovnkube-cluster-manager reads the status of the sessions from the
+		// northbound database, which only covers the whole cluster without
+		// interconnect
+		if OVNKubernetesFeature.EnableInterconnect {
+			return fmt.Errorf("egress IP BFD is not supported with interconnect")
+		}
+	}
+	if OVNKubernetesFeature.EgressFirewallDNSResponseAddress != "" {
+		if parts := strings.Split(OVNKubernetesFeature.EgressFirewallDNSService, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("invalid egress firewall DNS service %q, must be namespace/name",
+				OVNKubernetesFeature.EgressFirewallDNSService)
+		}
+	}
 	return nil
 }
 
@@ -2026,6 +2261,33 @@ func completeClusterManagerConfig() error {
 	return nil
 }
 
+func buildCOPPConfig(cli, file *config) error {
+	// Copy config file values over default values
+	if err := overrideFields(&COPP, &file.COPP, &savedCOPP); err != nil {
+		return err
+	}
+	// And CLI overrides over config file and default values
+	return overrideFields(&COPP, &cli.COPP, &savedCOPP)
+}
+
+// completeCOPPConfig completes the COPP config by parsing raw values
+// into their final form.
+func completeCOPPConfig() error {
+	if COPP.Rate <= 0 {
+		return fmt.Errorf("invalid COPP rate %d: must be greater than 0", COPP.Rate)
+	}
+	if COPP.Burst < 0 {
+		return fmt.Errorf("invalid COPP burst %d: must not be negative", COPP.Burst)
+	}
+	var err error
+	COPP.ProtocolMeters, err = ParseCOPPProtocolMeters(COPP.RawProtocolMeters,
+		COPPMeterConfig{Rate: COPP.Rate, Burst: COPP.Burst, Fair: COPP.Fair})
+	if err != nil {
+		return fmt.Errorf("COPP protocol meters invalid: %v", err)
+	}
+	return nil
+}
+
 func buildDefaultConfig(cli, file *config) error {
 	if err := overrideFields(&Default, &file.Default, &savedDefault); err != nil {
 		return err
@@ -2129,6 +2391,7 @@ func initConfigWithPath(ctx *cli.Context, exec kexec.Interface, saPath string, d
 		HybridOverlay:        savedHybridOverlay,
 		OvnKubeNode:          savedOvnKubeNode,
 		ClusterManager:       savedClusterManager,
+		COPP:                 savedCOPP,
 	}
 
 	configFile, configFileIsDefault = getConfigFilePath(ctx)
@@ -2250,6 +2513,10 @@ func initConfigWithPath(ctx *cli.Context, exec kexec.Interface, saPath string, d
 		return "", err
 	}
 
+	if err = buildCOPPConfig(&cliConfig, &cfg); err != nil {
+		return "", err
+	}
+
 	tmpAuth, err := buildOvnAuth(exec, true, &cliConfig.OvnNorth, &cfg.OvnNorth, defaults.OvnNorthAddress)
 	if err != nil {
 		return "", err
@@ -2278,6 +2545,7 @@ func initConfigWithPath(ctx *cli.Context, exec kexec.Interface, saPath string, d
 	klog.V(5).Infof("Hybrid Overlay config: %+v", HybridOverlay)
 	klog.V(5).Infof("Ovnkube Node config: %+v", OvnKubeNode)
 	klog.V(5).Infof("Ovnkube Cluster Manager config: %+v", ClusterManager)
+	klog.V(5).Infof("COPP config: %+v", COPP)
 
 	return retConfigFile, nil
 }
@@ -2306,6 +2574,10 @@ func completeConfig() error {
 		return err
 	}
 
+	if err := completeCOPPConfig(); err != nil {
+		return err
+	}
+
 	if err := allSubnets.checkForOverlaps(); err != nil {
 		return err
 	}
@@ -2316,6 +2588,8 @@ func completeConfig() error {
 		return err
 	}
 
+	resetRuntimeClusterSubnets(allSubnets)
+
 	return nil
 }
 
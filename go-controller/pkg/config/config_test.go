@@ -211,6 +211,7 @@ router-subnet=10.50.0.0/16
 single-node=false
 disable-forwarding=true
 allow-no-uplink=false
+rule-backend=nftables
 
 [hybridoverlay]
 enabled=true
@@ -231,6 +232,11 @@ enable-admin-network-policy=false
 [clustermanager]
 v4-transit-switch-subnet=100.89.0.0/16
 v6-transit-switch-subnet=fd98::/64
+
+[copp]
+rate=30
+burst=5
+protocol-meters=arp:100
 `
 
 	var newData string
@@ -329,6 +335,7 @@ var _ = Describe("Config Operations", func() {
 			gomega.Expect(Gateway.SingleNode).To(gomega.BeFalse())
 			gomega.Expect(Gateway.DisableForwarding).To(gomega.BeFalse())
 			gomega.Expect(Gateway.AllowNoUplink).To(gomega.BeFalse())
+			gomega.Expect(Gateway.RuleBackend).To(gomega.Equal(GatewayRuleBackendIPTables))
 			gomega.Expect(OVNKubernetesFeature.EgressIPReachabiltyTotalTimeout).To(gomega.Equal(1))
 			gomega.Expect(OVNKubernetesFeature.EgressIPNodeHealthCheckPort).To(gomega.Equal(0))
 			gomega.Expect(OVNKubernetesFeature.EnableMultiNetwork).To(gomega.BeFalse())
@@ -670,6 +677,7 @@ var _ = Describe("Config Operations", func() {
 			gomega.Expect(Gateway.SingleNode).To(gomega.BeFalse())
 			gomega.Expect(Gateway.DisableForwarding).To(gomega.BeTrue())
 			gomega.Expect(Gateway.AllowNoUplink).To(gomega.BeFalse())
+			gomega.Expect(Gateway.RuleBackend).To(gomega.Equal(GatewayRuleBackendNFTables))
 
 			gomega.Expect(HybridOverlay.Enabled).To(gomega.BeTrue())
 			gomega.Expect(OVNKubernetesFeature.EgressIPReachabiltyTotalTimeout).To(gomega.Equal(3))
@@ -683,6 +691,13 @@ var _ = Describe("Config Operations", func() {
 			}))
 			gomega.Expect(ClusterManager.V4TransitSwitchSubnet).To(gomega.Equal("100.89.0.0/16"))
 			gomega.Expect(ClusterManager.V6TransitSwitchSubnet).To(gomega.Equal("fd98::/64"))
+			gomega.Expect(COPP.Rate).To(gomega.Equal(30))
+			gomega.Expect(COPP.Burst).To(gomega.Equal(5))
+			gomega.Expect(COPP.Fair).To(gomega.BeTrue())
+			gomega.Expect(COPP.ProtocolMeters).To(gomega.Equal(map[string]COPPMeterConfig{
+				"arp": {Rate: 100, Burst: 5, Fair: true},
+			}))
+			gomega.Expect(COPP.GetMeterConfig("bfd")).To(gomega.Equal(COPPMeterConfig{Rate: 30, Burst: 5, Fair: true}))
 
 			return nil
 		}
@@ -789,6 +804,11 @@ var _ = Describe("Config Operations", func() {
 			gomega.Expect(Default.OfctrlWaitBeforeClear).To(gomega.Equal(5000))
 			gomega.Expect(ClusterManager.V4TransitSwitchSubnet).To(gomega.Equal("100.90.0.0/16"))
 			gomega.Expect(ClusterManager.V6TransitSwitchSubnet).To(gomega.Equal("fd96::/64"))
+			gomega.Expect(COPP.Rate).To(gomega.Equal(40))
+			gomega.Expect(COPP.Burst).To(gomega.Equal(5))
+			gomega.Expect(COPP.ProtocolMeters).To(gomega.Equal(map[string]COPPMeterConfig{
+				"bfd": {Rate: 200, Burst: 20, Fair: false},
+			}))
 
 			return nil
 		}
@@ -859,6 +879,8 @@ var _ = Describe("Config Operations", func() {
 			"-dns-service-name=kube-dns-2",
 			"-cluster-manager-v4-transit-switch-subnet=100.90.0.0/16",
 			"-cluster-manager-v6-transit-switch-subnet=fd96::/64",
+			"-copp-rate=40",
+			"-copp-protocol-meters=bfd:200:20:false",
 		}
 		err = app.Run(cliArgs)
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
@@ -1097,6 +1119,36 @@ enable-pprof=true
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
 	})
 
+	It("returns an error when the gateway rule backend is invalid", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("invalid gateway rule backend \"ebtables\": expect one of iptables,nftables"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-gateway-mode=shared",
+			"-gateway-rule-backend=ebtables",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	It("returns an error when egress IP BFD is enabled with interconnect", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("egress IP BFD is not supported with interconnect"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-enable-egressip-bfd",
+			"-enable-interconnect",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
 	It("returns an error when the vlan-id is specified for mode other than shared gateway mode", func() {
 		app.Action = func(ctx *cli.Context) error {
 			_, err := InitConfig(ctx, kexec.New(), nil)
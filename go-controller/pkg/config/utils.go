@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 
 	iputils "github.com/containernetworking/plugins/pkg/ip"
 	utilnet "k8s.io/utils/net"
@@ -158,6 +160,49 @@ func ParseFlowCollectors(flowCollectors string) ([]HostPort, error) {
 	return parsedFlowsCollectors, nil
 }
 
+// ParseCOPPProtocolMeters returns the parsed per protocol COPP meter overrides passed by the user.
+// Each entry is of the form <protocol>:<rate>[:<burst>[:<fair>]]; the omitted values are taken
+// from the provided defaults.
+func ParseCOPPProtocolMeters(protocolMeters string, defaults COPPMeterConfig) (map[string]COPPMeterConfig, error) {
+	parsedProtocolMeters := map[string]COPPMeterConfig{}
+	if protocolMeters == "" {
+		return parsedProtocolMeters, nil
+	}
+	for _, entry := range strings.Split(protocolMeters, ",") {
+		fields := strings.Split(strings.TrimSpace(entry), ":")
+		if len(fields) < 2 || len(fields) > 4 || fields[0] == "" {
+			return nil, fmt.Errorf("meter %q is not of the form <protocol>:<rate>[:<burst>[:<fair>]]", entry)
+		}
+		protocol := fields[0]
+		if !slices.Contains(COPPProtocols, protocol) {
+			return nil, fmt.Errorf("unsupported COPP protocol %q, supported protocols are %v", protocol, COPPProtocols)
+		}
+		if _, ok := parsedProtocolMeters[protocol]; ok {
+			return nil, fmt.Errorf("duplicate meter for protocol %s", protocol)
+		}
+		meter := defaults
+		var err error
+		meter.Rate, err = strconv.Atoi(fields[1])
+		if err != nil || meter.Rate <= 0 {
+			return nil, fmt.Errorf("meter rate %q of protocol %s is not a positive integer", fields[1], protocol)
+		}
+		if len(fields) > 2 {
+			meter.Burst, err = strconv.Atoi(fields[2])
+			if err != nil || meter.Burst < 0 {
+				return nil, fmt.Errorf("meter burst %q of protocol %s is not a non-negative integer", fields[2], protocol)
+			}
+		}
+		if len(fields) > 3 {
+			meter.Fair, err = strconv.ParseBool(fields[3])
+			if err != nil {
+				return nil, fmt.Errorf("meter fairness %q of protocol %s is not a boolean", fields[3], protocol)
+			}
+		}
+		parsedProtocolMeters[protocol] = meter
+	}
+	return parsedProtocolMeters, nil
+}
+
 type configSubnetType string
 
 const (
@@ -256,6 +301,96 @@ func (cs *configSubnets) checkIPFamilies() (usingIPv4, usingIPv6 bool, err error
 	return false, false, fmt.Errorf("illegal network configuration: %s", netConfig)
 }
 
+// runtimeClusterSubnets holds the cluster subnet ranges added to the default
+// network at runtime, on top of the configured Default.ClusterSubnets, along
+// with the configured subnets they are not allowed to overlap with.
+var runtimeClusterSubnets struct {
+	sync.RWMutex
+	configured *configSubnets
+	entries    []CIDRNetworkEntry
+}
+
+// resetRuntimeClusterSubnets drops the cluster subnet ranges added at runtime
+// and records the configured subnets new ranges are checked against.
+func resetRuntimeClusterSubnets(configured *configSubnets) {
+	runtimeClusterSubnets.Lock()
+	defer runtimeClusterSubnets.Unlock()
+	runtimeClusterSubnets.configured = configured
+	runtimeClusterSubnets.entries = nil
+}
+
+// ClusterSubnets returns the cluster subnet ranges of the default network:
+// the configured ones followed by the ones added at runtime through
+// AddClusterSubnets.
+func ClusterSubnets() []CIDRNetworkEntry {
+	runtimeClusterSubnets.RLock()
+	defer runtimeClusterSubnets.RUnlock()
+	if len(runtimeClusterSubnets.entries) == 0 {
+		return Default.ClusterSubnets
+	}
+	clusterSubnets := make([]CIDRNetworkEntry, 0, len(Default.ClusterSubnets)+len(runtimeClusterSubnets.entries))
+	clusterSubnets = append(clusterSubnets, Default.ClusterSubnets...)
+	return append(clusterSubnets, runtimeClusterSubnets.entries...)
+}
+
+// AddClusterSubnets adds cluster subnet ranges to the default network at
+// runtime. Ranges that are already part of the cluster subnets are ignored.
+// The remaining ones must be of an IP family the cluster is configured with
+// and must not overlap with any configured or previously added subnet;
+// otherwise an error is returned and none of the ranges are added. Returns the
+// ranges that were actually added.
+func AddClusterSubnets(entries []CIDRNetworkEntry) ([]CIDRNetworkEntry, error) {
+	runtimeClusterSubnets.Lock()
+	defer runtimeClusterSubnets.Unlock()
+
+	existing := make([]CIDRNetworkEntry, 0, len(Default.ClusterSubnets)+len(runtimeClusterSubnets.entries))
+	existing = append(existing, Default.ClusterSubnets...)
+	existing = append(existing, runtimeClusterSubnets.entries...)
+
+	subnets := newConfigSubnets()
+	if runtimeClusterSubnets.configured != nil {
+		subnets.subnets = append(subnets.subnets, runtimeClusterSubnets.configured.subnets...)
+	}
+	for _, entry := range runtimeClusterSubnets.entries {
+		subnets.append(configSubnetCluster, entry.CIDR)
+	}
+
+	var added []CIDRNetworkEntry
+	for _, entry := range entries {
+		known := false
+		for _, e := range existing {
+			if e.String() == entry.String() {
+				known = true
+				break
+			}
+		}
+		if known {
+			continue
+		}
+		ipv6 := utilnet.IsIPv6CIDR(entry.CIDR)
+		if (ipv6 && !IPv6Mode) || (!ipv6 && !IPv4Mode) {
+			return nil, fmt.Errorf("cluster subnet %s is of an IP family the cluster is not configured with", entry)
+		}
+		entryMaskLength, _ := entry.CIDR.Mask.Size()
+		if ipv6 && entry.HostSubnetLength != 64 {
+			return nil, fmt.Errorf("cluster subnet %s: IPv6 only supports /64 host subnets", entry)
+		}
+		if entry.HostSubnetLength <= entryMaskLength {
+			return nil, fmt.Errorf("cluster subnet %s: cannot use a host subnet length mask shorter than or equal to "+
+				"the cluster subnet mask", entry)
+		}
+		subnets.append(configSubnetCluster, entry.CIDR)
+		existing = append(existing, entry)
+		added = append(added, entry)
+	}
+	if err := subnets.checkForOverlaps(); err != nil {
+		return nil, err
+	}
+
+	runtimeClusterSubnets.entries = append(runtimeClusterSubnets.entries, added...)
+	return added, nil
+}
+
 func ContainsJoinIP(ip net.IP) bool {
 	var joinSubnetsConfig []string
 	if IPv4Mode {
@@ -2,6 +2,7 @@ package config
 
 import (
 	"net"
+	"reflect"
 	"testing"
 
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
@@ -348,3 +349,162 @@ func TestParseFlowCollectors_DeduplicateEquivalentEntries(t *testing.T) {
 		t.Errorf("parsed hostPorts returned unexpected results: %+v", hp)
 	}
 }
+
+func TestParseCOPPProtocolMeters(t *testing.T) {
+	defaults := COPPMeterConfig{Rate: 25, Burst: 0, Fair: true}
+	tests := []struct {
+		name           string
+		protocolMeters string
+		expected       map[string]COPPMeterConfig
+		expectErr      bool
+	}{
+		{
+			name:           "empty",
+			protocolMeters: "",
+			expected:       map[string]COPPMeterConfig{},
+		},
+		{
+			name:           "rate only takes remaining defaults",
+			protocolMeters: "arp:100",
+			expected: map[string]COPPMeterConfig{
+				"arp": {Rate: 100, Burst: 0, Fair: true},
+			},
+		},
+		{
+			name:           "multiple protocols with burst and fairness",
+			protocolMeters: "arp:100:10, bfd:200:20:false",
+			expected: map[string]COPPMeterConfig{
+				"arp": {Rate: 100, Burst: 10, Fair: true},
+				"bfd": {Rate: 200, Burst: 20, Fair: false},
+			},
+		},
+		{
+			name:           "missing rate",
+			protocolMeters: "arp",
+			expectErr:      true,
+		},
+		{
+			name:           "too many fields",
+			protocolMeters: "arp:100:10:true:foo",
+			expectErr:      true,
+		},
+		{
+			name:           "zero rate",
+			protocolMeters: "arp:0",
+			expectErr:      true,
+		},
+		{
+			name:           "negative burst",
+			protocolMeters: "arp:100:-1",
+			expectErr:      true,
+		},
+		{
+			name:           "invalid fairness",
+			protocolMeters: "arp:100:10:foo",
+			expectErr:      true,
+		},
+		{
+			name:           "duplicate protocol",
+			protocolMeters: "arp:100,arp:200",
+			expectErr:      true,
+		},
+		{
+			name:           "unsupported protocol",
+			protocolMeters: "arp:100,foo:200",
+			expectErr:      true,
+		},
+	}
+	for _, tc := range tests {
+		meters, err := ParseCOPPProtocolMeters(tc.protocolMeters, defaults)
+		if err == nil && tc.expectErr {
+			t.Errorf("testcase \"%s\" expected an error", tc.name)
+		} else if err != nil && !tc.expectErr {
+			t.Errorf("testcase \"%s\" failed to parse meters: %v", tc.name, err)
+		} else if !tc.expectErr && !reflect.DeepEqual(meters, tc.expected) {
+			t.Errorf("testcase \"%s\" expected meters %v but got %v", tc.name, tc.expected, meters)
+		}
+	}
+}
+
+func TestAddClusterSubnets(t *testing.T) {
+	tests := []struct {
+		name        string
+		added       []CIDRNetworkEntry
+		entries     []CIDRNetworkEntry
+		expected    []CIDRNetworkEntry
+		expectedErr bool
+	}{
+		{
+			name:     "new range",
+			entries:  []CIDRNetworkEntry{{CIDR: ovntest.MustParseIPNet("10.200.0.0/16"), HostSubnetLength: 24}},
+			expected: []CIDRNetworkEntry{{CIDR: ovntest.MustParseIPNet("10.200.0.0/16"), HostSubnetLength: 24}},
+		},
+		{
+			name:    "configured range is ignored",
+			entries: []CIDRNetworkEntry{{CIDR: ovntest.MustParseIPNet("10.128.0.0/14"), HostSubnetLength: 23}},
+		},
+		{
+			name:    "previously added range is ignored",
+			added:   []CIDRNetworkEntry{{CIDR: ovntest.MustParseIPNet("10.200.0.0/16"), HostSubnetLength: 24}},
+			entries: []CIDRNetworkEntry{{CIDR: ovntest.MustParseIPNet("10.200.0.0/16"), HostSubnetLength: 24}},
+		},
+		{
+			name:        "range overlapping a configured cluster subnet",
+			entries:     []CIDRNetworkEntry{{CIDR: ovntest.MustParseIPNet("10.129.0.0/16"), HostSubnetLength: 24}},
+			expectedErr: true,
+		},
+		{
+			name:        "range overlapping the service subnet",
+			entries:     []CIDRNetworkEntry{{CIDR: ovntest.MustParseIPNet("172.16.0.0/16"), HostSubnetLength: 24}},
+			expectedErr: true,
+		},
+		{
+			name:        "range overlapping a previously added range",
+			added:       []CIDRNetworkEntry{{CIDR: ovntest.MustParseIPNet("10.200.0.0/16"), HostSubnetLength: 24}},
+			entries:     []CIDRNetworkEntry{{CIDR: ovntest.MustParseIPNet("10.200.128.0/17"), HostSubnetLength: 24}},
+			expectedErr: true,
+		},
+		{
+			name:        "range of a disabled IP family",
+			entries:     []CIDRNetworkEntry{{CIDR: ovntest.MustParseIPNet("fd00:10:200::/48"), HostSubnetLength: 64}},
+			expectedErr: true,
+		},
+		{
+			name:        "host subnet length shorter than the range",
+			entries:     []CIDRNetworkEntry{{CIDR: ovntest.MustParseIPNet("10.200.0.0/16"), HostSubnetLength: 16}},
+			expectedErr: true,
+		},
+		{
+			name: "no range is added if one of them is invalid",
+			entries: []CIDRNetworkEntry{
+				{CIDR: ovntest.MustParseIPNet("10.200.0.0/16"), HostSubnetLength: 24},
+				{CIDR: ovntest.MustParseIPNet("10.129.0.0/16"), HostSubnetLength: 24},
+			},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range tests {
+		if err := PrepareTestConfig(); err != nil {
+			t.Fatalf("failed to prepare test config: %v", err)
+		}
+		if _, err := AddClusterSubnets(tc.added); err != nil {
+			t.Fatalf("testcase \"%s\" failed to add initial cluster subnets: %v", tc.name, err)
+		}
+		added, err := AddClusterSubnets(tc.entries)
+		if err == nil && tc.expectedErr {
+			t.Errorf("testcase \"%s\" expected an error", tc.name)
+		} else if err != nil && !tc.expectedErr {
+			t.Errorf("testcase \"%s\" failed to add cluster subnets: %v", tc.name, err)
+		} else if !reflect.DeepEqual(added, tc.expected) {
+			t.Errorf("testcase \"%s\" expected added cluster subnets %v but got %v", tc.name, tc.expected, added)
+		}
+		expectedClusterSubnets := append([]CIDRNetworkEntry{}, Default.ClusterSubnets...)
+		expectedClusterSubnets = append(append(expectedClusterSubnets, tc.added...), tc.expected...)
+		if !reflect.DeepEqual(ClusterSubnets(), expectedClusterSubnets) {
+			t.Errorf("testcase \"%s\" expected cluster subnets %v but got %v", tc.name, expectedClusterSubnets, ClusterSubnets())
+		}
+	}
+	if err := PrepareTestConfig(); err != nil {
+		t.Fatalf("failed to prepare test config: %v", err)
+	}
+}
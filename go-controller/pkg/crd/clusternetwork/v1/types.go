@@ -0,0 +1,58 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +resource:path=clusternetwork
+// +kubebuilder:resource:shortName=cn,scope=Cluster
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:printcolumn:name="Cluster Subnets",type=string,JSONPath=".spec.clusterSubnets[*].cidr"
+// ClusterNetwork is a CRD allowing the cluster administrator to add cluster
+// subnet ranges to the default network at runtime, on top of the ones
+// configured at startup, from which nodes are given host subnets once the
+// configured ranges are exhausted.
+type ClusterNetwork struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Specification of the desired behavior of ClusterNetwork.
+	Spec ClusterNetworkSpec `json:"spec"`
+}
+
+// ClusterNetworkSpec is a desired state description of ClusterNetwork.
+type ClusterNetworkSpec struct {
+	// ClusterSubnets is the list of cluster subnet ranges to add to the
+	// default network. Can be IPv4 and/or IPv6, of the IP families the
+	// cluster is configured with. Ranges can only be added: the deletion of
+	// a ClusterNetwork is held until no host subnet allocated out of its
+	// ranges is left, and the ranges are removed when ovnkube is restarted.
+	// +kubebuilder:validation:MinItems=1
+	ClusterSubnets []ClusterSubnet `json:"clusterSubnets"`
+}
+
+// ClusterSubnet is a cluster subnet range from which host subnets are
+// allocated to nodes.
+type ClusterSubnet struct {
+	// CIDR of the cluster subnet range.
+	// +kubebuilder:validation:Required
+	CIDR string `json:"cidr"`
+	// HostSubnetLength is the prefix length of the host subnets allocated
+	// from the range. Defaults to 24 for IPv4 and 64 for IPv6.
+	// +optional
+	HostSubnetLength int `json:"hostSubnetLength,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +resource:path=clusternetwork
+// ClusterNetworkList is the list of ClusterNetworks.
+type ClusterNetworkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	// List of ClusterNetwork.
+	Items []ClusterNetwork `json:"items"`
+}
@@ -69,6 +69,12 @@ type EgressFirewallPort struct {
 	// +kubebuilder:validation:Minimum:=1
 	// +kubebuilder:validation:Maximum:=65535
 	Port int32 `json:"port"`
+	// endPort indicates that the range of ports from port to endPort, inclusive, should be matched.
+	// If set, it must be equal to or greater than port.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=65535
+	EndPort *int32 `json:"endPort,omitempty"`
 }
 
 // +kubebuilder:validation:MinProperties:=1
@@ -78,7 +84,9 @@ type EgressFirewallDestination struct {
 	// cidrSelector is the CIDR range to allow/deny traffic to. If this is set, dnsName and nodeSelector must be unset.
 	CIDRSelector string `json:"cidrSelector,omitempty"`
 	// dnsName is the domain name to allow/deny traffic to. If this is set, cidrSelector and nodeSelector must be unset.
-	// +kubebuilder:validation:Pattern=^([A-Za-z0-9-]+\.)*[A-Za-z0-9-]+\.?$
+	// A wildcard name like "*.example.com" matches all the subdomains of example.com; the IPs of the matching
+	// names are learned from the cluster DNS responses.
+	// +kubebuilder:validation:Pattern=^(\*\.)?([A-Za-z0-9-]+\.)*[A-Za-z0-9-]+\.?$
 	DNSName string `json:"dnsName,omitempty"`
 	// nodeSelector will allow/deny traffic to the Kubernetes node IP of selected nodes. If this is set,
 	// cidrSelector and DNSName must be unset.
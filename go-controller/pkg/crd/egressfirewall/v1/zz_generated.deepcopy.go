@@ -110,6 +110,11 @@ func (in *EgressFirewallList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EgressFirewallPort) DeepCopyInto(out *EgressFirewallPort) {
 	*out = *in
+	if in.EndPort != nil {
+		in, out := &in.EndPort, &out.EndPort
+		*out = new(int32)
+		**out = **in
+	}
 	return
 }
 
@@ -129,7 +134,9 @@ func (in *EgressFirewallRule) DeepCopyInto(out *EgressFirewallRule) {
 	if in.Ports != nil {
 		in, out := &in.Ports, &out.Ports
 		*out = make([]EgressFirewallPort, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	in.To.DeepCopyInto(&out.To)
 	return
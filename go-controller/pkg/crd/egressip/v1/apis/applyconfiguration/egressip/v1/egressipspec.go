@@ -18,15 +18,17 @@ limitations under the License.
 package v1
 
 import (
+	egressipv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // EgressIPSpecApplyConfiguration represents an declarative configuration of the EgressIPSpec type for use
 // with apply.
 type EgressIPSpecApplyConfiguration struct {
-	EgressIPs         []string          `json:"egressIPs,omitempty"`
-	NamespaceSelector *v1.LabelSelector `json:"namespaceSelector,omitempty"`
-	PodSelector       *v1.LabelSelector `json:"podSelector,omitempty"`
+	EgressIPs         []string                          `json:"egressIPs,omitempty"`
+	NamespaceSelector *v1.LabelSelector                 `json:"namespaceSelector,omitempty"`
+	PodSelector       *v1.LabelSelector                 `json:"podSelector,omitempty"`
+	LoadBalancing     *egressipv1.EgressIPLoadBalancing `json:"loadBalancing,omitempty"`
 }
 
 // EgressIPSpecApplyConfiguration constructs an declarative configuration of the EgressIPSpec type for use with
@@ -60,3 +62,11 @@ func (b *EgressIPSpecApplyConfiguration) WithPodSelector(value v1.LabelSelector)
 	b.PodSelector = &value
 	return b
 }
+
+// WithLoadBalancing sets the LoadBalancing field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LoadBalancing field is set to the value of the last call.
+func (b *EgressIPSpecApplyConfiguration) WithLoadBalancing(value egressipv1.EgressIPLoadBalancing) *EgressIPSpecApplyConfiguration {
+	b.LoadBalancing = &value
+	return b
+}
@@ -17,10 +17,19 @@ limitations under the License.
 
 package v1
 
+import (
+	egressipv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // EgressIPStatusApplyConfiguration represents an declarative configuration of the EgressIPStatus type for use
 // with apply.
 type EgressIPStatusApplyConfiguration struct {
-	Items []EgressIPStatusItemApplyConfiguration `json:"items,omitempty"`
+	Items               []EgressIPStatusItemApplyConfiguration           `json:"items,omitempty"`
+	LoadBalancing       *egressipv1.EgressIPLoadBalancing                `json:"loadBalancing,omitempty"`
+	ActiveEgressIPs     []string                                         `json:"activeEgressIPs,omitempty"`
+	UnassignedEgressIPs []EgressIPUnassignedStatusItemApplyConfiguration `json:"unassignedEgressIPs,omitempty"`
+	Conditions          []metav1.Condition                               `json:"conditions,omitempty"`
 }
 
 // EgressIPStatusApplyConfiguration constructs an declarative configuration of the EgressIPStatus type for use with
@@ -41,3 +50,44 @@ func (b *EgressIPStatusApplyConfiguration) WithItems(values ...*EgressIPStatusIt
 	}
 	return b
 }
+
+// WithLoadBalancing sets the LoadBalancing field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LoadBalancing field is set to the value of the last call.
+func (b *EgressIPStatusApplyConfiguration) WithLoadBalancing(value egressipv1.EgressIPLoadBalancing) *EgressIPStatusApplyConfiguration {
+	b.LoadBalancing = &value
+	return b
+}
+
+// WithActiveEgressIPs adds the given value to the ActiveEgressIPs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ActiveEgressIPs field.
+func (b *EgressIPStatusApplyConfiguration) WithActiveEgressIPs(values ...string) *EgressIPStatusApplyConfiguration {
+	for i := range values {
+		b.ActiveEgressIPs = append(b.ActiveEgressIPs, values[i])
+	}
+	return b
+}
+
+// WithUnassignedEgressIPs adds the given value to the UnassignedEgressIPs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the UnassignedEgressIPs field.
+func (b *EgressIPStatusApplyConfiguration) WithUnassignedEgressIPs(values ...*EgressIPUnassignedStatusItemApplyConfiguration) *EgressIPStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithUnassignedEgressIPs")
+		}
+		b.UnassignedEgressIPs = append(b.UnassignedEgressIPs, *values[i])
+	}
+	return b
+}
+
+// WithConditions adds the given value to the Conditions field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Conditions field.
+func (b *EgressIPStatusApplyConfiguration) WithConditions(values ...metav1.Condition) *EgressIPStatusApplyConfiguration {
+	for i := range values {
+		b.Conditions = append(b.Conditions, values[i])
+	}
+	return b
+}
@@ -36,6 +36,8 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &egressipv1.EgressIPStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("EgressIPStatusItem"):
 		return &egressipv1.EgressIPStatusItemApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("EgressIPUnassignedStatusItem"):
+		return &egressipv1.EgressIPUnassignedStatusItemApplyConfiguration{}
 
 	}
 	return nil
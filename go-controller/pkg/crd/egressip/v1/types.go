@@ -33,6 +33,27 @@ type EgressIP struct {
 type EgressIPStatus struct {
 	// The list of assigned egress IPs and their corresponding node assignment.
 	Items []EgressIPStatusItem `json:"items"`
+	// LoadBalancing is the load balancing mode applied to the assigned egress IPs.
+	// +optional
+	LoadBalancing EgressIPLoadBalancing `json:"loadBalancing,omitempty"`
+	// ActiveEgressIPs is the list of assigned egress IPs serving the egress
+	// traffic, one per IP family, when the ActiveStandby load balancing mode
+	// is applied. The other assigned egress IPs are on standby.
+	// +optional
+	ActiveEgressIPs []string `json:"activeEgressIPs,omitempty"`
+	// UnassignedEgressIPs is the list of the requested egress IPs which are
+	// not assigned to any node, along with the reason why.
+	// +optional
+	UnassignedEgressIPs []EgressIPUnassignedStatusItem `json:"unassignedEgressIPs,omitempty"`
+	// Conditions summarize the assignment of the requested egress IPs. The
+	// known condition types are Assigned, NoMatchingNodes, AddressConflict and
+	// CloudAssignmentPending.
+	// +optional
+	// +patchMergeKey=type
+	// +patchStrategy=merge
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type"`
 }
 
 // The per node status, for those egress IPs who have been assigned.
@@ -43,6 +64,52 @@ type EgressIPStatusItem struct {
 	EgressIP string `json:"egressIP"`
 }
 
+// The per egress IP status, for those egress IPs who could not be assigned.
+type EgressIPUnassignedStatusItem struct {
+	// Unassigned egress IP
+	EgressIP string `json:"egressIP"`
+	// Reason is a CamelCase reason for the egress IP not being assigned
+	Reason string `json:"reason"`
+	// Message is a human readable explanation of the reason
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+const (
+	// EgressIPConditionAssigned is true when all the requested egress IPs are
+	// assigned to a node.
+	EgressIPConditionAssigned = "Assigned"
+	// EgressIPConditionNoMatchingNodes is true when some requested egress IPs
+	// cannot be assigned because no egress node can host them.
+	EgressIPConditionNoMatchingNodes = "NoMatchingNodes"
+	// EgressIPConditionAddressConflict is true when some requested egress IPs
+	// are invalid or conflict with another address of the cluster.
+	EgressIPConditionAddressConflict = "AddressConflict"
+	// EgressIPConditionCloudAssignmentPending is true when some requested
+	// egress IPs are waiting for, or were refused, their assignment by the
+	// cloud provider.
+	EgressIPConditionCloudAssignmentPending = "CloudAssignmentPending"
+)
+
+const (
+	// EgressIPReasonNoMatchingNodes: no egress node can host the egress IP.
+	EgressIPReasonNoMatchingNodes = "NoMatchingNodes"
+	// EgressIPReasonInvalidEgressIP: the egress IP cannot be parsed.
+	EgressIPReasonInvalidEgressIP = "InvalidEgressIP"
+	// EgressIPReasonAddressConflict: the egress IP is a node IP or is already
+	// requested by another EgressIP.
+	EgressIPReasonAddressConflict = "AddressConflict"
+	// EgressIPReasonCloudAssignmentPending: the cloud provider has not
+	// attached the egress IP to the node yet.
+	EgressIPReasonCloudAssignmentPending = "CloudAssignmentPending"
+	// EgressIPReasonCloudAssignmentFailed: the cloud provider failed to
+	// attach the egress IP to the node.
+	EgressIPReasonCloudAssignmentFailed = "CloudAssignmentFailed"
+	// EgressIPReasonAssignmentPending: the egress IP is waiting to be
+	// (re-)assigned.
+	EgressIPReasonAssignmentPending = "AssignmentPending"
+)
+
 // EgressIPSpec is a desired state description of EgressIP.
 type EgressIPSpec struct {
 	// EgressIPs is the list of egress IP addresses requested. Can be IPv4 and/or IPv6.
@@ -60,8 +127,37 @@ type EgressIPSpec struct {
 	// match this pod selector.
 	// +optional
 	PodSelector metav1.LabelSelector `json:"podSelector,omitempty"`
+	// LoadBalancing selects how the egress traffic of the matched pods is
+	// distributed across the assigned egress IPs of the same IP family:
+	// - PerConnection: the connections of each pod are spread across all the
+	//   assigned egress IPs.
+	// - SourceIP: all the traffic of a pod leaves through the same egress IP,
+	//   chosen from the pod IP.
+	// - ActiveStandby: all the traffic leaves through the assigned egress IP
+	//   listed first in EgressIPs, the others being used only when it is not
+	//   assigned.
+	// Defaults to PerConnection.
+	// +kubebuilder:validation:Enum=PerConnection;SourceIP;ActiveStandby
+	// +optional
+	LoadBalancing EgressIPLoadBalancing `json:"loadBalancing,omitempty"`
 }
 
+// EgressIPLoadBalancing is the mode used to distribute egress traffic across
+// the assigned egress IPs of an EgressIP.
+type EgressIPLoadBalancing string
+
+const (
+	// EgressIPLoadBalancingPerConnection spreads the connections of each pod
+	// across all the assigned egress IPs.
+	EgressIPLoadBalancingPerConnection EgressIPLoadBalancing = "PerConnection"
+	// EgressIPLoadBalancingSourceIP sends all the traffic of a pod through the
+	// same assigned egress IP.
+	EgressIPLoadBalancingSourceIP EgressIPLoadBalancing = "SourceIP"
+	// EgressIPLoadBalancingActiveStandby sends all the traffic through the
+	// assigned egress IP with the highest priority, in the order of the spec.
+	EgressIPLoadBalancingActiveStandby EgressIPLoadBalancing = "ActiveStandby"
+)
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +resource:path=egressip
 // EgressIPList is the list of EgressIPList.
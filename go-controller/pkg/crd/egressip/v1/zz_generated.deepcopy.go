@@ -21,6 +21,7 @@ limitations under the License.
 package v1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -116,6 +117,23 @@ func (in *EgressIPStatus) DeepCopyInto(out *EgressIPStatus) {
 		*out = make([]EgressIPStatusItem, len(*in))
 		copy(*out, *in)
 	}
+	if in.ActiveEgressIPs != nil {
+		in, out := &in.ActiveEgressIPs, &out.ActiveEgressIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UnassignedEgressIPs != nil {
+		in, out := &in.UnassignedEgressIPs, &out.UnassignedEgressIPs
+		*out = make([]EgressIPUnassignedStatusItem, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -144,3 +162,19 @@ func (in *EgressIPStatusItem) DeepCopy() *EgressIPStatusItem {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressIPUnassignedStatusItem) DeepCopyInto(out *EgressIPUnassignedStatusItem) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressIPUnassignedStatusItem.
+func (in *EgressIPUnassignedStatusItem) DeepCopy() *EgressIPUnassignedStatusItem {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressIPUnassignedStatusItem)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -24,9 +24,10 @@ import (
 // EgressQoSRuleApplyConfiguration represents an declarative configuration of the EgressQoSRule type for use
 // with apply.
 type EgressQoSRuleApplyConfiguration struct {
-	DSCP        *int              `json:"dscp,omitempty"`
-	DstCIDR     *string           `json:"dstCIDR,omitempty"`
-	PodSelector *v1.LabelSelector `json:"podSelector,omitempty"`
+	DSCP        *int                                  `json:"dscp,omitempty"`
+	DstCIDR     *string                               `json:"dstCIDR,omitempty"`
+	PodSelector *v1.LabelSelector                     `json:"podSelector,omitempty"`
+	Bandwidth   *EgressQoSBandwidthApplyConfiguration `json:"bandwidth,omitempty"`
 }
 
 // EgressQoSRuleApplyConfiguration constructs an declarative configuration of the EgressQoSRule type for use with
@@ -58,3 +59,11 @@ func (b *EgressQoSRuleApplyConfiguration) WithPodSelector(value v1.LabelSelector
 	b.PodSelector = &value
 	return b
 }
+
+// WithBandwidth sets the Bandwidth field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Bandwidth field is set to the value of the last call.
+func (b *EgressQoSRuleApplyConfiguration) WithBandwidth(value *EgressQoSBandwidthApplyConfiguration) *EgressQoSRuleApplyConfiguration {
+	b.Bandwidth = value
+	return b
+}
@@ -31,7 +31,7 @@ import (
 // for pods egress traffic on its namespace to specified CIDRs.
 // Traffic from these pods will be checked against each EgressQoSRule in
 // the namespace's EgressQoS, and if there is a match the traffic is marked
-// with the relevant DSCP value.
+// with the relevant DSCP value and optionally rate limited.
 type EgressQoS struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -68,6 +68,27 @@ type EgressQoSRule struct {
 	// results in the rule being applied to all pods in the namespace.
 	// +optional
 	PodSelector metav1.LabelSelector `json:"podSelector,omitempty"`
+
+	// Bandwidth limits the rate of the matching pods' traffic.
+	// This field is optional, and in case it is not set the traffic is
+	// only marked with the DSCP value.
+	// +optional
+	Bandwidth *EgressQoSBandwidth `json:"bandwidth,omitempty"`
+}
+
+// EgressQoSBandwidth defines the rate limit applied to the traffic matching an EgressQoSRule.
+type EgressQoSBandwidth struct {
+	// Rate is the maximum rate of the traffic, in kbps.
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=4294967295
+	Rate int64 `json:"rate"`
+
+	// Burst is the maximum burst size of the traffic, in kilobits.
+	// This field is optional, and in case it is not set OVN picks the burst size.
+	// +optional
+	// +kubebuilder:validation:Minimum:=1
+	// +kubebuilder:validation:Maximum:=4294967295
+	Burst *int64 `json:"burst,omitempty"`
 }
 
 // EgressQoSStatus defines the observed state of EgressQoS
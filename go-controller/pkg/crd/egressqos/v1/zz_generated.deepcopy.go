@@ -53,6 +53,27 @@ func (in *EgressQoS) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EgressQoSBandwidth) DeepCopyInto(out *EgressQoSBandwidth) {
+	*out = *in
+	if in.Burst != nil {
+		in, out := &in.Burst, &out.Burst
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EgressQoSBandwidth.
+func (in *EgressQoSBandwidth) DeepCopy() *EgressQoSBandwidth {
+	if in == nil {
+		return nil
+	}
+	out := new(EgressQoSBandwidth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EgressQoSList) DeepCopyInto(out *EgressQoSList) {
 	*out = *in
@@ -95,6 +116,11 @@ func (in *EgressQoSRule) DeepCopyInto(out *EgressQoSRule) {
 		**out = **in
 	}
 	in.PodSelector.DeepCopyInto(&out.PodSelector)
+	if in.Bandwidth != nil {
+		in, out := &in.Bandwidth, &out.Bandwidth
+		*out = new(EgressQoSBandwidth)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
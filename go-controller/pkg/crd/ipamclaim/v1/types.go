@@ -0,0 +1,68 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:path=ipamclaims
+// +kubebuilder::singular=ipamclaim
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Network",type=string,JSONPath=".spec.network"
+// +kubebuilder:printcolumn:name="IPs",type=string,JSONPath=".status.ips"
+// +kubebuilder:subresource:status
+// IPAMClaim is a CRD that persists the IPs allocated on a secondary network to
+// a workload (a StatefulSet pod or a KubeVirt VM) across the restarts of its
+// pods. It is created and reused by the cluster manager, and garbage
+// collected along with the workload that owns it.
+type IPAMClaim struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IPAMClaimSpec   `json:"spec,omitempty"`
+	Status IPAMClaimStatus `json:"status,omitempty"`
+}
+
+// IPAMClaimSpec defines the desired state of IPAMClaim
+type IPAMClaimSpec struct {
+	// Network is the name of the secondary network the IPs are claimed on.
+	Network string `json:"network"`
+
+	// NetworkAttachmentDefinition is the namespaced name of the NAD the
+	// workload is attached to the network through.
+	NetworkAttachmentDefinition string `json:"networkAttachmentDefinition"`
+}
+
+// IPAMClaimStatus defines the observed state of IPAMClaim
+type IPAMClaimStatus struct {
+	// IPs is the list of IPs, in CIDR notation, claimed by the workload.
+	// +optional
+	IPs []string `json:"ips,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:path=ipamclaims
+// +kubebuilder::singular=ipamclaim
+// IPAMClaimList contains a list of IPAMClaim
+type IPAMClaimList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IPAMClaim `json:"items"`
+}
@@ -49,6 +49,15 @@ import (
 	egressservicescheme "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1/apis/clientset/versioned/scheme"
 	egressserviceinformerfactory "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1/apis/informers/externalversions"
 	egressserviceinformer "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1/apis/informers/externalversions/egressservice/v1"
+	ipamclaimapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/ipamclaim/v1"
+	ipamclaimscheme "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/ipamclaim/v1/apis/clientset/versioned/scheme"
+	ipamclaiminformerfactory "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/ipamclaim/v1/apis/informers/externalversions"
+	ipamclaiminformer "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/ipamclaim/v1/apis/informers/externalversions/ipamclaim/v1"
+
+	clusternetworkapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1"
+	clusternetworkscheme "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1/apis/clientset/versioned/scheme"
+	clusternetworkinformerfactory "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1/apis/informers/externalversions"
+	clusternetworkinformer "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1/apis/informers/externalversions/clusternetwork/v1"
 
 	adminbasedpolicyapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1"
 	adminbasedpolicyscheme "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/clientset/versioned/scheme"
@@ -71,8 +80,11 @@ import (
 	listers "k8s.io/client-go/listers/core/v1"
 	discoverylisters "k8s.io/client-go/listers/discovery/v1"
 	netlisters "k8s.io/client-go/listers/networking/v1"
+	"k8s.io/client-go/metadata/metadatainformer"
+	"k8s.io/client-go/metadata/metadatalister"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
+	kubevirtv1 "kubevirt.io/api/core/v1"
 )
 
 // WatchFactory initializes and manages common kube watches
@@ -81,16 +93,21 @@ type WatchFactory struct {
 	// requirements with atomic accesses
 	handlerCounter uint64
 
-	iFactory             informerfactory.SharedInformerFactory
-	anpFactory           anpinformerfactory.SharedInformerFactory
-	eipFactory           egressipinformerfactory.SharedInformerFactory
-	efFactory            egressfirewallinformerfactory.SharedInformerFactory
-	cpipcFactory         ocpcloudnetworkinformerfactory.SharedInformerFactory
-	egressQoSFactory     egressqosinformerfactory.SharedInformerFactory
-	mnpFactory           mnpinformerfactory.SharedInformerFactory
-	egressServiceFactory egressserviceinformerfactory.SharedInformerFactory
-	apbRouteFactory      adminbasedpolicyinformerfactory.SharedInformerFactory
-	informers            map[reflect.Type]*informer
+	iFactory              informerfactory.SharedInformerFactory
+	anpFactory            anpinformerfactory.SharedInformerFactory
+	eipFactory            egressipinformerfactory.SharedInformerFactory
+	efFactory             egressfirewallinformerfactory.SharedInformerFactory
+	cpipcFactory          ocpcloudnetworkinformerfactory.SharedInformerFactory
+	egressQoSFactory      egressqosinformerfactory.SharedInformerFactory
+	mnpFactory            mnpinformerfactory.SharedInformerFactory
+	egressServiceFactory  egressserviceinformerfactory.SharedInformerFactory
+	apbRouteFactory       adminbasedpolicyinformerfactory.SharedInformerFactory
+	ipamClaimFactory      ipamclaiminformerfactory.SharedInformerFactory
+	clusterNetworkFactory clusternetworkinformerfactory.SharedInformerFactory
+	// vmMetadataFactory watches the metadata of KubeVirt virtual machines,
+	// nil if KubeVirt is not installed
+	vmMetadataFactory metadatainformer.SharedInformerFactory
+	informers         map[reflect.Type]*informer
 
 	stopChan chan struct{}
 }
@@ -156,6 +173,8 @@ var (
 	CloudPrivateIPConfigType              reflect.Type = reflect.TypeOf(&ocpcloudnetworkapi.CloudPrivateIPConfig{})
 	EgressQoSType                         reflect.Type = reflect.TypeOf(&egressqosapi.EgressQoS{})
 	EgressServiceType                     reflect.Type = reflect.TypeOf(&egressserviceapi.EgressService{})
+	IPAMClaimType                         reflect.Type = reflect.TypeOf(&ipamclaimapi.IPAMClaim{})
+	ClusterNetworkType                    reflect.Type = reflect.TypeOf(&clusternetworkapi.ClusterNetwork{})
 	AdminNetworkPolicyType                reflect.Type = reflect.TypeOf(&anpapi.AdminNetworkPolicy{})
 	BaselineAdminNetworkPolicyType        reflect.Type = reflect.TypeOf(&anpapi.BaselineAdminNetworkPolicy{})
 	AddressSetNamespaceAndPodSelectorType reflect.Type = reflect.TypeOf(&addressSetNamespaceAndPodSelector{})
@@ -173,6 +192,9 @@ var (
 	ServiceForFakeNodePortWatcherType         reflect.Type = reflect.TypeOf(&serviceForFakeNodePortWatcher{}) // only for unit tests
 )
 
+// virtualMachineResource is the resource of KubeVirt virtual machines
+var virtualMachineResource = kubevirtv1.GroupVersion.WithResource("virtualmachines")
+
 // NewMasterWatchFactory initializes a new watch factory for:
 // a) ovnkube controller + cluster manager or
 // b) ovnkube controller + node
@@ -219,16 +241,17 @@ func NewOVNKubeControllerWatchFactory(ovnClientset *util.OVNKubeControllerClient
 	// the downside of making it tight (like 10 minutes) is needless spinning on all resources
 	// However, AddEventHandlerWithResyncPeriod can specify a per handler resync period
 	wf := &WatchFactory{
-		iFactory:             informerfactory.NewSharedInformerFactoryWithOptions(ovnClientset.KubeClient, resyncInterval, informerfactory.WithTransform(informerObjectTrim)),
-		anpFactory:           anpinformerfactory.NewSharedInformerFactory(ovnClientset.ANPClient, resyncInterval),
-		eipFactory:           egressipinformerfactory.NewSharedInformerFactory(ovnClientset.EgressIPClient, resyncInterval),
-		efFactory:            egressfirewallinformerfactory.NewSharedInformerFactory(ovnClientset.EgressFirewallClient, resyncInterval),
-		egressQoSFactory:     egressqosinformerfactory.NewSharedInformerFactory(ovnClientset.EgressQoSClient, resyncInterval),
-		mnpFactory:           mnpinformerfactory.NewSharedInformerFactory(ovnClientset.MultiNetworkPolicyClient, resyncInterval),
-		egressServiceFactory: egressserviceinformerfactory.NewSharedInformerFactory(ovnClientset.EgressServiceClient, resyncInterval),
-		apbRouteFactory:      adminbasedpolicyinformerfactory.NewSharedInformerFactory(ovnClientset.AdminPolicyRouteClient, resyncInterval),
-		informers:            make(map[reflect.Type]*informer),
-		stopChan:             make(chan struct{}),
+		iFactory:              informerfactory.NewSharedInformerFactoryWithOptions(ovnClientset.KubeClient, resyncInterval, informerfactory.WithTransform(informerObjectTrim)),
+		anpFactory:            anpinformerfactory.NewSharedInformerFactory(ovnClientset.ANPClient, resyncInterval),
+		eipFactory:            egressipinformerfactory.NewSharedInformerFactory(ovnClientset.EgressIPClient, resyncInterval),
+		efFactory:             egressfirewallinformerfactory.NewSharedInformerFactory(ovnClientset.EgressFirewallClient, resyncInterval),
+		egressQoSFactory:      egressqosinformerfactory.NewSharedInformerFactory(ovnClientset.EgressQoSClient, resyncInterval),
+		mnpFactory:            mnpinformerfactory.NewSharedInformerFactory(ovnClientset.MultiNetworkPolicyClient, resyncInterval),
+		egressServiceFactory:  egressserviceinformerfactory.NewSharedInformerFactory(ovnClientset.EgressServiceClient, resyncInterval),
+		apbRouteFactory:       adminbasedpolicyinformerfactory.NewSharedInformerFactory(ovnClientset.AdminPolicyRouteClient, resyncInterval),
+		clusterNetworkFactory: clusternetworkinformerfactory.NewSharedInformerFactory(ovnClientset.ClusterNetworkClient, resyncInterval),
+		informers:             make(map[reflect.Type]*informer),
+		stopChan:              make(chan struct{}),
 	}
 
 	if err := anpapi.AddToScheme(anpscheme.Scheme); err != nil {
@@ -259,6 +282,10 @@ func NewOVNKubeControllerWatchFactory(ovnClientset *util.OVNKubeControllerClient
 		return nil, err
 	}
 
+	if err := clusternetworkapi.AddToScheme(clusternetworkscheme.Scheme); err != nil {
+		return nil, err
+	}
+
 	// For Services and Endpoints, pre-populate the shared Informer with one that
 	// has a label selector excluding headless services.
 	wf.iFactory.InformerFor(&kapi.Service{}, func(c kubernetes.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
@@ -343,6 +370,13 @@ func NewOVNKubeControllerWatchFactory(ovnClientset *util.OVNKubeControllerClient
 		}
 	}
 
+	if config.OVNKubernetesFeature.EnableNodeSubnetExpansion {
+		wf.informers[ClusterNetworkType], err = newInformer(ClusterNetworkType, wf.clusterNetworkFactory.K8s().V1().ClusterNetworks().Informer())
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if util.IsMultiNetworkPoliciesSupportEnabled() {
 		wf.informers[MultiNetworkPolicyType], err = newInformer(MultiNetworkPolicyType, wf.mnpFactory.K8sCniCncfIo().V1beta1().MultiNetworkPolicies().Informer())
 		if err != nil {
@@ -435,6 +469,33 @@ func (wf *WatchFactory) Start() error {
 		}
 	}
 
+	if config.OVNKubernetesFeature.EnableNodeSubnetExpansion && wf.clusterNetworkFactory != nil {
+		wf.clusterNetworkFactory.Start(wf.stopChan)
+		for oType, synced := range waitForCacheSyncWithTimeout(wf.clusterNetworkFactory, wf.stopChan) {
+			if !synced {
+				return fmt.Errorf("error in syncing cache for %v informer", oType)
+			}
+		}
+	}
+
+	if util.IsPersistentIPsEnabled() && wf.ipamClaimFactory != nil {
+		wf.ipamClaimFactory.Start(wf.stopChan)
+		for oType, synced := range waitForCacheSyncWithTimeout(wf.ipamClaimFactory, wf.stopChan) {
+			if !synced {
+				return fmt.Errorf("error in syncing cache for %v informer", oType)
+			}
+		}
+	}
+
+	if wf.vmMetadataFactory != nil {
+		wf.vmMetadataFactory.Start(wf.stopChan)
+		for gvr, synced := range wf.vmMetadataFactory.WaitForCacheSync(util.GetChildStopChanWithTimeout(wf.stopChan, types.InformerSyncTimeout)) {
+			if !synced {
+				return fmt.Errorf("error in syncing cache for %v informer", gvr)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -446,12 +507,13 @@ func (wf *WatchFactory) Start() error {
 // of the localPodSelector or figure out how to deal with selecting all pods everywhere.
 func NewNodeWatchFactory(ovnClientset *util.OVNNodeClientset, nodeName string) (*WatchFactory, error) {
 	wf := &WatchFactory{
-		iFactory:             informerfactory.NewSharedInformerFactoryWithOptions(ovnClientset.KubeClient, resyncInterval, informerfactory.WithTransform(informerObjectTrim)),
-		egressServiceFactory: egressserviceinformerfactory.NewSharedInformerFactory(ovnClientset.EgressServiceClient, resyncInterval),
-		eipFactory:           egressipinformerfactory.NewSharedInformerFactory(ovnClientset.EgressIPClient, resyncInterval),
-		apbRouteFactory:      adminbasedpolicyinformerfactory.NewSharedInformerFactory(ovnClientset.AdminPolicyRouteClient, resyncInterval),
-		informers:            make(map[reflect.Type]*informer),
-		stopChan:             make(chan struct{}),
+		iFactory:              informerfactory.NewSharedInformerFactoryWithOptions(ovnClientset.KubeClient, resyncInterval, informerfactory.WithTransform(informerObjectTrim)),
+		egressServiceFactory:  egressserviceinformerfactory.NewSharedInformerFactory(ovnClientset.EgressServiceClient, resyncInterval),
+		eipFactory:            egressipinformerfactory.NewSharedInformerFactory(ovnClientset.EgressIPClient, resyncInterval),
+		apbRouteFactory:       adminbasedpolicyinformerfactory.NewSharedInformerFactory(ovnClientset.AdminPolicyRouteClient, resyncInterval),
+		clusterNetworkFactory: clusternetworkinformerfactory.NewSharedInformerFactory(ovnClientset.ClusterNetworkClient, resyncInterval),
+		informers:             make(map[reflect.Type]*informer),
+		stopChan:              make(chan struct{}),
 	}
 
 	if err := egressserviceapi.AddToScheme(egressservicescheme.Scheme); err != nil {
@@ -463,6 +525,9 @@ func NewNodeWatchFactory(ovnClientset *util.OVNNodeClientset, nodeName string) (
 	if err := adminbasedpolicyapi.AddToScheme(adminbasedpolicyscheme.Scheme); err != nil {
 		return nil, err
 	}
+	if err := clusternetworkapi.AddToScheme(clusternetworkscheme.Scheme); err != nil {
+		return nil, err
+	}
 
 	var err error
 	wf.informers[PodType], err = newQueuedInformer(PodType, wf.iFactory.Core().V1().Pods().Informer(), wf.stopChan,
@@ -551,6 +616,13 @@ func NewNodeWatchFactory(ovnClientset *util.OVNNodeClientset, nodeName string) (
 		}
 	}
 
+	if config.OVNKubernetesFeature.EnableNodeSubnetExpansion {
+		wf.informers[ClusterNetworkType], err = newInformer(ClusterNetworkType, wf.clusterNetworkFactory.K8s().V1().ClusterNetworks().Informer())
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if config.OVNKubernetesFeature.EnableMultiExternalGateway {
 		// make sure shared informer is created for a factory, so on wf.apbRouteFactory.Start() it is initialized and caches are synced.
 		wf.apbRouteFactory.K8s().V1().AdminPolicyBasedExternalRoutes().Informer()
@@ -564,20 +636,30 @@ func NewNodeWatchFactory(ovnClientset *util.OVNNodeClientset, nodeName string) (
 // mode process.
 func NewClusterManagerWatchFactory(ovnClientset *util.OVNClusterManagerClientset) (*WatchFactory, error) {
 	wf := &WatchFactory{
-		iFactory:             informerfactory.NewSharedInformerFactoryWithOptions(ovnClientset.KubeClient, resyncInterval, informerfactory.WithTransform(informerObjectTrim)),
-		efFactory:            egressfirewallinformerfactory.NewSharedInformerFactory(ovnClientset.EgressFirewallClient, resyncInterval),
-		eipFactory:           egressipinformerfactory.NewSharedInformerFactory(ovnClientset.EgressIPClient, resyncInterval),
-		cpipcFactory:         ocpcloudnetworkinformerfactory.NewSharedInformerFactory(ovnClientset.CloudNetworkClient, resyncInterval),
-		egressServiceFactory: egressserviceinformerfactory.NewSharedInformerFactoryWithOptions(ovnClientset.EgressServiceClient, resyncInterval),
-		apbRouteFactory:      adminbasedpolicyinformerfactory.NewSharedInformerFactory(ovnClientset.AdminPolicyRouteClient, resyncInterval),
-		egressQoSFactory:     egressqosinformerfactory.NewSharedInformerFactory(ovnClientset.EgressQoSClient, resyncInterval),
-		informers:            make(map[reflect.Type]*informer),
-		stopChan:             make(chan struct{}),
+		iFactory:              informerfactory.NewSharedInformerFactoryWithOptions(ovnClientset.KubeClient, resyncInterval, informerfactory.WithTransform(informerObjectTrim)),
+		efFactory:             egressfirewallinformerfactory.NewSharedInformerFactory(ovnClientset.EgressFirewallClient, resyncInterval),
+		eipFactory:            egressipinformerfactory.NewSharedInformerFactory(ovnClientset.EgressIPClient, resyncInterval),
+		cpipcFactory:          ocpcloudnetworkinformerfactory.NewSharedInformerFactory(ovnClientset.CloudNetworkClient, resyncInterval),
+		egressServiceFactory:  egressserviceinformerfactory.NewSharedInformerFactoryWithOptions(ovnClientset.EgressServiceClient, resyncInterval),
+		apbRouteFactory:       adminbasedpolicyinformerfactory.NewSharedInformerFactory(ovnClientset.AdminPolicyRouteClient, resyncInterval),
+		egressQoSFactory:      egressqosinformerfactory.NewSharedInformerFactory(ovnClientset.EgressQoSClient, resyncInterval),
+		ipamClaimFactory:      ipamclaiminformerfactory.NewSharedInformerFactory(ovnClientset.IPAMClaimClient, resyncInterval),
+		clusterNetworkFactory: clusternetworkinformerfactory.NewSharedInformerFactory(ovnClientset.ClusterNetworkClient, resyncInterval),
+		informers:             make(map[reflect.Type]*informer),
+		stopChan:              make(chan struct{}),
 	}
 	if err := egressipapi.AddToScheme(egressipscheme.Scheme); err != nil {
 		return nil, err
 	}
 
+	if err := ipamclaimapi.AddToScheme(ipamclaimscheme.Scheme); err != nil {
+		return nil, err
+	}
+
+	if err := clusternetworkapi.AddToScheme(clusternetworkscheme.Scheme); err != nil {
+		return nil, err
+	}
+
 	if err := egressserviceapi.AddToScheme(egressservicescheme.Scheme); err != nil {
 		return nil, err
 	}
@@ -647,6 +729,27 @@ func NewClusterManagerWatchFactory(ovnClientset *util.OVNClusterManagerClientset
 		}
 	}
 
+	if util.IsPersistentIPsEnabled() {
+		wf.informers[IPAMClaimType], err = newInformer(IPAMClaimType, wf.ipamClaimFactory.K8s().V1().IPAMClaims().Informer())
+		if err != nil {
+			return nil, err
+		}
+
+		// the IPAMClaims of VMs are owned by the VMs
+		if ovnClientset.MetadataClient != nil && isVirtualMachineResourceServed(ovnClientset.KubeClient) {
+			wf.vmMetadataFactory = metadatainformer.NewSharedInformerFactory(ovnClientset.MetadataClient, resyncInterval)
+			// make sure shared informer is created for a factory, so on wf.vmMetadataFactory.Start() it is initialized and caches are synced.
+			wf.vmMetadataFactory.ForResource(virtualMachineResource)
+		}
+	}
+
+	if config.OVNKubernetesFeature.EnableNodeSubnetExpansion {
+		wf.informers[ClusterNetworkType], err = newInformer(ClusterNetworkType, wf.clusterNetworkFactory.K8s().V1().ClusterNetworks().Informer())
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if config.OVNKubernetesFeature.EnableMultiExternalGateway {
 		// make sure shared informer is created for a factory, so on wf.apbRouteFactory.Start() it is initialized and caches are synced.
 		wf.apbRouteFactory.K8s().V1().AdminPolicyBasedExternalRoutes().Informer()
@@ -723,6 +826,14 @@ func getObjectMeta(objType reflect.Type, obj interface{}) (*metav1.ObjectMeta, e
 		if multinetworkpolicy, ok := obj.(*mnpapi.MultiNetworkPolicy); ok {
 			return &multinetworkpolicy.ObjectMeta, nil
 		}
+	case IPAMClaimType:
+		if ipamClaim, ok := obj.(*ipamclaimapi.IPAMClaim); ok {
+			return &ipamClaim.ObjectMeta, nil
+		}
+	case ClusterNetworkType:
+		if clusterNetwork, ok := obj.(*clusternetworkapi.ClusterNetwork); ok {
+			return &clusterNetwork.ObjectMeta, nil
+		}
 	}
 	return nil, fmt.Errorf("cannot get ObjectMeta from type %v", objType)
 }
@@ -830,6 +941,18 @@ func (wf *WatchFactory) GetResourceHandlerFunc(objType reflect.Type) (AddHandler
 			return wf.AddEndpointSliceHandler(funcs, processExisting)
 		}, nil
 
+	case IPAMClaimType:
+		return func(namespace string, sel labels.Selector,
+			funcs cache.ResourceEventHandler, processExisting func([]interface{}) error) (*Handler, error) {
+			return wf.AddIPAMClaimHandler(funcs, processExisting)
+		}, nil
+
+	case ClusterNetworkType:
+		return func(namespace string, sel labels.Selector,
+			funcs cache.ResourceEventHandler, processExisting func([]interface{}) error) (*Handler, error) {
+			return wf.AddClusterNetworkHandler(funcs, processExisting)
+		}, nil
+
 	}
 	return nil, fmt.Errorf("cannot get ObjectMeta from type %v", objType)
 }
@@ -985,6 +1108,26 @@ func (wf *WatchFactory) RemoveNetworkAttachmentDefinitionHandler(handler *Handle
 	wf.removeHandler(NetworkAttachmentDefinitionType, handler)
 }
 
+// AddIPAMClaimHandler adds a handler function that will be executed on IPAMClaim object changes
+func (wf *WatchFactory) AddIPAMClaimHandler(handlerFuncs cache.ResourceEventHandler, processExisting func([]interface{}) error) (*Handler, error) {
+	return wf.addHandler(IPAMClaimType, "", nil, handlerFuncs, processExisting, defaultHandlerPriority)
+}
+
+// RemoveIPAMClaimHandler removes an IPAMClaim object event handler function
+func (wf *WatchFactory) RemoveIPAMClaimHandler(handler *Handler) {
+	wf.removeHandler(IPAMClaimType, handler)
+}
+
+// AddClusterNetworkHandler adds a handler function that will be executed on ClusterNetwork object changes
+func (wf *WatchFactory) AddClusterNetworkHandler(handlerFuncs cache.ResourceEventHandler, processExisting func([]interface{}) error) (*Handler, error) {
+	return wf.addHandler(ClusterNetworkType, "", nil, handlerFuncs, processExisting, defaultHandlerPriority)
+}
+
+// RemoveClusterNetworkHandler removes a ClusterNetwork object event handler function
+func (wf *WatchFactory) RemoveClusterNetworkHandler(handler *Handler) {
+	wf.removeHandler(ClusterNetworkType, handler)
+}
+
 // AddEgressIPHandler adds a handler function that will be executed on EgressIP object changes
 func (wf *WatchFactory) AddEgressIPHandler(handlerFuncs cache.ResourceEventHandler, processExisting func([]interface{}) error) (*Handler, error) {
 	return wf.addHandler(EgressIPType, "", nil, handlerFuncs, processExisting, defaultHandlerPriority)
@@ -1273,6 +1416,24 @@ func (wf *WatchFactory) EgressFirewallInformer() egressfirewallinformer.EgressFi
 	return wf.efFactory.K8s().V1().EgressFirewalls()
 }
 
+func (wf *WatchFactory) IPAMClaimInformer() ipamclaiminformer.IPAMClaimInformer {
+	return wf.ipamClaimFactory.K8s().V1().IPAMClaims()
+}
+
+func (wf *WatchFactory) ClusterNetworkInformer() clusternetworkinformer.ClusterNetworkInformer {
+	return wf.clusterNetworkFactory.K8s().V1().ClusterNetworks()
+}
+
+// VirtualMachineMetadataLister returns a lister of the metadata of KubeVirt
+// virtual machines, nil if KubeVirt was not installed when the factory was
+// created.
+func (wf *WatchFactory) VirtualMachineMetadataLister() metadatalister.Lister {
+	if wf.vmMetadataFactory == nil {
+		return nil
+	}
+	return metadatalister.New(wf.vmMetadataFactory.ForResource(virtualMachineResource).Informer().GetIndexer(), virtualMachineResource)
+}
+
 // withServiceNameAndNoHeadlessServiceSelector returns a LabelSelector (added to the
 // watcher for EndpointSlices) that will only choose EndpointSlices with a non-empty
 // "kubernetes.io/service-name" label and without "service.kubernetes.io/headless"
@@ -1304,6 +1465,22 @@ func withServiceNameAndNoHeadlessServiceSelector() func(options *metav1.ListOpti
 	}
 }
 
+// isVirtualMachineResourceServed returns whether the API server serves KubeVirt
+// virtual machines
+func isVirtualMachineResourceServed(kubeClient kubernetes.Interface) bool {
+	resources, err := kubeClient.Discovery().ServerResourcesForGroupVersion(kubevirtv1.GroupVersion.String())
+	if err != nil {
+		klog.Infof("KubeVirt virtual machines are not served: %v", err)
+		return false
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Name == virtualMachineResource.Resource {
+			return true
+		}
+	}
+	return false
+}
+
 // noAlternateProxySelector is a LabelSelector added to the watch for
 // services that excludes services with a well-known label indicating
 // proxying is via an alternate proxy.
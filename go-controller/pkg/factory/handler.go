@@ -13,9 +13,11 @@ import (
 	multinetworkpolicylister "github.com/k8snetworkplumbingwg/multi-networkpolicy/pkg/client/listers/k8s.cni.cncf.io/v1beta1"
 	networkattachmentdefinitionlister "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/listers/k8s.cni.cncf.io/v1"
 
+	clusternetworklister "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1/apis/listers/clusternetwork/v1"
 	egressfirewalllister "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1/apis/listers/egressfirewall/v1"
 	egressqoslister "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1/apis/listers/egressqos/v1"
 	egressservicelister "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1/apis/listers/egressservice/v1"
+	ipamclaimlister "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/ipamclaim/v1/apis/listers/ipamclaim/v1"
 
 	cloudprivateipconfiglister "github.com/openshift/client-go/cloudnetwork/listers/cloudnetwork/v1"
 	egressiplister "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/listers/egressip/v1"
@@ -528,6 +530,10 @@ func newInformerLister(oType reflect.Type, sharedInformer cache.SharedIndexInfor
 		return multinetworkpolicylister.NewMultiNetworkPolicyLister(sharedInformer.GetIndexer()), nil
 	case EgressServiceType:
 		return egressservicelister.NewEgressServiceLister(sharedInformer.GetIndexer()), nil
+	case IPAMClaimType:
+		return ipamclaimlister.NewIPAMClaimLister(sharedInformer.GetIndexer()), nil
+	case ClusterNetworkType:
+		return clusternetworklister.NewClusterNetworkLister(sharedInformer.GetIndexer()), nil
 	}
 
 	return nil, fmt.Errorf("cannot create lister from type %v", oType)
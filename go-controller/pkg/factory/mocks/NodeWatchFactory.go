@@ -6,6 +6,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	cache "k8s.io/client-go/tools/cache"
 
+	clusternetworkv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1/apis/informers/externalversions/clusternetwork/v1"
+
 	discoveryv1 "k8s.io/api/discovery/v1"
 
 	egressipv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/informers/externalversions/egressip/v1"
@@ -42,6 +44,32 @@ func (_m *NodeWatchFactory) APBRouteInformer() v1.AdminPolicyBasedExternalRouteI
 	return r0
 }
 
+// AddClusterNetworkHandler provides a mock function with given fields: handlerFuncs, processExisting
+func (_m *NodeWatchFactory) AddClusterNetworkHandler(handlerFuncs cache.ResourceEventHandler, processExisting func([]interface{}) error) (*factory.Handler, error) {
+	ret := _m.Called(handlerFuncs, processExisting)
+
+	var r0 *factory.Handler
+	var r1 error
+	if rf, ok := ret.Get(0).(func(cache.ResourceEventHandler, func([]interface{}) error) (*factory.Handler, error)); ok {
+		return rf(handlerFuncs, processExisting)
+	}
+	if rf, ok := ret.Get(0).(func(cache.ResourceEventHandler, func([]interface{}) error) *factory.Handler); ok {
+		r0 = rf(handlerFuncs, processExisting)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*factory.Handler)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(cache.ResourceEventHandler, func([]interface{}) error) error); ok {
+		r1 = rf(handlerFuncs, processExisting)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AddEndpointSliceHandler provides a mock function with given fields: handlerFuncs, processExisting
 func (_m *NodeWatchFactory) AddEndpointSliceHandler(handlerFuncs cache.ResourceEventHandler, processExisting func([]interface{}) error) (*factory.Handler, error) {
 	ret := _m.Called(handlerFuncs, processExisting)
@@ -172,6 +200,22 @@ func (_m *NodeWatchFactory) AddServiceHandler(handlerFuncs cache.ResourceEventHa
 	return r0, r1
 }
 
+// ClusterNetworkInformer provides a mock function with given fields:
+func (_m *NodeWatchFactory) ClusterNetworkInformer() clusternetworkv1.ClusterNetworkInformer {
+	ret := _m.Called()
+
+	var r0 clusternetworkv1.ClusterNetworkInformer
+	if rf, ok := ret.Get(0).(func() clusternetworkv1.ClusterNetworkInformer); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(clusternetworkv1.ClusterNetworkInformer)
+		}
+	}
+
+	return r0
+}
+
 // EgressIPInformer provides a mock function with given fields:
 func (_m *NodeWatchFactory) EgressIPInformer() egressipv1.EgressIPInformer {
 	ret := _m.Called()
@@ -564,6 +608,11 @@ func (_m *NodeWatchFactory) PodCoreInformer() informerscorev1.PodInformer {
 	return r0
 }
 
+// RemoveClusterNetworkHandler provides a mock function with given fields: handler
+func (_m *NodeWatchFactory) RemoveClusterNetworkHandler(handler *factory.Handler) {
+	_m.Called(handler)
+}
+
 // RemoveEndpointSliceHandler provides a mock function with given fields: handler
 func (_m *NodeWatchFactory) RemoveEndpointSliceHandler(handler *factory.Handler) {
 	_m.Called(handler)
@@ -48,7 +48,7 @@ const (
 //     ovn
 func ComposeARPProxyLSPOption() string {
 	arpProxy := []string{ARPProxyMAC, ARPProxyIPv4, ARPProxyIPv6}
-	for _, clusterSubnet := range config.Default.ClusterSubnets {
+	for _, clusterSubnet := range config.ClusterSubnets() {
 		arpProxy = append(arpProxy, clusterSubnet.CIDR.String())
 	}
 	return strings.Join(arpProxy, " ")
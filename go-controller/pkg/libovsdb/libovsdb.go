@@ -171,6 +171,8 @@ func NewSBClientWithConfig(cfg config.OvnAuthConfig, promRegistry prometheus.Reg
 			client.WithTable(&sbdb.SBGlobal{}),
 			// used for metrics
 			client.WithTable(&sbdb.PortBinding{}),
+			// used for metrics
+			client.WithTable(&sbdb.ServiceMonitor{}),
 		),
 	)
 	if err != nil {
@@ -225,6 +227,44 @@ func NewNBClientWithConfig(cfg config.OvnAuthConfig, promRegistry prometheus.Reg
 	return c, nil
 }
 
+// NewNBBFDClient creates a new OVN Northbound Database client only monitoring
+// the BFD table
+func NewNBBFDClient(stopCh <-chan struct{}) (client.Client, error) {
+	return NewNBBFDClientWithConfig(config.OvnNorth, prometheus.DefaultRegisterer, stopCh)
+}
+
+// NewNBBFDClientWithConfig creates a new OVN Northbound Database client only
+// monitoring the BFD table, with the provided configuration
+func NewNBBFDClientWithConfig(cfg config.OvnAuthConfig, promRegistry prometheus.Registerer, stopCh <-chan struct{}) (client.Client, error) {
+	dbModel, err := nbdb.FullDatabaseModel()
+	if err != nil {
+		return nil, err
+	}
+
+	enableMetricsOption := client.WithMetricsRegistryNamespaceSubsystem(promRegistry, "ovnkube",
+		"cluster_manager_libovsdb")
+
+	c, err := newClient(cfg, dbModel, stopCh, enableMetricsOption)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), types.OVSDBTimeout*2)
+	go func() {
+		<-stopCh
+		cancel()
+	}()
+
+	// used by the egress IP BFD failure detection
+	_, err = c.Monitor(ctx, c.NewMonitor(client.WithTable(&nbdb.BFD{})))
+	if err != nil {
+		c.Close()
+		return nil, err
+	}
+
+	return c, nil
+}
+
 func createTLSConfig(certFile, privKeyFile, caCertFile, serverName string) (*tls.Config, error) {
 	cert, err := tls.LoadX509KeyPair(certFile, privKeyFile)
 	if err != nil {
@@ -2,11 +2,13 @@ package ops
 
 import (
 	"context"
+	"errors"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 
 	libovsdbclient "github.com/ovn-org/libovsdb/client"
 	libovsdb "github.com/ovn-org/libovsdb/ovsdb"
+	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 )
@@ -79,6 +81,53 @@ func CreateOrUpdateLoadBalancersOps(nbClient libovsdbclient.Client, ops []libovs
 	return modelClient.CreateOrUpdateOps(ops, opModels...)
 }
 
+// CreateOrUpdateLoadBalancerHealthChecksOps creates or updates the provided
+// health checks and sets them as the health checks of the provided load
+// balancer, returning the corresponding ops. The health checks already
+// referenced by the load balancer are looked up by vip. Health checks that are
+// no longer referenced are garbage collected by OVSDB.
+func CreateOrUpdateLoadBalancerHealthChecksOps(nbClient libovsdbclient.Client, ops []libovsdb.Operation, lb *nbdb.LoadBalancer, hcs ...*nbdb.LoadBalancerHealthCheck) ([]libovsdb.Operation, error) {
+	existing := sets.New[string]()
+	if lb.UUID != "" {
+		cachedLB := &nbdb.LoadBalancer{UUID: lb.UUID}
+		ctx, cancel := context.WithTimeout(context.Background(), types.OVSDBTimeout)
+		defer cancel()
+		err := nbClient.Get(ctx, cachedLB)
+		if err != nil && !errors.Is(err, libovsdbclient.ErrNotFound) {
+			return nil, err
+		}
+		existing.Insert(cachedLB.HealthCheck...)
+	}
+
+	opModels := make([]operationModel, 0, len(hcs))
+	for i := range hcs {
+		// can't use i in the predicate, for loop replaces it in-memory
+		hc := hcs[i]
+		opModel := operationModel{
+			Model: hc,
+			ModelPredicate: func(item *nbdb.LoadBalancerHealthCheck) bool {
+				return existing.Has(item.UUID) && item.Vip == hc.Vip
+			},
+			OnModelUpdates: []interface{}{&hc.Options, &hc.ExternalIDs},
+			ErrNotFound:    false,
+			BulkOp:         false,
+		}
+		opModels = append(opModels, opModel)
+	}
+
+	modelClient := newModelClient(nbClient)
+	ops, err := modelClient.CreateOrUpdateOps(ops, opModels...)
+	if err != nil {
+		return nil, err
+	}
+
+	lb.HealthCheck = make([]string, 0, len(hcs))
+	for _, hc := range hcs {
+		lb.HealthCheck = append(lb.HealthCheck, hc.UUID)
+	}
+	return ops, nil
+}
+
 // RemoveLoadBalancerVipsOps removes the provided VIPs from the provided load
 // balancer set and returns the corresponding ops
 func RemoveLoadBalancerVipsOps(nbClient libovsdbclient.Client, ops []libovsdb.Operation, lb *nbdb.LoadBalancer, vips ...string) ([]libovsdb.Operation, error) {
@@ -119,6 +168,23 @@ func DeleteLoadBalancersOps(nbClient libovsdbclient.Client, ops []libovsdb.Opera
 	return modelClient.DeleteOps(ops, opModels...)
 }
 
+type loadBalancerPredicate func(*nbdb.LoadBalancer) bool
+
+// DeleteLoadBalancersWithPredicateOps returns the ops to delete the load
+// balancers matching the provided predicate
+func DeleteLoadBalancersWithPredicateOps(nbClient libovsdbclient.Client, ops []libovsdb.Operation,
+	p loadBalancerPredicate) ([]libovsdb.Operation, error) {
+	opModel := operationModel{
+		Model:          &nbdb.LoadBalancer{},
+		ModelPredicate: p,
+		ErrNotFound:    false,
+		BulkOp:         true,
+	}
+
+	modelClient := newModelClient(nbClient)
+	return modelClient.DeleteOps(ops, opModel)
+}
+
 // DeleteLoadBalancers deletes the provided load balancers
 func DeleteLoadBalancers(nbClient libovsdbclient.Client, lbs []*nbdb.LoadBalancer) error {
 	ops, err := DeleteLoadBalancersOps(nbClient, nil, lbs...)
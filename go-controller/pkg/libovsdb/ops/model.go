@@ -28,6 +28,8 @@ func getUUID(model model.Model) string {
 		return t.UUID
 	case *nbdb.LoadBalancerGroup:
 		return t.UUID
+	case *nbdb.LoadBalancerHealthCheck:
+		return t.UUID
 	case *nbdb.LogicalRouter:
 		return t.UUID
 	case *nbdb.LogicalRouterPolicy:
@@ -91,6 +93,8 @@ func setUUID(model model.Model, uuid string) {
 		t.UUID = uuid
 	case *nbdb.LoadBalancerGroup:
 		t.UUID = uuid
+	case *nbdb.LoadBalancerHealthCheck:
+		t.UUID = uuid
 	case *nbdb.LogicalRouter:
 		t.UUID = uuid
 	case *nbdb.LogicalRouterPolicy:
@@ -179,6 +183,10 @@ func copyIndexes(model model.Model) model.Model {
 			UUID: t.UUID,
 			Name: t.Name,
 		}
+	case *nbdb.LoadBalancerHealthCheck:
+		return &nbdb.LoadBalancerHealthCheck{
+			UUID: t.UUID,
+		}
 	case *nbdb.LogicalRouter:
 		return &nbdb.LogicalRouter{
 			UUID: t.UUID,
@@ -302,6 +310,8 @@ func getListFromModel(model model.Model) interface{} {
 		return &[]*nbdb.LoadBalancer{}
 	case *nbdb.LoadBalancerGroup:
 		return &[]*nbdb.LoadBalancerGroup{}
+	case *nbdb.LoadBalancerHealthCheck:
+		return &[]*nbdb.LoadBalancerHealthCheck{}
 	case *nbdb.LogicalRouter:
 		return &[]*nbdb.LogicalRouter{}
 	case *nbdb.LogicalRouterPolicy:
@@ -764,6 +764,17 @@ func DeleteLogicalRouterStaticRoutes(nbClient libovsdbclient.Client, routerName
 
 // BFD ops
 
+type bfdPredicate func(*nbdb.BFD) bool
+
+// FindBFDsWithPredicate looks up BFDs from the cache based on a given predicate
+func FindBFDsWithPredicate(nbClient libovsdbclient.Client, p bfdPredicate) ([]*nbdb.BFD, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), types.OVSDBTimeout)
+	defer cancel()
+	found := []*nbdb.BFD{}
+	err := nbClient.WhereCache(p).List(ctx, &found)
+	return found, err
+}
+
 // CreateOrUpdateBFDOps creates or updates the provided BFDs and returns
 // the corresponding ops
 func CreateOrUpdateBFDOps(nbClient libovsdbclient.Client, ops []libovsdb.Operation, bfds ...*nbdb.BFD) ([]libovsdb.Operation, error) {
@@ -783,6 +794,23 @@ func CreateOrUpdateBFDOps(nbClient libovsdbclient.Client, ops []libovsdb.Operati
 	return m.CreateOrUpdateOps(ops, opModels...)
 }
 
+// DeleteBFDsOps returns the ops to delete the provided BFDs
+func DeleteBFDsOps(nbClient libovsdbclient.Client, ops []libovsdb.Operation, bfds ...*nbdb.BFD) ([]libovsdb.Operation, error) {
+	opModels := make([]operationModel, 0, len(bfds))
+	for i := range bfds {
+		bfd := bfds[i]
+		opModel := operationModel{
+			Model:       bfd,
+			ErrNotFound: false,
+			BulkOp:      false,
+		}
+		opModels = append(opModels, opModel)
+	}
+
+	m := newModelClient(nbClient)
+	return m.DeleteOps(ops, opModels...)
+}
+
 // DeleteBFDs deletes the provided BFDs
 func DeleteBFDs(nbClient libovsdbclient.Client, bfds ...*nbdb.BFD) error {
 	opModels := make([]operationModel, 0, len(bfds))
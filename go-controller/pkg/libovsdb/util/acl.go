@@ -2,6 +2,7 @@ package util
 
 import (
 	"fmt"
+	"net"
 	"strings"
 
 	libovsdbclient "github.com/ovn-org/libovsdb/client"
@@ -12,6 +13,8 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	knet "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilnet "k8s.io/utils/net"
 )
 
 // aclPipelineType defines when ACLs will be applied (direction and pipeline stage).
@@ -324,3 +327,74 @@ func GetL4MatchesFromNetworkPolicyPorts(rulePorts []*NetworkPolicyPort) map[stri
 	}
 	return l4Matches
 }
+
+// NamedPortL4MatchSuffix is appended to the protocol used in the
+// libovsdbops.PortPolicyProtocolKey DB Index of the ACLs created for named
+// ports, so that they don't collide with the ACLs created for port numbers
+// and port ranges of the same protocol within a given gress rule.
+const NamedPortL4MatchSuffix = "-namedPort"
+
+// NamedNetworkPolicyPort is an internal representation of a named port
+// resolved against the container ports of a given pod
+type NamedNetworkPolicyPort struct {
+	L4Protocol    string // will store the OVN protocol string syntax for the corresponding K8s protocol
+	L3PodIP       string
+	L3PodIPFamily string // will store the OVN IP family syntax ("ip4" or "ip6") of L3PodIP
+	L4PodPort     int32
+}
+
+// GetNamedNetworkPolicyPorts returns the NamedNetworkPolicyPorts for the
+// container port called portName of the given pod, one per pod IP.
+// It returns an empty slice if no container of the pod exposes such port.
+func GetNamedNetworkPolicyPorts(pod *v1.Pod, podIPs []net.IP, portName string) []NamedNetworkPolicyPort {
+	namedPorts := []NamedNetworkPolicyPort{}
+	for _, container := range pod.Spec.Containers {
+		for _, containerPort := range container.Ports {
+			if containerPort.Name != portName {
+				continue
+			}
+			proto := containerPort.Protocol
+			if proto == "" {
+				proto = v1.ProtocolTCP
+			}
+			for _, podIP := range podIPs {
+				ipFamily := "ip4"
+				if utilnet.IsIPv6(podIP) {
+					ipFamily = "ip6"
+				}
+				namedPorts = append(namedPorts, NamedNetworkPolicyPort{
+					L4Protocol:    convertK8sProtocolToOVNProtocol(proto),
+					L3PodIP:       podIP.String(),
+					L3PodIPFamily: ipFamily,
+					L4PodPort:     containerPort.ContainerPort,
+				})
+			}
+		}
+	}
+	return namedPorts
+}
+
+// GetL3L4MatchesFromNamedPorts accepts the named ports cache of a gress rule,
+// keyed by port name, and constructs the l3l4Matches for each protocol type
+// It returns a map that has protocol as the key and the l3l4Match as the value
+// The returned map is empty if none of the named ports could be resolved.
+func GetL3L4MatchesFromNamedPorts(ruleNamedPorts map[string]sets.Set[NamedNetworkPolicyPort]) map[string]string {
+	protoMatches := make(map[string]sets.Set[string])
+	for _, namedPorts := range ruleNamedPorts {
+		for namedPort := range namedPorts {
+			matches, ok := protoMatches[namedPort.L4Protocol]
+			if !ok {
+				matches = sets.New[string]()
+				protoMatches[namedPort.L4Protocol] = matches
+			}
+			matches.Insert(fmt.Sprintf("(%s.dst == %s && %s.dst == %d)", namedPort.L3PodIPFamily,
+				namedPort.L3PodIP, namedPort.L4Protocol, namedPort.L4PodPort))
+		}
+	}
+	l3l4Matches := make(map[string]string)
+	for protocol, matches := range protoMatches {
+		// sort the matches so that the ACL match doesn't change across syncs
+		l3l4Matches[protocol] = fmt.Sprintf("(%s)", strings.Join(sets.List(matches), " || "))
+	}
+	return l3l4Matches
+}
@@ -1,10 +1,12 @@
 package util
 
 import (
+	"net"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 )
 
 func TestConvertK8sProtocolToOVNProtocol(t *testing.T) {
@@ -170,3 +172,99 @@ func TestGetL4Match(t *testing.T) {
 		assert.Equal(t, tc.expected, l4Match)
 	}
 }
+
+func TestGetNamedNetworkPolicyPorts(t *testing.T) {
+	pod := &v1.Pod{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Ports: []v1.ContainerPort{
+						{Name: "web", ContainerPort: 8080},
+						{Name: "dns", ContainerPort: 5353, Protocol: v1.ProtocolUDP},
+					},
+				},
+			},
+		},
+	}
+	podIPs := []net.IP{net.ParseIP("10.128.1.3"), net.ParseIP("fe00:10:128:1::3")}
+	testcases := []struct {
+		desc     string
+		portName string
+		expected []NamedNetworkPolicyPort
+	}{
+		{
+			"unknown port name",
+			"http",
+			[]NamedNetworkPolicyPort{},
+		},
+		{
+			"port without protocol defaults to tcp",
+			"web",
+			[]NamedNetworkPolicyPort{
+				{L4Protocol: "tcp", L3PodIP: "10.128.1.3", L3PodIPFamily: "ip4", L4PodPort: 8080},
+				{L4Protocol: "tcp", L3PodIP: "fe00:10:128:1::3", L3PodIPFamily: "ip6", L4PodPort: 8080},
+			},
+		},
+		{
+			"udp port",
+			"dns",
+			[]NamedNetworkPolicyPort{
+				{L4Protocol: "udp", L3PodIP: "10.128.1.3", L3PodIPFamily: "ip4", L4PodPort: 5353},
+				{L4Protocol: "udp", L3PodIP: "fe00:10:128:1::3", L3PodIPFamily: "ip6", L4PodPort: 5353},
+			},
+		},
+	}
+	for _, tc := range testcases {
+		assert.Equal(t, tc.expected, GetNamedNetworkPolicyPorts(pod, podIPs, tc.portName), tc.desc)
+	}
+}
+
+func TestGetL3L4MatchesFromNamedPorts(t *testing.T) {
+	testcases := []struct {
+		desc       string
+		namedPorts map[string]sets.Set[NamedNetworkPolicyPort]
+		expected   map[string]string
+	}{
+		{
+			"unresolved named ports",
+			map[string]sets.Set[NamedNetworkPolicyPort]{
+				"web": sets.New[NamedNetworkPolicyPort](),
+			},
+			map[string]string{},
+		},
+		{
+			"single resolved named port",
+			map[string]sets.Set[NamedNetworkPolicyPort]{
+				"web": sets.New(
+					NamedNetworkPolicyPort{L4Protocol: "tcp", L3PodIP: "10.128.1.3", L3PodIPFamily: "ip4", L4PodPort: 8080},
+				),
+			},
+			map[string]string{
+				"tcp": "((ip4.dst == 10.128.1.3 && tcp.dst == 8080))",
+			},
+		},
+		{
+			"multiple named ports and protocols",
+			map[string]sets.Set[NamedNetworkPolicyPort]{
+				"web": sets.New(
+					NamedNetworkPolicyPort{L4Protocol: "tcp", L3PodIP: "10.128.1.4", L3PodIPFamily: "ip4", L4PodPort: 8080},
+					NamedNetworkPolicyPort{L4Protocol: "tcp", L3PodIP: "fe00:10:128:1::4", L3PodIPFamily: "ip6", L4PodPort: 8080},
+				),
+				"web-alt": sets.New(
+					NamedNetworkPolicyPort{L4Protocol: "tcp", L3PodIP: "10.128.1.3", L3PodIPFamily: "ip4", L4PodPort: 8081},
+				),
+				"dns": sets.New(
+					NamedNetworkPolicyPort{L4Protocol: "udp", L3PodIP: "10.128.1.3", L3PodIPFamily: "ip4", L4PodPort: 5353},
+				),
+			},
+			map[string]string{
+				"tcp": "((ip4.dst == 10.128.1.3 && tcp.dst == 8081) || (ip4.dst == 10.128.1.4 && tcp.dst == 8080) || " +
+					"(ip6.dst == fe00:10:128:1::4 && tcp.dst == 8080))",
+				"udp": "((ip4.dst == 10.128.1.3 && udp.dst == 5353))",
+			},
+		},
+	}
+	for _, tc := range testcases {
+		assert.Equal(t, tc.expected, GetL3L4MatchesFromNamedPorts(tc.namedPorts), tc.desc)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"k8s.io/apimachinery/pkg/util/sets"
 	utilnet "k8s.io/utils/net"
 )
 
@@ -24,11 +25,14 @@ import (
 // On first look it may seem like we are sending out traffic that doesn't "fit/match" other routes which is true, but the intent is that
 // if we don't know where to send the traffic within the cluster, then we make it leave the cluster (we have a flow on br-ex that protects us and
 // drops it if its not supposed to be going outside). This is needed when IC=true to ensure traffic from the other node arriving at this remote node
-// does not get dropped. This removes the need for per-pod LRSR for primaryEIP and secondaryEIP && ESVC add a per-pod LRP on each egressNode to
+// does not get dropped. This removes the need for per-pod LRSR for ESVC that adds a per-pod LRP on each egressNode to
 // override this LRSR and send it to it's management port. NOTE: Handle changes around this logic with care. This is being added intentionally.
-// (TODO: FIXME): With this route, we are officially breaking support for IC with zones that have multiple-nodes
+// EgressIP does not rely on this route: it adds per-pod LRPs for remote pods on the zone of the egressNode instead, which also works
+// for zones that have multiple nodes.
+// (TODO: FIXME): Egress services and pod live migration still don't support IC with zones that have multiple-nodes as
+// this route is per node while it matches the traffic of the pods of all the nodes of the zone.
 // NOTE: This route is exactly the same as what is added by pod-live-migration feature and we keep the route exactly
-// same across the 3 features so that if the route already exists on the node, this is just a no-op
+// same across the 2 features so that if the route already exists on the node, this is just a no-op
 func CreateDefaultRouteToExternal(nbClient libovsdbclient.Client, nodeName string) error {
 	gatewayIPs, err := GetLRPAddrs(nbClient, types.GWRouterToJoinSwitchPrefix+types.GWRouterPrefix+nodeName)
 	if err != nil {
@@ -60,3 +64,28 @@ func CreateDefaultRouteToExternal(nbClient libovsdbclient.Client, nodeName strin
 	}
 	return nil
 }
+
+// DeleteDefaultRouteToExternal deletes the "catch-all" LRSR added by CreateDefaultRouteToExternal for the given node.
+// Only the routes of the whole cluster subnets are deleted, not the ones of the node subnets sharing the same nexthop.
+func DeleteDefaultRouteToExternal(nbClient libovsdbclient.Client, nodeName string) error {
+	gatewayIPs, err := GetLRPAddrs(nbClient, types.GWRouterToJoinSwitchPrefix+types.GWRouterPrefix+nodeName)
+	if err != nil {
+		return fmt.Errorf("attempt at finding node gateway router %s network information failed, err: %w", nodeName, err)
+	}
+	clusterSubnets := sets.New[string]()
+	for _, subnet := range util.GetAllClusterSubnets() {
+		clusterSubnets.Insert(subnet.String())
+	}
+	nextHops := sets.New[string]()
+	for _, gatewayIP := range gatewayIPs {
+		nextHops.Insert(gatewayIP.IP.String())
+	}
+	p := func(lrsr *nbdb.LogicalRouterStaticRoute) bool {
+		return clusterSubnets.Has(lrsr.IPPrefix) && nextHops.Has(lrsr.Nexthop) &&
+			lrsr.Policy != nil && *lrsr.Policy == nbdb.LogicalRouterStaticRoutePolicySrcIP
+	}
+	if err := libovsdbops.DeleteLogicalRouterStaticRoutesWithPredicate(nbClient, types.OVNClusterRouter, p); err != nil {
+		return fmt.Errorf("unable to delete pod to external catch-all reroute for node %s, err: %v", nodeName, err)
+	}
+	return nil
+}
@@ -19,6 +19,7 @@ import (
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/sbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -269,6 +270,52 @@ var metricEgressFirewallCount = prometheus.NewGauge(prometheus.GaugeOpts{
 	Help:      "The number of egress firewall policies",
 })
 
+var metricEgressFirewallDNSLearnedNames = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemController,
+	Name:      "egress_firewall_dns_learned_names",
+	Help:      "The number of names matching wildcard egress firewall dnsNames that are learned from DNS responses",
+})
+
+var metricEgressFirewallDNSLearnedNamesEvicted = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemController,
+	Name:      "egress_firewall_dns_learned_names_evicted_total",
+	Help:      "The total number of learned names evicted because the cache of a wildcard egress firewall dnsName was full",
+})
+
+var metricLBHealthCheckFailovers = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemController,
+	Name:      "lb_health_check_failovers_total",
+	Help:      "The total number of load balancer backends taken out of service by OVN health checks",
+})
+
+var metricLBHealthCheckOfflineBackends = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemController,
+	Name:      "lb_health_check_offline_backends",
+	Help:      "The number of load balancer backends currently considered offline by OVN health checks",
+})
+
+var metricEgressIPBFDSessionStatus = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemController,
+	Name:      "egress_ip_bfd_session_status",
+	Help:      "The status of the BFD sessions between egress nodes, 1 if up and 0 otherwise",
+}, []string{
+	"node",
+	"peer",
+	"peer_ip",
+})
+
+var metricEgressIPBFDSessionFailures = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemController,
+	Name:      "egress_ip_bfd_session_failures_total",
+	Help:      "The total number of BFD sessions between egress nodes that went down",
+})
+
 /** AdminNetworkPolicyMetrics Begin**/
 var metricANPCount = prometheus.NewGauge(prometheus.GaugeOpts{
 	Namespace: MetricOvnkubeNamespace,
@@ -422,6 +469,8 @@ func RegisterOVNKubeControllerFunctional() {
 	}
 	prometheus.MustRegister(metricEgressFirewallRuleCount)
 	prometheus.MustRegister(metricEgressFirewallCount)
+	prometheus.MustRegister(metricEgressFirewallDNSLearnedNames)
+	prometheus.MustRegister(metricEgressFirewallDNSLearnedNamesEvicted)
 	prometheus.MustRegister(metricEgressRoutingViaHost)
 	prometheus.MustRegister(metricANPCount)
 	prometheus.MustRegister(metricBANPCount)
@@ -588,6 +637,95 @@ func ipsecMetricHandler(table string, model model.Model) {
 	}
 }
 
+// MonitorLBHealthChecks will register the load balancer health check metrics. It will also add a handler
+// to SB libovsdb cache to update them from the status of the service monitors.
+// This function should only be called once.
+func MonitorLBHealthChecks(sbClient libovsdbclient.Client) {
+	prometheus.MustRegister(metricLBHealthCheckFailovers)
+	prometheus.MustRegister(metricLBHealthCheckOfflineBackends)
+	sbClient.Cache().AddEventHandler(&cache.EventHandlerFuncs{
+		AddFunc: func(table string, model model.Model) {
+			lbHealthCheckMetricHandler(table, nil, model)
+		},
+		UpdateFunc: func(table string, old, new model.Model) {
+			lbHealthCheckMetricHandler(table, old, new)
+		},
+		DeleteFunc: func(table string, model model.Model) {
+			lbHealthCheckMetricHandler(table, model, nil)
+		},
+	})
+}
+
+func lbHealthCheckMetricHandler(table string, old, new model.Model) {
+	if table != sbdb.ServiceMonitorTable {
+		return
+	}
+	wasOffline := old != nil && isServiceMonitorOffline(old.(*sbdb.ServiceMonitor))
+	isOffline := new != nil && isServiceMonitorOffline(new.(*sbdb.ServiceMonitor))
+	switch {
+	case !wasOffline && isOffline:
+		metricLBHealthCheckOfflineBackends.Inc()
+		if old != nil {
+			// only a backend that was in service can fail over
+			metricLBHealthCheckFailovers.Inc()
+		}
+	case wasOffline && !isOffline:
+		metricLBHealthCheckOfflineBackends.Dec()
+	}
+}
+
+func isServiceMonitorOffline(serviceMonitor *sbdb.ServiceMonitor) bool {
+	return serviceMonitor.Status != nil && *serviceMonitor.Status != sbdb.ServiceMonitorStatusOnline
+}
+
+// MonitorEgressIPBFD will register the egress IP BFD session metrics. It will also add a handler to NB libovsdb
+// cache to update them from the status of the BFD sessions between egress nodes.
+// This function should only be called once.
+func MonitorEgressIPBFD(nbClient libovsdbclient.Client) {
+	prometheus.MustRegister(metricEgressIPBFDSessionStatus)
+	prometheus.MustRegister(metricEgressIPBFDSessionFailures)
+	nbClient.Cache().AddEventHandler(&cache.EventHandlerFuncs{
+		AddFunc: func(table string, model model.Model) {
+			egressIPBFDMetricHandler(table, nil, model)
+		},
+		UpdateFunc: func(table string, old, new model.Model) {
+			egressIPBFDMetricHandler(table, old, new)
+		},
+		DeleteFunc: func(table string, model model.Model) {
+			egressIPBFDMetricHandler(table, model, nil)
+		},
+	})
+}
+
+func egressIPBFDMetricHandler(table string, old, new model.Model) {
+	if table != nbdb.BFDTable {
+		return
+	}
+	if new == nil {
+		bfd := old.(*nbdb.BFD)
+		if node, ok := bfd.ExternalIDs[types.EgressIPBFDNodeExternalID]; ok {
+			metricEgressIPBFDSessionStatus.DeleteLabelValues(node, bfd.ExternalIDs[types.EgressIPBFDPeerExternalID], bfd.DstIP)
+		}
+		return
+	}
+	bfd := new.(*nbdb.BFD)
+	node, ok := bfd.ExternalIDs[types.EgressIPBFDNodeExternalID]
+	if !ok {
+		return
+	}
+	status := 0.0
+	if isBFDUp(bfd) {
+		status = 1
+	} else if old != nil && isBFDUp(old.(*nbdb.BFD)) {
+		metricEgressIPBFDSessionFailures.Inc()
+	}
+	metricEgressIPBFDSessionStatus.WithLabelValues(node, bfd.ExternalIDs[types.EgressIPBFDPeerExternalID], bfd.DstIP).Set(status)
+}
+
+func isBFDUp(bfd *nbdb.BFD) bool {
+	return bfd.Status != nil && *bfd.Status == nbdb.BFDStatusUp
+}
+
 // IncrementEgressFirewallCount increments the number of Egress firewalls
 func IncrementEgressFirewallCount() {
 	metricEgressFirewallCount.Inc()
@@ -598,6 +736,16 @@ func DecrementEgressFirewallCount() {
 	metricEgressFirewallCount.Dec()
 }
 
+// UpdateEgressFirewallDNSLearnedNames records the number of names learned for wildcard egress firewall dnsNames
+func UpdateEgressFirewallDNSLearnedNames(count float64) {
+	metricEgressFirewallDNSLearnedNames.Add(count)
+}
+
+// IncrementEgressFirewallDNSLearnedNamesEvicted increments the number of evicted learned names
+func IncrementEgressFirewallDNSLearnedNamesEvicted() {
+	metricEgressFirewallDNSLearnedNamesEvicted.Inc()
+}
+
 // IncrementANPCount increments the number of Admin Network Policies
 func IncrementANPCount() {
 	metricANPCount.Inc()
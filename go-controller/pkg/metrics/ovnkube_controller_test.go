@@ -10,13 +10,17 @@ import (
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics/mocks"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/sbdb"
 	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	fakeclientgo "k8s.io/client-go/kubernetes/fake"
 
 	"github.com/onsi/ginkgo"
 	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 )
 
 func setupOvn(nbData libovsdbtest.TestSetup) (client.Client, client.Client, *libovsdbtest.Context) {
@@ -234,3 +238,102 @@ var _ = ginkgo.Describe("Config Duration Operations", func() {
 		})
 	})
 })
+
+var _ = ginkgo.Describe("LB Health Check Operations", func() {
+	getValue := func(metric prometheus.Metric) float64 {
+		m := &dto.Metric{}
+		gomega.Expect(metric.Write(m)).To(gomega.Succeed())
+		if m.Counter != nil {
+			return m.Counter.GetValue()
+		}
+		return m.Gauge.GetValue()
+	}
+	serviceMonitor := func(status *sbdb.ServiceMonitorStatus) *sbdb.ServiceMonitor {
+		return &sbdb.ServiceMonitor{IP: "10.128.0.2", Port: 8080, Status: status}
+	}
+
+	ginkgo.It("records failovers and offline backends", func() {
+		failovers := getValue(metricLBHealthCheckFailovers)
+		offline := getValue(metricLBHealthCheckOfflineBackends)
+
+		// a backend that is offline since it started being monitored did not fail over
+		lbHealthCheckMetricHandler(sbdb.ServiceMonitorTable, nil, serviceMonitor(&sbdb.ServiceMonitorStatusOffline))
+		gomega.Expect(getValue(metricLBHealthCheckFailovers)).To(gomega.Equal(failovers))
+		gomega.Expect(getValue(metricLBHealthCheckOfflineBackends)).To(gomega.Equal(offline + 1))
+
+		lbHealthCheckMetricHandler(sbdb.ServiceMonitorTable, serviceMonitor(&sbdb.ServiceMonitorStatusOffline),
+			serviceMonitor(&sbdb.ServiceMonitorStatusOnline))
+		gomega.Expect(getValue(metricLBHealthCheckOfflineBackends)).To(gomega.Equal(offline))
+
+		lbHealthCheckMetricHandler(sbdb.ServiceMonitorTable, serviceMonitor(&sbdb.ServiceMonitorStatusOnline),
+			serviceMonitor(&sbdb.ServiceMonitorStatusOffline))
+		gomega.Expect(getValue(metricLBHealthCheckFailovers)).To(gomega.Equal(failovers + 1))
+		gomega.Expect(getValue(metricLBHealthCheckOfflineBackends)).To(gomega.Equal(offline + 1))
+
+		lbHealthCheckMetricHandler(sbdb.ServiceMonitorTable, serviceMonitor(&sbdb.ServiceMonitorStatusOffline), nil)
+		gomega.Expect(getValue(metricLBHealthCheckFailovers)).To(gomega.Equal(failovers + 1))
+		gomega.Expect(getValue(metricLBHealthCheckOfflineBackends)).To(gomega.Equal(offline))
+	})
+})
+
+var _ = ginkgo.Describe("Egress IP BFD Operations", func() {
+	getValue := func(metric prometheus.Metric) float64 {
+		m := &dto.Metric{}
+		gomega.Expect(metric.Write(m)).To(gomega.Succeed())
+		if m.Counter != nil {
+			return m.Counter.GetValue()
+		}
+		return m.Gauge.GetValue()
+	}
+	countSessions := func() int {
+		ch := make(chan prometheus.Metric, 10)
+		metricEgressIPBFDSessionStatus.Collect(ch)
+		close(ch)
+		return len(ch)
+	}
+	bfd := func(status *nbdb.BFDStatus) *nbdb.BFD {
+		return &nbdb.BFD{
+			LogicalPort: "rtoe-GR_node1",
+			DstIP:       "172.18.0.3",
+			Status:      status,
+			ExternalIDs: map[string]string{
+				types.EgressIPBFDNodeExternalID: "node1",
+				types.EgressIPBFDPeerExternalID: "node2",
+			},
+		}
+	}
+
+	ginkgo.It("records the session status and failures", func() {
+		failures := getValue(metricEgressIPBFDSessionFailures)
+		status := func() float64 {
+			return getValue(metricEgressIPBFDSessionStatus.WithLabelValues("node1", "node2", "172.18.0.3"))
+		}
+
+		egressIPBFDMetricHandler(nbdb.BFDTable, nil, bfd(nil))
+		gomega.Expect(status()).To(gomega.Equal(0.0))
+
+		egressIPBFDMetricHandler(nbdb.BFDTable, bfd(nil), bfd(&nbdb.BFDStatusUp))
+		gomega.Expect(status()).To(gomega.Equal(1.0))
+		gomega.Expect(getValue(metricEgressIPBFDSessionFailures)).To(gomega.Equal(failures))
+
+		egressIPBFDMetricHandler(nbdb.BFDTable, bfd(&nbdb.BFDStatusUp), bfd(&nbdb.BFDStatusDown))
+		gomega.Expect(status()).To(gomega.Equal(0.0))
+		gomega.Expect(getValue(metricEgressIPBFDSessionFailures)).To(gomega.Equal(failures + 1))
+
+		// a session that never came up did not fail
+		egressIPBFDMetricHandler(nbdb.BFDTable, bfd(&nbdb.BFDStatusDown), bfd(&nbdb.BFDStatusInit))
+		gomega.Expect(getValue(metricEgressIPBFDSessionFailures)).To(gomega.Equal(failures + 1))
+
+		egressIPBFDMetricHandler(nbdb.BFDTable, bfd(&nbdb.BFDStatusInit), nil)
+		gomega.Expect(countSessions()).To(gomega.Equal(0))
+	})
+
+	ginkgo.It("ignores the BFD sessions not between egress nodes", func() {
+		failures := getValue(metricEgressIPBFDSessionFailures)
+		other := &nbdb.BFD{LogicalPort: "rtoe-GR_node1", DstIP: "172.18.0.10", Status: &nbdb.BFDStatusDown}
+		egressIPBFDMetricHandler(nbdb.BFDTable, &nbdb.BFD{LogicalPort: "rtoe-GR_node1", DstIP: "172.18.0.10",
+			Status: &nbdb.BFDStatusUp}, other)
+		gomega.Expect(getValue(metricEgressIPBFDSessionFailures)).To(gomega.Equal(failures))
+		gomega.Expect(countSessions()).To(gomega.Equal(0))
+	})
+})
@@ -4,12 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	kapi "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
+	ktypes "k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/cache"
@@ -44,6 +48,11 @@ const (
 
 var ErrNetworkControllerTopologyNotManaged = errors.New("no cluster network controller to manage topology")
 
+// errSharedNetworkUpdatePending is returned when a NAD of a network shared by
+// several NADs is updated in place while the other NADs still have the
+// previous configuration: the update is applied once all of them are updated.
+var errSharedNetworkUpdatePending = errors.New("the network is shared with net-attach-defs that have a different configuration")
+
 type BaseNetworkController interface {
 	Start(ctx context.Context) error
 	Stop()
@@ -53,6 +62,10 @@ type BaseNetworkController interface {
 type NetworkController interface {
 	BaseNetworkController
 	CompareNetInfo(util.BasicNetInfo) bool
+	// UpdateNetInfo applies in place the changes of the given network
+	// information, previously validated as compatible with
+	// util.ValidateNetInfoUpdate, reconciling the network as needed.
+	UpdateNetInfo(util.BasicNetInfo) error
 	AddNAD(nadName string)
 	DeleteNAD(nadName string)
 	HasNAD(nadName string) bool
@@ -67,6 +80,13 @@ type NetworkControllerManager interface {
 	CleanupDeletedNetworks(allControllers []NetworkController) error
 }
 
+// nadNetInfo is the network information parsed from a NAD along with the
+// UID of the NAD it was parsed from
+type nadNetInfo struct {
+	util.NetInfo
+	uid ktypes.UID
+}
+
 type networkNADInfo struct {
 	nadNames  map[string]struct{}
 	nc        NetworkController
@@ -85,8 +105,8 @@ type NetAttachDefinitionController struct {
 	stopChan           chan struct{}
 	wg                 sync.WaitGroup
 
-	// key is nadName, value is BasicNetInfo
-	perNADNetInfo *syncmap.SyncMap[util.BasicNetInfo]
+	// key is nadName, value is nadNetInfo
+	perNADNetInfo *syncmap.SyncMap[*nadNetInfo]
 	// controller for all networks, key is netName of net-attach-def, value is networkNADInfo
 	// this map is updated either at the very beginning of ovnkube controller when initializing the
 	// default controller or when net-attach-def is added/deleted. All these are serialized by syncmap lock
@@ -114,7 +134,7 @@ func NewNetAttachDefinitionController(name string, ncm NetworkControllerManager,
 		queue:              workqueue.NewNamedRateLimitingQueue(rateLimiter, "net-attach-def"),
 		loopPeriod:         time.Second,
 		stopChan:           make(chan struct{}),
-		perNADNetInfo:      syncmap.NewSyncMap[util.BasicNetInfo](),
+		perNADNetInfo:      syncmap.NewSyncMap[*nadNetInfo](),
 		perNetworkNADInfo:  syncmap.NewSyncMap[*networkNADInfo](),
 	}
 	_, err := netAttachDefInformer.Informer().AddEventHandler(
@@ -300,20 +320,44 @@ func (nadController *NetAttachDefinitionController) onNetworkAttachDefinitionUpd
 		return
 	}
 
-	// don't process resync or objects that are marked for deletion
+	// don't process resync, objects that are marked for deletion or updates
+	// that don't change the spec
 	if oldNAD.ResourceVersion == newNAD.ResourceVersion ||
-		!newNAD.GetDeletionTimestamp().IsZero() {
+		!newNAD.GetDeletionTimestamp().IsZero() ||
+		reflect.DeepEqual(oldNAD.Spec, newNAD.Spec) {
 		return
 	}
 
-	err := fmt.Sprintf("%s: Updating net-attach-def %s/%s is not supported", nadController.name, newNAD.Namespace, newNAD.Name)
+	klog.V(4).Infof("%s: Updating net-attach-def %s/%s", nadController.name, newNAD.Namespace, newNAD.Name)
+	nadController.queueNetworkAttachDefinition(newObj)
+}
+
+// recordNADUpdateRejected posts a warning event on the given NAD explaining why
+// its update could not be applied
+func (nadController *NetAttachDefinitionController) recordNADUpdateRejected(nad *nettypes.NetworkAttachmentDefinition, reason error) {
+	msg := fmt.Sprintf("%s: Updating net-attach-def %s/%s is not supported: %v", nadController.name, nad.Namespace, nad.Name, reason)
+	nadRef := kapi.ObjectReference{
+		Kind:      "NetworkAttachmentDefinition",
+		Namespace: nad.Namespace,
+		Name:      nad.Name,
+		UID:       nad.UID,
+	}
+	nadController.recorder.Eventf(&nadRef, kapi.EventTypeWarning, "ErrorUpdatingResource", msg)
+	klog.Warning(msg)
+}
+
+// recordNADUpdatePending posts an event on the given NAD explaining that its
+// update is waiting for the other NADs of its network to be updated
+func (nadController *NetAttachDefinitionController) recordNADUpdatePending(nad *nettypes.NetworkAttachmentDefinition, reason error) {
+	msg := fmt.Sprintf("%s: Update of net-attach-def %s/%s is pending: %v", nadController.name, nad.Namespace, nad.Name, reason)
 	nadRef := kapi.ObjectReference{
 		Kind:      "NetworkAttachmentDefinition",
-		Namespace: newNAD.Namespace,
-		Name:      newNAD.Name,
+		Namespace: nad.Namespace,
+		Name:      nad.Name,
+		UID:       nad.UID,
 	}
-	nadController.recorder.Eventf(&nadRef, kapi.EventTypeWarning, "ErrorUpdatingResource", err)
-	klog.Warningf(err)
+	nadController.recorder.Eventf(&nadRef, kapi.EventTypeNormal, "UpdatePending", msg)
+	klog.Info(msg)
 }
 
 func (nadController *NetAttachDefinitionController) onNetworkAttachDefinitionDelete(obj interface{}) {
@@ -353,6 +397,9 @@ func (nadController *NetAttachDefinitionController) getAllNetworkControllers() [
 
 // AddNetAttachDef adds the given nad to the associated controller. It creates the controller if this
 // is the first NAD of the network.
+// If the NAD was already added and has been updated in place, compatible changes are applied to the
+// running network controller while incompatible ones are rejected with an event; a NAD that was
+// deleted and re-created with a different configuration is instead re-added to its network.
 // Non-retriable errors (configuration error etc.) are just logged, and the function immediately returns nil.
 func (nadController *NetAttachDefinitionController) AddNetAttachDef(ncm NetworkControllerManager,
 	netattachdef *nettypes.NetworkAttachmentDefinition, doStart bool) error {
@@ -372,7 +419,7 @@ func (nadController *NetAttachDefinitionController) AddNetAttachDef(ncm NetworkC
 	}
 
 	return nadController.perNADNetInfo.DoWithLock(netAttachDefName, func(nadName string) error {
-		nadNci, loaded := nadController.perNADNetInfo.LoadOrStore(nadName, nInfo)
+		nadNci, loaded := nadController.perNADNetInfo.LoadOrStore(nadName, &nadNetInfo{NetInfo: nInfo, uid: netattachdef.UID})
 		if !loaded {
 			// first time to process this nad
 			if invalidNADErr != nil {
@@ -411,6 +458,40 @@ func (nadController *NetAttachDefinitionController) AddNetAttachDef(ncm NetworkC
 				}
 				return nil
 			}
+			if nadNci.uid == netattachdef.UID {
+				// the NAD was updated in place: keep its network running
+				// applying the changes if they are compatible
+				if invalidNADErr == nil {
+					invalidNADErr = util.ValidateNetInfoUpdate(nadNci, nInfo)
+				}
+				if invalidNADErr != nil {
+					nadController.recordNADUpdateRejected(netattachdef, invalidNADErr)
+					return nil
+				}
+				klog.V(5).Infof("%s: Update net-attach-def %s of network %s in place", nadController.name, nadName, netName)
+				otherNADs, err := nadController.updateNADInController(netName, nadName, nInfo)
+				if errors.Is(err, errSharedNetworkUpdatePending) {
+					// the last NAD of the network to be updated applies the
+					// update and requeues this one
+					nadController.recordNADUpdatePending(netattachdef, err)
+					return nil
+				}
+				if err != nil {
+					klog.Errorf("%s: Failed to update net-attach-def %s of network %s: %v", nadController.name, nadName, netName, err)
+					return err
+				}
+				nadController.perNADNetInfo.Store(nadName, &nadNetInfo{NetInfo: nInfo, uid: netattachdef.UID})
+				// the other NADs of the network were updated before this one,
+				// resync them so that their cached network information is
+				// refreshed as well
+				for _, otherNAD := range otherNADs {
+					nadController.queue.Add(otherNAD)
+				}
+				if !doStart {
+					return nil
+				}
+				return nadController.addNADToController(ncm, nadName, nInfo, doStart)
+			}
 			if nadUpdated {
 				klog.V(5).Infof("%s: net-attach-def %s network %s updated", nadController.name, nadName, netName)
 				// delete the NAD from the old network first
@@ -427,7 +508,7 @@ func (nadController *NetAttachDefinitionController) AddNetAttachDef(ncm NetworkC
 				return nil
 			}
 			klog.V(5).Infof("%s: Add updated net-attach-def %s to network %s", nadController.name, nadName, netName)
-			nadController.perNADNetInfo.LoadOrStore(nadName, nInfo)
+			nadController.perNADNetInfo.LoadOrStore(nadName, &nadNetInfo{NetInfo: nInfo, uid: netattachdef.UID})
 			err = nadController.addNADToController(ncm, nadName, nInfo, doStart)
 			if err != nil {
 				klog.Errorf("%s: Failed to add net-attach-def %s to network %s: %v", nadController.name, nadName, netName, err)
@@ -482,7 +563,9 @@ func (nadController *NetAttachDefinitionController) addNADToController(ncm Netwo
 			}()
 			// first NAD for this network, create controller
 			klog.V(5).Infof("%s: First net-attach-def %s of network %s added, create network controller", nadController.name, nadName, networkName)
-			oc, err = ncm.NewNetworkController(nInfo)
+			// the network controller gets its own copy of the network
+			// information as it can be updated in place
+			oc, err = ncm.NewNetworkController(util.CopyNetInfo(nInfo))
 			if err != nil {
 				return err
 			}
@@ -530,6 +613,72 @@ func (nadController *NetAttachDefinitionController) addNADToController(ncm Netwo
 	})
 }
 
+// updateNADInController applies the compatible changes of the given updated NAD network
+// information to the controller of its network. All the NADs of a shared network must
+// have the same configuration, so the changes are only applied once every NAD of the
+// network has been updated, errSharedNetworkUpdatePending is returned until then.
+// It returns the other NADs of the network when the changes were applied.
+func (nadController *NetAttachDefinitionController) updateNADInController(netName, nadName string, nInfo util.NetInfo) ([]string, error) {
+	var otherNADs []string
+	err := nadController.perNetworkNADInfo.DoWithLock(netName, func(networkName string) error {
+		nni, found := nadController.perNetworkNADInfo.Load(networkName)
+		if !found {
+			return fmt.Errorf("%s: network controller for network %s not found", nadController.name, networkName)
+		}
+		if _, nadExists := nni.nadNames[nadName]; !nadExists {
+			return fmt.Errorf("%s: net-attach-def %s does not exist on network %s", nadController.name, nadName, networkName)
+		}
+		var pendingNADs []string
+		for otherNAD := range nni.nadNames {
+			if otherNAD == nadName {
+				continue
+			}
+			if !nadController.nadHasNetInfo(otherNAD, nInfo) {
+				pendingNADs = append(pendingNADs, otherNAD)
+			}
+			otherNADs = append(otherNADs, otherNAD)
+		}
+		if len(pendingNADs) > 0 {
+			sort.Strings(pendingNADs)
+			return fmt.Errorf("%w: %s", errSharedNetworkUpdatePending, strings.Join(pendingNADs, ", "))
+		}
+		if nni.nc.CompareNetInfo(nInfo) {
+			// already applied when another NAD of the network was updated
+			otherNADs = nil
+			return nil
+		}
+		if err := nni.nc.UpdateNetInfo(nInfo); err != nil {
+			return fmt.Errorf("%s: failed to update network controller for network %s: %w", nadController.name, networkName, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return otherNADs, nil
+}
+
+// nadHasNetInfo returns true if the current spec of the given NAD defines the given
+// network information. A NAD that no longer exists is ignored as it is being deleted.
+func (nadController *NetAttachDefinitionController) nadHasNetInfo(nadName string, nInfo util.NetInfo) bool {
+	namespace, name, err := cache.SplitMetaNamespaceKey(nadName)
+	if err != nil {
+		return false
+	}
+	nad, err := nadController.netAttachDefLister.NetworkAttachmentDefinitions(namespace).Get(name)
+	if apierrors.IsNotFound(err) {
+		return true
+	}
+	if err != nil {
+		return false
+	}
+	nadInfo, err := util.ParseNADInfo(nad)
+	if err != nil {
+		return false
+	}
+	return nadInfo.CompareNetInfo(nInfo)
+}
+
 func (nadController *NetAttachDefinitionController) deleteNADFromController(netName, nadName string) error {
 	klog.V(5).Infof("%s: Delete net-attach-def %s from network %s", nadController.name, nadName, netName)
 	return nadController.perNetworkNADInfo.DoWithLock(netName, func(networkName string) error {
@@ -0,0 +1,216 @@
+package networkAttachDefController
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/workqueue"
+
+	nettypes "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	nadlisters "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/listers/k8s.cni.cncf.io/v1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/syncmap"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+type fakeNetworkController struct {
+	util.NetInfo
+	updates int
+	started bool
+	cleaned bool
+}
+
+func (nc *fakeNetworkController) Start(_ context.Context) error {
+	nc.started = true
+	return nil
+}
+
+func (nc *fakeNetworkController) Stop() {
+	nc.started = false
+}
+
+func (nc *fakeNetworkController) UpdateNetInfo(netInfo util.BasicNetInfo) error {
+	nc.updates++
+	nc.NetInfo = netInfo.(util.NetInfo)
+	return nil
+}
+
+func (nc *fakeNetworkController) Cleanup(_ string) error {
+	nc.cleaned = true
+	return nil
+}
+
+type fakeNetworkControllerManager struct {
+	controllers []*fakeNetworkController
+}
+
+func (ncm *fakeNetworkControllerManager) NewNetworkController(netInfo util.NetInfo) (NetworkController, error) {
+	nc := &fakeNetworkController{NetInfo: netInfo}
+	ncm.controllers = append(ncm.controllers, nc)
+	return nc, nil
+}
+
+func (ncm *fakeNetworkControllerManager) CleanupDeletedNetworks(_ []NetworkController) error {
+	return nil
+}
+
+func newTestNAD(name, uid, subnets string) *nettypes.NetworkAttachmentDefinition {
+	return &nettypes.NetworkAttachmentDefinition{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "ns1",
+			UID:       ktypes.UID(uid),
+		},
+		Spec: nettypes.NetworkAttachmentDefinitionSpec{
+			Config: fmt.Sprintf(`{"cniVersion": "0.3.1", "name": "blue", "type": "ovn-k8s-cni-overlay", `+
+				`"topology": "layer2", "subnets": %q, "netAttachDefName": "ns1/%s"}`, subnets, name),
+		},
+	}
+}
+
+func newTestNADController(ncm NetworkControllerManager) (*NetAttachDefinitionController, cache.Indexer, *record.FakeRecorder) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	recorder := record.NewFakeRecorder(10)
+	return &NetAttachDefinitionController{
+		name:               "test",
+		recorder:           recorder,
+		ncm:                ncm,
+		netAttachDefLister: nadlisters.NewNetworkAttachmentDefinitionLister(indexer),
+		queue:              workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "test"),
+		perNADNetInfo:      syncmap.NewSyncMap[*nadNetInfo](),
+		perNetworkNADInfo:  syncmap.NewSyncMap[*networkNADInfo](),
+	}, indexer, recorder
+}
+
+// addNADs stores the NADs in the lister and adds them to the controller
+func addNADs(g *gomega.WithT, nadController *NetAttachDefinitionController, indexer cache.Indexer, nads ...*nettypes.NetworkAttachmentDefinition) {
+	for _, nad := range nads {
+		g.Expect(indexer.Add(nad)).To(gomega.Succeed())
+		g.Expect(nadController.AddNetAttachDef(nadController.ncm, nad, true)).To(gomega.Succeed())
+	}
+}
+
+func subnetsOf(netInfo util.NetInfo) string {
+	var subnets []string
+	for _, subnet := range netInfo.Subnets() {
+		subnets = append(subnets, subnet.CIDR.String())
+	}
+	return strings.Join(subnets, ",")
+}
+
+func TestAddNetAttachDefUpdate(t *testing.T) {
+	tests := []struct {
+		desc string
+		// the NADs, all of the same network, added before the updates
+		initial []*nettypes.NetworkAttachmentDefinition
+		// the NADs updated, in order
+		updates []*nettypes.NetworkAttachmentDefinition
+		// the expected subnets of the network controller that was running
+		// before the updates
+		expectedSubnets string
+		// the expected number of in-place updates of that controller
+		expectedUpdates int
+		// the expected number of network controllers created
+		expectedControllers int
+		// the expected events, in order
+		expectedEvents []string
+		// the expected NADs queued to refresh their cached network information
+		expectedQueued int
+	}{
+		{
+			desc:                "compatible update is applied in place",
+			initial:             []*nettypes.NetworkAttachmentDefinition{newTestNAD("nad1", "uid1", "192.168.1.0/24")},
+			updates:             []*nettypes.NetworkAttachmentDefinition{newTestNAD("nad1", "uid1", "192.168.1.0/24,192.168.2.0/24")},
+			expectedSubnets:     "192.168.1.0/24,192.168.2.0/24",
+			expectedUpdates:     1,
+			expectedControllers: 1,
+		},
+		{
+			desc:                "incompatible update is rejected with an event",
+			initial:             []*nettypes.NetworkAttachmentDefinition{newTestNAD("nad1", "uid1", "192.168.1.0/24")},
+			updates:             []*nettypes.NetworkAttachmentDefinition{newTestNAD("nad1", "uid1", "192.168.2.0/24")},
+			expectedSubnets:     "192.168.1.0/24",
+			expectedControllers: 1,
+			expectedEvents:      []string{"Warning ErrorUpdatingResource"},
+		},
+		{
+			desc:            "re-created NAD with a new UID replaces the network controller",
+			initial:         []*nettypes.NetworkAttachmentDefinition{newTestNAD("nad1", "uid1", "192.168.1.0/24")},
+			updates:         []*nettypes.NetworkAttachmentDefinition{newTestNAD("nad1", "uid2", "192.168.2.0/24")},
+			expectedSubnets: "192.168.1.0/24",
+			// the controller of the previous NAD is cleaned up and a new one is created
+			expectedControllers: 2,
+		},
+		{
+			desc: "update of a shared network waits for all its NADs",
+			initial: []*nettypes.NetworkAttachmentDefinition{
+				newTestNAD("nad1", "uid1", "192.168.1.0/24"),
+				newTestNAD("nad2", "uid2", "192.168.1.0/24"),
+			},
+			updates: []*nettypes.NetworkAttachmentDefinition{
+				newTestNAD("nad1", "uid1", "192.168.1.0/24,192.168.2.0/24"),
+				newTestNAD("nad2", "uid2", "192.168.1.0/24,192.168.2.0/24"),
+			},
+			expectedSubnets:     "192.168.1.0/24,192.168.2.0/24",
+			expectedUpdates:     1,
+			expectedControllers: 1,
+			expectedEvents:      []string{"Normal UpdatePending"},
+			expectedQueued:      1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			ncm := &fakeNetworkControllerManager{}
+			nadController, indexer, recorder := newTestNADController(ncm)
+
+			addNADs(g, nadController, indexer, tt.initial...)
+			g.Expect(ncm.controllers).To(gomega.HaveLen(1))
+			nc := ncm.controllers[0]
+			g.Expect(nc.started).To(gomega.BeTrue())
+
+			for _, nad := range tt.updates {
+				g.Expect(indexer.Update(nad)).To(gomega.Succeed())
+				g.Expect(nadController.AddNetAttachDef(ncm, nad, true)).To(gomega.Succeed())
+			}
+
+			g.Expect(ncm.controllers).To(gomega.HaveLen(tt.expectedControllers))
+			g.Expect(subnetsOf(nc.NetInfo)).To(gomega.Equal(tt.expectedSubnets))
+			g.Expect(nc.updates).To(gomega.Equal(tt.expectedUpdates))
+			g.Expect(recorder.Events).To(gomega.HaveLen(len(tt.expectedEvents)))
+			for _, expectedEvent := range tt.expectedEvents {
+				g.Expect(<-recorder.Events).To(gomega.HavePrefix(expectedEvent))
+			}
+			g.Expect(nadController.queue.Len()).To(gomega.Equal(tt.expectedQueued))
+			if tt.expectedControllers > 1 {
+				g.Expect(nc.cleaned).To(gomega.BeTrue())
+				g.Expect(ncm.controllers[1].started).To(gomega.BeTrue())
+			}
+
+			// processing the requeued NADs and resyncing all of them
+			// must succeed without updating the network again
+			for nadController.queue.Len() > 0 {
+				key, _ := nadController.queue.Get()
+				g.Expect(nadController.sync(key.(string))).To(gomega.Succeed())
+				nadController.queue.Done(key)
+			}
+			for _, nad := range indexer.List() {
+				g.Expect(nadController.AddNetAttachDef(ncm, nad.(*nettypes.NetworkAttachmentDefinition), true)).To(gomega.Succeed())
+			}
+			g.Expect(nc.updates).To(gomega.Equal(tt.expectedUpdates))
+			for _, nad := range indexer.List() {
+				nadName := util.GetNADName("ns1", nad.(*nettypes.NetworkAttachmentDefinition).Name)
+				cached, found := nadController.perNADNetInfo.Load(nadName)
+				g.Expect(found).To(gomega.BeTrue())
+				g.Expect(subnetsOf(cached.NetInfo)).To(gomega.Equal(subnetsOf(ncm.controllers[len(ncm.controllers)-1].NetInfo)))
+			}
+		})
+	}
+}
@@ -237,6 +237,12 @@ func (cm *NetworkControllerManager) configureMetrics(stopChan <-chan struct{}) {
 	metrics.RegisterOVNKubeControllerFunctional()
 	metrics.RunTimestamp(stopChan, cm.sbClient, cm.nbClient)
 	metrics.MonitorIPSec(cm.nbClient)
+	if config.OVNKubernetesFeature.EnableServiceHealthChecks {
+		metrics.MonitorLBHealthChecks(cm.sbClient)
+	}
+	if config.OVNKubernetesFeature.EnableEgressIP && config.OVNKubernetesFeature.EnableEgressIPBFD {
+		metrics.MonitorEgressIPBFD(cm.nbClient)
+	}
 }
 
 func (cm *NetworkControllerManager) createACLLoggingMeter() error {
@@ -42,7 +42,11 @@ func (ncm *nodeNetworkControllerManager) NewNetworkController(nInfo util.NetInfo
 	topoType := nInfo.TopologyType()
 	switch topoType {
 	case ovntypes.Layer3Topology, ovntypes.Layer2Topology, ovntypes.LocalnetTopology:
-		return node.NewSecondaryNodeNetworkController(ncm.newCommonNetworkControllerInfo(), nInfo), nil
+		var gateway node.Gateway
+		if defaultNodeNetworkController, ok := ncm.defaultNodeNetworkController.(*node.DefaultNodeNetworkController); ok {
+			gateway = defaultNodeNetworkController.Gateway()
+		}
+		return node.NewSecondaryNodeNetworkController(ncm.newCommonNetworkControllerInfo(), nInfo, gateway), nil
 	}
 	return nil, fmt.Errorf("topology type %s not supported", topoType)
 }
@@ -69,9 +73,10 @@ func NewNodeNetworkControllerManager(ovnClient *util.OVNClientset, wf factory.No
 		recorder:      eventRecorder,
 	}
 
-	// need to configure OVS interfaces for Pods on secondary networks in the DPU mode
+	// need to configure OVS interfaces for Pods on secondary networks in the DPU
+	// mode and the external bridge for secondary networks with gateway routers
 	var err error
-	if config.OVNKubernetesFeature.EnableMultiNetwork && config.OvnKubeNode.Mode == ovntypes.NodeModeDPU {
+	if config.OVNKubernetesFeature.EnableMultiNetwork && config.OvnKubeNode.Mode != ovntypes.NodeModeDPUHost {
 		ncm.nadController, err = nad.NewNetAttachDefinitionController("node-network-controller-manager", ncm, ovnClient.NetworkAttchDefClient, eventRecorder)
 	}
 	if err != nil {
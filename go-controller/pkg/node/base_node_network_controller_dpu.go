@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/vishvananda/netlink"
 	kapi "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -53,6 +54,11 @@ func (bnnc *BaseNodeNetworkController) addDPUPodForNAD(pod *kapi.Pod, dpuCD *uti
 	}
 	err = bnnc.addRepPort(pod, dpuCD, podInterfaceInfo, getter)
 	if err != nil {
+		// surface the failure to the DPU host through the connection status annotation
+		connStatus := util.DPUConnectionStatus{Status: util.DPUConnectionStatusError, Reason: err.Error()}
+		if statusErr := bnnc.updatePodDPUConnStatusWithRetry(pod, &connStatus, nadName); statusErr != nil {
+			klog.Errorf("Failed to set DPU connection status annotation for %s: %v", podDesc, statusErr)
+		}
 		return fmt.Errorf("failed to add rep port for %s, %v. retrying", podDesc, err)
 	}
 	return nil
@@ -63,7 +69,7 @@ func (bnnc *BaseNodeNetworkController) delDPUPodForNAD(pod *kapi.Pod, dpuCD *uti
 	podDesc := fmt.Sprintf("pod %s/%s for NAD %s", pod.Namespace, pod.Name, nadName)
 	klog.Infof("Deleting %s from DPU", podDesc)
 
-	if podDeleted {
+	if !podDeleted {
 		// no need to unset connection status annotation if pod is deleted anyway
 		err := bnnc.updatePodDPUConnStatusWithRetry(pod, nil, nadName)
 		if err != nil {
@@ -269,7 +275,6 @@ func (bnnc *BaseNodeNetworkController) addRepPort(pod *kapi.Pod, dpuCD *util.DPU
 	}
 
 	// Update connection-status annotation
-	// TODO(adrianc): we should update Status in case of error as well
 	connStatus := util.DPUConnectionStatus{Status: util.DPUConnectionStatusReady, Reason: ""}
 	err = bnnc.updatePodDPUConnStatusWithRetry(pod, &connStatus, nadName)
 	if err != nil {
@@ -282,7 +287,6 @@ func (bnnc *BaseNodeNetworkController) addRepPort(pod *kapi.Pod, dpuCD *util.DPU
 
 // delRepPort delete the representor of the VF from the ovs bridge
 func (bnnc *BaseNodeNetworkController) delRepPort(pod *kapi.Pod, dpuCD *util.DPUConnectionDetails, vfRepName, nadName string) error {
-	//TODO(adrianc): handle: clearPodBandwidth(pr.SandboxID), pr.deletePodConntrack()
 	podDesc := fmt.Sprintf("pod %s/%s for NAD %s", pod.Namespace, pod.Name, nadName)
 	klog.Infof("Delete VF representor %s for %s", vfRepName, podDesc)
 	ifExists, sandbox, expectedNADName, err := util.GetOVSPortPodInfo(vfRepName)
@@ -310,7 +314,7 @@ func (bnnc *BaseNodeNetworkController) delRepPort(pod *kapi.Pod, dpuCD *util.DPU
 	}
 
 	// remove from br-int
-	return wait.PollUntilContextTimeout(context.Background(), 500*time.Millisecond, 60*time.Second, true, func(ctx context.Context) (bool, error) {
+	err = wait.PollUntilContextTimeout(context.Background(), 500*time.Millisecond, 60*time.Second, true, func(ctx context.Context) (bool, error) {
 		_, _, err := util.RunOVSVsctl("--if-exists", "del-port", "br-int", vfRepName)
 		if err != nil {
 			return false, nil
@@ -318,4 +322,34 @@ func (bnnc *BaseNodeNetworkController) delRepPort(pod *kapi.Pod, dpuCD *util.DPU
 		klog.Infof("Port %s deleted from bridge br-int", vfRepName)
 		return true, nil
 	})
+	if err != nil {
+		return fmt.Errorf("failed to delete port %s from bridge br-int: %v", vfRepName, err)
+	}
+
+	var errs []error
+	if err = cni.ClearPodBandwidth(dpuCD.SandboxId); err != nil {
+		errs = append(errs, fmt.Errorf("failed to clear bandwidth of sandbox %s for %s: %v", dpuCD.SandboxId, podDesc, err))
+	}
+	if err = deletePodConntrack(pod, nadName); err != nil {
+		errs = append(errs, fmt.Errorf("failed to delete conntrack entries for %s: %v", podDesc, err))
+	}
+	return apierrors.NewAggregate(errs)
+}
+
+// deletePodConntrack deletes the conntrack entries of the IPs the pod has on the given NAD
+func deletePodConntrack(pod *kapi.Pod, nadName string) error {
+	podAnnotation, err := util.UnmarshalPodAnnotation(pod.Annotations, nadName)
+	if err != nil {
+		if util.IsAnnotationNotSetError(err) {
+			return nil
+		}
+		return err
+	}
+	var errs []error
+	for _, ip := range podAnnotation.IPs {
+		if err = util.DeleteConntrack(ip.IP.String(), 0, "", netlink.ConntrackReplyAnyIP, nil); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete conntrack entries for %s: %v", ip.IP, err))
+		}
+	}
+	return apierrors.NewAggregate(errs)
 }
@@ -2,10 +2,12 @@ package node
 
 import (
 	"fmt"
+	"net"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
+	"github.com/vishvananda/netlink"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
@@ -75,6 +77,15 @@ func checkOVSPortPodInfo(execMock *ovntest.FakeExec, vfRep string, exists bool,
 	})
 }
 
+func checkClearPodBandwidth(execMock *ovntest.FakeExec, sandbox string) {
+	execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+		Cmd: genOVSFindCmd("30", "interface", "name", "external-ids:sandbox="+sandbox),
+	})
+	execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+		Cmd: genOVSFindCmd("30", "qos", "_uuid", "external-ids:sandbox="+sandbox),
+	})
+}
+
 func newFakeKubeClientWithPod(pod *v1.Pod) *fake.Clientset {
 	return fake.NewSimpleClientset(&v1.PodList{Items: []v1.Pod{*pod}})
 }
@@ -221,6 +232,7 @@ var _ = Describe("Node DPU tests", func() {
 			execMock.AddFakeCmd(&ovntest.ExpectedCmd{
 				Cmd: genOVSDelPortCmd(vfRep),
 			})
+			checkClearPodBandwidth(execMock, "a8d09931")
 			podNamespaceLister.On("Get", mock.AnythingOfType("string")).Return(&pod, nil)
 
 			// call addRepPort()
@@ -243,14 +255,7 @@ var _ = Describe("Node DPU tests", func() {
 					Cmd: genOVSAddPortCmd(vfRep, genIfaceID(pod.Namespace, pod.Name), "", "", "a8d09931", string(pod.UID)),
 				})
 				// clearPodBandwidth
-				execMock.AddFakeCmd(&ovntest.ExpectedCmd{
-					Cmd: genOVSFindCmd("30", "interface", "name",
-						"external-ids:sandbox=a8d09931"),
-				})
-				execMock.AddFakeCmd(&ovntest.ExpectedCmd{
-					Cmd: genOVSFindCmd("30", "qos", "_uuid",
-						"external-ids:sandbox=a8d09931"),
-				})
+				checkClearPodBandwidth(execMock, "a8d09931")
 				// getIfaceOFPort
 				execMock.AddFakeCmd(&ovntest.ExpectedCmd{
 					Cmd:    genOVSGetCmd("Interface", "pf0vf9", "ofport", ""),
@@ -279,6 +284,7 @@ var _ = Describe("Node DPU tests", func() {
 					execMock.AddFakeCmd(&ovntest.ExpectedCmd{
 						Cmd: genOVSDelPortCmd("pf0vf9"),
 					})
+					checkClearPodBandwidth(execMock, "a8d09931")
 
 					podNamespaceLister.On("Get", mock.AnythingOfType("string")).Return(&pod, nil)
 
@@ -296,6 +302,7 @@ var _ = Describe("Node DPU tests", func() {
 					execMock.AddFakeCmd(&ovntest.ExpectedCmd{
 						Cmd: genOVSDelPortCmd("pf0vf9"),
 					})
+					checkClearPodBandwidth(execMock, "a8d09931")
 
 					podNamespaceLister.On("Get", mock.AnythingOfType("string")).Return(&pod, nil)
 
@@ -314,6 +321,7 @@ var _ = Describe("Node DPU tests", func() {
 					execMock.AddFakeCmd(&ovntest.ExpectedCmd{
 						Cmd: genOVSDelPortCmd("pf0vf9"),
 					})
+					checkClearPodBandwidth(execMock, "a8d09931")
 
 					podNamespaceLister.On("Get", mock.AnythingOfType("string")).Return(&pod, nil)
 
@@ -363,6 +371,7 @@ var _ = Describe("Node DPU tests", func() {
 				execMock.AddFakeCmd(&ovntest.ExpectedCmd{
 					Cmd: genOVSDelPortCmd("pf0vf9"),
 				})
+				checkClearPodBandwidth(execMock, "a8d09931")
 
 				factoryMock.On("PodCoreInformer").Return(&podInformer)
 				podInformer.On("Lister").Return(&podLister)
@@ -399,6 +408,7 @@ var _ = Describe("Node DPU tests", func() {
 			execMock.AddFakeCmd(&ovntest.ExpectedCmd{
 				Cmd: fmt.Sprintf("ovs-vsctl --timeout=15 --if-exists del-port br-int %s", "pf0vf9"),
 			})
+			checkClearPodBandwidth(execMock, scd.SandboxId)
 			err := dnnc.delRepPort(&pod, &scd, vfRep, types.DefaultNetworkName)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc())
@@ -410,6 +420,7 @@ var _ = Describe("Node DPU tests", func() {
 			execMock.AddFakeCmd(&ovntest.ExpectedCmd{
 				Cmd: genOVSDelPortCmd("pf0vf9"),
 			})
+			checkClearPodBandwidth(execMock, scd.SandboxId)
 			err := dnnc.delRepPort(&pod, &scd, vfRep, types.DefaultNetworkName)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc())
@@ -429,10 +440,70 @@ var _ = Describe("Node DPU tests", func() {
 				Cmd: genOVSDelPortCmd("pf0vf9"),
 				Err: nil,
 			})
+			checkClearPodBandwidth(execMock, scd.SandboxId)
 			// pass on the second
 			err := dnnc.delRepPort(&pod, &scd, vfRep, types.DefaultNetworkName)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc())
 		})
+
+		It("Deletes conntrack entries of the pod IPs", func() {
+			var err error
+			pod.Annotations, err = util.MarshalPodAnnotation(pod.Annotations, &util.PodAnnotation{
+				IPs: []*net.IPNet{ovntest.MustParseIPNet("10.244.0.5/24")},
+				MAC: ovntest.MustParseMAC("0a:58:0a:f4:00:05"),
+			}, types.DefaultNetworkName)
+			Expect(err).ToNot(HaveOccurred())
+			checkOVSPortPodInfo(execMock, vfRep, true, "15", scd.SandboxId, types.DefaultNetworkName)
+			netlinkOpsMock.On("LinkByName", vfRep).Return(vfLink, nil)
+			netlinkOpsMock.On("LinkSetDown", vfLink).Return(nil)
+			execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd: genOVSDelPortCmd("pf0vf9"),
+			})
+			checkClearPodBandwidth(execMock, scd.SandboxId)
+			netlinkOpsMock.On("ConntrackDeleteFilter", netlink.ConntrackTableType(netlink.ConntrackTable),
+				netlink.InetFamily(netlink.FAMILY_V4), mock.Anything).Return(uint(1), nil)
+
+			err = dnnc.delRepPort(&pod, &scd, vfRep, types.DefaultNetworkName)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc())
+			netlinkOpsMock.AssertNumberOfCalls(GinkgoT(), "ConntrackDeleteFilter", 1)
+		})
+	})
+
+	Context("addDPUPodForNAD", func() {
+		It("Sets an error dpu.connection-status pod annotation on failure", func() {
+			var err error
+			scd := util.DPUConnectionDetails{
+				PfId:      "0",
+				VfId:      "9",
+				SandboxId: "a8d09931",
+			}
+			pod.Annotations, err = util.MarshalPodDPUConnDetails(nil, &scd, types.DefaultNetworkName)
+			Expect(err).ToNot(HaveOccurred())
+			pod.Annotations, err = util.MarshalPodAnnotation(pod.Annotations, &util.PodAnnotation{
+				IPs: []*net.IPNet{ovntest.MustParseIPNet("10.244.0.5/24")},
+				MAC: ovntest.MustParseMAC("0a:58:0a:f4:00:05"),
+			}, types.DefaultNetworkName)
+			Expect(err).ToNot(HaveOccurred())
+			clientset = cni.NewClientSet(newFakeKubeClientWithPod(&pod), &podLister)
+			sriovnetOpsMock.On("GetVfRepresentorDPU", "0", "9").Return("", fmt.Errorf("failed to get VF representor"))
+
+			dcs := util.DPUConnectionStatus{
+				Status: util.DPUConnectionStatusError,
+				Reason: "failed to get VF representor",
+			}
+			cpod := pod.DeepCopy()
+			cpod.Annotations, err = util.MarshalPodDPUConnStatus(cpod.Annotations, &dcs, types.DefaultNetworkName)
+			Expect(err).ToNot(HaveOccurred())
+			factoryMock.On("PodCoreInformer").Return(&podInformer)
+			podInformer.On("Lister").Return(&podLister)
+			podNamespaceLister.On("Get", mock.AnythingOfType("string")).Return(&pod, nil)
+			kubeMock.On("UpdatePodStatus", cpod).Return(nil)
+
+			err = dnnc.addDPUPodForNAD(&pod, &scd, types.DefaultNetworkName, types.DefaultNetworkName, clientset)
+			Expect(err).To(HaveOccurred())
+			kubeMock.AssertCalled(GinkgoT(), "UpdatePodStatus", cpod)
+		})
 	})
 })
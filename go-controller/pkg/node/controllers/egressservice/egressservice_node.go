@@ -308,7 +308,11 @@ func (c *Controller) repair() error {
 		errorList = append(errorList, err)
 	}
 
-	err = c.repairIPTables(v4EndpointsToSvcKey, v6EndpointsToSvcKey)
+	if useNFTables() {
+		err = c.repairNFTables(v4EndpointsToSvcKey, v6EndpointsToSvcKey)
+	} else {
+		err = c.repairIPTables(v4EndpointsToSvcKey, v6EndpointsToSvcKey)
+	}
 	if err != nil {
 		errorList = append(errorList, err)
 	}
@@ -731,7 +735,7 @@ func (c *Controller) syncEgressService(key string) error {
 
 	if cachedState.v4LB != "" {
 		for ep := range v4ToAdd {
-			err := nodeipt.AddRules([]nodeipt.Rule{snatIPTRuleFor(key, cachedState.v4LB, ep)}, true)
+			err := c.addSNATRule(key, cachedState.v4LB, ep)
 			if err != nil {
 				return err
 			}
@@ -739,7 +743,7 @@ func (c *Controller) syncEgressService(key string) error {
 		}
 
 		for ep := range v4ToDelete {
-			err := nodeipt.DelRules([]nodeipt.Rule{snatIPTRuleFor(key, cachedState.v4LB, ep)})
+			err := c.deleteSNATRule(key, cachedState.v4LB, ep)
 			if err != nil {
 				return err
 			}
@@ -750,7 +754,7 @@ func (c *Controller) syncEgressService(key string) error {
 
 	if cachedState.v6LB != "" {
 		for ep := range v6ToAdd {
-			err := nodeipt.AddRules([]nodeipt.Rule{snatIPTRuleFor(key, cachedState.v6LB, ep)}, true)
+			err := c.addSNATRule(key, cachedState.v6LB, ep)
 			if err != nil {
 				return err
 			}
@@ -759,7 +763,7 @@ func (c *Controller) syncEgressService(key string) error {
 		}
 
 		for ep := range v6ToDelete {
-			err := nodeipt.DelRules([]nodeipt.Rule{snatIPTRuleFor(key, cachedState.v6LB, ep)})
+			err := c.deleteSNATRule(key, cachedState.v6LB, ep)
 			if err != nil {
 				return err
 			}
@@ -867,7 +871,7 @@ func (c *Controller) allEndpointsFor(svc *corev1.Service, localOnly bool) (sets.
 // Clears all of the SNAT rules of the service.
 func (c *Controller) clearServiceSNATRules(key string, state *svcState) error {
 	for ip := range state.v4Eps {
-		err := nodeipt.DelRules([]nodeipt.Rule{snatIPTRuleFor(key, state.v4LB, ip)})
+		err := c.deleteSNATRule(key, state.v4LB, ip)
 		if err != nil {
 			return err
 		}
@@ -877,7 +881,7 @@ func (c *Controller) clearServiceSNATRules(key string, state *svcState) error {
 	state.v4LB = ""
 
 	for ip := range state.v6Eps {
-		err := nodeipt.DelRules([]nodeipt.Rule{snatIPTRuleFor(key, state.v6LB, ip)})
+		err := c.deleteSNATRule(key, state.v6LB, ip)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,179 @@
+package egressservice
+
+import (
+	"fmt"
+
+	"github.com/coreos/go-iptables/iptables"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	nodeipt "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/iptables"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+)
+
+const (
+	// NFTablesTable is the nftables table owning the egress service rules when the nftables backend is used
+	NFTablesTable = "ovn-kubernetes-egress-services"
+	nftChain      = "egress-services" // nat chain attached to postrouting
+	nftSNATMap    = "snat"            // per IP family map of endpoint : LB, suffixed with the family name
+)
+
+// nftSNATFamily holds how the SNAT map of an IP family is named and matched
+type nftSNATFamily struct {
+	set      string
+	proto    string
+	addrType string
+}
+
+var (
+	nftSNATIPv4 = nftSNATFamily{set: nftSNATMap + "-ipv4", proto: "ip", addrType: "ipv4_addr"}
+	nftSNATIPv6 = nftSNATFamily{set: nftSNATMap + "-ipv6", proto: "ip6", addrType: "ipv6_addr"}
+)
+
+func nftSNATFamilyFor(ip string) nftSNATFamily {
+	if utilnet.IsIPv6String(ip) {
+		return nftSNATIPv6
+	}
+	return nftSNATIPv4
+}
+
+// Returns the SNAT map element that should be created for the given lb/endpoint
+func snatNFTElementFor(lb, ip string) *util.NFTElement {
+	return &util.NFTElement{Set: nftSNATFamilyFor(ip).set, Key: []string{ip}, Value: []string{lb}}
+}
+
+func useNFTables() bool {
+	return config.Gateway.RuleBackend == config.GatewayRuleBackendNFTables
+}
+
+func runNFTTransaction(tx *util.NFTTransaction) error {
+	nft, err := util.GetNFTablesHelper(NFTablesTable)
+	if err != nil {
+		return err
+	}
+	return nft.Run(tx)
+}
+
+// addNFTBaseObjects adds the table, chain, maps and rules of the egress services to the transaction.
+// Packets coming with the controller's "returnMark" are not evaluated for SNATing, the other ones
+// are SNATed to the LB of the service when their source is found in the SNAT map.
+func (c *Controller) addNFTBaseObjects(tx *util.NFTTransaction) {
+	tx.Add(&util.NFTTable{})
+	chain := &util.NFTChain{Name: nftChain, Type: "nat", Hook: "postrouting", Priority: "srcnat"}
+	tx.Add(chain)
+	tx.Flush(chain)
+	tx.Add(&util.NFTRule{Chain: nftChain, Rule: fmt.Sprintf("meta mark %s return", c.returnMark), Comment: "DoNotSNAT"})
+	var families []nftSNATFamily
+	if config.IPv4Mode {
+		families = append(families, nftSNATIPv4)
+	}
+	if config.IPv6Mode {
+		families = append(families, nftSNATIPv6)
+	}
+	for _, f := range families {
+		tx.Add(&util.NFTSet{Name: f.set, KeyType: f.addrType, ValueType: f.addrType})
+		tx.Add(&util.NFTRule{Chain: nftChain, Rule: fmt.Sprintf("snat %s to %s saddr map @%s", f.proto, f.proto, f.set)})
+	}
+}
+
+// addSNATRule SNATs the traffic of the endpoint to the lb
+func (c *Controller) addSNATRule(key, lb, ep string) error {
+	if useNFTables() {
+		tx := util.NewNFTTransaction()
+		tx.Add(snatNFTElementFor(lb, ep))
+		return runNFTTransaction(tx)
+	}
+	return nodeipt.AddRules([]nodeipt.Rule{snatIPTRuleFor(key, lb, ep)}, true)
+}
+
+// deleteSNATRule stops SNATing the traffic of the endpoint to the lb
+func (c *Controller) deleteSNATRule(key, lb, ep string) error {
+	if useNFTables() {
+		tx := util.NewNFTTransaction()
+		tx.Remove(snatNFTElementFor(lb, ep))
+		return runNFTTransaction(tx)
+	}
+	return nodeipt.DelRules([]nodeipt.Rule{snatIPTRuleFor(key, lb, ep)})
+}
+
+// repairNFTables recreates the egress services table with the SNAT map elements of the valid
+// endpoints in a single transaction, and updates the caches with them.
+// Valid endpoints in this context are those that belong to an existing EgressService with a LB.
+func (c *Controller) repairNFTables(v4EpsToServices, v6EpsToServices map[string]string) error {
+	cleanupIPTablesChain()
+
+	tx := util.NewNFTTransaction()
+	tx.Remove(&util.NFTTable{})
+	c.addNFTBaseObjects(tx)
+
+	type snatEp struct {
+		svcState *svcState
+		ep       string
+		v6       bool
+	}
+	var added []snatEp
+	addEps := func(epsToSvcs map[string]string, v6 bool) {
+		for ep, svcKey := range epsToSvcs {
+			svcState, found := c.services[svcKey]
+			if !found {
+				continue
+			}
+			lb := svcState.v4LB
+			if v6 {
+				lb = svcState.v6LB
+			}
+			if lb == "" {
+				continue
+			}
+			tx.Add(snatNFTElementFor(lb, ep))
+			added = append(added, snatEp{svcState: svcState, ep: ep, v6: v6})
+		}
+	}
+	if config.IPv4Mode {
+		addEps(v4EpsToServices, false)
+	}
+	if config.IPv6Mode {
+		addEps(v6EpsToServices, true)
+	}
+
+	if err := runNFTTransaction(tx); err != nil {
+		return fmt.Errorf("failed to repair egress services nftables table: %w", err)
+	}
+
+	// the elements are in place, update the services' caches to not reconfigure them later.
+	for _, a := range added {
+		if a.v6 {
+			a.svcState.v6Eps.Insert(a.ep)
+			continue
+		}
+		a.svcState.v4Eps.Insert(a.ep)
+	}
+	return nil
+}
+
+// cleanupIPTablesChain removes the egress services iptables chain that a previous
+// run with the iptables backend might have left behind
+func cleanupIPTablesChain() {
+	var protocols []iptables.Protocol
+	if config.IPv4Mode {
+		protocols = append(protocols, iptables.ProtocolIPv4)
+	}
+	if config.IPv6Mode {
+		protocols = append(protocols, iptables.ProtocolIPv6)
+	}
+	for _, proto := range protocols {
+		ipt, err := util.GetIPTablesHelper(proto)
+		if err != nil {
+			klog.Errorf("Failed to clean up egress service iptables chain: %v", err)
+			return
+		}
+		jump := nodeipt.Rule{Table: "nat", Chain: "POSTROUTING", Args: []string{"-j", Chain}, Protocol: proto}
+		if err := nodeipt.DelRules([]nodeipt.Rule{jump}); err != nil {
+			klog.Errorf("Failed to delete jump rule to egress service iptables chain: %v", err)
+		}
+		_ = ipt.ClearChain("nat", Chain)
+		_ = ipt.DeleteChain("nat", Chain)
+	}
+}
@@ -17,6 +17,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/wait"
 	clientset "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
 	utilnet "k8s.io/utils/net"
@@ -26,6 +27,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni"
 	config "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	adminpolicybasedrouteclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/clientset/versioned"
+	clusternetworkapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/informer"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
@@ -106,6 +108,12 @@ type DefaultNodeNetworkController struct {
 	retryEndpointSlices *retry.RetryFramework
 
 	apbExternalRouteNodeController *apbroute.ExternalGatewayNodeController
+
+	// ClusterNetwork events factory handler, adding cluster subnets at runtime
+	clusterNetworkHandler *factory.Handler
+	// number of cluster subnets the gateway is synced with, cluster subnets
+	// are only ever appended
+	syncedClusterSubnets int
 }
 
 func newDefaultNodeNetworkController(cnnci *CommonNodeNetworkControllerInfo, stopChan chan struct{},
@@ -707,6 +715,15 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 		return fmt.Errorf("error retrieving node %s: %v", nc.name, err)
 	}
 
+	if config.OVNKubernetesFeature.EnableNodeSubnetExpansion {
+		// cluster subnets added at runtime need to be known before the
+		// management port and the gateway are set up
+		if err := util.SyncClusterNetworkSubnets(nc.watchFactory.ClusterNetworkInformer().Lister()); err != nil {
+			return err
+		}
+		nc.syncedClusterSubnets = len(config.ClusterSubnets())
+	}
+
 	nodeAddrStr, err := util.GetNodePrimaryIP(node)
 	if err != nil {
 		return err
@@ -818,7 +835,7 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 
 	// Initialize gateway
 	if config.OvnKubeNode.Mode == types.NodeModeDPUHost {
-		err = nc.initGatewayDPUHost(nodeAddr)
+		err = nc.initGatewayDPUHost(nodeAddr, subnets)
 		if err != nil {
 			return err
 		}
@@ -977,6 +994,14 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 	nc.gateway.Start()
 	klog.Infof("Gateway and management port readiness took %v", time.Since(start))
 
+	if config.OVNKubernetesFeature.EnableNodeSubnetExpansion {
+		// the gateway needs to be synced with the cluster subnets added from
+		// now on
+		if err := nc.WatchClusterNetworks(); err != nil {
+			return err
+		}
+	}
+
 	// Note(adrianc): DPU deployments are expected to support the new shared gateway changes, upgrade flow
 	// is not needed. Future upgrade flows will need to take DPUs into account.
 	if config.OvnKubeNode.Mode != types.NodeModeDPUHost {
@@ -1130,10 +1155,86 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 // Stop gracefully stops the controller
 // deleteLogicalEntities will never be true for default network
 func (nc *DefaultNodeNetworkController) Stop() {
+	if nc.clusterNetworkHandler != nil {
+		nc.watchFactory.RemoveClusterNetworkHandler(nc.clusterNetworkHandler)
+	}
 	close(nc.stopChan)
 	nc.wg.Wait()
 }
 
+// WatchClusterNetworks starts the watching of ClusterNetwork resources adding
+// cluster subnets to the default network at runtime. Once the cluster subnets
+// changed, the gateway is reconciled so that its OpenFlow flows and forwarding
+// rules cover them. Routes towards the added cluster subnets are installed
+// when the management port is next checked.
+func (nc *DefaultNodeNetworkController) WatchClusterNetworks() error {
+	addClusterNetwork := func(obj interface{}) {
+		clusterNetwork, ok := obj.(*clusternetworkapi.ClusterNetwork)
+		if !ok {
+			klog.Errorf("Could not cast %T object to *clusternetworkapi.ClusterNetwork", obj)
+			return
+		}
+		added, err := util.AddClusterNetworkSubnets(clusterNetwork)
+		if err != nil {
+			klog.Errorf("Failed to add cluster subnets: %v", err)
+			return
+		}
+		if len(added) > 0 {
+			klog.Infof("Added cluster subnets %v from ClusterNetwork %s", added, clusterNetwork.Name)
+		}
+		// the cluster subnets might have already been added by another
+		// controller running in the same process, compare with what the
+		// gateway is synced with
+		clusterSubnets := len(config.ClusterSubnets())
+		if clusterSubnets == nc.syncedClusterSubnets {
+			return
+		}
+		if err := nc.syncGatewayClusterSubnets(); err != nil {
+			klog.Errorf("Failed to sync the gateway with ClusterNetwork %s: %v", clusterNetwork.Name, err)
+			return
+		}
+		nc.syncedClusterSubnets = clusterSubnets
+	}
+	handler, err := nc.watchFactory.AddClusterNetworkHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: addClusterNetwork,
+		UpdateFunc: func(_, newObj interface{}) {
+			addClusterNetwork(newObj)
+		},
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("unable to watch cluster networks: %w", err)
+	}
+	nc.clusterNetworkHandler = handler
+	return nil
+}
+
+// syncGatewayClusterSubnets regenerates the flows of the gateway bridges and
+// the forwarding rules of the gateway from the current cluster subnets.
+func (nc *DefaultNodeNetworkController) syncGatewayClusterSubnets() error {
+	if config.OvnKubeNode.Mode == types.NodeModeDPUHost {
+		// the gateway bridge is managed on the DPU
+		return nil
+	}
+	if err := nc.gateway.Reconcile(); err != nil {
+		return fmt.Errorf("failed to reconcile gateway: %w", err)
+	}
+	if config.Gateway.DisableForwarding {
+		var subnets []*net.IPNet
+		for _, subnet := range config.ClusterSubnets() {
+			subnets = append(subnets, subnet.CIDR)
+		}
+		if err := initExternalBridgeServiceForwardingRules(subnets); err != nil {
+			return fmt.Errorf("failed to add forwarding rules for the cluster subnets: %w", err)
+		}
+	}
+	return nil
+}
+
+// Gateway returns the gateway of the node, nil until the controller is started
+func (nc *DefaultNodeNetworkController) Gateway() Gateway {
+	return nc.gateway
+}
+
 func (nc *DefaultNodeNetworkController) startEgressIPHealthCheckingServer(mgmtPortEntry managementPortEntry) error {
 	healthCheckPort := config.OVNKubernetesFeature.EgressIPNodeHealthCheckPort
 	if healthCheckPort == 0 {
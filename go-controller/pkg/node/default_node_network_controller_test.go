@@ -4,11 +4,15 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"sync/atomic"
 
 	"github.com/urfave/cli/v2"
 	"github.com/vishvananda/netlink"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	clusternetworkapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1"
+	clusternetworkfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1/apis/clientset/versioned/fake"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube/mocks"
 
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
@@ -18,6 +22,7 @@ import (
 	utilMocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/mocks"
 	kapi "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -620,3 +625,88 @@ var _ = Describe("Node", func() {
 		})
 	})
 })
+
+// fakeClusterSubnetsGateway counts the reconciliations of the gateway
+type fakeClusterSubnetsGateway struct {
+	Gateway
+	reconciled atomic.Int32
+}
+
+func (g *fakeClusterSubnetsGateway) Reconcile() error {
+	g.reconciled.Add(1)
+	return nil
+}
+
+var _ = Describe("Node ClusterNetworks", func() {
+	var (
+		cnClient *clusternetworkfake.Clientset
+		wf       *factory.WatchFactory
+		gw       *fakeClusterSubnetsGateway
+		nc       *DefaultNodeNetworkController
+	)
+
+	newClusterNetwork := func(name, cidr string) *clusternetworkapi.ClusterNetwork {
+		return &clusternetworkapi.ClusterNetwork{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: clusternetworkapi.ClusterNetworkSpec{
+				ClusterSubnets: []clusternetworkapi.ClusterSubnet{{CIDR: cidr, HostSubnetLength: 23}},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.OVNKubernetesFeature.EnableNodeSubnetExpansion = true
+
+		cnClient = clusternetworkfake.NewSimpleClientset(newClusterNetwork("existing", "10.132.0.0/14"))
+		fakeClient := &util.OVNNodeClientset{
+			KubeClient:           fake.NewSimpleClientset(),
+			ClusterNetworkClient: cnClient,
+		}
+		var err error
+		wf, err = factory.NewNodeWatchFactory(fakeClient, "node1")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(wf.Start()).To(Succeed())
+
+		gw = &fakeClusterSubnetsGateway{}
+		nc = &DefaultNodeNetworkController{
+			BaseNodeNetworkController: BaseNodeNetworkController{
+				CommonNodeNetworkControllerInfo: CommonNodeNetworkControllerInfo{
+					name:         "node1",
+					watchFactory: wf,
+				},
+				NetInfo: &util.DefaultNetInfo{},
+			},
+			gateway: gw,
+		}
+		// the existing ClusterNetworks are synced on startup, before the
+		// gateway is set up
+		Expect(util.SyncClusterNetworkSubnets(wf.ClusterNetworkInformer().Lister())).To(Succeed())
+		nc.syncedClusterSubnets = len(config.ClusterSubnets())
+	})
+
+	AfterEach(func() {
+		if nc.clusterNetworkHandler != nil {
+			wf.RemoveClusterNetworkHandler(nc.clusterNetworkHandler)
+		}
+		wf.Shutdown()
+	})
+
+	It("reconciles the gateway only when cluster subnets are added", func() {
+		Expect(nc.WatchClusterNetworks()).To(Succeed())
+		Consistently(gw.reconciled.Load).Should(BeZero())
+
+		_, err := cnClient.K8sV1().ClusterNetworks().Create(context.TODO(), newClusterNetwork("added", "10.136.0.0/14"), metav1.CreateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(gw.reconciled.Load).Should(BeEquivalentTo(1))
+		Expect(config.ClusterSubnets()).To(HaveLen(3))
+
+		// the cluster subnets don't change when the ClusterNetwork is updated
+		_, err = cnClient.K8sV1().ClusterNetworks().Update(context.TODO(), &clusternetworkapi.ClusterNetwork{
+			ObjectMeta: metav1.ObjectMeta{Name: "added", Finalizers: []string{util.ClusterNetworkFinalizer}},
+			Spec:       newClusterNetwork("added", "10.136.0.0/14").Spec,
+		}, metav1.UpdateOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Consistently(gw.reconciled.Load).Should(BeEquivalentTo(1))
+	})
+})
@@ -419,6 +419,145 @@ var _ = Describe("Egress Service Operations", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		It("manages nftables SNAT elements for LoadBalancer egress service backed by cluster networked pods", func() {
+			app.Action = func(ctx *cli.Context) error {
+				config.Gateway.RuleBackend = config.GatewayRuleBackendNFTables
+				nft := util.SetFakeNFTablesHelper(egressservice.NFTablesTable)
+				fakeOvnNode.fakeExec.AddFakeCmd(&ovntest.ExpectedCmd{
+					Cmd:    "ip -4 --json rule show",
+					Output: "[]",
+					Err:    nil,
+				})
+
+				// leftovers of a previous run with the iptables backend
+				fakeRules := []nodeipt.Rule{
+					{
+						Table: "nat",
+						Chain: "POSTROUTING",
+						Args:  []string{"-j", "OVN-KUBE-EGRESS-SVC"},
+					},
+					{
+						Table: "nat",
+						Chain: "OVN-KUBE-EGRESS-SVC",
+						Args: []string{
+							"-s", "10.128.0.3",
+							"-m", "comment", "--comment", "namespace1/service1",
+							"-j", "SNAT",
+							"--to-source", "5.5.5.5",
+						},
+					},
+				}
+				Expect(appendIptRules(fakeRules)).To(Succeed())
+
+				epPortName := "https"
+				epPortValue := int32(443)
+
+				egressService := egressserviceapi.EgressService{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "service1",
+						Namespace: "namespace1",
+					},
+					Status: egressserviceapi.EgressServiceStatus{
+						Host: fakeNodeName,
+					},
+				}
+				service := *newService("service1", "namespace1", "10.129.0.2",
+					[]v1.ServicePort{
+						{
+							NodePort: int32(31111),
+							Protocol: v1.ProtocolTCP,
+							Port:     int32(8080),
+						},
+					},
+					v1.ServiceTypeLoadBalancer,
+					[]string{},
+					v1.ServiceStatus{
+						LoadBalancer: v1.LoadBalancerStatus{
+							Ingress: []v1.LoadBalancerIngress{{
+								IP: "5.5.5.5",
+							}},
+						},
+					},
+					false, false,
+				)
+
+				ep1 := discovery.Endpoint{
+					Addresses: []string{"10.128.0.3"},
+				}
+				epPort := discovery.EndpointPort{
+					Name: &epPortName,
+					Port: &epPortValue,
+				}
+
+				// host-networked endpoint, should not have an SNAT element created
+				ep2 := discovery.Endpoint{
+					Addresses: []string{"192.168.18.15"},
+					NodeName:  &fakeNodeName,
+				}
+				endpointSlice := *newEndpointSlice(
+					"service1",
+					"namespace1",
+					[]discovery.Endpoint{ep1, ep2},
+					[]discovery.EndpointPort{epPort})
+
+				fakeOvnNode.start(ctx,
+					&v1.ServiceList{
+						Items: []v1.Service{
+							service,
+						},
+					},
+					&discovery.EndpointSliceList{
+						Items: []discovery.EndpointSlice{
+							endpointSlice,
+						},
+					},
+					&egressserviceapi.EgressServiceList{
+						Items: []egressserviceapi.EgressService{
+							egressService,
+						},
+					},
+				)
+
+				wf := fakeOvnNode.watcher.(*factory.WatchFactory)
+				c, err := egressservice.NewController(fakeOvnNode.stopChan, ovnKubeNodeSNATMark, fakeOvnNode.nc.name,
+					wf.EgressServiceInformer(), wf.ServiceInformer(), wf.EndpointSliceInformer())
+				Expect(err).ToNot(HaveOccurred())
+				err = c.Run(fakeOvnNode.wg, 1)
+				Expect(err).ToNot(HaveOccurred())
+
+				Eventually(func() (map[string]string, error) {
+					return nft.ListElements("snat-ipv4")
+				}).Should(Equal(map[string]string{"10.128.0.3": "5.5.5.5"}))
+				Expect(nft.ListRules("egress-services")).To(Equal([]string{
+					"meta mark 0x3f0 return comment \"DoNotSNAT\"",
+					"snat ip to ip saddr map @snat-ipv4",
+				}))
+
+				expectedTables := map[string]util.FakeTable{
+					"nat": {
+						"POSTROUTING": []string{},
+					},
+					"filter": {},
+					"mangle": {},
+				}
+				f4 := iptV4.(*util.FakeIPTables)
+				Expect(f4.MatchState(expectedTables)).To(Succeed())
+
+				err = fakeOvnNode.fakeClient.EgressServiceClient.K8sV1().EgressServices("namespace1").Delete(context.TODO(), "service1", metav1.DeleteOptions{})
+				Expect(err).ToNot(HaveOccurred())
+
+				Eventually(func() (map[string]string, error) {
+					return nft.ListElements("snat-ipv4")
+				}).Should(BeEmpty())
+
+				Expect(fakeOvnNode.fakeExec.CalledMatchesExpected()).To(BeTrue(), fakeOvnNode.fakeExec.ErrorDesc)
+
+				return nil
+			}
+			err := app.Run([]string{app.Name})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
 		It("manages iptables/ip rules for LoadBalancer egress service backed by ovn-k pods with Network", func() {
 			app.Action = func(ctx *cli.Context) error {
 				fakeOvnNode.fakeExec.AddFakeCmd(&ovntest.ExpectedCmd{
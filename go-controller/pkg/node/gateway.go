@@ -33,6 +33,8 @@ type Gateway interface {
 	GetGatewayBridgeIface() string
 	SetDefaultGatewayBridgeMAC(addr net.HardwareAddr)
 	Reconcile() error
+	AddEgressNetwork(netName string, networkID int) error
+	DelEgressNetwork(netName string) error
 }
 
 type gateway struct {
@@ -40,8 +42,8 @@ type gateway struct {
 	loadBalancerHealthChecker informer.ServiceAndEndpointsEventHandler
 	// portClaimWatcher is for reserving ports for virtual IPs allocated by the cluster on the host
 	portClaimWatcher informer.ServiceEventHandler
-	// nodePortWatcherIptables is used in Shared GW mode to handle nodePort IPTable rules
-	nodePortWatcherIptables informer.ServiceEventHandler
+	// nodePortWatcherIptables is used on DPU hosts to handle nodePort IPTable rules
+	nodePortWatcherIptables informer.ServiceAndEndpointsEventHandler
 	// nodePortWatcher is used in Local+Shared GW modes to handle nodePort flows in shared OVS bridge
 	nodePortWatcher informer.ServiceAndEndpointsEventHandler
 	openflowManager *openflowManager
@@ -171,6 +173,11 @@ func (g *gateway) AddEndpointSlice(epSlice *discovery.EndpointSlice) error {
 			errors = append(errors, err)
 		}
 	}
+	if g.nodePortWatcherIptables != nil {
+		if err = g.nodePortWatcherIptables.AddEndpointSlice(epSlice); err != nil {
+			errors = append(errors, err)
+		}
+	}
 	return apierrors.NewAggregate(errors)
 
 }
@@ -189,6 +196,11 @@ func (g *gateway) UpdateEndpointSlice(oldEpSlice, newEpSlice *discovery.Endpoint
 			errors = append(errors, err)
 		}
 	}
+	if g.nodePortWatcherIptables != nil {
+		if err = g.nodePortWatcherIptables.UpdateEndpointSlice(oldEpSlice, newEpSlice); err != nil {
+			errors = append(errors, err)
+		}
+	}
 	return apierrors.NewAggregate(errors)
 
 }
@@ -207,6 +219,11 @@ func (g *gateway) DeleteEndpointSlice(epSlice *discovery.EndpointSlice) error {
 			errors = append(errors, err)
 		}
 	}
+	if g.nodePortWatcherIptables != nil {
+		if err = g.nodePortWatcherIptables.DeleteEndpointSlice(epSlice); err != nil {
+			errors = append(errors, err)
+		}
+	}
 	return apierrors.NewAggregate(errors)
 
 }
@@ -377,6 +394,24 @@ func (g *gateway) SetDefaultGatewayBridgeMAC(macAddr net.HardwareAddr) {
 	klog.Infof("Default gateway bridge MAC address updated to %s", macAddr)
 }
 
+// AddEgressNetwork connects the gateway routers of the given secondary network
+// to the external bridge, see openflowManager.addEgressNetwork
+func (g *gateway) AddEgressNetwork(netName string, networkID int) error {
+	if g.openflowManager == nil {
+		return fmt.Errorf("gateway of network %s is not supported without an external bridge", netName)
+	}
+	return g.openflowManager.addEgressNetwork(netName, networkID)
+}
+
+// DelEgressNetwork disconnects the gateway routers of the given secondary
+// network from the external bridge
+func (g *gateway) DelEgressNetwork(netName string) error {
+	if g.openflowManager == nil {
+		return nil
+	}
+	return g.openflowManager.delEgressNetwork(netName)
+}
+
 // Reconcile handles triggering updates to different components of a gateway, like OFM, Services
 func (g *gateway) Reconcile() error {
 	klog.Info("Reconciling gateway with updates")
@@ -432,6 +467,18 @@ type bridgeConfiguration struct {
 	ofPortPatch string
 	ofPortPhys  string
 	ofPortHost  string
+	// secondary networks with gateway routers connected to the bridge
+	egressNetworks map[string]*bridgeEgressNetwork
+}
+
+// bridgeEgressNetwork holds the bridge configuration of a secondary network
+// with gateway routers connected to the bridge
+type bridgeEgressNetwork struct {
+	patchPort   string
+	ofPortPatch string
+	// ctMark identifies the connections of the network in the bridge
+	// conntrack zone so that the return traffic goes back to the network
+	ctMark string
 }
 
 // updateInterfaceIPAddresses sets and returns the bridge's current ips
@@ -443,7 +443,7 @@ func interfaceForEXGW(intfName string) string {
 	return intfName
 }
 
-func (nc *DefaultNodeNetworkController) initGatewayDPUHost(kubeNodeIP net.IP) error {
+func (nc *DefaultNodeNetworkController) initGatewayDPUHost(kubeNodeIP net.IP, hostSubnets []*net.IPNet) error {
 	// A DPU host gateway is complementary to the shared gateway running
 	// on the DPU embedded CPU. it performs some initializations and
 	// watch on services for iptable rule updates and run a loadBalancerHealth checker
@@ -490,10 +490,19 @@ func (nc *DefaultNodeNetworkController) initGatewayDPUHost(kubeNodeIP net.IP) er
 	// TODO(adrianc): revisit if support for nodeIPManager is needed.
 
 	if config.Gateway.NodeportEnable {
-		if err := initSharedGatewayIPTables(); err != nil {
+		if err := newServiceRuleManager().initRules(); err != nil {
 			return err
 		}
-		gw.nodePortWatcherIptables = newNodePortWatcherIptables()
+		// ITP=local traffic from the host is steered into OVN via the management port
+		// which is a VF on the DPU host.
+		if err := initSvcViaMgmPortRoutingRules(hostSubnets); err != nil {
+			return err
+		}
+		nodeIPs := make([]net.IP, 0, len(ifAddrs))
+		for _, ifAddr := range ifAddrs {
+			nodeIPs = append(nodeIPs, ifAddr.IP)
+		}
+		gw.nodePortWatcherIptables = newNodePortWatcherIptables(nc.name, nodeIPs, nc.watchFactory)
 		gw.loadBalancerHealthChecker = newLoadBalancerHealthChecker(nc.name, nc.watchFactory)
 		portClaimWatcher, err := newPortClaimWatcher(nc.recorder)
 		if err != nil {
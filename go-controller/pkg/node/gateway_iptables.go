@@ -14,6 +14,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	kapi "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	utilnet "k8s.io/utils/net"
 )
@@ -206,7 +207,6 @@ func computeProbability(n, i int) string {
 func generateIPTRulesForLoadBalancersWithoutNodePorts(svcPort kapi.ServicePort, externalIP string, service *kapi.Service, localEndpoints []string) []nodeipt.Rule {
 	var iptRules []nodeipt.Rule
 	if len(localEndpoints) == 0 {
-		// either its smart nic mode; etp&itp not implemented, OR
 		// fetching endpointSlices error-ed out prior to reaching here so nothing to do
 		return iptRules
 	}
@@ -611,3 +611,102 @@ func getGatewayIPTRules(service *kapi.Service, localEndpoints []string, svcHasLo
 	}
 	return rules
 }
+
+// serviceRuleManager programs the host rules DNAT-ing NodePort, ExternalIP and LoadBalancer
+// service traffic and steering host traffic towards ITP=local services. Its scope is limited to
+// those rules: the rules of the management port SNAT chain, which the nftables implementation
+// also programs for the services, along with the masquerade, forwarding and node iptables
+// controller rules, are iptables rules whatever the configured rule backend.
+// TODO: program those rule sets with the configured rule backend too.
+type serviceRuleManager interface {
+	// initRules creates the chains holding the service rules
+	initRules() error
+	// addServiceRules programs the rules of the service for its local endpoints
+	addServiceRules(service *kapi.Service, localEndpoints []string, svcHasLocalHostNetEndPnt bool) error
+	// deleteServiceRules removes all the rules the service could have been programmed with
+	deleteServiceRules(service *kapi.Service, localEndpoints []string) error
+	// syncServiceRules replaces all the programmed rules with the ones of the provided services
+	syncServiceRules(services []*serviceConfig) error
+	// cleanup removes the chains holding the service rules
+	cleanup()
+}
+
+// newServiceRuleManager returns the serviceRuleManager of the configured rule backend
+func newServiceRuleManager() serviceRuleManager {
+	if config.Gateway.RuleBackend == config.GatewayRuleBackendNFTables {
+		return &nftablesServiceRuleManager{}
+	}
+	return &iptablesServiceRuleManager{}
+}
+
+// iptablesServiceRuleManager programs the service rules in the OVN-KUBE-* iptables chains
+type iptablesServiceRuleManager struct{}
+
+func (m *iptablesServiceRuleManager) initRules() error {
+	if config.Gateway.Mode == config.GatewayModeLocal {
+		return initLocalGatewayIPTables()
+	}
+	return initSharedGatewayIPTables()
+}
+
+func (m *iptablesServiceRuleManager) addServiceRules(service *kapi.Service, localEndpoints []string, svcHasLocalHostNetEndPnt bool) error {
+	return insertIptRules(getGatewayIPTRules(service, localEndpoints, svcHasLocalHostNetEndPnt))
+}
+
+func (m *iptablesServiceRuleManager) deleteServiceRules(service *kapi.Service, localEndpoints []string) error {
+	// Always try and delete all rules, see delServiceRules for the possible scenarios
+	var errors []error
+	if err := nodeipt.DelRules(getGatewayIPTRules(service, localEndpoints, true)); err != nil {
+		errors = append(errors, err)
+	}
+	if err := nodeipt.DelRules(getGatewayIPTRules(service, localEndpoints, false)); err != nil {
+		errors = append(errors, err)
+	}
+	return apierrors.NewAggregate(errors)
+}
+
+func (m *iptablesServiceRuleManager) syncServiceRules(services []*serviceConfig) error {
+	var errors []error
+	keepIPTRules := []nodeipt.Rule{}
+	for _, svcConfig := range services {
+		keepIPTRules = append(keepIPTRules, getGatewayIPTRules(svcConfig.service, sets.List(svcConfig.localEndpoints), svcConfig.hasLocalHostNetworkEp)...)
+	}
+	// (NOTE: Order is important, add jump to iptableETPChain before jump to NP/EIP chains)
+	for _, chain := range []string{iptableITPChain, iptableNodePortChain, iptableExternalIPChain, iptableETPChain, iptableMgmPortChain} {
+		if err := recreateIPTRules("nat", chain, keepIPTRules); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	if err := recreateIPTRules("mangle", iptableITPChain, keepIPTRules); err != nil {
+		errors = append(errors, err)
+	}
+	return apierrors.NewAggregate(errors)
+}
+
+func (m *iptablesServiceRuleManager) cleanup() {
+	cleanupSharedGatewayIPTChains()
+}
+
+// cleanupServiceIPTChains removes the iptables service chains and the rules jumping to them
+func cleanupServiceIPTChains() {
+	for _, proto := range clusterIPTablesProtocols() {
+		ipt, err := util.GetIPTablesHelper(proto)
+		if err != nil {
+			klog.Errorf("Failed to clean up iptables service chains: %v", err)
+			return
+		}
+		for _, chain := range []string{iptableITPChain, iptableNodePortChain, iptableExternalIPChain, iptableETPChain} {
+			if err := nodeipt.DelRules(getGatewayInitRules(chain, proto)); err != nil {
+				klog.Errorf("Failed to delete jump rules to iptables chain %s: %v", chain, err)
+			}
+			tables := []string{"nat"}
+			if chain == iptableITPChain {
+				tables = append(tables, "mangle")
+			}
+			for _, table := range tables {
+				_ = ipt.ClearChain(table, chain)
+				_ = ipt.DeleteChain(table, chain)
+			}
+		}
+	}
+}
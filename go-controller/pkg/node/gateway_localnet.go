@@ -128,7 +128,7 @@ func newLocalGateway(nodeName string, hostSubnets []*net.IPNet, gwNextHops []net
 
 		if config.Gateway.NodeportEnable {
 			if config.OvnKubeNode.Mode == types.NodeModeFull {
-				// (TODO): Internal Traffic Policy is not supported in DPU mode
+				// In DPU mode the management port routing rules for ITP=local traffic are set up by the DPU host
 				if err := initSvcViaMgmPortRoutingRules(hostSubnets); err != nil {
 					return err
 				}
@@ -13,6 +13,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/retry"
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/mocks"
 	"github.com/urfave/cli/v2"
@@ -53,6 +54,7 @@ func initFakeNodePortWatcher(iptV4, iptV6 util.IPTablesHelper) *nodePortWatcher
 	fNPW := nodePortWatcher{
 		ofportPhys:  "eth0",
 		ofportPatch: "patch-breth0_ov",
+		ofportHost:  ovsLocalPort,
 		gatewayIPv4: v4localnetGatewayIP,
 		gatewayIPv6: v6localnetGatewayIP,
 		serviceInfo: make(map[k8stypes.NamespacedName]*serviceConfig),
@@ -65,7 +67,7 @@ func initFakeNodePortWatcher(iptV4, iptV6 util.IPTablesHelper) *nodePortWatcher
 }
 
 func startNodePortWatcher(n *nodePortWatcher, fakeClient *util.OVNNodeClientset, fakeMgmtPortConfig *managementPortConfig) error {
-	if err := initLocalGatewayIPTables(); err != nil {
+	if err := newServiceRuleManager().initRules(); err != nil {
 		return err
 	}
 
@@ -97,7 +99,7 @@ func startNodePortWatcher(n *nodePortWatcher, fakeClient *util.OVNNodeClientset,
 }
 
 func startNodePortWatcherWithRetry(n *nodePortWatcher, fakeClient *util.OVNNodeClientset, fakeMgmtPortConfig *managementPortConfig, stopChan chan struct{}, wg *sync.WaitGroup) (*retry.RetryFramework, error) {
-	if err := initLocalGatewayIPTables(); err != nil {
+	if err := newServiceRuleManager().initRules(); err != nil {
 		return nil, err
 	}
 
@@ -2638,4 +2640,529 @@ var _ = Describe("Node Operations", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 	})
+
+	Context("in DPU mode", func() {
+		It("manages openflows towards the host representor for NodePort backed by local-host-networked pods where ETP=local", func() {
+			app.Action = func(ctx *cli.Context) error {
+				config.Gateway.Mode = config.GatewayModeShared
+				epPortName := "https"
+				outport := int32(443)
+				service := *newService("service1", "namespace1", "10.129.0.2",
+					[]v1.ServicePort{
+						{
+							NodePort:   int32(31111),
+							Protocol:   v1.ProtocolTCP,
+							Port:       int32(8080),
+							TargetPort: intstr.FromInt(int(outport)),
+						},
+					},
+					v1.ServiceTypeNodePort,
+					nil,
+					v1.ServiceStatus{},
+					true, false,
+				)
+				ep1 := discovery.Endpoint{
+					Addresses: []string{"192.168.18.15"}, // host-networked endpoint local to this node
+					NodeName:  &fakeNodeName,
+				}
+				epPort1 := discovery.EndpointPort{
+					Name: &epPortName,
+					Port: &outport,
+				}
+				endpointSlice := *newEndpointSlice(
+					"service1",
+					"namespace1",
+					[]discovery.Endpoint{ep1},
+					[]discovery.EndpointPort{epPort1})
+
+				fakeOvnNode.start(ctx,
+					&v1.ServiceList{
+						Items: []v1.Service{
+							service,
+						},
+					},
+					&endpointSlice,
+				)
+
+				// the host is reached through the host representor port on the DPU
+				fNPW.dpuMode = true
+				fNPW.ofportHost = "3"
+				fNPW.watchFactory = fakeOvnNode.watcher
+				Expect(startNodePortWatcher(fNPW, fakeOvnNode.fakeClient, &fakeMgmtPortConfig)).To(Succeed())
+				err := fNPW.AddService(&service)
+				Expect(err).NotTo(HaveOccurred())
+
+				expectedFlows := []string{
+					"cookie=0x453ae29bcbbc08bd, priority=110, in_port=eth0, tcp, tp_dst=31111, actions=ct(commit,zone=64003,nat(dst=10.244.0.1:443),table=6)",
+					"cookie=0xe745ecf105, priority=110, table=6, actions=output:3",
+					"cookie=0x453ae29bcbbc08bd, priority=110, in_port=3, tcp, tp_src=443, actions=ct(zone=64003 nat,table=7)",
+					"cookie=0xe745ecf105, priority=110, table=7, actions=output:eth0",
+				}
+				flows := fNPW.ofm.flowCache["NodePort_namespace1_service1_tcp_31111"]
+				Expect(flows).To(Equal(expectedFlows))
+
+				// no iptables rules are programmed on the DPU
+				expectedTables := map[string]util.FakeTable{
+					"nat": {
+						"PREROUTING": []string{
+							"-j OVN-KUBE-ETP",
+							"-j OVN-KUBE-EXTERNALIP",
+							"-j OVN-KUBE-NODEPORT",
+						},
+						"OUTPUT": []string{
+							"-j OVN-KUBE-EXTERNALIP",
+							"-j OVN-KUBE-NODEPORT",
+							"-j OVN-KUBE-ITP",
+						},
+						"POSTROUTING": []string{
+							"-j OVN-KUBE-EGRESS-SVC",
+						},
+						"OVN-KUBE-NODEPORT":   []string{},
+						"OVN-KUBE-EXTERNALIP": []string{},
+						"OVN-KUBE-ITP":        []string{},
+						"OVN-KUBE-ETP":        []string{},
+						"OVN-KUBE-EGRESS-SVC": []string{},
+					},
+					"filter": {},
+					"mangle": {
+						"OUTPUT": []string{
+							"-j OVN-KUBE-ITP",
+						},
+						"OVN-KUBE-ITP": []string{},
+					},
+				}
+				f4 := iptV4.(*util.FakeIPTables)
+				Expect(f4.MatchState(expectedTables)).To(Succeed())
+
+				return nil
+			}
+			err := app.Run([]string{app.Name})
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("manages iptables rules on the DPU host for NodePort where ETP=local and ITP=local", func() {
+			app.Action = func(ctx *cli.Context) error {
+				config.Gateway.Mode = config.GatewayModeShared
+				epPortName := "https"
+				outport := int32(443)
+				service := *newService("service1", "namespace1", "10.129.0.2",
+					[]v1.ServicePort{
+						{
+							NodePort:   int32(31111),
+							Protocol:   v1.ProtocolTCP,
+							Port:       int32(8080),
+							TargetPort: intstr.FromInt(int(outport)),
+						},
+					},
+					v1.ServiceTypeNodePort,
+					nil,
+					v1.ServiceStatus{},
+					true, true,
+				)
+				ep1 := discovery.Endpoint{
+					Addresses: []string{"192.168.18.15"}, // host-networked endpoint local to this node
+					NodeName:  &fakeNodeName,
+				}
+				epPort1 := discovery.EndpointPort{
+					Name: &epPortName,
+					Port: &outport,
+				}
+				endpointSlice := newEndpointSlice(
+					"service1",
+					"namespace1",
+					[]discovery.Endpoint{ep1},
+					[]discovery.EndpointPort{epPort1})
+
+				fakeOvnNode.start(ctx,
+					&v1.ServiceList{
+						Items: []v1.Service{
+							service,
+						},
+					},
+					endpointSlice,
+				)
+
+				config.OvnKubeNode.Mode = types.NodeModeDPUHost
+				Expect(initSharedGatewayIPTables()).To(Succeed())
+				f4 := iptV4.(*util.FakeIPTables)
+				// the management port chain is set up along with the management port netdev
+				Expect(f4.NewChain("nat", iptableMgmPortChain)).To(Succeed())
+				npwipt := newNodePortWatcherIptables(fakeNodeName, []net.IP{net.ParseIP("192.168.18.15")}, fakeOvnNode.watcher)
+				Expect(npwipt.AddService(&service)).To(Succeed())
+
+				nodePortRule := fmt.Sprintf("-p %s -m addrtype --dst-type LOCAL --dport %v -j DNAT --to-destination %s:%v",
+					service.Spec.Ports[0].Protocol, service.Spec.Ports[0].NodePort, service.Spec.ClusterIP, service.Spec.Ports[0].Port)
+				getExpectedTables := func(natITPRules, mgmtPortRules, mangleITPRules []string) map[string]util.FakeTable {
+					return map[string]util.FakeTable{
+						"nat": {
+							"PREROUTING": []string{
+								"-j OVN-KUBE-ETP",
+								"-j OVN-KUBE-EXTERNALIP",
+								"-j OVN-KUBE-NODEPORT",
+							},
+							"OUTPUT": []string{
+								"-j OVN-KUBE-EXTERNALIP",
+								"-j OVN-KUBE-NODEPORT",
+								"-j OVN-KUBE-ITP",
+							},
+							"POSTROUTING": []string{
+								"-j OVN-KUBE-EGRESS-SVC",
+							},
+							"OVN-KUBE-NODEPORT":      []string{nodePortRule},
+							"OVN-KUBE-EXTERNALIP":    []string{},
+							"OVN-KUBE-SNAT-MGMTPORT": mgmtPortRules,
+							"OVN-KUBE-ITP":           natITPRules,
+							"OVN-KUBE-ETP":           []string{},
+							"OVN-KUBE-EGRESS-SVC":    []string{},
+						},
+						"filter": {},
+						"mangle": {
+							"OUTPUT": []string{
+								"-j OVN-KUBE-ITP",
+							},
+							"OVN-KUBE-ITP": mangleITPRules,
+						},
+					}
+				}
+
+				// the host-networked endpoint is local, ITP=local traffic is redirected to it
+				Expect(f4.MatchState(getExpectedTables(
+					[]string{fmt.Sprintf("-p %s -d %s --dport %d -j REDIRECT --to-port %d", service.Spec.Ports[0].Protocol,
+						service.Spec.ClusterIP, service.Spec.Ports[0].Port, outport)},
+					[]string{},
+					[]string{},
+				))).To(Succeed())
+
+				// the endpoint moves into the pod network: ITP=local traffic is steered via the management
+				// port and the source IP of ETP=local traffic is preserved
+				endpointSlice.Endpoints[0].Addresses = []string{"10.244.0.3"}
+				_, err := fakeOvnNode.fakeClient.KubeClient.DiscoveryV1().EndpointSlices(endpointSlice.Namespace).Update(
+					context.TODO(), endpointSlice, metav1.UpdateOptions{})
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(func() error {
+					if err := npwipt.UpdateEndpointSlice(endpointSlice, endpointSlice); err != nil {
+						return err
+					}
+					return f4.MatchState(getExpectedTables(
+						[]string{},
+						[]string{fmt.Sprintf("-p TCP --dport %v -j RETURN", service.Spec.Ports[0].NodePort)},
+						[]string{fmt.Sprintf("-p %s -d %s --dport %d -j MARK --set-xmark %s", service.Spec.Ports[0].Protocol,
+							service.Spec.ClusterIP, service.Spec.Ports[0].Port, ovnkubeITPMark)},
+					))
+				}).Should(Succeed())
+
+				Expect(npwipt.DeleteService(&service)).To(Succeed())
+				expectedTables := getExpectedTables([]string{}, []string{}, []string{})
+				expectedTables["nat"]["OVN-KUBE-NODEPORT"] = []string{}
+				Expect(f4.MatchState(expectedTables)).To(Succeed())
+
+				return nil
+			}
+			err := app.Run([]string{app.Name})
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("with the nftables rule backend", func() {
+		var nft *util.FakeNFTables
+
+		BeforeEach(func() {
+			config.Gateway.RuleBackend = config.GatewayRuleBackendNFTables
+			nft = util.SetFakeNFTablesHelper(nftServicesTable)
+		})
+
+		It("replaces iptables service chains and removes stale nftables elements on startup", func() {
+			app.Action = func(ctx *cli.Context) error {
+				externalIP := "1.1.1.1"
+				for i := 0; i < 2; i++ {
+					fakeOvnNode.fakeExec.AddFakeCmd(&ovntest.ExpectedCmd{
+						Cmd: "ovs-ofctl show ",
+					})
+				}
+				service := *newService("service1", "namespace1", "10.129.0.2",
+					[]v1.ServicePort{
+						{
+							Port:     int32(8032),
+							Protocol: v1.ProtocolTCP,
+						},
+					},
+					v1.ServiceTypeClusterIP,
+					[]string{externalIP},
+					v1.ServiceStatus{},
+					false, false,
+				)
+
+				// leftovers of a previous run with the iptables backend
+				Expect(initLocalGatewayIPTables()).To(Succeed())
+				Expect(insertIptRules(getExternalIPTRules(service.Spec.Ports[0], externalIP, service.Spec.ClusterIP, false, false))).To(Succeed())
+				// leftovers of a previous run with the nftables backend
+				tx := util.NewNFTTransaction()
+				addServiceNFTBaseObjects(tx)
+				tx.Add(&util.NFTElement{Set: "external-ips-ipv4", Key: []string{"10.10.10.10", "udp", "27000"}, Value: []string{"172.32.0.12", "27000"}})
+				Expect(nft.Run(tx)).To(Succeed())
+
+				fakeOvnNode.start(ctx,
+					&v1.ServiceList{
+						Items: []v1.Service{
+							service,
+						},
+					},
+				)
+
+				fNPW.watchFactory = fakeOvnNode.watcher
+				Expect(startNodePortWatcher(fNPW, fakeOvnNode.fakeClient, &fakeMgmtPortConfig)).To(Succeed())
+				Expect(fakeOvnNode.fakeExec.CalledMatchesExpected()).To(BeTrue(), fExec.ErrorDesc)
+
+				expectedTables := map[string]util.FakeTable{
+					"nat": {
+						"PREROUTING":             []string{},
+						"OUTPUT":                 []string{},
+						"POSTROUTING":            []string{"-j OVN-KUBE-EGRESS-SVC"},
+						"OVN-KUBE-SNAT-MGMTPORT": []string{},
+						"OVN-KUBE-EGRESS-SVC":    []string{},
+					},
+					"filter": {},
+					"mangle": {
+						"OUTPUT": []string{},
+					},
+				}
+				f4 := iptV4.(*util.FakeIPTables)
+				Expect(f4.MatchState(expectedTables)).To(Succeed())
+
+				Expect(nft.ListElements("external-ips-ipv4")).To(Equal(map[string]string{
+					"1.1.1.1 . tcp . 8032": "10.129.0.2 . 8032",
+				}))
+				Expect(nft.ListElements("nodeports-ipv4")).To(BeEmpty())
+				Expect(nft.ListChains()).To(Equal([]string{nftServiceMarkChain, nftServiceOutputChain, nftServicePreroutingChain}))
+				return nil
+			}
+			Expect(app.Run([]string{app.Name})).To(Succeed())
+		})
+
+		It("manages nftables elements and iptables management port rules for NodePort where ETP=local and ITP=local, LGW", func() {
+			app.Action = func(ctx *cli.Context) error {
+				config.Gateway.Mode = config.GatewayModeLocal
+				epPortName := "https"
+				epPortValue := int32(443)
+				service := *newService("service1", "namespace1", "10.129.0.2",
+					[]v1.ServicePort{
+						{
+							NodePort: int32(31111),
+							Protocol: v1.ProtocolTCP,
+							Port:     int32(8080),
+						},
+					},
+					v1.ServiceTypeNodePort,
+					nil,
+					v1.ServiceStatus{},
+					true, true,
+				)
+				ep1 := discovery.Endpoint{
+					Addresses: []string{"10.244.0.3"},
+				}
+				epPort1 := discovery.EndpointPort{
+					Name: &epPortName,
+					Port: &epPortValue,
+				}
+				// endpointSlice.Endpoints is ovn-networked so this will
+				// come under !hasLocalHostNetEp case
+				endpointSlice := *newEndpointSlice(
+					"service1",
+					"namespace1",
+					[]discovery.Endpoint{ep1},
+					[]discovery.EndpointPort{epPort1})
+
+				fakeOvnNode.start(ctx,
+					&v1.ServiceList{
+						Items: []v1.Service{
+							service,
+						},
+					},
+					&endpointSlice,
+				)
+
+				fNPW.watchFactory = fakeOvnNode.watcher
+				Expect(startNodePortWatcher(fNPW, fakeOvnNode.fakeClient, &fakeMgmtPortConfig)).To(Succeed())
+				Expect(fNPW.AddService(&service)).To(Succeed())
+
+				Expect(nft.ListElements("nodeports-ipv4")).To(Equal(map[string]string{
+					"tcp . 31111": "10.129.0.2 . 8080",
+				}))
+				Expect(nft.ListElements("etp-nodeports-ipv4")).To(Equal(map[string]string{
+					"tcp . 31111": fmt.Sprintf("%s . 31111", config.Gateway.MasqueradeIPs.V4HostETPLocalMasqueradeIP.String()),
+				}))
+				Expect(nft.ListElements("itp-mark-ipv4")).To(Equal(map[string]string{
+					"10.129.0.2 . tcp . 8080": "",
+				}))
+				Expect(nft.ListElements("itp-redirect-ipv4")).To(BeEmpty())
+
+				expectedTables := map[string]util.FakeTable{
+					"nat": {
+						"OVN-KUBE-SNAT-MGMTPORT": []string{
+							fmt.Sprintf("-p TCP --dport %v -j RETURN", service.Spec.Ports[0].NodePort),
+						},
+					},
+					"filter": {},
+					"mangle": {},
+				}
+				f4 := iptV4.(*util.FakeIPTables)
+				Expect(f4.MatchState(expectedTables)).To(Succeed())
+
+				addConntrackMocks(netlinkMock, []ctFilterDesc{{"10.129.0.2", 8080}, {"192.168.18.15", 31111}})
+				Expect(fNPW.DeleteService(&service)).To(Succeed())
+
+				for _, set := range []string{"nodeports-ipv4", "etp-nodeports-ipv4", "itp-mark-ipv4"} {
+					Expect(nft.ListElements(set)).To(BeEmpty(), set)
+				}
+				expectedTables["nat"]["OVN-KUBE-SNAT-MGMTPORT"] = []string{}
+				Expect(f4.MatchState(expectedTables)).To(Succeed())
+				return nil
+			}
+			Expect(app.Run([]string{app.Name})).To(Succeed())
+		})
+
+		It("only adds and deletes the nftables elements not programmed for another service", func() {
+			app.Action = func(ctx *cli.Context) error {
+				newNodePortService := func(name, clusterIP string, nodePorts ...int32) *v1.Service {
+					var ports []v1.ServicePort
+					for _, nodePort := range nodePorts {
+						ports = append(ports, v1.ServicePort{
+							NodePort: nodePort,
+							Protocol: v1.ProtocolTCP,
+							Port:     int32(8080),
+						})
+					}
+					return newService(name, "namespace1", clusterIP,
+						ports,
+						v1.ServiceTypeNodePort,
+						nil,
+						v1.ServiceStatus{},
+						false, false,
+					)
+				}
+				service1 := newNodePortService("service1", "10.129.0.2", 31111)
+				service2 := newNodePortService("service2", "10.129.0.3", 31111, 31112)
+				fakeOvnNode.start(ctx)
+
+				tx := util.NewNFTTransaction()
+				addServiceNFTBaseObjects(tx)
+				Expect(nft.Run(tx)).To(Succeed())
+				m := &nftablesServiceRuleManager{}
+				Expect(m.addServiceRules(service1, nil, false)).To(Succeed())
+				// NodePort 31111 is already programmed for service1, only 31112 is programmed for service2
+				Expect(m.addServiceRules(service2, nil, false)).To(Succeed())
+				Expect(nft.ListElements("nodeports-ipv4")).To(Equal(map[string]string{
+					"tcp . 31111": "10.129.0.2 . 8080",
+					"tcp . 31112": "10.129.0.3 . 8080",
+				}))
+
+				Expect(m.deleteServiceRules(service2, nil)).To(Succeed())
+				Expect(nft.ListElements("nodeports-ipv4")).To(Equal(map[string]string{
+					"tcp . 31111": "10.129.0.2 . 8080",
+				}))
+				Expect(m.deleteServiceRules(service1, nil)).To(Succeed())
+				Expect(nft.ListElements("nodeports-ipv4")).To(BeEmpty())
+				return nil
+			}
+			Expect(app.Run([]string{app.Name})).To(Succeed())
+		})
+
+		It("manages nftables load balancing chains for LoadBalancer where AllocateLoadBalancerNodePorts=False, ETP=local, LGW", func() {
+			app.Action = func(ctx *cli.Context) error {
+				config.Gateway.Mode = config.GatewayModeLocal
+				for i := 0; i < 3; i++ {
+					fakeOvnNode.fakeExec.AddFakeCmd(&ovntest.ExpectedCmd{
+						Cmd: "ovs-ofctl show ",
+						Err: fmt.Errorf("deliberate error to fall back to output:LOCAL"),
+					})
+				}
+				service := *newServiceWithoutNodePortAllocation("service1", "namespace1", "10.129.0.2",
+					[]v1.ServicePort{
+						{
+							Protocol:   v1.ProtocolTCP,
+							Port:       int32(80),
+							TargetPort: intstr.FromInt(int(int32(8080))),
+						},
+					},
+					v1.ServiceTypeLoadBalancer,
+					nil,
+					v1.ServiceStatus{
+						LoadBalancer: v1.LoadBalancerStatus{
+							Ingress: []v1.LoadBalancerIngress{{
+								IP: "5.5.5.5",
+							}},
+						},
+					},
+					true, false,
+				)
+				ep1 := discovery.Endpoint{
+					Addresses: []string{"10.244.0.3"},
+					NodeName:  &fakeNodeName,
+				}
+				ep2 := discovery.Endpoint{
+					Addresses: []string{"10.244.0.4"},
+					NodeName:  &fakeNodeName,
+				}
+				epPortName := "http"
+				epPortValue := int32(8080)
+				epPort1 := discovery.EndpointPort{
+					Name: &epPortName,
+					Port: &epPortValue,
+				}
+				endpointSlice := *newEndpointSlice(
+					"service1",
+					"namespace1",
+					[]discovery.Endpoint{ep1, ep2},
+					[]discovery.EndpointPort{epPort1})
+
+				fakeOvnNode.start(ctx,
+					&v1.ServiceList{
+						Items: []v1.Service{
+							service,
+						},
+					},
+					&endpointSlice,
+				)
+
+				fNPW.watchFactory = fakeOvnNode.watcher
+				Expect(startNodePortWatcher(fNPW, fakeOvnNode.fakeClient, &fakeMgmtPortConfig)).To(Succeed())
+				Expect(fNPW.AddService(&service)).To(Succeed())
+
+				lbChain := getNFTLBChainName(&service, "5.5.5.5", service.Spec.Ports[0])
+				Expect(nft.ListElements("etp-lb-chains-ipv4")).To(Equal(map[string]string{
+					"5.5.5.5 . tcp . 80": "goto " + lbChain,
+				}))
+				Expect(nft.ListElements("external-ips-ipv4")).To(Equal(map[string]string{
+					"5.5.5.5 . tcp . 80": "10.129.0.2 . 80",
+				}))
+				Expect(nft.ListRules(lbChain)).To(Equal([]string{
+					"meta nfproto ipv4 dnat ip to numgen random mod 2 map { 0 : 10.244.0.3 . 8080, 1 : 10.244.0.4 . 8080 }",
+				}))
+
+				expectedTables := map[string]util.FakeTable{
+					"nat": {
+						"OVN-KUBE-SNAT-MGMTPORT": []string{
+							"-p TCP -d 10.244.0.3 --dport 8080 -j RETURN",
+							"-p TCP -d 10.244.0.4 --dport 8080 -j RETURN",
+						},
+					},
+					"filter": {},
+					"mangle": {},
+				}
+				f4 := iptV4.(*util.FakeIPTables)
+				Expect(f4.MatchState(expectedTables)).To(Succeed())
+
+				addConntrackMocks(netlinkMock, []ctFilterDesc{{"5.5.5.5", 80}, {"10.129.0.2", 80}})
+				Expect(fNPW.DeleteService(&service)).To(Succeed())
+
+				Expect(nft.ListElements("etp-lb-chains-ipv4")).To(BeEmpty())
+				Expect(nft.ListElements("external-ips-ipv4")).To(BeEmpty())
+				Expect(nft.ListChains()).NotTo(ContainElement(lbChain))
+				expectedTables["nat"]["OVN-KUBE-SNAT-MGMTPORT"] = []string{}
+				Expect(f4.MatchState(expectedTables)).To(Succeed())
+				return nil
+			}
+			Expect(app.Run([]string{app.Name})).To(Succeed())
+		})
+	})
 })
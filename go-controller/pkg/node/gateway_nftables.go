@@ -0,0 +1,491 @@
+//go:build linux
+// +build linux
+
+package node
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strings"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	nodeipt "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/iptables"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	kapi "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+)
+
+const (
+	// nftServicesTable is the nftables table owning the service rules when the nftables backend is used
+	nftServicesTable = "ovn-kubernetes-services"
+
+	nftServicePreroutingChain = "service-prerouting" // nat chain for traffic entering the host
+	nftServiceOutputChain     = "service-output"     // nat chain for traffic originated by the host
+	nftServiceMarkChain       = "service-mark"       // route chain marking host traffic towards ITP=local services
+	// nftETPLBChainPrefix prefixes the chains load balancing the traffic of an ETP=local
+	// service without NodePort over its local endpoints
+	nftETPLBChainPrefix = "etp-lb-"
+
+	// per IP family maps and sets, suffixed with the family name
+	nftNodePortsMap      = "nodeports"        // proto . nodePort : clusterIP . port
+	nftETPNodePortsMap   = "etp-nodeports"    // proto . nodePort : masqueradeIP . nodePort
+	nftExternalIPsMap    = "external-ips"     // externalIP . proto . port : clusterIP . port
+	nftETPExternalIPsMap = "etp-external-ips" // externalIP . proto . port : masqueradeIP . nodePort
+	nftETPLBChainsMap    = "etp-lb-chains"    // externalIP . proto . port : goto etp-lb-<hash>
+	nftITPRedirectMap    = "itp-redirect"     // clusterIP . proto . port : targetPort
+	nftITPMarkSet        = "itp-mark"         // clusterIP . proto . port
+)
+
+// nftIPFamily describes how an IP family is matched in the inet family tables
+type nftIPFamily struct {
+	// name suffixes the per family maps and sets
+	name string
+	// proto is the network header payload protocol
+	proto string
+	// addrType is the nftables type of the family addresses
+	addrType string
+}
+
+var (
+	nftIPv4 = nftIPFamily{name: "ipv4", proto: "ip", addrType: "ipv4_addr"}
+	nftIPv6 = nftIPFamily{name: "ipv6", proto: "ip6", addrType: "ipv6_addr"}
+)
+
+// clusterNFTIPFamilies returns the IP families enabled in the cluster
+func clusterNFTIPFamilies() []nftIPFamily {
+	var families []nftIPFamily
+	if config.IPv4Mode {
+		families = append(families, nftIPv4)
+	}
+	if config.IPv6Mode {
+		families = append(families, nftIPv6)
+	}
+	return families
+}
+
+// getNFTIPFamily returns the IP family of the provided IP string
+func getNFTIPFamily(ip string) nftIPFamily {
+	if utilnet.IsIPv6String(ip) {
+		return nftIPv6
+	}
+	return nftIPv4
+}
+
+func (f nftIPFamily) set(name string) string {
+	return name + "-" + f.name
+}
+
+func nftProtocol(protocol kapi.Protocol) string {
+	return strings.ToLower(string(protocol))
+}
+
+// getServiceNFTSets returns the maps and sets holding the service rules of the IP family
+func getServiceNFTSets(f nftIPFamily) []*util.NFTSet {
+	nodePortKey := "inet_proto . inet_service"
+	vipKey := f.addrType + " . inet_proto . inet_service"
+	target := f.addrType + " . inet_service"
+	return []*util.NFTSet{
+		{Name: f.set(nftNodePortsMap), KeyType: nodePortKey, ValueType: target},
+		{Name: f.set(nftETPNodePortsMap), KeyType: nodePortKey, ValueType: target},
+		{Name: f.set(nftExternalIPsMap), KeyType: vipKey, ValueType: target},
+		{Name: f.set(nftETPExternalIPsMap), KeyType: vipKey, ValueType: target},
+		{Name: f.set(nftETPLBChainsMap), KeyType: vipKey, ValueType: "verdict"},
+		{Name: f.set(nftITPRedirectMap), KeyType: vipKey, ValueType: "inet_service"},
+		{Name: f.set(nftITPMarkSet), KeyType: vipKey},
+	}
+}
+
+// getServiceNFTSet returns the service map or set with the given name
+func getServiceNFTSet(name string) *util.NFTSet {
+	for _, f := range []nftIPFamily{nftIPv4, nftIPv6} {
+		for _, set := range getServiceNFTSets(f) {
+			if set.Name == name {
+				return set
+			}
+		}
+	}
+	return &util.NFTSet{Name: name}
+}
+
+// getServiceNFTRules returns the rules looking up the service maps and sets of the IP family.
+// (NOTE: Order is important, ETP=local lookups must happen before the NodePort/ExternalIP ones)
+func getServiceNFTRules(f nftIPFamily) []*util.NFTRule {
+	vip := fmt.Sprintf("%s daddr . meta l4proto . th dport", f.proto)
+	nodePortDNAT := fmt.Sprintf("fib daddr type local meta nfproto %s dnat %s to meta l4proto . th dport map @%%s", f.name, f.proto)
+	vipDNAT := fmt.Sprintf("dnat %s to %s map @%%s", f.proto, vip)
+	return []*util.NFTRule{
+		// ETP chain only meant for external traffic
+		{Chain: nftServicePreroutingChain, Rule: fmt.Sprintf(nodePortDNAT, f.set(nftETPNodePortsMap))},
+		{Chain: nftServicePreroutingChain, Rule: fmt.Sprintf("%s vmap @%s", vip, f.set(nftETPLBChainsMap))},
+		{Chain: nftServicePreroutingChain, Rule: fmt.Sprintf(vipDNAT, f.set(nftETPExternalIPsMap))},
+		{Chain: nftServicePreroutingChain, Rule: fmt.Sprintf(nodePortDNAT, f.set(nftNodePortsMap))},
+		{Chain: nftServicePreroutingChain, Rule: fmt.Sprintf(vipDNAT, f.set(nftExternalIPsMap))},
+		{Chain: nftServiceOutputChain, Rule: fmt.Sprintf(nodePortDNAT, f.set(nftNodePortsMap))},
+		{Chain: nftServiceOutputChain, Rule: fmt.Sprintf(vipDNAT, f.set(nftExternalIPsMap))},
+		{Chain: nftServiceOutputChain, Rule: fmt.Sprintf("meta nfproto %s redirect to : %s map @%s", f.name, vip, f.set(nftITPRedirectMap))},
+		{Chain: nftServiceMarkChain, Rule: fmt.Sprintf("%s @%s meta mark set %s", vip, f.set(nftITPMarkSet), ovnkubeITPMark)},
+	}
+}
+
+// addServiceNFTBaseObjects adds the table, chains, maps and sets and the rules looking them up
+// to the transaction. Base chains are flushed first as adding a rule is not idempotent.
+func addServiceNFTBaseObjects(tx *util.NFTTransaction) {
+	tx.Add(&util.NFTTable{})
+	for _, chain := range []*util.NFTChain{
+		{Name: nftServicePreroutingChain, Type: "nat", Hook: "prerouting", Priority: "dstnat"},
+		{Name: nftServiceOutputChain, Type: "nat", Hook: "output", Priority: "dstnat"},
+		{Name: nftServiceMarkChain, Type: "route", Hook: "output", Priority: "mangle"},
+	} {
+		tx.Add(chain)
+		tx.Flush(chain)
+	}
+	for _, f := range clusterNFTIPFamilies() {
+		for _, set := range getServiceNFTSets(f) {
+			tx.Add(set)
+		}
+		for _, rule := range getServiceNFTRules(f) {
+			tx.Add(rule)
+		}
+	}
+}
+
+// nftLBChain is a chain DNAT-ing the traffic of an ETP=local service without NodePort
+// to its local endpoints, picked at random
+type nftLBChain struct {
+	chain *util.NFTChain
+	// rule is nil when there are no local endpoints
+	rule *util.NFTRule
+}
+
+// nftServiceObjects are the nftables objects programmed for a service
+type nftServiceObjects struct {
+	elements []*util.NFTElement
+	lbChains []*nftLBChain
+}
+
+// getNFTLBChainName returns the name of the chain of the service port for the external IP. It
+// depends on the service so that services claiming the same external IP and port do not share it.
+func getNFTLBChainName(service *kapi.Service, externalIP string, svcPort kapi.ServicePort) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(fmt.Sprintf("%s/%s/%s/%s/%d", service.Namespace, service.Name, externalIP, svcPort.Protocol, svcPort.Port)))
+	return fmt.Sprintf("%s%016x", nftETPLBChainPrefix, h.Sum64())
+}
+
+// getNFTLBChain is the nftables counterpart of generateIPTRulesForLoadBalancersWithoutNodePorts
+func getNFTLBChain(service *kapi.Service, svcPort kapi.ServicePort, externalIP string, localEndpoints []string) *nftLBChain {
+	f := getNFTIPFamily(externalIP)
+	lbChain := &nftLBChain{chain: &util.NFTChain{Name: getNFTLBChainName(service, externalIP, svcPort)}}
+	var targets []string
+	for _, ep := range localEndpoints {
+		if getNFTIPFamily(ep) == f {
+			targets = append(targets, fmt.Sprintf("%d : %s . %d", len(targets), ep, int32(svcPort.TargetPort.IntValue())))
+		}
+	}
+	if len(targets) > 0 {
+		lbChain.rule = &util.NFTRule{
+			Chain: lbChain.chain.Name,
+			Rule: fmt.Sprintf("meta nfproto %s dnat %s to numgen random mod %d map { %s }",
+				f.name, f.proto, len(targets), strings.Join(targets, ", ")),
+		}
+	}
+	return lbChain
+}
+
+// getGatewayNFTObjects returns the map and set elements and chains of the service. It programs the
+// same cases as getGatewayIPTRules, to which the function description applies.
+func getGatewayNFTObjects(service *kapi.Service, localEndpoints []string, svcHasLocalHostNetEndPnt bool) *nftServiceObjects {
+	objects := &nftServiceObjects{}
+	addElement := func(f nftIPFamily, set string, key []string, value ...string) {
+		objects.elements = append(objects.elements, &util.NFTElement{Set: f.set(set), Key: key, Value: value})
+	}
+	clusterIPs := util.GetClusterIPs(service)
+	svcTypeIsETPLocal := util.ServiceExternalTrafficPolicyLocal(service)
+	svcTypeIsITPLocal := util.ServiceInternalTrafficPolicyLocal(service)
+	for _, svcPort := range service.Spec.Ports {
+		proto := nftProtocol(svcPort.Protocol)
+		port := fmt.Sprintf("%d", svcPort.Port)
+		nodePort := fmt.Sprintf("%d", svcPort.NodePort)
+		if util.ServiceTypeHasNodePort(service) {
+			err := util.ValidatePort(svcPort.Protocol, svcPort.NodePort)
+			if err != nil {
+				klog.Errorf("Skipping service: %s, invalid service NodePort: %v", svcPort.Name, err)
+				continue
+			}
+			err = util.ValidatePort(svcPort.Protocol, svcPort.Port)
+			if err != nil {
+				klog.Errorf("Skipping service: %s, invalid service port %v", svcPort.Name, err)
+				continue
+			}
+			for _, clusterIP := range clusterIPs {
+				f := getNFTIPFamily(clusterIP)
+				if svcTypeIsETPLocal && !svcHasLocalHostNetEndPnt && config.Gateway.Mode == config.GatewayModeLocal {
+					// case1: DNAT to masqueradeIP:nodePort takes priority over DNAT to clusterIP
+					addElement(f, nftETPNodePortsMap, []string{proto, nodePort}, getMasqueradeVIP(clusterIP), nodePort)
+				}
+				// case2
+				addElement(f, nftNodePortsMap, []string{proto, nodePort}, clusterIP, port)
+			}
+		}
+
+		for _, externalIP := range util.GetExternalAndLBIPs(service) {
+			err := util.ValidatePort(svcPort.Protocol, svcPort.Port)
+			if err != nil {
+				klog.Errorf("Skipping service: %s, invalid service port %v", svcPort.Name, err)
+				continue
+			}
+			clusterIP, err := util.MatchIPStringFamily(utilnet.IsIPv6String(externalIP), clusterIPs)
+			if err != nil {
+				continue
+			}
+			f := getNFTIPFamily(externalIP)
+			key := []string{externalIP, proto, port}
+			if svcTypeIsETPLocal && !svcHasLocalHostNetEndPnt {
+				// case1
+				if !util.ServiceTypeHasNodePort(service) {
+					lbChain := getNFTLBChain(service, svcPort, externalIP, localEndpoints)
+					objects.lbChains = append(objects.lbChains, lbChain)
+					addElement(f, nftETPLBChainsMap, key, "goto "+lbChain.chain.Name)
+				} else {
+					addElement(f, nftETPExternalIPsMap, key, getMasqueradeVIP(externalIP), nodePort)
+				}
+			}
+			// case2
+			addElement(f, nftExternalIPsMap, key, clusterIP, port)
+		}
+
+		if svcTypeIsITPLocal {
+			// case3
+			for _, clusterIP := range clusterIPs {
+				f := getNFTIPFamily(clusterIP)
+				key := []string{clusterIP, proto, port}
+				if svcHasLocalHostNetEndPnt {
+					addElement(f, nftITPRedirectMap, key, fmt.Sprintf("%d", int32(svcPort.TargetPort.IntValue())))
+				} else {
+					addElement(f, nftITPMarkSet, key)
+				}
+			}
+		}
+	}
+	return objects
+}
+
+// getServiceMgmtPortIPTRules returns the rules preventing the SNAT of ETP=local service traffic to the
+// management port. Those stay in iptables, along with the management port SNAT rule they bypass.
+func getServiceMgmtPortIPTRules(service *kapi.Service, localEndpoints []string, svcHasLocalHostNetEndPnt bool) []nodeipt.Rule {
+	var rules []nodeipt.Rule
+	for _, rule := range getGatewayIPTRules(service, localEndpoints, svcHasLocalHostNetEndPnt) {
+		if rule.Chain == iptableMgmPortChain {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// addServiceNFTObjects adds the objects of the service to the transaction
+func addServiceNFTObjects(tx *util.NFTTransaction, objects *nftServiceObjects) {
+	// chains must exist before map elements jump to them
+	for _, lbChain := range objects.lbChains {
+		tx.Add(lbChain.chain)
+		tx.Flush(lbChain.chain)
+		if lbChain.rule != nil {
+			tx.Add(lbChain.rule)
+		}
+	}
+	for _, element := range objects.elements {
+		tx.Add(element)
+	}
+}
+
+// skipClaimedNFTElements removes from the objects of the service the map and set elements whose
+// key is already programmed with another value, along with the chains only those elements use
+func skipClaimedNFTElements(service *kapi.Service, objects *nftServiceObjects) error {
+	nft, err := util.GetNFTablesHelper(nftServicesTable)
+	if err != nil {
+		return err
+	}
+	programmed := map[string]map[string]string{}
+	skippedChains := sets.New[string]()
+	var keep []*util.NFTElement
+	for _, element := range objects.elements {
+		elements, listed := programmed[element.Set]
+		if !listed {
+			elements, err = nft.ListSetElements(getServiceNFTSet(element.Set))
+			if err != nil {
+				return err
+			}
+			programmed[element.Set] = elements
+		}
+		key := strings.Join(element.Key, " . ")
+		value := strings.Join(element.Value, " . ")
+		if existing, found := elements[key]; found && existing != value {
+			klog.Warningf("Skipping nftables element %s/%s of service %s/%s as it is already used",
+				element.Set, key, service.Namespace, service.Name)
+			if strings.HasPrefix(value, "goto ") {
+				skippedChains.Insert(strings.TrimPrefix(value, "goto "))
+			}
+			continue
+		}
+		keep = append(keep, element)
+	}
+	objects.elements = keep
+	var keepLBChains []*nftLBChain
+	for _, lbChain := range objects.lbChains {
+		if !skippedChains.Has(lbChain.chain.Name) {
+			keepLBChains = append(keepLBChains, lbChain)
+		}
+	}
+	objects.lbChains = keepLBChains
+	return nil
+}
+
+// nftablesServiceRuleManager programs the service rules with nftables maps and sets looked up
+// by a fixed set of rules, so that adding or removing a service only updates map elements
+type nftablesServiceRuleManager struct{}
+
+func (m *nftablesServiceRuleManager) run(tx *util.NFTTransaction) error {
+	nft, err := util.GetNFTablesHelper(nftServicesTable)
+	if err != nil {
+		return err
+	}
+	return nft.Run(tx)
+}
+
+func (m *nftablesServiceRuleManager) initRules() error {
+	// remove the iptables chains that a previous run with the iptables backend might have left behind
+	cleanupServiceIPTChains()
+	tx := util.NewNFTTransaction()
+	addServiceNFTBaseObjects(tx)
+	if err := m.run(tx); err != nil {
+		return fmt.Errorf("failed to initialize nftables service rules: %v", err)
+	}
+	return nil
+}
+
+// addServiceRules skips the map and set elements already programmed with other values: like in
+// syncServiceRules, a key is only programmed for the first service claiming it
+func (m *nftablesServiceRuleManager) addServiceRules(service *kapi.Service, localEndpoints []string, svcHasLocalHostNetEndPnt bool) error {
+	var errors []error
+	objects := getGatewayNFTObjects(service, localEndpoints, svcHasLocalHostNetEndPnt)
+	if err := skipClaimedNFTElements(service, objects); err != nil {
+		errors = append(errors, err)
+	} else {
+		tx := util.NewNFTTransaction()
+		addServiceNFTObjects(tx, objects)
+		if err := m.run(tx); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	if err := insertIptRules(getServiceMgmtPortIPTRules(service, localEndpoints, svcHasLocalHostNetEndPnt)); err != nil {
+		errors = append(errors, err)
+	}
+	return apierrors.NewAggregate(errors)
+}
+
+// deleteServiceRules only removes the map and set elements still holding the values of the
+// service, as a key claimed by several services might be programmed for another one
+func (m *nftablesServiceRuleManager) deleteServiceRules(service *kapi.Service, localEndpoints []string) error {
+	var errors []error
+	nft, err := util.GetNFTablesHelper(nftServicesTable)
+	if err != nil {
+		return err
+	}
+	tx := util.NewNFTTransaction()
+	programmed := map[string]map[string]string{}
+	removed := sets.New[string]()
+	var lbChains []*nftLBChain
+	for _, svcHasLocalHostNetEndPnt := range []bool{true, false} {
+		objects := getGatewayNFTObjects(service, localEndpoints, svcHasLocalHostNetEndPnt)
+		for _, element := range objects.elements {
+			elements, listed := programmed[element.Set]
+			if !listed {
+				elements, err = nft.ListSetElements(getServiceNFTSet(element.Set))
+				if err != nil {
+					errors = append(errors, err)
+				}
+				programmed[element.Set] = elements
+			}
+			key := strings.Join(element.Key, " . ")
+			value, found := elements[key]
+			if !found || value != strings.Join(element.Value, " . ") || removed.Has(element.Set+"/"+key) {
+				continue
+			}
+			removed.Insert(element.Set + "/" + key)
+			tx.Delete(element)
+		}
+		lbChains = append(lbChains, objects.lbChains...)
+	}
+	// chains are named after the service, they can be deleted once its map elements are
+	for _, lbChain := range lbChains {
+		if removed.Has(lbChain.chain.Name) {
+			continue
+		}
+		removed.Insert(lbChain.chain.Name)
+		tx.Remove(lbChain.chain)
+	}
+	if err := m.run(tx); err != nil {
+		errors = append(errors, err)
+	}
+	for _, svcHasLocalHostNetEndPnt := range []bool{true, false} {
+		if err := nodeipt.DelRules(getServiceMgmtPortIPTRules(service, localEndpoints, svcHasLocalHostNetEndPnt)); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	return apierrors.NewAggregate(errors)
+}
+
+func (m *nftablesServiceRuleManager) syncServiceRules(services []*serviceConfig) error {
+	var errors []error
+	// the table is recreated in the same transaction, dropping all stale objects atomically
+	tx := util.NewNFTTransaction()
+	tx.Remove(&util.NFTTable{})
+	addServiceNFTBaseObjects(tx)
+	keepIPTRules := []nodeipt.Rule{}
+	elements := map[string]*util.NFTElement{}
+	for _, svcConfig := range services {
+		localEndpoints := sets.List(svcConfig.localEndpoints)
+		objects := getGatewayNFTObjects(svcConfig.service, localEndpoints, svcConfig.hasLocalHostNetworkEp)
+		// a key can only be programmed once, keep the first service claiming it
+		var keep []*util.NFTElement
+		for _, element := range objects.elements {
+			id := element.Set + "/" + strings.Join(element.Key, " . ")
+			if existing, found := elements[id]; found {
+				if strings.Join(existing.Value, " . ") != strings.Join(element.Value, " . ") {
+					klog.Warningf("Skipping nftables element %s of service %s/%s as it is already used",
+						id, svcConfig.service.Namespace, svcConfig.service.Name)
+				}
+				continue
+			}
+			elements[id] = element
+			keep = append(keep, element)
+		}
+		objects.elements = keep
+		var keepLBChains []*nftLBChain
+		for _, lbChain := range objects.lbChains {
+			if _, found := elements[lbChain.chain.Name]; !found {
+				elements[lbChain.chain.Name] = nil
+				keepLBChains = append(keepLBChains, lbChain)
+			}
+		}
+		objects.lbChains = keepLBChains
+		addServiceNFTObjects(tx, objects)
+		keepIPTRules = append(keepIPTRules, getServiceMgmtPortIPTRules(svcConfig.service, localEndpoints, svcConfig.hasLocalHostNetworkEp)...)
+	}
+	if err := m.run(tx); err != nil {
+		errors = append(errors, err)
+	}
+	if err := recreateIPTRules("nat", iptableMgmPortChain, keepIPTRules); err != nil {
+		errors = append(errors, err)
+	}
+	return apierrors.NewAggregate(errors)
+}
+
+func (m *nftablesServiceRuleManager) cleanup() {
+	tx := util.NewNFTTransaction()
+	tx.Remove(&util.NFTTable{})
+	if err := m.run(tx); err != nil {
+		klog.Errorf("Failed to delete nftables table %s: %v", nftServicesTable, err)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"math"
 	"net"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 
@@ -13,7 +14,6 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/controllers/egressservice"
-	nodeipt "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/iptables"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/routemanager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
@@ -45,6 +45,9 @@ const (
 	ctMarkOVN = "0x1"
 	// ctMarkHost is the conntrack mark value for host traffic
 	ctMarkHost = "0x2"
+	// ctMarkEgressNetworkBase is the base conntrack mark value for the traffic
+	// of secondary network gateways, offset by the network ID
+	ctMarkEgressNetworkBase = 0x1000
 	// ovnkubeITPMark is the fwmark used for host->ITP=local svc traffic. Note that the fwmark is not a part
 	// of the packet, but just stored by kernel in its memory to track/filter packet. Hence fwmark is lost as
 	// soon as packet exits the host.
@@ -59,11 +62,24 @@ const (
 
 // nodePortWatcherIptables manages iptables rules for shared gateway
 // to ensure that services using NodePorts are accessible.
+// It is used on DPU hosts, where the service OpenFlows are programmed on the DPU.
 type nodePortWatcherIptables struct {
+	nodeName string
+	// nodeIPs are the host addresses, used to detect host-networked endpoints
+	nodeIPs      []net.IP
+	watchFactory factory.NodeWatchFactory
+	// Map of service name to programmed iptables rules
+	serviceInfo     map[ktypes.NamespacedName]*serviceConfig
+	serviceInfoLock sync.Mutex
 }
 
-func newNodePortWatcherIptables() *nodePortWatcherIptables {
-	return &nodePortWatcherIptables{}
+func newNodePortWatcherIptables(nodeName string, nodeIPs []net.IP, watchFactory factory.NodeWatchFactory) *nodePortWatcherIptables {
+	return &nodePortWatcherIptables{
+		nodeName:     nodeName,
+		nodeIPs:      nodeIPs,
+		watchFactory: watchFactory,
+		serviceInfo:  make(map[ktypes.NamespacedName]*serviceConfig),
+	}
 }
 
 // nodePortWatcher manages OpenFlow and iptables rules
@@ -75,7 +91,9 @@ type nodePortWatcher struct {
 	gatewayIPLock sync.Mutex
 	ofportPhys    string
 	ofportPatch   string
-	gwBridge      string
+	// ofportHost is the port towards the host, i.e the host representor port in DPU mode and LOCAL otherwise
+	ofportHost string
+	gwBridge   string
 	// Map of service name to programmed iptables/OF rules
 	serviceInfo     map[ktypes.NamespacedName]*serviceConfig
 	serviceInfoLock sync.Mutex
@@ -192,11 +210,11 @@ func (npw *nodePortWatcher) updateServiceFlowCache(service *kapi.Service, add, h
 					nodeportFlows = append(nodeportFlows,
 						// table 6, Sends the packet to the host. Note that the constant etp svc cookie is used since this flow would be
 						// same for all such services.
-						fmt.Sprintf("cookie=%s, priority=110, table=6, actions=output:LOCAL",
-							etpSvcOpenFlowCookie),
+						fmt.Sprintf("cookie=%s, priority=110, table=6, actions=output:%s",
+							etpSvcOpenFlowCookie, npw.ofportHost),
 						// table 0, Matches on return traffic, i.e traffic coming from the host networked pod's port, and unDNATs
-						fmt.Sprintf("cookie=%s, priority=110, in_port=LOCAL, %s, tp_src=%s, actions=ct(zone=%d nat,table=7)",
-							cookie, flowProtocol, svcPort.TargetPort.String(), config.Default.HostNodePortConntrackZone),
+						fmt.Sprintf("cookie=%s, priority=110, in_port=%s, %s, tp_src=%s, actions=ct(zone=%d nat,table=7)",
+							cookie, npw.ofportHost, flowProtocol, svcPort.TargetPort.String(), config.Default.HostNodePortConntrackZone),
 						// table 7, Sends the packet back out eth0 to the external client. Note that the constant etp svc
 						// cookie is used since this would be same for all such services.
 						fmt.Sprintf("cookie=%s, priority=110, table=7, "+
@@ -312,11 +330,11 @@ func (npw *nodePortWatcher) createLbAndExternalSvcFlows(service *kapi.Service, s
 		externalIPFlows = append(externalIPFlows,
 			// table 6, Sends the packet to Host. Note that the constant etp svc cookie is used since this flow would be
 			// same for all such services.
-			fmt.Sprintf("cookie=%s, priority=110, table=6, actions=output:LOCAL",
-				etpSvcOpenFlowCookie),
+			fmt.Sprintf("cookie=%s, priority=110, table=6, actions=output:%s",
+				etpSvcOpenFlowCookie, npw.ofportHost),
 			// table 0, Matches on return traffic, i.e traffic coming from the host networked pod's port, and unDNATs
-			fmt.Sprintf("cookie=%s, priority=110, in_port=LOCAL, %s, tp_src=%s, actions=ct(commit,zone=%d nat,table=7)",
-				cookie, flowProtocol, svcPort.TargetPort.String(), config.Default.HostNodePortConntrackZone),
+			fmt.Sprintf("cookie=%s, priority=110, in_port=%s, %s, tp_src=%s, actions=ct(commit,zone=%d nat,table=7)",
+				cookie, npw.ofportHost, flowProtocol, svcPort.TargetPort.String(), config.Default.HostNodePortConntrackZone),
 			// table 7, Sends the reply packet back out eth0 to the external client. Note that the constant etp svc
 			// cookie is used since this would be same for all such services.
 			fmt.Sprintf("cookie=%s, priority=110, table=7, actions=output:%s",
@@ -484,13 +502,13 @@ func addServiceRules(service *kapi.Service, localEndpoints []string, svcHasLocal
 		npw.ofm.requestFlowSync()
 		if !npw.dpuMode {
 			// add iptable rules only in full mode
-			if err = insertIptRules(getGatewayIPTRules(service, localEndpoints, svcHasLocalHostNetEndPnt)); err != nil {
+			if err = newServiceRuleManager().addServiceRules(service, localEndpoints, svcHasLocalHostNetEndPnt); err != nil {
 				errors = append(errors, fmt.Errorf("failed to add iptables rules for service: %v", err))
 			}
 		}
 	} else {
 		// For Host Only Mode
-		if err = insertIptRules(getGatewayIPTRules(service, localEndpoints, svcHasLocalHostNetEndPnt)); err != nil {
+		if err = newServiceRuleManager().addServiceRules(service, localEndpoints, svcHasLocalHostNetEndPnt); err != nil {
 			errors = append(errors, fmt.Errorf("failed to add iptables rules for service: %v", err))
 		}
 
@@ -537,20 +555,13 @@ func delServiceRules(service *kapi.Service, localEndpoints []string, npw *nodePo
 			// |                          |                       |                       |   + default dnat towards CIP   |
 			// +--------------------------+-----------------------+-----------------------+--------------------------------+
 
-			if err = nodeipt.DelRules(getGatewayIPTRules(service, localEndpoints, true)); err != nil {
-				errors = append(errors, fmt.Errorf("error updating service flow cache: %v", err))
-			}
-			if err = nodeipt.DelRules(getGatewayIPTRules(service, localEndpoints, false)); err != nil {
-				errors = append(errors, fmt.Errorf("error updating service flow cache: %v", err))
+			if err = newServiceRuleManager().deleteServiceRules(service, localEndpoints); err != nil {
+				errors = append(errors, fmt.Errorf("error deleting service rules: %v", err))
 			}
 		}
 	} else {
-
-		if err = nodeipt.DelRules(getGatewayIPTRules(service, localEndpoints, true)); err != nil {
-			errors = append(errors, fmt.Errorf("error updating service flow cache: %v", err))
-		}
-		if err = nodeipt.DelRules(getGatewayIPTRules(service, localEndpoints, false)); err != nil {
-			errors = append(errors, fmt.Errorf("error updating service flow cache: %v", err))
+		if err = newServiceRuleManager().deleteServiceRules(service, localEndpoints); err != nil {
+			errors = append(errors, fmt.Errorf("error deleting service rules: %v", err))
 		}
 	}
 	return apierrors.NewAggregate(errors)
@@ -729,7 +740,7 @@ func (npw *nodePortWatcher) DeleteService(service *kapi.Service) error {
 func (npw *nodePortWatcher) SyncServices(services []interface{}) error {
 	var err error
 	var errors []error
-	keepIPTRules := []nodeipt.Rule{}
+	keepServices := []*serviceConfig{}
 	for _, serviceInterface := range services {
 		name := ktypes.NamespacedName{Namespace: serviceInterface.(*kapi.Service).Namespace, Name: serviceInterface.(*kapi.Service).Name}
 
@@ -767,7 +778,7 @@ func (npw *nodePortWatcher) SyncServices(services []interface{}) error {
 		}
 		// Add correct iptables rules only for Full mode
 		if !npw.dpuMode {
-			keepIPTRules = append(keepIPTRules, getGatewayIPTRules(service, sets.List(localEndpoints), hasLocalHostNetworkEp)...)
+			keepServices = append(keepServices, &serviceConfig{service: service, hasLocalHostNetworkEp: hasLocalHostNetworkEp, localEndpoints: localEndpoints})
 		}
 	}
 
@@ -775,13 +786,13 @@ func (npw *nodePortWatcher) SyncServices(services []interface{}) error {
 	npw.ofm.requestFlowSync()
 	// sync IPtables rules once only for Full mode
 	if !npw.dpuMode {
-		// (NOTE: Order is important, add jump to iptableETPChain before jump to NP/EIP chains)
-		for _, chain := range []string{iptableITPChain, egressservice.Chain, iptableNodePortChain, iptableExternalIPChain, iptableETPChain, iptableMgmPortChain} {
-			if err = recreateIPTRules("nat", chain, keepIPTRules); err != nil {
+		if config.Gateway.RuleBackend == config.GatewayRuleBackendIPTables {
+			// stale egress service rules are dropped here, the egress service controller repairs its chain on startup
+			if err = recreateIPTRules("nat", egressservice.Chain, nil); err != nil {
 				errors = append(errors, err)
 			}
 		}
-		if err = recreateIPTRules("mangle", iptableITPChain, keepIPTRules); err != nil {
+		if err = newServiceRuleManager().syncServiceRules(keepServices); err != nil {
 			errors = append(errors, err)
 		}
 	}
@@ -980,12 +991,74 @@ func (npw *nodePortWatcher) UpdateEndpointSlice(oldEpSlice, newEpSlice *discover
 	return apierrors.NewAggregate(errors)
 }
 
+// getLocalEndpoints returns the eligible endpoints of the service that are local to this node and
+// whether at least one of them is host-networked.
+func (npwipt *nodePortWatcherIptables) getLocalEndpoints(service *kapi.Service) (sets.Set[string], bool, error) {
+	epSlices, err := npwipt.watchFactory.GetEndpointSlices(service.Namespace, service.Name)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return nil, false, fmt.Errorf("error retrieving all endpointslices for service %s/%s: %w",
+				service.Namespace, service.Name, err)
+		}
+		klog.V(5).Infof("No endpointslice found for service %s in namespace %s", service.Name, service.Namespace)
+		return nil, false, nil
+	}
+	localEndpoints := util.GetLocalEligibleEndpointAddresses(epSlices, service, npwipt.nodeName)
+	return localEndpoints, util.HasLocalHostNetworkEndpoints(localEndpoints, npwipt.nodeIPs), nil
+}
+
+// syncServiceRules programs the iptables rules of the service for its current local endpoints. Previously
+// programmed rules are only replaced when they differ from the ones required now.
+func (npwipt *nodePortWatcherIptables) syncServiceRules(service *kapi.Service) error {
+	localEndpoints, hasLocalHostNetworkEp, err := npwipt.getLocalEndpoints(service)
+	if err != nil {
+		return err
+	}
+	name := ktypes.NamespacedName{Namespace: service.Namespace, Name: service.Name}
+
+	npwipt.serviceInfoLock.Lock()
+	defer npwipt.serviceInfoLock.Unlock()
+	if old, exists := npwipt.serviceInfo[name]; exists {
+		if reflect.DeepEqual(getGatewayIPTRules(old.service, sets.List(old.localEndpoints), old.hasLocalHostNetworkEp),
+			getGatewayIPTRules(service, sets.List(localEndpoints), hasLocalHostNetworkEp)) {
+			old.service = service
+			return nil
+		}
+		if err = delServiceRules(old.service, sets.List(old.localEndpoints), nil); err != nil {
+			return err
+		}
+		delete(npwipt.serviceInfo, name)
+	}
+	if err = addServiceRules(service, sets.List(localEndpoints), hasLocalHostNetworkEp, nil); err != nil {
+		return err
+	}
+	npwipt.serviceInfo[name] = &serviceConfig{service: service, hasLocalHostNetworkEp: hasLocalHostNetworkEp, localEndpoints: localEndpoints}
+	return nil
+}
+
+// deleteServiceRules removes the iptables rules programmed for the service
+func (npwipt *nodePortWatcherIptables) deleteServiceRules(service *kapi.Service) error {
+	name := ktypes.NamespacedName{Namespace: service.Namespace, Name: service.Name}
+
+	npwipt.serviceInfoLock.Lock()
+	defer npwipt.serviceInfoLock.Unlock()
+	svcConfig, exists := npwipt.serviceInfo[name]
+	if !exists {
+		return delServiceRules(service, nil, nil)
+	}
+	if err := delServiceRules(svcConfig.service, sets.List(svcConfig.localEndpoints), nil); err != nil {
+		return err
+	}
+	delete(npwipt.serviceInfo, name)
+	return nil
+}
+
 func (npwipt *nodePortWatcherIptables) AddService(service *kapi.Service) error {
 	// don't process headless service or services that doesn't have NodePorts or ExternalIPs
 	if !util.ServiceTypeHasClusterIP(service) || !util.IsClusterIPSet(service) {
 		return nil
 	}
-	if err := addServiceRules(service, nil, false, nil); err != nil {
+	if err := npwipt.syncServiceRules(service); err != nil {
 		return fmt.Errorf("AddService failed for nodePortWatcherIptables: %v", err)
 	}
 	return nil
@@ -993,26 +1066,19 @@ func (npwipt *nodePortWatcherIptables) AddService(service *kapi.Service) error {
 
 func (npwipt *nodePortWatcherIptables) UpdateService(old, new *kapi.Service) error {
 	var err error
-	var errors []error
 	if serviceUpdateNotNeeded(old, new) {
 		klog.V(5).Infof("Skipping service update for: %s as change does not apply to "+
 			"any of .Spec.Ports, .Spec.ExternalIP, .Spec.ClusterIP, .Spec.ClusterIPs,"+
-			" .Spec.Type, .Status.LoadBalancer.Ingress", new.Name)
+			" .Spec.Type, .Status.LoadBalancer.Ingress, .Spec.ExternalTrafficPolicy, .Spec.InternalTrafficPolicy", new.Name)
 		return nil
 	}
 
-	if util.ServiceTypeHasClusterIP(old) && util.IsClusterIPSet(old) {
-		if err = delServiceRules(old, nil, nil); err != nil {
-			errors = append(errors, err)
-		}
-	}
-
 	if util.ServiceTypeHasClusterIP(new) && util.IsClusterIPSet(new) {
-		if err = addServiceRules(new, nil, false, nil); err != nil {
-			errors = append(errors, err)
-		}
+		err = npwipt.syncServiceRules(new)
+	} else if util.ServiceTypeHasClusterIP(old) && util.IsClusterIPSet(old) {
+		err = npwipt.deleteServiceRules(old)
 	}
-	if err = apierrors.NewAggregate(errors); err != nil {
+	if err != nil {
 		return fmt.Errorf("UpdateService failed for nodePortWatcherIptables: %v", err)
 	}
 	return nil
@@ -1024,16 +1090,16 @@ func (npwipt *nodePortWatcherIptables) DeleteService(service *kapi.Service) erro
 	if !util.ServiceTypeHasClusterIP(service) || !util.IsClusterIPSet(service) {
 		return nil
 	}
-	if err := delServiceRules(service, nil, nil); err != nil {
+	if err := npwipt.deleteServiceRules(service); err != nil {
 		return fmt.Errorf("DeleteService failed for nodePortWatcherIptables: %v", err)
 	}
 	return nil
 }
 
 func (npwipt *nodePortWatcherIptables) SyncServices(services []interface{}) error {
-	var err error
-	var errors []error
-	keepIPTRules := []nodeipt.Rule{}
+	keepServices := []*serviceConfig{}
+	npwipt.serviceInfoLock.Lock()
+	defer npwipt.serviceInfoLock.Unlock()
 	for _, serviceInterface := range services {
 		service, ok := serviceInterface.(*kapi.Service)
 		if !ok {
@@ -1045,18 +1111,53 @@ func (npwipt *nodePortWatcherIptables) SyncServices(services []interface{}) erro
 		if !util.ServiceTypeHasClusterIP(service) || !util.IsClusterIPSet(service) {
 			continue
 		}
+		localEndpoints, hasLocalHostNetworkEp, err := npwipt.getLocalEndpoints(service)
+		if err != nil {
+			return fmt.Errorf("error during SyncServices: %w", err)
+		}
+		name := ktypes.NamespacedName{Namespace: service.Namespace, Name: service.Name}
+		npwipt.serviceInfo[name] = &serviceConfig{service: service, hasLocalHostNetworkEp: hasLocalHostNetworkEp, localEndpoints: localEndpoints}
 		// Add correct iptables rules.
-		// TODO: ETP and ITP is not implemented for smart NIC mode.
-		keepIPTRules = append(keepIPTRules, getGatewayIPTRules(service, nil, false)...)
+		keepServices = append(keepServices, npwipt.serviceInfo[name])
 	}
 
 	// sync IPtables rules once
-	for _, chain := range []string{iptableNodePortChain, iptableExternalIPChain} {
-		if err = recreateIPTRules("nat", chain, keepIPTRules); err != nil {
-			errors = append(errors, err)
+	return newServiceRuleManager().syncServiceRules(keepServices)
+}
+
+// syncEndpointSliceService reprograms the rules of the service backed by the endpointslice
+// as its local endpoints might have changed.
+func (npwipt *nodePortWatcherIptables) syncEndpointSliceService(epSlice *discovery.EndpointSlice) error {
+	namespacedName, err := util.ServiceNamespacedNameFromEndpointSlice(epSlice)
+	if err != nil {
+		return fmt.Errorf("cannot sync %s/%s in nodePortWatcherIptables: %v", epSlice.Namespace, epSlice.Name, err)
+	}
+	svc, err := npwipt.watchFactory.GetService(namespacedName.Namespace, namespacedName.Name)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return fmt.Errorf("error retrieving service %s/%s for endpointslice %s: %w",
+				namespacedName.Namespace, namespacedName.Name, epSlice.Name, err)
 		}
+		// This is not necessarily an error, the rules of a deleted service are removed on service delete
+		klog.V(5).Infof("No service found for endpointslice %s in namespace %s", epSlice.Name, epSlice.Namespace)
+		return nil
 	}
-	return apierrors.NewAggregate(errors)
+	if !util.ServiceTypeHasClusterIP(svc) || !util.IsClusterIPSet(svc) {
+		return nil
+	}
+	return npwipt.syncServiceRules(svc)
+}
+
+func (npwipt *nodePortWatcherIptables) AddEndpointSlice(epSlice *discovery.EndpointSlice) error {
+	return npwipt.syncEndpointSliceService(epSlice)
+}
+
+func (npwipt *nodePortWatcherIptables) UpdateEndpointSlice(oldEpSlice, newEpSlice *discovery.EndpointSlice) error {
+	return npwipt.syncEndpointSliceService(newEpSlice)
+}
+
+func (npwipt *nodePortWatcherIptables) DeleteEndpointSlice(epSlice *discovery.EndpointSlice) error {
+	return npwipt.syncEndpointSliceService(epSlice)
 }
 
 func flowsForDefaultBridge(bridge *bridgeConfiguration, extraIPs []net.IP) ([]string, error) {
@@ -1493,7 +1594,7 @@ func commonFlows(subnets []*net.IPNet, bridge *bridgeConfiguration) ([]string, e
 	// there is a chance that the pod traffic will reach the egress node before it configures the SNAT flows.
 	// Drop pod traffic that is not SNATed, excluding local pods(required for ICNIv2)
 	if config.OVNKubernetesFeature.EnableEgressIP {
-		for _, clusterEntry := range config.Default.ClusterSubnets {
+		for _, clusterEntry := range config.ClusterSubnets() {
 			cidr := clusterEntry.CIDR
 			ipPrefix := "ip"
 			if utilnet.IsIPv6CIDR(cidr) {
@@ -1526,7 +1627,7 @@ func commonFlows(subnets []*net.IPNet, bridge *bridgeConfiguration) ([]string, e
 
 		if config.Gateway.DisableSNATMultipleGWs {
 			// table 1, traffic to pod subnet go directly to OVN
-			for _, clusterEntry := range config.Default.ClusterSubnets {
+			for _, clusterEntry := range config.ClusterSubnets() {
 				cidr := clusterEntry.CIDR
 				var ipPrefix string
 				if utilnet.IsIPv6CIDR(cidr) {
@@ -1592,6 +1693,91 @@ func commonFlows(subnets []*net.IPNet, bridge *bridgeConfiguration) ([]string, e
 	return dftFlows, nil
 }
 
+// flowsForEgressNetworks generates the flows steering the traffic of the
+// gateway routers of secondary networks connected to the bridge. Egress traffic
+// is committed in the bridge conntrack zone with the network mark, SNATed again
+// to the node IP to resolve port clashes with the traffic of other networks,
+// and the return traffic is sent back to the network based on that mark.
+func flowsForEgressNetworks(bridge *bridgeConfiguration) ([]string, error) {
+	// CAUTION: when adding new flows where the in_port is ofPortPatch and the out_port is ofPortPhys, ensure
+	// that dl_src is included in match criteria!
+	ofPortPhys := bridge.ofPortPhys
+	if ofPortPhys == "" || len(bridge.egressNetworks) == 0 {
+		return nil, nil
+	}
+	bridgeMacAddress := bridge.macAddress.String()
+
+	netNames := make([]string, 0, len(bridge.egressNetworks))
+	for netName := range bridge.egressNetworks {
+		netNames = append(netNames, netName)
+	}
+	sort.Strings(netNames)
+
+	var flows []string
+	// ARP replies and neighbor advertisements to the shared MAC are sent to
+	// the gateway routers of all the networks
+	arpOutputs := []string{bridge.ofPortPatch, bridge.ofPortHost}
+	for _, netName := range netNames {
+		egressNetwork := bridge.egressNetworks[netName]
+		ofPortPatch := egressNetwork.ofPortPatch
+		arpOutputs = append(arpOutputs, ofPortPatch)
+
+		for _, ipFamily := range []struct {
+			enabled  bool
+			isIPv6   bool
+			ipPrefix string
+		}{
+			{config.IPv4Mode, false, "ip"},
+			{config.IPv6Mode, true, "ipv6"},
+		} {
+			if !ipFamily.enabled {
+				continue
+			}
+			physicalIP, err := util.MatchFirstIPNetFamily(ipFamily.isIPv6, bridge.ips)
+			if err != nil {
+				return nil, fmt.Errorf("unable to determine physical IP of host for network %s: %v", netName, err)
+			}
+			// table 0, packets coming from the network headed externally
+			flows = append(flows,
+				fmt.Sprintf("cookie=%s, priority=100, in_port=%s, dl_src=%s, %s, "+
+					"actions=ct(commit, zone=%d, nat(src=%s), exec(set_field:%s->ct_mark)), output:%s",
+					defaultOpenFlowCookie, ofPortPatch, bridgeMacAddress, ipFamily.ipPrefix,
+					config.Default.ConntrackZone, physicalIP.IP, egressNetwork.ctMark, ofPortPhys))
+			// table 1, established and related connections of the network go back to the network
+			flows = append(flows,
+				fmt.Sprintf("cookie=%s, priority=100, table=1, %s, ct_state=+trk+est, ct_mark=%s, "+
+					"actions=output:%s",
+					defaultOpenFlowCookie, ipFamily.ipPrefix, egressNetwork.ctMark, ofPortPatch))
+			flows = append(flows,
+				fmt.Sprintf("cookie=%s, priority=100, table=1, %s, ct_state=+trk+rel, ct_mark=%s, "+
+					"actions=output:%s",
+					defaultOpenFlowCookie, ipFamily.ipPrefix, egressNetwork.ctMark, ofPortPatch))
+		}
+
+		// table 0, non-IP packets coming from the network with the correct
+		// mac address are forwarded normally, anything else is dropped
+		flows = append(flows,
+			fmt.Sprintf("cookie=%s, priority=10, table=0, in_port=%s, dl_src=%s, actions=output:NORMAL",
+				defaultOpenFlowCookie, ofPortPatch, bridgeMacAddress))
+		flows = append(flows,
+			fmt.Sprintf("cookie=%s, priority=9, table=0, in_port=%s, actions=drop",
+				defaultOpenFlowCookie, ofPortPatch))
+	}
+
+	outputs := "output:" + strings.Join(arpOutputs, ",output:")
+	if config.IPv4Mode {
+		flows = append(flows,
+			fmt.Sprintf("cookie=%s, priority=11, table=0, in_port=%s, dl_dst=%s, arp, arp_op=2, actions=%s",
+				defaultOpenFlowCookie, ofPortPhys, bridgeMacAddress, outputs))
+	}
+	if config.IPv6Mode {
+		flows = append(flows,
+			fmt.Sprintf("cookie=%s, priority=11, table=0, in_port=%s, dl_dst=%s, icmp6, icmpv6_type=%d, actions=%s",
+				defaultOpenFlowCookie, ofPortPhys, bridgeMacAddress, types.NeighborAdvertisementICMPType, outputs))
+	}
+	return flows, nil
+}
+
 func setBridgeOfPorts(bridge *bridgeConfiguration) error {
 	// Get ofport of patchPort
 	ofportPatch, stderr, err := util.GetOVSOfPort("get", "Interface", bridge.patchPort, "ofport")
@@ -1781,7 +1967,7 @@ func newSharedGateway(nodeName string, subnets []*net.IPNet, gwNextHops []net.IP
 
 		if config.Gateway.NodeportEnable {
 			if config.OvnKubeNode.Mode == types.NodeModeFull {
-				// (TODO): Internal Traffic Policy is not supported in DPU mode
+				// In DPU mode the management port routing rules for ITP=local traffic are set up by the DPU host
 				if err := initSvcViaMgmPortRoutingRules(subnets); err != nil {
 					return err
 				}
@@ -1831,21 +2017,15 @@ func newNodePortWatcher(gwBridge *bridgeConfiguration, ofm *openflowManager,
 	// will not be processed by the OpenFlow flows, so we need to add iptable rules that DNATs the
 	// NodePortIP:NodePort to ClusterServiceIP:Port. We don't need to do this while
 	// running on DPU or on DPU-Host.
-	if config.OvnKubeNode.Mode == types.NodeModeFull {
-		if config.Gateway.Mode == config.GatewayModeLocal {
-			if err := initLocalGatewayIPTables(); err != nil {
-				return nil, err
-			}
-		} else if config.Gateway.Mode == config.GatewayModeShared {
-			if err := initSharedGatewayIPTables(); err != nil {
-				return nil, err
-			}
+	if config.OvnKubeNode.Mode == types.NodeModeFull && config.Gateway.Mode != config.GatewayModeDisabled {
+		if err := newServiceRuleManager().initRules(); err != nil {
+			return nil, err
 		}
 	}
 
 	if config.Gateway.DisableForwarding {
 		var subnets []*net.IPNet
-		for _, subnet := range config.Default.ClusterSubnets {
+		for _, subnet := range config.ClusterSubnets() {
 			subnets = append(subnets, subnet.CIDR)
 		}
 		subnets = append(subnets, config.Kubernetes.ServiceCIDRs...)
@@ -1872,6 +2052,7 @@ func newNodePortWatcher(gwBridge *bridgeConfiguration, ofm *openflowManager,
 		gatewayIPv6:   gatewayIPv6,
 		ofportPhys:    ofportPhys,
 		ofportPatch:   ofportPatch,
+		ofportHost:    gwBridge.ofPortHost,
 		gwBridge:      gwBridge.bridgeName,
 		serviceInfo:   make(map[ktypes.NamespacedName]*serviceConfig),
 		nodeIPManager: nodeIPManager,
@@ -1922,7 +2103,7 @@ func cleanupSharedGateway() error {
 		return fmt.Errorf("failed to replace-flows on bridge %q stderr:%s (%v)", bridgeName, stderr, err)
 	}
 
-	cleanupSharedGatewayIPTChains()
+	newServiceRuleManager().cleanup()
 	return nil
 }
 
@@ -49,7 +49,7 @@ func newManagementPortIPFamilyConfig(hostSubnet *net.IPNet, isIPv6 bool) (*manag
 	}
 
 	// capture all the subnets for which we need to add routes through management port
-	for _, subnet := range config.Default.ClusterSubnets {
+	for _, subnet := range config.ClusterSubnets() {
 		if utilnet.IsIPv6CIDR(subnet.CIDR) == isIPv6 {
 			cfg.allSubnets = append(cfg.allSubnets, subnet.CIDR)
 		}
@@ -191,7 +191,17 @@ func setupManagementPortIPFamilyConfig(routeManager *routemanager.Controller, mp
 		return warnings, err
 	}
 
-	for _, subnet := range cfg.allSubnets {
+	allSubnets := cfg.allSubnets
+	// cluster subnets might have been added at runtime since the management
+	// port was configured
+	for _, clusterSubnet := range config.ClusterSubnets() {
+		if utilnet.IsIPv6CIDR(clusterSubnet.CIDR) != utilnet.IsIPv6CIDR(cfg.ifAddr) ||
+			util.IsContainedInAnyCIDR(clusterSubnet.CIDR, allSubnets...) {
+			continue
+		}
+		allSubnets = append(allSubnets[:len(allSubnets):len(allSubnets)], clusterSubnet.CIDR)
+	}
+	for _, subnet := range allSubnets {
 		exists, err = util.LinkRouteExists(mpcfg.link, cfg.gwIP, subnet)
 		if err != nil {
 			return warnings, err
@@ -206,9 +206,14 @@ func (c *openflowManager) updateBridgeFlowCache(subnets []*net.IPNet, extraIPs [
 		return err
 	}
 	dftFlows = append(dftFlows, dftCommonFlows...)
+	egressNetworkFlows, err := flowsForEgressNetworks(c.defaultBridge)
+	if err != nil {
+		return err
+	}
 
 	c.updateFlowCacheEntry("NORMAL", []string{fmt.Sprintf("table=0,priority=0,actions=%s\n", util.NormalAction)})
 	c.updateFlowCacheEntry("DEFAULT", dftFlows)
+	c.updateFlowCacheEntry("EGRESS-NETWORKS", egressNetworkFlows)
 
 	// we consume ex gw bridge flows only if that is enabled
 	if c.externalGatewayBridge != nil {
@@ -224,6 +229,63 @@ func (c *openflowManager) updateBridgeFlowCache(subnets []*net.IPNet, extraIPs [
 	return nil
 }
 
+// addEgressNetwork steers the traffic of the gateway routers of the given
+// secondary network between their patch port on the default bridge and the
+// physical interface. It is safe to call again to pick up a new ofport of the
+// patch port.
+func (c *openflowManager) addEgressNetwork(netName string, networkID int) error {
+	c.defaultBridge.Lock()
+	defer c.defaultBridge.Unlock()
+
+	// the gateway routers of the network are connected through a localnet
+	// port named after the default one, scoped to the network
+	patchPort := "patch-" + util.GetSecondaryNetworkPrefix(netName) + c.defaultBridge.interfaceID + "-to-br-int"
+	ofPortPatch, stderr, err := util.GetOVSOfPort("--if-exists", "get", "Interface", patchPort, "ofport")
+	if err != nil {
+		return fmt.Errorf("failed to get ofport of %s, stderr: %q, error: %v", patchPort, stderr, err)
+	}
+	if ofPortPatch == "" {
+		return fmt.Errorf("patch port %s of network %s not created yet by ovn-controller", patchPort, netName)
+	}
+
+	egressNetwork := &bridgeEgressNetwork{
+		patchPort:   patchPort,
+		ofPortPatch: ofPortPatch,
+		ctMark:      fmt.Sprintf("0x%x", ctMarkEgressNetworkBase+networkID),
+	}
+	if current, ok := c.defaultBridge.egressNetworks[netName]; ok && *current == *egressNetwork {
+		return nil
+	}
+	if c.defaultBridge.egressNetworks == nil {
+		c.defaultBridge.egressNetworks = map[string]*bridgeEgressNetwork{}
+	}
+	c.defaultBridge.egressNetworks[netName] = egressNetwork
+	return c.updateEgressNetworkFlowCache()
+}
+
+// delEgressNetwork stops steering the traffic of the gateway routers of the
+// given secondary network
+func (c *openflowManager) delEgressNetwork(netName string) error {
+	c.defaultBridge.Lock()
+	defer c.defaultBridge.Unlock()
+	if _, ok := c.defaultBridge.egressNetworks[netName]; !ok {
+		return nil
+	}
+	delete(c.defaultBridge.egressNetworks, netName)
+	return c.updateEgressNetworkFlowCache()
+}
+
+// updateEgressNetworkFlowCache must be called with the default bridge lock held
+func (c *openflowManager) updateEgressNetworkFlowCache() error {
+	flows, err := flowsForEgressNetworks(c.defaultBridge)
+	if err != nil {
+		return err
+	}
+	c.updateFlowCacheEntry("EGRESS-NETWORKS", flows)
+	c.requestFlowSync()
+	return nil
+}
+
 func checkPorts(patchIntf, ofPortPatch, physIntf, ofPortPhys string) error {
 	// it could be that the ovn-controller recreated the patch between the host OVS bridge and
 	// the integration bridge, as a result the ofport number changed for that patch interface
@@ -272,6 +334,11 @@ func bootstrapOVSFlows() error {
 	for _, line := range strings.Split(portsOutput, "\n") {
 		matches := r.FindStringSubmatch(line)
 		if len(matches) == 2 {
+			// skip the patch ports of secondary network gateways, whose names
+			// are prefixed with the network name
+			if _, _, err := util.RunOVSVsctl("br-exists", matches[1]); err != nil {
+				continue
+			}
 			patchPort = matches[0]
 			bridge = matches[1]
 			break
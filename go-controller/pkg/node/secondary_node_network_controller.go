@@ -2,25 +2,37 @@ package node
 
 import (
 	"context"
+	"fmt"
 	"sync"
+	"time"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
 )
 
+// egressNetworkSyncPeriod is the period at which the external bridge is
+// reconciled for secondary networks with gateway routers
+const egressNetworkSyncPeriod = 15 * time.Second
+
 // SecondaryNodeNetworkController structure is the object which holds the controls for starting
 // and reacting upon the watched resources (e.g. pods, endpoints) for secondary network
 type SecondaryNodeNetworkController struct {
 	BaseNodeNetworkController
 	// pod events factory handler
 	podHandler *factory.Handler
+	// gateway of the node, connecting the gateway routers of the network to
+	// the external bridge
+	gateway Gateway
 }
 
 // NewSecondaryNodeNetworkController creates a new OVN controller for creating logical network
 // infrastructure and policy for default l3 network
-func NewSecondaryNodeNetworkController(cnnci *CommonNodeNetworkControllerInfo, netInfo util.NetInfo) *SecondaryNodeNetworkController {
+func NewSecondaryNodeNetworkController(cnnci *CommonNodeNetworkControllerInfo, netInfo util.NetInfo, gateway Gateway) *SecondaryNodeNetworkController {
 	return &SecondaryNodeNetworkController{
 		BaseNodeNetworkController: BaseNodeNetworkController{
 			CommonNodeNetworkControllerInfo: *cnnci,
@@ -28,18 +40,56 @@ func NewSecondaryNodeNetworkController(cnnci *CommonNodeNetworkControllerInfo, n
 			stopChan:                        make(chan struct{}),
 			wg:                              &sync.WaitGroup{},
 		},
+		gateway: gateway,
 	}
 }
 
 // Start starts the default controller; handles all events and creates all needed logical entities
 func (nc *SecondaryNodeNetworkController) Start(ctx context.Context) error {
 	klog.Infof("Start secondary node network controller of network %s", nc.GetNetworkName())
-	handler, err := nc.watchPodsDPU()
+	if config.OvnKubeNode.Mode == types.NodeModeDPU {
+		handler, err := nc.watchPodsDPU()
+		if err != nil {
+			return err
+		}
+		nc.podHandler = handler
+	}
+
+	if nc.hasEgress() {
+		// the patch port of the network on the external bridge is created by
+		// ovn-controller once the gateway router of the node is, and might be
+		// recreated later on, so keep reconciling it
+		nc.wg.Add(1)
+		go func() {
+			defer nc.wg.Done()
+			wait.Until(func() {
+				if err := nc.syncEgress(); err != nil {
+					klog.Warningf("Failed to sync gateway of network %s on the external bridge: %v", nc.GetNetworkName(), err)
+				}
+			}, egressNetworkSyncPeriod, nc.stopChan)
+		}()
+	}
+	return nil
+}
+
+// hasEgress returns whether the network has gateway routers to connect to the
+// external bridge of the node
+func (nc *SecondaryNodeNetworkController) hasEgress() bool {
+	return nc.TopologyType() == types.Layer3Topology && nc.HasGateway() && nc.gateway != nil
+}
+
+// syncEgress connects the gateway router of the network to the external
+// bridge of the node
+func (nc *SecondaryNodeNetworkController) syncEgress() error {
+	node, err := nc.watchFactory.GetNode(nc.name)
 	if err != nil {
 		return err
 	}
-	nc.podHandler = handler
-	return nil
+	networkID, err := util.ParseNetworkIDAnnotation(node, nc.GetNetworkName())
+	if err != nil {
+		return fmt.Errorf("failed to get the ID of network %s: %w", nc.GetNetworkName(), err)
+	}
+	return nc.gateway.AddEgressNetwork(nc.GetNetworkName(), networkID)
 }
 
 // Stop gracefully stops the controller
@@ -53,7 +103,17 @@ func (nc *SecondaryNodeNetworkController) Stop() {
 	}
 }
 
+// UpdateNetInfo applies in place the compatible changes of the given network
+// information. There is nothing to reconcile on the node as the updated
+// configuration is only relevant for pods created afterwards.
+func (nc *SecondaryNodeNetworkController) UpdateNetInfo(netInfo util.BasicNetInfo) error {
+	return util.UpdateNetInfo(nc.NetInfo, netInfo)
+}
+
 // Cleanup cleans up node entities for the given secondary network
 func (nc *SecondaryNodeNetworkController) Cleanup(netName string) error {
+	if nc.gateway != nil {
+		return nc.gateway.DelEgressNetwork(netName)
+	}
 	return nil
 }
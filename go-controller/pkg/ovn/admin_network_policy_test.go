@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/rand"
 	"net"
+	"strconv"
 	"strings"
 	"time"
 
@@ -1035,6 +1036,110 @@ var _ = ginkgo.Describe("OVN ANP Operations", func() {
 			err := app.Run([]string{app.Name})
 			gomega.Expect(err).ToNot(gomega.HaveOccurred())
 		})
+		ginkgo.It("NamedPort Rules for ANP", func() {
+			app.Action = func(ctx *cli.Context) error {
+				anpNamespaceSubject := *newNamespaceWithLabels(anpSubjectNamespaceName, anpLabel)
+				anpNamespacePeer := *newNamespaceWithLabels(anpPeerNamespaceName, peerAllowLabel)
+				config.IPv4Mode = true
+				config.IPv6Mode = true
+				fakeOVN.start(
+					&v1.NamespaceList{
+						Items: []v1.Namespace{
+							anpNamespaceSubject,
+							anpNamespacePeer,
+						},
+					},
+				)
+				fakeOVN.InitAndRunANPController()
+				ginkgo.By("1. Create ANP with 1 egress rule using a named port that no pod exposes yet")
+				anpSubject := newANPSubjectObject(
+					&metav1.LabelSelector{
+						MatchLabels: anpLabel,
+					},
+					nil,
+				)
+				anpNamedPorts := []anpapi.AdminNetworkPolicyPort{
+					{
+						NamedPort: utilpointer.String("web"),
+					},
+				}
+				anp := newANPObject("harry-potter", 75, anpSubject,
+					[]anpapi.AdminNetworkPolicyIngressRule{},
+					[]anpapi.AdminNetworkPolicyEgressRule{
+						{
+							Name:   "allow-traffic-to-hufflepuff-web-from-gryffindor",
+							Action: anpapi.AdminNetworkPolicyRuleActionAllow,
+							To: []anpapi.AdminNetworkPolicyEgressPeer{
+								{
+									Namespaces: &anpapi.NamespacedPeer{
+										NamespaceSelector: &metav1.LabelSelector{
+											MatchLabels: peerAllowLabel,
+										},
+									},
+								},
+							},
+							Ports: &anpNamedPorts,
+						},
+					},
+				)
+				anp.ResourceVersion = "1"
+				anp, err := fakeOVN.fakeClient.ANPClient.PolicyV1alpha1().AdminNetworkPolicies().Create(context.TODO(), anp, metav1.CreateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				// the rule doesn't match anything until the named port gets resolved, so no ACLs are expected
+				pg := getDefaultPGForANPSubject(anp.Name, []string{}, nil, false)
+				peerASEgressRule0v4, peerASEgressRule0v6 := buildANPAddressSets(anp, 0, []net.IP{}, libovsdbutil.ACLEgress)
+				expectedDatabaseState := []libovsdbtest.TestData{pg, peerASEgressRule0v4, peerASEgressRule0v6}
+				gomega.Eventually(fakeOVN.nbClient).Should(libovsdbtest.HaveDataIgnoringUUIDs(expectedDatabaseState))
+				gomega.Eventually(func() string {
+					anp, err = fakeOVN.fakeClient.ANPClient.PolicyV1alpha1().AdminNetworkPolicies().Get(context.TODO(), anp.Name, metav1.GetOptions{})
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					if len(anp.Status.Conditions) == 0 {
+						return ""
+					}
+					return anp.Status.Conditions[0].Message
+				}).Should(gomega.Equal("Setting up OVN DB plumbing was successful; named ports not found on any selected pod: " +
+					"Egress rule 0 (allow-traffic-to-hufflepuff-web-from-gryffindor): web"))
+				gomega.Expect(anp.Status.Conditions[0].Status).To(gomega.Equal(metav1.ConditionTrue))
+
+				ginkgo.By("2. Create a peer pod exposing the named port and ensure the ACL is created for it")
+				anpPeerPod := newPod(anpPeerNamespaceName, anpPeerPodName, node1Name, anpPodV4IP2)
+				anpPeerPod.Spec.Containers[0].Ports = []v1.ContainerPort{
+					{
+						Name:          "web",
+						ContainerPort: 8080,
+					},
+				}
+				_, err = fakeOVN.fakeClient.KubeClient.CoreV1().Pods(anpPeerPod.Namespace).Create(context.TODO(), anpPeerPod, metav1.CreateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				acls := getANPGressACL(anpovn.GetACLActionForANPRule(anpapi.AdminNetworkPolicyRuleActionAllow), anp.Name,
+					string(libovsdbutil.ACLEgress), getANPRulePriority(getBaseRulePriority(anp.Spec.Priority), 0), 0, nil, false)
+				namedPortACL := acls[0]
+				namedPortACL.Match = fmt.Sprintf("%s && ((ip4.dst == %s && tcp.dst == 8080))", namedPortACL.Match, anpPodV4IP2)
+				namedPortACL.ExternalIDs[libovsdbops.PortPolicyProtocolKey.String()] = "tcp" + libovsdbutil.NamedPortL4MatchSuffix
+				namedPortACL.ExternalIDs[libovsdbops.PrimaryIDKey.String()] = fmt.Sprintf("%s:AdminNetworkPolicy:%s:%s:%d:tcp%s",
+					DefaultNetworkControllerName, anp.Name, libovsdbutil.ACLEgress, 0, libovsdbutil.NamedPortL4MatchSuffix)
+				pg = getDefaultPGForANPSubject(anp.Name, []string{}, acls, false)
+				peerASEgressRule0v4, peerASEgressRule0v6 = buildANPAddressSets(anp, 0, []net.IP{testing.MustParseIP(anpPodV4IP2)}, libovsdbutil.ACLEgress)
+				expectedDatabaseState = []libovsdbtest.TestData{pg, namedPortACL, peerASEgressRule0v4, peerASEgressRule0v6}
+				gomega.Eventually(fakeOVN.nbClient).Should(libovsdbtest.HaveDataIgnoringUUIDs(expectedDatabaseState))
+				gomega.Eventually(func() string {
+					anp, err = fakeOVN.fakeClient.ANPClient.PolicyV1alpha1().AdminNetworkPolicies().Get(context.TODO(), anp.Name, metav1.GetOptions{})
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					return anp.Status.Conditions[0].Message
+				}).Should(gomega.Equal("Setting up OVN DB plumbing was successful"))
+
+				ginkgo.By("3. Delete the peer pod and ensure the ACL for the named port is removed")
+				err = fakeOVN.fakeClient.KubeClient.CoreV1().Pods(anpPeerPod.Namespace).Delete(context.TODO(), anpPeerPod.Name, metav1.DeleteOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				pg = getDefaultPGForANPSubject(anp.Name, []string{}, nil, false)
+				peerASEgressRule0v4, peerASEgressRule0v6 = buildANPAddressSets(anp, 0, []net.IP{}, libovsdbutil.ACLEgress)
+				expectedDatabaseState = []libovsdbtest.TestData{pg, peerASEgressRule0v4, peerASEgressRule0v6}
+				gomega.Eventually(fakeOVN.nbClient).Should(libovsdbtest.HaveDataIgnoringUUIDs(expectedDatabaseState))
+				return nil
+			}
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		})
 		ginkgo.It("should not be able to create two admin network policies at the same priority", func() {
 			app.Action = func(ctx *cli.Context) error {
 				fakeOVN.start()
@@ -1595,5 +1700,216 @@ var _ = ginkgo.Describe("OVN ANP Operations", func() {
 			err := app.Run([]string{app.Name})
 			gomega.Expect(err).ToNot(gomega.HaveOccurred())
 		})
+		ginkgo.It("egress networks peers: should create/update/delete acls correctly", func() {
+			app.Action = func(ctx *cli.Context) error {
+				anpNamespaceSubject := *newNamespaceWithLabels(anpSubjectNamespaceName, anpLabel)
+				anpNamespacePeer := *newNamespaceWithLabels(anpPeerNamespaceName, peerDenyLabel)
+				config.IPv4Mode = true
+				config.IPv6Mode = true
+				node1 := nodeFor(node1Name, node1IPv4, node1IPv6, node1IPv4Subnet, node1IPv6Subnet, node1transitIPv4, node1transitIPv6)
+				node1Switch := &nbdb.LogicalSwitch{
+					Name: node1Name,
+					UUID: node1Name + "-UUID",
+				}
+				t := newTPod(
+					node1Name,
+					node1IPv4Subnet+" "+node1IPv6Subnet,
+					"10.128.1.2",
+					"10.128.1.1"+" "+"fe00:10:128:1::1",
+					anpSubjectPodName,
+					anpPodV4IP+" "+anpPodV6IP,
+					anpPodMAC,
+					anpSubjectNamespaceName,
+				)
+				anpSubjectPod := *newPod(anpSubjectNamespaceName, anpSubjectPodName, node1Name, t.podIP)
+				// pinning annotations because between subject and peer pods IPAM isunpredictable
+				anpSubjectPod.Annotations = map[string]string{}
+				anpSubjectPod.Annotations["k8s.ovn.org/pod-networks"] = `{"default":{"ip_addresses":["10.128.1.3/24","fe00:10:128:1::3/64"],` +
+					`"mac_address":"0a:58:0a:80:01:03","gateway_ips":["10.128.1.1","fe00:10:128:1::1"],"routes":[{"dest":"10.128.1.0/24","nextHop":"10.128.1.1"}],` +
+					`"ip_address":"10.128.1.3/24","gateway_ip":"10.128.1.1"}}`
+				t2 := newTPod(
+					node1Name,
+					node1IPv4Subnet+" "+node1IPv6Subnet,
+					"10.128.1.2",
+					"10.128.1.1"+" "+"fe00:10:128:1::1",
+					anpPeerPodName,
+					anpPodV4IP2+" "+anpPodV6IP2,
+					anpPodMAC2,
+					anpPeerNamespaceName,
+				)
+				anpPeerPod := *newPod(anpPeerNamespaceName, anpPeerPodName, node1Name, t2.podIP)
+				// pinning annotations because between subject and peer pods IPAM isunpredictable
+				anpPeerPod.Annotations = map[string]string{}
+				anpPeerPod.Annotations["k8s.ovn.org/pod-networks"] = `{"default":{"ip_addresses":["10.128.1.4/24","fe00:10:128:1::4/64"],` +
+					`"mac_address":"0a:58:0a:80:01:04","gateway_ips":["10.128.1.1","fe00:10:128:1::1"],"routes":[{"dest":"10.128.1.0/24","nextHop":"10.128.1.1"}],` +
+					`"ip_address":"10.128.1.4/24","gateway_ip":"10.128.1.1"}}`
+				dbSetup := libovsdbtest.TestSetup{
+					NBData: []libovsdbtest.TestData{
+						node1Switch,
+					},
+				}
+				fakeOVN.startWithDBSetup(dbSetup,
+					&v1.NamespaceList{
+						Items: []v1.Namespace{
+							anpNamespaceSubject,
+							anpNamespacePeer,
+						},
+					},
+					&v1.NodeList{
+						Items: []v1.Node{
+							*node1,
+						},
+					},
+					&v1.PodList{
+						Items: []v1.Pod{
+							anpSubjectPod, // subject pod ("house": "gryffindor")
+							anpPeerPod,    // peer pod ("house": "slytherin")
+						},
+					},
+				)
+
+				fakeOVN.controller.zone = node1Name // ensure we set the controller's zone as the node's zone
+				t.portName = util.GetLogicalPortName(t.namespace, t.podName)
+				t.populateLogicalSwitchCache(fakeOVN)
+				t2.portName = util.GetLogicalPortName(t2.namespace, t2.podName)
+				t2.populateLogicalSwitchCache(fakeOVN)
+				err := fakeOVN.controller.WatchNamespaces()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				err = fakeOVN.controller.WatchPods()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				fakeOVN.InitAndRunANPController()
+
+				fakeOVN.fakeClient.ANPClient.(*anpfake.Clientset).PrependReactor("update", "adminnetworkpolicies", func(action clienttesting.Action) (handled bool, ret runtime.Object, err error) {
+					update := action.(clienttesting.UpdateAction)
+					// Since fake client (NewSimpleClientset) does not differentiate between
+					// an update and updatestatus, updatestatus in tests updates the spec as
+					// well causing race conditions. Thus adding a hack here to ensure update
+					// status is caught and processed by the reactor while update spec is
+					// delegated to the main code for handling
+					if action.GetSubresource() == "status" {
+						klog.Infof("Got an update status action for %v", update.GetObject())
+						return true, update.GetObject(), nil
+					}
+					klog.Infof("Got an update spec action for %v", update.GetObject())
+					return false, update.GetObject(), nil
+				})
+
+				ginkgo.By("1. creating an admin network policy with 2 egress rules that have networks peers")
+				anpSubject := newANPSubjectObject(
+					&metav1.LabelSelector{
+						MatchLabels: anpLabel,
+					},
+					nil,
+				)
+				egressRules := []anpapi.AdminNetworkPolicyEgressRule{
+					{
+						Name:   "deny-traffic-to-slytherin-and-external-networks-from-gryffindor",
+						Action: anpapi.AdminNetworkPolicyRuleActionDeny,
+						To: []anpapi.AdminNetworkPolicyEgressPeer{
+							{
+								Namespaces: &anpapi.NamespacedPeer{
+									NamespaceSelector: &metav1.LabelSelector{
+										MatchLabels: peerDenyLabel,
+									},
+								},
+							},
+							{
+								Networks: []anpapi.CIDR{"135.10.0.5/32", "188.198.40.0/28", "2001:db8:abcd:1234:c000::/66"},
+							},
+						},
+					},
+					{
+						Name:   "allow-web-traffic-to-the-internet-from-gryffindor",
+						Action: anpapi.AdminNetworkPolicyRuleActionAllow,
+						To: []anpapi.AdminNetworkPolicyEgressPeer{
+							{
+								Networks: []anpapi.CIDR{"0.0.0.0/0", "::/0"},
+							},
+						},
+						Ports: &[]anpapi.AdminNetworkPolicyPort{
+							{
+								PortNumber: &anpapi.Port{
+									Protocol: v1.ProtocolTCP,
+									Port:     int32(443),
+								},
+							},
+						},
+					},
+				}
+				anp := newANPObject("harry-potter", 5, anpSubject, []anpapi.AdminNetworkPolicyIngressRule{}, egressRules)
+				anp.ResourceVersion = "1"
+				anp, err = fakeOVN.fakeClient.ANPClient.PolicyV1alpha1().AdminNetworkPolicies().Create(context.TODO(), anp, metav1.CreateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				// networks are matched on in the ACLs along with the rule's address-set
+				getNetworksACLs := func(anp *anpapi.AdminNetworkPolicy, networks map[int]string) []*nbdb.ACL {
+					acls := getACLsForANPRules(anp)
+					for _, acl := range acls {
+						ruleIndex, _ := strconv.Atoi(acl.ExternalIDs[libovsdbops.GressIdxKey.String()])
+						asIndex := anpovn.GetANPPeerAddrSetDbIDs(anp.Name, string(libovsdbutil.ACLEgress),
+							fmt.Sprintf("%d", ruleIndex), DefaultNetworkControllerName, false)
+						asv4, asv6 := addressset.GetHashNamesForAS(asIndex)
+						acl.Match = strings.Replace(acl.Match, fmt.Sprintf("((ip4.dst == $%s || ip6.dst == $%s))", asv4, asv6),
+							fmt.Sprintf(networks[ruleIndex], asv4, asv6), 1)
+					}
+					return acls
+				}
+				acls := getNetworksACLs(anp, map[int]string{
+					0: "((ip4.dst == $%s || ip4.dst == {135.10.0.5/32, 188.198.40.0/28} || ip6.dst == $%s || ip6.dst == {2001:db8:abcd:1234:c000::/66}))",
+					1: "((ip4.dst == $%s || ip4.dst == {0.0.0.0/0} || ip6.dst == $%s || ip6.dst == {::/0}))",
+				})
+				pg := getDefaultPGForANPSubject(anp.Name, []string{t.portUUID}, acls, false)
+				subjectNSASIPv4, subjectNSASIPv6 := buildNamespaceAddressSets(anpSubjectNamespaceName,
+					[]net.IP{testing.MustParseIP(anpPodV4IP), testing.MustParseIP(anpPodV6IP)})
+				peerNSASIPv4, peerNSASIPv6 := buildNamespaceAddressSets(anpPeerNamespaceName,
+					[]net.IP{testing.MustParseIP(anpPodV4IP2), testing.MustParseIP(anpPodV6IP2)})
+				expectedDatabaseState := []libovsdbtest.TestData{pg, subjectNSASIPv4, subjectNSASIPv6, peerNSASIPv4, peerNSASIPv6}
+				expectedDatabaseState = append(expectedDatabaseState, getExpectedDataPodsAndSwitches([]testPod{t, t2}, []string{node1Name})...)
+				for _, acl := range acls {
+					acl := acl
+					expectedDatabaseState = append(expectedDatabaseState, acl)
+				}
+				// egressRule AddressSets: networks are not added to the address-sets
+				peerASEgressRule0v4, peerASEgressRule0v6 := buildANPAddressSets(anp,
+					0, []net.IP{testing.MustParseIP(anpPodV4IP2), testing.MustParseIP(anpPodV6IP2)}, libovsdbutil.ACLEgress)
+				expectedDatabaseState = append(expectedDatabaseState, peerASEgressRule0v4, peerASEgressRule0v6)
+				peerASEgressRule1v4, peerASEgressRule1v6 := buildANPAddressSets(anp,
+					1, []net.IP{}, libovsdbutil.ACLEgress)
+				expectedDatabaseState = append(expectedDatabaseState, peerASEgressRule1v4, peerASEgressRule1v6)
+				gomega.Eventually(fakeOVN.nbClient).Should(libovsdbtest.HaveDataIgnoringUUIDs(expectedDatabaseState))
+
+				ginkgo.By("2. update the networks of the deny rule in admin network policy; check if the ACL is updated")
+				egressRules[0].To[1].Networks = []anpapi.CIDR{"188.198.40.0/28"}
+				anp.ResourceVersion = "2"
+				anp.Spec.Egress = egressRules
+				anp, err = fakeOVN.fakeClient.ANPClient.PolicyV1alpha1().AdminNetworkPolicies().Update(context.TODO(), anp, metav1.UpdateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				acls = getNetworksACLs(anp, map[int]string{
+					0: "((ip4.dst == $%s || ip4.dst == {188.198.40.0/28} || ip6.dst == $%s))",
+					1: "((ip4.dst == $%s || ip4.dst == {0.0.0.0/0} || ip6.dst == $%s || ip6.dst == {::/0}))",
+				})
+				pg = getDefaultPGForANPSubject(anp.Name, []string{t.portUUID}, acls, false)
+				expectedDatabaseState = []libovsdbtest.TestData{pg, subjectNSASIPv4, subjectNSASIPv6, peerNSASIPv4, peerNSASIPv6}
+				expectedDatabaseState = append(expectedDatabaseState, getExpectedDataPodsAndSwitches([]testPod{t, t2}, []string{node1Name})...)
+				for _, acl := range acls {
+					acl := acl
+					expectedDatabaseState = append(expectedDatabaseState, acl)
+				}
+				expectedDatabaseState = append(expectedDatabaseState, peerASEgressRule0v4, peerASEgressRule0v6, peerASEgressRule1v4, peerASEgressRule1v6)
+				gomega.Eventually(fakeOVN.nbClient).Should(libovsdbtest.HaveDataIgnoringUUIDs(expectedDatabaseState))
+
+				ginkgo.By("3. delete the ANP; check if all objects are deleted correctly")
+				anp.ResourceVersion = "3"
+				err = fakeOVN.fakeClient.ANPClient.PolicyV1alpha1().AdminNetworkPolicies().Delete(context.TODO(), anp.Name, metav1.DeleteOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				expectedDatabaseState = []libovsdbtest.TestData{subjectNSASIPv4, subjectNSASIPv6, peerNSASIPv4, peerNSASIPv6} // port group should be deleted
+				expectedDatabaseState = append(expectedDatabaseState, getExpectedDataPodsAndSwitches([]testPod{t, t2}, []string{node1Name})...)
+				gomega.Eventually(fakeOVN.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
+
+				return nil
+			}
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+		})
 	})
 })
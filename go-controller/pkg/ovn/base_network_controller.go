@@ -18,6 +18,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
+	svccontroller "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/controller/services"
 	lsm "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/logical_switch_manager"
 	zoneic "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/zone_interconnect"
 	ovnretry "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/retry"
@@ -161,6 +162,9 @@ type BaseSecondaryNetworkController struct {
 	BaseNetworkController
 	// multi-network policy events factory handler
 	policyHandler *factory.Handler
+	// controller for the services exposed on this network, only set if
+	// multi-network services support is enabled
+	svcController *svccontroller.Controller
 }
 
 // NewCommonNetworkControllerInfo creates CommonNetworkControllerInfo shared by controllers
@@ -327,7 +331,9 @@ func (bnc *BaseNetworkController) createNodeLogicalSwitch(nodeName string, hostS
 
 	var v4Gateway, v6Gateway net.IP
 	logicalSwitch.OtherConfig = map[string]string{}
-	for _, hostSubnet := range hostSubnets {
+	// the node might have additional host subnets, the switch is configured
+	// after the first one of each IP family
+	for _, hostSubnet := range util.MatchFirstIPNetOfEachFamily(hostSubnets) {
 		gwIfAddr := util.GetNodeGatewayIfAddr(hostSubnet)
 		mgmtIfAddr := util.GetNodeManagementIfAddr(hostSubnet)
 
@@ -408,6 +414,14 @@ func (bnc *BaseNetworkController) createNodeLogicalSwitch(nodeName string, hostS
 		return fmt.Errorf("failed finding migratable pod IPs belonging to %s: %v", nodeName, err)
 	}
 
+	if bnc.hasNodeSubnetExpansion() {
+		// retain the IPs already allocated if host subnets were only added to
+		// the node
+		existingSubnets := sets.New(util.StringSlice(bnc.lsManager.GetSwitchSubnets(logicalSwitch.Name))...)
+		if existingSubnets.Len() > 0 && sets.New(util.StringSlice(hostSubnets)...).IsSuperset(existingSubnets) {
+			return bnc.lsManager.ExpandSwitch(logicalSwitch.Name, hostSubnets, migratableIPsByPod...)
+		}
+	}
 	return bnc.lsManager.AddOrUpdateSwitch(logicalSwitch.Name, hostSubnets, migratableIPsByPod...)
 }
 
@@ -590,6 +604,11 @@ func (bnc *BaseNetworkController) doesNetworkRequireIPAM() bool {
 	return util.DoesNetworkRequireIPAM(bnc.NetInfo)
 }
 
+func (bnc *BaseNetworkController) hasNodeSubnetExpansion() bool {
+	// additional host subnets are only allocated for the default network
+	return config.OVNKubernetesFeature.EnableNodeSubnetExpansion && !bnc.IsSecondary()
+}
+
 func (bnc *BaseNetworkController) buildPortGroup(hashName, name string, ports []*nbdb.LogicalSwitchPort, acls []*nbdb.ACL) *nbdb.PortGroup {
 	externalIds := map[string]string{"name": name}
 	if bnc.IsSecondary() {
@@ -14,6 +14,7 @@ import (
 	ipallocator "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/ip"
 	subnetipallocator "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/ip/subnet"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kubevirt"
 	logicalswitchmanager "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/logical_switch_manager"
 	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
@@ -615,6 +616,11 @@ func (bnc *BaseNetworkController) assignPodAddresses(switchName string) (net.Har
 	}
 	podCIDRs, err = bnc.lsManager.AllocateNextIPs(switchName)
 	if err != nil {
+		var subnetsFullErr *subnetipallocator.SubnetsFullError
+		if errors.As(err, &subnetsFullErr) && bnc.hasNodeSubnetExpansion() {
+			// the switch of the default network is named after the node
+			bnc.reportExhaustedNodeSubnets(switchName, subnetsFullErr.Subnets)
+		}
 		return nil, nil, err
 	}
 	if len(podCIDRs) > 0 {
@@ -623,6 +629,29 @@ func (bnc *BaseNetworkController) assignPodAddresses(switchName string) (net.Har
 	return podMAC, podCIDRs, nil
 }
 
+// reportExhaustedNodeSubnets annotates the node with its host subnets that have
+// no IP left for pods so that the cluster manager allocates it an additional one
+func (bnc *BaseNetworkController) reportExhaustedNodeSubnets(nodeName string, exhaustedSubnets []*net.IPNet) {
+	node, err := bnc.watchFactory.GetNode(nodeName)
+	if err != nil {
+		klog.Errorf("Failed to get node %s to report its exhausted subnets %v: %v", nodeName, exhaustedSubnets, err)
+		return
+	}
+	reported, err := util.ParseNodeExhaustedSubnetsAnnotation(node, bnc.GetNetworkName())
+	if err == nil && sets.New(util.StringSlice(reported)...).IsSuperset(sets.New(util.StringSlice(exhaustedSubnets)...)) {
+		return
+	}
+	klog.Infof("Host subnets %v of node %s are exhausted, requesting an additional one", exhaustedSubnets, nodeName)
+	nodeAnnotator := kube.NewNodeAnnotator(bnc.kube, nodeName)
+	if err := util.SetNodeExhaustedSubnetsAnnotation(nodeAnnotator, exhaustedSubnets); err != nil {
+		klog.Errorf("Failed to set the exhausted subnets annotation of node %s: %v", nodeName, err)
+		return
+	}
+	if err := nodeAnnotator.Run(); err != nil {
+		klog.Errorf("Failed to set the exhausted subnets annotation of node %s: %v", nodeName, err)
+	}
+}
+
 // Given a logical switch port and the switch on which it is scheduled, get all
 // addresses currently assigned to it including subnet masks.
 func (bnc *BaseNetworkController) getPortAddresses(switchName string, existingLSP *nbdb.LogicalSwitchPort) (net.HardwareAddr, []*net.IPNet, error) {
@@ -14,6 +14,7 @@ import (
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	svccontroller "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/controller/services"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
@@ -580,6 +581,51 @@ func (bsnc *BaseSecondaryNetworkController) WatchMultiNetworkPolicy() error {
 	return err
 }
 
+// startServiceController starts the controller of the services exposed on this
+// network, if multi-network services support is enabled.
+func (bsnc *BaseSecondaryNetworkController) startServiceController() error {
+	if !util.IsMultiNetworkServicesSupportEnabled() || bsnc.svcController != nil {
+		return nil
+	}
+	svcController, err := svccontroller.NewController(
+		bsnc.client, bsnc.nbClient,
+		bsnc.watchFactory.ServiceCoreInformer(),
+		bsnc.watchFactory.EndpointSliceCoreInformer(),
+		bsnc.watchFactory.NodeCoreInformer(),
+		bsnc.recorder,
+		bsnc.NetInfo,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create service controller for network %s: %w", bsnc.GetNetworkName(), err)
+	}
+	bsnc.svcController = svcController
+
+	klog.Infof("Starting OVN Service Controller for network %s", bsnc.GetNetworkName())
+	bsnc.wg.Add(1)
+	go func() {
+		defer bsnc.wg.Done()
+		// load balancer groups and templates are not used on secondary networks
+		err := svcController.Run(1, bsnc.stopChan, true, false, false)
+		if err != nil {
+			klog.Errorf("Error running OVN Kubernetes Services controller for network %s: %v", bsnc.GetNetworkName(), err)
+		}
+	}()
+	return nil
+}
+
+// cleanupServiceLogicalEntities cleans up all the load balancers of the services exposed on the given network
+func cleanupServiceLogicalEntities(nbClient libovsdbclient.Client, ops []ovsdb.Operation, netName string) ([]ovsdb.Operation, error) {
+	var err error
+	lbPredicate := func(item *nbdb.LoadBalancer) bool {
+		return item.ExternalIDs[types.NetworkExternalID] == netName
+	}
+	ops, err = libovsdbops.DeleteLoadBalancersWithPredicateOps(nbClient, ops, lbPredicate)
+	if err != nil {
+		return ops, fmt.Errorf("failed to get ops to delete load balancers of network %s", netName)
+	}
+	return ops, nil
+}
+
 // cleanupPolicyLogicalEntities cleans up all the port groups and addressset belongs to the given network
 func cleanupPolicyLogicalEntities(nbClient libovsdbclient.Client, ops []ovsdb.Operation, netName string) ([]ovsdb.Operation, error) {
 	var err error
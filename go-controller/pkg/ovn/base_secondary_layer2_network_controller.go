@@ -1,11 +1,13 @@
 package ovn
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"reflect"
 
 	mnpapi "github.com/k8snetworkplumbingwg/multi-networkpolicy/pkg/apis/k8s.cni.cncf.io/v1beta1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/ip/subnet"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
@@ -252,6 +254,11 @@ func (oc *BaseSecondaryLayer2NetworkController) cleanup(topotype, netName string
 		return err
 	}
 
+	ops, err = cleanupServiceLogicalEntities(oc.nbClient, ops, netName)
+	if err != nil {
+		return err
+	}
+
 	_, err = libovsdbops.TransactAndCheck(oc.nbClient, ops)
 	if err != nil {
 		return fmt.Errorf("failed to deleting switches of network %s: %v", netName, err)
@@ -285,6 +292,37 @@ func (oc *BaseSecondaryLayer2NetworkController) run() error {
 
 func (oc *BaseSecondaryLayer2NetworkController) initializeLogicalSwitch(switchName string, clusterSubnets []config.CIDRNetworkEntry,
 	excludeSubnets []*net.IPNet) (*nbdb.LogicalSwitch, error) {
+	logicalSwitch, hostSubnets, err := oc.createOrUpdateLogicalSwitch(switchName, clusterSubnets)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = oc.lsManager.AddOrUpdateSwitch(switchName, hostSubnets, excludeSubnets...); err != nil {
+		return nil, err
+	}
+
+	return logicalSwitch, nil
+}
+
+// updateLogicalSwitch reconciles the logical switch with updated cluster
+// subnets and excluded subnets, retaining the IPs already allocated from it
+func (oc *BaseSecondaryLayer2NetworkController) updateLogicalSwitch(switchName string, clusterSubnets []config.CIDRNetworkEntry,
+	excludeSubnets []*net.IPNet) error {
+	_, hostSubnets, err := oc.createOrUpdateLogicalSwitch(switchName, clusterSubnets)
+	if err != nil {
+		return err
+	}
+
+	err = oc.lsManager.ExpandSwitch(switchName, hostSubnets, excludeSubnets...)
+	if errors.Is(err, subnet.ErrSubnetNotFound) {
+		// the switch was not initialized yet
+		err = oc.lsManager.AddOrUpdateSwitch(switchName, hostSubnets, excludeSubnets...)
+	}
+	return err
+}
+
+func (oc *BaseSecondaryLayer2NetworkController) createOrUpdateLogicalSwitch(switchName string,
+	clusterSubnets []config.CIDRNetworkEntry) (*nbdb.LogicalSwitch, []*net.IPNet, error) {
 	logicalSwitch := nbdb.LogicalSwitch{
 		Name:        switchName,
 		ExternalIDs: map[string]string{},
@@ -306,20 +344,16 @@ func (oc *BaseSecondaryLayer2NetworkController) initializeLogicalSwitch(switchNa
 	if oc.isLayer2Interconnect() {
 		err := oc.zoneICHandler.AddTransitSwitchConfig(&logicalSwitch)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 	}
 
 	err := libovsdbops.CreateOrUpdateLogicalSwitch(oc.nbClient, &logicalSwitch)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create logical switch %+v: %v", logicalSwitch, err)
-	}
-
-	if err = oc.lsManager.AddOrUpdateSwitch(switchName, hostSubnets, excludeSubnets...); err != nil {
-		return nil, err
+		return nil, nil, fmt.Errorf("failed to create logical switch %+v: %v", logicalSwitch, err)
 	}
 
-	return &logicalSwitch, nil
+	return &logicalSwitch, hostSubnets, nil
 }
 
 func (oc *BaseSecondaryLayer2NetworkController) addUpdateNodeEvent(node *corev1.Node) error {
@@ -2,6 +2,7 @@ package adminnetworkpolicy
 
 import (
 	"fmt"
+	"net"
 	"reflect"
 	"strconv"
 	"sync"
@@ -15,6 +16,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -124,7 +126,7 @@ func (c *Controller) ensureAdminNetworkPolicy(anp *anpapi.AdminNetworkPolicy) er
 	// 2) Construct Address-sets with IPs of the peers in the rules
 	// 3) Construct ACLs using AS-es and PGs
 	portGroupName, _ := getAdminNetworkPolicyPGName(desiredANPState.name, false)
-	desiredPorts, err := c.convertANPSubjectToLSPs(desiredANPState.subject)
+	desiredPorts, err := c.convertANPSubjectToLSPs(desiredANPState)
 	if err != nil {
 		return fmt.Errorf("unable to fetch ports for anp %s: %v", desiredANPState.name, err)
 	}
@@ -188,7 +190,10 @@ func (c *Controller) convertANPRulesToACLs(desiredANPState, currentANPState *adm
 		acls = append(acls, acl...)
 		if isAtLeastOneRuleUpdatedCheckRequired &&
 			!*atLeastOneRuleUpdated &&
-			(ingressRule.action != currentANPState.ingressRules[i].action || !reflect.DeepEqual(ingressRule.ports, currentANPState.ingressRules[i].ports)) {
+			(ingressRule.action != currentANPState.ingressRules[i].action ||
+				!reflect.DeepEqual(ingressRule.ports, currentANPState.ingressRules[i].ports) ||
+				!reflect.DeepEqual(ingressRule.namedPorts, currentANPState.ingressRules[i].namedPorts) ||
+				!reflect.DeepEqual(ingressRule.getNetworks(), currentANPState.ingressRules[i].getNetworks())) {
 			klog.V(3).Infof("ANP %s's ingress rule %s at priority %d was updated", desiredANPState.name, ingressRule.name, ingressRule.priority)
 			*atLeastOneRuleUpdated = true
 		}
@@ -198,7 +203,10 @@ func (c *Controller) convertANPRulesToACLs(desiredANPState, currentANPState *adm
 		acls = append(acls, acl...)
 		if isAtLeastOneRuleUpdatedCheckRequired &&
 			!*atLeastOneRuleUpdated &&
-			(egressRule.action != currentANPState.egressRules[i].action || !reflect.DeepEqual(egressRule.ports, currentANPState.egressRules[i].ports)) {
+			(egressRule.action != currentANPState.egressRules[i].action ||
+				!reflect.DeepEqual(egressRule.ports, currentANPState.egressRules[i].ports) ||
+				!reflect.DeepEqual(egressRule.namedPorts, currentANPState.egressRules[i].namedPorts) ||
+				!reflect.DeepEqual(egressRule.getNetworks(), currentANPState.egressRules[i].getNetworks())) {
 			klog.V(3).Infof("ANP %s's ingress rule %s at priority %d was updated", desiredANPState.name, egressRule.name, egressRule.priority)
 			*atLeastOneRuleUpdated = true
 		}
@@ -209,17 +217,23 @@ func (c *Controller) convertANPRulesToACLs(desiredANPState, currentANPState *adm
 
 // convertANPRuleToACL takes the given gressRule and converts it into an ACL(0 ports rule) or
 // multiple ACLs(ports are set) and returns those ACLs for a given gressRule
+// Named ports get their own ACLs (one per protocol) matching on the IPs of the pods that expose them;
+// a rule that only has named ports which couldn't be resolved doesn't match anything and has no ACLs
 func (c *Controller) convertANPRuleToACL(rule *gressRule, pgName, anpName string, aclLoggingParams *libovsdbutil.ACLLoggingLevels, isBanp bool) []*nbdb.ACL {
 	klog.V(5).Infof("Creating ACL for rule %d/%s belonging to ANP %s", rule.priority, rule.gressPrefix, anpName)
 	// create match based on direction and address-set name
 	asIndex := GetANPPeerAddrSetDbIDs(anpName, rule.gressPrefix, fmt.Sprintf("%d", rule.gressIndex), c.controllerName, isBanp)
-	l3Match := constructMatchFromAddressSet(rule.gressPrefix, asIndex)
+	l3Match := constructMatchFromAddressSet(rule.gressPrefix, asIndex, rule.getNetworks())
 	// create match based on rule type (ingress/egress) and port-group
 	lportMatch := libovsdbutil.GetACLMatch(pgName, "", libovsdbutil.ACLDirection(rule.gressPrefix))
 	var match string
 	acls := []*nbdb.ACL{}
-	// We will have one ACL per protocol if len(rule.ports) > 0 and one single ACL if len(rule.ports) == 0
-	for protocol, l4Match := range libovsdbutil.GetL4MatchesFromNetworkPolicyPorts(rule.ports) {
+	l4Matches := map[string]string{}
+	if len(rule.ports) > 0 || len(rule.namedPorts) == 0 {
+		// We will have one ACL per protocol if len(rule.ports) > 0 and one single ACL if len(rule.ports) == 0
+		l4Matches = libovsdbutil.GetL4MatchesFromNetworkPolicyPorts(rule.ports)
+	}
+	for protocol, l4Match := range l4Matches {
 		if l4Match == libovsdbutil.UnspecifiedL4Match {
 			match = fmt.Sprintf("%s && %s", l3Match, lportMatch)
 		} else {
@@ -235,6 +249,19 @@ func (c *Controller) convertANPRuleToACL(rule *gressRule, pgName, anpName string
 		)
 		acls = append(acls, acl)
 	}
+	for protocol, l3l4Match := range libovsdbutil.GetL3L4MatchesFromNamedPorts(rule.namedPorts) {
+		match = fmt.Sprintf("%s && %s && %s", l3Match, lportMatch, l3l4Match)
+		acl := libovsdbutil.BuildANPACL(
+			getANPRuleACLDbIDs(anpName, rule.gressPrefix, fmt.Sprintf("%d", rule.gressIndex),
+				protocol+libovsdbutil.NamedPortL4MatchSuffix, c.controllerName, isBanp),
+			int(rule.priority),
+			match,
+			rule.action,
+			libovsdbutil.ACLDirectionToACLPipeline(libovsdbutil.ACLDirection(rule.gressPrefix)),
+			aclLoggingParams,
+		)
+		acls = append(acls, acl)
+	}
 
 	return acls
 }
@@ -244,14 +271,15 @@ func (c *Controller) convertANPRuleToACL(rule *gressRule, pgName, anpName string
 func (c *Controller) convertANPPeersToIPs(anp *adminNetworkPolicyState) error {
 	var err error
 	for _, ingressRule := range anp.ingressRules {
-		ingressRule.peerIPs, err = c.convertANPPeersToIPSet(ingressRule.peers)
+		// named ports of ingress rules are resolved against the subject pods, see convertANPSubjectToLSPs
+		ingressRule.peerIPs, err = c.convertANPPeersToIPSet(ingressRule.peers, nil)
 		if err != nil {
 			return fmt.Errorf("unable to create address set for "+
 				" rule %s with priority %d: %w", ingressRule.name, ingressRule.priority, err)
 		}
 	}
 	for _, egressRule := range anp.egressRules {
-		egressRule.peerIPs, err = c.convertANPPeersToIPSet(egressRule.peers)
+		egressRule.peerIPs, err = c.convertANPPeersToIPSet(egressRule.peers, egressRule.namedPorts)
 		if err != nil {
 			return fmt.Errorf("unable to create address set for "+
 				" rule %s with priority %d: %w", egressRule.name, egressRule.priority, err)
@@ -265,7 +293,9 @@ func (c *Controller) convertANPPeersToIPs(anp *adminNetworkPolicyState) error {
 // This function also takes care of populating the adminNetworkPolicyPeer.namespaces cache
 // It also adds up all the peerIPs that are supposed to be present in the created AS and returns them on
 // a per-rule basis so that the actual ops to transact these into the AS can be constructed using that
-func (c *Controller) convertANPPeersToIPSet(peers []*adminNetworkPolicyPeer) (sets.Set[string], error) {
+// If namedPorts is not empty, the named ports are resolved against the container ports of the peer pods
+func (c *Controller) convertANPPeersToIPSet(peers []*adminNetworkPolicyPeer,
+	namedPorts map[string]sets.Set[libovsdbutil.NamedNetworkPolicyPort]) (sets.Set[string], error) {
 	peerIPs := sets.Set[string]{}
 	for _, peer := range peers {
 		namespaces, err := c.anpNamespaceLister.List(peer.namespaceSelector)
@@ -302,6 +332,7 @@ func (c *Controller) convertANPPeersToIPSet(peers []*adminNetworkPolicyPeer) (se
 				}
 				peerIPs.Insert(util.StringSlice(podIPs)...)
 				podCache.Insert(pod.Name)
+				resolveNamedPorts(pod, podIPs, namedPorts)
 			}
 		}
 		peer.namespaces = namespaceCache
@@ -325,8 +356,9 @@ func (c *Controller) convertANPPeersToIPSet(peers []*adminNetworkPolicyPeer) (se
 
 // convertANPSubjectToLSPs calculates all the LSP's that match for the provided anp's subject and returns them
 // It also populates the adminNetworkPolicySubject.namespaces and adminNetworkPolicySubject.podPorts
-// pieces of the cache
-func (c *Controller) convertANPSubjectToLSPs(anpSubject *adminNetworkPolicySubject) ([]*nbdb.LogicalSwitchPort, error) {
+// pieces of the cache and resolves the named ports of the anp's ingress rules against the subject pods
+func (c *Controller) convertANPSubjectToLSPs(anp *adminNetworkPolicyState) ([]*nbdb.LogicalSwitchPort, error) {
+	anpSubject := anp.subject
 	ports := []*nbdb.LogicalSwitchPort{}
 	anpSubject.podPorts = sets.Set[string]{}
 	namespaces, err := c.anpNamespaceLister.List(anpSubject.namespaceSelector)
@@ -346,7 +378,15 @@ func (c *Controller) convertANPSubjectToLSPs(anpSubject *adminNetworkPolicySubje
 			return nil, err
 		}
 		for _, pod := range pods {
-			if util.PodWantsHostNetwork(pod) || util.PodCompleted(pod) || !util.PodScheduled(pod) || !c.isPodScheduledinLocalZone(pod) {
+			if util.PodWantsHostNetwork(pod) || util.PodCompleted(pod) || !util.PodScheduled(pod) {
+				continue
+			}
+			// resolve the named ports for subject pods across all zones so that the
+			// status reported by each zone is consistent; remote pods never hit these ACLs
+			if err := resolveNamedPortsForIngressRules(pod, anp.ingressRules); err != nil {
+				return nil, err
+			}
+			if !c.isPodScheduledinLocalZone(pod) {
 				continue
 			}
 			logicalPortName := util.GetLogicalPortName(pod.Namespace, pod.Name)
@@ -377,6 +417,38 @@ func (c *Controller) convertANPSubjectToLSPs(anpSubject *adminNetworkPolicySubje
 	return ports, nil
 }
 
+// resolveNamedPortsForIngressRules resolves the named ports of the provided ingress rules
+// against the container ports of the given subject pod
+func resolveNamedPortsForIngressRules(pod *v1.Pod, ingressRules []*gressRule) error {
+	var podIPs []net.IP
+	for _, rule := range ingressRules {
+		if len(rule.namedPorts) == 0 {
+			continue
+		}
+		if podIPs == nil {
+			var err error
+			podIPs, err = util.GetPodIPsOfNetwork(pod, &util.DefaultNetInfo{})
+			if err != nil {
+				if errors.Is(err, util.ErrNoPodIPFound) {
+					// pod update will take care of this once the pod gets its IPs
+					return nil
+				}
+				return err
+			}
+		}
+		resolveNamedPorts(pod, podIPs, rule.namedPorts)
+	}
+	return nil
+}
+
+// resolveNamedPorts adds to the provided namedPorts cache the ports of the given pod
+// that are named after any of its keys
+func resolveNamedPorts(pod *v1.Pod, podIPs []net.IP, namedPorts map[string]sets.Set[libovsdbutil.NamedNetworkPolicyPort]) {
+	for portName, resolvedPorts := range namedPorts {
+		resolvedPorts.Insert(libovsdbutil.GetNamedNetworkPolicyPorts(pod, podIPs, portName)...)
+	}
+}
+
 // clearAdminNetworkPolicy will handle the logic for deleting all db objects related
 // to the provided anp which got deleted.
 // uses externalIDs to figure out ownership
@@ -506,7 +578,7 @@ func (c *Controller) updateExistingANP(currentANPState, desiredANPState *adminNe
 	// Did ANP.Spec.Egress rules get updated?
 	// (at this stage the length of ANP.Spec.Egress hasn't changed, so individual rules either got updated at their values or positions are switched)
 	// The fields that we care about for rebuilding ACLs are
-	// (i) `ports` (ii) `actions` (iii) priority for a given rule
+	// (i) `ports` (ii) `actions` (iii) priority (iv) `networks` peers for a given rule
 	// Did the ANP.Spec.Egress.Peers Change?
 	// 1) ANP.Spec.Egress.Peers.Namespaces changed && ||
 	// 2) ANP.Spec.Egress.Peers.Pods changed && ||
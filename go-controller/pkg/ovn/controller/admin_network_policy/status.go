@@ -3,7 +3,10 @@ package adminnetworkpolicy
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
@@ -42,8 +45,57 @@ const (
 	// Defined status.reason fields for (Baseline)Admin Network Policy
 	policyReadyReason    = "SetupSucceeded"
 	policyNotReadyReason = "SetupFailed"
+	// Defined status.message fields for (Baseline)Admin Network Policy
+	policyReadyMessage = "Setting up OVN DB plumbing was successful"
 )
 
+// getPolicyReadyMessage returns the status.message for a ready policy. Named ports that don't match any
+// container port of the selected pods are reported in the message since the rules using them are
+// plumbed but won't match any traffic for those ports until such a pod shows up. Networks peers of an
+// IP family the cluster doesn't support are reported as well since they are left out of the rules.
+func getPolicyReadyMessage(policyState *adminNetworkPolicyState) string {
+	if policyState == nil {
+		return policyReadyMessage
+	}
+	unresolved := []string{}
+	unsupported := []string{}
+	for _, rules := range [][]*gressRule{policyState.ingressRules, policyState.egressRules} {
+		for _, rule := range rules {
+			names := []string{}
+			for name, resolvedPorts := range rule.namedPorts {
+				if len(resolvedPorts) == 0 {
+					names = append(names, name)
+				}
+			}
+			if len(names) > 0 {
+				sort.Strings(names)
+				unresolved = append(unresolved, fmt.Sprintf("%s rule %d (%s): %s",
+					rule.gressPrefix, rule.gressIndex, rule.name, strings.Join(names, ",")))
+			}
+			networks := []string{}
+			v4Networks, v6Networks := splitNetworksByIPFamily(rule.getNetworks())
+			if !config.IPv4Mode {
+				networks = append(networks, v4Networks...)
+			}
+			if !config.IPv6Mode {
+				networks = append(networks, v6Networks...)
+			}
+			if len(networks) > 0 {
+				unsupported = append(unsupported, fmt.Sprintf("%s rule %d (%s): %s",
+					rule.gressPrefix, rule.gressIndex, rule.name, strings.Join(networks, ",")))
+			}
+		}
+	}
+	message := policyReadyMessage
+	if len(unresolved) > 0 {
+		message = fmt.Sprintf("%s; named ports not found on any selected pod: %s", message, strings.Join(unresolved, "; "))
+	}
+	if len(unsupported) > 0 {
+		message = fmt.Sprintf("%s; networks of an unsupported IP family: %s", message, strings.Join(unsupported, "; "))
+	}
+	return message
+}
+
 // updateANPStatusToReady updates the status of the policy to reflect that it is ready
 // Each zone's ovnkube-controller will call this, hence let's update status using retryWithConflict
 func (c *Controller) updateANPStatusToReady(anp *anpapi.AdminNetworkPolicy, zone string) error {
@@ -57,7 +109,7 @@ func (c *Controller) updateANPStatusToReady(anp *anpapi.AdminNetworkPolicy, zone
 			Type:    policyReadyStatusType + zone,
 			Status:  metav1.ConditionTrue,
 			Reason:  policyReadyReason,
-			Message: "Setting up OVN DB plumbing was successful",
+			Message: getPolicyReadyMessage(c.anpCache[anp.Name]),
 		})
 		_, err = c.anpClientSet.PolicyV1alpha1().AdminNetworkPolicies().UpdateStatus(context.TODO(), canp, metav1.UpdateOptions{})
 		return err
@@ -112,7 +164,7 @@ func (c *Controller) updateBANPStatusToReady(banp *anpapi.BaselineAdminNetworkPo
 			Type:    policyReadyStatusType + zone,
 			Status:  metav1.ConditionTrue,
 			Reason:  policyReadyReason,
-			Message: "Setting up OVN DB plumbing was successful",
+			Message: getPolicyReadyMessage(c.banpCache),
 		})
 		_, err = c.anpClientSet.PolicyV1alpha1().BaselineAdminNetworkPolicies().UpdateStatus(context.TODO(), cbanp, metav1.UpdateOptions{})
 		return err
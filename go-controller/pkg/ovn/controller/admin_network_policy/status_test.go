@@ -14,9 +14,11 @@ import (
 	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
 	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/tools/record"
+	utilpointer "k8s.io/utils/pointer"
 	anpapi "sigs.k8s.io/network-policy-api/apis/v1alpha1"
 	anpfake "sigs.k8s.io/network-policy-api/pkg/client/clientset/versioned/fake"
 )
@@ -214,3 +216,93 @@ func TestAddOrUpdateAdminNetworkPolicyStatus(t *testing.T) {
 	g.Expect(banp.Status.Conditions[1].Reason).To(gomega.Equal(policyReadyReason))
 	g.Expect(banp.Status.Conditions[1].Status).To(gomega.Equal(metav1.ConditionTrue))
 }
+
+func TestGetPolicyReadyMessage(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	g.Expect(getPolicyReadyMessage(nil)).To(gomega.Equal(policyReadyMessage))
+
+	namedPorts := []anpapi.AdminNetworkPolicyPort{
+		{NamedPort: utilpointer.String("web")},
+		{NamedPort: utilpointer.String("dns")},
+		{PortNumber: &anpapi.Port{Protocol: v1.ProtocolTCP, Port: 8080}},
+	}
+	anp := initialANP.DeepCopy()
+	anp.Spec.Ingress = []anpapi.AdminNetworkPolicyIngressRule{
+		{
+			Name:   "allow-web",
+			Action: anpapi.AdminNetworkPolicyRuleActionAllow,
+			Ports:  &namedPorts,
+		},
+	}
+	anp.Spec.Egress = []anpapi.AdminNetworkPolicyEgressRule{
+		{
+			Name:   "deny-web",
+			Action: anpapi.AdminNetworkPolicyRuleActionDeny,
+			Ports:  &namedPorts,
+		},
+	}
+	anpState, err := newAdminNetworkPolicyState(anp)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(anpState.ingressRules[0].ports).To(gomega.HaveLen(1))
+	g.Expect(anpState.ingressRules[0].namedPorts).To(gomega.HaveLen(2))
+	g.Expect(getPolicyReadyMessage(anpState)).To(gomega.Equal(policyReadyMessage +
+		"; named ports not found on any selected pod: Ingress rule 0 (allow-web): dns,web; Egress rule 0 (deny-web): dns,web"))
+
+	anpState.ingressRules[0].namedPorts["web"].Insert(libovsdbutil.NamedNetworkPolicyPort{
+		L4Protocol: "tcp", L3PodIP: "10.128.1.3", L3PodIPFamily: "ip4", L4PodPort: 8080})
+	anpState.egressRules[0].namedPorts["web"].Insert(libovsdbutil.NamedNetworkPolicyPort{
+		L4Protocol: "tcp", L3PodIP: "10.128.1.4", L3PodIPFamily: "ip4", L4PodPort: 8080})
+	anpState.egressRules[0].namedPorts["dns"].Insert(libovsdbutil.NamedNetworkPolicyPort{
+		L4Protocol: "udp", L3PodIP: "10.128.1.4", L3PodIPFamily: "ip4", L4PodPort: 5353})
+	g.Expect(getPolicyReadyMessage(anpState)).To(gomega.Equal(policyReadyMessage +
+		"; named ports not found on any selected pod: Ingress rule 0 (allow-web): dns"))
+}
+
+func TestGetPolicyReadyMessageWithNetworks(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config.IPv4Mode = true
+	config.IPv6Mode = false
+	defer func() {
+		config.IPv4Mode = false
+		config.IPv6Mode = false
+	}()
+
+	anp := initialANP.DeepCopy()
+	anp.Spec.Egress = []anpapi.AdminNetworkPolicyEgressRule{
+		{
+			Name:   "deny-external",
+			Action: anpapi.AdminNetworkPolicyRuleActionDeny,
+			To: []anpapi.AdminNetworkPolicyEgressPeer{
+				{
+					Networks: []anpapi.CIDR{"10.0.0.1/8", "fd00::/8"},
+				},
+				{
+					Networks: []anpapi.CIDR{"192.168.1.0/24"},
+				},
+			},
+		},
+		{
+			Name:   "allow-dns",
+			Action: anpapi.AdminNetworkPolicyRuleActionAllow,
+			To: []anpapi.AdminNetworkPolicyEgressPeer{
+				{
+					Networks: []anpapi.CIDR{"8.8.8.8/32"},
+				},
+			},
+		},
+	}
+	anpState, err := newAdminNetworkPolicyState(anp)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	// networks are normalized
+	g.Expect(anpState.egressRules[0].getNetworks()).To(gomega.Equal([]string{"10.0.0.0/8", "192.168.1.0/24", "fd00::/8"}))
+	g.Expect(getPolicyReadyMessage(anpState)).To(gomega.Equal(policyReadyMessage +
+		"; networks of an unsupported IP family: Egress rule 0 (deny-external): fd00::/8"))
+
+	config.IPv6Mode = true
+	g.Expect(getPolicyReadyMessage(anpState)).To(gomega.Equal(policyReadyMessage))
+
+	anp.Spec.Egress[1].To[0].Networks = []anpapi.CIDR{"8.8.8.8"}
+	_, err = newAdminNetworkPolicyState(anp)
+	g.Expect(err).To(gomega.HaveOccurred())
+	g.Expect(err.Error()).To(gomega.ContainSubstring(`invalid network "8.8.8.8"`))
+}
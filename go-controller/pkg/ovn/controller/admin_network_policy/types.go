@@ -1,6 +1,10 @@
 package adminnetworkpolicy
 
 import (
+	"fmt"
+	"net"
+	"sort"
+
 	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -49,6 +53,8 @@ type adminNetworkPolicyPeer struct {
 	namespaces map[string]sets.Set[string]
 	// set of nodes matching the provided nodeSelector
 	nodes sets.Set[string]
+	// set of CIDRs provided as networks peers
+	networks sets.Set[string]
 }
 
 type gressRule struct {
@@ -63,10 +69,26 @@ type gressRule struct {
 	action string
 	peers  []*adminNetworkPolicyPeer
 	ports  []*libovsdbutil.NetworkPolicyPort
+	// namedPorts stores the named ports of this rule resolved against the selected pods
+	// {K: name of the port; V: {set of resolved ports for each of the selected pods exposing it}}
+	// ingress rules resolve these against the subject pods while egress rules resolve them against the peer pods
+	namedPorts map[string]sets.Set[libovsdbutil.NamedNetworkPolicyPort]
 	// all the peerIPs of the peer entities (pods, nodes) selected by this ANP Rule
 	peerIPs sets.Set[string]
 }
 
+// getNetworks returns the sorted list of CIDRs provided by the networks peers of this rule
+// NOTE: Networks are matched on directly in the ACLs instead of being added to the rule's address-set.
+func (rule *gressRule) getNetworks() []string {
+	networks := sets.New[string]()
+	for _, peer := range rule.peers {
+		networks.Insert(peer.networks.UnsortedList()...)
+	}
+	result := networks.UnsortedList()
+	sort.Strings(result)
+	return result
+}
+
 // adminNetworkPolicyState is the cache that keeps the state of a single
 // admin network policy in the cluster with name being unique
 type adminNetworkPolicyState struct {
@@ -177,16 +199,12 @@ func newAdminNetworkPolicySubject(raw anpapi.AdminNetworkPolicySubject) (*adminN
 
 // newAdminNetworkPolicyPort takes the provided ANP API Port and creates a new corresponding
 // adminNetworkPolicyPort cache object for that Port.
+// NOTE: Named ports can only be resolved per pod, they are tracked using gressRule.namedPorts instead.
 func newAdminNetworkPolicyPort(raw anpapi.AdminNetworkPolicyPort) *libovsdbutil.NetworkPolicyPort {
-	anpPort := &libovsdbutil.NetworkPolicyPort{}
 	if raw.PortNumber != nil {
-		anpPort = libovsdbutil.GetNetworkPolicyPort(raw.PortNumber.Protocol, raw.PortNumber.Port, 0)
-	} else if raw.NamedPort != nil {
-		// TODO: Add support for this
-	} else {
-		anpPort = libovsdbutil.GetNetworkPolicyPort(raw.PortRange.Protocol, raw.PortRange.Start, raw.PortRange.End)
+		return libovsdbutil.GetNetworkPolicyPort(raw.PortNumber.Protocol, raw.PortNumber.Port, 0)
 	}
-	return anpPort
+	return libovsdbutil.GetNetworkPolicyPort(raw.PortRange.Protocol, raw.PortRange.Start, raw.PortRange.End)
 }
 
 // newAdminNetworkPolicyPeer takes the provided ANP API Peer and creates a new corresponding
@@ -229,42 +247,70 @@ func newAdminNetworkPolicyPeer(rawNamespaces *anpapi.NamespacedPeer, rawPods *an
 			podSelector:       peerPodSelector,
 		}
 	}
-	anpPeer.nodeSelector = labels.Nothing() // Nodes are not supported as ingress peers and for egress peers this will get overwritten
+	anpPeer.nodeSelector = labels.Nothing() // doesn't match any nodes
 	return anpPeer, nil
 }
 
+// newAdminNetworkPolicyNodePeer takes the provided ANP API Nodes Peer and creates a new corresponding
+// adminNetworkPolicyPeer cache object for that Peer.
+func newAdminNetworkPolicyNodePeer(rawNodes *metav1.LabelSelector) (*adminNetworkPolicyPeer, error) {
+	peerNodeSelector, err := metav1.LabelSelectorAsSelector(rawNodes)
+	if err != nil {
+		return nil, err
+	}
+	if peerNodeSelector.Empty() {
+		peerNodeSelector = labels.Everything() // matches all nodes
+	}
+	return &adminNetworkPolicyPeer{
+		namespaceSelector: labels.Nothing(), // doesn't match any namespaces
+		podSelector:       labels.Nothing(), // doesn't match any pods
+		nodeSelector:      peerNodeSelector,
+	}, nil
+}
+
+// newAdminNetworkPolicyNetworksPeer takes the provided ANP API Networks Peer and creates a new corresponding
+// adminNetworkPolicyPeer cache object for that Peer.
+func newAdminNetworkPolicyNetworksPeer(rawNetworks []anpapi.CIDR) (*adminNetworkPolicyPeer, error) {
+	networks := sets.New[string]()
+	for _, rawNetwork := range rawNetworks {
+		_, ipNet, err := net.ParseCIDR(string(rawNetwork))
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q: %v", rawNetwork, err)
+		}
+		networks.Insert(ipNet.String())
+	}
+	return &adminNetworkPolicyPeer{
+		namespaceSelector: labels.Nothing(), // doesn't match any namespaces
+		podSelector:       labels.Nothing(), // doesn't match any pods
+		nodeSelector:      labels.Nothing(), // doesn't match any nodes
+		networks:          networks,
+	}, nil
+}
+
 // newAdminNetworkPolicyIngressPeer takes the provided ANP API Peer and creates a new corresponding
 // adminNetworkPolicyPeer cache object for that Peer.
+// NOTE: Nodes peers for ingress rules are not implemented: no released v1alpha1 API (up to v0.1.5) defines them.
+// Once it does they can be created using newAdminNetworkPolicyNodePeer, and clearNodeForANP and setNodeForANP
+// must then check the ingress rules too.
 func newAdminNetworkPolicyIngressPeer(raw anpapi.AdminNetworkPolicyIngressPeer) (*adminNetworkPolicyPeer, error) {
+	if raw.Namespaces == nil && raw.Pods == nil {
+		return nil, fmt.Errorf("unsupported ingress peer: none of the known peer types is set")
+	}
 	return newAdminNetworkPolicyPeer(raw.Namespaces, raw.Pods)
 }
 
 // newAdminNetworkPolicyEgressPeer takes the provided ANP API Peer and creates a new corresponding
 // adminNetworkPolicyPeer cache object for that Peer.
 func newAdminNetworkPolicyEgressPeer(raw anpapi.AdminNetworkPolicyEgressPeer) (*adminNetworkPolicyPeer, error) {
-	var anpPeer *adminNetworkPolicyPeer
-	if raw.Namespaces != nil || raw.Pods != nil {
+	switch {
+	case raw.Namespaces != nil || raw.Pods != nil:
 		return newAdminNetworkPolicyPeer(raw.Namespaces, raw.Pods)
-	} else if raw.Nodes != nil {
-		peerNodeSelector, err := metav1.LabelSelectorAsSelector(raw.Nodes)
-		if err != nil {
-			return nil, err
-		}
-		if !peerNodeSelector.Empty() {
-			anpPeer = &adminNetworkPolicyPeer{
-				namespaceSelector: labels.Nothing(), // doesn't match any namespaces
-				podSelector:       labels.Nothing(), // doesn't match any pods
-				nodeSelector:      peerNodeSelector,
-			}
-		} else {
-			anpPeer = &adminNetworkPolicyPeer{
-				namespaceSelector: labels.Nothing(),    // doesn't match any namespaces
-				podSelector:       labels.Nothing(),    // doesn't match any pods
-				nodeSelector:      labels.Everything(), // matches all nodes
-			}
-		}
+	case raw.Nodes != nil:
+		return newAdminNetworkPolicyNodePeer(raw.Nodes)
+	case len(raw.Networks) > 0:
+		return newAdminNetworkPolicyNetworksPeer(raw.Networks)
 	}
-	return anpPeer, nil
+	return nil, fmt.Errorf("unsupported egress peer: none of the known peer types is set")
 }
 
 // newAdminNetworkPolicyIngressRule takes the provided ANP API Ingress Rule and creates a new corresponding
@@ -278,6 +324,7 @@ func newAdminNetworkPolicyIngressRule(raw anpapi.AdminNetworkPolicyIngressRule,
 		gressPrefix: string(libovsdbutil.ACLIngress),
 		peers:       make([]*adminNetworkPolicyPeer, 0),
 		ports:       make([]*libovsdbutil.NetworkPolicyPort, 0),
+		namedPorts:  make(map[string]sets.Set[libovsdbutil.NamedNetworkPolicyPort]),
 	}
 	for _, peer := range raw.From {
 		anpPeer, err := newAdminNetworkPolicyIngressPeer(peer)
@@ -288,6 +335,10 @@ func newAdminNetworkPolicyIngressRule(raw anpapi.AdminNetworkPolicyIngressRule,
 	}
 	if raw.Ports != nil {
 		for _, port := range *raw.Ports {
+			if port.NamedPort != nil {
+				anpRule.namedPorts[*port.NamedPort] = sets.New[libovsdbutil.NamedNetworkPolicyPort]()
+				continue
+			}
 			anpPort := newAdminNetworkPolicyPort(port)
 			anpRule.ports = append(anpRule.ports, anpPort)
 		}
@@ -307,6 +358,7 @@ func newAdminNetworkPolicyEgressRule(raw anpapi.AdminNetworkPolicyEgressRule, in
 		gressPrefix: string(libovsdbutil.ACLEgress),
 		peers:       make([]*adminNetworkPolicyPeer, 0),
 		ports:       make([]*libovsdbutil.NetworkPolicyPort, 0),
+		namedPorts:  make(map[string]sets.Set[libovsdbutil.NamedNetworkPolicyPort]),
 	}
 	for _, peer := range raw.To {
 		anpPeer, err := newAdminNetworkPolicyEgressPeer(peer)
@@ -317,6 +369,10 @@ func newAdminNetworkPolicyEgressRule(raw anpapi.AdminNetworkPolicyEgressRule, in
 	}
 	if raw.Ports != nil {
 		for _, port := range *raw.Ports {
+			if port.NamedPort != nil {
+				anpRule.namedPorts[*port.NamedPort] = sets.New[libovsdbutil.NamedNetworkPolicyPort]()
+				continue
+			}
 			anpPort := newAdminNetworkPolicyPort(port)
 			anpRule.ports = append(anpRule.ports, anpPort)
 		}
@@ -382,6 +438,7 @@ func newBaselineAdminNetworkPolicyIngressRule(raw anpapi.BaselineAdminNetworkPol
 		gressPrefix: string(libovsdbutil.ACLIngress),
 		peers:       make([]*adminNetworkPolicyPeer, 0),
 		ports:       make([]*libovsdbutil.NetworkPolicyPort, 0),
+		namedPorts:  make(map[string]sets.Set[libovsdbutil.NamedNetworkPolicyPort]),
 	}
 	for _, peer := range raw.From {
 		anpPeer, err := newAdminNetworkPolicyIngressPeer(peer)
@@ -392,6 +449,10 @@ func newBaselineAdminNetworkPolicyIngressRule(raw anpapi.BaselineAdminNetworkPol
 	}
 	if raw.Ports != nil {
 		for _, port := range *raw.Ports {
+			if port.NamedPort != nil {
+				banpRule.namedPorts[*port.NamedPort] = sets.New[libovsdbutil.NamedNetworkPolicyPort]()
+				continue
+			}
 			anpPort := newAdminNetworkPolicyPort(port)
 			banpRule.ports = append(banpRule.ports, anpPort)
 		}
@@ -411,6 +472,7 @@ func newBaselineAdminNetworkPolicyEgressRule(raw anpapi.BaselineAdminNetworkPoli
 		gressPrefix: string(libovsdbutil.ACLEgress),
 		peers:       make([]*adminNetworkPolicyPeer, 0),
 		ports:       make([]*libovsdbutil.NetworkPolicyPort, 0),
+		namedPorts:  make(map[string]sets.Set[libovsdbutil.NamedNetworkPolicyPort]),
 	}
 	for _, peer := range raw.To {
 		banpPeer, err := newAdminNetworkPolicyEgressPeer(peer)
@@ -421,6 +483,10 @@ func newBaselineAdminNetworkPolicyEgressRule(raw anpapi.BaselineAdminNetworkPoli
 	}
 	if raw.Ports != nil {
 		for _, port := range *raw.Ports {
+			if port.NamedPort != nil {
+				banpRule.namedPorts[*port.NamedPort] = sets.New[libovsdbutil.NamedNetworkPolicyPort]()
+				continue
+			}
 			banpPort := newAdminNetworkPolicyPort(port)
 			banpRule.ports = append(banpRule.ports, banpPort)
 		}
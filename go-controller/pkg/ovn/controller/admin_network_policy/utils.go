@@ -3,6 +3,7 @@ package adminnetworkpolicy
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
@@ -13,6 +14,7 @@ import (
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	utilnet "k8s.io/utils/net"
 	anpapi "sigs.k8s.io/network-policy-api/apis/v1alpha1"
 )
 
@@ -89,25 +91,44 @@ func GetANPPeerAddrSetDbIDs(name, gressPrefix, gressIndex, controller string, is
 }
 
 // constructMatchFromAddressSet returns the L3Match for an ACL constructed from a gressRule
-func constructMatchFromAddressSet(gressPrefix string, addrSetIndex *libovsdbops.DbObjectIDs) string {
+// The CIDRs of the rule's networks peers are matched on along with the rule's address-set
+func constructMatchFromAddressSet(gressPrefix string, addrSetIndex *libovsdbops.DbObjectIDs, networks []string) string {
 	hashedAddressSetNameIPv4, hashedAddressSetNameIPv6 := addressset.GetHashNamesForAS(addrSetIndex)
-	var direction, match string
+	var direction string
 	if gressPrefix == string(libovsdbutil.ACLIngress) {
 		direction = "src"
 	} else {
 		direction = "dst"
 	}
 
-	switch {
-	case config.IPv4Mode && config.IPv6Mode:
-		match = fmt.Sprintf("(ip4.%s == $%s || ip6.%s == $%s)", direction, hashedAddressSetNameIPv4, direction, hashedAddressSetNameIPv6)
-	case config.IPv4Mode:
-		match = fmt.Sprintf("(ip4.%s == $%s)", direction, hashedAddressSetNameIPv4)
-	case config.IPv6Mode:
-		match = fmt.Sprintf("(ip6.%s == $%s)", direction, hashedAddressSetNameIPv6)
+	v4Networks, v6Networks := splitNetworksByIPFamily(networks)
+	matches := []string{}
+	if config.IPv4Mode {
+		matches = append(matches, fmt.Sprintf("ip4.%s == $%s", direction, hashedAddressSetNameIPv4))
+		if len(v4Networks) > 0 {
+			matches = append(matches, fmt.Sprintf("ip4.%s == {%s}", direction, strings.Join(v4Networks, ", ")))
+		}
+	}
+	if config.IPv6Mode {
+		matches = append(matches, fmt.Sprintf("ip6.%s == $%s", direction, hashedAddressSetNameIPv6))
+		if len(v6Networks) > 0 {
+			matches = append(matches, fmt.Sprintf("ip6.%s == {%s}", direction, strings.Join(v6Networks, ", ")))
+		}
 	}
 
-	return fmt.Sprintf("(%s)", match)
+	return fmt.Sprintf("((%s))", strings.Join(matches, " || "))
+}
+
+// splitNetworksByIPFamily splits the provided CIDRs into IPv4 and IPv6 ones
+func splitNetworksByIPFamily(networks []string) (v4Networks, v6Networks []string) {
+	for _, network := range networks {
+		if utilnet.IsIPv6CIDRString(network) {
+			v6Networks = append(v6Networks, network)
+		} else {
+			v4Networks = append(v4Networks, network)
+		}
+	}
+	return v4Networks, v6Networks
 }
 
 // getACLLoggingLevelsForANP takes the ANP's annotations:
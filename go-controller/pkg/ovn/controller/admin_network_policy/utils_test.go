@@ -6,8 +6,10 @@ import (
 	"testing"
 
 	"github.com/onsi/gomega"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
 
@@ -130,3 +132,59 @@ func TestGetACLLoggingLevelsForANP(t *testing.T) {
 	}
 
 }
+
+func TestConstructMatchFromAddressSet(t *testing.T) {
+	asIndex := GetANPPeerAddrSetDbIDs("harry-potter", string(libovsdbutil.ACLEgress), "0", "default-network-controller", false)
+	asv4, asv6 := addressset.GetHashNamesForAS(asIndex)
+	tests := []struct {
+		name        string
+		gressPrefix string
+		ipv4Mode    bool
+		ipv6Mode    bool
+		networks    []string
+		expected    string
+	}{
+		{
+			name:        "dual-stack egress rule without networks",
+			gressPrefix: string(libovsdbutil.ACLEgress),
+			ipv4Mode:    true,
+			ipv6Mode:    true,
+			expected:    fmt.Sprintf("((ip4.dst == $%s || ip6.dst == $%s))", asv4, asv6),
+		},
+		{
+			name:        "single-stack ingress rule without networks",
+			gressPrefix: string(libovsdbutil.ACLIngress),
+			ipv4Mode:    true,
+			expected:    fmt.Sprintf("((ip4.src == $%s))", asv4),
+		},
+		{
+			name:        "dual-stack egress rule with networks",
+			gressPrefix: string(libovsdbutil.ACLEgress),
+			ipv4Mode:    true,
+			ipv6Mode:    true,
+			networks:    []string{"10.0.0.0/8", "192.168.1.0/24", "fd00::/8"},
+			expected: fmt.Sprintf("((ip4.dst == $%s || ip4.dst == {10.0.0.0/8, 192.168.1.0/24} || ip6.dst == $%s || ip6.dst == {fd00::/8}))",
+				asv4, asv6),
+		},
+		{
+			name:        "single-stack egress rule ignores networks of the other IP family",
+			gressPrefix: string(libovsdbutil.ACLEgress),
+			ipv6Mode:    true,
+			networks:    []string{"10.0.0.0/8", "fd00::/8"},
+			expected:    fmt.Sprintf("((ip6.dst == $%s || ip6.dst == {fd00::/8}))", asv6),
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(fmt.Sprintf("%d_%s", i, tt.name), func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			config.IPv4Mode = tt.ipv4Mode
+			config.IPv6Mode = tt.ipv6Mode
+			defer func() {
+				config.IPv4Mode = false
+				config.IPv6Mode = false
+			}()
+			g.Expect(constructMatchFromAddressSet(tt.gressPrefix, asIndex, tt.networks)).To(gomega.Equal(tt.expected))
+		})
+	}
+}
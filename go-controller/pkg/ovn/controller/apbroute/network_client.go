@@ -337,7 +337,7 @@ func (nb *northBoundClient) addHybridRoutePolicyForPod(podIP net.IP, node string
 
 		var matchDst string
 		var clusterL3Prefix string
-		for _, clusterSubnet := range config.Default.ClusterSubnets {
+		for _, clusterSubnet := range config.ClusterSubnets() {
 			if utilnet.IsIPv6CIDR(clusterSubnet.CIDR) {
 				clusterL3Prefix = "ip6"
 			} else {
@@ -571,7 +571,7 @@ func (nb *northBoundClient) delHybridRoutePolicyForPod(podIP net.IP, node string
 	if deletePolicy {
 		var matchDst string
 		var clusterL3Prefix string
-		for _, clusterSubnet := range config.Default.ClusterSubnets {
+		for _, clusterSubnet := range config.ClusterSubnets() {
 			if utilnet.IsIPv6CIDR(clusterSubnet.CIDR) {
 				clusterL3Prefix = "ip6"
 			} else {
@@ -2,6 +2,7 @@ package services
 
 import (
 	"fmt"
+	"net"
 	"reflect"
 	"strings"
 
@@ -23,6 +24,25 @@ import (
 const placeholderNodeIPs = "node"
 const localWithFallbackAnnotation = "traffic-policy.network.alpha.openshift.io/local-with-fallback"
 
+// internalTrafficPolicyAnnotation set to internalTrafficPolicyPreferLocal on a
+// service with InternalTrafficPolicy=Cluster makes traffic to its ClusterIPs
+// prefer the endpoints local to the node, falling back to cluster-wide
+// endpoints if there are none.
+const internalTrafficPolicyAnnotation = "k8s.ovn.org/internal-traffic-policy"
+const internalTrafficPolicyPreferLocal = "PreferLocal"
+
+// lbHealthCheckAnnotation opts a service in to OVN load balancer health checks
+// of its pod endpoints, if the feature is enabled.
+const lbHealthCheckAnnotation = "k8s.ovn.org/lb-health-check"
+
+// OVN load balancer health check options, in seconds and number of probes
+const (
+	lbHealthCheckInterval     = "2"
+	lbHealthCheckTimeout      = "1"
+	lbHealthCheckSuccessCount = "2"
+	lbHealthCheckFailureCount = "2"
+)
+
 // lbConfig is the abstract desired load balancer configuration.
 // vips and endpoints are mixed families.
 type lbConfig struct {
@@ -38,8 +58,15 @@ type lbConfig struct {
 	// if true, then vips added on the switch are in "local" mode
 	// that means, remove any non-local endpoints.
 	internalTrafficLocal bool
+	// if true, then vips added on the switch are in "prefer local" mode
+	// that means, remove any non-local endpoints unless there are no
+	// local endpoints.
+	internalTrafficPreferLocal bool
 	// indicates if this LB is configuring service of type NodePort.
 	hasNodePort bool
+	// the logical ports of the pod endpoints indexed by IP, only set
+	// if the endpoints are to be health checked.
+	healthCheckPorts map[string]string
 }
 
 func (c *lbConfig) makeNodeSwitchTargetIPs(service *v1.Service, node *nodeInfo, epIPs []string) (targetIPs []string, changed bool) {
@@ -57,6 +84,14 @@ func (c *lbConfig) makeNodeSwitchTargetIPs(service *v1.Service, node *nodeInfo,
 		targetIPs = util.FilterIPsSlice(targetIPs, node.nodeSubnets(), true)
 	}
 
+	if c.internalTrafficPreferLocal {
+		// for InternalTrafficPolicy=PreferLocal, remove non-local endpoints from the switch targets only,
+		// unless there are no local endpoints: then fallback to all endpoints
+		if localTargetIPs := util.FilterIPsSlice(targetIPs, node.nodeSubnets(), true); len(localTargetIPs) > 0 {
+			targetIPs = localTargetIPs
+		}
+	}
+
 	// OCP HACK BEGIN
 	if _, set := service.Annotations[localWithFallbackAnnotation]; set && c.externalTrafficLocal {
 		// if service is annotated and is ETP=local, fallback to ETP=cluster on nodes with no local endpoints:
@@ -131,6 +166,7 @@ var protos = []v1.Protocol{
 // - services with host-network endpoints
 // - services with ExternalTrafficPolicy=Local
 // - services with InternalTrafficPolicy=Local
+// - services with InternalTrafficPolicy=PreferLocal (see internalTrafficPolicyAnnotation)
 //
 // Template LBs will be created for
 //   - services with NodePort set but *without* ExternalTrafficPolicy=Local or
@@ -138,6 +174,11 @@ var protos = []v1.Protocol{
 func buildServiceLBConfigs(service *v1.Service, endpointSlices []*discovery.EndpointSlice, useLBGroup, useTemplates bool) (perNodeConfigs, templateConfigs, clusterConfigs []lbConfig) {
 	needsAffinityTimeout := hasSessionAffinityTimeOut(service)
 
+	var healthCheckPorts map[string]string
+	if hasLBHealthChecks(service) {
+		healthCheckPorts = getEndpointLogicalPorts(endpointSlices)
+	}
+
 	// For each svcPort, determine if it will be applied per-node or cluster-wide
 	for _, svcPort := range service.Spec.Ports {
 		eps := util.GetLbEndpoints(endpointSlices, svcPort, service)
@@ -145,6 +186,7 @@ func buildServiceLBConfigs(service *v1.Service, endpointSlices []*discovery.Endp
 		// if ExternalTrafficPolicy or InternalTrafficPolicy is local, then we need to do things a bit differently
 		externalTrafficLocal := (service.Spec.ExternalTrafficPolicy == v1.ServiceExternalTrafficPolicyTypeLocal)
 		internalTrafficLocal := (service.Spec.InternalTrafficPolicy != nil) && (*service.Spec.InternalTrafficPolicy == v1.ServiceInternalTrafficPolicyLocal)
+		internalTrafficPreferLocal := !internalTrafficLocal && hasInternalTrafficPreferLocal(service)
 
 		// NodePort services get a per-node load balancer, but with the node's physical IP as the vip
 		// Thus, the vip "node" will be expanded later.
@@ -194,25 +236,28 @@ func buildServiceLBConfigs(service *v1.Service, endpointSlices []*discovery.Endp
 		// Build the clusterIP config
 		// This is NEVER influenced by ExternalTrafficPolicy
 		clusterIPConfig := lbConfig{
-			protocol:             svcPort.Protocol,
-			inport:               svcPort.Port,
-			vips:                 vips,
-			eps:                  eps,
-			externalTrafficLocal: false, // always false for ClusterIPs
-			internalTrafficLocal: internalTrafficLocal,
-			hasNodePort:          false,
+			protocol:                   svcPort.Protocol,
+			inport:                     svcPort.Port,
+			vips:                       vips,
+			eps:                        eps,
+			externalTrafficLocal:       false, // always false for ClusterIPs
+			internalTrafficLocal:       internalTrafficLocal,
+			internalTrafficPreferLocal: internalTrafficPreferLocal,
+			hasNodePort:                false,
+			healthCheckPorts:           healthCheckPorts,
 		}
 
 		// Normally, the ClusterIP LB is global (on all node switches and routers),
 		// unless any of the following are true:
 		// - Any of the endpoints are host-network
 		// - ETP=local service backed by non-local-host-networked endpoints
+		// - ITP=local or ITP=preferLocal service
 		// - OCP only HACK: It's an openshift-dns:default-dns service
 		//
 		// In that case, we need to create per-node LBs.
-		if hasHostEndpoints(eps.V4IPs) || hasHostEndpoints(eps.V6IPs) || internalTrafficLocal ||
+		if hasHostEndpoints(eps.V4IPs) || hasHostEndpoints(eps.V6IPs) || internalTrafficLocal || internalTrafficPreferLocal ||
 			// OCP only hack begin
-			(service.Namespace == "openshift-dns" && service.Name == "dns-default") {
+			isOCPHackDNSDefault(service) {
 			// OCP only hack end
 			perNodeConfigs = append(perNodeConfigs, clusterIPConfig)
 		} else {
@@ -223,6 +268,21 @@ func buildServiceLBConfigs(service *v1.Service, endpointSlices []*discovery.Endp
 	return
 }
 
+// buildNetworkServiceLBConfigs builds the configs of a service exposed on a
+// secondary network. Only its ClusterIPs are reachable on the network, load
+// balanced to all the endpoints from the endpoint slices of the network.
+func buildNetworkServiceLBConfigs(service *v1.Service, endpointSlices []*discovery.EndpointSlice) (clusterConfigs []lbConfig) {
+	for _, svcPort := range service.Spec.Ports {
+		clusterConfigs = append(clusterConfigs, lbConfig{
+			protocol: svcPort.Protocol,
+			inport:   svcPort.Port,
+			vips:     util.GetClusterIPs(service),
+			eps:      util.GetLbEndpoints(endpointSlices, svcPort, service),
+		})
+	}
+	return
+}
+
 // makeLBName creates the load balancer name - used to minimize churn
 func makeLBName(service *v1.Service, proto v1.Protocol, scope string) string {
 	return fmt.Sprintf("Service_%s/%s_%s_%s",
@@ -231,13 +291,24 @@ func makeLBName(service *v1.Service, proto v1.Protocol, scope string) string {
 	)
 }
 
+// getExternalIDsForLoadBalancer returns the external IDs of the load balancers
+// of the service on the given network.
+func getExternalIDsForLoadBalancer(service *v1.Service, netInfo util.NetInfo) map[string]string {
+	externalIDs := util.ExternalIDsForObject(service)
+	if netInfo.IsSecondary() {
+		externalIDs[types.NetworkExternalID] = netInfo.GetNetworkName()
+	}
+	return externalIDs
+}
+
 // buildClusterLBs takes a list of lbConfigs and aggregates them
 // in to one ovn LB per protocol.
 //
 // It takes a list of (proto:[vips]:port -> [endpoints]) configs and re-aggregates
 // them to a list of (proto:[vip:port -> [endpoint:port]])
 // This load balancer is attached to all node switches. In shared-GW mode, it is also on all routers
-func buildClusterLBs(service *v1.Service, configs []lbConfig, nodeInfos []nodeInfo, useLBGroup bool) []LB {
+// On secondary networks, the load balancer is scoped to the network and attached to its switches.
+func buildClusterLBs(service *v1.Service, configs []lbConfig, nodeInfos []nodeInfo, useLBGroup bool, netInfo util.NetInfo) []LB {
 	var nodeSwitches []string
 	var nodeRouters []string
 	var groups []string
@@ -250,7 +321,11 @@ func buildClusterLBs(service *v1.Service, configs []lbConfig, nodeInfos []nodeIn
 		nodeRouters = make([]string, 0, len(nodeInfos))
 		groups = make([]string, 0)
 
-		for _, node := range nodeInfos {
+		for i, node := range nodeInfos {
+			// the nodes of a layer2 network share the same switch
+			if i > 0 && node.switchName == nodeInfos[i-1].switchName {
+				continue
+			}
 			nodeSwitches = append(nodeSwitches, node.switchName)
 			// For shared gateway, add to the node's GWR as well.
 			// The node may not have a gateway router - it might be waiting initialization, or
@@ -270,9 +345,9 @@ func buildClusterLBs(service *v1.Service, configs []lbConfig, nodeInfos []nodeIn
 			continue
 		}
 		lb := LB{
-			Name:        makeLBName(service, proto, "cluster"),
+			Name:        netInfo.GetNetworkScopedName(makeLBName(service, proto, "cluster")),
 			Protocol:    string(proto),
-			ExternalIDs: util.ExternalIDsForObject(service),
+			ExternalIDs: getExternalIDsForLoadBalancer(service, netInfo),
 			Opts:        lbOpts(service),
 
 			Switches: nodeSwitches,
@@ -281,6 +356,15 @@ func buildClusterLBs(service *v1.Service, configs []lbConfig, nodeInfos []nodeIn
 		}
 
 		for _, config := range cfgs {
+			// OVN can only health check TCP and UDP backends
+			if config.healthCheckPorts != nil && proto != v1.ProtocolSCTP {
+				lb.Opts.HealthCheck = true
+				if lb.IPPortMappings == nil {
+					lb.IPPortMappings = map[string]string{}
+				}
+				addIPPortMappings(lb.IPPortMappings, config, nodeInfos)
+			}
+
 			if config.externalTrafficLocal {
 				klog.Errorf("BUG: service %s/%s has routerLocalMode=true for cluster-wide lbConfig",
 					service.Namespace, service.Name)
@@ -330,6 +414,41 @@ func buildClusterLBs(service *v1.Service, configs []lbConfig, nodeInfos []nodeIn
 	return out
 }
 
+// addIPPortMappings adds to ipPortMappings the logical port and source IP
+// OVN uses to health check each of the pod endpoints of the config. Only the
+// endpoints within the pod subnets of nodeInfos are health checked, the rest
+// are always considered online. The services controller only passes the nodes
+// of its own zone (see nodeTracker.getZoneNodes), as OVN can only health check
+// the endpoints on the local logical switches.
+func addIPPortMappings(ipPortMappings map[string]string, config lbConfig, nodeInfos []nodeInfo) {
+	for _, ips := range [][]string{config.eps.V4IPs, config.eps.V6IPs} {
+		for _, ip := range ips {
+			logicalPort, ok := config.healthCheckPorts[ip]
+			if !ok {
+				continue
+			}
+			epIP := net.ParseIP(ip)
+			for _, node := range nodeInfos {
+				for i := range node.podSubnets {
+					if !node.podSubnets[i].Contains(epIP) {
+						continue
+					}
+					srcIfAddr := util.GetNodeServiceMonitorIfAddr(&node.podSubnets[i])
+					if srcIfAddr == nil {
+						continue
+					}
+					// OVN expects IPv6 addresses in brackets
+					if utilnet.IsIPv6(epIP) {
+						ipPortMappings["["+ip+"]"] = fmt.Sprintf("%s:[%s]", logicalPort, srcIfAddr.IP)
+					} else {
+						ipPortMappings[ip] = fmt.Sprintf("%s:%s", logicalPort, srcIfAddr.IP)
+					}
+				}
+			}
+		}
+	}
+}
+
 // buildTemplateLBs takes a list of lbConfigs and expands them to one template
 // LB per protocol (per address family).
 //
@@ -605,8 +724,8 @@ func buildPerNodeLBs(service *v1.Service, configs []lbConfig, nodes []nodeInfo)
 				switchV6targets := joinHostsPort(config.eps.V6IPs, config.eps.Port)
 
 				// OCP HACK begin
-				// TODO: Remove this hack once we add support for ITP:preferLocal and DNS operator starts using it.
-				if service.Namespace == "openshift-dns" && service.Name == "dns-default" {
+				// TODO: Remove this hack once the DNS operator sets the internal traffic policy annotation.
+				if isOCPHackDNSDefault(service) {
 					// Filter out endpoints that are local to this node.
 					switchV4targetDNSips := util.FilterIPsSlice(config.eps.V4IPs, node.podSubnets, true)
 					switchV6targetDNSips := util.FilterIPsSlice(config.eps.V6IPs, node.podSubnets, true)
@@ -654,7 +773,7 @@ func buildPerNodeLBs(service *v1.Service, configs []lbConfig, nodes []nodeInfo)
 							Targets: targetsETP,
 						})
 					}
-					if config.internalTrafficLocal && util.IsClusterIP(vip) { // ITP only applicable to CIP
+					if (config.internalTrafficLocal || config.internalTrafficPreferLocal) && util.IsClusterIP(vip) { // ITP only applicable to CIP
 						targetsITP := joinHostsPort(switchV4targetips, config.eps.Port)
 						if isv6 {
 							targetsITP = joinHostsPort(switchV6targetips, config.eps.Port)
@@ -813,6 +932,51 @@ func lbOpts(service *v1.Service) LBOpts {
 	return lbOptions
 }
 
+// OCP HACK begin
+// isOCPHackDNSDefault returns true for the openshift-dns/dns-default service
+// as long as it doesn't set internalTrafficPolicyAnnotation: its internal
+// traffic prefers local endpoints until the DNS operator uses the annotation.
+func isOCPHackDNSDefault(service *v1.Service) bool {
+	_, set := service.Annotations[internalTrafficPolicyAnnotation]
+	return !set && service.Namespace == "openshift-dns" && service.Name == "dns-default"
+}
+
+// OCP HACK end
+
+// hasInternalTrafficPreferLocal returns true if the service is annotated to
+// prefer local endpoints for its internal traffic.
+func hasInternalTrafficPreferLocal(service *v1.Service) bool {
+	return service.Annotations[internalTrafficPolicyAnnotation] == internalTrafficPolicyPreferLocal
+}
+
+// hasLBHealthChecks returns true if the service opted in to OVN load balancer
+// health checks and the feature is enabled.
+func hasLBHealthChecks(service *v1.Service) bool {
+	return config.OVNKubernetesFeature.EnableServiceHealthChecks &&
+		service.Annotations[lbHealthCheckAnnotation] == "true"
+}
+
+// getEndpointLogicalPorts returns the logical ports of the pods backing the
+// endpoints, indexed by endpoint IP.
+func getEndpointLogicalPorts(endpointSlices []*discovery.EndpointSlice) map[string]string {
+	logicalPorts := map[string]string{}
+	for _, slice := range endpointSlices {
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.TargetRef == nil || endpoint.TargetRef.Kind != "Pod" {
+				continue
+			}
+			namespace := endpoint.TargetRef.Namespace
+			if namespace == "" {
+				namespace = slice.Namespace
+			}
+			for _, ip := range endpoint.Addresses {
+				logicalPorts[utilnet.ParseIPSloppy(ip).String()] = util.GetLogicalPortName(namespace, endpoint.TargetRef.Name)
+			}
+		}
+	}
+	return logicalPorts
+}
+
 func lbTemplateOpts(service *v1.Service, addressFamily v1.IPFamily) LBOpts {
 	lbOptions := lbOpts(service)
 
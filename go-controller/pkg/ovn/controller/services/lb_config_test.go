@@ -7,6 +7,7 @@ import (
 	"time"
 
 	globalconfig "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	"github.com/stretchr/testify/assert"
@@ -945,11 +946,68 @@ func Test_buildClusterLBs(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "dual stack, health checks",
+			service: defaultService,
+			configs: []lbConfig{
+				{
+					vips:     []string{"1.2.3.4", "fe80::1"},
+					protocol: v1.ProtocolTCP,
+					inport:   80,
+					eps: util.LbEndpoints{
+						V4IPs: []string{"192.168.0.1", "192.168.1.1"},
+						V6IPs: []string{"fe90::1", "fe91::1"},
+						Port:  8080,
+					},
+					healthCheckPorts: map[string]string{
+						"192.168.0.1": "testns_pod-a",
+						"192.168.1.1": "testns_pod-b",
+						"fe90::1":     "testns_pod-a",
+						"fe91::1":     "testns_pod-b",
+					},
+				},
+			},
+			nodeInfos: []nodeInfo{
+				{
+					name:              "node-a",
+					podSubnets:        []net.IPNet{*ovntest.MustParseIPNet("192.168.0.0/24"), *ovntest.MustParseIPNet("fe90::/64")},
+					gatewayRouterName: "gr-node-a",
+					switchName:        "switch-node-a",
+				},
+			},
+			expected: []LB{
+				{
+					Name:        fmt.Sprintf("Service_%s/%s_TCP_cluster", namespace, name),
+					Protocol:    "TCP",
+					ExternalIDs: defaultExternalIDs,
+					Rules: []LBRule{
+						{
+							Source:  Addr{IP: "1.2.3.4", Port: 80},
+							Targets: []Addr{{IP: "192.168.0.1", Port: 8080}, {IP: "192.168.1.1", Port: 8080}},
+						},
+						{
+							Source:  Addr{IP: "fe80::1", Port: 80},
+							Targets: []Addr{{IP: "fe90::1", Port: 8080}, {IP: "fe91::1", Port: 8080}},
+						},
+					},
+					// only the endpoints on the nodes of the zone are health checked
+					IPPortMappings: map[string]string{
+						"192.168.0.1": "testns_pod-a:192.168.0.254",
+						"[fe90::1]":   "testns_pod-a:[fe90::ffff:ffff:ffff:fffe]",
+					},
+
+					Routers:  defaultRouters,
+					Switches: defaultSwitches,
+					Groups:   defaultGroups,
+					Opts:     LBOpts{Reject: true, HealthCheck: true},
+				},
+			},
+		},
 	}
 
 	for i, tt := range tc {
 		t.Run(fmt.Sprintf("%d_%s", i, tt.name), func(t *testing.T) {
-			actual := buildClusterLBs(tt.service, tt.configs, tt.nodeInfos, true)
+			actual := buildClusterLBs(tt.service, tt.configs, tt.nodeInfos, true, &util.DefaultNetInfo{})
 			assert.Equal(t, tt.expected, actual)
 		})
 	}
@@ -1968,6 +2026,313 @@ func Test_buildPerNodeLBs(t *testing.T) {
 	}
 }
 
+func Test_buildPerNodeLBs_InternalTrafficPreferLocal(t *testing.T) {
+	oldClusterSubnet := globalconfig.Default.ClusterSubnets
+	oldServiceCIDRs := globalconfig.Kubernetes.ServiceCIDRs
+	oldGwMode := globalconfig.Gateway.Mode
+	defer func() {
+		globalconfig.Gateway.Mode = oldGwMode
+		globalconfig.Default.ClusterSubnets = oldClusterSubnet
+		globalconfig.Kubernetes.ServiceCIDRs = oldServiceCIDRs
+	}()
+	_, cidr4, _ := net.ParseCIDR("10.128.0.0/16")
+	_, cidr6, _ := net.ParseCIDR("fe00::/64")
+	globalconfig.Default.ClusterSubnets = []globalconfig.CIDRNetworkEntry{{CIDR: cidr4, HostSubnetLength: 26}, {CIDR: cidr6, HostSubnetLength: 26}}
+	_, svcCIDRs, _ := net.ParseCIDR("192.168.0.0/16")
+	globalconfig.Kubernetes.ServiceCIDRs = []*net.IPNet{svcCIDRs}
+
+	name := "foo"
+	namespace := "testns"
+
+	defaultService := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   namespace,
+			Annotations: map[string]string{internalTrafficPolicyAnnotation: internalTrafficPolicyPreferLocal},
+		},
+		Spec: v1.ServiceSpec{
+			Type: v1.ServiceTypeClusterIP,
+		},
+	}
+
+	defaultNodes := []nodeInfo{
+		{
+			name:              "node-a",
+			hostAddresses:     []net.IP{net.ParseIP("10.0.0.1")},
+			gatewayRouterName: "gr-node-a",
+			switchName:        "switch-node-a",
+			podSubnets:        []net.IPNet{{IP: net.ParseIP("10.128.0.0"), Mask: net.CIDRMask(24, 32)}},
+		},
+		{
+			name:              "node-b",
+			hostAddresses:     []net.IP{net.ParseIP("10.0.0.2")},
+			gatewayRouterName: "gr-node-b",
+			switchName:        "switch-node-b",
+			podSubnets:        []net.IPNet{{IP: net.ParseIP("10.128.1.0"), Mask: net.CIDRMask(24, 32)}},
+		},
+		{
+			name:              "node-c",
+			hostAddresses:     []net.IP{net.ParseIP("10.0.0.3")},
+			gatewayRouterName: "gr-node-c",
+			switchName:        "switch-node-c",
+			podSubnets:        []net.IPNet{{IP: net.ParseIP("10.128.2.0"), Mask: net.CIDRMask(24, 32)}},
+		},
+	}
+
+	defaultExternalIDs := map[string]string{
+		types.LoadBalancerKindExternalID:  "Service",
+		types.LoadBalancerOwnerExternalID: fmt.Sprintf("%s/%s", namespace, name),
+	}
+
+	defaultOpts := LBOpts{Reject: true}
+
+	tc := []struct {
+		name     string
+		service  *v1.Service
+		configs  []lbConfig
+		expected []LB
+	}{
+		{
+			name:    "clusterIP + externalIP service, standard pods",
+			service: defaultService,
+			configs: []lbConfig{
+				{
+					vips:     []string{"192.168.1.1", "1.2.3.4"},
+					protocol: v1.ProtocolTCP,
+					inport:   80,
+					eps: util.LbEndpoints{
+						V4IPs: []string{"10.128.0.2", "10.128.1.2"},
+						Port:  8080,
+					},
+					internalTrafficPreferLocal: true,
+				},
+			},
+			expected: []LB{
+				{
+					// node-c has no local endpoints, so its switch falls back
+					// to all the endpoints, same as the routers
+					Name:        "Service_testns/foo_TCP_node_router_node-a_merged",
+					ExternalIDs: defaultExternalIDs,
+					Routers:     []string{"gr-node-a", "gr-node-b", "gr-node-c"},
+					Switches:    []string{"switch-node-c"},
+					Protocol:    "TCP",
+					Rules: []LBRule{
+						{
+							Source:  Addr{IP: "192.168.1.1", Port: 80},
+							Targets: []Addr{{IP: "10.128.0.2", Port: 8080}, {IP: "10.128.1.2", Port: 8080}},
+						},
+						{
+							Source:  Addr{IP: "1.2.3.4", Port: 80},
+							Targets: []Addr{{IP: "10.128.0.2", Port: 8080}, {IP: "10.128.1.2", Port: 8080}},
+						},
+					},
+					Opts: defaultOpts,
+				},
+				{
+					Name:        "Service_testns/foo_TCP_node_switch_node-a",
+					ExternalIDs: defaultExternalIDs,
+					Switches:    []string{"switch-node-a"},
+					Protocol:    "TCP",
+					Rules: []LBRule{
+						{
+							Source:  Addr{IP: "192.168.1.1", Port: 80},
+							Targets: []Addr{{IP: "10.128.0.2", Port: 8080}},
+						},
+						{
+							Source:  Addr{IP: "1.2.3.4", Port: 80},
+							Targets: []Addr{{IP: "10.128.0.2", Port: 8080}, {IP: "10.128.1.2", Port: 8080}},
+						},
+					},
+					Opts: defaultOpts,
+				},
+				{
+					Name:        "Service_testns/foo_TCP_node_switch_node-b",
+					ExternalIDs: defaultExternalIDs,
+					Switches:    []string{"switch-node-b"},
+					Protocol:    "TCP",
+					Rules: []LBRule{
+						{
+							Source:  Addr{IP: "192.168.1.1", Port: 80},
+							Targets: []Addr{{IP: "10.128.1.2", Port: 8080}},
+						},
+						{
+							Source:  Addr{IP: "1.2.3.4", Port: 80},
+							Targets: []Addr{{IP: "10.128.0.2", Port: 8080}, {IP: "10.128.1.2", Port: 8080}},
+						},
+					},
+					Opts: defaultOpts,
+				},
+			},
+		},
+	}
+
+	for i, tt := range tc {
+		t.Run(fmt.Sprintf("%d_%s", i, tt.name), func(t *testing.T) {
+			globalconfig.Gateway.Mode = globalconfig.GatewayModeShared
+			actual := buildPerNodeLBs(tt.service, tt.configs, defaultNodes)
+			assert.Equal(t, tt.expected, actual, "shared gateway mode not as expected")
+
+			globalconfig.Gateway.Mode = globalconfig.GatewayModeLocal
+			actual = buildPerNodeLBs(tt.service, tt.configs, defaultNodes)
+			assert.Equal(t, tt.expected, actual, "local gateway mode not as expected")
+		})
+	}
+}
+
+func Test_buildTemplateLBs(t *testing.T) {
+	oldClusterSubnet := globalconfig.Default.ClusterSubnets
+	defer func() {
+		globalconfig.Default.ClusterSubnets = oldClusterSubnet
+	}()
+	_, cidr4, _ := net.ParseCIDR("10.128.0.0/16")
+	globalconfig.Default.ClusterSubnets = []globalconfig.CIDRNetworkEntry{{CIDR: cidr4, HostSubnetLength: 24}}
+
+	name := "foo"
+	namespace := "testns"
+
+	defaultService := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: v1.ServiceSpec{
+			Type: v1.ServiceTypeNodePort,
+		},
+	}
+
+	defaultNodes := []nodeInfo{
+		{
+			name:          "node-a",
+			hostAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+			chassisID:     "chassis-a",
+			podSubnets:    []net.IPNet{{IP: net.ParseIP("10.128.0.0"), Mask: net.CIDRMask(24, 32)}},
+		},
+		{
+			name:          "node-b",
+			hostAddresses: []net.IP{net.ParseIP("10.0.0.2")},
+			chassisID:     "chassis-b",
+			podSubnets:    []net.IPNet{{IP: net.ParseIP("10.128.1.0"), Mask: net.CIDRMask(24, 32)}},
+		},
+		{
+			name:          "node-c",
+			hostAddresses: []net.IP{net.ParseIP("10.0.0.3")},
+			chassisID:     "chassis-c",
+			podSubnets:    []net.IPNet{{IP: net.ParseIP("10.128.2.0"), Mask: net.CIDRMask(24, 32)}},
+		},
+	}
+
+	nodeIPv4Templates := NewNodeIPsTemplates(v1.IPv4Protocol)
+	for _, node := range defaultNodes {
+		nodeIPv4Templates.AddIP(node.chassisID, node.hostAddresses[0])
+	}
+	nodeIPv4Template := nodeIPv4Templates.AsTemplates()[0]
+	nodeIPv6Templates := NewNodeIPsTemplates(v1.IPv6Protocol)
+
+	defaultExternalIDs := map[string]string{
+		types.LoadBalancerKindExternalID:  "Service",
+		types.LoadBalancerOwnerExternalID: fmt.Sprintf("%s/%s", namespace, name),
+	}
+
+	defaultOpts := LBOpts{Reject: true, Template: true, AddressFamily: v1.IPv4Protocol}
+
+	switchTemplateTarget := makeTemplate(
+		makeLBTargetTemplateName(defaultService, v1.ProtocolTCP, 30080, v1.IPv4Protocol, "node_switch_template"))
+	switchTemplateTarget.Value = map[string]string{
+		"chassis-a": "10.128.0.2:8080",
+		"chassis-b": "10.128.1.2:8080",
+		// node-c has no local endpoints, so it falls back to all the endpoints
+		"chassis-c": "10.128.0.2:8080,10.128.1.2:8080",
+	}
+
+	tc := []struct {
+		name     string
+		configs  []lbConfig
+		expected []LB
+	}{
+		{
+			name: "nodeport service, same targets on all nodes",
+			configs: []lbConfig{
+				{
+					vips:     []string{placeholderNodeIPs},
+					protocol: v1.ProtocolTCP,
+					inport:   30080,
+					eps: util.LbEndpoints{
+						V4IPs: []string{"10.128.0.2", "10.128.1.2"},
+						Port:  8080,
+					},
+					hasNodePort: true,
+				},
+			},
+			expected: []LB{
+				{
+					Name:        "Service_testns/foo_TCP_node_switch_template_IPv4_merged",
+					ExternalIDs: defaultExternalIDs,
+					Groups:      []string{types.ClusterSwitchLBGroupName, types.ClusterRouterLBGroupName},
+					Protocol:    "TCP",
+					Rules: []LBRule{
+						{
+							Source:  Addr{Template: nodeIPv4Template, Port: 30080},
+							Targets: []Addr{{IP: "10.128.0.2", Port: 8080}, {IP: "10.128.1.2", Port: 8080}},
+						},
+					},
+					Templates: TemplateMap{},
+					Opts:      defaultOpts,
+				},
+			},
+		},
+		{
+			name: "prefer local switch targets, per node targets on the switches only",
+			configs: []lbConfig{
+				{
+					vips:     []string{placeholderNodeIPs},
+					protocol: v1.ProtocolTCP,
+					inport:   30080,
+					eps: util.LbEndpoints{
+						V4IPs: []string{"10.128.0.2", "10.128.1.2"},
+						Port:  8080,
+					},
+					hasNodePort:                true,
+					internalTrafficPreferLocal: true,
+				},
+			},
+			expected: []LB{
+				{
+					Name:        "Service_testns/foo_TCP_node_switch_template_IPv4",
+					ExternalIDs: defaultExternalIDs,
+					Groups:      []string{types.ClusterSwitchLBGroupName},
+					Protocol:    "TCP",
+					Rules: []LBRule{
+						{
+							Source:  Addr{Template: nodeIPv4Template, Port: 30080},
+							Targets: []Addr{{Template: switchTemplateTarget}},
+						},
+					},
+					Templates: TemplateMap{switchTemplateTarget.Name: switchTemplateTarget},
+					Opts:      defaultOpts,
+				},
+				{
+					Name:        "Service_testns/foo_TCP_node_router_template_IPv4",
+					ExternalIDs: defaultExternalIDs,
+					Groups:      []string{types.ClusterRouterLBGroupName},
+					Protocol:    "TCP",
+					Rules: []LBRule{
+						{
+							Source:  Addr{Template: nodeIPv4Template, Port: 30080},
+							Targets: []Addr{{IP: "10.128.0.2", Port: 8080}, {IP: "10.128.1.2", Port: 8080}},
+						},
+					},
+					Templates: TemplateMap{},
+					Opts:      defaultOpts,
+				},
+			},
+		},
+	}
+
+	for i, tt := range tc {
+		t.Run(fmt.Sprintf("%d_%s", i, tt.name), func(t *testing.T) {
+			actual := buildTemplateLBs(defaultService, tt.configs, defaultNodes, nodeIPv4Templates, nodeIPv6Templates)
+			assert.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
 func Test_idledServices(t *testing.T) {
 	serviceName := "foo"
 	ns := "testns"
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	libovsdbclient "github.com/ovn-org/libovsdb/client"
+	"github.com/ovn-org/libovsdb/ovsdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
@@ -31,6 +32,10 @@ type LB struct {
 
 	Templates TemplateMap // Templates that this LB uses as backends.
 
+	// IPPortMappings maps the backend IPs to the logical port and source IP
+	// used to health check them, only set if health checks are enabled.
+	IPPortMappings map[string]string
+
 	// the names of logical switches, routers and LB groups that this LB should be attached to
 	Switches []string
 	Routers  []string
@@ -55,6 +60,9 @@ type LBOpts struct {
 
 	// Only useful for template LBs.
 	AddressFamily corev1.IPFamily
+
+	// If true, then the backends are health checked by OVN.
+	HealthCheck bool
 }
 
 type Addr struct {
@@ -91,8 +99,9 @@ func addrsToString(addrs []Addr) string {
 // templateLoadBalancer enriches a NB load balancer record with the
 // associated template maps it requires provisioned in the NB database.
 type templateLoadBalancer struct {
-	nbLB      *nbdb.LoadBalancer
-	templates TemplateMap
+	nbLB         *nbdb.LoadBalancer
+	templates    TemplateMap
+	healthChecks []*nbdb.LoadBalancerHealthCheck
 }
 
 func toNBLoadBalancerList(tlbs []*templateLoadBalancer) []*nbdb.LoadBalancer {
@@ -164,6 +173,11 @@ func EnsureLBs(nbClient libovsdbclient.Client, service *corev1.Service, existing
 			existingRouters = sets.New[string](existingLB.Routers...)
 			existingSwitches = sets.New[string](existingLB.Switches...)
 			existingGroups = sets.New[string](existingLB.Groups...)
+			if existingLB.Opts.HealthCheck && !lb.Opts.HealthCheck {
+				// clear the health checks that are not wanted anymore
+				blb.nbLB.HealthCheck = []string{}
+				blb.nbLB.IPPortMappings = map[string]string{}
+			}
 		}
 		wantRouters := sets.New(lb.Routers...)
 		wantSwitches := sets.New(lb.Switches...)
@@ -176,7 +190,20 @@ func EnsureLBs(nbClient libovsdbclient.Client, service *corev1.Service, existing
 		mapLBDifferenceByKey(removeLBsFromGroups, existingGroups, wantGroups, blb)
 	}
 
-	ops, err := libovsdbops.CreateOrUpdateLoadBalancersOps(nbClient, nil, toNBLoadBalancerList(tlbs)...)
+	var ops []ovsdb.Operation
+	var err error
+	for _, tlb := range tlbs {
+		if tlb.healthChecks == nil {
+			continue
+		}
+		ops, err = libovsdbops.CreateOrUpdateLoadBalancerHealthChecksOps(nbClient, ops, tlb.nbLB, tlb.healthChecks...)
+		if err != nil {
+			return fmt.Errorf("failed to create ops for ensuring health checks of load balancer %s for service %s/%s: %w",
+				tlb.nbLB.Name, service.Namespace, service.Name, err)
+		}
+	}
+
+	ops, err = libovsdbops.CreateOrUpdateLoadBalancersOps(nbClient, ops, toNBLoadBalancerList(tlbs)...)
 	if err != nil {
 		return err
 	}
@@ -385,10 +412,38 @@ func buildLB(lb *LB) *templateLoadBalancer {
 		}
 	}
 
-	return &templateLoadBalancer{
+	tlb := &templateLoadBalancer{
 		nbLB:      libovsdbops.BuildLoadBalancer(lb.Name, strings.ToLower(lb.Protocol), selectionFields, buildVipMap(lb.Rules), options, lb.ExternalIDs),
 		templates: lb.Templates,
 	}
+
+	if lb.Opts.HealthCheck {
+		tlb.healthChecks = buildHealthChecks(lb)
+		tlb.nbLB.IPPortMappings = lb.IPPortMappings
+		if tlb.nbLB.IPPortMappings == nil {
+			tlb.nbLB.IPPortMappings = map[string]string{}
+		}
+	}
+
+	return tlb
+}
+
+// buildHealthChecks returns a health check for each vip of the load balancer
+func buildHealthChecks(lb *LB) []*nbdb.LoadBalancerHealthCheck {
+	hcs := make([]*nbdb.LoadBalancerHealthCheck, 0, len(lb.Rules))
+	for _, r := range lb.Rules {
+		hcs = append(hcs, &nbdb.LoadBalancerHealthCheck{
+			Vip: r.Source.String(),
+			Options: map[string]string{
+				"interval":      lbHealthCheckInterval,
+				"timeout":       lbHealthCheckTimeout,
+				"success_count": lbHealthCheckSuccessCount,
+				"failure_count": lbHealthCheckFailureCount,
+			},
+			ExternalIDs: lb.ExternalIDs,
+		})
+	}
+	return hcs
 }
 
 // buildVipMap returns a viups map from a set of rules
@@ -425,18 +480,19 @@ func DeleteLBs(nbClient libovsdbclient.Client, uuids []string) error {
 	return nil
 }
 
-// getLBs returns a slice of load balancers found in OVN.
-func getLBs(nbClient libovsdbclient.Client, allTemplates TemplateMap) ([]*LB, error) {
-	_, out, err := _getLBsCommon(nbClient, allTemplates, false)
+// getLBs returns a slice of load balancers of the given network found in OVN.
+func getLBs(nbClient libovsdbclient.Client, allTemplates TemplateMap, netInfo util.NetInfo) ([]*LB, error) {
+	_, out, err := _getLBsCommon(nbClient, allTemplates, false, netInfo)
 	return out, err
 }
 
-// getServiceLBs returns a set of services as well as a slice of load balancers found in OVN.
-func getServiceLBs(nbClient libovsdbclient.Client, allTemplates TemplateMap) (sets.Set[string], []*LB, error) {
-	return _getLBsCommon(nbClient, allTemplates, true)
+// getServiceLBs returns a set of services as well as a slice of load balancers
+// of the given network found in OVN.
+func getServiceLBs(nbClient libovsdbclient.Client, allTemplates TemplateMap, netInfo util.NetInfo) (sets.Set[string], []*LB, error) {
+	return _getLBsCommon(nbClient, allTemplates, true, netInfo)
 }
 
-func _getLBsCommon(nbClient libovsdbclient.Client, allTemplates TemplateMap, withServiceOwner bool) (sets.Set[string], []*LB, error) {
+func _getLBsCommon(nbClient libovsdbclient.Client, allTemplates TemplateMap, withServiceOwner bool, netInfo util.NetInfo) (sets.Set[string], []*LB, error) {
 	lbs, err := libovsdbops.ListLoadBalancers(nbClient)
 	if err != nil {
 		return nil, nil, fmt.Errorf("could not list load_balancer: %w", err)
@@ -451,6 +507,11 @@ func _getLBsCommon(nbClient libovsdbclient.Client, allTemplates TemplateMap, wit
 			continue
 		}
 
+		// Skip load balancers of other networks
+		if !isLoadBalancerOnNetwork(lb, netInfo) {
+			continue
+		}
+
 		if withServiceOwner {
 			service, ok := lb.ExternalIDs[types.LoadBalancerOwnerExternalID]
 			if !ok {
@@ -460,12 +521,13 @@ func _getLBsCommon(nbClient libovsdbclient.Client, allTemplates TemplateMap, wit
 		}
 
 		// Note: no need to fill in Opts and Rules: syncServices populates them later.
+		// Only HealthCheck is filled in so that stale health checks get cleared.
 		// Switches, Routers and Groups for each load balancer will get filled in below.
 		res := LB{
 			UUID:        lb.UUID,
 			Name:        lb.Name,
 			ExternalIDs: lb.ExternalIDs,
-			Opts:        LBOpts{},
+			Opts:        LBOpts{HealthCheck: len(lb.HealthCheck) > 0},
 			Rules:       []LBRule{},
 			Templates:   getLoadBalancerTemplates(lb, allTemplates),
 			Switches:    []string{},
@@ -534,3 +596,14 @@ func _getLBsCommon(nbClient libovsdbclient.Client, allTemplates TemplateMap, wit
 
 	return services, out, nil
 }
+
+// isLoadBalancerOnNetwork returns true if the load balancer was configured for
+// the given network: the load balancers of secondary networks are tagged with
+// the network name.
+func isLoadBalancerOnNetwork(lb *nbdb.LoadBalancer, netInfo util.NetInfo) bool {
+	network, ok := lb.ExternalIDs[types.NetworkExternalID]
+	if !netInfo.IsSecondary() {
+		return !ok
+	}
+	return network == netInfo.GetNetworkName()
+}
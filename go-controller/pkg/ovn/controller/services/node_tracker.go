@@ -32,6 +32,9 @@ type nodeTracker struct {
 
 	// zone in which this nodeTracker is tracking
 	zone string
+
+	// the network whose switches and routers are tracked
+	netInfo util.NetInfo
 }
 
 type nodeInfo struct {
@@ -95,11 +98,12 @@ func (ni *nodeInfo) nodeSubnets() []net.IPNet {
 	return out
 }
 
-func newNodeTracker(zone string, resyncFn func(nodes []nodeInfo)) *nodeTracker {
+func newNodeTracker(zone string, resyncFn func(nodes []nodeInfo), netInfo util.NetInfo) *nodeTracker {
 	return &nodeTracker{
 		nodes:    map[string]nodeInfo{},
 		zone:     zone,
 		resyncFn: resyncFn,
+		netInfo:  netInfo,
 	}
 }
 
@@ -223,6 +227,10 @@ func (nt *nodeTracker) removeNode(nodeName string) {
 // The gateway router will exist sometime after the L3Gateway annotation is set.
 func (nt *nodeTracker) updateNode(node *v1.Node) {
 	klog.V(2).Infof("Processing possible switch / router updates for node %s", node.Name)
+	if nt.netInfo.IsSecondary() {
+		nt.updateSecondaryNetworkNode(node)
+		return
+	}
 	hsn, err := util.ParseNodeHostSubnetAnnotation(node, types.DefaultNetworkName)
 	if err != nil || hsn == nil || util.NoHostSubnet(node) {
 		// usually normal; means the node's gateway hasn't been initialized yet
@@ -274,6 +282,52 @@ func (nt *nodeTracker) updateNode(node *v1.Node) {
 	)
 }
 
+// updateSecondaryNetworkNode is called when the switch of a node on a secondary
+// network may have changed. Secondary networks have no gateway routers, so
+// only the switches are tracked: one per node on layer3 networks, a single one
+// shared by all the nodes on layer2 networks.
+func (nt *nodeTracker) updateSecondaryNetworkNode(node *v1.Node) {
+	var switchName string
+	var podSubnets []*net.IPNet
+	switch nt.netInfo.TopologyType() {
+	case types.Layer3Topology:
+		hsn, err := util.ParseNodeHostSubnetAnnotation(node, nt.netInfo.GetNetworkName())
+		if err != nil || hsn == nil || util.NoHostSubnet(node) {
+			klog.Infof("Node %s has invalid / no HostSubnet annotations for network %s (probably waiting on initialization): %v",
+				node.Name, nt.netInfo.GetNetworkName(), err)
+			nt.removeNode(node.Name)
+			return
+		}
+		switchName = nt.netInfo.GetNetworkScopedName(node.Name)
+		podSubnets = hsn
+	case types.Layer2Topology:
+		if util.NoHostSubnet(node) {
+			nt.removeNode(node.Name)
+			return
+		}
+		switchName = nt.netInfo.GetNetworkScopedName(types.OVNLayer2Switch)
+		for _, subnet := range nt.netInfo.Subnets() {
+			podSubnets = append(podSubnets, subnet.CIDR)
+		}
+	default:
+		klog.Warningf("Services are not supported on network %s with topology %s",
+			nt.netInfo.GetNetworkName(), nt.netInfo.TopologyType())
+		return
+	}
+
+	nt.updateNodeInfo(
+		node.Name,
+		switchName,
+		"",
+		"",
+		nil,
+		nil,
+		podSubnets,
+		util.GetNodeZone(node),
+		util.HasNodeMigratedZone(node),
+	)
+}
+
 // getZoneNodes returns a list of all nodes (and their relevant information)
 // which belong to the nodeTracker 'zone'
 // MUST be called with nt locked
@@ -8,6 +8,7 @@ import (
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/labels"
@@ -38,14 +39,18 @@ type repair struct {
 	unsyncedServices sets.Set[string]
 
 	nbClient libovsdbclient.Client
+
+	// the network the load balancers are configured on
+	netInfo util.NetInfo
 }
 
 // NewRepair creates a controller that periodically ensures that there is no stale data in OVN
-func newRepair(serviceLister corelisters.ServiceLister, nbClient libovsdbclient.Client) *repair {
+func newRepair(serviceLister corelisters.ServiceLister, nbClient libovsdbclient.Client, netInfo util.NetInfo) *repair {
 	return &repair{
 		serviceLister:    serviceLister,
 		unsyncedServices: sets.Set[string]{},
 		nbClient:         nbClient,
+		netInfo:          netInfo,
 	}
 }
 
@@ -80,7 +85,7 @@ func (r *repair) runBeforeSync(useTemplates bool) {
 	}
 
 	// Find all load-balancers associated with Services
-	existingLBs, err := getLBs(r.nbClient, allTemplates)
+	existingLBs, err := getLBs(r.nbClient, allTemplates, r.netInfo)
 	if err != nil {
 		klog.Errorf("Unable to get service lbs for repair: %v", err)
 	}
@@ -133,6 +138,10 @@ func (r *repair) runBeforeSync(useTemplates bool) {
 	}
 	klog.V(2).Infof("Deleted %d stale Chassis Template Vars", len(staleTemplateNames))
 
+	if r.netInfo.IsSecondary() {
+		return
+	}
+
 	// Remove existing reject rules. They are not used anymore
 	// given the introduction of idling loadbalancers
 	p := func(item *nbdb.ACL) bool {
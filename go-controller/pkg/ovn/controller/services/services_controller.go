@@ -3,6 +3,7 @@ package services
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -45,23 +46,37 @@ const (
 
 	controllerName     = "ovn-lb-controller"
 	nodeControllerName = "node-tracker-controller"
+
+	// serviceNetworkAnnotation exposes a service on the secondary network of
+	// the referenced network attachment definition, given as <name> in the
+	// namespace of the service or as <namespace>/<name>.
+	serviceNetworkAnnotation = "k8s.v1.cni.cncf.io/service-network"
+	// multusEndpointSliceManagedBy is the managed-by label value of the endpoint
+	// slices of the services exposed on a secondary network. Their endpoints are
+	// the IPs of the pods on that network, as reported in the network-status
+	// annotation of the pods.
+	multusEndpointSliceManagedBy = "multus-endpointslice-controller.npwg.k8s.io"
 )
 
 var NoServiceLabelError = fmt.Errorf("endpointSlice missing %s label", discovery.LabelServiceName)
 
-// NewController returns a new *Controller.
+// NewController returns a new *Controller for the services of the given
+// network. On secondary networks, only the ClusterIPs of the services
+// annotated with serviceNetworkAnnotation are handled.
 func NewController(client clientset.Interface,
 	nbClient libovsdbclient.Client,
 	serviceInformer coreinformers.ServiceInformer,
 	endpointSliceInformer discoveryinformers.EndpointSliceInformer,
 	nodeInformer coreinformers.NodeInformer,
 	recorder record.EventRecorder,
+	netInfo util.NetInfo,
 ) (*Controller, error) {
 	klog.V(4).Info("Creating event broadcaster")
 	c := &Controller{
 		client:                client,
 		nbClient:              nbClient,
-		queue:                 workqueue.NewNamedRateLimitingQueue(newRatelimiter(100), controllerName),
+		netInfo:               netInfo,
+		queue:                 workqueue.NewNamedRateLimitingQueue(newRatelimiter(100), netInfo.GetNetworkScopedName(controllerName)),
 		workerLoopPeriod:      time.Second,
 		alreadyApplied:        map[string][]LB{},
 		nodeIPv4Templates:     NewNodeIPsTemplates(v1.IPv4Protocol),
@@ -71,7 +86,7 @@ func NewController(client clientset.Interface,
 		endpointSliceInformer: endpointSliceInformer,
 		endpointSliceLister:   endpointSliceInformer.Lister(),
 		eventRecorder:         recorder,
-		repair:                newRepair(serviceInformer.Lister(), nbClient),
+		repair:                newRepair(serviceInformer.Lister(), nbClient, netInfo),
 		nodeInformer:          nodeInformer,
 		nodesSynced:           nodeInformer.Informer().HasSynced,
 	}
@@ -82,7 +97,7 @@ func NewController(client clientset.Interface,
 	// load balancers need to be applied to nodes, so
 	// we need to watch Node objects for changes.
 	// Need to re-sync all services when a node gains its switch or GWR
-	c.nodeTracker = newNodeTracker(zone, c.RequestFullSync)
+	c.nodeTracker = newNodeTracker(zone, c.RequestFullSync, netInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -98,6 +113,9 @@ type Controller struct {
 	nbClient      libovsdbclient.Client
 	eventRecorder record.EventRecorder
 
+	// the network the load balancers are configured on
+	netInfo util.NetInfo
+
 	serviceInformer coreinformers.ServiceInformer
 	// serviceLister is able to list/get services and is populated by the shared informer passed to
 	serviceLister corelisters.ServiceLister
@@ -165,13 +183,16 @@ func (c *Controller) Run(workers int, stopCh <-chan struct{}, runRepair, useLBGr
 	c.useLBGroups = useLBGroups
 	c.useTemplates = useTemplates
 
-	klog.Infof("Starting controller %s", controllerName)
-	defer klog.Infof("Shutting down controller %s", controllerName)
+	klog.Infof("Starting controller %s for network %s", controllerName, c.netInfo.GetNetworkName())
+	defer klog.Infof("Shutting down controller %s for network %s", controllerName, c.netInfo.GetNetworkName())
 
 	nodeHandler, err := c.nodeTracker.Start(c.nodeInformer)
 	if err != nil {
 		return err
 	}
+	// the informers are shared with the controllers of the other networks,
+	// which keep running when this one is stopped
+	defer c.removeEventHandler(c.nodeInformer.Informer(), nodeHandler)
 	// We need the node tracker to be synced first, as we rely on it to properly reprogram initial per node load balancers
 	klog.Info("Waiting for node tracker handler to sync")
 	c.startupDoneLock.Lock()
@@ -190,6 +211,7 @@ func (c *Controller) Run(workers int, stopCh <-chan struct{}, runRepair, useLBGr
 	if err != nil {
 		return err
 	}
+	defer c.removeEventHandler(c.serviceInformer.Informer(), svcHandler)
 
 	klog.Info("Setting up event handlers for endpoint slices")
 	endpointHandler, err := c.endpointSliceInformer.Informer().AddEventHandler(factory.WithUpdateHandlingForObjReplace(cache.ResourceEventHandlerFuncs{
@@ -200,6 +222,7 @@ func (c *Controller) Run(workers int, stopCh <-chan struct{}, runRepair, useLBGr
 	if err != nil {
 		return err
 	}
+	defer c.removeEventHandler(c.endpointSliceInformer.Informer(), endpointHandler)
 
 	klog.Info("Waiting for service and endpoint handlers to sync")
 	if !util.WaitForHandlerSyncWithTimeout(controllerName, stopCh, types.HandlerSyncTimeout, svcHandler.HasSynced, endpointHandler.HasSynced) {
@@ -231,6 +254,13 @@ func (c *Controller) Run(workers int, stopCh <-chan struct{}, runRepair, useLBGr
 	return nil
 }
 
+func (c *Controller) removeEventHandler(informer cache.SharedIndexInformer, handle cache.ResourceEventHandlerRegistration) {
+	if err := informer.RemoveEventHandler(handle); err != nil {
+		klog.Errorf("Failed to remove event handler of controller %s for network %s: %v",
+			controllerName, c.netInfo.GetNetworkName(), err)
+	}
+}
+
 // worker runs a worker thread that just dequeues items, processes them, and
 // marks them done. You may run as many of these in parallel as you wish; the
 // workqueue guarantees that they will not end up processing the same service
@@ -299,7 +329,7 @@ func (c *Controller) initTopLevelCache() error {
 	}
 
 	// Then list all load balancers and their respective services.
-	services, lbs, err := getServiceLBs(c.nbClient, allTemplates)
+	services, lbs, err := getServiceLBs(c.nbClient, allTemplates, c.netInfo)
 	if err != nil {
 		return fmt.Errorf("failed to load balancers: %w", err)
 	}
@@ -353,7 +383,9 @@ func (c *Controller) syncService(key string) error {
 	// Delete the Service's LB(s) from OVN if:
 	// - the Service was deleted from the cache (doesn't exist in Kubernetes anymore)
 	// - the Service mutated to a new service Type that we don't handle (ExternalName, Headless)
-	if err != nil || service == nil || !util.ServiceTypeHasClusterIP(service) || !util.IsClusterIPSet(service) {
+	// - the Service is not exposed on the network of this controller
+	if err != nil || service == nil || !util.ServiceTypeHasClusterIP(service) || !util.IsClusterIPSet(service) ||
+		!c.isServiceOnNetwork(service) {
 		service = &v1.Service{
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: namespace,
@@ -407,16 +439,27 @@ func (c *Controller) syncService(key string) error {
 			"Error listing Endpoint Slices for Service %s/%s: %v", namespace, name, err)
 		return err
 	}
+	networkEndpointSlices := make([]*discovery.EndpointSlice, 0, len(endpointSlices))
+	for _, endpointSlice := range endpointSlices {
+		if c.isEndpointSliceOnNetwork(endpointSlice) {
+			networkEndpointSlices = append(networkEndpointSlices, endpointSlice)
+		}
+	}
 
 	// Build the abstract LB configs for this service
-	perNodeConfigs, templateConfigs, clusterConfigs := buildServiceLBConfigs(service, endpointSlices,
-		c.useLBGroups, c.useTemplates)
+	var perNodeConfigs, templateConfigs, clusterConfigs []lbConfig
+	if c.netInfo.IsSecondary() {
+		clusterConfigs = buildNetworkServiceLBConfigs(service, networkEndpointSlices)
+	} else {
+		perNodeConfigs, templateConfigs, clusterConfigs = buildServiceLBConfigs(service, networkEndpointSlices,
+			c.useLBGroups, c.useTemplates)
+	}
 	klog.V(5).Infof("Built service %s LB cluster-wide configs %#v", key, clusterConfigs)
 	klog.V(5).Infof("Built service %s LB per-node configs %#v", key, perNodeConfigs)
 	klog.V(5).Infof("Built service %s LB template configs %#v", key, templateConfigs)
 
 	// Convert the LB configs in to load-balancer objects
-	clusterLBs := buildClusterLBs(service, clusterConfigs, c.nodeInfos, c.useLBGroups)
+	clusterLBs := buildClusterLBs(service, clusterConfigs, c.nodeInfos, c.useLBGroups, c.netInfo)
 	templateLBs := buildTemplateLBs(service, templateConfigs, c.nodeInfos,
 		c.nodeIPv4Templates, c.nodeIPv6Templates)
 	perNodeLBs := buildPerNodeLBs(service, perNodeConfigs, c.nodeInfos)
@@ -636,6 +679,9 @@ func (c *Controller) onEndpointSliceDelete(obj interface{}) {
 // queueServiceForEndpointSlice attempts to queue the corresponding Service for
 // the provided EndpointSlice.
 func (c *Controller) queueServiceForEndpointSlice(endpointSlice *discovery.EndpointSlice) {
+	if !c.isEndpointSliceOnNetwork(endpointSlice) {
+		return
+	}
 	key, err := ServiceControllerKey(endpointSlice)
 	if err != nil {
 		// Do not log endpointsSlices missing service labels as errors.
@@ -652,6 +698,31 @@ func (c *Controller) queueServiceForEndpointSlice(endpointSlice *discovery.Endpo
 	c.queue.Add(key)
 }
 
+// isServiceOnNetwork returns true if the service is exposed on the network of
+// the controller: all services are exposed on the default network, while
+// only those annotated with one of its network attachment definitions are
+// exposed on a secondary network.
+func (c *Controller) isServiceOnNetwork(service *v1.Service) bool {
+	if !c.netInfo.IsSecondary() {
+		return true
+	}
+	nadName, ok := service.Annotations[serviceNetworkAnnotation]
+	if !ok {
+		return false
+	}
+	if !strings.Contains(nadName, "/") {
+		nadName = util.GetNADName(service.Namespace, nadName)
+	}
+	return c.netInfo.HasNAD(nadName)
+}
+
+// isEndpointSliceOnNetwork returns true if the endpoints of the endpoint slice
+// are on the network of the controller.
+func (c *Controller) isEndpointSliceOnNetwork(endpointSlice *discovery.EndpointSlice) bool {
+	isMultusEndpointSlice := endpointSlice.Labels[discovery.LabelManagedBy] == multusEndpointSliceManagedBy
+	return isMultusEndpointSlice == c.netInfo.IsSecondary()
+}
+
 // serviceControllerKey returns a controller key for a Service but derived from
 // an EndpointSlice.
 func ServiceControllerKey(endpointSlice *discovery.EndpointSlice) (string, error) {
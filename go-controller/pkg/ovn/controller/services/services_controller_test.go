@@ -7,15 +7,19 @@ import (
 	"strings"
 	"testing"
 
+	cnitypes "github.com/containernetworking/cni/pkg/types"
 	"github.com/onsi/ginkgo"
 	"github.com/onsi/gomega"
 	"github.com/onsi/gomega/format"
 	libovsdbclient "github.com/ovn-org/libovsdb/client"
+	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
 	globalconfig "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
 	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
 	v1 "k8s.io/api/core/v1"
 	discovery "k8s.io/api/discovery/v1"
@@ -48,6 +52,10 @@ func newController() (*serviceController, error) {
 }
 
 func newControllerWithDBSetup(dbSetup libovsdbtest.TestSetup) (*serviceController, error) {
+	return newControllerWithDBSetupForNetwork(dbSetup, &util.DefaultNetInfo{})
+}
+
+func newControllerWithDBSetupForNetwork(dbSetup libovsdbtest.TestSetup, netInfo util.NetInfo) (*serviceController, error) {
 	gomega.RegisterFailHandler(ginkgo.Fail)
 	nbClient, cleanup, err := libovsdbtest.NewNBTestHarness(dbSetup, nil)
 	if err != nil {
@@ -75,6 +83,7 @@ func newControllerWithDBSetup(dbSetup libovsdbtest.TestSetup) (*serviceControlle
 		informerFactory.Discovery().V1().EndpointSlices(),
 		informerFactory.Core().V1().Nodes(),
 		recorder,
+		netInfo,
 	)
 	gomega.Expect(err).ToNot(gomega.HaveOccurred())
 
@@ -508,6 +517,320 @@ func TestSyncServices(t *testing.T) {
 	}
 }
 
+func TestSyncServicesWithHealthChecks(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	initialMaxLength := format.MaxLength
+	temporarilyEnableGomegaMaxLengthFormat()
+	t.Cleanup(func() {
+		restoreGomegaMaxLengthFormat(initialMaxLength)
+	})
+
+	ns := "testns"
+	serviceName := "foo"
+
+	oldGateway := globalconfig.Gateway.Mode
+	oldClusterSubnet := globalconfig.Default.ClusterSubnets
+	globalconfig.Gateway.Mode = globalconfig.GatewayModeShared
+	globalconfig.IPv4Mode = true
+	globalconfig.OVNKubernetesFeature.EnableServiceHealthChecks = true
+	defer func() {
+		globalconfig.Gateway.Mode = oldGateway
+		globalconfig.Default.ClusterSubnets = oldClusterSubnet
+		globalconfig.IPv4Mode = false
+		globalconfig.OVNKubernetesFeature.EnableServiceHealthChecks = false
+	}()
+	_, cidr4, _ := net.ParseCIDR("10.128.0.0/16")
+	globalconfig.Default.ClusterSubnets = []globalconfig.CIDRNetworkEntry{{CIDR: cidr4, HostSubnetLength: 24}}
+
+	const (
+		nodeA           = "node-a"
+		nodeB           = "node-b"
+		nodeAEndpointIP = "10.128.0.2"
+		nodeBEndpointIP = "10.128.1.2"
+		remoteEndpoint  = "10.128.2.2"
+	)
+	firstNode := nodeConfig(nodeA, "10.0.0.1")
+	firstNode.podSubnets = []net.IPNet{*ovntest.MustParseIPNet("10.128.0.0/24")}
+	secondNode := nodeConfig(nodeB, "10.0.0.2")
+	secondNode.podSubnets = []net.IPNet{*ovntest.MustParseIPNet("10.128.1.0/24")}
+
+	podEndpoint := func(ip, podName string) discovery.Endpoint {
+		ep := readyEndpointsWithAddresses(ip)
+		ep.TargetRef = &v1.ObjectReference{Kind: "Pod", Namespace: ns, Name: podName}
+		return ep
+	}
+	slice := &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceName + "ab23",
+			Namespace: ns,
+			Labels:    map[string]string{discovery.LabelServiceName: serviceName},
+		},
+		Ports: []discovery.EndpointPort{{
+			Protocol: &tcp,
+			Port:     &outport,
+		}},
+		AddressType: discovery.AddressTypeIPv4,
+		Endpoints: []discovery.Endpoint{
+			podEndpoint(nodeAEndpointIP, "pod-a"),
+			podEndpoint(nodeBEndpointIP, "pod-b"),
+			podEndpoint(remoteEndpoint, "pod-c"),
+		},
+	}
+	service := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        serviceName,
+			Namespace:   ns,
+			Annotations: map[string]string{lbHealthCheckAnnotation: "true"},
+		},
+		Spec: v1.ServiceSpec{
+			Type:       v1.ServiceTypeClusterIP,
+			ClusterIP:  "192.168.1.1",
+			ClusterIPs: []string{"192.168.1.1"},
+			Selector:   map[string]string{"foo": "bar"},
+			Ports: []v1.ServicePort{{
+				Port:       80,
+				Protocol:   v1.ProtocolTCP,
+				TargetPort: intstr.FromInt(3456),
+			}},
+		},
+	}
+
+	initialDb := []libovsdbtest.TestData{
+		nodeLogicalSwitch(nodeA, initialLsGroups),
+		nodeLogicalSwitch(nodeB, initialLsGroups),
+		nodeLogicalRouter(nodeA, initialLrGroups),
+		nodeLogicalRouter(nodeB, initialLrGroups),
+		lbGroup(types.ClusterLBGroupName),
+		lbGroup(types.ClusterSwitchLBGroupName),
+		lbGroup(types.ClusterRouterLBGroupName),
+	}
+
+	controller, err := newControllerWithDBSetup(libovsdbtest.TestSetup{NBData: initialDb})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	defer controller.close()
+	controller.endpointSliceStore.Add(slice)
+	controller.serviceStore.Add(service)
+	controller.nodeTracker.nodes = map[string]nodeInfo{
+		nodeA: *firstNode,
+		nodeB: *secondNode,
+	}
+	controller.RequestFullSync(controller.nodeTracker.getZoneNodes())
+
+	vips := map[string]string{
+		"192.168.1.1:80": "10.128.0.2:3456,10.128.1.2:3456,10.128.2.2:3456",
+	}
+	expectedDb := func(lb *nbdb.LoadBalancer, hcs ...libovsdbtest.TestData) []libovsdbtest.TestData {
+		return append([]libovsdbtest.TestData{
+			lb,
+			nodeLogicalSwitch(nodeA, initialLsGroups),
+			nodeLogicalSwitch(nodeB, initialLsGroups),
+			nodeLogicalRouter(nodeA, initialLrGroups),
+			nodeLogicalRouter(nodeB, initialLrGroups),
+			lbGroup(types.ClusterLBGroupName, loadBalancerClusterWideTCPServiceName(ns, serviceName)),
+			lbGroup(types.ClusterSwitchLBGroupName),
+			lbGroup(types.ClusterRouterLBGroupName),
+			nodeIPTemplate(firstNode),
+			nodeIPTemplate(secondNode),
+		}, hcs...)
+	}
+
+	// the pods on the nodes of the zone are health checked
+	g.Expect(controller.syncService(namespacedServiceName(ns, serviceName))).To(gomega.Succeed())
+	g.Expect(controller.nbClient).To(libovsdbtest.HaveData(expectedDb(
+		&nbdb.LoadBalancer{
+			UUID:        loadBalancerClusterWideTCPServiceName(ns, serviceName),
+			Name:        loadBalancerClusterWideTCPServiceName(ns, serviceName),
+			Options:     servicesOptions(),
+			Protocol:    &nbdb.LoadBalancerProtocolTCP,
+			Vips:        vips,
+			ExternalIDs: serviceExternalIDs(namespacedServiceName(ns, serviceName)),
+			HealthCheck: []string{"hc-uuid"},
+			IPPortMappings: map[string]string{
+				nodeAEndpointIP: "testns_pod-a:10.128.0.254",
+				nodeBEndpointIP: "testns_pod-b:10.128.1.254",
+			},
+		},
+		&nbdb.LoadBalancerHealthCheck{
+			UUID: "hc-uuid",
+			Vip:  "192.168.1.1:80",
+			Options: map[string]string{
+				"interval":      lbHealthCheckInterval,
+				"timeout":       lbHealthCheckTimeout,
+				"success_count": lbHealthCheckSuccessCount,
+				"failure_count": lbHealthCheckFailureCount,
+			},
+			ExternalIDs: serviceExternalIDs(namespacedServiceName(ns, serviceName)),
+		},
+	)))
+
+	// the health checks are removed when the service opts out
+	service = service.DeepCopy()
+	service.Annotations = nil
+	controller.serviceStore.Update(service)
+	g.Expect(controller.syncService(namespacedServiceName(ns, serviceName))).To(gomega.Succeed())
+	g.Expect(controller.nbClient).To(libovsdbtest.HaveData(expectedDb(
+		&nbdb.LoadBalancer{
+			UUID:        loadBalancerClusterWideTCPServiceName(ns, serviceName),
+			Name:        loadBalancerClusterWideTCPServiceName(ns, serviceName),
+			Options:     servicesOptions(),
+			Protocol:    &nbdb.LoadBalancerProtocolTCP,
+			Vips:        vips,
+			ExternalIDs: serviceExternalIDs(namespacedServiceName(ns, serviceName)),
+		},
+	)))
+}
+
+func TestSyncServicesOnSecondaryNetwork(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	initialMaxLength := format.MaxLength
+	temporarilyEnableGomegaMaxLengthFormat()
+	t.Cleanup(func() {
+		restoreGomegaMaxLengthFormat(initialMaxLength)
+	})
+
+	ns := "testns"
+	serviceName := "foo"
+	networkName := "blue"
+
+	globalconfig.IPv4Mode = true
+	defer func() {
+		globalconfig.IPv4Mode = false
+	}()
+
+	netInfo, err := util.NewNetInfo(&ovncnitypes.NetConf{
+		NetConf:  cnitypes.NetConf{Name: networkName},
+		Topology: types.Layer3Topology,
+		Subnets:  "10.200.0.0/16/24",
+	})
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	netInfo.AddNAD(util.GetNADName(ns, "blue-nad"))
+
+	const (
+		nodeA = "node-a"
+		nodeB = "node-b"
+	)
+	networkNode := func(nodeName, subnet string) nodeInfo {
+		return nodeInfo{
+			name:       nodeName,
+			podSubnets: []net.IPNet{*ovntest.MustParseIPNet(subnet)},
+			switchName: netInfo.GetNetworkScopedName(nodeName),
+			zone:       types.OvnDefaultZone,
+		}
+	}
+	networkSwitch := func(nodeName string, lbs ...string) *nbdb.LogicalSwitch {
+		ls := &nbdb.LogicalSwitch{
+			UUID:        netInfo.GetNetworkScopedName(nodeName),
+			Name:        netInfo.GetNetworkScopedName(nodeName),
+			ExternalIDs: map[string]string{types.NetworkExternalID: networkName},
+		}
+		if len(lbs) > 0 {
+			ls.LoadBalancer = lbs
+		}
+		return ls
+	}
+
+	// the endpoint slices with the pod IPs on the default network and on the
+	// secondary network
+	defaultSlice := &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceName + "ab23",
+			Namespace: ns,
+			Labels:    map[string]string{discovery.LabelServiceName: serviceName},
+		},
+		Ports: []discovery.EndpointPort{{
+			Protocol: &tcp,
+			Port:     &outport,
+		}},
+		AddressType: discovery.AddressTypeIPv4,
+		Endpoints:   []discovery.Endpoint{readyEndpointsWithAddresses("10.128.0.2", "10.128.1.2")},
+	}
+	networkSlice := &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceName + "-blue-ab23",
+			Namespace: ns,
+			Labels: map[string]string{
+				discovery.LabelServiceName: serviceName,
+				discovery.LabelManagedBy:   multusEndpointSliceManagedBy,
+			},
+		},
+		Ports: []discovery.EndpointPort{{
+			Protocol: &tcp,
+			Port:     &outport,
+		}},
+		AddressType: discovery.AddressTypeIPv4,
+		Endpoints:   []discovery.Endpoint{readyEndpointsWithAddresses("10.200.0.5", "10.200.1.5")},
+	}
+	service := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        serviceName,
+			Namespace:   ns,
+			Annotations: map[string]string{serviceNetworkAnnotation: "blue-nad"},
+		},
+		Spec: v1.ServiceSpec{
+			Type:       v1.ServiceTypeNodePort,
+			ClusterIP:  "192.168.1.1",
+			ClusterIPs: []string{"192.168.1.1"},
+			Selector:   map[string]string{"foo": "bar"},
+			Ports: []v1.ServicePort{{
+				Port:       80,
+				Protocol:   v1.ProtocolTCP,
+				TargetPort: intstr.FromInt(3456),
+				NodePort:   30123,
+			}},
+		},
+	}
+
+	initialDb := []libovsdbtest.TestData{
+		nodeLogicalSwitch(nodeA, nil),
+		networkSwitch(nodeA),
+		networkSwitch(nodeB),
+	}
+
+	controller, err := newControllerWithDBSetupForNetwork(libovsdbtest.TestSetup{NBData: initialDb}, netInfo)
+	g.Expect(err).ToNot(gomega.HaveOccurred())
+	defer controller.close()
+	controller.useLBGroups = false
+	controller.useTemplates = false
+	controller.endpointSliceStore.Add(defaultSlice)
+	controller.endpointSliceStore.Add(networkSlice)
+	controller.serviceStore.Add(service)
+	controller.nodeTracker.nodes = map[string]nodeInfo{
+		nodeA: networkNode(nodeA, "10.200.0.0/24"),
+		nodeB: networkNode(nodeB, "10.200.1.0/24"),
+	}
+	controller.RequestFullSync(controller.nodeTracker.getZoneNodes())
+
+	// only the ClusterIP is load balanced on the switches of the network, to
+	// the endpoints on the network
+	lbName := netInfo.GetNetworkScopedName(loadBalancerClusterWideTCPServiceName(ns, serviceName))
+	externalIDs := serviceExternalIDs(namespacedServiceName(ns, serviceName))
+	externalIDs[types.NetworkExternalID] = networkName
+	g.Expect(controller.syncService(namespacedServiceName(ns, serviceName))).To(gomega.Succeed())
+	g.Expect(controller.nbClient).To(libovsdbtest.HaveData([]libovsdbtest.TestData{
+		&nbdb.LoadBalancer{
+			UUID:     lbName,
+			Name:     lbName,
+			Options:  servicesOptions(),
+			Protocol: &nbdb.LoadBalancerProtocolTCP,
+			Vips: map[string]string{
+				"192.168.1.1:80": "10.200.0.5:3456,10.200.1.5:3456",
+			},
+			ExternalIDs: externalIDs,
+		},
+		nodeLogicalSwitch(nodeA, nil),
+		networkSwitch(nodeA, lbName),
+		networkSwitch(nodeB, lbName),
+	}))
+
+	// the load balancer is removed when the service is not exposed on the
+	// network anymore
+	service = service.DeepCopy()
+	service.Annotations = nil
+	controller.serviceStore.Update(service)
+	g.Expect(controller.syncService(namespacedServiceName(ns, serviceName))).To(gomega.Succeed())
+	g.Expect(controller.nbClient).To(libovsdbtest.HaveData(initialDb))
+}
+
 func Test_ETPCluster_NodePort_Service_WithMultipleIPAddresses(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	globalconfig.IPv4Mode = true
@@ -18,7 +18,7 @@ func hasHostEndpoints(endpointIPs []string) bool {
 
 // IsHostEndpoint determines if the given endpoint ip belongs to a host networked pod
 func IsHostEndpoint(endpointIP string) bool {
-	for _, clusterNet := range globalconfig.Default.ClusterSubnets {
+	for _, clusterNet := range globalconfig.ClusterSubnets() {
 		if clusterNet.CIDR.Contains(net.ParseIP(endpointIP)) {
 			return false
 		}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	libovsdbclient "github.com/ovn-org/libovsdb/client"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 
@@ -26,18 +27,6 @@ const (
 	defaultCOPPName = "ovnkube-default"
 )
 
-var defaultProtocolNames = [...]string{
-	OVNARPRateLimiter,
-	OVNARPResolveRateLimiter,
-	OVNBFDRateLimiter,
-	OVNControllerEventsRateLimiter,
-	OVNICMPV4ErrorsRateLimiter,
-	OVNICMPV6ErrorsRateLimiter,
-	OVNRejectRateLimiter,
-	OVNTCPRSTRateLimiter,
-	OVNServiceMonitorLimiter,
-}
-
 func getMeterNameForProtocol(protocol string) string {
 	// format: <OVNSupportedProtocolName>-rate-limiter
 	return protocol + "-" + types.OvnRateLimitingMeter
@@ -45,6 +34,7 @@ func getMeterNameForProtocol(protocol string) string {
 
 // EnsureDefaultCOPP creates the default COPP that needs to be added to each GR
 // if not already present. Also cleans up old COPP entries if required.
+// The meters of the COPP are created or updated according to config.COPP.
 func EnsureDefaultCOPP(nbClient libovsdbclient.Client) (string, error) {
 	p := func(item *nbdb.Copp) bool {
 		return item.Name == ""
@@ -54,27 +44,38 @@ func EnsureDefaultCOPP(nbClient libovsdbclient.Client) (string, error) {
 		return "", fmt.Errorf("failed to delete duplicate COPPs: %w", err)
 	}
 
-	band := &nbdb.MeterBand{
-		Action: types.MeterAction,
-		Rate:   int(25), // hard-coding for now. TODO(tssurya): make this configurable if needed
-	}
-	ops, err = libovsdbops.CreateMeterBandOps(nbClient, ops, band)
-	if err != nil {
-		return "", fmt.Errorf("can't create meter band %v: %v", band, err)
-	}
+	// meters configured with the same rate and burst share the same band
+	bands := map[string]*nbdb.MeterBand{}
+	meterNames := make(map[string]string, len(config.COPPProtocols))
+	for _, protocol := range config.COPPProtocols {
+		meterConfig := config.COPP.GetMeterConfig(protocol)
+		bandKey := fmt.Sprintf("%d/%d", meterConfig.Rate, meterConfig.Burst)
+		band, ok := bands[bandKey]
+		if !ok {
+			band = &nbdb.MeterBand{
+				Action:    types.MeterAction,
+				Rate:      meterConfig.Rate,
+				BurstSize: meterConfig.Burst,
+			}
+			ops, err = libovsdbops.CreateMeterBandOps(nbClient, ops, band)
+			if err != nil {
+				return "", fmt.Errorf("can't create meter band %v: %v", band, err)
+			}
+			bands[bandKey] = band
+		}
 
-	meterNames := make(map[string]string, len(defaultProtocolNames))
-	meterFairness := true
-	for _, protocol := range defaultProtocolNames {
 		// format: <OVNSupportedProtocolName>-rate-limiter
 		meterName := getMeterNameForProtocol(protocol)
 		meterNames[protocol] = meterName
 
+		meterFairness := meterConfig.Fair
 		meter := &nbdb.Meter{
 			Name: meterName,
 			Fair: &meterFairness,
 			Unit: types.PacketsPerSecond,
 		}
+		// existing meters are updated in place so that the routers referencing
+		// the COPP pick up configuration changes
 		ops, err = libovsdbops.CreateOrUpdateMeterOps(nbClient, ops, meter, []*nbdb.MeterBand{band},
 			&meter.Bands, &meter.Fair, &meter.Unit)
 		if err != nil {
@@ -4,14 +4,15 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 )
 
 func TestEnsureDefaultCOPP(t *testing.T) {
-	meterMap := make(map[string]string, len(defaultProtocolNames))
-	for _, protocol := range defaultProtocolNames {
+	meterMap := make(map[string]string, len(config.COPPProtocols))
+	for _, protocol := range config.COPPProtocols {
 		// format: <OVNSupportedProtocolName>-rate-limiter
 		meterMap[protocol] = getMeterNameForProtocol(protocol)
 	}
@@ -19,15 +20,15 @@ func TestEnsureDefaultCOPP(t *testing.T) {
 	meterBand := &nbdb.MeterBand{
 		UUID:   "meter-band-UUID",
 		Action: types.MeterAction,
-		Rate:   int(25), // hard-coding for now. TODO(tssurya): make this configurable if needed
+		Rate:   int(25),
 	}
 
 	var meters []*nbdb.Meter
 	meterFairness := true
-	for i := 0; i < len(defaultProtocolNames); i++ {
+	for i := 0; i < len(config.COPPProtocols); i++ {
 		meters = append(meters, &nbdb.Meter{
 			UUID:  fmt.Sprintf("meter-%d-UUID", i),
-			Name:  getMeterNameForProtocol(defaultProtocolNames[i]),
+			Name:  getMeterNameForProtocol(config.COPPProtocols[i]),
 			Fair:  &meterFairness,
 			Unit:  types.PacketsPerSecond,
 			Bands: []string{meterBand.UUID},
@@ -101,9 +102,50 @@ func TestEnsureDefaultCOPP(t *testing.T) {
 		multipleEmptyAndNamedCOPPNBData = append(multipleEmptyAndNamedCOPPNBData, m)
 	}
 
+	// meters of the configured protocols get their own bands while the rest
+	// keep sharing the default one
+	configuredCOPP := config.COPPConfig{
+		Rate: 25,
+		Fair: true,
+		ProtocolMeters: map[string]config.COPPMeterConfig{
+			OVNARPRateLimiter: {Rate: 100, Burst: 10, Fair: false},
+			OVNBFDRateLimiter: {Rate: 100, Burst: 10, Fair: true},
+		},
+	}
+	configuredMeterBand := &nbdb.MeterBand{
+		UUID:      "configured-meter-band-UUID",
+		Action:    types.MeterAction,
+		Rate:      100,
+		BurstSize: 10,
+	}
+	configuredNBData := []libovsdbtest.TestData{
+		&nbdb.Copp{
+			Name:   "ovnkube-default",
+			UUID:   "copp-UUID",
+			Meters: meterMap,
+		},
+		meterBand,
+		configuredMeterBand,
+	}
+	for i, protocol := range config.COPPProtocols {
+		meterConfig := configuredCOPP.GetMeterConfig(protocol)
+		band := meterBand
+		if _, ok := configuredCOPP.ProtocolMeters[protocol]; ok {
+			band = configuredMeterBand
+		}
+		configuredNBData = append(configuredNBData, &nbdb.Meter{
+			UUID:  fmt.Sprintf("meter-%d-UUID", i),
+			Name:  getMeterNameForProtocol(protocol),
+			Fair:  &meterConfig.Fair,
+			Unit:  types.PacketsPerSecond,
+			Bands: []string{band.UUID},
+		})
+	}
+
 	tests := []struct {
 		desc         string
 		expectErr    bool
+		coppConfig   *config.COPPConfig
 		initialNbdb  libovsdbtest.TestSetup
 		expectedNbdb libovsdbtest.TestSetup
 	}{
@@ -146,9 +188,35 @@ func TestEnsureDefaultCOPP(t *testing.T) {
 				NBData: expectedNBData,
 			},
 		},
+		{
+			desc:       "creates meters with configured protocol rates",
+			expectErr:  false,
+			coppConfig: &configuredCOPP,
+			expectedNbdb: libovsdbtest.TestSetup{
+				NBData: configuredNBData,
+			},
+		},
+		{
+			desc:       "updates existing meters with configured protocol rates",
+			expectErr:  false,
+			coppConfig: &configuredCOPP,
+			initialNbdb: libovsdbtest.TestSetup{
+				NBData: existingNamedCOPPNBData,
+			},
+			expectedNbdb: libovsdbtest.TestSetup{
+				NBData: configuredNBData,
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.desc, func(t *testing.T) {
+			if err := config.PrepareTestConfig(); err != nil {
+				t.Fatalf("test: \"%s\" failed to prepare test config: %v", tt.desc, err)
+			}
+			if tt.coppConfig != nil {
+				config.COPP = *tt.coppConfig
+			}
+
 			nbClient, cleanup, err := libovsdbtest.NewNBTestHarness(tt.initialNbdb, nil)
 			if err != nil {
 				t.Fatalf("test: \"%s\" failed to set up test harness: %v", tt.desc, err)
@@ -159,6 +227,9 @@ func TestEnsureDefaultCOPP(t *testing.T) {
 			if err != nil && !tt.expectErr {
 				t.Fatal(fmt.Errorf("EnsureDefaultCOPP() error = %v", err))
 			}
+			if err == nil && tt.expectErr {
+				t.Fatal(fmt.Errorf("EnsureDefaultCOPP() expected error"))
+			}
 
 			matcher := libovsdbtest.HaveData(tt.expectedNbdb.NBData)
 			success, err := matcher.Match(nbClient)
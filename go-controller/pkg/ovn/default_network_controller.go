@@ -88,6 +88,9 @@ type DefaultNetworkController struct {
 
 	// Controller used for programming OVN for egress IP
 	eIPC egressIPZoneController
+	// Controller used for the BFD sessions between egress nodes, nil unless
+	// egress IP BFD is enabled
+	eIPBFDC *egressIPBFDController
 
 	// Controller used to handle services
 	svcController *svccontroller.Controller
@@ -115,6 +118,12 @@ type DefaultNetworkController struct {
 	// retry framework for Egress Firewall Nodes
 	retryEgressFwNodes *retry.RetryFramework
 
+	// ClusterNetwork events factory handler, adding cluster subnets at runtime
+	clusterNetworkHandler *factory.Handler
+	// number of cluster subnets the gateways and egress firewalls are synced
+	// with, cluster subnets are only ever appended
+	syncedClusterSubnets int
+
 	// Node-specific syncMaps used by node event handler
 	gatewaysFailed              sync.Map
 	mgmtPortFailed              sync.Map
@@ -159,6 +168,7 @@ func newDefaultNetworkControllerCommon(cnci *CommonNetworkControllerInfo,
 		cnci.watchFactory.EndpointSliceCoreInformer(),
 		cnci.watchFactory.NodeCoreInformer(),
 		cnci.recorder,
+		&util.DefaultNetInfo{},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create new service controller while creating new default network controller: %w", err)
@@ -325,6 +335,9 @@ func (oc *DefaultNetworkController) Start(ctx context.Context) error {
 
 // Stop gracefully stops the controller
 func (oc *DefaultNetworkController) Stop() {
+	if oc.clusterNetworkHandler != nil {
+		oc.watchFactory.RemoveClusterNetworkHandler(oc.clusterNetworkHandler)
+	}
 	close(oc.stopChan)
 	oc.cancelableCtx.Cancel()
 	oc.wg.Wait()
@@ -383,6 +396,15 @@ func (oc *DefaultNetworkController) Init(ctx context.Context) error {
 		oc.routerLoadBalancerGroupUUID = loadBalancerGroup.UUID
 	}
 
+	if oc.hasNodeSubnetExpansion() {
+		// cluster subnets added at runtime need to be known before the
+		// cluster router and the nodes are set up
+		if err := util.SyncClusterNetworkSubnets(oc.watchFactory.ClusterNetworkInformer().Lister()); err != nil {
+			return err
+		}
+		oc.syncedClusterSubnets = len(config.ClusterSubnets())
+	}
+
 	networkID := util.InvalidNetworkID
 	nodeNames := []string{}
 	for _, node := range existingNodes {
@@ -427,6 +449,12 @@ func (oc *DefaultNetworkController) Run(ctx context.Context) error {
 		return err
 	}
 
+	if oc.hasNodeSubnetExpansion() {
+		if err := oc.WatchClusterNetworks(); err != nil {
+			return err
+		}
+	}
+
 	startSvc := time.Now()
 	// Services should be started after nodes to prevent LB churn
 	err := oc.StartServiceController(oc.wg, true)
@@ -475,6 +503,9 @@ func (oc *DefaultNetworkController) Run(ctx context.Context) error {
 		if err := WithSyncDurationMetric("egress ip pod", oc.WatchEgressIPPods); err != nil {
 			return err
 		}
+		if config.OVNKubernetesFeature.EnableEgressIPBFD {
+			oc.eIPBFDC = newEgressIPBFDController(oc.nbClient, oc.watchFactory, oc.isLocalZoneNode)
+		}
 		if err := WithSyncDurationMetric("egress node", oc.WatchEgressNodes); err != nil {
 			return err
 		}
@@ -485,7 +516,7 @@ func (oc *DefaultNetworkController) Run(ctx context.Context) error {
 
 	if config.OVNKubernetesFeature.EnableEgressFirewall {
 		var err error
-		oc.egressFirewallDNS, err = NewEgressDNS(oc.addressSetFactory, oc.controllerName, oc.stopChan)
+		oc.egressFirewallDNS, err = NewEgressDNS(oc.addressSetFactory, oc.controllerName, oc.watchFactory, oc.stopChan)
 		if err != nil {
 			return err
 		}
@@ -815,7 +846,12 @@ func (h *defaultNetworkControllerEventHandler) AddResource(obj interface{}, from
 		// NOTE: Adding GARP needs to be done only during node add
 		// It is a one time operation and doesn't need to be done during
 		// node updates. It needs to be done only for nodes local to this zone
-		return h.oc.addEgressNode(node)
+		if err := h.oc.addEgressNode(node); err != nil {
+			return err
+		}
+		if h.oc.eIPBFDC != nil && isEgressIPBFDNode(node) {
+			return h.oc.eIPBFDC.syncSessions()
+		}
 
 	case factory.EgressFwNodeType:
 		node := obj.(*kapi.Node)
@@ -902,6 +938,8 @@ func (h *defaultNetworkControllerEventHandler) UpdateResource(oldObj, newObj int
 			if h.oc.isLocalZoneNode(oldNode) {
 				// determine what actually changed in this update
 				_, nodeSync := h.oc.addNodeFailed.Load(newNode.Name)
+				// additional host subnets need to be added to the node switch
+				nodeSync = nodeSync || (nodeSubnetChanged && h.oc.hasNodeSubnetExpansion())
 				_, failed := h.oc.nodeClusterRouterPortFailed.Load(newNode.Name)
 				clusterRtrSync := failed || nodeChassisChanged(oldNode, newNode) || nodeSubnetChanged
 				_, failed = h.oc.mgmtPortFailed.Load(newNode.Name)
@@ -987,6 +1025,11 @@ func (h *defaultNetworkControllerEventHandler) UpdateResource(oldObj, newObj int
 				return err
 			}
 		}
+		// the BFD sessions follow the egress label and gateway router IPs
+		if h.oc.eIPBFDC != nil && (isEgressIPBFDNode(oldNode) != isEgressIPBFDNode(newNode) ||
+			util.NodeL3GatewayAnnotationChanged(oldNode, newNode)) {
+			return h.oc.eIPBFDC.syncSessions()
+		}
 		return nil
 
 	case factory.EgressFwNodeType:
@@ -1065,6 +1108,9 @@ func (h *defaultNetworkControllerEventHandler) DeleteResource(obj, cachedObj int
 		h.oc.eIPC.nodeZoneState.LockKey(node.Name)
 		h.oc.eIPC.nodeZoneState.Delete(node.Name)
 		h.oc.eIPC.nodeZoneState.UnlockKey(node.Name)
+		if h.oc.eIPBFDC != nil && isEgressIPBFDNode(node) {
+			return h.oc.eIPBFDC.syncSessions()
+		}
 		return nil
 
 	case factory.EgressFwNodeType:
@@ -93,7 +93,7 @@ func (oc *DefaultNetworkController) newEgressFirewallRule(rawEgressFirewallRule
 		}
 		efr.to.cidrSelector = rawEgressFirewallRule.To.CIDRSelector
 		intersect := false
-		for _, clusterSubnet := range config.Default.ClusterSubnets {
+		for _, clusterSubnet := range config.ClusterSubnets() {
 			if clusterSubnet.CIDR.Contains(ipNet.IP) || ipNet.Contains(clusterSubnet.CIDR.IP) {
 				intersect = true
 				break
@@ -120,6 +120,9 @@ func (oc *DefaultNetworkController) newEgressFirewallRule(rawEgressFirewallRule
 			efr.to.nodeAddrs.Insert(hostAddresses...)
 		}
 	}
+	if err := validateEgressFirewallPorts(rawEgressFirewallRule.Ports); err != nil {
+		return nil, err
+	}
 	efr.ports = rawEgressFirewallRule.Ports
 
 	return efr, nil
@@ -613,19 +616,19 @@ func egressGetL4Match(ports []egressfirewallapi.EgressFirewallPort) string {
 			if port.Port == 0 {
 				udpString = "udp"
 			} else {
-				udpString = fmt.Sprintf("%s udp.dst == %d ||", udpString, port.Port)
+				udpString = fmt.Sprintf("%s %s ||", udpString, egressGetPortMatch("udp", port))
 			}
 		} else if kapi.Protocol(port.Protocol) == kapi.ProtocolTCP && tcpString != "tcp" {
 			if port.Port == 0 {
 				tcpString = "tcp"
 			} else {
-				tcpString = fmt.Sprintf("%s tcp.dst == %d ||", tcpString, port.Port)
+				tcpString = fmt.Sprintf("%s %s ||", tcpString, egressGetPortMatch("tcp", port))
 			}
 		} else if kapi.Protocol(port.Protocol) == kapi.ProtocolSCTP && sctpString != "sctp" {
 			if port.Port == 0 {
 				sctpString = "sctp"
 			} else {
-				sctpString = fmt.Sprintf("%s sctp.dst == %d ||", sctpString, port.Port)
+				sctpString = fmt.Sprintf("%s %s ||", sctpString, egressGetPortMatch("sctp", port))
 			}
 		}
 	}
@@ -667,9 +670,34 @@ func egressGetL4Match(ports []egressfirewallapi.EgressFirewallPort) string {
 	return fmt.Sprintf("(%s)", l4Match)
 }
 
+// egressGetPortMatch returns the destination port match for a single egressFirewall port of the given protocol,
+// matching the whole range when an endPort is specified.
+func egressGetPortMatch(protocol string, port egressfirewallapi.EgressFirewallPort) string {
+	if port.EndPort != nil && *port.EndPort != port.Port {
+		return fmt.Sprintf("%d<=%s.dst<=%d", port.Port, protocol, *port.EndPort)
+	}
+	return fmt.Sprintf("%s.dst == %d", protocol, port.Port)
+}
+
+// validateEgressFirewallPorts checks that the port ranges of an egressFirewall rule are well-formed.
+func validateEgressFirewallPorts(ports []egressfirewallapi.EgressFirewallPort) error {
+	for _, port := range ports {
+		if port.EndPort == nil {
+			continue
+		}
+		if port.Port == 0 {
+			return fmt.Errorf("invalid %s port range: endPort %d is set without port", port.Protocol, *port.EndPort)
+		}
+		if *port.EndPort < port.Port {
+			return fmt.Errorf("invalid %s port range: endPort %d is lower than port %d", port.Protocol, *port.EndPort, port.Port)
+		}
+	}
+	return nil
+}
+
 func getV4ClusterSubnetsExclusion() string {
 	var exclusions []string
-	for _, clusterSubnet := range config.Default.ClusterSubnets {
+	for _, clusterSubnet := range config.ClusterSubnets() {
 		if utilnet.IsIPv4CIDR(clusterSubnet.CIDR) {
 			exclusions = append(exclusions, fmt.Sprintf("%s.dst != %s", "ip4", clusterSubnet.CIDR))
 		}
@@ -679,7 +707,7 @@ func getV4ClusterSubnetsExclusion() string {
 
 func getV6ClusterSubnetsExclusion() string {
 	var exclusions []string
-	for _, clusterSubnet := range config.Default.ClusterSubnets {
+	for _, clusterSubnet := range config.ClusterSubnets() {
 		if utilnet.IsIPv6CIDR(clusterSubnet.CIDR) {
 			exclusions = append(exclusions, fmt.Sprintf("%s.dst != %s", "ip6", clusterSubnet.CIDR))
 		}
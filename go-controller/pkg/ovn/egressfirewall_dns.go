@@ -3,18 +3,30 @@ package ovn
 import (
 	"fmt"
 	"net"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
 	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 )
 
+const (
+	// minLearnedDNSNameTTL is the minimum time a name learned from a DNS response is kept, so that
+	// connections opened right after the response are still allowed when the record TTL is very short.
+	minLearnedDNSNameTTL = 30 * time.Second
+	// learnedDNSNamesPurgeInterval is how often expired learned names are removed.
+	learnedDNSNamesPurgeInterval = 10 * time.Second
+)
+
 type EgressDNS struct {
 	// Protects pdMap/namespaces operations
 	lock sync.Mutex
@@ -25,6 +37,8 @@ type EgressDNS struct {
 	// allows for the creation of addresssets
 	addressSetFactory addressset.AddressSetFactory
 	controllerName    string
+	// used to find the cluster DNS pods allowed to send dnstap messages
+	watchFactory *factory.WatchFactory
 
 	// Report change when Add operation is done
 	added          chan struct{}
@@ -41,6 +55,16 @@ type dnsEntry struct {
 	dnsResolves []net.IP
 	// the addressSet that contains the current IPs
 	dnsAddressSet addressset.AddressSet
+	// learnedNames holds the names matching a wildcard dnsName that were learned from DNS
+	// responses, it is nil for regular dnsNames
+	learnedNames map[string]*learnedDNSName
+}
+
+type learnedDNSName struct {
+	// the IP addresses the learned name resolved to
+	ips []net.IP
+	// when the learned name expires and its IPs are removed from the addressSet
+	expires time.Time
 }
 
 func getEgressFirewallDNSAddrSetDbIDs(dnsName, controller string) *libovsdbops.DbObjectIDs {
@@ -51,7 +75,7 @@ func getEgressFirewallDNSAddrSetDbIDs(dnsName, controller string) *libovsdbops.D
 		})
 }
 
-func NewEgressDNS(addressSetFactory addressset.AddressSetFactory, controllerName string,
+func NewEgressDNS(addressSetFactory addressset.AddressSetFactory, controllerName string, watchFactory *factory.WatchFactory,
 	controllerStop <-chan struct{}) (*EgressDNS, error) {
 	dnsInfo, err := util.NewDNS("/etc/resolv.conf")
 	if err != nil {
@@ -63,6 +87,7 @@ func NewEgressDNS(addressSetFactory addressset.AddressSetFactory, controllerName
 		dnsEntries:        make(map[string]*dnsEntry),
 		addressSetFactory: addressSetFactory,
 		controllerName:    controllerName,
+		watchFactory:      watchFactory,
 
 		added:          make(chan struct{}, 1),
 		deleted:        make(chan string, 1),
@@ -78,6 +103,10 @@ func (e *EgressDNS) Add(namespace, dnsName string) (addressset.AddressSet, error
 	defer e.lock.Unlock()
 
 	if _, exists := e.dnsEntries[dnsName]; !exists {
+		if util.IsWildcardDNSName(dnsName) && config.OVNKubernetesFeature.EgressFirewallDNSResponseAddress == "" {
+			return nil, fmt.Errorf("wildcard dnsName %s is not supported: the cluster DNS responses are not "+
+				"received, egressfirewall-dns-response-address is not set", dnsName)
+		}
 		var err error
 		dnsEntry := dnsEntry{
 			namespaces: make(map[string]struct{}),
@@ -91,7 +120,12 @@ func (e *EgressDNS) Add(namespace, dnsName string) (addressset.AddressSet, error
 			return nil, fmt.Errorf("cannot create addressSet for %s: %v", dnsName, err)
 		}
 		e.dnsEntries[dnsName] = &dnsEntry
-		go e.addToDNS(dnsName)
+		if util.IsWildcardDNSName(dnsName) {
+			// names matching a wildcard can't be resolved, they are learned from DNS responses instead
+			dnsEntry.learnedNames = make(map[string]*learnedDNSName)
+		} else {
+			go e.addToDNS(dnsName)
+		}
 	}
 	e.dnsEntries[dnsName].namespaces[namespace] = struct{}{}
 	return e.dnsEntries[dnsName].dnsAddressSet, nil
@@ -114,6 +148,10 @@ func (e *EgressDNS) Delete(namespace string) error {
 			}
 			// the dnsEntry is no longer needed because nothing references it, so delete it
 			delete(e.dnsEntries, dnsName)
+			if dnsEntry.learnedNames != nil {
+				metrics.UpdateEgressFirewallDNSLearnedNames(-float64(len(dnsEntry.learnedNames)))
+				continue
+			}
 			dnsNamesToDelete = append(dnsNamesToDelete, dnsName)
 		}
 	}
@@ -142,11 +180,18 @@ func (e *EgressDNS) updateEntryForName(dnsName string) error {
 	}
 	e.dnsEntries[dnsName].dnsResolves = ips
 
-	// ignore ips from clusterSubnet, since this subnet shouldn't be affected by egress firewall
+	if err := e.dnsEntries[dnsName].dnsAddressSet.SetIPs(filterClusterSubnetIPs(ips)); err != nil {
+		return fmt.Errorf("cannot add IPs from EgressFirewall AddressSet %s: %v", dnsName, err)
+	}
+	return nil
+}
+
+// filterClusterSubnetIPs ignores ips from clusterSubnet, since this subnet shouldn't be affected by egress firewall
+func filterClusterSubnetIPs(ips []net.IP) []net.IP {
 	ipsNoClusterSubnet := []net.IP{}
 	for _, ip := range ips {
 		fromClusterSubnet := false
-		for _, clusterSubnet := range config.Default.ClusterSubnets {
+		for _, clusterSubnet := range config.ClusterSubnets() {
 			if clusterSubnet.CIDR.Contains(ip) {
 				fromClusterSubnet = true
 				break
@@ -157,8 +202,106 @@ func (e *EgressDNS) updateEntryForName(dnsName string) error {
 			ipsNoClusterSubnet = append(ipsNoClusterSubnet, ip)
 		}
 	}
-	if err := e.dnsEntries[dnsName].dnsAddressSet.SetIPs(ipsNoClusterSubnet); err != nil {
-		return fmt.Errorf("cannot add IPs from EgressFirewall AddressSet %s: %v", dnsName, err)
+	return ipsNoClusterSubnet
+}
+
+// LearnDNSResponse records that dnsName resolved to ips for every wildcard dnsName matching it. The IPs are
+// added to the addressSet of the wildcard dnsName until the learned name expires after ttl. When the cache
+// of a wildcard dnsName is full, the learned name expiring first is evicted.
+func (e *EgressDNS) LearnDNSResponse(dnsName string, ips []net.IP, ttl time.Duration) {
+	if len(ips) == 0 {
+		return
+	}
+	if ttl < minLearnedDNSNameTTL {
+		ttl = minLearnedDNSNameTTL
+	}
+	name := strings.ToLower(strings.TrimSuffix(dnsName, "."))
+
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	for wildcardName, entry := range e.dnsEntries {
+		if entry.learnedNames == nil || !util.DNSNameMatchesWildcard(wildcardName, name) {
+			continue
+		}
+		if _, exists := entry.learnedNames[name]; !exists {
+			maxLearnedNames := config.OVNKubernetesFeature.EgressFirewallDNSMaxLearnedNames
+			if maxLearnedNames > 0 && len(entry.learnedNames) >= maxLearnedNames {
+				evictLearnedDNSName(entry)
+			}
+			metrics.UpdateEgressFirewallDNSLearnedNames(1)
+		}
+		entry.learnedNames[name] = &learnedDNSName{
+			ips:     ips,
+			expires: time.Now().Add(ttl),
+		}
+		if err := updateLearnedEntry(wildcardName, entry); err != nil {
+			utilruntime.HandleError(err)
+		}
+	}
+}
+
+// evictLearnedDNSName removes the learned name expiring first from the dnsEntry of a wildcard dnsName.
+func evictLearnedDNSName(entry *dnsEntry) {
+	var evictedName string
+	var evictedExpires time.Time
+	for name, learned := range entry.learnedNames {
+		if evictedName == "" || learned.expires.Before(evictedExpires) {
+			evictedName = name
+			evictedExpires = learned.expires
+		}
+	}
+	delete(entry.learnedNames, evictedName)
+	metrics.UpdateEgressFirewallDNSLearnedNames(-1)
+	metrics.IncrementEgressFirewallDNSLearnedNamesEvicted()
+}
+
+// purgeExpiredLearnedDNSNames removes the expired learned names of all wildcard dnsNames and
+// updates their addressSets.
+func (e *EgressDNS) purgeExpiredLearnedDNSNames() {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	now := time.Now()
+	for wildcardName, entry := range e.dnsEntries {
+		purged := 0
+		for name, learned := range entry.learnedNames {
+			if learned.expires.Before(now) {
+				delete(entry.learnedNames, name)
+				purged++
+			}
+		}
+		if purged == 0 {
+			continue
+		}
+		metrics.UpdateEgressFirewallDNSLearnedNames(-float64(purged))
+		if err := updateLearnedEntry(wildcardName, entry); err != nil {
+			utilruntime.HandleError(err)
+		}
+	}
+}
+
+// updateLearnedEntry sets the addressSet of a wildcard dnsName to the IPs of all its learned names.
+// Must be called with the EgressDNS lock held.
+func updateLearnedEntry(wildcardName string, entry *dnsEntry) error {
+	ipSet := sets.New[string]()
+	ips := []net.IP{}
+	for _, learned := range entry.learnedNames {
+		for _, ip := range learned.ips {
+			if !ipSet.Has(ip.String()) {
+				ipSet.Insert(ip.String())
+				ips = append(ips, ip)
+			}
+		}
+	}
+	currentIPSet := sets.New[string]()
+	for _, ip := range entry.dnsResolves {
+		currentIPSet.Insert(ip.String())
+	}
+	if entry.dnsResolves != nil && ipSet.Equal(currentIPSet) {
+		return nil
+	}
+	entry.dnsResolves = ips
+	if err := entry.dnsAddressSet.SetIPs(filterClusterSubnetIPs(ips)); err != nil {
+		return fmt.Errorf("cannot add IPs from EgressFirewall AddressSet %s: %v", wildcardName, err)
 	}
 	return nil
 }
@@ -189,15 +332,35 @@ func (e *EgressDNS) addToDNS(dnsName string) {
 //     and the durationTillNextQuery is updated
 //  2. e.added is received and durationTillNextQuery is recomputed
 //  3. e.deleted is received and coincides with dnsName
+//
+// Names matching wildcard dnsNames are not queried, they are learned from the dnstap messages of the cluster
+// DNS pods received on the configured address and purged once they expire.
 func (e *EgressDNS) Run(defaultInterval time.Duration) {
 	var domainNameExpiringNext, domainNameDeleted string
 	var ttl time.Time
 	var timeSet bool
 	// initially the next DNS Query happens at the default interval
 	durationTillNextQuery := defaultInterval
+	var dnstapListener net.Listener
+	if address := config.OVNKubernetesFeature.EgressFirewallDNSResponseAddress; address != "" {
+		var err error
+		if dnstapListener, err = e.startDNSTapListener(address); err != nil {
+			klog.Errorf("Failed to listen for dnstap messages on %s, wildcard EgressFirewall dnsNames "+
+				"won't be learned: %v", address, err)
+		}
+	}
 	go func() {
 		timer := time.NewTicker(durationTillNextQuery)
 		defer timer.Stop()
+		purgeTimer := time.NewTicker(learnedDNSNamesPurgeInterval)
+		defer purgeTimer.Stop()
+		if dnstapListener != nil {
+			defer func() {
+				if err := dnstapListener.Close(); err != nil {
+					klog.Warningf("Failed to stop listening for dnstap messages: %v", err)
+				}
+			}()
+		}
 		for {
 			// perform periodic updates on dnsNames as each ttl runs out, checking for updates at
 			// least every defaultInterval. Update durationTillNextQuery everytime a new DNS name gets
@@ -214,6 +377,9 @@ func (e *EgressDNS) Run(defaultInterval time.Duration) {
 						utilruntime.HandleError(err)
 					}
 				}
+			case <-purgeTimer.C:
+				e.purgeExpiredLearnedDNSNames()
+				continue
 			case domainNameDeleted = <-e.deleted:
 				// If domainNameExpiringNext we are waiting to update was deleted,
 				// recalculate durationTillNextQuery and domainNameExpiringNext.
@@ -15,9 +15,16 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
+	dnstap "github.com/dnstap/golang-dnstap"
 	"github.com/miekg/dns"
+	"google.golang.org/protobuf/proto"
+	kapi "k8s.io/api/core/v1"
+	discovery "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	utilnet "k8s.io/utils/net"
 )
 
@@ -63,7 +70,7 @@ func TestNewEgressDNS(t *testing.T) {
 				}
 				call.Once()
 			}
-			_, err := NewEgressDNS(testOvnAddFtry, DefaultNetworkControllerName, testCh)
+			_, err := NewEgressDNS(testOvnAddFtry, DefaultNetworkControllerName, nil, testCh)
 			//t.Log(res, err)
 			if tc.errExp {
 				assert.Error(t, err)
@@ -339,7 +346,7 @@ func TestAdd(t *testing.T) {
 				}
 				call.Once()
 			}
-			res, err := NewEgressDNS(mockAddressSetFactoryOps, DefaultNetworkControllerName, testCh)
+			res, err := NewEgressDNS(mockAddressSetFactoryOps, DefaultNetworkControllerName, nil, testCh)
 			assert.NoError(t, err)
 
 			res.Run(tc.syncTime)
@@ -480,7 +487,7 @@ func TestDelete(t *testing.T) {
 				}
 				call.Once()
 			}
-			res, err := NewEgressDNS(mockAddressSetFactoryOps, DefaultNetworkControllerName, testCh)
+			res, err := NewEgressDNS(mockAddressSetFactoryOps, DefaultNetworkControllerName, nil, testCh)
 			assert.NoError(t, err)
 
 			res.Run(tc.syncTime)
@@ -543,3 +550,282 @@ func (e *EgressDNS) getDNSEntry(dnsName string) (map[string]struct{}, []net.IP,
 
 	return nil, nil, nil
 }
+
+func TestLearnDNSResponse(t *testing.T) {
+	mockAddressSetFactoryOps := new(mocks.AddressSetFactory)
+	mockAddressSetOps := new(mocks.AddressSet)
+	mockDnsOps := new(util_mocks.DNSOps)
+	util.SetDNSLibOpsMockInst(mockDnsOps)
+	wildcardDNSName := "*.example.com"
+	test1IPv4 := "2.2.2.2"
+	test2IPv4 := "3.3.3.3"
+	clusterSubnetIP := "10.128.0.1"
+	_, clusterSubnet, _ := net.ParseCIDR("10.128.0.0/14")
+
+	config.PrepareTestConfig()
+	config.IPv4Mode = true
+	config.Default.ClusterSubnets = []config.CIDRNetworkEntry{{CIDR: clusterSubnet}}
+	config.OVNKubernetesFeature.EgressFirewallDNSMaxLearnedNames = 1
+	config.OVNKubernetesFeature.EgressFirewallDNSResponseAddress = "127.0.0.1:6000"
+
+	mockDnsOps.On("ClientConfigFromFile", mock.AnythingOfType("string")).Return(&dns.ClientConfig{
+		Servers: []string{"1.1.1.1"},
+		Port:    "1234"}, nil).Once()
+	mockAddressSetFactoryOps.On("NewAddressSet", mock.AnythingOfType("*ops.DbObjectIDs"),
+		mock.AnythingOfType("[]net.IP")).Return(mockAddressSetOps, nil).Once()
+
+	testCh := make(chan struct{})
+	defer close(testCh)
+	res, err := NewEgressDNS(mockAddressSetFactoryOps, DefaultNetworkControllerName, nil, testCh)
+	assert.NoError(t, err)
+	// wildcard names are never resolved, so no DNS query is expected
+	_, err = res.Add("addNamespace", wildcardDNSName)
+	assert.NoError(t, err)
+
+	// a response for a matching name through a CNAME adds its IPs, except the ones from the cluster subnet,
+	// the records that are not owned by the names of the CNAME chain are ignored
+	mockAddressSetOps.On("SetIPs", []net.IP{net.ParseIP(test1IPv4)}).Return(nil).Once()
+	cname, _ := dns.NewRR("www.example.com.        300     IN      CNAME       www.example.net.")
+	res.learnFromDNSResponse(&dns.Msg{
+		MsgHdr:   dns.MsgHdr{Response: true, Rcode: dns.RcodeSuccess},
+		Question: []dns.Question{{Name: "www.example.com.", Qtype: dns.TypeA, Qclass: dns.ClassINET}},
+		Answer: []dns.RR{cname, generateRR("www.example.net", test1IPv4, "300"), generateRR("www.example.net", clusterSubnetIP, "300"),
+			generateRR("other.example.org", test2IPv4, "300")},
+	})
+	_, dnsResolves, _ := res.getDNSEntry(wildcardDNSName)
+	assert.Len(t, dnsResolves, 2)
+
+	// names not matching the wildcard and failed responses are ignored
+	res.LearnDNSResponse("example.com", []net.IP{net.ParseIP(test2IPv4)}, time.Minute)
+	res.learnFromDNSResponse(&dns.Msg{
+		MsgHdr:   dns.MsgHdr{Response: true, Rcode: dns.RcodeNameError},
+		Question: []dns.Question{{Name: "api.example.com.", Qtype: dns.TypeA, Qclass: dns.ClassINET}},
+	})
+
+	// the cache is full, so the first learned name is evicted
+	mockAddressSetOps.On("SetIPs", []net.IP{net.ParseIP(test2IPv4)}).Return(nil).Once()
+	res.LearnDNSResponse("API.example.com.", []net.IP{net.ParseIP(test2IPv4)}, time.Minute)
+	// learning the same IPs again doesn't update the address set
+	res.LearnDNSResponse("api.example.com", []net.IP{net.ParseIP(test2IPv4)}, time.Minute)
+	_, dnsResolves, _ = res.getDNSEntry(wildcardDNSName)
+	assert.Equal(t, []net.IP{net.ParseIP(test2IPv4)}, dnsResolves)
+
+	// expired names are purged
+	mockAddressSetOps.On("SetIPs", []net.IP{}).Return(nil).Once()
+	res.lock.Lock()
+	res.dnsEntries[wildcardDNSName].learnedNames["api.example.com"].expires = time.Now().Add(-time.Second)
+	res.lock.Unlock()
+	res.purgeExpiredLearnedDNSNames()
+	_, dnsResolves, _ = res.getDNSEntry(wildcardDNSName)
+	assert.Empty(t, dnsResolves)
+
+	mockAddressSetOps.On("Destroy").Return(nil).Once()
+	assert.NoError(t, res.Delete("addNamespace"))
+	namespaces, _, _ := res.getDNSEntry(wildcardDNSName)
+	assert.Nil(t, namespaces)
+
+	mockDnsOps.AssertExpectations(t)
+	mockAddressSetFactoryOps.AssertExpectations(t)
+	mockAddressSetOps.AssertExpectations(t)
+}
+
+func TestAddWildcardDNSNameWithoutDNSResponses(t *testing.T) {
+	mockAddressSetFactoryOps := new(mocks.AddressSetFactory)
+	mockDnsOps := new(util_mocks.DNSOps)
+	util.SetDNSLibOpsMockInst(mockDnsOps)
+
+	config.PrepareTestConfig()
+	mockDnsOps.On("ClientConfigFromFile", mock.AnythingOfType("string")).Return(&dns.ClientConfig{
+		Servers: []string{"1.1.1.1"},
+		Port:    "1234"}, nil).Once()
+
+	testCh := make(chan struct{})
+	defer close(testCh)
+	res, err := NewEgressDNS(mockAddressSetFactoryOps, DefaultNetworkControllerName, nil, testCh)
+	assert.NoError(t, err)
+	// without the cluster DNS responses the names matching the wildcard can't be learned
+	_, err = res.Add("addNamespace", "*.example.com")
+	assert.ErrorContains(t, err, "egressfirewall-dns-response-address is not set")
+	namespaces, _, _ := res.getDNSEntry("*.example.com")
+	assert.Nil(t, namespaces)
+
+	mockDnsOps.AssertExpectations(t)
+	mockAddressSetFactoryOps.AssertExpectations(t)
+}
+
+func newTestDNSTapFrame(t *testing.T, msgType dnstap.Message_Type, clientPort uint32, msg *dns.Msg) []byte {
+	packed, err := msg.Pack()
+	assert.NoError(t, err)
+	dt := &dnstap.Dnstap{
+		Type: dnstap.Dnstap_MESSAGE.Enum(),
+		Message: &dnstap.Message{
+			Type:         msgType.Enum(),
+			QueryAddress: net.ParseIP("10.128.0.5").To4(),
+			QueryPort:    &clientPort,
+		},
+	}
+	if msgType == dnstap.Message_CLIENT_QUERY {
+		dt.Message.QueryMessage = packed
+	} else {
+		dt.Message.ResponseMessage = packed
+	}
+	frame, err := proto.Marshal(dt)
+	assert.NoError(t, err)
+	return frame
+}
+
+func TestLearnFromDNSTapFrame(t *testing.T) {
+	query := &dns.Msg{}
+	query.SetQuestion("www.example.com.", dns.TypeA)
+	query.Id = 1234
+	response := &dns.Msg{}
+	response.SetReply(query)
+	response.Answer = []dns.RR{generateRR("www.example.com", "2.2.2.2", "300")}
+	otherResponse := response.Copy()
+	otherResponse.Id = 4321
+
+	tests := []struct {
+		desc   string
+		frames func(t *testing.T) [][]byte
+		// the names expected to be learned
+		expectedLearned []string
+	}{
+		{
+			desc: "response to a client query is learned",
+			frames: func(t *testing.T) [][]byte {
+				return [][]byte{
+					newTestDNSTapFrame(t, dnstap.Message_CLIENT_QUERY, 5353, query),
+					newTestDNSTapFrame(t, dnstap.Message_CLIENT_RESPONSE, 5353, response),
+				}
+			},
+			expectedLearned: []string{"www.example.com"},
+		},
+		{
+			desc: "response without a client query is ignored",
+			frames: func(t *testing.T) [][]byte {
+				return [][]byte{
+					newTestDNSTapFrame(t, dnstap.Message_CLIENT_RESPONSE, 5353, response),
+				}
+			},
+		},
+		{
+			desc: "response with another ID is ignored",
+			frames: func(t *testing.T) [][]byte {
+				return [][]byte{
+					newTestDNSTapFrame(t, dnstap.Message_CLIENT_QUERY, 5353, query),
+					newTestDNSTapFrame(t, dnstap.Message_CLIENT_RESPONSE, 5353, otherResponse),
+				}
+			},
+		},
+		{
+			desc: "response to another client port is ignored",
+			frames: func(t *testing.T) [][]byte {
+				return [][]byte{
+					newTestDNSTapFrame(t, dnstap.Message_CLIENT_QUERY, 5353, query),
+					newTestDNSTapFrame(t, dnstap.Message_CLIENT_RESPONSE, 5354, response),
+				}
+			},
+		},
+		{
+			desc: "invalid frame is ignored",
+			frames: func(t *testing.T) [][]byte {
+				return [][]byte{[]byte("not a dnstap frame")}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			mockAddressSetOps := new(mocks.AddressSet)
+			mockAddressSetOps.On("SetIPs", mock.AnythingOfType("[]net.IP")).Return(nil)
+			config.PrepareTestConfig()
+			e := &EgressDNS{
+				dnsEntries: map[string]*dnsEntry{
+					"*.example.com": {
+						namespaces:    map[string]struct{}{"namespace1": {}},
+						dnsAddressSet: mockAddressSetOps,
+						learnedNames:  map[string]*learnedDNSName{},
+					},
+				},
+			}
+			tracker := newDNSQueryTracker()
+			for _, frame := range tt.frames(t) {
+				e.learnFromDNSTapFrame(tracker, frame)
+			}
+			var learned []string
+			for name := range e.dnsEntries["*.example.com"].learnedNames {
+				learned = append(learned, name)
+			}
+			assert.Equal(t, tt.expectedLearned, learned)
+		})
+	}
+}
+
+func TestIsClusterDNSSourceIP(t *testing.T) {
+	config.PrepareTestConfig()
+	dnsNode := "node1"
+	endpointSlice := &discovery.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "kube-dns-abcde",
+			Namespace: "kube-system",
+			Labels:    map[string]string{discovery.LabelServiceName: "kube-dns"},
+		},
+		AddressType: discovery.AddressTypeIPv4,
+		Endpoints: []discovery.Endpoint{
+			{Addresses: []string{"10.244.1.5"}, NodeName: &dnsNode},
+		},
+	}
+	nodes := []runtime.Object{
+		&kapi.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        dnsNode,
+				Annotations: map[string]string{util.OVNNodeHostCIDRs: `["172.18.0.2/16","192.168.10.2/24"]`},
+			},
+			Status: kapi.NodeStatus{Addresses: []kapi.NodeAddress{{Type: kapi.NodeInternalIP, Address: "172.18.0.2"}}},
+		},
+		&kapi.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node2"},
+			Status:     kapi.NodeStatus{Addresses: []kapi.NodeAddress{{Type: kapi.NodeInternalIP, Address: "172.18.0.3"}}},
+		},
+	}
+	fakeClient := util.GetOVNClientset(append(nodes, endpointSlice)...).GetMasterClientset()
+	watchFactory, err := factory.NewMasterWatchFactory(fakeClient)
+	assert.NoError(t, err)
+	assert.NoError(t, watchFactory.Start())
+	t.Cleanup(watchFactory.Shutdown)
+	e := &EgressDNS{watchFactory: watchFactory}
+
+	tests := []struct {
+		desc     string
+		ip       string
+		expected bool
+	}{
+		{
+			desc:     "cluster DNS pod is accepted",
+			ip:       "10.244.1.5",
+			expected: true,
+		},
+		{
+			desc:     "node IP of a node hosting a cluster DNS pod is accepted",
+			ip:       "172.18.0.2",
+			expected: true,
+		},
+		{
+			desc:     "host address of a node hosting a cluster DNS pod is accepted",
+			ip:       "192.168.10.2",
+			expected: true,
+		},
+		{
+			desc: "other pod is rejected",
+			ip:   "10.244.1.6",
+		},
+		{
+			desc: "node IP of a node without cluster DNS pods is rejected",
+			ip:   "172.18.0.3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			assert.Equal(t, tt.expected, e.isClusterDNSSourceIP(net.ParseIP(tt.ip)))
+		})
+	}
+}
@@ -0,0 +1,292 @@
+package ovn
+
+import (
+	"errors"
+	"math"
+	"net"
+	"strings"
+	"time"
+
+	dnstap "github.com/dnstap/golang-dnstap"
+	"github.com/miekg/dns"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// dnstapHandshakeTimeout bounds the frame streams handshake of a dnstap connection.
+	dnstapHandshakeTimeout = 10 * time.Second
+	// maxPendingDNSQueries is the maximum number of client queries of a dnstap connection waiting for their response.
+	maxPendingDNSQueries = 10000
+	// pendingDNSQueryTimeout is how long a client query waits for its response.
+	pendingDNSQueryTimeout = 30 * time.Second
+	// maxCNAMEChainLength is the maximum number of CNAME records followed from the question name of a response.
+	maxCNAMEChainLength = 8
+)
+
+// dnsQueryKey identifies a client query of a cluster DNS pod.
+type dnsQueryKey struct {
+	clientAddress string
+	clientPort    uint32
+	id            uint16
+	name          string
+	qtype         uint16
+}
+
+// dnsQueryTracker holds the client queries seen on a dnstap connection, so that only the responses to these
+// queries are learned from.
+type dnsQueryTracker struct {
+	pending map[dnsQueryKey]time.Time
+}
+
+func newDNSQueryTracker() *dnsQueryTracker {
+	return &dnsQueryTracker{
+		pending: make(map[dnsQueryKey]time.Time),
+	}
+}
+
+// newDNSQueryKey returns the key of the query the given dnstap message and its query or response DNS message
+// belong to, false if the DNS message doesn't hold exactly one question.
+func newDNSQueryKey(m *dnstap.Message, msg *dns.Msg) (dnsQueryKey, bool) {
+	if len(msg.Question) != 1 {
+		return dnsQueryKey{}, false
+	}
+	return dnsQueryKey{
+		clientAddress: net.IP(m.GetQueryAddress()).String(),
+		clientPort:    m.GetQueryPort(),
+		id:            msg.Id,
+		name:          strings.ToLower(dns.Fqdn(msg.Question[0].Name)),
+		qtype:         msg.Question[0].Qtype,
+	}, true
+}
+
+// addQuery records a client query. When too many queries are waiting for their response, the expired
+// ones are dropped first and the query is ignored if none expired.
+func (t *dnsQueryTracker) addQuery(key dnsQueryKey, now time.Time) {
+	if len(t.pending) >= maxPendingDNSQueries {
+		for pendingKey, seen := range t.pending {
+			if now.Sub(seen) > pendingDNSQueryTimeout {
+				delete(t.pending, pendingKey)
+			}
+		}
+		if len(t.pending) >= maxPendingDNSQueries {
+			klog.V(5).Infof("Too many DNS queries waiting for their response, ignoring query for %s", key.name)
+			return
+		}
+	}
+	t.pending[key] = now
+}
+
+// matchResponse returns true if a response answers a client query that didn't time out, the query is
+// then forgotten so that a response is learned from once.
+func (t *dnsQueryTracker) matchResponse(key dnsQueryKey, now time.Time) bool {
+	seen, found := t.pending[key]
+	if !found {
+		return false
+	}
+	delete(t.pending, key)
+	return now.Sub(seen) <= pendingDNSQueryTimeout
+}
+
+// learnFromDNSTapFrame tracks the client queries and learns from the responses to these queries of a
+// dnstap frame sent by a cluster DNS pod.
+func (e *EgressDNS) learnFromDNSTapFrame(tracker *dnsQueryTracker, frame []byte) {
+	dt := &dnstap.Dnstap{}
+	if err := proto.Unmarshal(frame, dt); err != nil {
+		klog.V(5).Infof("Ignoring invalid dnstap frame: %v", err)
+		return
+	}
+	m := dt.GetMessage()
+	if dt.GetType() != dnstap.Dnstap_MESSAGE || m == nil {
+		return
+	}
+	now := time.Now()
+	msg := &dns.Msg{}
+	switch m.GetType() {
+	case dnstap.Message_CLIENT_QUERY:
+		if err := msg.Unpack(m.GetQueryMessage()); err != nil || msg.Response {
+			return
+		}
+		if key, ok := newDNSQueryKey(m, msg); ok {
+			tracker.addQuery(key, now)
+		}
+	case dnstap.Message_CLIENT_RESPONSE:
+		if err := msg.Unpack(m.GetResponseMessage()); err != nil {
+			return
+		}
+		key, ok := newDNSQueryKey(m, msg)
+		if !ok || !tracker.matchResponse(key, now) {
+			klog.V(5).Infof("Ignoring DNS response that doesn't match a client query")
+			return
+		}
+		e.learnFromDNSResponse(msg)
+	}
+}
+
+// learnFromDNSResponse learns the question name of a DNS response as resolving to the A and AAAA records
+// of its answers. Only the records owned by the question name, or by the names of the CNAME chain starting
+// from it, are taken into account.
+func (e *EgressDNS) learnFromDNSResponse(msg *dns.Msg) {
+	if !msg.Response || msg.Rcode != dns.RcodeSuccess || len(msg.Question) != 1 {
+		return
+	}
+	question := msg.Question[0]
+	name := strings.ToLower(dns.Fqdn(question.Name))
+	var minTTL uint32 = math.MaxUint32
+	for i := 0; i < maxCNAMEChainLength; i++ {
+		var cname *dns.CNAME
+		for _, rr := range msg.Answer {
+			if t, ok := rr.(*dns.CNAME); ok && strings.EqualFold(dns.Fqdn(t.Hdr.Name), name) {
+				cname = t
+				break
+			}
+		}
+		if cname == nil {
+			break
+		}
+		name = strings.ToLower(dns.Fqdn(cname.Target))
+		minTTL = min(minTTL, cname.Hdr.Ttl)
+	}
+	var ips []net.IP
+	for _, rr := range msg.Answer {
+		if !strings.EqualFold(dns.Fqdn(rr.Header().Name), name) {
+			continue
+		}
+		switch t := rr.(type) {
+		case *dns.A:
+			ips = append(ips, t.A)
+		case *dns.AAAA:
+			ips = append(ips, t.AAAA)
+		default:
+			continue
+		}
+		minTTL = min(minTTL, rr.Header().Ttl)
+	}
+	if len(ips) == 0 {
+		return
+	}
+	e.LearnDNSResponse(question.Name, ips, time.Duration(minTTL)*time.Second)
+}
+
+// isClusterDNSSourceIP returns true if ip is the address of an endpoint of the cluster DNS service, or an address of
+// a node hosting one of them: the dnstap connections of the DNS pods running on other nodes reach this hostNetwork
+// listener SNATed to the IP of their node.
+func (e *EgressDNS) isClusterDNSSourceIP(ip net.IP) bool {
+	namespace, name, err := cache.SplitMetaNamespaceKey(config.OVNKubernetesFeature.EgressFirewallDNSService)
+	if err != nil {
+		klog.Errorf("Invalid cluster DNS service %s: %v", config.OVNKubernetesFeature.EgressFirewallDNSService, err)
+		return false
+	}
+	endpointSlices, err := e.watchFactory.GetEndpointSlices(namespace, name)
+	if err != nil {
+		klog.Errorf("Failed to get the endpoint slices of the cluster DNS service %s/%s: %v", namespace, name, err)
+		return false
+	}
+	nodeNames := sets.New[string]()
+	for _, endpointSlice := range endpointSlices {
+		for _, endpoint := range endpointSlice.Endpoints {
+			for _, address := range endpoint.Addresses {
+				if ip.Equal(net.ParseIP(address)) {
+					return true
+				}
+			}
+			if endpoint.NodeName != nil {
+				nodeNames.Insert(*endpoint.NodeName)
+			}
+		}
+	}
+	for nodeName := range nodeNames {
+		node, err := e.watchFactory.GetNode(nodeName)
+		if err != nil {
+			klog.Warningf("Failed to get node %s hosting a cluster DNS pod: %v", nodeName, err)
+			continue
+		}
+		if isNodeIP(node, ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNodeIP returns true if ip is an internal or external address of the node, or one of its host addresses.
+func isNodeIP(node *kapi.Node, ip net.IP) bool {
+	for _, address := range node.Status.Addresses {
+		if (address.Type == kapi.NodeInternalIP || address.Type == kapi.NodeExternalIP) && ip.Equal(net.ParseIP(address.Address)) {
+			return true
+		}
+	}
+	// the host addresses are not annotated before ovnkube-node starts on the node
+	hostAddresses, err := util.ParseNodeHostCIDRsDropNetMask(node)
+	if err != nil {
+		return false
+	}
+	return hostAddresses.Has(ip.String())
+}
+
+// startDNSTapListener receives the dnstap messages of the cluster DNS pods over frame streams on the given TCP
+// address and learns the names matching wildcard dnsNames from the responses to their client queries.
+// Connections from other sources than the endpoints of the cluster DNS service and the nodes hosting them are
+// rejected.
+func (e *EgressDNS) startDNSTapListener(address string) (net.Listener, error) {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	klog.Infof("Listening for dnstap messages on %s to learn wildcard EgressFirewall dnsNames", address)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				if errors.Is(err, net.ErrClosed) {
+					return
+				}
+				klog.Warningf("Failed to accept dnstap connection: %v", err)
+				continue
+			}
+			go e.serveDNSTapConnection(conn)
+		}
+	}()
+	return listener, nil
+}
+
+// serveDNSTapConnection reads the dnstap messages of a connection until it or EgressDNS is closed.
+func (e *EgressDNS) serveDNSTapConnection(conn net.Conn) {
+	defer conn.Close()
+	remoteAddr, ok := conn.RemoteAddr().(*net.TCPAddr)
+	if !ok || !e.isClusterDNSSourceIP(remoteAddr.IP) {
+		klog.Warningf("Rejecting dnstap connection from %s: not a cluster DNS pod or its node", conn.RemoteAddr())
+		return
+	}
+	input, err := dnstap.NewFrameStreamInputTimeout(conn, true, dnstapHandshakeTimeout)
+	if err != nil {
+		klog.Warningf("Failed to start dnstap connection from %s: %v", conn.RemoteAddr(), err)
+		return
+	}
+	frames := make(chan []byte, 32)
+	go func() {
+		input.ReadInto(frames)
+		close(frames)
+	}()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		// closing the connection stops reading it
+		select {
+		case <-done:
+		case <-e.stopChan:
+		case <-e.controllerStop:
+		}
+		conn.Close()
+	}()
+	tracker := newDNSQueryTracker()
+	for frame := range frames {
+		e.learnFromDNSTapFrame(tracker, frame)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	clusternetworkapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1"
 	egressfirewallapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1"
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
@@ -27,6 +28,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	utilpointer "k8s.io/utils/pointer"
 )
 
 func newObjectMeta(name, namespace string) metav1.ObjectMeta {
@@ -865,6 +867,45 @@ var _ = ginkgo.Describe("OVN EgressFirewall Operations", func() {
 				err := app.Run([]string{app.Name})
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			})
+			ginkgo.It(fmt.Sprintf("updates the subnet exclusion of an egressfirewall when cluster subnets are added, gateway mode %s", gwMode), func() {
+				config.Gateway.Mode = gwMode
+				app.Action = func(ctx *cli.Context) error {
+					clusterSubnetStr := "10.128.0.0/14"
+					_, clusterSubnet, _ := net.ParseCIDR(clusterSubnetStr)
+					config.Default.ClusterSubnets = []config.CIDRNetworkEntry{{CIDR: clusterSubnet, HostSubnetLength: 23}}
+
+					namespace1 := *newNamespace("namespace1")
+					egressFirewall := newEgressFirewallObject("default", namespace1.Name, []egressfirewallapi.EgressFirewallRule{
+						{
+							Type: "Deny",
+							To: egressfirewallapi.EgressFirewallDestination{
+								CIDRSelector: "0.0.0.0/0",
+							},
+						},
+					})
+					startOvn(dbSetup, []v1.Namespace{namespace1}, []egressfirewallapi.EgressFirewall{*egressFirewall})
+
+					expectedDatabaseState := getEFExpectedDb(initialData, fakeOVN, namespace1.Name,
+						"(ip4.dst == 0.0.0.0/0 && ip4.dst != "+clusterSubnetStr+")", "", nbdb.ACLActionDrop)
+					gomega.Eventually(fakeOVN.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
+
+					addedSubnetStr := "10.132.0.0/14"
+					fakeOVN.controller.addClusterNetwork(&clusternetworkapi.ClusterNetwork{
+						ObjectMeta: metav1.ObjectMeta{Name: "expansion"},
+						Spec: clusternetworkapi.ClusterNetworkSpec{
+							ClusterSubnets: []clusternetworkapi.ClusterSubnet{{CIDR: addedSubnetStr, HostSubnetLength: 23}},
+						},
+					})
+
+					expectedDatabaseState = getEFExpectedDb(initialData, fakeOVN, namespace1.Name,
+						"(ip4.dst == 0.0.0.0/0 && ip4.dst != "+clusterSubnetStr+"&&ip4.dst != "+addedSubnetStr+")", "", nbdb.ACLActionDrop)
+					gomega.Eventually(fakeOVN.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
+
+					return nil
+				}
+				err := app.Run([]string{app.Name})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			})
 			ginkgo.It(fmt.Sprintf("correctly creates an egressfirewall for namespace name > 43 symbols, gateway mode %s", gwMode), func() {
 				app.Action = func(ctx *cli.Context) error {
 					// 52 characters namespace
@@ -985,7 +1026,7 @@ var _ = ginkgo.Describe("OVN EgressFirewall Operations", func() {
 					var err error
 					setDNSOpsMock(dnsName, resolvedIP)
 					fakeOVN.controller.egressFirewallDNS, err = NewEgressDNS(fakeOVN.controller.addressSetFactory,
-						fakeOVN.controller.controllerName, fakeOVN.controller.stopChan)
+						fakeOVN.controller.controllerName, fakeOVN.controller.watchFactory, fakeOVN.controller.stopChan)
 					gomega.Expect(err).NotTo(gomega.HaveOccurred())
 
 					_, err = fakeOVN.fakeClient.EgressFirewallClient.K8sV1().EgressFirewalls(egressFirewall.Namespace).
@@ -1118,6 +1159,30 @@ var _ = ginkgo.Describe("OVN test basic functions", func() {
 				},
 				expectedMatch: "((udp && ( udp.dst == 400 )) || (tcp && ( tcp.dst == 100 || tcp.dst == 102 )) || (sctp && ( sctp.dst == 13 )))",
 			},
+			{
+				ports: []egressfirewallapi.EgressFirewallPort{
+					{
+						Protocol: "TCP",
+						Port:     30000,
+						EndPort:  utilpointer.Int32(32767),
+					},
+					{
+						Protocol: "SCTP",
+						Port:     13,
+						EndPort:  utilpointer.Int32(15),
+					},
+					{
+						Protocol: "TCP",
+						Port:     102,
+					},
+					{
+						Protocol: "UDP",
+						Port:     400,
+						EndPort:  utilpointer.Int32(400),
+					},
+				},
+				expectedMatch: "((udp && ( udp.dst == 400 )) || (tcp && ( 30000<=tcp.dst<=32767 || tcp.dst == 102 )) || (sctp && ( 13<=sctp.dst<=15 )))",
+			},
 		}
 		for _, test := range testcases {
 			l4Match := egressGetL4Match(test.ports)
@@ -1382,6 +1447,52 @@ var _ = ginkgo.Describe("OVN test basic functions", func() {
 					to:     destination{cidrSelector: "2002:0:0:1234:0001::/80", clusterSubnetIntersection: true},
 				},
 			},
+			// port range tests
+			{
+				egressFirewallRule: egressfirewallapi.EgressFirewallRule{
+					Type: egressfirewallapi.EgressFirewallRuleAllow,
+					To:   egressfirewallapi.EgressFirewallDestination{CIDRSelector: "1.2.3.4/32"},
+					Ports: []egressfirewallapi.EgressFirewallPort{
+						{Protocol: "TCP", Port: 30000, EndPort: utilpointer.Int32(32767)},
+					},
+				},
+				id:  1,
+				err: false,
+				output: egressFirewallRule{
+					id:     1,
+					access: egressfirewallapi.EgressFirewallRuleAllow,
+					ports: []egressfirewallapi.EgressFirewallPort{
+						{Protocol: "TCP", Port: 30000, EndPort: utilpointer.Int32(32767)},
+					},
+					to: destination{cidrSelector: "1.2.3.4/32"},
+				},
+			},
+			{
+				egressFirewallRule: egressfirewallapi.EgressFirewallRule{
+					Type: egressfirewallapi.EgressFirewallRuleAllow,
+					To:   egressfirewallapi.EgressFirewallDestination{CIDRSelector: "1.2.3.4/32"},
+					Ports: []egressfirewallapi.EgressFirewallPort{
+						{Protocol: "SCTP", Port: 200, EndPort: utilpointer.Int32(100)},
+					},
+				},
+				id:        1,
+				err:       true,
+				errOutput: "invalid SCTP port range: endPort 100 is lower than port 200",
+				output:    egressFirewallRule{},
+			},
+			{
+				egressFirewallRule: egressfirewallapi.EgressFirewallRule{
+					Type: egressfirewallapi.EgressFirewallRuleAllow,
+					To:   egressfirewallapi.EgressFirewallDestination{CIDRSelector: "1.2.3.4/32"},
+					Ports: []egressfirewallapi.EgressFirewallPort{
+						{Protocol: "UDP", EndPort: utilpointer.Int32(100)},
+					},
+				},
+				id:        1,
+				err:       true,
+				errOutput: "invalid UDP port range: endPort 100 is set without port",
+				output:    egressFirewallRule{},
+			},
 			// nodeSelector tests
 			// selector matches nothing
 			{
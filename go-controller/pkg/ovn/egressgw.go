@@ -719,7 +719,7 @@ func (oc *DefaultNetworkController) addHybridRoutePolicyForPod(podIP net.IP, nod
 
 		var matchDst string
 		var clusterL3Prefix string
-		for _, clusterSubnet := range config.Default.ClusterSubnets {
+		for _, clusterSubnet := range config.ClusterSubnets() {
 			if utilnet.IsIPv6CIDR(clusterSubnet.CIDR) {
 				clusterL3Prefix = "ip6"
 			} else {
@@ -792,7 +792,7 @@ func (oc *DefaultNetworkController) delHybridRoutePolicyForPod(podIP net.IP, nod
 		if deletePolicy {
 			var matchDst string
 			var clusterL3Prefix string
-			for _, clusterSubnet := range config.Default.ClusterSubnets {
+			for _, clusterSubnet := range config.ClusterSubnets() {
 				if utilnet.IsIPv6CIDR(clusterSubnet.CIDR) {
 					clusterL3Prefix = "ip6"
 				} else {
@@ -15,6 +15,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	egressipv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kubevirt"
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
@@ -135,6 +136,17 @@ func (oc *DefaultNetworkController) reconcileEgressIP(old, new *egressipv1.Egres
 			}
 		}
 
+		// CASE 3.1.1: the load balancing mode changed, or the priorities of
+		// the egress IPs in ActiveStandby mode: select the egress IPs serving
+		// the pods again
+		if util.GetEgressIPLoadBalancing(&oldEIP.Spec) != util.GetEgressIPLoadBalancing(&newEIP.Spec) ||
+			(util.GetEgressIPLoadBalancing(&newEIP.Spec) == egressipv1.EgressIPLoadBalancingActiveStandby &&
+				!reflect.DeepEqual(oldEIP.Spec.EgressIPs, newEIP.Spec.EgressIPs)) {
+			if err := oc.syncEgressIPReroutePolicies(newEIP.Name, &newEIP.Spec); err != nil {
+				return err
+			}
+		}
+
 		oldNamespaceSelector, err := metav1.LabelSelectorAsSelector(&oldEIP.Spec.NamespaceSelector)
 		if err != nil {
 			return fmt.Errorf("invalid old namespaceSelector, err: %v", err)
@@ -440,6 +452,57 @@ func (oc *DefaultNetworkController) reconcileEgressIPPod(old, new *v1.Pod) (err
 
 // main reconcile functions end here and local zone controller functions begin
 
+// getEgressIPSpec returns the spec of the EgressIP, or an empty spec if it no
+// longer exists
+func (oc *DefaultNetworkController) getEgressIPSpec(name string) *egressipv1.EgressIPSpec {
+	eIP, err := oc.watchFactory.GetEgressIP(name)
+	if err != nil {
+		return &egressipv1.EgressIPSpec{}
+	}
+	return &eIP.Spec
+}
+
+// syncEgressIPReroutePolicies selects the egress IPs serving each of the pods
+// served by the EgressIP according to the given spec
+func (oc *DefaultNetworkController) syncEgressIPReroutePolicies(name string, spec *egressipv1.EgressIPSpec) error {
+	oc.eIPC.podAssignmentMutex.Lock()
+	defer oc.eIPC.podAssignmentMutex.Unlock()
+	podKeys := sets.New[string]()
+	for podKey := range oc.eIPC.podAssignment {
+		podKeys.Insert(podKey)
+	}
+	return oc.syncEgressIPPodsReroutePolicies(name, spec, podKeys)
+}
+
+// syncEgressIPPodsReroutePolicies selects the egress IPs serving the given pods,
+// if served by the EgressIP, according to the given spec.
+// This function should be called with a lock on podAssignmentMutex
+func (oc *DefaultNetworkController) syncEgressIPPodsReroutePolicies(name string, spec *egressipv1.EgressIPSpec, podKeys sets.Set[string]) error {
+	var errs []error
+	for podKey := range podKeys {
+		podState, exists := oc.eIPC.podAssignment[podKey]
+		if !exists || podState.egressIPName != name || len(podState.egressStatuses.statusMap) == 0 {
+			continue
+		}
+		pod, err := oc.watchFactory.GetPod(getPodNamespaceAndNameFromKey(podKey))
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		podIPs, err := util.GetPodCIDRsWithFullMask(pod, oc.NetInfo)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := oc.eIPC.syncPodReroutePolicies(name, spec, pod, podIPs, podState.egressStatuses.items()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
 func (oc *DefaultNetworkController) addEgressIPAssignments(name string, statusAssignments []egressipv1.EgressIPStatusItem, namespaceSelector, podSelector metav1.LabelSelector) error {
 	namespaces, err := oc.watchFactory.GetNamespacesBySelector(namespaceSelector)
 	if err != nil {
@@ -523,6 +586,8 @@ func (oc *DefaultNetworkController) addPodEgressIPAssignments(name string, statu
 	var remainingAssignments []egressipv1.EgressIPStatusItem
 	var podIPs []*net.IPNet
 	var err error
+	eIPSpec := oc.getEgressIPSpec(name)
+	loadBalancing := util.GetEgressIPLoadBalancing(eIPSpec)
 	if oc.isPodScheduledinLocalZone(pod) {
 		// Retrieve the pod's networking configuration from the
 		// logicalPortCache. The reason for doing this: a) only normal network
@@ -592,7 +657,7 @@ func (oc *DefaultNetworkController) addPodEgressIPAssignments(name string, statu
 		err = oc.eIPC.nodeZoneState.DoWithLock(status.Node, func(key string) error {
 			if status.Node == pod.Spec.NodeName {
 				// we are safe, no need to grab lock again
-				if err := oc.eIPC.addPodEgressIPAssignment(name, status, pod, podIPs); err != nil {
+				if err := oc.eIPC.addPodEgressIPAssignment(name, status, pod, podIPs, loadBalancing); err != nil {
 					return fmt.Errorf("unable to create egressip configuration for pod %s/%s/%v, err: %w", pod.Namespace, pod.Name, podIPs, err)
 				}
 				podState.egressStatuses.statusMap[status] = ""
@@ -600,7 +665,7 @@ func (oc *DefaultNetworkController) addPodEgressIPAssignments(name string, statu
 			}
 			return oc.eIPC.nodeZoneState.DoWithLock(pod.Spec.NodeName, func(key string) error {
 				// we need to grab lock again for pod's node
-				if err := oc.eIPC.addPodEgressIPAssignment(name, status, pod, podIPs); err != nil {
+				if err := oc.eIPC.addPodEgressIPAssignment(name, status, pod, podIPs, loadBalancing); err != nil {
 					return fmt.Errorf("unable to create egressip configuration for pod %s/%s/%v, err: %w", pod.Namespace, pod.Name, podIPs, err)
 				}
 				podState.egressStatuses.statusMap[status] = ""
@@ -611,6 +676,11 @@ func (oc *DefaultNetworkController) addPodEgressIPAssignments(name string, statu
 			return err
 		}
 	}
+	if len(remainingAssignments) > 0 && loadBalancing != egressipv1.EgressIPLoadBalancingPerConnection {
+		if err := oc.eIPC.syncPodReroutePolicies(name, eIPSpec, pod, podIPs, podState.egressStatuses.items()); err != nil {
+			return fmt.Errorf("unable to select the egress IPs serving pod %s/%s/%v, err: %w", pod.Namespace, pod.Name, podIPs, err)
+		}
+	}
 	if oc.isPodScheduledinLocalZone(pod) {
 		// add the podIP to the global egressIP address set
 		addrSetIPs := make([]net.IP, len(podIPs))
@@ -630,11 +700,19 @@ func (oc *DefaultNetworkController) addPodEgressIPAssignments(name string, statu
 // the NB DB for that egress IP object and delete everything which match the
 // status. We also need to update the podAssignment cache and finally re-add the
 // external GW setup in case the pod still exists.
-func (oc *DefaultNetworkController) deleteEgressIPAssignments(name string, statusesToRemove []egressipv1.EgressIPStatusItem) error {
+func (oc *DefaultNetworkController) deleteEgressIPAssignments(name string, statusesToRemove []egressipv1.EgressIPStatusItem) (err error) {
 	oc.eIPC.podAssignmentMutex.Lock()
 	defer oc.eIPC.podAssignmentMutex.Unlock()
 	var podIPs []net.IP
-	var err error
+	podsToSync := sets.New[string]()
+	defer func() {
+		// the pods still served by the remaining statuses might have been
+		// served by the removed ones
+		eIPSpec := oc.getEgressIPSpec(name)
+		if err == nil && util.GetEgressIPLoadBalancing(eIPSpec) != egressipv1.EgressIPLoadBalancingPerConnection {
+			err = oc.syncEgressIPPodsReroutePolicies(name, eIPSpec, podsToSync)
+		}
+	}()
 	for _, statusToRemove := range statusesToRemove {
 		removed := false
 		for podKey, podStatus := range oc.eIPC.podAssignment {
@@ -667,6 +745,7 @@ func (oc *DefaultNetworkController) deleteEgressIPAssignments(name string, statu
 			if err != nil {
 				return err
 			}
+			podsToSync.Insert(podKey)
 			if len(podStatus.egressStatuses.statusMap) == 0 && len(podStatus.standbyEgressIPNames) == 0 {
 				// pod could be managed by more than one egressIP
 				// so remove the podKey from cache only if we are sure
@@ -769,6 +848,15 @@ func (oc *DefaultNetworkController) deletePodEgressIPAssignments(name string, st
 			return err
 		}
 	}
+	if len(podStatus.egressStatuses.statusMap) > 0 {
+		// the pod might have been served by the removed statuses
+		eIPSpec := oc.getEgressIPSpec(name)
+		if util.GetEgressIPLoadBalancing(eIPSpec) != egressipv1.EgressIPLoadBalancingPerConnection {
+			if err := oc.eIPC.syncPodReroutePolicies(name, eIPSpec, pod, podIPs, podStatus.egressStatuses.items()); err != nil {
+				return err
+			}
+		}
+	}
 	// Delete the key if there are no more status assignments to keep
 	// for the pod.
 	if len(podStatus.egressStatuses.statusMap) == 0 {
@@ -845,6 +933,46 @@ func (oc *DefaultNetworkController) syncEgressIPs(namespaces []interface{}) erro
 	if err = oc.syncStaleAddressSetIPs(egressIPCache); err != nil {
 		return fmt.Errorf("syncEgressIPs unable to reset stale address IPs: %v", err)
 	}
+	if err = oc.syncStaleDefaultRouteToExternal(); err != nil {
+		return fmt.Errorf("syncEgressIPs unable to remove stale default routes to external: %v", err)
+	}
+	return nil
+}
+
+// syncStaleDefaultRouteToExternal removes the "catch-all" route to external that EgressIP used to add on the
+// ovn_cluster_router for each local egress node with interconnect, see libovsdbutil.CreateDefaultRouteToExternal.
+// EgressIP now adds reroute policies for the remote pods instead, so the route is only kept where other features
+// still need it: on all the local nodes when egress services are enabled and on the nodes running live migratable
+// VMs.
+func (oc *DefaultNetworkController) syncStaleDefaultRouteToExternal() error {
+	if !config.OVNKubernetesFeature.EnableInterconnect || oc.zone == types.OvnDefaultZone ||
+		config.OVNKubernetesFeature.EnableEgressService {
+		return nil
+	}
+	vmPods, err := kubevirt.FindLiveMigratablePods(oc.watchFactory)
+	if err != nil {
+		return err
+	}
+	vmNodes := sets.New[string]()
+	for _, vmPod := range vmPods {
+		vmNodes.Insert(vmPod.Spec.NodeName)
+	}
+	nodes, err := oc.watchFactory.GetNodes()
+	if err != nil {
+		return fmt.Errorf("unable to list nodes: %v", err)
+	}
+	for _, node := range nodes {
+		if !oc.isLocalZoneNode(node) || vmNodes.Has(node.Name) {
+			continue
+		}
+		if err := libovsdbutil.DeleteDefaultRouteToExternal(oc.nbClient, node.Name); err != nil {
+			if errors.Is(err, libovsdbclient.ErrNotFound) {
+				// the gateway router of the node is not created yet, so neither is the route
+				continue
+			}
+			return err
+		}
+	}
 	return nil
 }
 
@@ -983,12 +1111,16 @@ func (oc *DefaultNetworkController) syncStaleEgressReroutePolicy(egressIPCache m
 		parsedLogicalIP := net.ParseIP(logicalIP)
 		egressPodIPs := sets.NewString()
 		if exists {
-			// Since LRPs are created only for pods local to this zone
-			// we need to care about only those pods. Nexthop for them will
-			// either be transit switch IP or join switch IP.
+			// LRPs are created for pods local to this zone, with the transit
+			// switch IP or join switch IP as nexthop, and for remote pods served
+			// by egress nodes local to this zone, with the join switch IP or
+			// management port IP of the local egress nodes as nexthop.
 			for _, podIPs := range cacheEntry.egressLocalPods {
 				egressPodIPs.Insert(podIPs.UnsortedList()...)
 			}
+			for _, podIPs := range cacheEntry.egressRemotePods {
+				egressPodIPs.Insert(podIPs.UnsortedList()...)
+			}
 		}
 		if !exists || cacheEntry.gatewayRouterIPs.Len() == 0 || !egressPodIPs.Has(parsedLogicalIP.String()) {
 			klog.Infof("syncStaleEgressReroutePolicy will delete %s due to no nexthop or stale logical ip: %v", egressIPName, item)
@@ -1176,14 +1308,14 @@ func (oc *DefaultNetworkController) generateCacheForEgressIP() (map[string]egres
 				if len(egressIPCache[egressIP.Name].egressLocalNodes) == 0 && !oc.isPodScheduledinLocalZone(pod) {
 					continue // don't process anything on master's that have nothing to do with the pod
 				}
-				// FIXME(trozet): potential race where pod is not yet added in the cache by the pod handler
-				logicalPort, err := oc.logicalPortCache.get(pod, types.DefaultNetworkName)
-				if err != nil {
-					klog.Errorf("Error getting logical port %s, err: %v", util.GetLogicalPortName(pod.Namespace, pod.Name), err)
-					continue
-				}
 				podKey := getPodKey(pod)
 				if oc.isPodScheduledinLocalZone(pod) {
+					// FIXME(trozet): potential race where pod is not yet added in the cache by the pod handler
+					logicalPort, err := oc.logicalPortCache.get(pod, types.DefaultNetworkName)
+					if err != nil {
+						klog.Errorf("Error getting logical port %s, err: %v", util.GetLogicalPortName(pod.Namespace, pod.Name), err)
+						continue
+					}
 					_, ok := egressIPCache[egressIP.Name].egressLocalPods[podKey]
 					if !ok {
 						egressIPCache[egressIP.Name].egressLocalPods[podKey] = sets.New[string]()
@@ -1192,12 +1324,18 @@ func (oc *DefaultNetworkController) generateCacheForEgressIP() (map[string]egres
 						egressIPCache[egressIP.Name].egressLocalPods[podKey].Insert(ipNet.IP.String())
 					}
 				} else if len(egressIPCache[egressIP.Name].egressLocalNodes) > 0 {
-					// it means this controller has at least one egressNode that is in localZone but matched pod is remote
+					// it means this controller has at least one egressNode that is in localZone but matched pod is remote,
+					// remote pods are not in the logical port cache so take their IPs from the pod annotation
+					podIPs, err := util.GetPodCIDRsWithFullMask(pod, oc.NetInfo)
+					if err != nil {
+						klog.Errorf("Error getting IPs of remote pod %s, err: %v", podKey, err)
+						continue
+					}
 					_, ok := egressIPCache[egressIP.Name].egressRemotePods[podKey]
 					if !ok {
 						egressIPCache[egressIP.Name].egressRemotePods[podKey] = sets.New[string]()
 					}
-					for _, ipNet := range logicalPort.ips {
+					for _, ipNet := range podIPs {
 						egressIPCache[egressIP.Name].egressRemotePods[podKey].Insert(ipNet.IP.String())
 					}
 				}
@@ -1268,17 +1406,6 @@ func (oc *DefaultNetworkController) addEgressNode(node *v1.Node) error {
 			return fmt.Errorf("unable to configure GARP on external logical switch port for egress node: %s, "+
 				"this will result in packet drops during egress IP re-assignment,  err: %v", node.Name, err)
 		}
-		if config.OVNKubernetesFeature.EnableInterconnect && oc.zone != types.OvnDefaultZone {
-			// NOTE: EgressIP is not supported on multi-nodes-in-same-zone case
-			// NOTE2: We don't want this route for all-nodes-in-same-zone (almost nonIC a.k.a single zone) case because
-			// it makes no sense - all nodes are connected via the same ovn_cluster_router
-			// NOTE3: When the node gets deleted we do not remove this route intentionally because
-			// on IC if the node is gone, then the ovn_cluster_router is also gone along with all
-			// the routes on it.
-			if err := libovsdbutil.CreateDefaultRouteToExternal(oc.nbClient, node.Name); err != nil {
-				return err
-			}
-		}
 	}
 	return nil
 }
@@ -1380,6 +1507,14 @@ func (e egressStatuses) contains(potentialStatus egressipv1.EgressIPStatusItem)
 	return false
 }
 
+func (e egressStatuses) items() []egressipv1.EgressIPStatusItem {
+	items := make([]egressipv1.EgressIPStatusItem, 0, len(e.statusMap))
+	for status := range e.statusMap {
+		items = append(items, status)
+	}
+	return items
+}
+
 func (e egressStatuses) delete(deleteStatus egressipv1.EgressIPStatusItem) {
 	delete(e.statusMap, deleteStatus)
 }
@@ -1478,7 +1613,8 @@ func (oc *DefaultNetworkController) addStandByEgressIPAssignment(podKey string,
 // (routing pod traffic to the egress node) and NAT objects on the egress node
 // (SNAT-ing to the egress IP).
 // This function should be called with lock on nodeZoneState cache key status.Node and pod.Spec.NodeName
-func (e *egressIPZoneController) addPodEgressIPAssignment(egressIPName string, status egressipv1.EgressIPStatusItem, pod *kapi.Pod, podIPs []*net.IPNet) (err error) {
+func (e *egressIPZoneController) addPodEgressIPAssignment(egressIPName string, status egressipv1.EgressIPStatusItem, pod *kapi.Pod, podIPs []*net.IPNet,
+	loadBalancing egressipv1.EgressIPLoadBalancing) (err error) {
 	if config.Metrics.EnableScaleMetrics {
 		start := time.Now()
 		defer func() {
@@ -1507,6 +1643,10 @@ func (e *egressIPZoneController) addPodEgressIPAssignment(egressIPName string, s
 		return fmt.Errorf("failed to determine next hop for pod %s/%s when configuring egress IP %s"+
 			" IP %s: %v", pod.Namespace, pod.Name, egressIPName, status.EgressIP, err)
 	}
+	// with the other load balancing modes, the nexthops of the reroute
+	// policies are selected once all the statuses are set up for the pod,
+	// see syncPodReroutePolicies
+	perConnection := loadBalancing == egressipv1.EgressIPLoadBalancingPerConnection
 	var ops []ovsdb.Operation
 	if loadedEgressNode && isLocalZoneEgressNode {
 		if isOVNNetwork {
@@ -1515,8 +1655,11 @@ func (e *egressIPZoneController) addPodEgressIPAssignment(egressIPName string, s
 				return fmt.Errorf("unable to create NAT rule ops for status: %v, err: %v", status, err)
 			}
 		}
-		if config.OVNKubernetesFeature.EnableInterconnect && !isOVNNetwork && (loadedPodNode && !isLocalZonePod) {
-			// configure reroute for non-local-zone pods on egress nodes
+		if perConnection && config.OVNKubernetesFeature.EnableInterconnect && (loadedPodNode && !isLocalZonePod) {
+			// configure reroute for non-local-zone pods on egress nodes: traffic from remote pods
+			// reaches the ovn_cluster_router of this zone through the transit switch and, since
+			// the zone may contain more than one node, it has to be steered explicitly towards
+			// the gateway router (or the management port) of the egress node
 			ops, err = e.createReroutePolicyOps(ops, podIPs, status, egressIPName, nextHopIP)
 			if err != nil {
 				return fmt.Errorf("unable to create logical router policy ops %v, err: %v", status, err)
@@ -1527,9 +1670,11 @@ func (e *egressIPZoneController) addPodEgressIPAssignment(egressIPName string, s
 	// exec when node is local OR when pods are local
 	// don't add a reroute policy if the egress node towards which we are adding this doesn't exist
 	if loadedEgressNode && loadedPodNode && isLocalZonePod {
-		ops, err = e.createReroutePolicyOps(ops, podIPs, status, egressIPName, nextHopIP)
-		if err != nil {
-			return fmt.Errorf("unable to create logical router policy ops, err: %v", err)
+		if perConnection {
+			ops, err = e.createReroutePolicyOps(ops, podIPs, status, egressIPName, nextHopIP)
+			if err != nil {
+				return fmt.Errorf("unable to create logical router policy ops, err: %v", err)
+			}
 		}
 		ops, err = e.deleteExternalGWPodSNATOps(ops, pod, podIPs, status, isOVNNetwork)
 		if err != nil {
@@ -1595,7 +1740,7 @@ func (e *egressIPZoneController) deletePodEgressIPAssignment(egressIPName string
 	}
 
 	if loadedEgressNode && isLocalZoneEgressNode {
-		if config.OVNKubernetesFeature.EnableInterconnect && !isOVNNetwork && (!loadedPodNode || !isLocalZonePod) { // node is deleted (we can't determine zone so we always try and nuke OR pod is remote to zone)
+		if config.OVNKubernetesFeature.EnableInterconnect && (!loadedPodNode || !isLocalZonePod) { // node is deleted (we can't determine zone so we always try and nuke OR pod is remote to zone)
 			// delete reroute for non-local-zone pods on egress nodes
 			ops, err = e.deleteReroutePolicyOps(ops, podIPs, status, egressIPName, nextHopIP)
 			if err != nil {
@@ -1830,6 +1975,84 @@ func (e *egressIPZoneController) createReroutePolicyOps(ops []ovsdb.Operation, p
 	return ops, nil
 }
 
+// syncPodReroutePolicies sets the nexthops of the reroute policies of the pod
+// to the ones of the egress IPs selected among the given statuses by the load
+// balancing mode of the EgressIP. As for createReroutePolicyOps, the policies
+// of pods local to the zone reroute to all the selected egress IPs while the
+// policies of remote pods only reroute to the ones assigned to local nodes.
+func (e *egressIPZoneController) syncPodReroutePolicies(egressIPName string, spec *egressipv1.EgressIPSpec, pod *kapi.Pod, podIPNets []*net.IPNet,
+	statuses []egressipv1.EgressIPStatusItem) error {
+	isLocalZonePod, loadedPodNode := e.nodeZoneState.Load(pod.Spec.NodeName)
+	if !loadedPodNode {
+		return nil
+	}
+	var ops []ovsdb.Operation
+	var err error
+	for _, podIPNet := range podIPNets {
+		var nextHops []string
+		for _, status := range util.SelectEgressIPStatusItems(spec, statuses, podIPNet.IP) {
+			isLocalZoneEgressNode, loadedEgressNode := e.nodeZoneState.Load(status.Node)
+			if !loadedEgressNode {
+				continue
+			}
+			if !isLocalZonePod && !(config.OVNKubernetesFeature.EnableInterconnect && isLocalZoneEgressNode) {
+				continue
+			}
+			nextHopIP, err := e.getStatusNextHop(egressIPName, status)
+			if err != nil {
+				return fmt.Errorf("failed to determine next hop for pod %s/%s when configuring egress IP %s"+
+					" IP %s: %v", pod.Namespace, pod.Name, egressIPName, status.EgressIP, err)
+			}
+			nextHops = append(nextHops, nextHopIP)
+		}
+		lrp := nbdb.LogicalRouterPolicy{
+			Match:    fmt.Sprintf("%s.src == %s", ipFamilyName(utilnet.IsIPv6(podIPNet.IP)), podIPNet.IP.String()),
+			Priority: types.EgressIPReroutePriority,
+			Nexthops: nextHops,
+			Action:   nbdb.LogicalRouterPolicyActionReroute,
+			ExternalIDs: map[string]string{
+				"name": egressIPName,
+			},
+		}
+		p := func(item *nbdb.LogicalRouterPolicy) bool {
+			return item.Match == lrp.Match && item.Priority == lrp.Priority && item.ExternalIDs["name"] == lrp.ExternalIDs["name"]
+		}
+		if len(nextHops) == 0 {
+			ops, err = libovsdbops.DeleteLogicalRouterPolicyWithPredicateOps(e.nbClient, ops, types.OVNClusterRouter, p)
+		} else {
+			ops, err = libovsdbops.CreateOrUpdateLogicalRouterPolicyWithPredicateOps(e.nbClient, ops, types.OVNClusterRouter, &lrp, p, &lrp.Nexthops)
+		}
+		if err != nil {
+			return fmt.Errorf("error syncing logical router policy %+v on router %s: %v", lrp, types.OVNClusterRouter, err)
+		}
+	}
+	_, err = libovsdbops.TransactAndCheck(e.nbClient, ops)
+	return err
+}
+
+// getStatusNextHop returns the next hop towards the egress node of the given
+// status
+func (e *egressIPZoneController) getStatusNextHop(egressIPName string, status egressipv1.EgressIPStatusItem) (string, error) {
+	isLocalZoneEgressNode, _ := e.nodeZoneState.Load(status.Node)
+	eNode, err := e.watchFactory.GetNode(status.Node)
+	if err != nil {
+		return "", fmt.Errorf("failed to lookup node %s: %w", status.Node, err)
+	}
+	parsedNodeEIPConfig, err := util.GetNodeEIPConfig(eNode)
+	if err != nil {
+		return "", fmt.Errorf("failed to get node %s egress IP config: %w", eNode.Name, err)
+	}
+	isOVNNetwork := util.IsOVNNetwork(parsedNodeEIPConfig, net.ParseIP(status.EgressIP))
+	nextHopIP, err := e.getNextHop(status.Node, status.EgressIP, egressIPName, isLocalZoneEgressNode, isOVNNetwork)
+	if err != nil {
+		return "", err
+	}
+	if nextHopIP == "" {
+		return "", fmt.Errorf("no next hop found towards node %s", status.Node)
+	}
+	return nextHopIP, nil
+}
+
 // deleteReroutePolicyOps creates an operation that does idempotent updates of the
 // LogicalRouterPolicy corresponding to the egressIP object, according to the
 // following update procedure:
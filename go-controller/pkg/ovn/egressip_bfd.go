@@ -0,0 +1,192 @@
+package ovn
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	libovsdbclient "github.com/ovn-org/libovsdb/client"
+	libovsdb "github.com/ovn-org/libovsdb/ovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+)
+
+// egressIPBFDController configures BFD sessions between the gateway routers of
+// the egress nodes. ovnkube-cluster-manager follows the status of the sessions
+// in the northbound database to move egress IPs away from a failed egress node
+// faster than with its reachability checks.
+type egressIPBFDController struct {
+	// Mutex serializes the configuration of the sessions
+	sync.Mutex
+	nbClient        libovsdbclient.Client
+	watchFactory    *factory.WatchFactory
+	isLocalZoneNode func(node *kapi.Node) bool
+}
+
+func newEgressIPBFDController(nbClient libovsdbclient.Client, watchFactory *factory.WatchFactory,
+	isLocalZoneNode func(node *kapi.Node) bool) *egressIPBFDController {
+	return &egressIPBFDController{
+		nbClient:        nbClient,
+		watchFactory:    watchFactory,
+		isLocalZoneNode: isLocalZoneNode,
+	}
+}
+
+// isEgressIPBFDNode returns true if BFD sessions must be configured towards
+// and, if local to the zone, from the node
+func isEgressIPBFDNode(node *kapi.Node) bool {
+	_, hasEgressLabel := node.Labels[util.GetNodeEgressLabel()]
+	return hasEgressLabel && !util.NoHostSubnet(node)
+}
+
+func getEgressIPBFDKey(logicalPort, dstIP string) string {
+	return logicalPort + "/" + dstIP
+}
+
+func isEgressIPBFD(bfd *nbdb.BFD) bool {
+	_, ok := bfd.ExternalIDs[types.EgressIPBFDNodeExternalID]
+	return ok
+}
+
+func isEgressIPBFDRoute(lrsr *nbdb.LogicalRouterStaticRoute) bool {
+	_, ok := lrsr.ExternalIDs[types.EgressIPBFDNodeExternalID]
+	return ok
+}
+
+// syncSessions configures a BFD session from the gateway router of each egress
+// node local to the zone towards the gateway router of every other egress
+// node on the same subnet, for each IP family they share, and removes the
+// stale ones. Each session is referenced by a host route towards the peer
+// gateway router IP, as OVN only runs the BFD sessions in use.
+func (c *egressIPBFDController) syncSessions() error {
+	c.Lock()
+	defer c.Unlock()
+	nodes, err := c.watchFactory.GetNodes()
+	if err != nil {
+		return fmt.Errorf("unable to list nodes: %w", err)
+	}
+	gatewayIPs := map[string][]*net.IPNet{}
+	var localNodes []string
+	for _, node := range nodes {
+		if !isEgressIPBFDNode(node) {
+			continue
+		}
+		l3GatewayConfig, err := util.ParseNodeL3GatewayAnnotation(node)
+		if err != nil || l3GatewayConfig.Mode == config.GatewayModeDisabled {
+			klog.V(5).Infof("Egress node %s has no gateway router yet, skipping its BFD sessions", node.Name)
+			continue
+		}
+		gatewayIPs[node.Name] = l3GatewayConfig.IPAddresses
+		if c.isLocalZoneNode(node) {
+			localNodes = append(localNodes, node.Name)
+		}
+	}
+
+	var ops []libovsdb.Operation
+	desired := sets.New[string]()
+	interval := config.OVNKubernetesFeature.EgressIPBFDInterval
+	multiplier := config.OVNKubernetesFeature.EgressIPBFDMultiplier
+	for _, nodeName := range localNodes {
+		routerName := types.GWRouterPrefix + nodeName
+		logicalPort := types.GWRouterToExtSwitchPrefix + routerName
+		for peerName, peerIPs := range gatewayIPs {
+			if peerName == nodeName {
+				continue
+			}
+			for _, gatewayIP := range gatewayIPs[nodeName] {
+				peerIP, err := util.MatchFirstIPNetFamily(utilnet.IsIPv6CIDR(gatewayIP), peerIPs)
+				if err != nil {
+					continue
+				}
+				// the session is routed straight out of the gateway router
+				// port, so the peer must be on the same L2 segment
+				if !gatewayIP.Contains(peerIP.IP) {
+					klog.Warningf("Egress node %s gateway router IP %s is not on-link with the one of egress node %s, %s: "+
+						"skipping their BFD session", peerName, peerIP.IP, nodeName, gatewayIP)
+					continue
+				}
+				externalIDs := map[string]string{
+					types.EgressIPBFDNodeExternalID: nodeName,
+					types.EgressIPBFDPeerExternalID: peerName,
+				}
+				bfd := &nbdb.BFD{
+					LogicalPort: logicalPort,
+					DstIP:       peerIP.IP.String(),
+					MinTx:       &interval,
+					MinRx:       &interval,
+					DetectMult:  &multiplier,
+					ExternalIDs: externalIDs,
+				}
+				ops, err = libovsdbops.CreateOrUpdateBFDOps(c.nbClient, ops, bfd)
+				if err != nil {
+					return fmt.Errorf("failed to create or update BFD session from %s to %s: %w", nodeName, peerName, err)
+				}
+				lrsr := &nbdb.LogicalRouterStaticRoute{
+					IPPrefix:    peerIP.IP.String() + util.GetIPFullMaskString(peerIP.IP.String()),
+					Nexthop:     peerIP.IP.String(),
+					OutputPort:  &logicalPort,
+					BFD:         &bfd.UUID,
+					ExternalIDs: externalIDs,
+				}
+				p := func(item *nbdb.LogicalRouterStaticRoute) bool {
+					return isEgressIPBFDRoute(item) && item.Nexthop == lrsr.Nexthop &&
+						item.OutputPort != nil && *item.OutputPort == logicalPort
+				}
+				ops, err = libovsdbops.CreateOrUpdateLogicalRouterStaticRoutesWithPredicateOps(c.nbClient, ops,
+					routerName, lrsr, p)
+				if err != nil {
+					return fmt.Errorf("failed to create or update BFD route from %s to %s: %w", nodeName, peerName, err)
+				}
+				desired.Insert(getEgressIPBFDKey(logicalPort, bfd.DstIP))
+			}
+		}
+	}
+
+	staleRouteRouters := sets.New[string]()
+	routes, err := libovsdbops.FindLogicalRouterStaticRoutesWithPredicate(c.nbClient, isEgressIPBFDRoute)
+	if err != nil {
+		return fmt.Errorf("failed to find the egress IP BFD routes: %w", err)
+	}
+	for _, route := range routes {
+		if route.OutputPort == nil || !desired.Has(getEgressIPBFDKey(*route.OutputPort, route.Nexthop)) {
+			staleRouteRouters.Insert(types.GWRouterPrefix + route.ExternalIDs[types.EgressIPBFDNodeExternalID])
+		}
+	}
+	for _, routerName := range sets.List(staleRouteRouters) {
+		ops, err = libovsdbops.DeleteLogicalRouterStaticRoutesWithPredicateOps(c.nbClient, ops, routerName,
+			func(item *nbdb.LogicalRouterStaticRoute) bool {
+				return isEgressIPBFDRoute(item) &&
+					(item.OutputPort == nil || !desired.Has(getEgressIPBFDKey(*item.OutputPort, item.Nexthop)))
+			})
+		if err != nil {
+			return fmt.Errorf("failed to delete the stale egress IP BFD routes of %s: %w", routerName, err)
+		}
+	}
+	bfds, err := libovsdbops.FindBFDsWithPredicate(c.nbClient, isEgressIPBFD)
+	if err != nil {
+		return fmt.Errorf("failed to find the egress IP BFD sessions: %w", err)
+	}
+	var staleBFDs []*nbdb.BFD
+	for _, bfd := range bfds {
+		if !desired.Has(getEgressIPBFDKey(bfd.LogicalPort, bfd.DstIP)) {
+			staleBFDs = append(staleBFDs, bfd)
+		}
+	}
+	ops, err = libovsdbops.DeleteBFDsOps(c.nbClient, ops, staleBFDs...)
+	if err != nil {
+		return fmt.Errorf("failed to delete the stale egress IP BFD sessions: %w", err)
+	}
+	if _, err = libovsdbops.TransactAndCheck(c.nbClient, ops); err != nil {
+		return fmt.Errorf("failed to configure the egress IP BFD sessions: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,178 @@
+package ovn
+
+import (
+	"context"
+
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/urfave/cli/v2"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = ginkgo.Describe("OVN master EgressIP BFD Operations", func() {
+	var (
+		app     *cli.App
+		fakeOvn *FakeOVN
+	)
+	const (
+		node1Name = "node1"
+		node2Name = "node2"
+		node1IP   = "192.168.126.12"
+		node2IP   = "192.168.126.51"
+		node3Name = "node3"
+		node3IP   = "192.168.127.8"
+	)
+
+	ginkgo.BeforeEach(func() {
+		// Restore global default values before each testcase
+		config.PrepareTestConfig()
+		config.OVNKubernetesFeature.EnableEgressIP = true
+		config.OVNKubernetesFeature.EnableEgressIPBFD = true
+
+		app = cli.NewApp()
+		app.Name = "test"
+		app.Flags = config.Flags
+
+		fakeOvn = NewFakeOVN(true)
+	})
+
+	ginkgo.AfterEach(func() {
+		fakeOvn.shutdown()
+	})
+
+	newEgressNode := func(name, ip string) v1.Node {
+		return getNodeObj(name, map[string]string{
+			"k8s.ovn.org/l3-gateway-config": `{"default":{"mode":"local","mac-address":"7e:57:f8:f0:3c:49", "ip-address":"` +
+				ip + `/24", "next-hop":"192.168.126.1"}}`,
+			"k8s.ovn.org/node-chassis-id": "79fdcfc4-6fe6-4cd3-8242-c0f85a4668ec-" + name,
+		}, map[string]string{
+			"k8s.ovn.org/egress-assignable": "",
+		})
+	}
+
+	gatewayRouter := func(nodeName string, staticRoutes ...string) *nbdb.LogicalRouter {
+		return &nbdb.LogicalRouter{
+			Name:         types.GWRouterPrefix + nodeName,
+			UUID:         types.GWRouterPrefix + nodeName + "-UUID",
+			StaticRoutes: staticRoutes,
+		}
+	}
+
+	// sessionData returns the BFD session and its route from the gateway
+	// router of nodeName towards the one of peerName
+	sessionData := func(nodeName, peerName, peerIP string) []libovsdbtest.TestData {
+		logicalPort := types.GWRouterToExtSwitchPrefix + types.GWRouterPrefix + nodeName
+		interval := config.OVNKubernetesFeature.EgressIPBFDInterval
+		multiplier := config.OVNKubernetesFeature.EgressIPBFDMultiplier
+		externalIDs := map[string]string{
+			types.EgressIPBFDNodeExternalID: nodeName,
+			types.EgressIPBFDPeerExternalID: peerName,
+		}
+		bfdUUID := "bfd-" + nodeName + "-UUID"
+		return []libovsdbtest.TestData{
+			&nbdb.BFD{
+				UUID:        bfdUUID,
+				LogicalPort: logicalPort,
+				DstIP:       peerIP,
+				MinTx:       &interval,
+				MinRx:       &interval,
+				DetectMult:  &multiplier,
+				ExternalIDs: externalIDs,
+			},
+			&nbdb.LogicalRouterStaticRoute{
+				UUID:        "route-" + nodeName + "-UUID",
+				IPPrefix:    peerIP + "/32",
+				Nexthop:     peerIP,
+				OutputPort:  &logicalPort,
+				BFD:         &bfdUUID,
+				ExternalIDs: externalIDs,
+			},
+		}
+	}
+
+	ginkgo.It("should configure BFD sessions between egress nodes", func() {
+		app.Action = func(ctx *cli.Context) error {
+			node1 := newEgressNode(node1Name, node1IP)
+			node2 := newEgressNode(node2Name, node2IP)
+			fakeOvn.startWithDBSetup(
+				libovsdbtest.TestSetup{
+					NBData: []libovsdbtest.TestData{
+						gatewayRouter(node1Name),
+						gatewayRouter(node2Name),
+					},
+				},
+				&v1.NodeList{
+					Items: []v1.Node{node1, node2},
+				},
+			)
+			bfdController := newEgressIPBFDController(fakeOvn.controller.nbClient, fakeOvn.controller.watchFactory,
+				fakeOvn.controller.isLocalZoneNode)
+
+			gomega.Expect(bfdController.syncSessions()).To(gomega.Succeed())
+			expectedDatabaseState := append(sessionData(node1Name, node2Name, node2IP), sessionData(node2Name, node1Name, node1IP)...)
+			expectedDatabaseState = append(expectedDatabaseState,
+				gatewayRouter(node1Name, "route-"+node1Name+"-UUID"),
+				gatewayRouter(node2Name, "route-"+node2Name+"-UUID"),
+			)
+			gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
+
+			// node2 is no longer an egress node
+			node2.Labels = map[string]string{}
+			_, err := fakeOvn.fakeClient.KubeClient.CoreV1().Nodes().Update(context.TODO(), &node2, metav1.UpdateOptions{})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Eventually(func() bool {
+				node, err := fakeOvn.controller.watchFactory.GetNode(node2Name)
+				return err == nil && !isEgressIPBFDNode(node)
+			}).Should(gomega.BeTrue())
+			gomega.Expect(bfdController.syncSessions()).To(gomega.Succeed())
+			gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData([]libovsdbtest.TestData{
+				gatewayRouter(node1Name),
+				gatewayRouter(node2Name),
+			}))
+			return nil
+		}
+
+		err := app.Run([]string{app.Name})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	ginkgo.It("should not configure BFD sessions between egress nodes on different subnets", func() {
+		app.Action = func(ctx *cli.Context) error {
+			node1 := newEgressNode(node1Name, node1IP)
+			node2 := newEgressNode(node2Name, node2IP)
+			node3 := newEgressNode(node3Name, node3IP)
+			fakeOvn.startWithDBSetup(
+				libovsdbtest.TestSetup{
+					NBData: []libovsdbtest.TestData{
+						gatewayRouter(node1Name),
+						gatewayRouter(node2Name),
+						gatewayRouter(node3Name),
+					},
+				},
+				&v1.NodeList{
+					Items: []v1.Node{node1, node2, node3},
+				},
+			)
+			bfdController := newEgressIPBFDController(fakeOvn.controller.nbClient, fakeOvn.controller.watchFactory,
+				fakeOvn.controller.isLocalZoneNode)
+
+			gomega.Expect(bfdController.syncSessions()).To(gomega.Succeed())
+			expectedDatabaseState := append(sessionData(node1Name, node2Name, node2IP), sessionData(node2Name, node1Name, node1IP)...)
+			expectedDatabaseState = append(expectedDatabaseState,
+				gatewayRouter(node1Name, "route-"+node1Name+"-UUID"),
+				gatewayRouter(node2Name, "route-"+node2Name+"-UUID"),
+				gatewayRouter(node3Name),
+			)
+			gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
+			return nil
+		}
+
+		err := app.Run([]string{app.Name})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+})
@@ -875,6 +875,222 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 			ginkgotable.Entry("interconnect enabled", true),
 		)
 
+		ginkgotable.DescribeTable("[OVN network] should perform proper OVN transactions when the zone contains multiple nodes",
+			func(egressNodeName string, isEgressNodeLocal bool) {
+				app.Action = func(ctx *cli.Context) error {
+					config.OVNKubernetesFeature.EnableInterconnect = true
+					egressIP := "192.168.126.101"
+					egressIPNet := "192.168.126.0/24"
+					localZone := "zone-a"
+					node1IPv4Addresses := []string{"192.168.126.202/24"}
+					node2IPv4Addresses := []string{"192.168.126.51/24"}
+					node3IPv4Addresses := []string{"192.168.126.52/24"}
+
+					// node1 and node2 share the local zone while node3 is in a remote zone
+					nodes := getIPv4Nodes([]nodeInfo{{node1IPv4Addresses, localZone, "100.88.0.2/16"},
+						{node2IPv4Addresses, localZone, "100.88.0.3/16"}, {node3IPv4Addresses, "zone-b", "100.88.0.4/16"}})
+					node1, node2, node3 := nodes[0], nodes[1], nodes[2]
+					egressNamespace := newNamespace(eipNamespace)
+					// egressPod1 runs on a local node which is never the egress node, egressPod3 runs on the remote node
+					egressPod1 := *newPodWithLabels(eipNamespace, podName, node1Name, podV4IP, egressPodLabel)
+					egressPod3 := *newPodWithLabels(eipNamespace, podName2, node3Name, podV4IP3, egressPodLabel)
+
+					eIP := egressipv1.EgressIP{
+						ObjectMeta: newEgressIPMeta(egressIPName),
+						Spec: egressipv1.EgressIPSpec{
+							EgressIPs: []string{egressIP},
+							PodSelector: metav1.LabelSelector{
+								MatchLabels: egressPodLabel,
+							},
+							NamespaceSelector: metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									"name": egressNamespace.Name,
+								},
+							},
+						},
+					}
+
+					// the catch-all route to external previously added for the egress nodes is stale
+					// while the route of the node subnet with the same nexthop is kept
+					staleRoute := getReRouteStaticRoute("10.128.0.0/14", nodeLogicalRouterIPv4[0])
+					nodeSubnetRoute := getReRouteStaticRoute("10.128.0.0/24", nodeLogicalRouterIPv4[0])
+					nodeSubnetRoute.UUID = "node-subnet-route-UUID"
+					initialDB := []libovsdbtest.TestData{
+						&nbdb.LogicalRouter{
+							Name:         types.OVNClusterRouter,
+							UUID:         types.OVNClusterRouter + "-UUID",
+							StaticRoutes: []string{staleRoute.UUID, nodeSubnetRoute.UUID},
+						},
+						staleRoute,
+						nodeSubnetRoute,
+					}
+					for _, nodeName := range []string{node1Name, node2Name} {
+						initialDB = append(initialDB,
+							&nbdb.LogicalRouter{
+								Name:  types.GWRouterPrefix + nodeName,
+								UUID:  types.GWRouterPrefix + nodeName + "-UUID",
+								Ports: []string{types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + nodeName + "-UUID"},
+							},
+							&nbdb.LogicalSwitchPort{
+								UUID: types.EXTSwitchToGWRouterPrefix + types.GWRouterPrefix + nodeName + "-UUID",
+								Name: types.EXTSwitchToGWRouterPrefix + types.GWRouterPrefix + nodeName,
+								Type: "router",
+								Options: map[string]string{
+									"router-port": types.GWRouterToExtSwitchPrefix + "GR_" + nodeName,
+								},
+							},
+							&nbdb.LogicalSwitch{
+								UUID:  types.ExternalSwitchPrefix + nodeName + "-UUID",
+								Name:  types.ExternalSwitchPrefix + nodeName,
+								Ports: []string{types.EXTSwitchToGWRouterPrefix + types.GWRouterPrefix + nodeName + "-UUID"},
+							},
+						)
+					}
+					initialDB = append(initialDB,
+						&nbdb.LogicalRouterPort{
+							UUID:     types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + node1Name + "-UUID",
+							Name:     types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + node1Name,
+							Networks: []string{nodeLogicalRouterIfAddrV4},
+						},
+						&nbdb.LogicalRouterPort{
+							UUID:     types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + node2Name + "-UUID",
+							Name:     types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + node2Name,
+							Networks: []string{node2LogicalRouterIfAddrV4},
+						},
+					)
+
+					fakeOvn.startWithDBSetup(
+						libovsdbtest.TestSetup{NBData: initialDB},
+						&egressipv1.EgressIPList{
+							Items: []egressipv1.EgressIP{eIP},
+						},
+						&v1.NodeList{
+							Items: []v1.Node{node1, node2, node3},
+						},
+						&v1.NamespaceList{
+							Items: []v1.Namespace{*egressNamespace},
+						},
+						&v1.PodList{
+							Items: []v1.Pod{egressPod1, egressPod3},
+						})
+					fakeOvn.controller.zone = localZone
+					fakeOvn.controller.localZoneNodes.Delete(node3Name)
+
+					// only the local pod is present in the logical port cache
+					i, n, _ := net.ParseCIDR(podV4IP + "/23")
+					n.IP = i
+					fakeOvn.controller.logicalPortCache.add(&egressPod1, "", types.DefaultNetworkName, "", nil, []*net.IPNet{n})
+
+					err := fakeOvn.controller.WatchEgressIPNamespaces()
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					err = fakeOvn.controller.WatchEgressIPPods()
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					err = fakeOvn.controller.WatchEgressNodes()
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					err = fakeOvn.controller.WatchEgressIP()
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+					fakeOvn.patchEgressIPObj(egressNodeName, egressIPName, egressIP, egressIPNet)
+					gomega.Eventually(getEgressIPStatusLen(egressIPName)).Should(gomega.Equal(1))
+					gomega.Eventually(nodeSwitch).Should(gomega.Equal(egressNodeName))
+
+					expectedDatabaseState := []libovsdbtest.TestData{
+						&nbdb.LogicalRouterPolicy{
+							Priority: types.DefaultNoRereoutePriority,
+							Match:    "ip4.src == 10.128.0.0/14 && ip4.dst == 10.128.0.0/14",
+							Action:   nbdb.LogicalRouterPolicyActionAllow,
+							UUID:     "default-no-reroute-UUID",
+						},
+						&nbdb.LogicalRouterPolicy{
+							Priority: types.DefaultNoRereoutePriority,
+							Match:    fmt.Sprintf("ip4.src == 10.128.0.0/14 && ip4.dst == %s", config.Gateway.V4JoinSubnet),
+							Action:   nbdb.LogicalRouterPolicyActionAllow,
+							UUID:     "no-reroute-service-UUID",
+						},
+						&nbdb.LogicalRouterPolicy{
+							Priority: types.DefaultNoRereoutePriority,
+							Match:    "(ip4.src == $a4548040316634674295 || ip4.src == $a13607449821398607916) && ip4.dst == $a14918748166599097711",
+							Action:   nbdb.LogicalRouterPolicyActionAllow,
+							Options:  map[string]string{"pkt_mark": "1008"},
+							UUID:     "no-reroute-node-UUID",
+						},
+						&nbdb.LogicalRouterPort{
+							UUID:     types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + node1Name + "-UUID",
+							Name:     types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + node1Name,
+							Networks: []string{nodeLogicalRouterIfAddrV4},
+						},
+						&nbdb.LogicalRouterPort{
+							UUID:     types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + node2Name + "-UUID",
+							Name:     types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + node2Name,
+							Networks: []string{node2LogicalRouterIfAddrV4},
+						},
+					}
+					policies := []string{"default-no-reroute-UUID", "no-reroute-service-UUID", "no-reroute-node-UUID"}
+					var egressNodeNats []string
+					if isEgressNodeLocal {
+						// the local egress node SNATs both the local and the remote pod, the reroute
+						// policy for the remote pod catches the traffic coming in from the transit switch
+						expectedNatLogicalPort := "k8s-" + egressNodeName
+						pod1SNAT := getEIPSNAT(podV4IP, egressIP, expectedNatLogicalPort)
+						pod3SNAT := getEIPSNAT(podV4IP3, egressIP, expectedNatLogicalPort)
+						pod3SNAT.UUID = "egressip-nat2-UUID"
+						egressNodeNats = []string{pod1SNAT.UUID, pod3SNAT.UUID}
+						expectedDatabaseState = append(expectedDatabaseState,
+							getReRoutePolicy(podV4IP, "4", "reroute-UUID", node2LogicalRouterIPv4, eipExternalID),
+							getReRoutePolicy(podV4IP3, "4", "reroute2-UUID", node2LogicalRouterIPv4, eipExternalID),
+							pod1SNAT, pod3SNAT)
+						policies = append(policies, "reroute-UUID", "reroute2-UUID")
+					} else {
+						// the remote egress node is reached through its transit switch port
+						expectedDatabaseState = append(expectedDatabaseState,
+							getReRoutePolicy(podV4IP, "4", "reroute-UUID", []string{"100.88.0.4"}, eipExternalID))
+						policies = append(policies, "reroute-UUID")
+					}
+					expectedDatabaseState = append(expectedDatabaseState, &nbdb.LogicalRouter{
+						Name:         types.OVNClusterRouter,
+						UUID:         types.OVNClusterRouter + "-UUID",
+						Policies:     policies,
+						StaticRoutes: []string{nodeSubnetRoute.UUID},
+					}, nodeSubnetRoute)
+					// GARP is configured for every node of the local zone
+					for _, nodeName := range []string{node1Name, node2Name} {
+						gr := &nbdb.LogicalRouter{
+							Name:  types.GWRouterPrefix + nodeName,
+							UUID:  types.GWRouterPrefix + nodeName + "-UUID",
+							Ports: []string{types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + nodeName + "-UUID"},
+						}
+						if nodeName == egressNodeName {
+							gr.Nat = egressNodeNats
+						}
+						expectedDatabaseState = append(expectedDatabaseState, gr,
+							&nbdb.LogicalSwitchPort{
+								UUID: types.EXTSwitchToGWRouterPrefix + types.GWRouterPrefix + nodeName + "-UUID",
+								Name: types.EXTSwitchToGWRouterPrefix + types.GWRouterPrefix + nodeName,
+								Type: "router",
+								Options: map[string]string{
+									"router-port":               types.GWRouterToExtSwitchPrefix + "GR_" + nodeName,
+									"nat-addresses":             "router",
+									"exclude-lb-vips-from-garp": "true",
+								},
+							},
+							&nbdb.LogicalSwitch{
+								UUID:  types.ExternalSwitchPrefix + nodeName + "-UUID",
+								Name:  types.ExternalSwitchPrefix + nodeName,
+								Ports: []string{types.EXTSwitchToGWRouterPrefix + types.GWRouterPrefix + nodeName + "-UUID"},
+							})
+					}
+
+					gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
+					return nil
+				}
+
+				err := app.Run([]string{app.Name})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			},
+			ginkgotable.Entry("egress node is local and is not the pod's node", node2Name, true),
+			ginkgotable.Entry("egress node is in a remote zone", node3Name, false),
+		)
+
 		ginkgotable.DescribeTable("[OVN network] using EgressNode retry should perform proper OVN transactions when pod is created after node egress label switch",
 			func(interconnect bool) {
 				config.OVNKubernetesFeature.EnableInterconnect = interconnect
@@ -2195,6 +2411,7 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 					if interconnect && node1Zone == "global" && node2Zone == "remote" {
 						lrps = append(lrps, getReRoutePolicy(egressPod1Node1.Status.PodIP, "4", "reroute-UUID", egressPod1Node1Reroute, eipExternalID),
 							getReRoutePolicy(egressPod2Node1.Status.PodIP, "4", "reroute-UUID2", egressPod2Node1Reroute, eip2ExternalID),
+							getReRoutePolicy(podV4IP3, "4", "egressip-pod3node2", egressPod3Node2Reroute, eipExternalID),
 							getReRoutePolicy(podV4IP4, "4", "egressip-pod4node2", egressPod4Node2Reroute, eip2ExternalID))
 					}
 
@@ -3337,10 +3554,8 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 						// add policy with nextHop towards egressNode's transit switchIP
 						expectedDatabaseState = append(expectedDatabaseState, getReRoutePolicy(egressPod.Status.PodIP, "6", "reroute-UUID", []string{"fd97::3"}, eipExternalID))
 					}
-					if !isnode1Local {
-						expectedDatabaseState[2].(*nbdb.LogicalRouter).Policies = []string{}
-						expectedDatabaseState = expectedDatabaseState[1:]
-					}
+					// NOTE: when the pod is remote, the egress node's zone still reroutes
+					// the pod's traffic towards the egress node's gateway router
 					gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
 
 					egressIPs, nodes := getEgressIPStatus(eIP.Name)
@@ -3585,10 +3800,6 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 							Ports: []string{"k8s-" + node2.Name + "-UUID"},
 						},
 					}
-					if podZone == "remote" {
-						expectedDatabaseState[2].(*nbdb.LogicalRouter).Policies = []string{}
-						expectedDatabaseState = expectedDatabaseState[1:]
-					}
 
 					gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
 
@@ -3637,7 +3848,7 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 			},
 			ginkgotable.Entry("interconnect disabled; non-ic - single zone setup", false, "global"),
 			ginkgotable.Entry("interconnect enabled; pod is in global zone", true, "global"),
-			ginkgotable.Entry("interconnect enabled; pod is in remote zone", true, "remote"), // static re-route is visible but reroute policy won't be
+			ginkgotable.Entry("interconnect enabled; pod is in remote zone", true, "remote"), // reroute policy towards the local egress node is visible
 		)
 
 		ginkgo.It("should not treat pod update if pod already had assigned IP when it got the ADD", func() {
@@ -4160,10 +4371,6 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 							Ports: []string{"k8s-" + node2Name + "-UUID"},
 						},
 					}
-					if podZone == "remote" {
-						expectedDatabaseState[2].(*nbdb.LogicalRouter).Policies = []string{}
-						expectedDatabaseState = expectedDatabaseState[1:]
-					}
 					gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
 					return nil
 				}
@@ -4173,7 +4380,7 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 			},
 			ginkgotable.Entry("interconnect disabled; non-ic - single zone setup", false, "global"),
 			ginkgotable.Entry("interconnect enabled; pod is in global zone", true, "global"),
-			ginkgotable.Entry("interconnect enabled; pod is in remote zone", true, "remote"), // static re-route is visible but reroute policy won't be
+			ginkgotable.Entry("interconnect enabled; pod is in remote zone", true, "remote"), // reroute policy towards the local egress node is visible
 		)
 
 		ginkgo.It("should not treat pod DELETE if pod did not have an assigned IP when it got the ADD and we receive a DELETE before the IP UPDATE", func() {
@@ -4396,11 +4603,6 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 							Ports: []string{"k8s-" + node2Name + "-UUID"},
 						},
 					}
-					if podZone == "remote" {
-						// egressNode is in different zone than pod and egressNode is in local zone, so static reroute will be visible
-						expectedDatabaseState[2].(*nbdb.LogicalRouter).Policies = []string{}
-						expectedDatabaseState = expectedDatabaseState[1:]
-					}
 
 					gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
 
@@ -4640,10 +4842,6 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 							Ports: []string{"k8s-" + node2Name + "-UUID"},
 						},
 					}
-					if podZone == "remote" {
-						expectedDatabaseState[2].(*nbdb.LogicalRouter).Policies = []string{}
-						expectedDatabaseState = expectedDatabaseState[1:]
-					}
 					gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
 
 					egressIPs, nodes := getEgressIPStatus(eIP.Name)
@@ -4873,11 +5071,6 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 							Ports: []string{"k8s-" + node2Name + "-UUID"},
 						},
 					}
-					if podZone == "remote" {
-						// pod is in remote zone, its LRP won't be visible
-						expectedDatabaseState[1].(*nbdb.LogicalRouter).Policies = []string{}
-						expectedDatabaseState = expectedDatabaseState[1:]
-					}
 					gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
 
 					egressIPs, nodes := getEgressIPStatus(eIP.Name)
@@ -5329,28 +5522,20 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 						expectedDatabaseState[3].(*nbdb.LogicalRouter).Nat = []string{"egressip-nat-1-UUID"}
 						expectedDatabaseState = append(expectedDatabaseState, natEIP1)
 					}
-					if node1Zone == "local" {
-						expectedDatabaseState = append(expectedDatabaseState, getReRouteStaticRoute(v4ClusterSubnet, nodeLogicalRouterIPv4[0]))
-						expectedDatabaseState[5].(*nbdb.LogicalRouter).StaticRoutes = []string{"reroute-static-route-UUID"}
-					}
 					if !interconnect || node2Zone != "remote" {
 						expectedDatabaseState[9].(*nbdb.LogicalSwitchPort).Options["nat-addresses"] = "router"
 						expectedDatabaseState[9].(*nbdb.LogicalSwitchPort).Options["exclude-lb-vips-from-garp"] = "true"
 						expectedDatabaseState[4].(*nbdb.LogicalRouter).Nat = []string{"egressip-nat-2-UUID"}
 						expectedDatabaseState = append(expectedDatabaseState, natEIP2)
 					}
-					if node2Zone == "local" {
-						expectedDatabaseState = append(expectedDatabaseState, getReRouteStaticRoute(v4ClusterSubnet, node2LogicalRouterIPv4[0]))
-						expectedDatabaseState[5].(*nbdb.LogicalRouter).StaticRoutes = []string{"reroute-static-route-UUID"}
-					}
 					if node2Zone != node1Zone && node2Zone == "remote" {
 						// the policy reroute will have its second nexthop as transit switchIP
 						// so the one with join switchIP is where podNode == egressNode and one with transitIP is where podNode != egressNode
 						expectedDatabaseState[0].(*nbdb.LogicalRouterPolicy).Nexthops = []string{"100.64.0.2", "100.88.0.3"}
 					}
 					if node2Zone != node1Zone && node1Zone == "remote" {
-						expectedDatabaseState[5].(*nbdb.LogicalRouter).Policies = []string{"default-no-reroute-UUID", "no-reroute-service-UUID", "default-no-reroute-node-UUID"}
-						expectedDatabaseState = expectedDatabaseState[1:] // policy is not visible since podNode is remote
+						// podNode is remote, the policy reroutes the pod only towards the local egressNode
+						expectedDatabaseState[0].(*nbdb.LogicalRouterPolicy).Nexthops = []string{"100.64.0.3"}
 					}
 					gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
 
@@ -5488,10 +5673,6 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 						natEIP1.ExternalIP = assignedEgressIP1
 						expectedDatabaseState = append(expectedDatabaseState, natEIP1)
 					}
-					if node1Zone == "local" {
-						expectedDatabaseState = append(expectedDatabaseState, getReRouteStaticRoute(v4ClusterSubnet, nodeLogicalRouterIPv4[0]))
-						expectedDatabaseState[5].(*nbdb.LogicalRouter).StaticRoutes = []string{"reroute-static-route-UUID"}
-					}
 					if !interconnect || node2Zone != "remote" {
 						expectedDatabaseState[9].(*nbdb.LogicalSwitchPort).Options["nat-addresses"] = "router"
 						expectedDatabaseState[9].(*nbdb.LogicalSwitchPort).Options["exclude-lb-vips-from-garp"] = "true"
@@ -5499,18 +5680,14 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 						natEIP2.ExternalIP = assignedEgressIP2
 						expectedDatabaseState = append(expectedDatabaseState, natEIP2)
 					}
-					if node2Zone == "local" {
-						expectedDatabaseState = append(expectedDatabaseState, getReRouteStaticRoute(v4ClusterSubnet, node2LogicalRouterIPv4[0]))
-						expectedDatabaseState[5].(*nbdb.LogicalRouter).StaticRoutes = []string{"reroute-static-route-UUID"}
-					}
 					if node2Zone != node1Zone && node2Zone == "remote" {
 						// the policy reroute will have its second nexthop as transit switchIP
 						// so the one with join switchIP is where podNode == egressNode and one with transitIP is where podNode != egressNode
 						expectedDatabaseState[0].(*nbdb.LogicalRouterPolicy).Nexthops = []string{"100.64.0.2", "100.88.0.3"}
 					}
 					if node2Zone != node1Zone && node1Zone == "remote" {
-						expectedDatabaseState[5].(*nbdb.LogicalRouter).Policies = []string{"default-no-reroute-UUID", "no-reroute-service-UUID", "default-no-reroute-node-UUID"}
-						expectedDatabaseState = expectedDatabaseState[1:] // policy is not visible since podNode is remote
+						// podNode is remote, the policy reroutes the pod only towards the local egressNode
+						expectedDatabaseState[0].(*nbdb.LogicalRouterPolicy).Nexthops = []string{"100.64.0.3"}
 					}
 					gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
 					return nil
@@ -5524,8 +5701,8 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 			// will showcase localzone setup - master is in pod's zone where pod's reroute policy towards egressNode will be done.
 			// NOTE: SNAT won't be visible because its in remote zone
 			ginkgotable.Entry("interconnect enabled; node1 in local and node2 in remote zones", true, "local", "remote"),
-			// will showcase localzone setup - master is in egress node's zone where pod's SNAT policy and static route will be done.
-			// NOTE: reroute policy won't be visible because its in remote zone (pod is in remote zone)
+			// will showcase localzone setup - master is in egress node's zone where pod's SNAT and reroute policy towards
+			// the local egressNode will be done.
 			ginkgotable.Entry("interconnect enabled; node1 in remote and node2 in local zones", true, "remote", "local"),
 		)
 
@@ -7280,6 +7457,12 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 						node2GR.Nat = []string{"egressip-nat-UUID2"}
 						finalDatabaseStatewithPod = append(finalDatabaseStatewithPod, podEIPSNAT2)
 					}
+					if node1Zone == "remote" {
+						// podNode is remote, the policy reroutes the pod only towards the local egressNode
+						podReRoutePolicy.Nexthops = node2LogicalRouterIPv4
+						finalDatabaseStatewithPod[2].(*nbdb.LogicalRouter).Policies = append(finalDatabaseStatewithPod[2].(*nbdb.LogicalRouter).Policies, podReRoutePolicy.UUID)
+						finalDatabaseStatewithPod = append(finalDatabaseStatewithPod, podReRoutePolicy)
+					}
 
 					gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(finalDatabaseStatewithPod))
 
@@ -10752,6 +10935,245 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		})
 
+		ginkgo.It("should only reroute towards the active egress node with ActiveStandby load balancing", func() {
+			app.Action = func(ctx *cli.Context) error {
+				egressIP1 := "192.168.126.101"
+				egressIP2 := "192.168.126.102"
+				node1IPv4 := "192.168.126.12"
+				node1IPv4CIDR := node1IPv4 + "/24"
+				node2IPv4 := "192.168.126.51"
+				node2IPv4CIDR := node2IPv4 + "/24"
+
+				egressPod1 := *newPodWithLabels(eipNamespace, podName, node1Name, podV4IP, egressPodLabel)
+				egressNamespace := newNamespace(eipNamespace)
+				labels := map[string]string{
+					"k8s.ovn.org/egress-assignable": "",
+				}
+				annotations := map[string]string{
+					"k8s.ovn.org/node-primary-ifaddr": fmt.Sprintf("{\"ipv4\": \"%s\"}", node1IPv4CIDR),
+					"k8s.ovn.org/node-subnets":        fmt.Sprintf("{\"default\":\"%s\"}", v4Node1Subnet),
+					"k8s.ovn.org/l3-gateway-config":   `{"default":{"mode":"local","mac-address":"7e:57:f8:f0:3c:49", "ip-address":"192.168.126.12/24", "next-hop":"192.168.126.1"}}`,
+					"k8s.ovn.org/node-chassis-id":     "79fdcfc4-6fe6-4cd3-8242-c0f85a4668ec",
+					util.OVNNodeHostCIDRs:             fmt.Sprintf("[\"%s\"]", node1IPv4CIDR),
+				}
+				node1 := getNodeObj(node1Name, annotations, labels)
+				annotations = map[string]string{
+					"k8s.ovn.org/node-primary-ifaddr": fmt.Sprintf("{\"ipv4\": \"%s\"}", node2IPv4CIDR),
+					"k8s.ovn.org/node-subnets":        fmt.Sprintf("{\"default\":\"%s\"}", v4Node2Subnet),
+					"k8s.ovn.org/l3-gateway-config":   `{"default":{"mode":"local","mac-address":"7e:57:f8:f0:3c:49", "ip-address":"192.168.126.51/24", "next-hop":"192.168.126.1"}}`,
+					"k8s.ovn.org/node-chassis-id":     "89fdcfc4-6fe6-4cd3-8242-c0f85a4668ec",
+					util.OVNNodeHostCIDRs:             fmt.Sprintf("[\"%s\"]", node2IPv4CIDR),
+				}
+				node2 := getNodeObj(node2Name, annotations, labels)
+
+				eIP := egressipv1.EgressIP{
+					ObjectMeta: newEgressIPMeta(egressIPName),
+					Spec: egressipv1.EgressIPSpec{
+						EgressIPs:     []string{egressIP2, egressIP1},
+						LoadBalancing: egressipv1.EgressIPLoadBalancingActiveStandby,
+						PodSelector: metav1.LabelSelector{
+							MatchLabels: egressPodLabel,
+						},
+						NamespaceSelector: metav1.LabelSelector{
+							MatchLabels: map[string]string{
+								"name": egressNamespace.Name,
+							},
+						},
+					},
+					Status: egressipv1.EgressIPStatus{
+						Items: []egressipv1.EgressIPStatusItem{
+							{
+								Node:     node1Name,
+								EgressIP: egressIP1,
+							},
+							{
+								Node:     node2Name,
+								EgressIP: egressIP2,
+							},
+						},
+					},
+				}
+
+				fakeOvn.startWithDBSetup(
+					libovsdbtest.TestSetup{
+						NBData: []libovsdbtest.TestData{
+							&nbdb.LogicalRouter{
+								Name: types.OVNClusterRouter,
+								UUID: types.OVNClusterRouter + "-UUID",
+							},
+							&nbdb.LogicalRouter{
+								Name:  types.GWRouterPrefix + node1.Name,
+								UUID:  types.GWRouterPrefix + node1.Name + "-UUID",
+								Ports: []string{types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + node1.Name + "-UUID"},
+							},
+							&nbdb.LogicalRouter{
+								Name:  types.GWRouterPrefix + node2.Name,
+								UUID:  types.GWRouterPrefix + node2.Name + "-UUID",
+								Ports: []string{types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + node2.Name + "-UUID"},
+							},
+							&nbdb.LogicalRouterPort{
+								UUID:     types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + node1.Name + "-UUID",
+								Name:     types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + node1.Name,
+								Networks: []string{nodeLogicalRouterIfAddrV4},
+							},
+							&nbdb.LogicalRouterPort{
+								UUID:     types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + node2.Name + "-UUID",
+								Name:     types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + node2.Name,
+								Networks: []string{node2LogicalRouterIfAddrV4},
+							},
+						},
+					},
+					&egressipv1.EgressIPList{
+						Items: []egressipv1.EgressIP{eIP},
+					},
+					&v1.NodeList{
+						Items: []v1.Node{node1, node2},
+					},
+					&v1.NamespaceList{
+						Items: []v1.Namespace{*egressNamespace},
+					},
+					&v1.PodList{
+						Items: []v1.Pod{egressPod1},
+					},
+				)
+
+				i, n, _ := net.ParseCIDR(podV4IP + "/23")
+				n.IP = i
+				fakeOvn.controller.logicalPortCache.add(&egressPod1, "", types.DefaultNetworkName, "", nil, []*net.IPNet{n})
+
+				err := fakeOvn.controller.WatchEgressIPNamespaces()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				err = fakeOvn.controller.WatchEgressIPPods()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				err = fakeOvn.controller.WatchEgressNodes()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				err = fakeOvn.controller.WatchEgressIP()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				egressSvcPodsV4, _ := addressset.GetHashNamesForAS(egresssvc.GetEgressServiceAddrSetDbIDs(DefaultNetworkControllerName))
+				egressipPodsV4, _ := addressset.GetHashNamesForAS(getEgressIPAddrSetDbIDs(EgressIPServedPodsAddrSetName, DefaultNetworkControllerName))
+				nodeIPsV4, _ := addressset.GetHashNamesForAS(getEgressIPAddrSetDbIDs(NodeIPAddrSetName, DefaultNetworkControllerName))
+				expectedNatLogicalPort1 := "k8s-node1"
+				expectedNatLogicalPort2 := "k8s-node2"
+				getExpectedDatabaseState := func(nexthop string) []libovsdbtest.TestData {
+					return []libovsdbtest.TestData{
+						&nbdb.LogicalRouterPolicy{
+							Priority: types.DefaultNoRereoutePriority,
+							Match: fmt.Sprintf("(ip4.src == $%s || ip4.src == $%s) && ip4.dst == $%s",
+								egressipPodsV4, egressSvcPodsV4, nodeIPsV4),
+							Action:  nbdb.LogicalRouterPolicyActionAllow,
+							UUID:    "default-no-reroute-node-UUID",
+							Options: map[string]string{"pkt_mark": "1008"},
+						},
+						&nbdb.LogicalRouterPolicy{
+							Priority: types.DefaultNoRereoutePriority,
+							Match:    "ip4.src == 10.128.0.0/14 && ip4.dst == 10.128.0.0/14",
+							Action:   nbdb.LogicalRouterPolicyActionAllow,
+							UUID:     "no-reroute-UUID",
+						},
+						&nbdb.LogicalRouterPolicy{
+							Priority: types.DefaultNoRereoutePriority,
+							Match:    fmt.Sprintf("ip4.src == 10.128.0.0/14 && ip4.dst == %s", config.Gateway.V4JoinSubnet),
+							Action:   nbdb.LogicalRouterPolicyActionAllow,
+							UUID:     "no-reroute-service-UUID",
+						},
+						&nbdb.LogicalRouterPolicy{
+							Priority: types.EgressIPReroutePriority,
+							Match:    fmt.Sprintf("ip4.src == %s", egressPod1.Status.PodIP),
+							Action:   nbdb.LogicalRouterPolicyActionReroute,
+							Nexthops: []string{nexthop},
+							ExternalIDs: map[string]string{
+								"name": eIP.Name,
+							},
+							UUID: "reroute-UUID1",
+						},
+						&nbdb.NAT{
+							UUID:       "egressip-nat-UUID1",
+							LogicalIP:  podV4IP,
+							ExternalIP: egressIP1,
+							ExternalIDs: map[string]string{
+								"name": egressIPName,
+							},
+							Type:        nbdb.NATTypeSNAT,
+							LogicalPort: &expectedNatLogicalPort1,
+							Options: map[string]string{
+								"stateless": "false",
+							},
+						},
+						&nbdb.NAT{
+							UUID:       "egressip-nat-UUID2",
+							LogicalIP:  podV4IP,
+							ExternalIP: egressIP2,
+							ExternalIDs: map[string]string{
+								"name": egressIPName,
+							},
+							Type:        nbdb.NATTypeSNAT,
+							LogicalPort: &expectedNatLogicalPort2,
+							Options: map[string]string{
+								"stateless": "false",
+							},
+						},
+						&nbdb.LogicalRouter{
+							Name:     types.OVNClusterRouter,
+							UUID:     types.OVNClusterRouter + "-UUID",
+							Policies: []string{"no-reroute-UUID", "no-reroute-service-UUID", "default-no-reroute-node-UUID", "reroute-UUID1"},
+						},
+						&nbdb.LogicalRouter{
+							Name:  types.GWRouterPrefix + node1.Name,
+							UUID:  types.GWRouterPrefix + node1.Name + "-UUID",
+							Ports: []string{types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + node1.Name + "-UUID"},
+							Nat:   []string{"egressip-nat-UUID1"},
+						},
+						&nbdb.LogicalRouter{
+							Name:  types.GWRouterPrefix + node2.Name,
+							UUID:  types.GWRouterPrefix + node2.Name + "-UUID",
+							Ports: []string{types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + node2.Name + "-UUID"},
+							Nat:   []string{"egressip-nat-UUID2"},
+						},
+						&nbdb.LogicalRouterPort{
+							UUID:     types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + node1.Name + "-UUID",
+							Name:     types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + node1.Name,
+							Networks: []string{nodeLogicalRouterIfAddrV4},
+						},
+						&nbdb.LogicalRouterPort{
+							UUID:     types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + node2.Name + "-UUID",
+							Name:     types.GWRouterToJoinSwitchPrefix + types.GWRouterPrefix + node2.Name,
+							Networks: []string{node2LogicalRouterIfAddrV4},
+						},
+					}
+				}
+				// egressIP2 is listed first in the spec: only node2 is used
+				gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(getExpectedDatabaseState("100.64.0.3")))
+
+				// changing the order of the egress IPs makes node1 the active one
+				eIPUpdate, err := fakeOvn.fakeClient.EgressIPClient.K8sV1().EgressIPs().Get(context.TODO(), eIP.Name, metav1.GetOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				eIPUpdate.Spec.EgressIPs = []string{egressIP1, egressIP2}
+				_, err = fakeOvn.fakeClient.EgressIPClient.K8sV1().EgressIPs().Update(context.TODO(), eIPUpdate, metav1.UpdateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(getExpectedDatabaseState("100.64.0.2")))
+
+				// removing node1 from the status fails over to node2
+				status := []egressipv1.EgressIPStatusItem{
+					{
+						Node:     node2Name,
+						EgressIP: egressIP2,
+					},
+				}
+				err = fakeOvn.controller.patchReplaceEgressIPStatus(egressIPName, status)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(getEgressIPStatusLen(egressIPName)).Should(gomega.Equal(1))
+				expectedDatabaseState := getExpectedDatabaseState("100.64.0.3")
+				expectedDatabaseState = append(expectedDatabaseState[:4], expectedDatabaseState[5:]...)
+				expectedDatabaseState[6].(*nbdb.LogicalRouter).Nat = nil
+				gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
 		ginkgo.It("should re-balance EgressIPs when their node is removed", func() {
 			app.Action = func(ctx *cli.Context) error {
 				config.IPv4Mode = true
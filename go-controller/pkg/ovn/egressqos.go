@@ -57,6 +57,7 @@ type egressQoS struct {
 type egressQoSRule struct {
 	priority    int
 	dscp        int
+	bandwidth   map[string]int // nil if the rule doesn't limit the traffic rate
 	destination string
 	addrSet     addressset.AddressSet
 	pods        *sync.Map // pods name -> ips in the addrSet
@@ -98,6 +99,10 @@ func (oc *DefaultNetworkController) cloneEgressQoS(raw *egressqosapi.EgressQoS)
 		eq.rules = append(eq.rules, eqr)
 	}
 
+	if err := validateEgressQoSBandwidth(eq.rules); err != nil {
+		addErrors = errors.Wrapf(addErrors, "error: cannot create egressqos for namespace %s - %v", eq.namespace, err)
+	}
+
 	if addErrors.Error() == "" {
 		addErrors = nil
 	}
@@ -128,9 +133,57 @@ func (oc *DefaultNetworkController) cloneEgressQoSRule(raw egressqosapi.EgressQo
 		podSelector: raw.PodSelector,
 	}
 
+	if raw.Bandwidth != nil {
+		eqr.bandwidth = map[string]int{nbdb.QoSBandwidthRate: int(raw.Bandwidth.Rate)}
+		if raw.Bandwidth.Burst != nil {
+			eqr.bandwidth[nbdb.QoSBandwidthBurst] = int(*raw.Bandwidth.Burst)
+		}
+	}
+
 	return eqr, nil
 }
 
+// validateEgressQoSBandwidth returns an error if the traffic of a rule with bandwidth limits may also be matched
+// by a higher priority rule without bandwidth limits. OVN applies the DSCP marking and the rate limiting of the
+// QoS rows in separate stages, so that traffic would be marked by the higher priority rule while still being
+// limited by the lower priority one. The rules are expected to be sorted by decreasing priority.
+func validateEgressQoSBandwidth(rules []*egressQoSRule) error {
+	for i, limited := range rules {
+		if limited.bandwidth == nil {
+			continue
+		}
+		for _, marking := range rules[:i] {
+			if marking.bandwidth == nil && egressQoSRulesOverlap(marking, limited) {
+				return fmt.Errorf("bandwidth of rule with priority %d conflicts with DSCP-only rule with higher priority %d "+
+					"matching the same traffic, make the rules disjoint or set a bandwidth on both",
+					limited.priority, marking.priority)
+			}
+		}
+	}
+	return nil
+}
+
+// egressQoSRulesOverlap returns true if the two rules may match the same traffic. Pod selectors are considered
+// to overlap unless they require different values for the same label, as different selectors can still match
+// the same pods.
+func egressQoSRulesOverlap(a, b *egressQoSRule) bool {
+	if util.LabelSelectorsDisjoint(a.podSelector, b.podSelector) {
+		return false
+	}
+	if a.destination == "" || b.destination == "" {
+		return true
+	}
+	_, aNet, err := net.ParseCIDR(a.destination)
+	if err != nil {
+		return true
+	}
+	_, bNet, err := net.ParseCIDR(b.destination)
+	if err != nil {
+		return true
+	}
+	return aNet.Contains(bNet.IP) || bNet.Contains(aNet.IP)
+}
+
 func (oc *DefaultNetworkController) createASForEgressQoSRule(podSelector metav1.LabelSelector, namespace string, priority int) (addressset.AddressSet, *sync.Map, error) {
 	var addrSet addressset.AddressSet
 
@@ -605,6 +658,7 @@ func (oc *DefaultNetworkController) addEgressQoS(eqObj *egressqosapi.EgressQoS)
 			Match:       match,
 			Priority:    r.priority,
 			Action:      map[string]int{nbdb.QoSActionDSCP: r.dscp},
+			Bandwidth:   r.bandwidth,
 			ExternalIDs: map[string]string{"EgressQoS": eq.namespace},
 		}
 		qoses = append(qoses, qos)
@@ -512,6 +512,145 @@ var _ = ginkgo.Describe("OVN EgressQoS Operations", func() {
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
 	})
 
+	ginkgo.It("reconciles egressqoses with bandwidth limits", func() {
+		app.Action = func(ctx *cli.Context) error {
+			config.IPv4Mode = true
+			config.IPv6Mode = false
+
+			node1Switch := &nbdb.LogicalSwitch{
+				UUID: "node1-UUID",
+				Name: node1Name,
+			}
+
+			dbSetup := libovsdbtest.TestSetup{
+				NBData: []libovsdbtest.TestData{
+					node1Switch,
+				},
+			}
+
+			fakeOVN.startWithDBSetup(dbSetup,
+				&v1.NamespaceList{
+					Items: []v1.Namespace{
+						namespaceT,
+					},
+				},
+			)
+
+			// Create an EgressQoS limiting the traffic of its first rule
+			eq := newEgressQoSObject("default", namespaceT.Name, []egressqosapi.EgressQoSRule{
+				{
+					DstCIDR:   pointer.String("1.2.3.4/32"),
+					DSCP:      50,
+					Bandwidth: &egressqosapi.EgressQoSBandwidth{Rate: 10000, Burst: pointer.Int64(1000)},
+				},
+				{
+					DstCIDR: pointer.String("5.6.7.8/32"),
+					DSCP:    60,
+				},
+			})
+			eq.ResourceVersion = "1"
+			_, err := fakeOVN.fakeClient.EgressQoSClient.K8sV1().EgressQoSes(namespaceT.Name).Create(context.TODO(), eq, metav1.CreateOptions{})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			fakeOVN.InitAndRunEgressQoSController()
+
+			qos1 := &nbdb.QoS{
+				Direction:   nbdb.QoSDirectionToLport,
+				Match:       fmt.Sprintf("(ip4.dst == 1.2.3.4/32) && ip4.src == $%s", asv4),
+				Priority:    EgressQoSFlowStartPriority,
+				Action:      map[string]int{nbdb.QoSActionDSCP: 50},
+				Bandwidth:   map[string]int{nbdb.QoSBandwidthRate: 10000, nbdb.QoSBandwidthBurst: 1000},
+				ExternalIDs: map[string]string{"EgressQoS": namespaceT.Name},
+				UUID:        "qos1-UUID",
+			}
+			qos2 := &nbdb.QoS{
+				Direction:   nbdb.QoSDirectionToLport,
+				Match:       fmt.Sprintf("(ip4.dst == 5.6.7.8/32) && ip4.src == $%s", asv4),
+				Priority:    EgressQoSFlowStartPriority - 1,
+				Action:      map[string]int{nbdb.QoSActionDSCP: 60},
+				ExternalIDs: map[string]string{"EgressQoS": namespaceT.Name},
+				UUID:        "qos2-UUID",
+			}
+			node1Switch.QOSRules = []string{qos1.UUID, qos2.UUID}
+			expectedDatabaseState := []libovsdbtest.TestData{
+				qos1,
+				qos2,
+				node1Switch,
+			}
+
+			gomega.Eventually(fakeOVN.nbClient).Should(libovsdbtest.HaveDataIgnoringUUIDs(expectedDatabaseState))
+			expectEgressQoSStatusMessageEventually(fakeOVN, namespaceT.Name, false)
+
+			// Update the EgressQoS to limit only the rate of the second rule
+			eq.Spec.Egress[0].Bandwidth = nil
+			eq.Spec.Egress[1].Bandwidth = &egressqosapi.EgressQoSBandwidth{Rate: 20000}
+			eq.ResourceVersion = "2"
+			_, err = fakeOVN.fakeClient.EgressQoSClient.K8sV1().EgressQoSes(namespaceT.Name).Update(context.TODO(), eq, metav1.UpdateOptions{})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			qos1.Bandwidth = nil
+			qos2.Bandwidth = map[string]int{nbdb.QoSBandwidthRate: 20000}
+			gomega.Eventually(fakeOVN.nbClient).Should(libovsdbtest.HaveDataIgnoringUUIDs(expectedDatabaseState))
+			expectEgressQoSStatusMessageEventually(fakeOVN, namespaceT.Name, false)
+
+			return nil
+		}
+		err := app.Run([]string{app.Name})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
+	ginkgo.It("Validate status with bandwidth limits conflicting with DSCP rules", func() {
+		app.Action = func(ctx *cli.Context) error {
+			maxEgressQoSRetries = 0
+			defer func() {
+				maxEgressQoSRetries = 10
+			}()
+
+			node1Switch := &nbdb.LogicalSwitch{
+				UUID: "node1-UUID",
+				Name: node1Name,
+			}
+
+			dbSetup := libovsdbtest.TestSetup{
+				NBData: []libovsdbtest.TestData{
+					node1Switch,
+				},
+			}
+
+			fakeOVN.startWithDBSetup(dbSetup,
+				&v1.NamespaceList{
+					Items: []v1.Namespace{
+						namespaceT,
+					},
+				},
+			)
+
+			// Create an EgressQoS whose limited rule is shadowed by a higher priority DSCP-only rule
+			eq := newEgressQoSObject("default", namespaceT.Name, []egressqosapi.EgressQoSRule{
+				{
+					DSCP: 50,
+				},
+				{
+					DstCIDR:   pointer.String("1.2.3.4/32"),
+					DSCP:      60,
+					Bandwidth: &egressqosapi.EgressQoSBandwidth{Rate: 10000},
+				},
+			})
+			_, err := fakeOVN.fakeClient.EgressQoSClient.K8sV1().EgressQoSes(namespaceT.Name).Create(context.TODO(), eq, metav1.CreateOptions{})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			fakeOVN.InitAndRunEgressQoSController()
+
+			gomega.Eventually(fakeOVN.nbClient).Should(libovsdbtest.HaveDataIgnoringUUIDs([]libovsdbtest.TestData{node1Switch}))
+			// Ensure default EgressQoS object is updated with zone failure status.
+			expectEgressQoSStatusMessageEventually(fakeOVN, namespaceT.Name, true)
+
+			return nil
+		}
+		err := app.Run([]string{app.Name})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
 	ginkgo.It("should respond to node events correctly", func() {
 		app.Action = func(ctx *cli.Context) error {
 			namespaceT := *newNamespace("namespace1")
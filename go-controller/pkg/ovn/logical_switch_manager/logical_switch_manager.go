@@ -1,26 +1,36 @@
 package logicalswitchmanager
 
 import (
+	"errors"
 	"fmt"
 	"net"
 
 	ipam "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/ip"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/allocator/ip/subnet"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	utilnet "k8s.io/utils/net"
 )
 
 var SwitchNotFound = subnet.ErrSubnetNotFound
 
+// ErrServiceMonitorIP is returned when allocating the address reserved as the source of the OVN load balancer
+// health checks, which happens when enable-service-health-checks is enabled on a cluster with pods using it
+var ErrServiceMonitorIP = errors.New("address reserved for the service health checks is in use, " +
+	"enable-service-health-checks must be set at install time or the pods using it recreated")
+
 // LogicalSwitchManager provides switch info management APIs including IPAM for the host subnets
 type LogicalSwitchManager struct {
 	allocator  subnet.Allocator
 	reserveIPs bool
 }
 
-// Initializes a new logical switch manager for L3 networks
+// Initializes a new logical switch manager for L3 networks. Nodes might have
+// several host subnets of the same IP family, pods get a single IP per family.
 func NewLogicalSwitchManager() *LogicalSwitchManager {
 	return &LogicalSwitchManager{
-		allocator:  subnet.NewAllocator(),
+		allocator:  subnet.NewIPFamilyAllocator(),
 		reserveIPs: true,
 	}
 }
@@ -31,12 +41,33 @@ func NewL2SwitchManager() *LogicalSwitchManager {
 	}
 }
 
+// reservedIfAddrs returns the well known addresses of a host subnet that are
+// not available for allocation
+func reservedIfAddrs(hostSubnet *net.IPNet) []*net.IPNet {
+	ifAddrs := []*net.IPNet{util.GetNodeGatewayIfAddr(hostSubnet), util.GetNodeManagementIfAddr(hostSubnet)}
+	if config.OVNKubernetesFeature.EnableServiceHealthChecks {
+		if svcMonitorIfAddr := getAllocatableServiceMonitorIfAddr(hostSubnet); svcMonitorIfAddr != nil {
+			ifAddrs = append(ifAddrs, svcMonitorIfAddr)
+		}
+	}
+	return ifAddrs
+}
+
+// getAllocatableServiceMonitorIfAddr returns the address of the host subnet used as source of the OVN load balancer
+// health checks, nil if it can't be allocated to pods because it is out of the allocated range of a large IPv6 subnet
+func getAllocatableServiceMonitorIfAddr(hostSubnet *net.IPNet) *net.IPNet {
+	if utilnet.IsIPv6CIDR(hostSubnet) && utilnet.RangeSize(hostSubnet) > ipam.MaxIPv6RangeSize {
+		return nil
+	}
+	return util.GetNodeServiceMonitorIfAddr(hostSubnet)
+}
+
 // AddOrUpdateSwitch adds/updates a switch to the logical switch manager for subnet
 // and IPAM management.
 func (manager *LogicalSwitchManager) AddOrUpdateSwitch(switchName string, hostSubnets []*net.IPNet, excludeSubnets ...*net.IPNet) error {
 	if manager.reserveIPs {
 		for _, hostSubnet := range hostSubnets {
-			for _, ip := range []*net.IPNet{util.GetNodeGatewayIfAddr(hostSubnet), util.GetNodeManagementIfAddr(hostSubnet)} {
+			for _, ip := range reservedIfAddrs(hostSubnet) {
 				excludeSubnets = append(excludeSubnets,
 					&net.IPNet{IP: ip.IP, Mask: util.GetIPFullMask(ip.IP)},
 				)
@@ -46,6 +77,22 @@ func (manager *LogicalSwitchManager) AddOrUpdateSwitch(switchName string, hostSu
 	return manager.allocator.AddOrUpdateSubnet(switchName, hostSubnets, excludeSubnets...)
 }
 
+// ExpandSwitch updates the subnets and excluded subnets of an existing switch
+// while retaining the IPs already allocated from it. New host subnets and
+// excluded subnets can be added but existing host subnets can't be removed.
+func (manager *LogicalSwitchManager) ExpandSwitch(switchName string, hostSubnets []*net.IPNet, excludeSubnets ...*net.IPNet) error {
+	if manager.reserveIPs {
+		for _, hostSubnet := range hostSubnets {
+			for _, ip := range reservedIfAddrs(hostSubnet) {
+				excludeSubnets = append(excludeSubnets,
+					&net.IPNet{IP: ip.IP, Mask: util.GetIPFullMask(ip.IP)},
+				)
+			}
+		}
+	}
+	return manager.allocator.ExpandSubnet(switchName, hostSubnets, excludeSubnets...)
+}
+
 // AddNoHostSubnetSwitch adds/updates a switch without any host subnets
 // to the logical switch manager
 func (manager *LogicalSwitchManager) AddNoHostSubnetSwitch(switchName string) error {
@@ -80,11 +127,36 @@ func (manager *LogicalSwitchManager) AllocateUntilFull(switchName string) error
 // AllocateIPs will block off IPs in the ipnets slice as already allocated
 // for a given switch
 func (manager *LogicalSwitchManager) AllocateIPs(switchName string, ipnets []*net.IPNet) error {
+	if err := manager.checkServiceMonitorIPs(switchName, ipnets); err != nil {
+		return err
+	}
 	return manager.allocator.AllocateIPs(switchName, ipnets)
 }
 
+// checkServiceMonitorIPs returns an error if one of the IPs is the address of the switch subnets reserved as the
+// source of the OVN load balancer health checks. Only pods that got their IPs before the health checks were
+// enabled can be using it.
+func (manager *LogicalSwitchManager) checkServiceMonitorIPs(switchName string, ipnets []*net.IPNet) error {
+	if !manager.reserveIPs || !config.OVNKubernetesFeature.EnableServiceHealthChecks {
+		return nil
+	}
+	for _, hostSubnet := range manager.GetSwitchSubnets(switchName) {
+		svcMonitorIfAddr := getAllocatableServiceMonitorIfAddr(hostSubnet)
+		if svcMonitorIfAddr == nil {
+			continue
+		}
+		for _, ipnet := range ipnets {
+			if svcMonitorIfAddr.IP.Equal(ipnet.IP) {
+				return fmt.Errorf("%w: IP %s on switch %s", ErrServiceMonitorIP, ipnet.IP, switchName)
+			}
+		}
+	}
+	return nil
+}
+
 // AllocateNextIPs allocates IP addresses from each of the host subnets
-// for a given switch
+// for a given switch, or from each IP family of the host subnets for L3
+// networks
 func (manager *LogicalSwitchManager) AllocateNextIPs(switchName string) ([]*net.IPNet, error) {
 	return manager.allocator.AllocateNextIPs(switchName)
 }
@@ -148,5 +148,32 @@ var _ = ginkgo.Describe("OVN Logical Switch Manager operations", func() {
 			err := app.Run([]string{app.Name})
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		})
+		ginkgo.It("reserves the service health checks address and refuses to allocate it to pods", func() {
+			app.Action = func(ctx *cli.Context) error {
+				_, err := config.InitConfig(ctx, fexec, nil)
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				config.OVNKubernetesFeature.EnableServiceHealthChecks = true
+
+				testNode := testNodeSubnetData{
+					switchName: "testNode1",
+					subnets: []string{
+						"10.1.1.0/24",
+						"2000::/64",
+					},
+				}
+
+				err = lsManager.AddOrUpdateSwitch(testNode.switchName, ovntest.MustParseIPNets(testNode.subnets...))
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				// a pod that got the address before the health checks were enabled is a conflict
+				err = lsManager.AllocateIPs(testNode.switchName, ovntest.MustParseIPNets("10.1.1.254/24", "2000::5/64"))
+				gomega.Expect(err).To(gomega.MatchError(ErrServiceMonitorIP))
+				err = lsManager.AllocateIPs(testNode.switchName, ovntest.MustParseIPNets("10.1.1.5/24", "2000::5/64"))
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				return nil
+			}
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
 	})
 })
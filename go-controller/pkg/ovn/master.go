@@ -169,7 +169,10 @@ func (oc *DefaultNetworkController) syncNodeManagementPort(node *kapi.Node, host
 
 	var v4Subnet *net.IPNet
 	addresses := macAddress.String()
-	for _, hostSubnet := range hostSubnets {
+	// the management port is only given an IP from the first host subnet of
+	// each IP family, additional host subnets are routed through it
+	primaryHostSubnets := util.MatchFirstIPNetOfEachFamily(hostSubnets)
+	for _, hostSubnet := range primaryHostSubnets {
 		mgmtIfAddr := util.GetNodeManagementIfAddr(hostSubnet)
 		addresses += " " + mgmtIfAddr.IP.String()
 
@@ -177,10 +180,26 @@ func (oc *DefaultNetworkController) syncNodeManagementPort(node *kapi.Node, host
 			return err
 		}
 
+		// allow the OVN load balancer health checks sourced from the node subnet
+		if svcMonitorIfAddr := util.GetNodeServiceMonitorIfAddr(hostSubnet); svcMonitorIfAddr != nil &&
+			config.OVNKubernetesFeature.EnableServiceHealthChecks {
+			if err := oc.addAllowACLFromNode(node.Name, svcMonitorIfAddr.IP); err != nil {
+				return err
+			}
+		}
+
 		if !utilnet.IsIPv6CIDR(hostSubnet) {
 			v4Subnet = hostSubnet
 		}
-		if config.Gateway.Mode == config.GatewayModeLocal {
+	}
+
+	if config.Gateway.Mode == config.GatewayModeLocal {
+		for _, hostSubnet := range hostSubnets {
+			primaryHostSubnet, err := util.MatchFirstIPNetFamily(utilnet.IsIPv6CIDR(hostSubnet), primaryHostSubnets)
+			if err != nil {
+				return err
+			}
+			mgmtIfAddr := util.GetNodeManagementIfAddr(primaryHostSubnet)
 			lrsr := nbdb.LogicalRouterStaticRoute{
 				Policy:   &nbdb.LogicalRouterStaticRoutePolicySrcIP,
 				IPPrefix: hostSubnet.String(),
@@ -189,7 +208,7 @@ func (oc *DefaultNetworkController) syncNodeManagementPort(node *kapi.Node, host
 			p := func(item *nbdb.LogicalRouterStaticRoute) bool {
 				return item.IPPrefix == lrsr.IPPrefix && libovsdbops.PolicyEqualPredicate(lrsr.Policy, item.Policy)
 			}
-			err := libovsdbops.CreateOrReplaceLogicalRouterStaticRouteWithPredicate(oc.nbClient, types.OVNClusterRouter,
+			err = libovsdbops.CreateOrReplaceLogicalRouterStaticRouteWithPredicate(oc.nbClient, types.OVNClusterRouter,
 				&lrsr, p, &lrsr.Nexthop)
 			if err != nil {
 				return fmt.Errorf("error creating static route %+v on router %s: %v", lrsr, types.OVNClusterRouter, err)
@@ -227,7 +246,7 @@ func (oc *DefaultNetworkController) syncGatewayLogicalNetwork(node *kapi.Node, l
 	hostSubnets []*net.IPNet, hostAddrs []string) error {
 	var err error
 	var gwLRPIPs, clusterSubnets []*net.IPNet
-	for _, clusterSubnet := range config.Default.ClusterSubnets {
+	for _, clusterSubnet := range config.ClusterSubnets() {
 		clusterSubnets = append(clusterSubnets, clusterSubnet.CIDR)
 	}
 
@@ -244,7 +263,7 @@ func (oc *DefaultNetworkController) syncGatewayLogicalNetwork(node *kapi.Node, l
 		return fmt.Errorf("failed to init shared interface gateway: %v", err)
 	}
 
-	for _, subnet := range hostSubnets {
+	for _, subnet := range util.MatchFirstIPNetOfEachFamily(hostSubnets) {
 		hostIfAddr := util.GetNodeManagementIfAddr(subnet)
 		l3GatewayConfigIP, err := util.MatchFirstIPNetFamily(utilnet.IsIPv6(hostIfAddr.IP), l3GatewayConfig.IPAddresses)
 		if err != nil {
@@ -835,7 +854,7 @@ func (oc *DefaultNetworkController) deleteOVNNodeEvent(node *kapi.Node) error {
 // logical router port "GwRouterToJoinSwitchPrefix + OVNClusterRouter" from the
 // config.Gateway.V4JoinSubnet and  config.Gateway.V6JoinSubnet. This will
 // always be the first IP from these subnets.
-func (oc *DefaultNetworkController) getOVNClusterRouterPortToJoinSwitchIfAddrs() (gwLRPIPs []*net.IPNet, err error) {
+func (bnc *BaseNetworkController) getOVNClusterRouterPortToJoinSwitchIfAddrs() (gwLRPIPs []*net.IPNet, err error) {
 	joinSubnetsConfig := []string{}
 	if config.IPv4Mode {
 		joinSubnetsConfig = append(joinSubnetsConfig, config.Gateway.V4JoinSubnet)
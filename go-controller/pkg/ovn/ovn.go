@@ -13,6 +13,7 @@ import (
 	libovsdbclient "github.com/ovn-org/libovsdb/client"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	clusternetworkapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kubevirt"
 	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
@@ -23,8 +24,10 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
 	kapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/kubernetes/scheme"
 	listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 	ref "k8s.io/client-go/tools/reference"
 	"k8s.io/klog/v2"
 )
@@ -354,6 +357,91 @@ func (oc *DefaultNetworkController) WatchEgressIPPods() error {
 	return err
 }
 
+// WatchClusterNetworks starts the watching of ClusterNetwork resources adding
+// cluster subnets to the default network at runtime
+func (oc *DefaultNetworkController) WatchClusterNetworks() error {
+	handler, err := oc.watchFactory.AddClusterNetworkHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: oc.addClusterNetwork,
+		UpdateFunc: func(_, newObj interface{}) {
+			oc.addClusterNetwork(newObj)
+		},
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("unable to watch cluster networks: %w", err)
+	}
+	oc.clusterNetworkHandler = handler
+	return nil
+}
+
+// addClusterNetwork adds the cluster subnets of the given ClusterNetwork to the
+// default network and, once the cluster subnets changed, resyncs the gateways
+// of the local zone nodes so that they route the new cluster subnets and the
+// egress firewalls so that their ACLs exclude them.
+func (oc *DefaultNetworkController) addClusterNetwork(obj interface{}) {
+	clusterNetwork, ok := obj.(*clusternetworkapi.ClusterNetwork)
+	if !ok {
+		klog.Errorf("Could not cast %T object to *clusternetworkapi.ClusterNetwork", obj)
+		return
+	}
+	added, err := util.AddClusterNetworkSubnets(clusterNetwork)
+	if err != nil {
+		klog.Errorf("Failed to add cluster subnets: %v", err)
+		return
+	}
+	if len(added) > 0 {
+		klog.Infof("Added cluster subnets %v from ClusterNetwork %s", added, clusterNetwork.Name)
+	}
+	// the cluster subnets might have already been added by another controller
+	// running in the same process, compare with what this controller synced
+	clusterSubnets := len(config.ClusterSubnets())
+	if clusterSubnets == oc.syncedClusterSubnets {
+		return
+	}
+	oc.syncedClusterSubnets = clusterSubnets
+	oc.syncGatewaysForClusterSubnets()
+	if config.OVNKubernetesFeature.EnableEgressFirewall {
+		oc.syncEgressFirewallsForClusterSubnets()
+	}
+}
+
+// syncGatewaysForClusterSubnets requests the gateways of the local zone nodes
+// to be synced with the current cluster subnets.
+func (oc *DefaultNetworkController) syncGatewaysForClusterSubnets() {
+	nodes, err := oc.watchFactory.GetNodes()
+	if err != nil {
+		klog.Errorf("Failed to get nodes to sync their gateways with the cluster subnets: %v", err)
+		return
+	}
+	for _, node := range nodes {
+		if !oc.isLocalZoneNode(node) {
+			continue
+		}
+		oc.gatewaysFailed.Store(node.Name, true)
+		if err := oc.retryNodes.AddRetryObjWithAddNoBackoff(node); err != nil {
+			klog.Errorf("Failed to add node %s to retryNodes: %v", node.Name, err)
+		}
+	}
+	oc.retryNodes.RequestRetryObjs()
+}
+
+// syncEgressFirewallsForClusterSubnets requests all egress firewalls to be
+// added again, since both whether their rules intersect with the cluster
+// subnets and the cluster subnets excluded from their ACLs might have changed.
+func (oc *DefaultNetworkController) syncEgressFirewallsForClusterSubnets() {
+	egressFirewalls, err := oc.watchFactory.EgressFirewallInformer().Lister().List(labels.Everything())
+	if err != nil {
+		klog.Errorf("Failed to list egress firewalls to sync them with the cluster subnets: %v", err)
+		return
+	}
+	for _, egressFirewall := range egressFirewalls {
+		if err := oc.retryEgressFirewalls.AddRetryObjWithAddNoBackoff(egressFirewall); err != nil {
+			klog.Errorf("Failed to add egress firewall %s to retryEgressFirewalls: %v",
+				getEgressFirewallNamespacedName(egressFirewall), err)
+		}
+	}
+	oc.retryEgressFirewalls.RequestRetryObjs()
+}
+
 // syncNodeGateway ensures a node's gateway router is configured
 func (oc *DefaultNetworkController) syncNodeGateway(node *kapi.Node, hostSubnets []*net.IPNet) error {
 	l3GatewayConfig, err := util.ParseNodeL3GatewayAnnotation(node)
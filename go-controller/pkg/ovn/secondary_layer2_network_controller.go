@@ -92,7 +92,11 @@ func (oc *SecondaryLayer2NetworkController) Start(ctx context.Context) error {
 }
 
 func (oc *SecondaryLayer2NetworkController) run(ctx context.Context) error {
-	return oc.BaseSecondaryLayer2NetworkController.run()
+	if err := oc.BaseSecondaryLayer2NetworkController.run(); err != nil {
+		return err
+	}
+
+	return oc.startServiceController()
 }
 
 // Cleanup cleans up logical entities for the given network, called from net-attach-def routine
@@ -109,6 +113,17 @@ func (oc *SecondaryLayer2NetworkController) Init() error {
 	return err
 }
 
+// UpdateNetInfo applies in place the compatible changes of the given network
+// information, updating the subnets of the layer2 switch
+func (oc *SecondaryLayer2NetworkController) UpdateNetInfo(netInfo util.BasicNetInfo) error {
+	if err := util.UpdateNetInfo(oc.NetInfo, netInfo); err != nil {
+		return err
+	}
+
+	switchName := oc.GetNetworkScopedName(types.OVNLayer2Switch)
+	return oc.updateLogicalSwitch(switchName, oc.Subnets(), oc.ExcludeSubnets())
+}
+
 func (oc *SecondaryLayer2NetworkController) Stop() {
 	klog.Infof("Stoping controller for secondary network %s", oc.GetNetworkName())
 	oc.BaseSecondaryLayer2NetworkController.stop()
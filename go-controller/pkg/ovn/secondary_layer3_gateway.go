@@ -0,0 +1,364 @@
+package ovn
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	libovsdbclient "github.com/ovn-org/libovsdb/client"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	utilnet "k8s.io/utils/net"
+)
+
+// networkExternalIDs returns the external IDs identifying the logical
+// entities of the network
+func (oc *SecondaryLayer3NetworkController) networkExternalIDs() map[string]string {
+	return map[string]string{
+		types.NetworkExternalID:  oc.GetNetworkName(),
+		types.TopologyExternalID: oc.TopologyType(),
+	}
+}
+
+// filterIPNetsForNetwork returns the given addresses of the IP families
+// enabled on the network
+func (oc *SecondaryLayer3NetworkController) filterIPNetsForNetwork(ipNets []*net.IPNet) []*net.IPNet {
+	ipv4Mode, ipv6Mode := oc.IPMode()
+	var filtered []*net.IPNet
+	if ipv4Mode {
+		filtered = append(filtered, util.MatchAllIPNetFamily(false, ipNets)...)
+	}
+	if ipv6Mode {
+		filtered = append(filtered, util.MatchAllIPNetFamily(true, ipNets)...)
+	}
+	return filtered
+}
+
+// getJoinSwitchIfAddrs returns the addresses of the cluster router port to the
+// join switch of the network. Each network has its own join switch, so the
+// addresses of the default network join subnet are reused.
+func (oc *SecondaryLayer3NetworkController) getJoinSwitchIfAddrs() ([]*net.IPNet, error) {
+	joinIfAddrs, err := oc.getOVNClusterRouterPortToJoinSwitchIfAddrs()
+	if err != nil {
+		return nil, err
+	}
+	for _, joinIfAddr := range joinIfAddrs {
+		joinSubnet := &net.IPNet{IP: joinIfAddr.IP.Mask(joinIfAddr.Mask), Mask: joinIfAddr.Mask}
+		for _, subnet := range oc.Subnets() {
+			if subnet.CIDR.Contains(joinSubnet.IP) || joinSubnet.Contains(subnet.CIDR.IP) {
+				return nil, fmt.Errorf("subnet %s of network %s overlaps with the join subnet %s",
+					subnet.CIDR, oc.GetNetworkName(), joinSubnet)
+			}
+		}
+	}
+	return oc.filterIPNetsForNetwork(joinIfAddrs), nil
+}
+
+// createJoinSwitch creates the join switch of the network, used to connect
+// the gateway routers to the cluster router
+func (oc *SecondaryLayer3NetworkController) createJoinSwitch(clusterRouter *nbdb.LogicalRouter) error {
+	joinIfAddrs, err := oc.getJoinSwitchIfAddrs()
+	if err != nil {
+		return err
+	}
+
+	joinSwitchName := oc.GetNetworkScopedName(types.OVNJoinSwitch)
+	logicalSwitch := nbdb.LogicalSwitch{
+		Name:        joinSwitchName,
+		ExternalIDs: oc.networkExternalIDs(),
+	}
+	err = libovsdbops.CreateOrUpdateLogicalSwitch(oc.nbClient, &logicalSwitch, &logicalSwitch.ExternalIDs)
+	if err != nil {
+		return fmt.Errorf("failed to create logical switch %+v: %v", logicalSwitch, err)
+	}
+
+	drRouterPort := types.GWRouterToJoinSwitchPrefix + clusterRouter.Name
+	drSwitchPort := types.JoinSwitchToGWRouterPrefix + clusterRouter.Name
+	lrpNetworks := []string{}
+	for _, joinIfAddr := range joinIfAddrs {
+		lrpNetworks = append(lrpNetworks, joinIfAddr.String())
+	}
+	logicalRouterPort := nbdb.LogicalRouterPort{
+		Name:     drRouterPort,
+		MAC:      util.IPAddrToHWAddr(joinIfAddrs[0].IP).String(),
+		Networks: lrpNetworks,
+	}
+	err = libovsdbops.CreateOrUpdateLogicalRouterPort(oc.nbClient, clusterRouter,
+		&logicalRouterPort, nil, &logicalRouterPort.MAC, &logicalRouterPort.Networks)
+	if err != nil {
+		return fmt.Errorf("failed to add logical router port %+v on router %s: %v", logicalRouterPort, clusterRouter.Name, err)
+	}
+
+	logicalSwitchPort := nbdb.LogicalSwitchPort{
+		Name:      drSwitchPort,
+		Type:      "router",
+		Addresses: []string{"router"},
+		Options: map[string]string{
+			"router-port": drRouterPort,
+		},
+	}
+	err = libovsdbops.CreateOrUpdateLogicalSwitchPortsOnSwitch(oc.nbClient, &logicalSwitch, &logicalSwitchPort)
+	if err != nil {
+		return fmt.Errorf("failed to create logical switch port %+v on switch %s: %v", logicalSwitchPort, joinSwitchName, err)
+	}
+	return nil
+}
+
+// gatewayInit creates the gateway router of the network for the given node.
+// The gateway router is connected to the cluster router through the network
+// join switch and to the node's external bridge, where traffic leaving the
+// network is SNATed to the node IP.
+func (oc *SecondaryLayer3NetworkController) gatewayInit(node *kapi.Node, hostSubnets []*net.IPNet) error {
+	l3GatewayConfig, err := util.ParseNodeL3GatewayAnnotation(node)
+	if err != nil {
+		return err
+	}
+	if l3GatewayConfig.Mode == config.GatewayModeDisabled {
+		return oc.gatewayCleanup(node.Name)
+	}
+
+	if len(hostSubnets) == 0 {
+		hostSubnets, err = util.ParseNodeHostSubnetAnnotation(node, oc.GetNetworkName())
+		if err != nil {
+			return err
+		}
+	}
+
+	gwLRPIfAddrs, err := util.ParseNodeGatewayRouterLRPAddrs(node)
+	if err != nil {
+		return fmt.Errorf("failed to get join switch port IP address for node %s: %v", node.Name, err)
+	}
+	gwLRPIfAddrs = oc.filterIPNetsForNetwork(gwLRPIfAddrs)
+	drLRPIfAddrs, err := oc.getJoinSwitchIfAddrs()
+	if err != nil {
+		return err
+	}
+	if len(gwLRPIfAddrs) == 0 || len(drLRPIfAddrs) == 0 {
+		return fmt.Errorf("no join switch IP address for the IP families of network %s on node %s",
+			oc.GetNetworkName(), node.Name)
+	}
+
+	gatewayRouter := oc.GetNetworkScopedName(types.GWRouterPrefix + node.Name)
+	logicalRouter := nbdb.LogicalRouter{
+		Name: gatewayRouter,
+		Options: map[string]string{
+			"always_learn_from_arp_request": "false",
+			"dynamic_neigh_routers":         "true",
+			"chassis":                       l3GatewayConfig.ChassisID,
+			"mac_binding_age_threshold":     types.GRMACBindingAgeThreshold,
+		},
+		ExternalIDs: oc.networkExternalIDs(),
+	}
+	err = libovsdbops.CreateOrUpdateLogicalRouter(oc.nbClient, &logicalRouter, &logicalRouter.Options,
+		&logicalRouter.ExternalIDs)
+	if err != nil {
+		return fmt.Errorf("failed to create logical router %+v: %v", logicalRouter, err)
+	}
+
+	gwSwitchPort := types.JoinSwitchToGWRouterPrefix + gatewayRouter
+	gwRouterPort := types.GWRouterToJoinSwitchPrefix + gatewayRouter
+	logicalSwitchPort := nbdb.LogicalSwitchPort{
+		Name:      gwSwitchPort,
+		Type:      "router",
+		Addresses: []string{"router"},
+		Options: map[string]string{
+			"router-port": gwRouterPort,
+		},
+	}
+	joinSwitchName := oc.GetNetworkScopedName(types.OVNJoinSwitch)
+	sw := nbdb.LogicalSwitch{Name: joinSwitchName}
+	err = libovsdbops.CreateOrUpdateLogicalSwitchPortsOnSwitch(oc.nbClient, &sw, &logicalSwitchPort)
+	if err != nil {
+		return fmt.Errorf("failed to create port %v on logical switch %q: %v", gwSwitchPort, joinSwitchName, err)
+	}
+
+	gwLRPNetworks := []string{}
+	for _, gwLRPIfAddr := range gwLRPIfAddrs {
+		gwLRPNetworks = append(gwLRPNetworks, gwLRPIfAddr.String())
+	}
+	logicalRouterPort := nbdb.LogicalRouterPort{
+		Name:     gwRouterPort,
+		MAC:      util.IPAddrToHWAddr(gwLRPIfAddrs[0].IP).String(),
+		Networks: gwLRPNetworks,
+	}
+	err = libovsdbops.CreateOrUpdateLogicalRouterPort(oc.nbClient, &logicalRouter,
+		&logicalRouterPort, nil, &logicalRouterPort.MAC, &logicalRouterPort.Networks)
+	if err != nil {
+		return fmt.Errorf("failed to create port %+v on router %+v: %v", logicalRouterPort, logicalRouter, err)
+	}
+
+	// route the traffic to the network back to the cluster router
+	for _, subnet := range oc.Subnets() {
+		drLRPIfAddr, err := util.MatchFirstIPNetFamily(utilnet.IsIPv6CIDR(subnet.CIDR), drLRPIfAddrs)
+		if err != nil {
+			return fmt.Errorf("failed to add a static route in GR %s with distributed router as the nexthop: %v",
+				gatewayRouter, err)
+		}
+		lrsr := nbdb.LogicalRouterStaticRoute{
+			IPPrefix: subnet.CIDR.String(),
+			Nexthop:  drLRPIfAddr.IP.String(),
+		}
+		p := func(item *nbdb.LogicalRouterStaticRoute) bool {
+			return item.IPPrefix == lrsr.IPPrefix && libovsdbops.PolicyEqualPredicate(item.Policy, lrsr.Policy)
+		}
+		err = libovsdbops.CreateOrReplaceLogicalRouterStaticRouteWithPredicate(oc.nbClient, gatewayRouter, &lrsr, p,
+			&lrsr.Nexthop)
+		if err != nil {
+			return fmt.Errorf("failed to add a static route %+v in GR %s with distributed router as the nexthop, err: %v",
+				lrsr, gatewayRouter, err)
+		}
+	}
+
+	// the localnet port of the external switch is scoped to the network so
+	// that ovn-controller creates a dedicated patch port on the external bridge
+	if err := oc.addExternalSwitch("",
+		oc.GetNetworkScopedName(l3GatewayConfig.InterfaceID),
+		node.Name,
+		gatewayRouter,
+		l3GatewayConfig.MACAddress.String(),
+		types.PhysicalNetworkName,
+		l3GatewayConfig.IPAddresses,
+		l3GatewayConfig.VLANID); err != nil {
+		return err
+	}
+
+	// add the default routes through the external bridge
+	externalRouterPort := types.GWRouterToExtSwitchPrefix + gatewayRouter
+	for _, nextHop := range l3GatewayConfig.NextHops {
+		allIPs := "0.0.0.0/0"
+		if utilnet.IsIPv6(nextHop) {
+			allIPs = "::/0"
+		}
+		lrsr := nbdb.LogicalRouterStaticRoute{
+			IPPrefix:   allIPs,
+			Nexthop:    nextHop.String(),
+			OutputPort: &externalRouterPort,
+		}
+		p := func(item *nbdb.LogicalRouterStaticRoute) bool {
+			return item.OutputPort != nil && *item.OutputPort == *lrsr.OutputPort && item.IPPrefix == lrsr.IPPrefix &&
+				libovsdbops.PolicyEqualPredicate(lrsr.Policy, item.Policy)
+		}
+		err := libovsdbops.CreateOrReplaceLogicalRouterStaticRouteWithPredicate(oc.nbClient, gatewayRouter, &lrsr,
+			p, &lrsr.Nexthop)
+		if err != nil {
+			return fmt.Errorf("error creating static route %+v in GR %s: %v", lrsr, gatewayRouter, err)
+		}
+	}
+
+	// send the traffic of the node subnets through the node gateway router
+	clusterRouter := oc.GetNetworkScopedName(types.OVNClusterRouter)
+	for _, hostSubnet := range hostSubnets {
+		gwLRPIfAddr, err := util.MatchFirstIPNetFamily(utilnet.IsIPv6CIDR(hostSubnet), gwLRPIfAddrs)
+		if err != nil {
+			return fmt.Errorf("failed to add source IP address based routes in distributed router %s: %v",
+				clusterRouter, err)
+		}
+		lrsr := nbdb.LogicalRouterStaticRoute{
+			Policy:   &nbdb.LogicalRouterStaticRoutePolicySrcIP,
+			IPPrefix: hostSubnet.String(),
+			Nexthop:  gwLRPIfAddr.IP.String(),
+		}
+		p := func(item *nbdb.LogicalRouterStaticRoute) bool {
+			return item.IPPrefix == lrsr.IPPrefix && libovsdbops.PolicyEqualPredicate(lrsr.Policy, item.Policy)
+		}
+		err = libovsdbops.CreateOrReplaceLogicalRouterStaticRouteWithPredicate(oc.nbClient, clusterRouter,
+			&lrsr, p, &lrsr.Nexthop)
+		if err != nil {
+			return fmt.Errorf("error creating static route %+v in %s: %v", lrsr, clusterRouter, err)
+		}
+	}
+
+	return oc.syncGatewaySNATs(&logicalRouter, l3GatewayConfig.IPAddresses)
+}
+
+// syncGatewaySNATs ensures the traffic of the network leaving through the
+// given gateway router is SNATed to the node IPs, removing stale SNATs
+func (oc *SecondaryLayer3NetworkController) syncGatewaySNATs(gatewayRouter *nbdb.LogicalRouter, nodeIPs []*net.IPNet) error {
+	nats := []*nbdb.NAT{}
+	desired := sets.New[string]()
+	for _, subnet := range oc.Subnets() {
+		nodeIP, err := util.MatchFirstIPNetFamily(utilnet.IsIPv6CIDR(subnet.CIDR), nodeIPs)
+		if err != nil {
+			return fmt.Errorf("failed to create SNAT rules for gateway router %s: %v", gatewayRouter.Name, err)
+		}
+		nat := libovsdbops.BuildSNAT(&nodeIP.IP, subnet.CIDR, "", nil)
+		nats = append(nats, nat)
+		desired.Insert(nat.ExternalIP + "/" + nat.LogicalIP)
+	}
+
+	existingNATs, err := libovsdbops.GetRouterNATs(oc.nbClient, gatewayRouter)
+	if err != nil && !errors.Is(err, libovsdbclient.ErrNotFound) {
+		return fmt.Errorf("unable to get NAT entries for router %s: %w", gatewayRouter.Name, err)
+	}
+	staleNATs := []*nbdb.NAT{}
+	for _, nat := range existingNATs {
+		if nat.Type == nbdb.NATTypeSNAT && !desired.Has(nat.ExternalIP+"/"+nat.LogicalIP) {
+			staleNATs = append(staleNATs, nat)
+		}
+	}
+	if len(staleNATs) > 0 {
+		if err := libovsdbops.DeleteNATs(oc.nbClient, gatewayRouter, staleNATs...); err != nil {
+			return fmt.Errorf("failed to delete stale SNAT rules from router %s: %v", gatewayRouter.Name, err)
+		}
+	}
+
+	if err := libovsdbops.CreateOrUpdateNATs(oc.nbClient, gatewayRouter, nats...); err != nil {
+		return fmt.Errorf("failed to update SNAT rules on router %s: %v", gatewayRouter.Name, err)
+	}
+	return nil
+}
+
+// gatewayCleanup removes the gateway router of the network for the given
+// node along with the logical entities connecting it
+func (oc *SecondaryLayer3NetworkController) gatewayCleanup(nodeName string) error {
+	gatewayRouter := oc.GetNetworkScopedName(types.GWRouterPrefix + nodeName)
+	clusterRouter := oc.GetNetworkScopedName(types.OVNClusterRouter)
+
+	gwIPAddrs, err := libovsdbutil.GetLRPAddrs(oc.nbClient, types.GWRouterToJoinSwitchPrefix+gatewayRouter)
+	if err != nil && !errors.Is(err, libovsdbclient.ErrNotFound) {
+		return err
+	}
+	nextHops := sets.New[string]()
+	for _, gwIPAddr := range gwIPAddrs {
+		nextHops.Insert(gwIPAddr.IP.String())
+	}
+	if nextHops.Len() > 0 {
+		p := func(item *nbdb.LogicalRouterStaticRoute) bool {
+			return nextHops.Has(item.Nexthop)
+		}
+		err = libovsdbops.DeleteLogicalRouterStaticRoutesWithPredicate(oc.nbClient, clusterRouter, p)
+		if err != nil && !errors.Is(err, libovsdbclient.ErrNotFound) {
+			return fmt.Errorf("failed to delete static routes to gateway router %s from router %s: %w",
+				gatewayRouter, clusterRouter, err)
+		}
+	}
+
+	joinSwitchName := oc.GetNetworkScopedName(types.OVNJoinSwitch)
+	portName := types.JoinSwitchToGWRouterPrefix + gatewayRouter
+	lsp := nbdb.LogicalSwitchPort{Name: portName}
+	sw := nbdb.LogicalSwitch{Name: joinSwitchName}
+	err = libovsdbops.DeleteLogicalSwitchPorts(oc.nbClient, &sw, &lsp)
+	if err != nil && !errors.Is(err, libovsdbclient.ErrNotFound) {
+		return fmt.Errorf("failed to delete logical switch port %s from switch %s: %w", portName, joinSwitchName, err)
+	}
+
+	logicalRouter := nbdb.LogicalRouter{Name: gatewayRouter}
+	err = libovsdbops.DeleteLogicalRouter(oc.nbClient, &logicalRouter)
+	if err != nil && !errors.Is(err, libovsdbclient.ErrNotFound) {
+		return fmt.Errorf("failed to delete gateway router %s: %w", gatewayRouter, err)
+	}
+
+	externalSwitch := oc.GetNetworkScopedName(externalSwitchName("", nodeName))
+	err = libovsdbops.DeleteLogicalSwitch(oc.nbClient, externalSwitch)
+	if err != nil && !errors.Is(err, libovsdbclient.ErrNotFound) {
+		return fmt.Errorf("failed to delete external switch %s: %w", externalSwitch, err)
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package ovn
+
+import (
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+
+	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+var _ = ginkgo.Describe("Secondary layer3 network gateway operations", func() {
+	const (
+		netName     = "isolatednet"
+		nadName     = "ns1/isolatednet"
+		networkCIDR = "10.128.0.0/16"
+	)
+
+	var (
+		fakeOvn *FakeOVN
+		oc      *SecondaryLayer3NetworkController
+	)
+
+	ginkgo.BeforeEach(func() {
+		// Restore global default values before each testcase
+		config.PrepareTestConfig()
+		config.OVNKubernetesFeature.EnableMultiNetwork = true
+
+		fakeOvn = NewFakeOVN(true)
+	})
+
+	ginkgo.AfterEach(func() {
+		fakeOvn.shutdown()
+	})
+
+	newController := func(subnets string) {
+		netInfo, err := util.NewNetInfo(&ovncnitypes.NetConf{
+			NetConf:       cnitypes.NetConf{Name: netName, Type: "ovn-k8s-cni-overlay"},
+			Topology:      types.Layer3Topology,
+			NADName:       nadName,
+			Subnets:       subnets,
+			EnableGateway: true,
+		})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		oc = NewSecondaryLayer3NetworkController(&fakeOvn.controller.CommonNetworkControllerInfo, netInfo)
+	}
+
+	ginkgo.It("creates and cleans up the gateway router of a node", func() {
+		clusterRouterName := netName + "_" + types.OVNClusterRouter
+		clusterRouter := &nbdb.LogicalRouter{
+			UUID: clusterRouterName + "-UUID",
+			Name: clusterRouterName,
+		}
+		fakeOvn.startWithDBSetup(libovsdbtest.TestSetup{
+			NBData: []libovsdbtest.TestData{clusterRouter},
+		})
+		newController(networkCIDR + "/24")
+
+		err := oc.createJoinSwitch(clusterRouter)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		node := newNode(nodeName, "192.168.126.12/24")
+		node.Annotations = map[string]string{
+			"k8s.ovn.org/l3-gateway-config":              `{"default":{"mode":"shared","interface-id":"breth0_test-node","mac-address":"7e:57:f8:f0:3c:49","ip-address":"192.168.126.12/24","next-hops":["192.168.126.1"]}}`,
+			"k8s.ovn.org/node-chassis-id":                "SYSTEM-ID",
+			"k8s.ovn.org/node-gateway-router-lrp-ifaddr": `{"ipv4":"100.64.0.2/16"}`,
+		}
+		hostSubnets := ovntest.MustParseIPNets("10.128.1.0/24")
+		err = oc.gatewayInit(node, hostSubnets)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		externalIDs := map[string]string{
+			types.NetworkExternalID:  netName,
+			types.TopologyExternalID: types.Layer3Topology,
+		}
+		gatewayRouterName := netName + "_" + types.GWRouterPrefix + nodeName
+		joinSwitchName := netName + "_" + types.OVNJoinSwitch
+		externalSwitchName := netName + "_" + types.ExternalSwitchPrefix + nodeName
+		externalRouterPort := types.GWRouterToExtSwitchPrefix + gatewayRouterName
+		clusterRouter.Ports = []string{"rtoj-cluster-router-UUID"}
+		clusterRouter.StaticRoutes = []string{"cluster-router-route-UUID"}
+
+		expectedData := []libovsdbtest.TestData{
+			clusterRouter,
+			&nbdb.LogicalRouterPort{
+				UUID:     "rtoj-cluster-router-UUID",
+				Name:     types.GWRouterToJoinSwitchPrefix + clusterRouterName,
+				MAC:      "0a:58:64:40:00:01",
+				Networks: []string{"100.64.0.1/16"},
+			},
+			&nbdb.LogicalRouterStaticRoute{
+				UUID:     "cluster-router-route-UUID",
+				Policy:   &nbdb.LogicalRouterStaticRoutePolicySrcIP,
+				IPPrefix: "10.128.1.0/24",
+				Nexthop:  "100.64.0.2",
+			},
+			&nbdb.LogicalSwitch{
+				UUID:        joinSwitchName + "-UUID",
+				Name:        joinSwitchName,
+				ExternalIDs: externalIDs,
+				Ports:       []string{"jtor-cluster-router-UUID", "jtor-gateway-router-UUID"},
+			},
+			&nbdb.LogicalSwitchPort{
+				UUID:      "jtor-cluster-router-UUID",
+				Name:      types.JoinSwitchToGWRouterPrefix + clusterRouterName,
+				Type:      "router",
+				Addresses: []string{"router"},
+				Options:   map[string]string{"router-port": types.GWRouterToJoinSwitchPrefix + clusterRouterName},
+			},
+			&nbdb.LogicalSwitchPort{
+				UUID:      "jtor-gateway-router-UUID",
+				Name:      types.JoinSwitchToGWRouterPrefix + gatewayRouterName,
+				Type:      "router",
+				Addresses: []string{"router"},
+				Options:   map[string]string{"router-port": types.GWRouterToJoinSwitchPrefix + gatewayRouterName},
+			},
+			&nbdb.LogicalRouter{
+				UUID: gatewayRouterName + "-UUID",
+				Name: gatewayRouterName,
+				Options: map[string]string{
+					"always_learn_from_arp_request": "false",
+					"dynamic_neigh_routers":         "true",
+					"chassis":                       "SYSTEM-ID",
+					"mac_binding_age_threshold":     types.GRMACBindingAgeThreshold,
+				},
+				ExternalIDs:  externalIDs,
+				Ports:        []string{"rtoj-gateway-router-UUID", "rtoe-gateway-router-UUID"},
+				StaticRoutes: []string{"gateway-router-network-route-UUID", "gateway-router-default-route-UUID"},
+				Nat:          []string{"gateway-router-snat-UUID"},
+			},
+			&nbdb.LogicalRouterPort{
+				UUID:     "rtoj-gateway-router-UUID",
+				Name:     types.GWRouterToJoinSwitchPrefix + gatewayRouterName,
+				MAC:      "0a:58:64:40:00:02",
+				Networks: []string{"100.64.0.2/16"},
+			},
+			&nbdb.LogicalRouterPort{
+				UUID:        "rtoe-gateway-router-UUID",
+				Name:        externalRouterPort,
+				MAC:         "7e:57:f8:f0:3c:49",
+				Networks:    []string{"192.168.126.12/24"},
+				ExternalIDs: map[string]string{"gateway-physical-ip": "yes"},
+			},
+			&nbdb.LogicalRouterStaticRoute{
+				UUID:     "gateway-router-network-route-UUID",
+				IPPrefix: networkCIDR,
+				Nexthop:  "100.64.0.1",
+			},
+			&nbdb.LogicalRouterStaticRoute{
+				UUID:       "gateway-router-default-route-UUID",
+				IPPrefix:   "0.0.0.0/0",
+				Nexthop:    "192.168.126.1",
+				OutputPort: &externalRouterPort,
+			},
+			&nbdb.NAT{
+				UUID:       "gateway-router-snat-UUID",
+				Type:       nbdb.NATTypeSNAT,
+				ExternalIP: "192.168.126.12",
+				LogicalIP:  networkCIDR,
+				Options:    map[string]string{"stateless": "false"},
+			},
+			&nbdb.LogicalSwitch{
+				UUID:        externalSwitchName + "-UUID",
+				Name:        externalSwitchName,
+				ExternalIDs: externalIDs,
+				Ports:       []string{"localnet-port-UUID", "etor-gateway-router-UUID"},
+			},
+			&nbdb.LogicalSwitchPort{
+				UUID:      "localnet-port-UUID",
+				Name:      netName + "_breth0_test-node",
+				Type:      "localnet",
+				Addresses: []string{"unknown"},
+				Options:   map[string]string{"network_name": types.PhysicalNetworkName},
+			},
+			&nbdb.LogicalSwitchPort{
+				UUID:      "etor-gateway-router-UUID",
+				Name:      types.EXTSwitchToGWRouterPrefix + gatewayRouterName,
+				Type:      "router",
+				Addresses: []string{"7e:57:f8:f0:3c:49"},
+				Options:   map[string]string{"router-port": externalRouterPort},
+			},
+		}
+		gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedData))
+
+		err = oc.gatewayCleanup(nodeName)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		clusterRouter.StaticRoutes = []string{}
+		expectedData = []libovsdbtest.TestData{
+			clusterRouter,
+			expectedData[1],
+			&nbdb.LogicalSwitch{
+				UUID:        joinSwitchName + "-UUID",
+				Name:        joinSwitchName,
+				ExternalIDs: externalIDs,
+				Ports:       []string{"jtor-cluster-router-UUID"},
+			},
+			expectedData[4],
+		}
+		gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedData))
+	})
+
+	ginkgo.It("refuses a network overlapping with the join subnet", func() {
+		clusterRouterName := netName + "_" + types.OVNClusterRouter
+		clusterRouter := &nbdb.LogicalRouter{
+			UUID: clusterRouterName + "-UUID",
+			Name: clusterRouterName,
+		}
+		fakeOvn.startWithDBSetup(libovsdbtest.TestSetup{
+			NBData: []libovsdbtest.TestData{clusterRouter},
+		})
+		newController("100.64.0.0/16/24")
+
+		err := oc.createJoinSwitch(clusterRouter)
+		gomega.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("overlaps with the join subnet")))
+	})
+})
@@ -103,9 +103,10 @@ func (h *secondaryLayer3NetworkControllerEventHandler) AddResource(obj interface
 				_, nodeSync := h.oc.addNodeFailed.Load(node.Name)
 				_, clusterRtrSync := h.oc.nodeClusterRouterPortFailed.Load(node.Name)
 				_, syncZoneIC := h.oc.syncZoneICFailed.Load(node.Name)
-				nodeParams = &nodeSyncs{syncNode: nodeSync, syncClusterRouterPort: clusterRtrSync, syncZoneIC: syncZoneIC}
+				_, gwSync := h.oc.gatewaysFailed.Load(node.Name)
+				nodeParams = &nodeSyncs{syncNode: nodeSync, syncClusterRouterPort: clusterRtrSync, syncGw: gwSync, syncZoneIC: syncZoneIC}
 			} else {
-				nodeParams = &nodeSyncs{syncNode: true, syncClusterRouterPort: true, syncZoneIC: config.OVNKubernetesFeature.EnableInterconnect}
+				nodeParams = &nodeSyncs{syncNode: true, syncClusterRouterPort: true, syncGw: h.oc.HasGateway(), syncZoneIC: config.OVNKubernetesFeature.EnableInterconnect}
 			}
 			if err := h.oc.addUpdateLocalNodeEvent(node, nodeParams); err != nil {
 				klog.Errorf("Node add failed for %s, will try again later: %v",
@@ -150,12 +151,14 @@ func (h *secondaryLayer3NetworkControllerEventHandler) UpdateResource(oldObj, ne
 				clusterRtrSync := failed || nodeChassisChanged(oldNode, newNode) || nodeSubnetChanged
 				_, syncZoneIC := h.oc.syncZoneICFailed.Load(newNode.Name)
 				syncZoneIC = syncZoneIC || zoneClusterChanged
-				nodeSyncsParam = &nodeSyncs{syncNode: nodeSync, syncClusterRouterPort: clusterRtrSync, syncZoneIC: syncZoneIC}
+				_, failed = h.oc.gatewaysFailed.Load(newNode.Name)
+				gwSync := h.oc.HasGateway() && (failed || gatewayChanged(oldNode, newNode) || nodeSubnetChanged)
+				nodeSyncsParam = &nodeSyncs{syncNode: nodeSync, syncClusterRouterPort: clusterRtrSync, syncGw: gwSync, syncZoneIC: syncZoneIC}
 			} else {
 				klog.Infof("Node %s moved from the remote zone %s to local zone %s.",
 					newNode.Name, util.GetNodeZone(oldNode), util.GetNodeZone(newNode))
 				// The node is now a local zone node. Trigger a full node sync.
-				nodeSyncsParam = &nodeSyncs{syncNode: true, syncClusterRouterPort: true, syncZoneIC: config.OVNKubernetesFeature.EnableInterconnect}
+				nodeSyncsParam = &nodeSyncs{syncNode: true, syncClusterRouterPort: true, syncGw: h.oc.HasGateway(), syncZoneIC: config.OVNKubernetesFeature.EnableInterconnect}
 			}
 
 			return h.oc.addUpdateLocalNodeEvent(newNode, nodeSyncsParam)
@@ -237,6 +240,7 @@ type SecondaryLayer3NetworkController struct {
 	// Node-specific syncMaps used by node event handler
 	addNodeFailed               sync.Map
 	nodeClusterRouterPortFailed sync.Map
+	gatewaysFailed              sync.Map
 	syncZoneICFailed            sync.Map
 }
 
@@ -275,6 +279,7 @@ func NewSecondaryLayer3NetworkController(cnci *CommonNetworkControllerInfo, netI
 		},
 		addNodeFailed:               sync.Map{},
 		nodeClusterRouterPortFailed: sync.Map{},
+		gatewaysFailed:              sync.Map{},
 		syncZoneICFailed:            sync.Map{},
 	}
 
@@ -361,6 +366,13 @@ func (oc *SecondaryLayer3NetworkController) Stop() {
 	}
 }
 
+// UpdateNetInfo applies in place the compatible changes of the given network
+// information. Additional cluster subnets are handed out to nodes by the
+// cluster manager and picked up through the node subnet annotations.
+func (oc *SecondaryLayer3NetworkController) UpdateNetInfo(netInfo util.BasicNetInfo) error {
+	return util.UpdateNetInfo(oc.NetInfo, netInfo)
+}
+
 // Cleanup cleans up logical entities for the given network, called from net-attach-def routine
 // could be called from a dummy Controller (only has CommonNetworkControllerInfo set)
 func (oc *SecondaryLayer3NetworkController) Cleanup(netName string) error {
@@ -394,6 +406,11 @@ func (oc *SecondaryLayer3NetworkController) Cleanup(netName string) error {
 		return err
 	}
 
+	ops, err = cleanupServiceLogicalEntities(oc.nbClient, ops, netName)
+	if err != nil {
+		return err
+	}
+
 	_, err = libovsdbops.TransactAndCheck(oc.nbClient, ops)
 	if err != nil {
 		return fmt.Errorf("failed to deleting routers/switches of network %s: %v", netName, err)
@@ -430,6 +447,10 @@ func (oc *SecondaryLayer3NetworkController) Run() error {
 		return err
 	}
 
+	if err := oc.startServiceController(); err != nil {
+		return err
+	}
+
 	klog.Infof("Completing all the Watchers for network %s took %v", oc.GetNetworkName(), time.Since(start))
 
 	return nil
@@ -449,8 +470,14 @@ func (oc *SecondaryLayer3NetworkController) WatchNodes() error {
 }
 
 func (oc *SecondaryLayer3NetworkController) Init(ctx context.Context) error {
-	_, err := oc.createOvnClusterRouter()
-	return err
+	clusterRouter, err := oc.createOvnClusterRouter()
+	if err != nil {
+		return err
+	}
+	if oc.HasGateway() {
+		return oc.createJoinSwitch(clusterRouter)
+	}
+	return nil
 }
 
 func (oc *SecondaryLayer3NetworkController) addUpdateLocalNodeEvent(node *kapi.Node, nSyncs *nodeSyncs) error {
@@ -473,6 +500,9 @@ func (oc *SecondaryLayer3NetworkController) addUpdateLocalNodeEvent(node *kapi.N
 		if hostSubnets, err = oc.addNode(node); err != nil {
 			oc.addNodeFailed.Store(node.Name, true)
 			oc.nodeClusterRouterPortFailed.Store(node.Name, true)
+			if oc.HasGateway() {
+				oc.gatewaysFailed.Store(node.Name, true)
+			}
 			oc.syncZoneICFailed.Store(node.Name, true)
 			err = fmt.Errorf("nodeAdd: error adding node %q for network %s: %w", node.Name, oc.GetNetworkName(), err)
 			oc.recordNodeErrorEvent(node, err)
@@ -490,6 +520,16 @@ func (oc *SecondaryLayer3NetworkController) addUpdateLocalNodeEvent(node *kapi.N
 		}
 	}
 
+	if nSyncs.syncGw {
+		if err = oc.gatewayInit(node, hostSubnets); err != nil {
+			errs = append(errs, fmt.Errorf("failed to init gateway for node %s on network %s: %w",
+				node.Name, oc.GetNetworkName(), err))
+			oc.gatewaysFailed.Store(node.Name, true)
+		} else {
+			oc.gatewaysFailed.Delete(node.Name)
+		}
+	}
+
 	// ensure pods that already exist on this node have their logical ports created
 	if nSyncs.syncNode { // do this only if it is a new node add
 		errors := oc.addAllPodsOnNode(node.Name)
@@ -562,6 +602,7 @@ func (oc *SecondaryLayer3NetworkController) deleteNodeEvent(node *kapi.Node) err
 	oc.lsManager.DeleteSwitch(oc.GetNetworkScopedName(node.Name))
 	oc.addNodeFailed.Delete(node.Name)
 	oc.nodeClusterRouterPortFailed.Delete(node.Name)
+	oc.gatewaysFailed.Delete(node.Name)
 	if config.OVNKubernetesFeature.EnableInterconnect {
 		if err := oc.zoneICHandler.DeleteNode(node); err != nil {
 			return err
@@ -572,6 +613,12 @@ func (oc *SecondaryLayer3NetworkController) deleteNodeEvent(node *kapi.Node) err
 }
 
 func (oc *SecondaryLayer3NetworkController) deleteNode(nodeName string) error {
+	if oc.HasGateway() {
+		if err := oc.gatewayCleanup(nodeName); err != nil {
+			return fmt.Errorf("error cleaning up gateway for node %s: %v", nodeName, err)
+		}
+	}
+
 	if err := oc.deleteNodeLogicalNetwork(nodeName); err != nil {
 		return fmt.Errorf("error deleting node %s logical network: %v", nodeName, err)
 	}
@@ -130,6 +130,17 @@ func (oc *SecondaryLocalnetNetworkController) Init() error {
 	return nil
 }
 
+// UpdateNetInfo applies in place the compatible changes of the given network
+// information, updating the subnets of the localnet switch
+func (oc *SecondaryLocalnetNetworkController) UpdateNetInfo(netInfo util.BasicNetInfo) error {
+	if err := util.UpdateNetInfo(oc.NetInfo, netInfo); err != nil {
+		return err
+	}
+
+	switchName := oc.GetNetworkScopedName(types.OVNLocalnetSwitch)
+	return oc.updateLogicalSwitch(switchName, oc.Subnets(), oc.ExcludeSubnets())
+}
+
 func (oc *SecondaryLocalnetNetworkController) Stop() {
 	klog.Infof("Stoping controller for secondary network %s", oc.GetNetworkName())
 	oc.BaseSecondaryLayer2NetworkController.stop()
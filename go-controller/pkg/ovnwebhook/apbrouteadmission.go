@@ -0,0 +1,120 @@
+package ovnwebhook
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	listers "k8s.io/client-go/listers/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	adminpolicybasedroutev1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1"
+	adminpolicybasedroutelisters "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/listers/adminpolicybasedroute/v1"
+)
+
+// AdminPolicyBasedExternalRouteAdmission validates AdminPolicyBasedExternalRoute objects. A namespace can only be
+// targeted by one policy: the controller refuses to apply a policy whose spec.from.namespaceSelector selects a
+// namespace already targeted by another policy. Next hops can be shared by policies targeting other namespaces.
+type AdminPolicyBasedExternalRouteAdmission struct {
+	apbLister       adminpolicybasedroutelisters.AdminPolicyBasedExternalRouteLister
+	namespaceLister listers.NamespaceLister
+}
+
+func NewAdminPolicyBasedExternalRouteAdmissionWebhook(apbLister adminpolicybasedroutelisters.AdminPolicyBasedExternalRouteLister,
+	namespaceLister listers.NamespaceLister) *AdminPolicyBasedExternalRouteAdmission {
+	return &AdminPolicyBasedExternalRouteAdmission{
+		apbLister:       apbLister,
+		namespaceLister: namespaceLister,
+	}
+}
+
+var _ admission.CustomValidator = &AdminPolicyBasedExternalRouteAdmission{}
+
+func (p AdminPolicyBasedExternalRouteAdmission) ValidateCreate(_ context.Context, obj runtime.Object) (warnings admission.Warnings, err error) {
+	return nil, p.validate(obj.(*adminpolicybasedroutev1.AdminPolicyBasedExternalRoute))
+}
+
+func (p AdminPolicyBasedExternalRouteAdmission) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (warnings admission.Warnings, err error) {
+	return nil, p.validate(newObj.(*adminpolicybasedroutev1.AdminPolicyBasedExternalRoute))
+}
+
+func (p AdminPolicyBasedExternalRouteAdmission) ValidateDelete(_ context.Context, _ runtime.Object) (warnings admission.Warnings, err error) {
+	// Ignore deletion, nothing to validate
+	return nil, nil
+}
+
+func (p AdminPolicyBasedExternalRouteAdmission) validate(policy *adminpolicybasedroutev1.AdminPolicyBasedExternalRoute) error {
+	var errs []error
+	targetSelector, err := metav1.LabelSelectorAsSelector(&policy.Spec.From.NamespaceSelector)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("invalid spec.from.namespaceSelector: %v", err))
+	}
+	hops := policy.Spec.NextHops
+	if len(hops.StaticHops) == 0 && len(hops.DynamicHops) == 0 {
+		errs = append(errs, fmt.Errorf("spec.nextHops must contain at least one static or dynamic hop"))
+	}
+	for i, hop := range hops.StaticHops {
+		if net.ParseIP(hop.IP) == nil {
+			errs = append(errs, fmt.Errorf("spec.nextHops.static[%d]: %q is not a valid IP address", i, hop.IP))
+		}
+	}
+	for i, hop := range hops.DynamicHops {
+		if _, err := metav1.LabelSelectorAsSelector(&hop.NamespaceSelector); err != nil {
+			errs = append(errs, fmt.Errorf("spec.nextHops.dynamic[%d]: invalid namespaceSelector: %v", i, err))
+		}
+		if _, err := metav1.LabelSelectorAsSelector(&hop.PodSelector); err != nil {
+			errs = append(errs, fmt.Errorf("spec.nextHops.dynamic[%d]: invalid podSelector: %v", i, err))
+		}
+	}
+	if targetSelector != nil {
+		if err := p.validateOtherPolicies(policy, targetSelector); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid AdminPolicyBasedExternalRoute %s: %v", policy.Name, kerrors.NewAggregate(errs))
+	}
+	return nil
+}
+
+// validateOtherPolicies checks that the namespaces targeted by the policy are not targeted by another policy
+func (p AdminPolicyBasedExternalRouteAdmission) validateOtherPolicies(policy *adminpolicybasedroutev1.AdminPolicyBasedExternalRoute,
+	targetSelector labels.Selector) error {
+	targetNamespaces, err := p.namespaceLister.List(targetSelector)
+	if err != nil {
+		return fmt.Errorf("failed to list the namespaces selected by spec.from.namespaceSelector: %v", err)
+	}
+	if len(targetNamespaces) == 0 {
+		return nil
+	}
+	policies, err := p.apbLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list AdminPolicyBasedExternalRoutes: %v", err)
+	}
+	var errs []error
+	for _, other := range policies {
+		if other.Name == policy.Name {
+			continue
+		}
+		otherSelector, err := metav1.LabelSelectorAsSelector(&other.Spec.From.NamespaceSelector)
+		if err != nil {
+			continue
+		}
+		var overlap []string
+		for _, namespace := range targetNamespaces {
+			if otherSelector.Matches(labels.Set(namespace.Labels)) {
+				overlap = append(overlap, namespace.Name)
+			}
+		}
+		if len(overlap) > 0 {
+			sort.Strings(overlap)
+			errs = append(errs, fmt.Errorf("namespaces %v are already targeted by policy %s", overlap, other.Name))
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
@@ -0,0 +1,114 @@
+package ovnwebhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	adminpolicybasedroutev1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1"
+	adminpolicybasedroutelisters "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/listers/adminpolicybasedroute/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+func newAdminPolicyBasedExternalRoute(name string, target map[string]string, hops adminpolicybasedroutev1.ExternalNextHops) *adminpolicybasedroutev1.AdminPolicyBasedExternalRoute {
+	return &adminpolicybasedroutev1.AdminPolicyBasedExternalRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: adminpolicybasedroutev1.AdminPolicyBasedExternalRouteSpec{
+			From: adminpolicybasedroutev1.ExternalNetworkSource{
+				NamespaceSelector: metav1.LabelSelector{MatchLabels: target},
+			},
+			NextHops: hops,
+		},
+	}
+}
+
+func TestAdminPolicyBasedExternalRouteAdmission_ValidateCreate(t *testing.T) {
+	gwSelector := metav1.LabelSelector{MatchLabels: map[string]string{"gw": "true"}}
+	existingHops := adminpolicybasedroutev1.ExternalNextHops{
+		StaticHops:  []*adminpolicybasedroutev1.StaticHop{{IP: "172.18.0.2"}, {IP: "fd00::2"}},
+		DynamicHops: []*adminpolicybasedroutev1.DynamicHop{{NamespaceSelector: gwSelector, PodSelector: gwSelector}},
+	}
+	existing := newAdminPolicyBasedExternalRoute("existing", map[string]string{"team": "a"}, existingHops)
+	namespaces := []*corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "ns1", Labels: map[string]string{"team": "a", "name": "ns1"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "ns2", Labels: map[string]string{"team": "a", "name": "ns2"}}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "ns3", Labels: map[string]string{"team": "b", "name": "ns3"}}},
+	}
+	tests := []struct {
+		name        string
+		obj         *adminpolicybasedroutev1.AdminPolicyBasedExternalRoute
+		expectedErr string
+	}{
+		{
+			name: "allow next hops used by a policy targeting other namespaces",
+			obj:  newAdminPolicyBasedExternalRoute("policy", map[string]string{"team": "b"}, existingHops),
+		},
+		{
+			name: "allow duplicated static and dynamic next hops",
+			obj: newAdminPolicyBasedExternalRoute("policy", map[string]string{"team": "b"}, adminpolicybasedroutev1.ExternalNextHops{
+				StaticHops: []*adminpolicybasedroutev1.StaticHop{{IP: "fd00::3"}, {IP: "fd00:0::3"}},
+				DynamicHops: []*adminpolicybasedroutev1.DynamicHop{
+					{NamespaceSelector: gwSelector, PodSelector: gwSelector},
+					{NamespaceSelector: gwSelector, PodSelector: gwSelector},
+				},
+			}),
+		},
+		{
+			name: "allow a policy not targeting any namespace yet",
+			obj:  newAdminPolicyBasedExternalRoute("policy", map[string]string{"team": "c"}, existingHops),
+		},
+		{
+			name: "allow updating the same policy",
+			obj: newAdminPolicyBasedExternalRoute("existing", map[string]string{"team": "a"}, adminpolicybasedroutev1.ExternalNextHops{
+				StaticHops: []*adminpolicybasedroutev1.StaticHop{{IP: "172.18.0.2"}, {IP: "172.18.0.4"}},
+			}),
+		},
+		{
+			name:        "deny no next hop",
+			obj:         newAdminPolicyBasedExternalRoute("policy", map[string]string{"team": "b"}, adminpolicybasedroutev1.ExternalNextHops{}),
+			expectedErr: "spec.nextHops must contain at least one static or dynamic hop",
+		},
+		{
+			name: "deny invalid static next hop",
+			obj: newAdminPolicyBasedExternalRoute("policy", map[string]string{"team": "b"}, adminpolicybasedroutev1.ExternalNextHops{
+				StaticHops: []*adminpolicybasedroutev1.StaticHop{{IP: "172.18.0.300"}},
+			}),
+			expectedErr: `spec.nextHops.static[0]: "172.18.0.300" is not a valid IP address`,
+		},
+		{
+			name: "deny a namespace targeted by another policy",
+			obj: newAdminPolicyBasedExternalRoute("policy", map[string]string{"name": "ns2"}, adminpolicybasedroutev1.ExternalNextHops{
+				StaticHops: []*adminpolicybasedroutev1.StaticHop{{IP: "172.18.0.3"}},
+			}),
+			expectedErr: "namespaces [ns2] are already targeted by policy existing",
+		},
+		{
+			name:        "deny namespaces targeted by another policy with the same next hops",
+			obj:         newAdminPolicyBasedExternalRoute("policy", nil, existingHops),
+			expectedErr: "namespaces [ns1 ns2] are already targeted by policy existing",
+		},
+	}
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	if err := indexer.Add(existing); err != nil {
+		t.Fatalf("failed to add policy %s: %v", existing.Name, err)
+	}
+	namespaceIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, namespace := range namespaces {
+		if err := namespaceIndexer.Add(namespace); err != nil {
+			t.Fatalf("failed to add namespace %s: %v", namespace.Name, err)
+		}
+	}
+	adm := NewAdminPolicyBasedExternalRouteAdmissionWebhook(adminpolicybasedroutelisters.NewAdminPolicyBasedExternalRouteLister(indexer),
+		listers.NewNamespaceLister(namespaceIndexer))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := adm.ValidateCreate(context.TODO(), tt.obj)
+			if tt.expectedErr == "" && err != nil || tt.expectedErr != "" && (err == nil || !strings.Contains(err.Error(), tt.expectedErr)) {
+				t.Errorf("ValidateCreate() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package ovnwebhook
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	egressfirewallv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// EgressFirewallAdmission validates EgressFirewall objects
+type EgressFirewallAdmission struct{}
+
+func NewEgressFirewallAdmissionWebhook() *EgressFirewallAdmission {
+	return &EgressFirewallAdmission{}
+}
+
+var _ admission.CustomValidator = &EgressFirewallAdmission{}
+
+func (p EgressFirewallAdmission) ValidateCreate(_ context.Context, obj runtime.Object) (warnings admission.Warnings, err error) {
+	return nil, validateEgressFirewall(obj.(*egressfirewallv1.EgressFirewall))
+}
+
+func (p EgressFirewallAdmission) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (warnings admission.Warnings, err error) {
+	return nil, validateEgressFirewall(newObj.(*egressfirewallv1.EgressFirewall))
+}
+
+func (p EgressFirewallAdmission) ValidateDelete(_ context.Context, _ runtime.Object) (warnings admission.Warnings, err error) {
+	// Ignore deletion, nothing to validate
+	return nil, nil
+}
+
+func validateEgressFirewall(ef *egressfirewallv1.EgressFirewall) error {
+	var errs []error
+	for i, rule := range ef.Spec.Egress {
+		if err := validateEgressFirewallRule(rule); err != nil {
+			errs = append(errs, fmt.Errorf("spec.egress[%d]: %v", i, err))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid EgressFirewall %s/%s: %v", ef.Namespace, ef.Name, kerrors.NewAggregate(errs))
+	}
+	return nil
+}
+
+func validateEgressFirewallRule(rule egressfirewallv1.EgressFirewallRule) error {
+	if rule.Type != egressfirewallv1.EgressFirewallRuleAllow && rule.Type != egressfirewallv1.EgressFirewallRuleDeny {
+		return fmt.Errorf("type must be one of %s or %s", egressfirewallv1.EgressFirewallRuleAllow, egressfirewallv1.EgressFirewallRuleDeny)
+	}
+
+	to := rule.To
+	destinations := 0
+	for _, set := range []bool{to.CIDRSelector != "", to.DNSName != "", to.NodeSelector != nil} {
+		if set {
+			destinations++
+		}
+	}
+	if destinations != 1 {
+		return fmt.Errorf("exactly one of to.cidrSelector, to.dnsName or to.nodeSelector must be set")
+	}
+	switch {
+	case to.CIDRSelector != "":
+		if _, _, err := net.ParseCIDR(to.CIDRSelector); err != nil {
+			return fmt.Errorf("invalid to.cidrSelector %q: %v", to.CIDRSelector, err)
+		}
+	case to.DNSName != "":
+		if err := validateDNSName(to.DNSName); err != nil {
+			return fmt.Errorf("invalid to.dnsName %q: %v", to.DNSName, err)
+		}
+	default:
+		if _, err := metav1.LabelSelectorAsSelector(to.NodeSelector); err != nil {
+			return fmt.Errorf("invalid to.nodeSelector: %v", err)
+		}
+	}
+
+	for _, port := range rule.Ports {
+		switch strings.ToUpper(port.Protocol) {
+		case "TCP", "UDP", "SCTP":
+		default:
+			return fmt.Errorf("invalid protocol %q: expect one of TCP, UDP or SCTP", port.Protocol)
+		}
+		if port.Port < 0 || port.Port > 65535 {
+			return fmt.Errorf("invalid %s port %d", port.Protocol, port.Port)
+		}
+		if port.EndPort == nil {
+			continue
+		}
+		if port.Port == 0 {
+			return fmt.Errorf("invalid %s port range: endPort %d is set without port", port.Protocol, *port.EndPort)
+		}
+		if *port.EndPort < port.Port || *port.EndPort > 65535 {
+			return fmt.Errorf("invalid %s port range: %d-%d", port.Protocol, port.Port, *port.EndPort)
+		}
+	}
+	return nil
+}
+
+// validateDNSName checks that dnsName is a fully qualified or relative DNS name, optionally starting
+// with a "*." wildcard label
+func validateDNSName(dnsName string) error {
+	name := strings.TrimSuffix(dnsName, ".")
+	if util.IsWildcardDNSName(name) {
+		name = strings.TrimPrefix(name, "*.")
+	}
+	if name == "" {
+		return fmt.Errorf("name is empty")
+	}
+	if len(name) > validation.DNS1123SubdomainMaxLength {
+		return fmt.Errorf("name is longer than %d characters", validation.DNS1123SubdomainMaxLength)
+	}
+	for _, label := range strings.Split(strings.ToLower(name), ".") {
+		if errs := validation.IsDNS1123Label(label); len(errs) > 0 {
+			return fmt.Errorf("label %q is invalid: %s", label, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package ovnwebhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	egressfirewallv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEgressFirewallAdmission_ValidateCreate(t *testing.T) {
+	endPort := int32(8080)
+	lowEndPort := int32(70)
+	tests := []struct {
+		name        string
+		rules       []egressfirewallv1.EgressFirewallRule
+		expectedErr string
+	}{
+		{
+			name: "allow valid rules",
+			rules: []egressfirewallv1.EgressFirewallRule{
+				{
+					Type:  egressfirewallv1.EgressFirewallRuleAllow,
+					To:    egressfirewallv1.EgressFirewallDestination{CIDRSelector: "1.2.3.0/24"},
+					Ports: []egressfirewallv1.EgressFirewallPort{{Protocol: "tcp", Port: 80, EndPort: &endPort}},
+				},
+				{
+					Type: egressfirewallv1.EgressFirewallRuleAllow,
+					To:   egressfirewallv1.EgressFirewallDestination{DNSName: "*.example.com."},
+				},
+				{
+					Type: egressfirewallv1.EgressFirewallRuleAllow,
+					To:   egressfirewallv1.EgressFirewallDestination{NodeSelector: &metav1.LabelSelector{}},
+				},
+				{
+					Type: egressfirewallv1.EgressFirewallRuleDeny,
+					To:   egressfirewallv1.EgressFirewallDestination{CIDRSelector: "0.0.0.0/0"},
+				},
+			},
+		},
+		{
+			name: "deny invalid type",
+			rules: []egressfirewallv1.EgressFirewallRule{
+				{Type: "Drop", To: egressfirewallv1.EgressFirewallDestination{CIDRSelector: "1.2.3.0/24"}},
+			},
+			expectedErr: "spec.egress[0]: type must be one of Allow or Deny",
+		},
+		{
+			name: "deny several destinations",
+			rules: []egressfirewallv1.EgressFirewallRule{
+				{
+					Type: egressfirewallv1.EgressFirewallRuleAllow,
+					To:   egressfirewallv1.EgressFirewallDestination{CIDRSelector: "1.2.3.0/24", DNSName: "example.com"},
+				},
+			},
+			expectedErr: "spec.egress[0]: exactly one of to.cidrSelector, to.dnsName or to.nodeSelector must be set",
+		},
+		{
+			name: "deny invalid CIDR",
+			rules: []egressfirewallv1.EgressFirewallRule{
+				{Type: egressfirewallv1.EgressFirewallRuleAllow, To: egressfirewallv1.EgressFirewallDestination{CIDRSelector: "1.2.3.4"}},
+			},
+			expectedErr: `spec.egress[0]: invalid to.cidrSelector "1.2.3.4"`,
+		},
+		{
+			name: "deny invalid DNS name",
+			rules: []egressfirewallv1.EgressFirewallRule{
+				{Type: egressfirewallv1.EgressFirewallRuleAllow, To: egressfirewallv1.EgressFirewallDestination{DNSName: "www.example.com"}},
+				{Type: egressfirewallv1.EgressFirewallRuleAllow, To: egressfirewallv1.EgressFirewallDestination{DNSName: "foo..example.com"}},
+			},
+			expectedErr: `spec.egress[1]: invalid to.dnsName "foo..example.com": label "" is invalid`,
+		},
+		{
+			name: "deny invalid protocol",
+			rules: []egressfirewallv1.EgressFirewallRule{
+				{
+					Type:  egressfirewallv1.EgressFirewallRuleAllow,
+					To:    egressfirewallv1.EgressFirewallDestination{CIDRSelector: "1.2.3.0/24"},
+					Ports: []egressfirewallv1.EgressFirewallPort{{Protocol: "ICMP"}},
+				},
+			},
+			expectedErr: `spec.egress[0]: invalid protocol "ICMP"`,
+		},
+		{
+			name: "deny invalid port range",
+			rules: []egressfirewallv1.EgressFirewallRule{
+				{
+					Type:  egressfirewallv1.EgressFirewallRuleAllow,
+					To:    egressfirewallv1.EgressFirewallDestination{CIDRSelector: "1.2.3.0/24"},
+					Ports: []egressfirewallv1.EgressFirewallPort{{Protocol: "UDP", Port: 80, EndPort: &lowEndPort}},
+				},
+			},
+			expectedErr: "spec.egress[0]: invalid UDP port range: 80-70",
+		},
+	}
+	adm := NewEgressFirewallAdmissionWebhook()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ef := &egressfirewallv1.EgressFirewall{
+				ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "ns"},
+				Spec:       egressfirewallv1.EgressFirewallSpec{Egress: tt.rules},
+			}
+			_, err := adm.ValidateCreate(context.TODO(), ef)
+			if tt.expectedErr == "" && err != nil || tt.expectedErr != "" && (err == nil || !strings.Contains(err.Error(), tt.expectedErr)) {
+				t.Errorf("ValidateCreate() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package ovnwebhook
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	listers "k8s.io/client-go/listers/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	clusternetworklister "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1/apis/listers/clusternetwork/v1"
+	egressipv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+	egressiplisters "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/listers/egressip/v1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// EgressIPAdmission validates EgressIP objects. Egress IPs must be valid IP addresses that are not used by another
+// EgressIP, by a node or by the cluster, service or node subnets.
+type EgressIPAdmission struct {
+	eipLister            egressiplisters.EgressIPLister
+	nodeLister           listers.NodeLister
+	clusterNetworkLister clusternetworklister.ClusterNetworkLister
+	clusterSubnets       []*net.IPNet
+	serviceSubnets       []*net.IPNet
+	extraAllowedUsers    sets.Set[string]
+}
+
+// NewEgressIPAdmissionWebhook returns an EgressIP validator. The cluster subnets added at runtime through
+// ClusterNetwork objects are validated against as well when clusterNetworkLister is not nil. Updates done by
+// extraAllowedUsers are not validated so that ovnkube components are never blocked from updating EgressIPs.
+func NewEgressIPAdmissionWebhook(eipLister egressiplisters.EgressIPLister, nodeLister listers.NodeLister,
+	clusterNetworkLister clusternetworklister.ClusterNetworkLister, clusterSubnets, serviceSubnets []*net.IPNet,
+	extraAllowedUsers ...string) *EgressIPAdmission {
+	return &EgressIPAdmission{
+		eipLister:            eipLister,
+		nodeLister:           nodeLister,
+		clusterNetworkLister: clusterNetworkLister,
+		clusterSubnets:       clusterSubnets,
+		serviceSubnets:       serviceSubnets,
+		extraAllowedUsers:    sets.New[string](extraAllowedUsers...),
+	}
+}
+
+var _ admission.CustomValidator = &EgressIPAdmission{}
+
+func (p EgressIPAdmission) ValidateCreate(_ context.Context, obj runtime.Object) (warnings admission.Warnings, err error) {
+	return p.validate(obj.(*egressipv1.EgressIP), nil)
+}
+
+// ValidateUpdate only validates the egress IPs added by the update so that EgressIPs that became invalid
+// after they were created, for example because a node now uses one of their IPs, can still be updated.
+func (p EgressIPAdmission) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (warnings admission.Warnings, err error) {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if p.extraAllowedUsers.Has(req.UserInfo.Username) {
+		return nil, nil
+	}
+	oldEIP := oldObj.(*egressipv1.EgressIP)
+	newEIP := newObj.(*egressipv1.EgressIP)
+	if equality.Semantic.DeepEqual(oldEIP.Spec, newEIP.Spec) {
+		return nil, nil
+	}
+	return p.validate(newEIP, oldEIP.Spec.EgressIPs)
+}
+
+func (p EgressIPAdmission) ValidateDelete(_ context.Context, _ runtime.Object) (warnings admission.Warnings, err error) {
+	// Ignore deletion, nothing to validate
+	return nil, nil
+}
+
+// validate validates the EgressIP. The collision checks are skipped for the egress IPs in existingIPs.
+func (p EgressIPAdmission) validate(eip *egressipv1.EgressIP, existingIPs []string) (admission.Warnings, error) {
+	var errs []error
+	if len(eip.Spec.EgressIPs) == 0 {
+		errs = append(errs, fmt.Errorf("spec.egressIPs must contain at least one IP address"))
+	}
+	if _, err := metav1.LabelSelectorAsSelector(&eip.Spec.NamespaceSelector); err != nil {
+		errs = append(errs, fmt.Errorf("invalid spec.namespaceSelector: %v", err))
+	}
+	if _, err := metav1.LabelSelectorAsSelector(&eip.Spec.PodSelector); err != nil {
+		errs = append(errs, fmt.Errorf("invalid spec.podSelector: %v", err))
+	}
+
+	existing := sets.New[string]()
+	for _, rawIP := range existingIPs {
+		if ip := net.ParseIP(rawIP); ip != nil {
+			rawIP = ip.String()
+		}
+		existing.Insert(rawIP)
+	}
+
+	// egressIPs holds the normalized egress IPs of the object so that different notations of the same
+	// IPv6 address are considered equal, addedIPs holds the ones that are not in existingIPs
+	egressIPs := map[string]net.IP{}
+	addedIPs := map[string]net.IP{}
+	for _, rawIP := range eip.Spec.EgressIPs {
+		ip := net.ParseIP(rawIP)
+		if ip == nil {
+			if !existing.Has(rawIP) {
+				errs = append(errs, fmt.Errorf("egress IP %q is not a valid IP address", rawIP))
+			}
+			continue
+		}
+		if _, found := egressIPs[ip.String()]; found {
+			errs = append(errs, fmt.Errorf("egress IP %s is listed more than once", rawIP))
+			continue
+		}
+		egressIPs[ip.String()] = ip
+		if existing.Has(ip.String()) {
+			continue
+		}
+		addedIPs[ip.String()] = ip
+		if err := p.validateEgressIP(ip); err != nil {
+			errs = append(errs, fmt.Errorf("egress IP %s %v", rawIP, err))
+		}
+	}
+	if len(addedIPs) > 0 {
+		if err := p.validateNodes(addedIPs); err != nil {
+			errs = append(errs, err)
+		}
+		if err := p.validateOtherEgressIPs(eip.Name, addedIPs); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid EgressIP %s: %v", eip.Name, kerrors.NewAggregate(errs))
+	}
+
+	var warnings admission.Warnings
+	if eip.Spec.LoadBalancing != "" && eip.Spec.LoadBalancing != egressipv1.EgressIPLoadBalancingPerConnection && len(egressIPs) == 1 {
+		warnings = append(warnings, fmt.Sprintf("spec.loadBalancing %s has no effect with a single egress IP", eip.Spec.LoadBalancing))
+	}
+	return warnings, nil
+}
+
+// validateEgressIP checks the egress IP against the cluster and service subnets
+func (p EgressIPAdmission) validateEgressIP(ip net.IP) error {
+	clusterSubnets, err := p.getClusterSubnets()
+	if err != nil {
+		return err
+	}
+	for _, subnet := range clusterSubnets {
+		if subnet.Contains(ip) {
+			return fmt.Errorf("collides with the cluster subnet %s", subnet)
+		}
+	}
+	for _, subnet := range p.serviceSubnets {
+		if subnet.Contains(ip) {
+			return fmt.Errorf("collides with the service subnet %s", subnet)
+		}
+	}
+	return nil
+}
+
+// getClusterSubnets returns the configured cluster subnets along with the ones of the ClusterNetwork objects
+func (p EgressIPAdmission) getClusterSubnets() ([]*net.IPNet, error) {
+	if p.clusterNetworkLister == nil {
+		return p.clusterSubnets, nil
+	}
+	clusterNetworks, err := p.clusterNetworkLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list ClusterNetworks: %v", err)
+	}
+	clusterSubnets := append([]*net.IPNet{}, p.clusterSubnets...)
+	for _, clusterNetwork := range clusterNetworks {
+		// invalid ClusterNetworks are not used by ovnkube-cluster-manager either
+		entries, err := util.ParseClusterNetworkSubnets(clusterNetwork)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			clusterSubnets = append(clusterSubnets, entry.CIDR)
+		}
+	}
+	return clusterSubnets, nil
+}
+
+// validateNodes checks the egress IPs against the addresses and the subnets of the nodes
+func (p EgressIPAdmission) validateNodes(egressIPs map[string]net.IP) error {
+	nodes, err := p.nodeLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list nodes: %v", err)
+	}
+	var errs []error
+	for _, node := range nodes {
+		for _, address := range node.Status.Addresses {
+			if address.Type != corev1.NodeInternalIP && address.Type != corev1.NodeExternalIP {
+				continue
+			}
+			nodeIP := net.ParseIP(address.Address)
+			if nodeIP == nil {
+				continue
+			}
+			if _, found := egressIPs[nodeIP.String()]; found {
+				errs = append(errs, fmt.Errorf("egress IP %s is the IP address of node %s", nodeIP, node.Name))
+			}
+		}
+		// nodes that were not assigned subnets yet are skipped
+		subnets, err := util.ParseNodeHostSubnetAnnotation(node, types.DefaultNetworkName)
+		if err != nil {
+			continue
+		}
+		for _, subnet := range subnets {
+			for _, ip := range egressIPs {
+				if subnet.Contains(ip) {
+					errs = append(errs, fmt.Errorf("egress IP %s collides with the subnet %s of node %s", ip, subnet, node.Name))
+				}
+			}
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// validateOtherEgressIPs checks that the egress IPs are not used by another EgressIP
+func (p EgressIPAdmission) validateOtherEgressIPs(name string, egressIPs map[string]net.IP) error {
+	eips, err := p.eipLister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list EgressIPs: %v", err)
+	}
+	var errs []error
+	for _, other := range eips {
+		if other.Name == name {
+			continue
+		}
+		for _, rawIP := range other.Spec.EgressIPs {
+			ip := net.ParseIP(rawIP)
+			if ip == nil {
+				continue
+			}
+			if _, found := egressIPs[ip.String()]; found {
+				errs = append(errs, fmt.Errorf("egress IP %s is already used by EgressIP %s", ip, other.Name))
+			}
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
@@ -0,0 +1,258 @@
+package ovnwebhook
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	clusternetworkv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1"
+	clusternetworklister "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1/apis/listers/clusternetwork/v1"
+	egressipv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+	egressiplisters "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/listers/egressip/v1"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const egressIPAllowedUser = "system:serviceaccount:ovn-kubernetes:ovnkube-cluster-manager"
+
+func newEgressIPAdmissionForTest(t *testing.T, eips []*egressipv1.EgressIP, nodes []*corev1.Node) *EgressIPAdmission {
+	eipIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, eip := range eips {
+		if err := eipIndexer.Add(eip); err != nil {
+			t.Fatalf("failed to add EgressIP %s: %v", eip.Name, err)
+		}
+	}
+	nodeIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, node := range nodes {
+		if err := nodeIndexer.Add(node); err != nil {
+			t.Fatalf("failed to add node %s: %v", node.Name, err)
+		}
+	}
+	clusterNetworkIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	for _, clusterNetwork := range []*clusternetworkv1.ClusterNetwork{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "expansion"},
+			Spec: clusternetworkv1.ClusterNetworkSpec{
+				ClusterSubnets: []clusternetworkv1.ClusterSubnet{{CIDR: "10.132.0.0/14", HostSubnetLength: 23}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "invalid"},
+			Spec: clusternetworkv1.ClusterNetworkSpec{
+				ClusterSubnets: []clusternetworkv1.ClusterSubnet{{CIDR: "10.136.0.0"}},
+			},
+		},
+	} {
+		if err := clusterNetworkIndexer.Add(clusterNetwork); err != nil {
+			t.Fatalf("failed to add ClusterNetwork %s: %v", clusterNetwork.Name, err)
+		}
+	}
+	_, clusterSubnet, _ := net.ParseCIDR("10.128.0.0/14")
+	_, serviceSubnet, _ := net.ParseCIDR("172.30.0.0/16")
+	return NewEgressIPAdmissionWebhook(egressiplisters.NewEgressIPLister(eipIndexer), listers.NewNodeLister(nodeIndexer),
+		clusternetworklister.NewClusterNetworkLister(clusterNetworkIndexer), []*net.IPNet{clusterSubnet},
+		[]*net.IPNet{serviceSubnet}, egressIPAllowedUser)
+}
+
+func newEgressIP(name string, ips ...string) *egressipv1.EgressIP {
+	return &egressipv1.EgressIP{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       egressipv1.EgressIPSpec{EgressIPs: ips},
+	}
+}
+
+func TestEgressIPAdmission_ValidateCreate(t *testing.T) {
+	existing := []*egressipv1.EgressIP{newEgressIP("existing", "192.168.126.10", "fc00::10")}
+	nodes := []*corev1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        nodeName,
+				Annotations: map[string]string{"k8s.ovn.org/node-subnets": `{"default":["10.128.1.0/24"]}`},
+			},
+			Status: corev1.NodeStatus{
+				Addresses: []corev1.NodeAddress{
+					{Type: corev1.NodeInternalIP, Address: "192.168.126.2"},
+					{Type: corev1.NodeHostName, Address: "192.168.126.3"},
+				},
+			},
+		},
+	}
+	withLoadBalancing := newEgressIP("eip", "192.168.126.20")
+	withLoadBalancing.Spec.LoadBalancing = egressipv1.EgressIPLoadBalancingSourceIP
+	withInvalidSelector := newEgressIP("eip", "192.168.126.20")
+	withInvalidSelector.Spec.PodSelector = metav1.LabelSelector{
+		MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "app", Operator: "bogus"}},
+	}
+	tests := []struct {
+		name             string
+		obj              *egressipv1.EgressIP
+		expectedWarnings admission.Warnings
+		expectedErr      string
+	}{
+		{
+			name: "allow valid egress IPs",
+			obj:  newEgressIP("eip", "192.168.126.20", "fc00::20"),
+		},
+		{
+			name: "allow updating the egress IPs of the same object",
+			obj:  newEgressIP("existing", "192.168.126.10", "192.168.126.11"),
+		},
+		{
+			name:        "deny no egress IPs",
+			obj:         newEgressIP("eip"),
+			expectedErr: "spec.egressIPs must contain at least one IP address",
+		},
+		{
+			name:        "deny invalid selector",
+			obj:         withInvalidSelector,
+			expectedErr: "invalid spec.podSelector",
+		},
+		{
+			name:        "deny invalid IP address",
+			obj:         newEgressIP("eip", "192.168.126.300"),
+			expectedErr: `egress IP "192.168.126.300" is not a valid IP address`,
+		},
+		{
+			name:        "deny duplicated IP address in a different notation",
+			obj:         newEgressIP("eip", "fc00::20", "fc00:0::20"),
+			expectedErr: "egress IP fc00:0::20 is listed more than once",
+		},
+		{
+			name:        "deny IP address in the cluster subnet",
+			obj:         newEgressIP("eip", "10.129.0.5"),
+			expectedErr: "egress IP 10.129.0.5 collides with the cluster subnet 10.128.0.0/14",
+		},
+		{
+			name:        "deny IP address in the cluster subnet of a ClusterNetwork",
+			obj:         newEgressIP("eip", "10.133.0.5"),
+			expectedErr: "egress IP 10.133.0.5 collides with the cluster subnet 10.132.0.0/14",
+		},
+		{
+			name: "allow IP address in an invalid ClusterNetwork",
+			obj:  newEgressIP("eip", "10.136.0.5"),
+		},
+		{
+			name:        "deny IP address in the service subnet",
+			obj:         newEgressIP("eip", "172.30.0.5"),
+			expectedErr: "egress IP 172.30.0.5 collides with the service subnet 172.30.0.0/16",
+		},
+		{
+			name:        "deny IP address of a node",
+			obj:         newEgressIP("eip", "192.168.126.2"),
+			expectedErr: "egress IP 192.168.126.2 is the IP address of node " + nodeName,
+		},
+		{
+			name:        "deny IP address in a node subnet",
+			obj:         newEgressIP("eip", "10.128.1.5"),
+			expectedErr: "egress IP 10.128.1.5 collides with the subnet 10.128.1.0/24 of node " + nodeName,
+		},
+		{
+			name: "allow IP address of a node hostname",
+			obj:  newEgressIP("eip", "192.168.126.3"),
+		},
+		{
+			name:        "deny IP address used by another EgressIP",
+			obj:         newEgressIP("eip", "192.168.126.20", "fc00:0:0::10"),
+			expectedErr: "egress IP fc00::10 is already used by EgressIP existing",
+		},
+		{
+			name:             "warn about load balancing with a single egress IP",
+			obj:              withLoadBalancing,
+			expectedWarnings: admission.Warnings{"spec.loadBalancing SourceIP has no effect with a single egress IP"},
+		},
+	}
+	adm := newEgressIPAdmissionForTest(t, existing, nodes)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := adm.ValidateCreate(context.TODO(), tt.obj)
+			if tt.expectedErr == "" && err != nil || tt.expectedErr != "" && (err == nil || !strings.Contains(err.Error(), tt.expectedErr)) {
+				t.Errorf("ValidateCreate() error = %v, expectedErr %v", err, tt.expectedErr)
+				return
+			}
+			if !reflect.DeepEqual(warnings, tt.expectedWarnings) {
+				t.Errorf("ValidateCreate() warnings = %v, expectedWarnings %v", warnings, tt.expectedWarnings)
+			}
+		})
+	}
+}
+
+func TestEgressIPAdmission_ValidateUpdate(t *testing.T) {
+	existing := []*egressipv1.EgressIP{newEgressIP("existing", "192.168.126.10")}
+	withStatus := newEgressIP("eip", "10.129.0.5")
+	withStatus.Status.Items = []egressipv1.EgressIPStatusItem{{Node: nodeName, EgressIP: "10.129.0.5"}}
+	userCtx := admission.NewContextWithRequest(context.TODO(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{Username: "system:admin"}},
+	})
+	allowedUserCtx := admission.NewContextWithRequest(context.TODO(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{UserInfo: authenticationv1.UserInfo{Username: egressIPAllowedUser}},
+	})
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		oldObj      *egressipv1.EgressIP
+		newObj      *egressipv1.EgressIP
+		expectedErr string
+	}{
+		{
+			name:   "allow status only update of a colliding EgressIP",
+			ctx:    userCtx,
+			oldObj: newEgressIP("eip", "10.129.0.5"),
+			newObj: withStatus,
+		},
+		{
+			name:   "allow keeping a colliding egress IP",
+			ctx:    userCtx,
+			oldObj: newEgressIP("eip", "10.129.0.5", "192.168.126.10"),
+			newObj: newEgressIP("eip", "10.129.0.5"),
+		},
+		{
+			name:   "allow keeping an invalid egress IP",
+			ctx:    userCtx,
+			oldObj: newEgressIP("eip", "192.168.126.300"),
+			newObj: newEgressIP("eip", "192.168.126.300", "192.168.126.20"),
+		},
+		{
+			name:        "deny adding a colliding egress IP",
+			ctx:         userCtx,
+			oldObj:      newEgressIP("eip", "10.129.0.5"),
+			newObj:      newEgressIP("eip", "10.129.0.5", "172.30.0.5"),
+			expectedErr: "egress IP 172.30.0.5 collides with the service subnet 172.30.0.0/16",
+		},
+		{
+			name:        "deny adding an egress IP used by another EgressIP",
+			ctx:         userCtx,
+			oldObj:      newEgressIP("eip", "192.168.126.20"),
+			newObj:      newEgressIP("eip", "192.168.126.20", "192.168.126.10"),
+			expectedErr: "egress IP 192.168.126.10 is already used by EgressIP existing",
+		},
+		{
+			name:        "deny removing all egress IPs",
+			ctx:         userCtx,
+			oldObj:      newEgressIP("eip", "192.168.126.20"),
+			newObj:      newEgressIP("eip"),
+			expectedErr: "spec.egressIPs must contain at least one IP address",
+		},
+		{
+			name:   "allow any update by an extra allowed user",
+			ctx:    allowedUserCtx,
+			oldObj: newEgressIP("eip", "192.168.126.20"),
+			newObj: newEgressIP("eip", "172.30.0.5"),
+		},
+	}
+	adm := newEgressIPAdmissionForTest(t, existing, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := adm.ValidateUpdate(tt.ctx, tt.oldObj, tt.newObj)
+			if tt.expectedErr == "" && err != nil || tt.expectedErr != "" && (err == nil || !strings.Contains(err.Error(), tt.expectedErr)) {
+				t.Errorf("ValidateUpdate() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
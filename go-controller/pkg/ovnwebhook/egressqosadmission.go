@@ -0,0 +1,108 @@
+package ovnwebhook
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	egressqosv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	// egressQoSName is the only name of EgressQoS handled by ovnkube-controller
+	egressQoSName = "default"
+	// egressQoSMaxRules is the maximum number of rules of an EgressQoS, one per OVN QoS priority
+	egressQoSMaxRules = 1000
+)
+
+// EgressQoSAdmission validates EgressQoS objects
+type EgressQoSAdmission struct{}
+
+func NewEgressQoSAdmissionWebhook() *EgressQoSAdmission {
+	return &EgressQoSAdmission{}
+}
+
+var _ admission.CustomValidator = &EgressQoSAdmission{}
+
+func (p EgressQoSAdmission) ValidateCreate(_ context.Context, obj runtime.Object) (warnings admission.Warnings, err error) {
+	return nil, validateEgressQoS(obj.(*egressqosv1.EgressQoS))
+}
+
+func (p EgressQoSAdmission) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (warnings admission.Warnings, err error) {
+	return nil, validateEgressQoS(newObj.(*egressqosv1.EgressQoS))
+}
+
+func (p EgressQoSAdmission) ValidateDelete(_ context.Context, _ runtime.Object) (warnings admission.Warnings, err error) {
+	// Ignore deletion, nothing to validate
+	return nil, nil
+}
+
+func validateEgressQoS(eq *egressqosv1.EgressQoS) error {
+	var errs []error
+	if eq.Name != egressQoSName {
+		errs = append(errs, fmt.Errorf("name must be %s", egressQoSName))
+	}
+	if len(eq.Spec.Egress) > egressQoSMaxRules {
+		errs = append(errs, fmt.Errorf("spec.egress has %d rules, maximum is %d", len(eq.Spec.Egress), egressQoSMaxRules))
+	}
+	dstNets := make([]*net.IPNet, len(eq.Spec.Egress))
+	for i, rule := range eq.Spec.Egress {
+		if rule.DSCP < 0 || rule.DSCP > 63 {
+			errs = append(errs, fmt.Errorf("spec.egress[%d]: dscp %d must be between 0 and 63", i, rule.DSCP))
+		}
+		if rule.DstCIDR != nil {
+			_, dstNet, err := net.ParseCIDR(*rule.DstCIDR)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("spec.egress[%d]: invalid dstCIDR %q: %v", i, *rule.DstCIDR, err))
+			}
+			dstNets[i] = dstNet
+		}
+		if _, err := metav1.LabelSelectorAsSelector(&rule.PodSelector); err != nil {
+			errs = append(errs, fmt.Errorf("spec.egress[%d]: invalid podSelector: %v", i, err))
+		}
+		if rule.Bandwidth != nil {
+			if rule.Bandwidth.Rate < 1 {
+				errs = append(errs, fmt.Errorf("spec.egress[%d]: bandwidth rate must be positive", i))
+			}
+			if rule.Bandwidth.Burst != nil && *rule.Bandwidth.Burst < 1 {
+				errs = append(errs, fmt.Errorf("spec.egress[%d]: bandwidth burst must be positive", i))
+			}
+		}
+	}
+	if len(errs) == 0 {
+		// rules are listed by decreasing priority
+		for i, limited := range eq.Spec.Egress {
+			if limited.Bandwidth == nil {
+				continue
+			}
+			for j, marking := range eq.Spec.Egress[:i] {
+				if marking.Bandwidth == nil && egressQoSRulesOverlap(marking, limited, dstNets[j], dstNets[i]) {
+					errs = append(errs, fmt.Errorf("spec.egress[%d]: bandwidth conflicts with the DSCP-only spec.egress[%d] "+
+						"matching the same traffic, make the rules disjoint or set a bandwidth on both", i, j))
+				}
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid EgressQoS %s/%s: %v", eq.Namespace, eq.Name, kerrors.NewAggregate(errs))
+	}
+	return nil
+}
+
+// egressQoSRulesOverlap returns true if the two rules may match the same traffic. Pod selectors are considered
+// to overlap unless they require different values for the same label. A nil destination matches all traffic.
+func egressQoSRulesOverlap(a, b egressqosv1.EgressQoSRule, aDst, bDst *net.IPNet) bool {
+	if util.LabelSelectorsDisjoint(a.PodSelector, b.PodSelector) {
+		return false
+	}
+	if aDst == nil || bDst == nil {
+		return true
+	}
+	return aDst.Contains(bDst.IP) || bDst.Contains(aDst.IP)
+}
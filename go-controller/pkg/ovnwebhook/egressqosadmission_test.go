@@ -0,0 +1,109 @@
+package ovnwebhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	egressqosv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEgressQoSAdmission_ValidateCreate(t *testing.T) {
+	dst := "1.2.3.0/24"
+	otherDst := "5.6.7.0/24"
+	invalidDst := "1.2.3.4"
+	zero := int64(0)
+	appSelector := metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	dbSelector := metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}}
+	tierSelector := metav1.LabelSelector{MatchLabels: map[string]string{"tier": "frontend"}}
+	tests := []struct {
+		name        string
+		qosName     string
+		rules       []egressqosv1.EgressQoSRule
+		expectedErr string
+	}{
+		{
+			name: "allow valid rules",
+			rules: []egressqosv1.EgressQoSRule{
+				{DSCP: 46, DstCIDR: &dst},
+				{DSCP: 10, DstCIDR: &otherDst, Bandwidth: &egressqosv1.EgressQoSBandwidth{Rate: 1000}},
+				{DSCP: 20, DstCIDR: &otherDst, PodSelector: appSelector, Bandwidth: &egressqosv1.EgressQoSBandwidth{Rate: 1000}},
+			},
+		},
+		{
+			name: "allow bandwidth rule of higher priority than a DSCP-only rule",
+			rules: []egressqosv1.EgressQoSRule{
+				{DSCP: 10, Bandwidth: &egressqosv1.EgressQoSBandwidth{Rate: 1000}},
+				{DSCP: 46},
+			},
+		},
+		{
+			name: "allow bandwidth rule selecting other pods than a DSCP-only rule",
+			rules: []egressqosv1.EgressQoSRule{
+				{DSCP: 46, PodSelector: dbSelector},
+				{DSCP: 10, PodSelector: appSelector, Bandwidth: &egressqosv1.EgressQoSBandwidth{Rate: 1000}},
+			},
+		},
+		{
+			name:        "deny other name",
+			qosName:     "qos",
+			expectedErr: "name must be default",
+		},
+		{
+			name:        "deny too many rules",
+			rules:       make([]egressqosv1.EgressQoSRule, egressQoSMaxRules+1),
+			expectedErr: "spec.egress has 1001 rules, maximum is 1000",
+		},
+		{
+			name:        "deny invalid DSCP",
+			rules:       []egressqosv1.EgressQoSRule{{DSCP: 64}},
+			expectedErr: "spec.egress[0]: dscp 64 must be between 0 and 63",
+		},
+		{
+			name:        "deny invalid dstCIDR",
+			rules:       []egressqosv1.EgressQoSRule{{DSCP: 46, DstCIDR: &invalidDst}},
+			expectedErr: `spec.egress[0]: invalid dstCIDR "1.2.3.4"`,
+		},
+		{
+			name: "deny invalid bandwidth",
+			rules: []egressqosv1.EgressQoSRule{
+				{DSCP: 46, Bandwidth: &egressqosv1.EgressQoSBandwidth{Rate: 1000, Burst: &zero}},
+			},
+			expectedErr: "spec.egress[0]: bandwidth burst must be positive",
+		},
+		{
+			name: "deny bandwidth rule overlapping a higher priority DSCP-only rule",
+			rules: []egressqosv1.EgressQoSRule{
+				{DSCP: 46, DstCIDR: &dst},
+				{DSCP: 10, PodSelector: appSelector, Bandwidth: &egressqosv1.EgressQoSBandwidth{Rate: 1000}},
+			},
+			expectedErr: "spec.egress[1]: bandwidth conflicts with the DSCP-only spec.egress[0]",
+		},
+		{
+			name: "deny bandwidth rule with another pod selector that may match the pods of a DSCP-only rule",
+			rules: []egressqosv1.EgressQoSRule{
+				{DSCP: 46, PodSelector: tierSelector},
+				{DSCP: 10, PodSelector: appSelector, Bandwidth: &egressqosv1.EgressQoSBandwidth{Rate: 1000}},
+			},
+			expectedErr: "spec.egress[1]: bandwidth conflicts with the DSCP-only spec.egress[0]",
+		},
+	}
+	adm := NewEgressQoSAdmissionWebhook()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := tt.qosName
+			if name == "" {
+				name = egressQoSName
+			}
+			eq := &egressqosv1.EgressQoS{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+				Spec:       egressqosv1.EgressQoSSpec{Egress: tt.rules},
+			}
+			_, err := adm.ValidateCreate(context.TODO(), eq)
+			if tt.expectedErr == "" && err != nil || tt.expectedErr != "" && (err == nil || !strings.Contains(err.Error(), tt.expectedErr)) {
+				t.Errorf("ValidateCreate() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package ovnwebhook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	egressservicev1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1"
+)
+
+// EgressServiceAdmission validates EgressService objects
+type EgressServiceAdmission struct{}
+
+func NewEgressServiceAdmissionWebhook() *EgressServiceAdmission {
+	return &EgressServiceAdmission{}
+}
+
+var _ admission.CustomValidator = &EgressServiceAdmission{}
+
+func (p EgressServiceAdmission) ValidateCreate(_ context.Context, obj runtime.Object) (warnings admission.Warnings, err error) {
+	return nil, validateEgressService(obj.(*egressservicev1.EgressService))
+}
+
+func (p EgressServiceAdmission) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (warnings admission.Warnings, err error) {
+	return nil, validateEgressService(newObj.(*egressservicev1.EgressService))
+}
+
+func (p EgressServiceAdmission) ValidateDelete(_ context.Context, _ runtime.Object) (warnings admission.Warnings, err error) {
+	// Ignore deletion, nothing to validate
+	return nil, nil
+}
+
+func validateEgressService(es *egressservicev1.EgressService) error {
+	var errs []error
+	switch es.Spec.SourceIPBy {
+	case "", egressservicev1.SourceIPLoadBalancer:
+		if _, err := metav1.LabelSelectorAsSelector(&es.Spec.NodeSelector); err != nil {
+			errs = append(errs, fmt.Errorf("invalid spec.nodeSelector: %v", err))
+		}
+	case egressservicev1.SourceIPNetwork:
+		// the traffic leaves from the node of each endpoint, no node is selected
+		if len(es.Spec.NodeSelector.MatchLabels) > 0 || len(es.Spec.NodeSelector.MatchExpressions) > 0 {
+			errs = append(errs, fmt.Errorf("spec.nodeSelector is only supported with spec.sourceIPBy %s", egressservicev1.SourceIPLoadBalancer))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("spec.sourceIPBy must be one of %s or %s", egressservicev1.SourceIPLoadBalancer, egressservicev1.SourceIPNetwork))
+	}
+	if strings.ContainsAny(es.Spec.Network, " \t\n") {
+		errs = append(errs, fmt.Errorf("spec.network %q must not contain whitespaces", es.Spec.Network))
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid EgressService %s/%s: %v", es.Namespace, es.Name, kerrors.NewAggregate(errs))
+	}
+	return nil
+}
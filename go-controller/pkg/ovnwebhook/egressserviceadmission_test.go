@@ -0,0 +1,63 @@
+package ovnwebhook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	egressservicev1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEgressServiceAdmission_ValidateCreate(t *testing.T) {
+	nodeSelector := metav1.LabelSelector{MatchLabels: map[string]string{"egress": "true"}}
+	tests := []struct {
+		name        string
+		spec        egressservicev1.EgressServiceSpec
+		expectedErr string
+	}{
+		{
+			name: "allow node selector with LoadBalancerIP",
+			spec: egressservicev1.EgressServiceSpec{SourceIPBy: egressservicev1.SourceIPLoadBalancer, NodeSelector: nodeSelector},
+		},
+		{
+			name: "allow Network without node selector",
+			spec: egressservicev1.EgressServiceSpec{SourceIPBy: egressservicev1.SourceIPNetwork, Network: "100"},
+		},
+		{
+			name:        "deny node selector with Network",
+			spec:        egressservicev1.EgressServiceSpec{SourceIPBy: egressservicev1.SourceIPNetwork, NodeSelector: nodeSelector},
+			expectedErr: "spec.nodeSelector is only supported with spec.sourceIPBy LoadBalancerIP",
+		},
+		{
+			name:        "deny unknown sourceIPBy",
+			spec:        egressservicev1.EgressServiceSpec{SourceIPBy: "NodeIP"},
+			expectedErr: "spec.sourceIPBy must be one of LoadBalancerIP or Network",
+		},
+		{
+			name: "deny invalid node selector",
+			spec: egressservicev1.EgressServiceSpec{NodeSelector: metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "egress", Operator: metav1.LabelSelectorOpIn}},
+			}},
+			expectedErr: "invalid spec.nodeSelector",
+		},
+		{
+			name:        "deny network with whitespaces",
+			spec:        egressservicev1.EgressServiceSpec{Network: "my network"},
+			expectedErr: `spec.network "my network" must not contain whitespaces`,
+		},
+	}
+	adm := NewEgressServiceAdmissionWebhook()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := &egressservicev1.EgressService{
+				ObjectMeta: metav1.ObjectMeta{Name: "svc", Namespace: "ns"},
+				Spec:       tt.spec,
+			}
+			_, err := adm.ValidateCreate(context.TODO(), es)
+			if tt.expectedErr == "" && err != nil || tt.expectedErr != "" && (err == nil || !strings.Contains(err.Error(), tt.expectedErr)) {
+				t.Errorf("ValidateCreate() error = %v, expectedErr %v", err, tt.expectedErr)
+			}
+		})
+	}
+}
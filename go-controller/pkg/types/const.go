@@ -159,6 +159,10 @@ const (
 	LoadBalancerKindExternalID = OvnK8sPrefix + "/" + "kind"
 	// key for load_balancer service external-id
 	LoadBalancerOwnerExternalID = OvnK8sPrefix + "/" + "owner"
+	// key for the egress node external-id of the BFD sessions between egress nodes
+	EgressIPBFDNodeExternalID = OvnK8sPrefix + "/" + "egress-ip-bfd-node"
+	// key for the peer egress node external-id of the BFD sessions between egress nodes
+	EgressIPBFDPeerExternalID = OvnK8sPrefix + "/" + "egress-ip-bfd-peer"
 
 	// different secondary network topology type defined in CNI netconf
 	Layer3Topology   = "layer3"
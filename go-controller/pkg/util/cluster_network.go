@@ -0,0 +1,87 @@
+package util
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	clusternetworkapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1"
+	clusternetworklister "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1/apis/listers/clusternetwork/v1"
+)
+
+// ClusterNetworkFinalizer is set on ClusterNetworks by ovnkube-cluster-manager
+// to hold their deletion while host subnets allocated out of their cluster
+// subnet ranges are in use.
+const ClusterNetworkFinalizer = "k8s.ovn.org/cluster-network-protection"
+
+// ParseClusterNetworkSubnets returns the cluster subnet ranges of the given
+// ClusterNetwork. When not specified, the host subnet length defaults to 24
+// or 64 bits for IPv4 and IPv6 respectively.
+func ParseClusterNetworkSubnets(clusterNetwork *clusternetworkapi.ClusterNetwork) ([]config.CIDRNetworkEntry, error) {
+	clusterSubnets := make([]string, 0, len(clusterNetwork.Spec.ClusterSubnets))
+	for _, clusterSubnet := range clusterNetwork.Spec.ClusterSubnets {
+		if strings.Count(clusterSubnet.CIDR, "/") != 1 {
+			return nil, fmt.Errorf("ClusterNetwork %s: CIDR %q not properly formatted", clusterNetwork.Name, clusterSubnet.CIDR)
+		}
+		if clusterSubnet.HostSubnetLength != 0 {
+			clusterSubnets = append(clusterSubnets, fmt.Sprintf("%s/%d", clusterSubnet.CIDR, clusterSubnet.HostSubnetLength))
+		} else {
+			clusterSubnets = append(clusterSubnets, clusterSubnet.CIDR)
+		}
+	}
+	entries, err := config.ParseClusterSubnetEntries(strings.Join(clusterSubnets, ","))
+	if err != nil {
+		return nil, fmt.Errorf("ClusterNetwork %s: %w", clusterNetwork.Name, err)
+	}
+	return entries, nil
+}
+
+// AddClusterNetworkSubnets adds the cluster subnet ranges of the given
+// ClusterNetwork to the default network and returns the ones that were not
+// already part of it.
+func AddClusterNetworkSubnets(clusterNetwork *clusternetworkapi.ClusterNetwork) ([]config.CIDRNetworkEntry, error) {
+	entries, err := ParseClusterNetworkSubnets(clusterNetwork)
+	if err != nil {
+		return nil, err
+	}
+	added, err := config.AddClusterSubnets(entries)
+	if err != nil {
+		return nil, fmt.Errorf("ClusterNetwork %s: %w", clusterNetwork.Name, err)
+	}
+	return added, nil
+}
+
+// SyncClusterNetworkSubnets adds the cluster subnet ranges of all existing
+// ClusterNetworks to the default network. It is meant to be called on startup,
+// before host subnets are handled, so that the ranges added at runtime are
+// known by then. ClusterNetworks whose ranges can't be added are logged and
+// skipped.
+func SyncClusterNetworkSubnets(lister clusternetworklister.ClusterNetworkLister) error {
+	clusterNetworks, err := lister.List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list ClusterNetworks: %w", err)
+	}
+	// add the ranges in the order they were added to the cluster, so that
+	// the same ones win over overlapping ranges across restarts
+	sort.Slice(clusterNetworks, func(i, j int) bool {
+		if clusterNetworks[i].CreationTimestamp.Equal(&clusterNetworks[j].CreationTimestamp) {
+			return clusterNetworks[i].Name < clusterNetworks[j].Name
+		}
+		return clusterNetworks[i].CreationTimestamp.Before(&clusterNetworks[j].CreationTimestamp)
+	})
+	for _, clusterNetwork := range clusterNetworks {
+		added, err := AddClusterNetworkSubnets(clusterNetwork)
+		if err != nil {
+			klog.Errorf("Failed to add cluster subnets: %v", err)
+			continue
+		}
+		if len(added) > 0 {
+			klog.Infof("Added cluster subnets %v from ClusterNetwork %s", added, clusterNetwork.Name)
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package util
 import (
 	"fmt"
 	"net"
+	"strings"
 	"sync"
 	"time"
 
@@ -232,6 +233,23 @@ func (d *DNS) GetNextQueryTime() (time.Time, string, bool) {
 	return minTime, dns, timeSet
 }
 
+// IsWildcardDNSName returns true if dnsName is a wildcard name like "*.example.com"
+func IsWildcardDNSName(dnsName string) bool {
+	return strings.HasPrefix(dnsName, "*.")
+}
+
+// DNSNameMatchesWildcard returns true if dnsName belongs to the subdomains covered by wildcardName.
+// For example both "www.example.com" and "a.b.example.com" match "*.example.com", while "example.com" does not.
+// The comparison is case insensitive and ignores a trailing dot on either name.
+func DNSNameMatchesWildcard(wildcardName, dnsName string) bool {
+	if !IsWildcardDNSName(wildcardName) {
+		return false
+	}
+	suffix := strings.ToLower(strings.TrimSuffix(strings.TrimPrefix(wildcardName, "*"), "."))
+	name := strings.ToLower(strings.TrimSuffix(dnsName, "."))
+	return len(name) > len(suffix) && strings.HasSuffix(name, suffix)
+}
+
 func ipsEqual(oldips, newips []net.IP) bool {
 	if len(oldips) != len(newips) {
 		return false
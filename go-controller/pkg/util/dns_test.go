@@ -283,3 +283,54 @@ func TestAdd(t *testing.T) {
 	}
 
 }
+
+func TestDNSNameMatchesWildcard(t *testing.T) {
+	tests := []struct {
+		desc         string
+		wildcardName string
+		dnsName      string
+		expected     bool
+	}{
+		{
+			desc:         "matches a direct subdomain",
+			wildcardName: "*.example.com",
+			dnsName:      "www.example.com",
+			expected:     true,
+		},
+		{
+			desc:         "matches a nested subdomain",
+			wildcardName: "*.example.com",
+			dnsName:      "a.b.example.com.",
+			expected:     true,
+		},
+		{
+			desc:         "matches regardless of case and trailing dot",
+			wildcardName: "*.Example.com.",
+			dnsName:      "WWW.example.COM",
+			expected:     true,
+		},
+		{
+			desc:         "does not match the parent domain",
+			wildcardName: "*.example.com",
+			dnsName:      "example.com",
+			expected:     false,
+		},
+		{
+			desc:         "does not match a domain sharing the suffix without a dot",
+			wildcardName: "*.example.com",
+			dnsName:      "wwwexample.com",
+			expected:     false,
+		},
+		{
+			desc:         "does not match with a regular name",
+			wildcardName: "www.example.com",
+			dnsName:      "www.example.com",
+			expected:     false,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			assert.Equal(t, tc.expected, DNSNameMatchesWildcard(tc.wildcardName, tc.dnsName))
+		})
+	}
+}
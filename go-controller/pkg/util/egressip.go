@@ -0,0 +1,95 @@
+package util
+
+import (
+	"hash/fnv"
+	"net"
+
+	egressipv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+
+	utilnet "k8s.io/utils/net"
+)
+
+// GetEgressIPLoadBalancing returns the load balancing mode of the given
+// EgressIP spec, defaulting to PerConnection
+func GetEgressIPLoadBalancing(spec *egressipv1.EgressIPSpec) egressipv1.EgressIPLoadBalancing {
+	if spec.LoadBalancing == "" {
+		return egressipv1.EgressIPLoadBalancingPerConnection
+	}
+	return spec.LoadBalancing
+}
+
+// SelectEgressIPStatusItems returns the assigned egress IPs of the IP family of
+// podIP that serve the egress traffic of the pod, according to the load
+// balancing mode of the given EgressIP spec:
+// - PerConnection: all of them
+// - SourceIP: one of them, chosen by rendezvous hashing of the pod IP so that
+// the choice is stable and only the pods of an unassigned egress IP move
+// - ActiveStandby: the one listed first in the spec
+func SelectEgressIPStatusItems(spec *egressipv1.EgressIPSpec, items []egressipv1.EgressIPStatusItem, podIP net.IP) []egressipv1.EgressIPStatusItem {
+	isIPv6 := utilnet.IsIPv6(podIP)
+	var candidates []egressipv1.EgressIPStatusItem
+	for _, item := range items {
+		if utilnet.IsIPv6String(item.EgressIP) == isIPv6 {
+			candidates = append(candidates, item)
+		}
+	}
+	if len(candidates) < 2 {
+		return candidates
+	}
+
+	switch GetEgressIPLoadBalancing(spec) {
+	case egressipv1.EgressIPLoadBalancingSourceIP:
+		var selected egressipv1.EgressIPStatusItem
+		var maxWeight uint32
+		for i, candidate := range candidates {
+			h := fnv.New32a()
+			h.Write([]byte(podIP.String() + "/" + candidate.EgressIP))
+			if weight := h.Sum32(); i == 0 || weight > maxWeight ||
+				(weight == maxWeight && candidate.EgressIP < selected.EgressIP) {
+				selected, maxWeight = candidate, weight
+			}
+		}
+		return []egressipv1.EgressIPStatusItem{selected}
+	case egressipv1.EgressIPLoadBalancingActiveStandby:
+		selected := candidates[0]
+		selectedPriority := egressIPPriority(spec, selected.EgressIP)
+		for _, candidate := range candidates[1:] {
+			priority := egressIPPriority(spec, candidate.EgressIP)
+			if priority < selectedPriority ||
+				(priority == selectedPriority && candidate.EgressIP < selected.EgressIP) {
+				selected, selectedPriority = candidate, priority
+			}
+		}
+		return []egressipv1.EgressIPStatusItem{selected}
+	default:
+		return candidates
+	}
+}
+
+// GetActiveEgressIPs returns, for an EgressIP using the ActiveStandby load
+// balancing mode, the assigned egress IPs serving the egress traffic, one per
+// IP family. It returns nil for the other modes.
+func GetActiveEgressIPs(spec *egressipv1.EgressIPSpec, items []egressipv1.EgressIPStatusItem) []string {
+	if GetEgressIPLoadBalancing(spec) != egressipv1.EgressIPLoadBalancingActiveStandby {
+		return nil
+	}
+	var active []string
+	for _, ip := range []net.IP{net.IPv4zero, net.IPv6zero} {
+		for _, item := range SelectEgressIPStatusItems(spec, items, ip) {
+			active = append(active, item.EgressIP)
+		}
+	}
+	return active
+}
+
+// egressIPPriority returns the priority of an egress IP, its index in the
+// spec, lower being preferred
+func egressIPPriority(spec *egressipv1.EgressIPSpec, egressIP string) int {
+	parsed := net.ParseIP(egressIP)
+	for i, specIP := range spec.EgressIPs {
+		if parsed.Equal(net.ParseIP(specIP)) {
+			return i
+		}
+	}
+	return len(spec.EgressIPs)
+}
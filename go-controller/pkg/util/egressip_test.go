@@ -0,0 +1,113 @@
+package util
+
+import (
+	"net"
+	"reflect"
+	"testing"
+
+	egressipv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+)
+
+func TestSelectEgressIPStatusItems(t *testing.T) {
+	items := []egressipv1.EgressIPStatusItem{
+		{Node: "node2", EgressIP: "192.168.126.102"},
+		{Node: "node1", EgressIP: "192.168.126.101"},
+		{Node: "node3", EgressIP: "fc00::3"},
+	}
+	tests := []struct {
+		desc          string
+		loadBalancing egressipv1.EgressIPLoadBalancing
+		podIP         string
+		expected      []egressipv1.EgressIPStatusItem
+	}{
+		{
+			desc:     "defaults to all the egress IPs of the pod IP family",
+			podIP:    "10.128.0.15",
+			expected: items[:2],
+		},
+		{
+			desc:          "PerConnection selects all the egress IPs of the pod IP family",
+			loadBalancing: egressipv1.EgressIPLoadBalancingPerConnection,
+			podIP:         "10.128.0.15",
+			expected:      items[:2],
+		},
+		{
+			desc:          "ActiveStandby selects the egress IP listed first in the spec",
+			loadBalancing: egressipv1.EgressIPLoadBalancingActiveStandby,
+			podIP:         "10.128.0.15",
+			expected:      items[1:2],
+		},
+		{
+			desc:          "ActiveStandby selects the only egress IP of the pod IP family",
+			loadBalancing: egressipv1.EgressIPLoadBalancingActiveStandby,
+			podIP:         "fd00:10:244::5",
+			expected:      items[2:],
+		},
+		{
+			desc:          "SourceIP selects a single egress IP of the pod IP family",
+			loadBalancing: egressipv1.EgressIPLoadBalancingSourceIP,
+			podIP:         "10.128.0.15",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			spec := &egressipv1.EgressIPSpec{
+				EgressIPs:     []string{"192.168.126.101", "fc00::3", "192.168.126.102"},
+				LoadBalancing: tc.loadBalancing,
+			}
+			podIP := net.ParseIP(tc.podIP)
+			result := SelectEgressIPStatusItems(spec, items, podIP)
+			if tc.loadBalancing == egressipv1.EgressIPLoadBalancingSourceIP {
+				if len(result) != 1 || result[0].EgressIP == "fc00::3" {
+					t.Fatalf("expected a single IPv4 egress IP, got %v", result)
+				}
+				// the selection must be stable regardless of the status order
+				reversed := []egressipv1.EgressIPStatusItem{items[2], items[1], items[0]}
+				if again := SelectEgressIPStatusItems(spec, reversed, podIP); !reflect.DeepEqual(result, again) {
+					t.Fatalf("expected %v to be selected again, got %v", result, again)
+				}
+				return
+			}
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestGetActiveEgressIPs(t *testing.T) {
+	items := []egressipv1.EgressIPStatusItem{
+		{Node: "node2", EgressIP: "192.168.126.102"},
+		{Node: "node3", EgressIP: "fc00::3"},
+	}
+	tests := []struct {
+		desc          string
+		loadBalancing egressipv1.EgressIPLoadBalancing
+		expected      []string
+	}{
+		{
+			desc: "no active egress IPs by default",
+		},
+		{
+			desc:          "no active egress IPs for SourceIP",
+			loadBalancing: egressipv1.EgressIPLoadBalancingSourceIP,
+		},
+		{
+			desc:          "the first assigned egress IP of each IP family for ActiveStandby",
+			loadBalancing: egressipv1.EgressIPLoadBalancingActiveStandby,
+			expected:      []string{"192.168.126.102", "fc00::3"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			spec := &egressipv1.EgressIPSpec{
+				EgressIPs:     []string{"192.168.126.101", "fc00::3", "192.168.126.102"},
+				LoadBalancing: tc.loadBalancing,
+			}
+			result := GetActiveEgressIPs(spec, items)
+			if !reflect.DeepEqual(result, tc.expected) {
+				t.Fatalf("expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
@@ -9,6 +9,8 @@ import (
 	cloudservicefake "github.com/openshift/client-go/cloudnetwork/clientset/versioned/fake"
 	adminpolicybasedrouteapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1"
 	adminpolicybasedroutefake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/clientset/versioned/fake"
+	clusternetwork "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1"
+	clusternetworkfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1/apis/clientset/versioned/fake"
 	egressfirewall "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1"
 	egressfirewallfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1/apis/clientset/versioned/fake"
 	egressip "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
@@ -17,6 +19,8 @@ import (
 	egressqosfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1/apis/clientset/versioned/fake"
 	egressservice "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1"
 	egressservicefake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1/apis/clientset/versioned/fake"
+	ipamclaim "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/ipamclaim/v1"
+	ipamclaimfake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/ipamclaim/v1/apis/clientset/versioned/fake"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,6 +38,8 @@ func GetOVNClientset(objects ...runtime.Object) *OVNClientset {
 	multiNetworkPolicyObjects := []runtime.Object{}
 	egressServiceObjects := []runtime.Object{}
 	apbExternalRouteObjects := []runtime.Object{}
+	ipamClaimObjects := []runtime.Object{}
+	clusterNetworkObjects := []runtime.Object{}
 	anpObjects := []runtime.Object{}
 	v1Objects := []runtime.Object{}
 	nads := []runtime.Object{}
@@ -58,6 +64,10 @@ func GetOVNClientset(objects ...runtime.Object) *OVNClientset {
 			apbExternalRouteObjects = append(apbExternalRouteObjects, object)
 		case *anpapi.AdminNetworkPolicy:
 			anpObjects = append(anpObjects, object)
+		case *ipamclaim.IPAMClaim:
+			ipamClaimObjects = append(ipamClaimObjects, object)
+		case *clusternetwork.ClusterNetwork:
+			clusterNetworkObjects = append(clusterNetworkObjects, object)
 		default:
 			v1Objects = append(v1Objects, object)
 		}
@@ -73,6 +83,8 @@ func GetOVNClientset(objects ...runtime.Object) *OVNClientset {
 		MultiNetworkPolicyClient: mnpfake.NewSimpleClientset(multiNetworkPolicyObjects...),
 		EgressServiceClient:      egressservicefake.NewSimpleClientset(egressServiceObjects...),
 		AdminPolicyRouteClient:   adminpolicybasedroutefake.NewSimpleClientset(apbExternalRouteObjects...),
+		IPAMClaimClient:          ipamclaimfake.NewSimpleClientset(ipamClaimObjects...),
+		ClusterNetworkClient:     clusternetworkfake.NewSimpleClientset(clusterNetworkObjects...),
 	}
 }
 
@@ -27,6 +27,7 @@ import (
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	typedcorev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/pkg/version"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -42,10 +43,12 @@ import (
 	ocpcloudnetworkclientset "github.com/openshift/client-go/cloudnetwork/clientset/versioned"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	adminpolicybasedrouteclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/clientset/versioned"
+	clusternetworkclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/clusternetwork/v1/apis/clientset/versioned"
 	egressfirewallclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1/apis/clientset/versioned"
 	egressipclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/clientset/versioned"
 	egressqosclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressqos/v1/apis/clientset/versioned"
 	egressserviceclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressservice/v1/apis/clientset/versioned"
+	ipamclaimclientset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/ipamclaim/v1/apis/clientset/versioned"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	anpclientset "sigs.k8s.io/network-policy-api/pkg/client/clientset/versioned"
 )
@@ -62,6 +65,11 @@ type OVNClientset struct {
 	MultiNetworkPolicyClient multinetworkpolicyclientset.Interface
 	EgressServiceClient      egressserviceclientset.Interface
 	AdminPolicyRouteClient   adminpolicybasedrouteclientset.Interface
+	IPAMClaimClient          ipamclaimclientset.Interface
+	ClusterNetworkClient     clusternetworkclientset.Interface
+	// MetadataClient gets the metadata of objects of third party APIs, like
+	// KubeVirt virtual machines
+	MetadataClient metadata.Interface
 }
 
 // OVNMasterClientset
@@ -75,6 +83,7 @@ type OVNMasterClientset struct {
 	MultiNetworkPolicyClient multinetworkpolicyclientset.Interface
 	EgressServiceClient      egressserviceclientset.Interface
 	AdminPolicyRouteClient   adminpolicybasedrouteclientset.Interface
+	ClusterNetworkClient     clusternetworkclientset.Interface
 }
 
 // OVNNetworkControllerManagerClientset
@@ -87,6 +96,7 @@ type OVNKubeControllerClientset struct {
 	MultiNetworkPolicyClient multinetworkpolicyclientset.Interface
 	EgressServiceClient      egressserviceclientset.Interface
 	AdminPolicyRouteClient   adminpolicybasedrouteclientset.Interface
+	ClusterNetworkClient     clusternetworkclientset.Interface
 }
 
 type OVNNodeClientset struct {
@@ -94,6 +104,7 @@ type OVNNodeClientset struct {
 	EgressServiceClient    egressserviceclientset.Interface
 	EgressIPClient         egressipclientset.Interface
 	AdminPolicyRouteClient adminpolicybasedrouteclientset.Interface
+	ClusterNetworkClient   clusternetworkclientset.Interface
 }
 
 type OVNClusterManagerClientset struct {
@@ -105,6 +116,9 @@ type OVNClusterManagerClientset struct {
 	AdminPolicyRouteClient adminpolicybasedrouteclientset.Interface
 	EgressFirewallClient   egressfirewallclientset.Interface
 	EgressQoSClient        egressqosclientset.Interface
+	IPAMClaimClient        ipamclaimclientset.Interface
+	ClusterNetworkClient   clusternetworkclientset.Interface
+	MetadataClient         metadata.Interface
 }
 
 const (
@@ -128,6 +142,7 @@ func (cs *OVNClientset) GetMasterClientset() *OVNMasterClientset {
 		MultiNetworkPolicyClient: cs.MultiNetworkPolicyClient,
 		EgressServiceClient:      cs.EgressServiceClient,
 		AdminPolicyRouteClient:   cs.AdminPolicyRouteClient,
+		ClusterNetworkClient:     cs.ClusterNetworkClient,
 	}
 }
 
@@ -141,6 +156,7 @@ func (cs *OVNMasterClientset) GetOVNKubeControllerClientset() *OVNKubeController
 		MultiNetworkPolicyClient: cs.MultiNetworkPolicyClient,
 		EgressServiceClient:      cs.EgressServiceClient,
 		AdminPolicyRouteClient:   cs.AdminPolicyRouteClient,
+		ClusterNetworkClient:     cs.ClusterNetworkClient,
 	}
 }
 
@@ -154,6 +170,7 @@ func (cs *OVNClientset) GetOVNKubeControllerClientset() *OVNKubeControllerClient
 		MultiNetworkPolicyClient: cs.MultiNetworkPolicyClient,
 		EgressServiceClient:      cs.EgressServiceClient,
 		AdminPolicyRouteClient:   cs.AdminPolicyRouteClient,
+		ClusterNetworkClient:     cs.ClusterNetworkClient,
 	}
 }
 
@@ -167,6 +184,9 @@ func (cs *OVNClientset) GetClusterManagerClientset() *OVNClusterManagerClientset
 		AdminPolicyRouteClient: cs.AdminPolicyRouteClient,
 		EgressFirewallClient:   cs.EgressFirewallClient,
 		EgressQoSClient:        cs.EgressQoSClient,
+		IPAMClaimClient:        cs.IPAMClaimClient,
+		ClusterNetworkClient:   cs.ClusterNetworkClient,
+		MetadataClient:         cs.MetadataClient,
 	}
 }
 
@@ -176,14 +196,16 @@ func (cs *OVNClientset) GetNodeClientset() *OVNNodeClientset {
 		EgressServiceClient:    cs.EgressServiceClient,
 		EgressIPClient:         cs.EgressIPClient,
 		AdminPolicyRouteClient: cs.AdminPolicyRouteClient,
+		ClusterNetworkClient:   cs.ClusterNetworkClient,
 	}
 }
 
 func (cs *OVNMasterClientset) GetNodeClientset() *OVNNodeClientset {
 	return &OVNNodeClientset{
-		KubeClient:          cs.KubeClient,
-		EgressServiceClient: cs.EgressServiceClient,
-		EgressIPClient:      cs.EgressIPClient,
+		KubeClient:           cs.KubeClient,
+		EgressServiceClient:  cs.EgressServiceClient,
+		EgressIPClient:       cs.EgressIPClient,
+		ClusterNetworkClient: cs.ClusterNetworkClient,
 	}
 }
 
@@ -426,6 +448,21 @@ func NewOVNClientset(conf *config.KubernetesConfig) (*OVNClientset, error) {
 		return nil, err
 	}
 
+	ipamClaimClientset, err := ipamclaimclientset.NewForConfig(kconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	clusterNetworkClientset, err := clusternetworkclientset.NewForConfig(kconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	metadataClient, err := metadata.NewForConfig(kconfig)
+	if err != nil {
+		return nil, err
+	}
+
 	return &OVNClientset{
 		KubeClient:               kclientset,
 		ANPClient:                anpClientset,
@@ -437,6 +474,9 @@ func NewOVNClientset(conf *config.KubernetesConfig) (*OVNClientset, error) {
 		MultiNetworkPolicyClient: multiNetworkPolicyClientset,
 		EgressServiceClient:      egressserviceClientset,
 		AdminPolicyRouteClient:   adminPolicyBasedRouteClientset,
+		IPAMClaimClient:          ipamClaimClientset,
+		ClusterNetworkClient:     clusterNetworkClientset,
+		MetadataClient:           metadataClient,
 	}, nil
 }
 
@@ -532,7 +572,7 @@ func ServiceInternalTrafficPolicyLocal(service *kapi.Service) bool {
 func GetClusterSubnets() ([]*net.IPNet, []*net.IPNet) {
 	var v4ClusterSubnets = []*net.IPNet{}
 	var v6ClusterSubnets = []*net.IPNet{}
-	for _, clusterSubnet := range config.Default.ClusterSubnets {
+	for _, clusterSubnet := range config.ClusterSubnets() {
 		if !utilnet.IsIPv6CIDR(clusterSubnet.CIDR) {
 			v4ClusterSubnets = append(v4ClusterSubnets, clusterSubnet.CIDR)
 		} else {
@@ -901,7 +941,7 @@ func ServiceNamespacedNameFromEndpointSlice(endpointSlice *discovery.EndpointSli
 // isHostEndpoint determines if the given endpoint ip belongs to a host networked pod
 func IsHostEndpoint(endpointIPstr string) bool {
 	endpointIP := net.ParseIP(endpointIPstr)
-	for _, clusterNet := range config.Default.ClusterSubnets {
+	for _, clusterNet := range config.ClusterSubnets() {
 		if clusterNet.CIDR.Contains(endpointIP) {
 			return false
 		}
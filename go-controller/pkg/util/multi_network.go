@@ -11,6 +11,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 
 	kapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	knet "k8s.io/utils/net"
 
 	nettypes "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
@@ -20,8 +21,9 @@ import (
 )
 
 var (
-	ErrorAttachDefNotOvnManaged = errors.New("net-attach-def not managed by OVN")
-	UnsupportedIPAMKeyError     = errors.New("IPAM key is not supported. Use OVN-K provided IPAM via the `subnets` attribute")
+	ErrorAttachDefNotOvnManaged  = errors.New("net-attach-def not managed by OVN")
+	UnsupportedIPAMKeyError      = errors.New("IPAM key is not supported. Use OVN-K provided IPAM via the `subnets` attribute")
+	ErrNetInfoUpdateNotSupported = errors.New("network configuration change can not be applied in place")
 )
 
 // BasicNetInfo is interface which holds basic network information
@@ -35,6 +37,8 @@ type BasicNetInfo interface {
 	Subnets() []config.CIDRNetworkEntry
 	ExcludeSubnets() []*net.IPNet
 	Vlan() uint
+	AllowsPersistentIPs() bool
+	HasGateway() bool
 
 	// utility methods
 	CompareNetInfo(BasicNetInfo) bool
@@ -114,7 +118,7 @@ func (nInfo *DefaultNetInfo) IPMode() (bool, bool) {
 
 // Subnets returns the defaultNetConfInfo's Subnets value
 func (nInfo *DefaultNetInfo) Subnets() []config.CIDRNetworkEntry {
-	return config.Default.ClusterSubnets
+	return config.ClusterSubnets()
 }
 
 // ExcludeSubnets returns the defaultNetConfInfo's ExcludeSubnets value
@@ -127,14 +131,33 @@ func (nInfo *DefaultNetInfo) Vlan() uint {
 	return config.Gateway.VLANID
 }
 
+// AllowsPersistentIPs returns the defaultNetConfInfo's AllowPersistentIPs
+// value which is always false
+func (nInfo *DefaultNetInfo) AllowsPersistentIPs() bool {
+	return false
+}
+
+// HasGateway returns whether the network has per-node gateway routers, which
+// is always the case for the default network
+func (nInfo *DefaultNetInfo) HasGateway() bool {
+	return true
+}
+
 // SecondaryNetInfo holds the network name information for secondary network if non-nil
 type secondaryNetInfo struct {
 	netName  string
 	topology string
-	mtu      int
 	vlan     uint
 
+	allowPersistentIPs bool
+	gateway            bool
+
 	ipv4mode, ipv6mode bool
+
+	// mtu, subnets and excludeSubnets can be updated in place, see
+	// UpdateNetInfo
+	mutableNetInfoLock sync.RWMutex
+	mtu                int
 	subnets            []config.CIDRNetworkEntry
 	excludeSubnets     []*net.IPNet
 
@@ -195,6 +218,8 @@ func (nInfo *secondaryNetInfo) TopologyType() string {
 
 // MTU returns the layer3NetConfInfo's MTU value
 func (nInfo *secondaryNetInfo) MTU() int {
+	nInfo.mutableNetInfoLock.RLock()
+	defer nInfo.mutableNetInfoLock.RUnlock()
 	return nInfo.mtu
 }
 
@@ -203,6 +228,18 @@ func (nInfo *secondaryNetInfo) Vlan() uint {
 	return nInfo.vlan
 }
 
+// AllowsPersistentIPs returns whether the IPs of the workloads attached to
+// this network are persisted in IPAMClaims
+func (nInfo *secondaryNetInfo) AllowsPersistentIPs() bool {
+	return nInfo.allowPersistentIPs
+}
+
+// HasGateway returns whether the network has per-node gateway routers
+// providing north-south egress
+func (nInfo *secondaryNetInfo) HasGateway() bool {
+	return nInfo.gateway
+}
+
 // IPMode returns the ipv4/ipv6 mode
 func (nInfo *secondaryNetInfo) IPMode() (bool, bool) {
 	return nInfo.ipv4mode, nInfo.ipv6mode
@@ -210,11 +247,15 @@ func (nInfo *secondaryNetInfo) IPMode() (bool, bool) {
 
 // Subnets returns the Subnets value
 func (nInfo *secondaryNetInfo) Subnets() []config.CIDRNetworkEntry {
+	nInfo.mutableNetInfoLock.RLock()
+	defer nInfo.mutableNetInfoLock.RUnlock()
 	return nInfo.subnets
 }
 
 // ExcludeSubnets returns the ExcludeSubnets value
 func (nInfo *secondaryNetInfo) ExcludeSubnets() []*net.IPNet {
+	nInfo.mutableNetInfoLock.RLock()
+	defer nInfo.mutableNetInfoLock.RUnlock()
 	return nInfo.excludeSubnets
 }
 
@@ -226,23 +267,138 @@ func (nInfo *secondaryNetInfo) CompareNetInfo(other BasicNetInfo) bool {
 	if nInfo.topology != other.TopologyType() {
 		return false
 	}
-	if nInfo.mtu != other.MTU() {
+	if nInfo.MTU() != other.MTU() {
 		return false
 	}
 	if nInfo.vlan != other.Vlan() {
 		return false
 	}
+	if nInfo.allowPersistentIPs != other.AllowsPersistentIPs() {
+		return false
+	}
+	if nInfo.gateway != other.HasGateway() {
+		return false
+	}
 
 	lessCIDRNetworkEntry := func(a, b config.CIDRNetworkEntry) bool { return a.String() < b.String() }
-	if !cmp.Equal(nInfo.subnets, other.Subnets(), cmpopts.SortSlices(lessCIDRNetworkEntry)) {
+	if !cmp.Equal(nInfo.Subnets(), other.Subnets(), cmpopts.SortSlices(lessCIDRNetworkEntry)) {
 		return false
 	}
 
 	lessIPNet := func(a, b net.IPNet) bool { return a.String() < b.String() }
-	return cmp.Equal(nInfo.excludeSubnets, other.ExcludeSubnets(), cmpopts.SortSlices(lessIPNet))
+	return cmp.Equal(nInfo.ExcludeSubnets(), other.ExcludeSubnets(), cmpopts.SortSlices(lessIPNet))
+}
+
+// ValidateNetInfoUpdate checks whether the network information current can be
+// updated in place to updated. MTU changes, additional subnets and additional
+// excluded subnets are considered compatible; any other change requires the
+// network to be re-created and an error wrapping ErrNetInfoUpdateNotSupported
+// is returned describing why.
+func ValidateNetInfoUpdate(current, updated BasicNetInfo) error {
+	if !current.IsSecondary() || !updated.IsSecondary() {
+		return fmt.Errorf("%w: the default network can not be updated", ErrNetInfoUpdateNotSupported)
+	}
+	if current.GetNetworkName() != updated.GetNetworkName() {
+		return fmt.Errorf("%w: network name changed from %s to %s", ErrNetInfoUpdateNotSupported,
+			current.GetNetworkName(), updated.GetNetworkName())
+	}
+	if current.TopologyType() != updated.TopologyType() {
+		return fmt.Errorf("%w: topology changed from %s to %s", ErrNetInfoUpdateNotSupported,
+			current.TopologyType(), updated.TopologyType())
+	}
+	if current.Vlan() != updated.Vlan() {
+		return fmt.Errorf("%w: VLAN ID changed from %d to %d", ErrNetInfoUpdateNotSupported,
+			current.Vlan(), updated.Vlan())
+	}
+	if current.AllowsPersistentIPs() != updated.AllowsPersistentIPs() {
+		return fmt.Errorf("%w: persistent IPs changed from %t to %t", ErrNetInfoUpdateNotSupported,
+			current.AllowsPersistentIPs(), updated.AllowsPersistentIPs())
+	}
+	if current.HasGateway() != updated.HasGateway() {
+		return fmt.Errorf("%w: gateway changed from %t to %t", ErrNetInfoUpdateNotSupported,
+			current.HasGateway(), updated.HasGateway())
+	}
+
+	currentSubnets := current.Subnets()
+	if len(currentSubnets) == 0 && len(updated.Subnets()) > 0 {
+		return fmt.Errorf("%w: subnets can not be added to a network without IPAM", ErrNetInfoUpdateNotSupported)
+	}
+	currentV4Mode, currentV6Mode := current.IPMode()
+	updatedV4Mode, updatedV6Mode := updated.IPMode()
+	if currentV4Mode != updatedV4Mode || currentV6Mode != updatedV6Mode {
+		return fmt.Errorf("%w: IP families of the subnets changed", ErrNetInfoUpdateNotSupported)
+	}
+	updatedSubnets := sets.New[string]()
+	for _, subnet := range updated.Subnets() {
+		updatedSubnets.Insert(subnet.String())
+	}
+	for _, subnet := range currentSubnets {
+		if !updatedSubnets.Has(subnet.String()) {
+			return fmt.Errorf("%w: subnet %s was removed or modified", ErrNetInfoUpdateNotSupported, subnet)
+		}
+	}
+
+	updatedExcludeSubnets := sets.New[string]()
+	for _, excludeSubnet := range updated.ExcludeSubnets() {
+		updatedExcludeSubnets.Insert(excludeSubnet.String())
+	}
+	for _, excludeSubnet := range current.ExcludeSubnets() {
+		if !updatedExcludeSubnets.Has(excludeSubnet.String()) {
+			return fmt.Errorf("%w: excluded subnet %s was removed", ErrNetInfoUpdateNotSupported, excludeSubnet)
+		}
+	}
+
+	return nil
+}
+
+// CopyNetInfo returns a copy of the given network information that does not
+// share any state with it and has no NADs
+func CopyNetInfo(netInfo NetInfo) NetInfo {
+	nInfo, ok := netInfo.(*secondaryNetInfo)
+	if !ok {
+		return netInfo
+	}
+	nInfo.mutableNetInfoLock.RLock()
+	defer nInfo.mutableNetInfoLock.RUnlock()
+	return &secondaryNetInfo{
+		netName:            nInfo.netName,
+		topology:           nInfo.topology,
+		vlan:               nInfo.vlan,
+		allowPersistentIPs: nInfo.allowPersistentIPs,
+		gateway:            nInfo.gateway,
+		ipv4mode:           nInfo.ipv4mode,
+		ipv6mode:           nInfo.ipv6mode,
+		mtu:                nInfo.mtu,
+		subnets:            nInfo.subnets,
+		excludeSubnets:     nInfo.excludeSubnets,
+	}
+}
+
+// UpdateNetInfo updates in place the network information current with the
+// compatible changes of updated as validated by ValidateNetInfoUpdate.
+func UpdateNetInfo(current NetInfo, updated BasicNetInfo) error {
+	if err := ValidateNetInfoUpdate(current, updated); err != nil {
+		return err
+	}
+	nInfo, ok := current.(*secondaryNetInfo)
+	if !ok {
+		return fmt.Errorf("%w: unexpected network information type %T", ErrNetInfoUpdateNotSupported, current)
+	}
+	// copy the values out before grabbing the lock in case updated and
+	// current refer to the same object
+	mtu, subnets, excludeSubnets := updated.MTU(), updated.Subnets(), updated.ExcludeSubnets()
+	nInfo.mutableNetInfoLock.Lock()
+	defer nInfo.mutableNetInfoLock.Unlock()
+	nInfo.mtu = mtu
+	nInfo.subnets = subnets
+	nInfo.excludeSubnets = excludeSubnets
+	return nil
 }
 
 func newLayer3NetConfInfo(netconf *ovncnitypes.NetConf) (NetInfo, error) {
+	if netconf.AllowPersistentIPs {
+		return nil, fmt.Errorf("invalid %s netconf %s: persistent IPs are not supported", netconf.Topology, netconf.Name)
+	}
 	subnets, _, err := parseSubnets(netconf.Subnets, "", types.Layer3Topology)
 	if err != nil {
 		return nil, err
@@ -253,41 +409,50 @@ func newLayer3NetConfInfo(netconf *ovncnitypes.NetConf) (NetInfo, error) {
 		topology: types.Layer3Topology,
 		subnets:  subnets,
 		mtu:      netconf.MTU,
+		gateway:  netconf.EnableGateway,
 	}
 	ni.ipv4mode, ni.ipv6mode = getIPMode(subnets)
 	return ni, nil
 }
 
 func newLayer2NetConfInfo(netconf *ovncnitypes.NetConf) (NetInfo, error) {
+	if netconf.EnableGateway {
+		return nil, fmt.Errorf("invalid %s netconf %s: gateway is not supported", netconf.Topology, netconf.Name)
+	}
 	subnets, excludes, err := parseSubnets(netconf.Subnets, netconf.ExcludeSubnets, types.Layer2Topology)
 	if err != nil {
 		return nil, fmt.Errorf("invalid %s netconf %s: %v", netconf.Topology, netconf.Name, err)
 	}
 
 	ni := &secondaryNetInfo{
-		netName:        netconf.Name,
-		topology:       types.Layer2Topology,
-		subnets:        subnets,
-		excludeSubnets: excludes,
-		mtu:            netconf.MTU,
+		netName:            netconf.Name,
+		topology:           types.Layer2Topology,
+		subnets:            subnets,
+		excludeSubnets:     excludes,
+		mtu:                netconf.MTU,
+		allowPersistentIPs: netconf.AllowPersistentIPs,
 	}
 	ni.ipv4mode, ni.ipv6mode = getIPMode(subnets)
 	return ni, nil
 }
 
 func newLocalnetNetConfInfo(netconf *ovncnitypes.NetConf) (NetInfo, error) {
+	if netconf.EnableGateway {
+		return nil, fmt.Errorf("invalid %s netconf %s: gateway is not supported", netconf.Topology, netconf.Name)
+	}
 	subnets, excludes, err := parseSubnets(netconf.Subnets, netconf.ExcludeSubnets, types.LocalnetTopology)
 	if err != nil {
 		return nil, fmt.Errorf("invalid %s netconf %s: %v", netconf.Topology, netconf.Name, err)
 	}
 
 	ni := &secondaryNetInfo{
-		netName:        netconf.Name,
-		topology:       types.LocalnetTopology,
-		subnets:        subnets,
-		excludeSubnets: excludes,
-		mtu:            netconf.MTU,
-		vlan:           uint(netconf.VLANID),
+		netName:            netconf.Name,
+		topology:           types.LocalnetTopology,
+		subnets:            subnets,
+		excludeSubnets:     excludes,
+		mtu:                netconf.MTU,
+		vlan:               uint(netconf.VLANID),
+		allowPersistentIPs: netconf.AllowPersistentIPs,
 	}
 	ni.ipv4mode, ni.ipv6mode = getIPMode(subnets)
 	return ni, nil
@@ -481,6 +646,24 @@ func IsMultiNetworkPoliciesSupportEnabled() bool {
 	return config.OVNKubernetesFeature.EnableMultiNetwork && config.OVNKubernetesFeature.EnableMultiNetworkPolicy
 }
 
+func IsMultiNetworkServicesSupportEnabled() bool {
+	return config.OVNKubernetesFeature.EnableMultiNetwork && config.OVNKubernetesFeature.EnableMultiNetworkServices
+}
+
+// IsPersistentIPsEnabled returns whether the IPs of workloads attached to
+// secondary networks can be persisted in IPAMClaims. Persistent IPs are handled
+// by the cluster manager, which only allocates pod IPs with interconnect.
+func IsPersistentIPsEnabled() bool {
+	return config.OVNKubernetesFeature.EnableMultiNetwork && config.OVNKubernetesFeature.EnableInterconnect &&
+		config.OVNKubernetesFeature.EnablePersistentIPs
+}
+
+// AllowsPersistentIPs returns whether the IPs of the workloads attached to the
+// given network are persisted in IPAMClaims
+func AllowsPersistentIPs(netInfo NetInfo) bool {
+	return IsPersistentIPsEnabled() && netInfo.AllowsPersistentIPs() && DoesNetworkRequireIPAM(netInfo)
+}
+
 func DoesNetworkRequireIPAM(netInfo NetInfo) bool {
 	return !((netInfo.TopologyType() == types.Layer2Topology || netInfo.TopologyType() == types.LocalnetTopology) && len(netInfo.Subnets()) == 0)
 }
@@ -242,6 +242,48 @@ func TestParseNetconf(t *testing.T) {
 				NetConf:  cnitypes.NetConf{Name: "tenantred", Type: "ovn-k8s-cni-overlay"},
 			},
 		},
+		{
+			desc: "valid attachment definition for a layer2 topology with persistent IPs",
+			inputNetAttachDefConfigSpec: `
+    {
+            "name": "tenantred",
+            "type": "ovn-k8s-cni-overlay",
+            "topology": "layer2",
+            "subnets": "192.168.200.0/16",
+            "allowPersistentIPs": true,
+            "netAttachDefName": "ns1/nad1"
+    }
+`,
+			expectedNetConf: &ovncnitypes.NetConf{
+				Topology:           "layer2",
+				NADName:            "ns1/nad1",
+				MTU:                1400,
+				Subnets:            "192.168.200.0/16",
+				AllowPersistentIPs: true,
+				NetConf:            cnitypes.NetConf{Name: "tenantred", Type: "ovn-k8s-cni-overlay"},
+			},
+		},
+		{
+			desc: "valid attachment definition for a layer3 topology with a gateway",
+			inputNetAttachDefConfigSpec: `
+    {
+            "name": "tenantred",
+            "type": "ovn-k8s-cni-overlay",
+            "topology": "layer3",
+            "subnets": "10.128.0.0/16/24",
+            "enableGateway": true,
+            "netAttachDefName": "ns1/nad1"
+    }
+`,
+			expectedNetConf: &ovncnitypes.NetConf{
+				Topology:      "layer3",
+				NADName:       "ns1/nad1",
+				MTU:           1400,
+				Subnets:       "10.128.0.0/16/24",
+				EnableGateway: true,
+				NetConf:       cnitypes.NetConf{Name: "tenantred", Type: "ovn-k8s-cni-overlay"},
+			},
+		},
 		{
 			desc: "valid attachment definition for the default network",
 			inputNetAttachDefConfigSpec: `
@@ -305,6 +347,158 @@ func TestParseNetconf(t *testing.T) {
 	}
 }
 
+func TestValidateNetInfoUpdate(t *testing.T) {
+	tests := []struct {
+		desc          string
+		current       *ovncnitypes.NetConf
+		updated       *ovncnitypes.NetConf
+		expectedError bool
+	}{
+		{
+			desc:    "no changes",
+			current: &ovncnitypes.NetConf{Topology: types.Layer2Topology, Subnets: "192.168.1.0/24"},
+			updated: &ovncnitypes.NetConf{Topology: types.Layer2Topology, Subnets: "192.168.1.0/24"},
+		},
+		{
+			desc:    "MTU changed",
+			current: &ovncnitypes.NetConf{Topology: types.Layer2Topology, Subnets: "192.168.1.0/24", MTU: 1400},
+			updated: &ovncnitypes.NetConf{Topology: types.Layer2Topology, Subnets: "192.168.1.0/24", MTU: 9000},
+		},
+		{
+			desc:    "subnet added to layer2 network",
+			current: &ovncnitypes.NetConf{Topology: types.Layer2Topology, Subnets: "192.168.1.0/24"},
+			updated: &ovncnitypes.NetConf{Topology: types.Layer2Topology, Subnets: "192.168.1.0/24,192.168.2.0/24"},
+		},
+		{
+			desc:    "subnet added to layer3 network",
+			current: &ovncnitypes.NetConf{Topology: types.Layer3Topology, Subnets: "10.128.0.0/16/24"},
+			updated: &ovncnitypes.NetConf{Topology: types.Layer3Topology, Subnets: "10.128.0.0/16/24,10.129.0.0/16/24"},
+		},
+		{
+			desc:    "excluded subnet added to localnet network",
+			current: &ovncnitypes.NetConf{Topology: types.LocalnetTopology, Subnets: "192.168.1.0/24", ExcludeSubnets: "192.168.1.1/32"},
+			updated: &ovncnitypes.NetConf{Topology: types.LocalnetTopology, Subnets: "192.168.1.0/24", ExcludeSubnets: "192.168.1.1/32,192.168.1.128/25"},
+		},
+		{
+			desc:          "subnet removed",
+			current:       &ovncnitypes.NetConf{Topology: types.Layer2Topology, Subnets: "192.168.1.0/24,192.168.2.0/24"},
+			updated:       &ovncnitypes.NetConf{Topology: types.Layer2Topology, Subnets: "192.168.1.0/24"},
+			expectedError: true,
+		},
+		{
+			desc:          "host subnet length changed",
+			current:       &ovncnitypes.NetConf{Topology: types.Layer3Topology, Subnets: "10.128.0.0/16/24"},
+			updated:       &ovncnitypes.NetConf{Topology: types.Layer3Topology, Subnets: "10.128.0.0/16/25"},
+			expectedError: true,
+		},
+		{
+			desc:          "excluded subnet removed",
+			current:       &ovncnitypes.NetConf{Topology: types.Layer2Topology, Subnets: "192.168.1.0/24", ExcludeSubnets: "192.168.1.1/32"},
+			updated:       &ovncnitypes.NetConf{Topology: types.Layer2Topology, Subnets: "192.168.1.0/24"},
+			expectedError: true,
+		},
+		{
+			desc:          "IP family added",
+			current:       &ovncnitypes.NetConf{Topology: types.Layer2Topology, Subnets: "192.168.1.0/24"},
+			updated:       &ovncnitypes.NetConf{Topology: types.Layer2Topology, Subnets: "192.168.1.0/24,fda6::/48"},
+			expectedError: true,
+		},
+		{
+			desc:          "subnets added to a network without IPAM",
+			current:       &ovncnitypes.NetConf{Topology: types.LocalnetTopology},
+			updated:       &ovncnitypes.NetConf{Topology: types.LocalnetTopology, Subnets: "192.168.1.0/24"},
+			expectedError: true,
+		},
+		{
+			desc:          "VLAN changed",
+			current:       &ovncnitypes.NetConf{Topology: types.LocalnetTopology, VLANID: 10},
+			updated:       &ovncnitypes.NetConf{Topology: types.LocalnetTopology, VLANID: 20},
+			expectedError: true,
+		},
+		{
+			desc:          "persistent IPs changed",
+			current:       &ovncnitypes.NetConf{Topology: types.Layer2Topology, Subnets: "192.168.1.0/24"},
+			updated:       &ovncnitypes.NetConf{Topology: types.Layer2Topology, Subnets: "192.168.1.0/24", AllowPersistentIPs: true},
+			expectedError: true,
+		},
+		{
+			desc:          "gateway changed",
+			current:       &ovncnitypes.NetConf{Topology: types.Layer3Topology, Subnets: "10.128.0.0/16/24"},
+			updated:       &ovncnitypes.NetConf{Topology: types.Layer3Topology, Subnets: "10.128.0.0/16/24", EnableGateway: true},
+			expectedError: true,
+		},
+		{
+			desc:          "topology changed",
+			current:       &ovncnitypes.NetConf{Topology: types.Layer2Topology, Subnets: "192.168.1.0/24"},
+			updated:       &ovncnitypes.NetConf{Topology: types.LocalnetTopology, Subnets: "192.168.1.0/24"},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tc.current.Name = "tenantred"
+			tc.updated.Name = "tenantred"
+			current, err := NewNetInfo(tc.current)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			updated, err := NewNetInfo(tc.updated)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+
+			err = UpdateNetInfo(current, updated)
+			if tc.expectedError {
+				g.Expect(err).To(gomega.MatchError(ErrNetInfoUpdateNotSupported))
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(current.CompareNetInfo(updated)).To(gomega.BeTrue())
+		})
+	}
+}
+
+func TestNewNetInfoGateway(t *testing.T) {
+	tests := []struct {
+		desc            string
+		netconf         *ovncnitypes.NetConf
+		expectedGateway bool
+		expectedError   bool
+	}{
+		{
+			desc:    "layer3 network without a gateway",
+			netconf: &ovncnitypes.NetConf{Topology: types.Layer3Topology, Subnets: "10.128.0.0/16/24"},
+		},
+		{
+			desc:            "layer3 network with a gateway",
+			netconf:         &ovncnitypes.NetConf{Topology: types.Layer3Topology, Subnets: "10.128.0.0/16/24", EnableGateway: true},
+			expectedGateway: true,
+		},
+		{
+			desc:          "layer2 network with a gateway",
+			netconf:       &ovncnitypes.NetConf{Topology: types.Layer2Topology, Subnets: "192.168.1.0/24", EnableGateway: true},
+			expectedError: true,
+		},
+		{
+			desc:          "localnet network with a gateway",
+			netconf:       &ovncnitypes.NetConf{Topology: types.LocalnetTopology, Subnets: "192.168.1.0/24", EnableGateway: true},
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			tc.netconf.Name = "tenantred"
+			netInfo, err := NewNetInfo(tc.netconf)
+			if tc.expectedError {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(netInfo.HasGateway()).To(gomega.Equal(tc.expectedGateway))
+		})
+	}
+}
+
 func applyNADDefaults(nad *nadv1.NetworkAttachmentDefinition) *nadv1.NetworkAttachmentDefinition {
 	const (
 		name      = "nad1"
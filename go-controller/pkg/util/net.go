@@ -62,6 +62,31 @@ func GetNodeHybridOverlayIfAddr(subnet *net.IPNet) *net.IPNet {
 	return &net.IPNet{IP: iputils.NextIP(mgmtIfAddr.IP), Mask: subnet.Mask}
 }
 
+// GetNodeServiceMonitorIfAddr returns the node logical switch address used as
+// source of the OVN load balancer health checks (the address before the last
+// one of the subnet), return nil if the subnet is invalid
+func GetNodeServiceMonitorIfAddr(subnet *net.IPNet) *net.IPNet {
+	if subnet == nil {
+		return nil
+	}
+	subnetIP := subnet.IP
+	if len(subnet.Mask) == net.IPv4len {
+		subnetIP = subnetIP.To4()
+	}
+	if len(subnetIP) != len(subnet.Mask) {
+		return nil
+	}
+	lastIP := make(net.IP, len(subnetIP))
+	for i := range subnetIP {
+		lastIP[i] = subnetIP[i] | ^subnet.Mask[i]
+	}
+	ip := iputils.PrevIP(lastIP)
+	if ip == nil || !subnet.Contains(ip) {
+		return nil
+	}
+	return &net.IPNet{IP: ip, Mask: subnet.Mask}
+}
+
 // IsNodeHybridOverlayIfAddr returns whether the provided IP is a node hybrid
 // overlay address on any of the provided subnets
 func IsNodeHybridOverlayIfAddr(ip net.IP, subnets []*net.IPNet) bool {
@@ -224,6 +249,25 @@ func MatchAllIPNetFamily(isIPv6 bool, ipnets []*net.IPNet) []*net.IPNet {
 	return ret
 }
 
+// MatchFirstIPNetOfEachFamily loops through the array of *net.IPNet and
+// returns the first ipnet of each IP family, in their original order.
+func MatchFirstIPNetOfEachFamily(ipnets []*net.IPNet) []*net.IPNet {
+	var ret []*net.IPNet
+	foundIPv4, foundIPv6 := false, false
+	for _, ipnet := range ipnets {
+		if utilnet.IsIPv6CIDR(ipnet) {
+			if !foundIPv6 {
+				foundIPv6 = true
+				ret = append(ret, ipnet)
+			}
+		} else if !foundIPv4 {
+			foundIPv4 = true
+			ret = append(ret, ipnet)
+		}
+	}
+	return ret
+}
+
 // MatchIPStringFamily loops through the array of string and returns the
 // first entry in the list in the same IP Family, based on input flag isIPv6.
 func MatchIPStringFamily(isIPv6 bool, ipStrings []string) (string, error) {
@@ -0,0 +1,716 @@
+//go:build linux
+// +build linux
+
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/klog/v2"
+	kexec "k8s.io/utils/exec"
+)
+
+const (
+	// NFTablesFamily is the family of the tables owning the ovn-kubernetes nftables objects
+	NFTablesFamily = "inet"
+
+	nftCommand = "nft"
+)
+
+// NFTablesHelper is an interface that applies nftables transactions to a
+// table, allowing mock implementations for unit testing
+type NFTablesHelper interface {
+	// Run applies all the operations of the transaction atomically: either
+	// all of them succeed or none of them is applied
+	Run(tx *NFTTransaction) error
+	// ListSetElements returns the elements of a set or map, as a map of their
+	// key to their value. Keys and values are rendered with their fields
+	// joined by " . ".
+	ListSetElements(set *NFTSet) (map[string]string, error)
+}
+
+// NFTObject is an object of a table that can be part of a transaction
+type NFTObject interface {
+	render(verb nftVerb, table string) (string, error)
+}
+
+type nftVerb string
+
+const (
+	nftAdd    nftVerb = "add"
+	nftFlush  nftVerb = "flush"
+	nftDelete nftVerb = "delete"
+)
+
+// NFTTable is the table of the helper itself
+type NFTTable struct{}
+
+// NFTChain is a chain of the table. Chains with a Type are base
+// chains attached to the Hook with the given Priority, the others are regular
+// chains that can only be reached through jump or goto verdicts.
+type NFTChain struct {
+	Name     string
+	Type     string
+	Hook     string
+	Priority string
+}
+
+// NFTSet is a set of the table, or a map if ValueType is set.
+// KeyType and ValueType are nftables data types, concatenated with " . ".
+type NFTSet struct {
+	Name      string
+	KeyType   string
+	ValueType string
+}
+
+// NFTRule is a rule appended to a chain of the table
+type NFTRule struct {
+	Chain   string
+	Rule    string
+	Comment string
+}
+
+// NFTElement is an element of a set, or of a map if Value is set
+type NFTElement struct {
+	Set   string
+	Key   []string
+	Value []string
+}
+
+func nftObjectPrefix(verb nftVerb, kind, table string) string {
+	return fmt.Sprintf("%s %s %s %s", verb, kind, NFTablesFamily, table)
+}
+
+func (t *NFTTable) render(verb nftVerb, table string) (string, error) {
+	if verb == nftFlush {
+		return "", fmt.Errorf("flushing the %s table is not supported", table)
+	}
+	return nftObjectPrefix(verb, "table", table), nil
+}
+
+func (c *NFTChain) render(verb nftVerb, table string) (string, error) {
+	cmd := fmt.Sprintf("%s %s", nftObjectPrefix(verb, "chain", table), c.Name)
+	if verb == nftAdd && c.Type != "" {
+		cmd += fmt.Sprintf(" { type %s hook %s priority %s ; }", c.Type, c.Hook, c.Priority)
+	}
+	return cmd, nil
+}
+
+func (s *NFTSet) kind() string {
+	if s.ValueType != "" {
+		return "map"
+	}
+	return "set"
+}
+
+func (s *NFTSet) render(verb nftVerb, table string) (string, error) {
+	cmd := fmt.Sprintf("%s %s", nftObjectPrefix(verb, s.kind(), table), s.Name)
+	if verb == nftAdd {
+		if s.ValueType != "" {
+			cmd += fmt.Sprintf(" { type %s : %s ; }", s.KeyType, s.ValueType)
+		} else {
+			cmd += fmt.Sprintf(" { type %s ; }", s.KeyType)
+		}
+	}
+	return cmd, nil
+}
+
+func (r *NFTRule) render(verb nftVerb, table string) (string, error) {
+	if verb != nftAdd {
+		return "", fmt.Errorf("rules can only be added, flush or delete chain %s instead", r.Chain)
+	}
+	cmd := fmt.Sprintf("%s %s %s", nftObjectPrefix(verb, "rule", table), r.Chain, r.Rule)
+	if r.Comment != "" {
+		cmd += fmt.Sprintf(" comment %q", r.Comment)
+	}
+	return cmd, nil
+}
+
+func (e *NFTElement) key() string {
+	return strings.Join(e.Key, " . ")
+}
+
+func (e *NFTElement) value() string {
+	return strings.Join(e.Value, " . ")
+}
+
+func (e *NFTElement) render(verb nftVerb, table string) (string, error) {
+	if verb == nftFlush {
+		return "", fmt.Errorf("flushing an element of %s is not supported", e.Set)
+	}
+	element := e.key()
+	if verb == nftAdd && len(e.Value) > 0 {
+		element += " : " + e.value()
+	}
+	return fmt.Sprintf("%s %s { %s }", nftObjectPrefix(verb, "element", table), e.Set, element), nil
+}
+
+type nftOperation struct {
+	verb nftVerb
+	obj  NFTObject
+}
+
+// NFTTransaction holds a list of operations on the table of an NFTablesHelper
+// that are applied atomically by NFTablesHelper.Run
+type NFTTransaction struct {
+	operations []nftOperation
+}
+
+// NewNFTTransaction returns an empty transaction
+func NewNFTTransaction() *NFTTransaction {
+	return &NFTTransaction{}
+}
+
+// Add adds the object, which is a no-op if it already exists
+func (tx *NFTTransaction) Add(obj NFTObject) {
+	tx.operations = append(tx.operations, nftOperation{verb: nftAdd, obj: obj})
+}
+
+// Flush removes all the rules of a chain or all the elements of a set or map
+func (tx *NFTTransaction) Flush(obj NFTObject) {
+	tx.operations = append(tx.operations, nftOperation{verb: nftFlush, obj: obj})
+}
+
+// Delete deletes the object, which must exist
+func (tx *NFTTransaction) Delete(obj NFTObject) {
+	tx.operations = append(tx.operations, nftOperation{verb: nftDelete, obj: obj})
+}
+
+// Remove deletes the object whether it exists or not, by adding it before deleting it
+func (tx *NFTTransaction) Remove(obj NFTObject) {
+	tx.Add(obj)
+	if chain, ok := obj.(*NFTChain); ok {
+		// a chain can only be deleted when it has no rules
+		tx.Flush(chain)
+	}
+	tx.Delete(obj)
+}
+
+// Len returns the number of operations of the transaction
+func (tx *NFTTransaction) Len() int {
+	return len(tx.operations)
+}
+
+// Render returns the nft script of the transaction on the given table
+func (tx *NFTTransaction) Render(table string) (string, error) {
+	var script strings.Builder
+	for _, op := range tx.operations {
+		cmd, err := op.obj.render(op.verb, table)
+		if err != nil {
+			return "", err
+		}
+		script.WriteString(cmd)
+		script.WriteString("\n")
+	}
+	return script.String(), nil
+}
+
+// execNFTables applies transactions by feeding their script to "nft -f -",
+// which commits all its commands in a single netlink batch
+type execNFTables struct {
+	exec  kexec.Interface
+	path  string
+	table string
+}
+
+func newExecNFTables(exec kexec.Interface, table string) (*execNFTables, error) {
+	path, err := exec.LookPath(nftCommand)
+	if err != nil {
+		return nil, err
+	}
+	return &execNFTables{exec: exec, path: path, table: table}, nil
+}
+
+func (n *execNFTables) Run(tx *NFTTransaction) error {
+	if tx.Len() == 0 {
+		return nil
+	}
+	script, err := tx.Render(n.table)
+	if err != nil {
+		return err
+	}
+	klog.V(5).Infof("Running nftables transaction:\n%s", script)
+	cmd := n.exec.Command(n.path, "-f", "-")
+	cmd.SetStdin(strings.NewReader(script))
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("failed to run nftables transaction: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+func (n *execNFTables) ListSetElements(set *NFTSet) (map[string]string, error) {
+	cmd := n.exec.Command(n.path, "-j", "list", set.kind(), NFTablesFamily, n.table, set.Name)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the elements of %s %s: %v", set.kind(), set.Name, err)
+	}
+	return parseNFTSetElements(out, set)
+}
+
+// parseNFTSetElements returns the elements of the set or map listed in the
+// JSON output of "nft -j list set|map"
+func parseNFTSetElements(out []byte, set *NFTSet) (map[string]string, error) {
+	var listing struct {
+		NFTables []map[string]struct {
+			Name string            `json:"name"`
+			Elem []json.RawMessage `json:"elem"`
+		} `json:"nftables"`
+	}
+	decoder := json.NewDecoder(bytes.NewReader(out))
+	decoder.UseNumber()
+	if err := decoder.Decode(&listing); err != nil {
+		return nil, fmt.Errorf("failed to parse the elements of %s %s: %v", set.kind(), set.Name, err)
+	}
+	elements := map[string]string{}
+	for _, object := range listing.NFTables {
+		listed, ok := object[set.kind()]
+		if !ok || listed.Name != set.Name {
+			continue
+		}
+		for _, elem := range listed.Elem {
+			var key, value interface{}
+			if set.ValueType != "" {
+				var pair []interface{}
+				if err := unmarshalNFTJSON(elem, &pair); err != nil || len(pair) != 2 {
+					return nil, fmt.Errorf("failed to parse element %s of map %s", elem, set.Name)
+				}
+				key, value = pair[0], pair[1]
+			} else if err := unmarshalNFTJSON(elem, &key); err != nil {
+				return nil, fmt.Errorf("failed to parse element %s of set %s", elem, set.Name)
+			}
+			elements[nftJSONExpression(key)] = nftJSONExpression(value)
+		}
+	}
+	return elements, nil
+}
+
+func unmarshalNFTJSON(data []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// nftJSONExpression renders an expression of the nftables JSON format the way
+// it is written in element keys and values
+func nftJSONExpression(expr interface{}) string {
+	switch e := expr.(type) {
+	case nil:
+		return ""
+	case string:
+		return e
+	case json.Number:
+		return e.String()
+	case map[string]interface{}:
+		if parts, ok := e["concat"].([]interface{}); ok {
+			var fields []string
+			for _, part := range parts {
+				fields = append(fields, nftJSONExpression(part))
+			}
+			return strings.Join(fields, " . ")
+		}
+		if elem, ok := e["elem"].(map[string]interface{}); ok {
+			// elements with options such as timeouts or counters
+			return nftJSONExpression(elem["val"])
+		}
+		if prefix, ok := e["prefix"].(map[string]interface{}); ok {
+			return nftJSONExpression(prefix["addr"]) + "/" + nftJSONExpression(prefix["len"])
+		}
+		for _, verdict := range []string{"goto", "jump"} {
+			if target, ok := e[verdict].(map[string]interface{}); ok {
+				return verdict + " " + nftJSONExpression(target["target"])
+			}
+		}
+		for _, verdict := range []string{"accept", "drop", "continue", "return"} {
+			if _, ok := e[verdict]; ok {
+				return verdict
+			}
+		}
+	}
+	return fmt.Sprintf("%v", expr)
+}
+
+var nftHelpers = make(map[string]NFTablesHelper)
+
+// SetNFTablesHelper sets the NFTablesHelper to be used for the table
+func SetNFTablesHelper(table string, nft NFTablesHelper) {
+	nftHelpers[table] = nft
+}
+
+// GetNFTablesHelper returns an NFTablesHelper for the table. If SetNFTablesHelper has
+// not yet been called, it will create a new NFTablesHelper running the "live" nft binary
+func GetNFTablesHelper(table string) (NFTablesHelper, error) {
+	if nftHelpers[table] == nil {
+		nft, err := newExecNFTables(kexec.New(), table)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create NFTablesHelper for table %s: %v", table, err)
+		}
+		SetNFTablesHelper(table, nft)
+	}
+	return nftHelpers[table], nil
+}
+
+type fakeNFTChain struct {
+	chain NFTChain
+	rules []string
+}
+
+type fakeNFTSet struct {
+	set      NFTSet
+	elements map[string]string
+}
+
+type fakeNFTTable struct {
+	chains map[string]*fakeNFTChain
+	sets   map[string]*fakeNFTSet
+}
+
+func (t *fakeNFTTable) clone() *fakeNFTTable {
+	if t == nil {
+		return nil
+	}
+	c := &fakeNFTTable{
+		chains: make(map[string]*fakeNFTChain, len(t.chains)),
+		sets:   make(map[string]*fakeNFTSet, len(t.sets)),
+	}
+	for name, chain := range t.chains {
+		c.chains[name] = &fakeNFTChain{chain: chain.chain, rules: append([]string(nil), chain.rules...)}
+	}
+	for name, set := range t.sets {
+		elements := make(map[string]string, len(set.elements))
+		for k, v := range set.elements {
+			elements[k] = v
+		}
+		c.sets[name] = &fakeNFTSet{set: set.set, elements: elements}
+	}
+	return c
+}
+
+// references returns the sets and chains a rule or a map value refers to
+func nftReferences(expr string) (sets []string, chains []string) {
+	fields := strings.Fields(expr)
+	for i, field := range fields {
+		if strings.HasPrefix(field, "@") {
+			sets = append(sets, strings.TrimPrefix(field, "@"))
+		}
+		if (field == "jump" || field == "goto") && i+1 < len(fields) {
+			chains = append(chains, fields[i+1])
+		}
+	}
+	return sets, chains
+}
+
+func (t *fakeNFTTable) isReferenced(chain, set string) bool {
+	matches := func(expr string) bool {
+		sets, chains := nftReferences(expr)
+		for _, s := range sets {
+			if s == set {
+				return true
+			}
+		}
+		for _, c := range chains {
+			if c == chain {
+				return true
+			}
+		}
+		return false
+	}
+	for _, c := range t.chains {
+		for _, rule := range c.rules {
+			if matches(rule) {
+				return true
+			}
+		}
+	}
+	for _, s := range t.sets {
+		for _, value := range s.elements {
+			if matches(value) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (t *fakeNFTTable) checkReferences(expr string) error {
+	sets, chains := nftReferences(expr)
+	for _, set := range sets {
+		if _, ok := t.sets[set]; !ok {
+			return fmt.Errorf("%q refers to set %s that does not exist", expr, set)
+		}
+	}
+	for _, chain := range chains {
+		if _, ok := t.chains[chain]; !ok {
+			return fmt.Errorf("%q refers to chain %s that does not exist", expr, chain)
+		}
+	}
+	return nil
+}
+
+// FakeNFTables is an in-memory implementation of NFTablesHelper for unit tests.
+// Transactions are validated like the kernel would and applied atomically.
+type FakeNFTables struct {
+	sync.Mutex
+	name string
+	// table is nil when the table does not exist
+	table *fakeNFTTable
+}
+
+// SetFakeNFTablesHelper sets a FakeNFTables as the NFTablesHelper of the table to be used in unit tests
+func SetFakeNFTablesHelper(table string) *FakeNFTables {
+	nft := NewFakeNFTables(table)
+	SetNFTablesHelper(table, nft)
+	return nft
+}
+
+// NewFakeNFTables returns a FakeNFTables for a table that does not exist yet
+func NewFakeNFTables(table string) *FakeNFTables {
+	return &FakeNFTables{name: table}
+}
+
+func (f *FakeNFTables) Run(tx *NFTTransaction) error {
+	f.Lock()
+	defer f.Unlock()
+	table := f.table.clone()
+	for _, op := range tx.operations {
+		var err error
+		table, err = applyFakeNFTOperation(table, op)
+		if err != nil {
+			cmd, _ := op.obj.render(op.verb, f.name)
+			return fmt.Errorf("nftables transaction failed at %q: %v", cmd, err)
+		}
+	}
+	f.table = table
+	return nil
+}
+
+func applyFakeNFTOperation(table *fakeNFTTable, op nftOperation) (*fakeNFTTable, error) {
+	// objects are rendered against a placeholder table name in errors and rule listings
+	const name = "fake"
+	if _, ok := op.obj.(*NFTTable); ok {
+		switch op.verb {
+		case nftAdd:
+			if table == nil {
+				table = &fakeNFTTable{chains: map[string]*fakeNFTChain{}, sets: map[string]*fakeNFTSet{}}
+			}
+			return table, nil
+		case nftDelete:
+			if table == nil {
+				return nil, fmt.Errorf("table does not exist")
+			}
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unsupported operation %s on table", op.verb)
+	}
+	if table == nil {
+		return nil, fmt.Errorf("table does not exist")
+	}
+
+	switch obj := op.obj.(type) {
+	case *NFTChain:
+		existing, exists := table.chains[obj.Name]
+		switch op.verb {
+		case nftAdd:
+			if !exists {
+				table.chains[obj.Name] = &fakeNFTChain{chain: *obj}
+			} else if existing.chain != *obj {
+				return nil, fmt.Errorf("chain exists with a different type, hook or priority")
+			}
+			return table, nil
+		case nftFlush:
+			if !exists {
+				return nil, fmt.Errorf("chain does not exist")
+			}
+			existing.rules = nil
+			return table, nil
+		case nftDelete:
+			if !exists {
+				return nil, fmt.Errorf("chain does not exist")
+			}
+			if len(existing.rules) > 0 || table.isReferenced(obj.Name, "") {
+				return nil, fmt.Errorf("chain is busy")
+			}
+			delete(table.chains, obj.Name)
+			return table, nil
+		}
+	case *NFTSet:
+		existing, exists := table.sets[obj.Name]
+		switch op.verb {
+		case nftAdd:
+			if !exists {
+				table.sets[obj.Name] = &fakeNFTSet{set: *obj, elements: map[string]string{}}
+			} else if existing.set != *obj {
+				return nil, fmt.Errorf("%s exists with a different type", obj.kind())
+			}
+			return table, nil
+		case nftFlush:
+			if !exists {
+				return nil, fmt.Errorf("%s does not exist", obj.kind())
+			}
+			existing.elements = map[string]string{}
+			return table, nil
+		case nftDelete:
+			if !exists {
+				return nil, fmt.Errorf("%s does not exist", obj.kind())
+			}
+			if table.isReferenced("", obj.Name) {
+				return nil, fmt.Errorf("%s is busy", obj.kind())
+			}
+			delete(table.sets, obj.Name)
+			return table, nil
+		}
+	case *NFTRule:
+		chain, exists := table.chains[obj.Chain]
+		if !exists {
+			return nil, fmt.Errorf("chain does not exist")
+		}
+		if err := table.checkReferences(obj.Rule); err != nil {
+			return nil, err
+		}
+		rule, _ := obj.render(nftAdd, name)
+		chain.rules = append(chain.rules, strings.TrimPrefix(rule, nftObjectPrefix(nftAdd, "rule", name)+" "+obj.Chain+" "))
+		return table, nil
+	case *NFTElement:
+		set, exists := table.sets[obj.Set]
+		if !exists {
+			return nil, fmt.Errorf("set does not exist")
+		}
+		if len(obj.Key) != len(strings.Split(set.set.KeyType, " . ")) {
+			return nil, fmt.Errorf("key %q does not match type %q", obj.key(), set.set.KeyType)
+		}
+		value, found := set.elements[obj.key()]
+		switch op.verb {
+		case nftAdd:
+			if (set.set.ValueType == "") != (len(obj.Value) == 0) ||
+				(len(obj.Value) > 0 && len(obj.Value) != len(strings.Split(set.set.ValueType, " . "))) {
+				return nil, fmt.Errorf("value %q does not match type %q", obj.value(), set.set.ValueType)
+			}
+			if err := table.checkReferences(obj.value()); err != nil {
+				return nil, err
+			}
+			if found && value != obj.value() {
+				// the kernel refuses to silently replace the value of an existing element
+				return nil, fmt.Errorf("element exists with value %q", value)
+			}
+			set.elements[obj.key()] = obj.value()
+			return table, nil
+		case nftDelete:
+			if !found {
+				return nil, fmt.Errorf("element does not exist")
+			}
+			delete(set.elements, obj.key())
+			return table, nil
+		}
+	}
+	cmd, err := op.obj.render(op.verb, name)
+	if err != nil {
+		return nil, err
+	}
+	return nil, fmt.Errorf("unsupported operation %q", cmd)
+}
+
+func (f *FakeNFTables) ListSetElements(set *NFTSet) (map[string]string, error) {
+	return f.ListElements(set.Name)
+}
+
+// HasTable returns whether the table exists
+func (f *FakeNFTables) HasTable() bool {
+	f.Lock()
+	defer f.Unlock()
+	return f.table != nil
+}
+
+// ListChains returns the names of the chains of the table
+func (f *FakeNFTables) ListChains() []string {
+	f.Lock()
+	defer f.Unlock()
+	var chains []string
+	if f.table != nil {
+		for name := range f.table.chains {
+			chains = append(chains, name)
+		}
+	}
+	sort.Strings(chains)
+	return chains
+}
+
+// ListRules returns the rules of the chain, including their comment
+func (f *FakeNFTables) ListRules(chain string) ([]string, error) {
+	f.Lock()
+	defer f.Unlock()
+	if f.table == nil || f.table.chains[chain] == nil {
+		return nil, fmt.Errorf("chain %s does not exist", chain)
+	}
+	return append([]string{}, f.table.chains[chain].rules...), nil
+}
+
+// ListElements returns the elements of a set or map, as a map of their key to
+// their value. Keys and values are rendered with their fields joined by " . ".
+func (f *FakeNFTables) ListElements(set string) (map[string]string, error) {
+	f.Lock()
+	defer f.Unlock()
+	if f.table == nil || f.table.sets[set] == nil {
+		return nil, fmt.Errorf("set %s does not exist", set)
+	}
+	elements := make(map[string]string, len(f.table.sets[set].elements))
+	for k, v := range f.table.sets[set].elements {
+		elements[k] = v
+	}
+	return elements, nil
+}
+
+// Dump returns an nft script recreating the current content of the table
+func (f *FakeNFTables) Dump() string {
+	f.Lock()
+	defer f.Unlock()
+	if f.table == nil {
+		return ""
+	}
+	tx := NewNFTTransaction()
+	tx.Add(&NFTTable{})
+	var names []string
+	for name := range f.table.chains {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		chain := f.table.chains[name].chain
+		tx.Add(&chain)
+	}
+	var setNames []string
+	for name := range f.table.sets {
+		setNames = append(setNames, name)
+	}
+	sort.Strings(setNames)
+	for _, name := range setNames {
+		set := f.table.sets[name]
+		tx.Add(&set.set)
+		var keys []string
+		for key := range set.elements {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			element := &NFTElement{Set: name, Key: []string{key}}
+			if value := set.elements[key]; value != "" {
+				element.Value = []string{value}
+			}
+			tx.Add(element)
+		}
+	}
+	script, err := tx.Render(f.name)
+	if err != nil {
+		return err.Error()
+	}
+	for _, name := range names {
+		for _, rule := range f.table.chains[name].rules {
+			script += fmt.Sprintf("%s %s %s\n", nftObjectPrefix(nftAdd, "rule", f.name), name, rule)
+		}
+	}
+	return script
+}
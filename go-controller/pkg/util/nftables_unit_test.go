@@ -0,0 +1,279 @@
+//go:build linux
+// +build linux
+
+package util
+
+import (
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	kexec "k8s.io/utils/exec"
+	fakeexec "k8s.io/utils/exec/testing"
+)
+
+func newTestNFTTransaction() *NFTTransaction {
+	tx := NewNFTTransaction()
+	tx.Add(&NFTTable{})
+	tx.Add(&NFTChain{Name: "nat-prerouting", Type: "nat", Hook: "prerouting", Priority: "dstnat"})
+	tx.Add(&NFTChain{Name: "lb"})
+	tx.Add(&NFTSet{Name: "nodeports", KeyType: "inet_proto . inet_service", ValueType: "ipv4_addr . inet_service"})
+	tx.Add(&NFTSet{Name: "lbs", KeyType: "ipv4_addr", ValueType: "verdict"})
+	tx.Add(&NFTRule{Chain: "nat-prerouting", Rule: "meta nfproto ipv4 dnat ip to meta l4proto . th dport map @nodeports", Comment: "nodeports"})
+	tx.Add(&NFTRule{Chain: "nat-prerouting", Rule: "ip daddr vmap @lbs"})
+	tx.Add(&NFTElement{Set: "nodeports", Key: []string{"tcp", "30080"}, Value: []string{"10.96.0.10", "80"}})
+	tx.Add(&NFTElement{Set: "lbs", Key: []string{"1.1.1.1"}, Value: []string{"goto lb"}})
+	return tx
+}
+
+func TestNFTTransactionRender(t *testing.T) {
+	tx := newTestNFTTransaction()
+	tx.Delete(&NFTElement{Set: "nodeports", Key: []string{"tcp", "30080"}, Value: []string{"10.96.0.10", "80"}})
+	tx.Remove(&NFTChain{Name: "lb"})
+	tx.Flush(&NFTSet{Name: "lbs", KeyType: "ipv4_addr", ValueType: "verdict"})
+
+	expected := `add table inet ovn-kubernetes
+add chain inet ovn-kubernetes nat-prerouting { type nat hook prerouting priority dstnat ; }
+add chain inet ovn-kubernetes lb
+add map inet ovn-kubernetes nodeports { type inet_proto . inet_service : ipv4_addr . inet_service ; }
+add map inet ovn-kubernetes lbs { type ipv4_addr : verdict ; }
+add rule inet ovn-kubernetes nat-prerouting meta nfproto ipv4 dnat ip to meta l4proto . th dport map @nodeports comment "nodeports"
+add rule inet ovn-kubernetes nat-prerouting ip daddr vmap @lbs
+add element inet ovn-kubernetes nodeports { tcp . 30080 : 10.96.0.10 . 80 }
+add element inet ovn-kubernetes lbs { 1.1.1.1 : goto lb }
+delete element inet ovn-kubernetes nodeports { tcp . 30080 }
+add chain inet ovn-kubernetes lb
+flush chain inet ovn-kubernetes lb
+delete chain inet ovn-kubernetes lb
+flush map inet ovn-kubernetes lbs
+`
+	script, err := tx.Render("ovn-kubernetes")
+	assert.NoError(t, err)
+	assert.Equal(t, expected, script)
+
+	tx = NewNFTTransaction()
+	tx.Flush(&NFTRule{Chain: "lb", Rule: "accept"})
+	_, err = tx.Render("ovn-kubernetes")
+	assert.Error(t, err)
+}
+
+func TestExecNFTablesRun(t *testing.T) {
+	fcmd := fakeexec.FakeCmd{
+		CombinedOutputScript: []fakeexec.FakeAction{
+			func() ([]byte, []byte, error) { return nil, nil, nil },
+			func() ([]byte, []byte, error) {
+				return []byte("Error: No such file or directory"), nil, fmt.Errorf("exit status 1")
+			},
+		},
+	}
+	fexec := &fakeexec.FakeExec{
+		LookPathFunc: func(cmd string) (string, error) { return "/usr/sbin/" + cmd, nil },
+	}
+	for range fcmd.CombinedOutputScript {
+		fexec.CommandScript = append(fexec.CommandScript, func(cmd string, args ...string) kexec.Cmd {
+			assert.Equal(t, "/usr/sbin/nft", cmd)
+			assert.Equal(t, []string{"-f", "-"}, args)
+			return fakeexec.InitFakeCmd(&fcmd, cmd, args...)
+		})
+	}
+	nft, err := newExecNFTables(fexec, "ovn-kubernetes")
+	assert.NoError(t, err)
+
+	// empty transactions are not run
+	assert.NoError(t, nft.Run(NewNFTTransaction()))
+	assert.Equal(t, 0, fexec.CommandCalls)
+
+	tx := newTestNFTTransaction()
+	assert.NoError(t, nft.Run(tx))
+	stdin, _ := io.ReadAll(fcmd.Stdin)
+	script, err := tx.Render("ovn-kubernetes")
+	assert.NoError(t, err)
+	assert.Equal(t, script, string(stdin))
+
+	err = nft.Run(tx)
+	assert.EqualError(t, err, "failed to run nftables transaction: exit status 1: Error: No such file or directory")
+}
+
+func TestFakeNFTables(t *testing.T) {
+	tests := []struct {
+		desc        string
+		tx          func(tx *NFTTransaction)
+		errExp      bool
+		elementsExp map[string]string
+	}{
+		{
+			desc: "adding existing objects with the same value is a no-op",
+			tx: func(tx *NFTTransaction) {
+				tx.Add(&NFTElement{Set: "nodeports", Key: []string{"tcp", "30080"}, Value: []string{"10.96.0.10", "80"}})
+			},
+			elementsExp: map[string]string{"tcp . 30080": "10.96.0.10 . 80"},
+		},
+		{
+			desc: "adding an existing element with a different value fails",
+			tx: func(tx *NFTTransaction) {
+				tx.Add(&NFTElement{Set: "nodeports", Key: []string{"udp", "30081"}, Value: []string{"10.96.0.11", "53"}})
+				tx.Add(&NFTElement{Set: "nodeports", Key: []string{"tcp", "30080"}, Value: []string{"10.96.0.11", "80"}})
+			},
+			errExp:      true,
+			elementsExp: map[string]string{"tcp . 30080": "10.96.0.10 . 80"},
+		},
+		{
+			desc: "deleting a missing element fails atomically",
+			tx: func(tx *NFTTransaction) {
+				tx.Delete(&NFTElement{Set: "nodeports", Key: []string{"tcp", "30080"}})
+				tx.Delete(&NFTElement{Set: "nodeports", Key: []string{"tcp", "30081"}})
+			},
+			errExp:      true,
+			elementsExp: map[string]string{"tcp . 30080": "10.96.0.10 . 80"},
+		},
+		{
+			desc: "removing a missing element succeeds",
+			tx: func(tx *NFTTransaction) {
+				tx.Remove(&NFTElement{Set: "nodeports", Key: []string{"tcp", "30081"}, Value: []string{"10.96.0.11", "80"}})
+			},
+			elementsExp: map[string]string{"tcp . 30080": "10.96.0.10 . 80"},
+		},
+		{
+			desc: "key not matching the set type fails",
+			tx: func(tx *NFTTransaction) {
+				tx.Add(&NFTElement{Set: "nodeports", Key: []string{"30081"}, Value: []string{"10.96.0.11", "80"}})
+			},
+			errExp:      true,
+			elementsExp: map[string]string{"tcp . 30080": "10.96.0.10 . 80"},
+		},
+		{
+			desc:        "rule referring to a missing set fails",
+			tx:          func(tx *NFTTransaction) { tx.Add(&NFTRule{Chain: "nat-prerouting", Rule: "ip daddr @missing accept"}) },
+			errExp:      true,
+			elementsExp: map[string]string{"tcp . 30080": "10.96.0.10 . 80"},
+		},
+		{
+			desc:        "deleting a chain referred to by a map element fails",
+			tx:          func(tx *NFTTransaction) { tx.Remove(&NFTChain{Name: "lb"}) },
+			errExp:      true,
+			elementsExp: map[string]string{"tcp . 30080": "10.96.0.10 . 80"},
+		},
+		{
+			desc: "flushing and adding elements back is atomic",
+			tx: func(tx *NFTTransaction) {
+				tx.Flush(&NFTSet{Name: "nodeports", KeyType: "inet_proto . inet_service", ValueType: "ipv4_addr . inet_service"})
+				tx.Add(&NFTElement{Set: "nodeports", Key: []string{"tcp", "30080"}, Value: []string{"10.96.0.12", "8080"}})
+			},
+			elementsExp: map[string]string{"tcp . 30080": "10.96.0.12 . 8080"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			nft := NewFakeNFTables("ovn-kubernetes")
+			assert.NoError(t, nft.Run(newTestNFTTransaction()))
+			tx := NewNFTTransaction()
+			tc.tx(tx)
+			err := nft.Run(tx)
+			if tc.errExp {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			elements, err := nft.ListElements("nodeports")
+			assert.NoError(t, err)
+			assert.Equal(t, tc.elementsExp, elements)
+			rules, err := nft.ListRules("nat-prerouting")
+			assert.NoError(t, err)
+			assert.Len(t, rules, 2)
+		})
+	}
+}
+
+func TestFakeNFTablesDeleteTable(t *testing.T) {
+	nft := NewFakeNFTables("ovn-kubernetes")
+	tx := NewNFTTransaction()
+	tx.Delete(&NFTTable{})
+	assert.Error(t, nft.Run(tx))
+
+	assert.NoError(t, nft.Run(newTestNFTTransaction()))
+	assert.True(t, nft.HasTable())
+	assert.Equal(t, []string{"lb", "nat-prerouting"}, nft.ListChains())
+
+	tx = NewNFTTransaction()
+	tx.Remove(&NFTTable{})
+	assert.NoError(t, nft.Run(tx))
+	assert.False(t, nft.HasTable())
+	assert.Equal(t, "", nft.Dump())
+}
+
+func TestExecNFTablesListSetElements(t *testing.T) {
+	tests := []struct {
+		desc        string
+		set         *NFTSet
+		output      string
+		errExpected bool
+		expElements map[string]string
+	}{
+		{
+			desc: "concatenated keys and values of a map",
+			set:  &NFTSet{Name: "nodeports", KeyType: "inet_proto . inet_service", ValueType: "ipv4_addr . inet_service"},
+			output: `{"nftables": [{"metainfo": {"json_schema_version": 1}}, {"map": {"family": "inet", "name": "nodeports", ` +
+				`"table": "ovn-kubernetes", "type": ["inet_proto", "inet_service"], "map": ["ipv4_addr", "inet_service"], ` +
+				`"elem": [[{"concat": ["tcp", 30080]}, {"concat": ["10.96.0.10", 80]}], [{"concat": ["udp", 30053]}, {"concat": ["10.96.0.11", 53]}]]}}]}`,
+			expElements: map[string]string{
+				"tcp . 30080": "10.96.0.10 . 80",
+				"udp . 30053": "10.96.0.11 . 53",
+			},
+		},
+		{
+			desc: "verdicts of a verdict map",
+			set:  &NFTSet{Name: "lbs", KeyType: "ipv4_addr", ValueType: "verdict"},
+			output: `{"nftables": [{"map": {"family": "inet", "name": "lbs", "table": "ovn-kubernetes", "type": "ipv4_addr", ` +
+				`"map": "verdict", "elem": [["1.1.1.1", {"goto": {"target": "lb"}}]]}}]}`,
+			expElements: map[string]string{"1.1.1.1": "goto lb"},
+		},
+		{
+			desc: "keys of a set",
+			set:  &NFTSet{Name: "marks", KeyType: "ipv6_addr . inet_proto . inet_service"},
+			output: `{"nftables": [{"set": {"family": "inet", "name": "marks", "table": "ovn-kubernetes", ` +
+				`"type": ["ipv6_addr", "inet_proto", "inet_service"], "elem": [{"concat": ["fd00::1", "tcp", 8080]}]}}]}`,
+			expElements: map[string]string{"fd00::1 . tcp . 8080": ""},
+		},
+		{
+			desc:        "empty map",
+			set:         &NFTSet{Name: "lbs", KeyType: "ipv4_addr", ValueType: "verdict"},
+			output:      `{"nftables": [{"map": {"family": "inet", "name": "lbs", "table": "ovn-kubernetes", "type": "ipv4_addr", "map": "verdict"}}]}`,
+			expElements: map[string]string{},
+		},
+		{
+			desc:        "malformed output",
+			set:         &NFTSet{Name: "lbs", KeyType: "ipv4_addr", ValueType: "verdict"},
+			output:      `{"nftables": [{"map": {"name": "lbs", "elem": ["1.1.1.1"]}}]}`,
+			errExpected: true,
+		},
+	}
+
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			fcmd := fakeexec.FakeCmd{
+				OutputScript: []fakeexec.FakeAction{
+					func() ([]byte, []byte, error) { return []byte(tc.output), nil, nil },
+				},
+			}
+			fexec := &fakeexec.FakeExec{
+				LookPathFunc: func(cmd string) (string, error) { return "/usr/sbin/" + cmd, nil },
+				CommandScript: []fakeexec.FakeCommandAction{
+					func(cmd string, args ...string) kexec.Cmd {
+						assert.Equal(t, []string{"-j", "list", tc.set.kind(), "inet", "ovn-kubernetes", tc.set.Name}, args)
+						return fakeexec.InitFakeCmd(&fcmd, cmd, args...)
+					},
+				},
+			}
+			nft, err := newExecNFTables(fexec, "ovn-kubernetes")
+			assert.NoError(t, err)
+			elements, err := nft.ListSetElements(tc.set)
+			if tc.errExpected {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expElements, elements)
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	kapi "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
@@ -74,6 +75,15 @@ const (
 	// OvnNodeEgressLabel is a user assigned node label indicating to ovn-kubernetes that the node is to be used for egress IP assignment
 	ovnNodeEgressLabel = "k8s.ovn.org/egress-assignable"
 
+	// ovnNodeEgressIPCapacity is a user assigned node annotation limiting the
+	// amount of egress IPs that can be assigned to the node
+	ovnNodeEgressIPCapacity = "k8s.ovn.org/egress-ip-capacity"
+
+	// ovnNodeEgressIPWeight is a user assigned node annotation setting the
+	// preference weight of the node for egress IP assignment: nodes are assigned
+	// egress IPs in proportion to their weight, which defaults to 1
+	ovnNodeEgressIPWeight = "k8s.ovn.org/egress-ip-weight"
+
 	// OVNNodeHostCIDRs is used to track the different host IP addresses and subnet masks on the node
 	OVNNodeHostCIDRs = "k8s.ovn.org/host-cidrs"
 
@@ -498,6 +508,8 @@ type ParsedNodeEgressIPConfiguration struct {
 	V4       ParsedIFAddr
 	V6       ParsedIFAddr
 	Capacity Capacity
+	// Weight is the preference weight of the node for egress IP assignment
+	Weight int
 }
 
 func getNodeIfAddrAnnotation(node *kapi.Node) (*primaryIfAddrAnnotation, error) {
@@ -620,6 +632,40 @@ func GetNodeEIPConfig(node *kapi.Node) (*ParsedNodeEgressIPConfiguration, error)
 	return parsedEgressIPConfig, nil
 }
 
+// ParseNodeEgressIPAssignmentConfig applies the user assigned egress IP
+// capacity and weight annotations of the node to its egress IP config. The
+// capacity can only lower the one reported by the cloud. A malformed
+// annotation is ignored, leaving the default capacity or weight in place, and
+// reported in the returned error.
+func ParseNodeEgressIPAssignmentConfig(node *kapi.Node, parsedEgressIPConfig *ParsedNodeEgressIPConfiguration) error {
+	var errs []error
+	if capacityAnnotation, ok := node.Annotations[ovnNodeEgressIPCapacity]; ok {
+		capacity, err := strconv.Atoi(capacityAnnotation)
+		if err != nil || capacity < 0 {
+			errs = append(errs, fmt.Errorf("invalid annotation %s %q for node %q, expected a non-negative integer", ovnNodeEgressIPCapacity, capacityAnnotation, node.Name))
+		} else if capacity < parsedEgressIPConfig.Capacity.IP {
+			parsedEgressIPConfig.Capacity.IP = capacity
+		}
+	}
+	parsedEgressIPConfig.Weight = 1
+	if weightAnnotation, ok := node.Annotations[ovnNodeEgressIPWeight]; ok {
+		weight, err := strconv.Atoi(weightAnnotation)
+		if err != nil || weight < 1 {
+			errs = append(errs, fmt.Errorf("invalid annotation %s %q for node %q, expected a positive integer", ovnNodeEgressIPWeight, weightAnnotation, node.Name))
+		} else {
+			parsedEgressIPConfig.Weight = weight
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// NodeEgressIPAssignmentAnnotationsChanged returns true if the user assigned
+// egress IP capacity or weight annotations of the node changed
+func NodeEgressIPAssignmentAnnotationsChanged(oldNode, newNode *kapi.Node) bool {
+	return oldNode.Annotations[ovnNodeEgressIPCapacity] != newNode.Annotations[ovnNodeEgressIPCapacity] ||
+		oldNode.Annotations[ovnNodeEgressIPWeight] != newNode.Annotations[ovnNodeEgressIPWeight]
+}
+
 // ParseCloudEgressIPConfig returns the cloud's information concerning the node's primary network interface
 func ParseCloudEgressIPConfig(node *kapi.Node) (*ParsedNodeEgressIPConfiguration, error) {
 	egressIPConfigAnnotation, ok := node.Annotations[cloudEgressIPConfigAnnotationKey]
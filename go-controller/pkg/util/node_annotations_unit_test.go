@@ -616,3 +616,77 @@ func TestParseNodeGatewayMTUSupport(t *testing.T) {
 		})
 	}
 }
+
+func TestParseNodeEgressIPAssignmentConfig(t *testing.T) {
+	tests := []struct {
+		desc        string
+		annotations map[string]string
+		errExpected bool
+		expCapacity int
+		expWeight   int
+	}{
+		{
+			desc:        "success: unlimited capacity and default weight without annotations",
+			expCapacity: UnlimitedNodeCapacity,
+			expWeight:   1,
+		},
+		{
+			desc:        "success: capacity and weight set by annotations",
+			annotations: map[string]string{"k8s.ovn.org/egress-ip-capacity": "2", "k8s.ovn.org/egress-ip-weight": "5"},
+			expCapacity: 2,
+			expWeight:   5,
+		},
+		{
+			desc:        "success: zero capacity",
+			annotations: map[string]string{"k8s.ovn.org/egress-ip-capacity": "0"},
+			expCapacity: 0,
+			expWeight:   1,
+		},
+		{
+			desc:        "error: negative capacity falls back to unlimited capacity",
+			annotations: map[string]string{"k8s.ovn.org/egress-ip-capacity": "-1"},
+			errExpected: true,
+			expCapacity: UnlimitedNodeCapacity,
+			expWeight:   1,
+		},
+		{
+			desc:        "error: zero weight falls back to the default weight",
+			annotations: map[string]string{"k8s.ovn.org/egress-ip-weight": "0"},
+			errExpected: true,
+			expCapacity: UnlimitedNodeCapacity,
+			expWeight:   1,
+		},
+		{
+			desc:        "error: weight is not an integer, the capacity is still applied",
+			annotations: map[string]string{"k8s.ovn.org/egress-ip-capacity": "3", "k8s.ovn.org/egress-ip-weight": "high"},
+			errExpected: true,
+			expCapacity: 3,
+			expWeight:   1,
+		},
+	}
+
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			node := v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "node1",
+					Annotations: map[string]string{"k8s.ovn.org/node-primary-ifaddr": `{"ipv4":"192.168.126.12/24"}`},
+				},
+			}
+			for k, v := range tc.annotations {
+				node.Annotations[k] = v
+			}
+			cfg, e := GetNodeEIPConfig(&node)
+			assert.NoError(t, e)
+			e = ParseNodeEgressIPAssignmentConfig(&node, cfg)
+			if tc.errExpected {
+				t.Log(e)
+				assert.Error(t, e)
+			} else {
+				assert.NoError(t, e)
+			}
+			assert.Equal(t, tc.expCapacity, cfg.Capacity.IP)
+			assert.Equal(t, tc.expWeight, cfg.Weight)
+		})
+	}
+}
@@ -509,11 +509,19 @@ func AddRoutesGatewayIP(
 		if err != nil {
 			return err
 		}
+		// a node might have additional host subnets, the gateway is the one
+		// of the host subnet the pod IP belongs to
+		for _, subnet := range nodeSubnets {
+			if subnet.Contains(podIfAddr.IP) {
+				nodeSubnet = subnet
+				break
+			}
+		}
 
 		gatewayIPnet := GetNodeGatewayIfAddr(nodeSubnet)
 
 		// Ensure default pod network traffic always goes to OVN
-		for _, clusterSubnet := range config.Default.ClusterSubnets {
+		for _, clusterSubnet := range config.ClusterSubnets() {
 			if isIPv6 == utilnet.IsIPv6CIDR(clusterSubnet.CIDR) {
 				podAnnotation.Routes = append(podAnnotation.Routes, PodRoute{
 					Dest:    clusterSubnet.CIDR,
@@ -33,9 +33,22 @@ import (
 //         "default": ["10.130.0.0/23", "fd01:0:0:2::/64"]
 //       }
 
+//
+// When node subnet expansion is enabled, ovnkube-controller reports the host subnets of
+// a node that have no IP left to allocate to pods in a similar annotation, upon which the
+// cluster manager allocates an additional host subnet of the same IP family to the node:
+//
+//   annotations:
+//     k8s.ovn.org/node-exhausted-subnets: |
+//       {
+//         "default": ["10.130.0.0/23"]
+//       }
+
 const (
 	// ovnNodeSubnets is the constant string representing the node subnets annotation key
 	ovnNodeSubnets = "k8s.ovn.org/node-subnets"
+	// ovnNodeExhaustedSubnets is the constant string representing the node exhausted subnets annotation key
+	ovnNodeExhaustedSubnets = "k8s.ovn.org/node-exhausted-subnets"
 )
 
 // updateSubnetAnnotation add the hostSubnets of the given network to the input node annotations;
@@ -209,3 +222,39 @@ func ParseNodesHostSubnetAnnotation(nodes []*kapi.Node, netName string) ([]*net.
 	}
 	return allSubnets, nil
 }
+
+// UpdateNodeExhaustedSubnetsAnnotation updates a "k8s.ovn.org/node-exhausted-subnets" annotation
+// for network "netName" with the specified host subnets, suitable for passing to
+// kube.SetAnnotationsOnNode. If exhaustedSubnets is empty, it deletes the annotation for
+// network "netName".
+func UpdateNodeExhaustedSubnetsAnnotation(annotations map[string]string, exhaustedSubnets []*net.IPNet, netName string) (map[string]string, error) {
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	err := updateSubnetAnnotation(annotations, ovnNodeExhaustedSubnets, netName, exhaustedSubnets)
+	if err != nil {
+		return nil, err
+	}
+	return annotations, nil
+}
+
+// ParseNodeExhaustedSubnetsAnnotation parses the "k8s.ovn.org/node-exhausted-subnets"
+// annotation on a node and returns the exhausted host subnets for the given network.
+func ParseNodeExhaustedSubnetsAnnotation(node *kapi.Node, netName string) ([]*net.IPNet, error) {
+	subnetsMap, err := parseSubnetAnnotation(node.Annotations, ovnNodeExhaustedSubnets)
+	if err != nil {
+		return nil, err
+	}
+	subnets, ok := subnetsMap[netName]
+	if !ok {
+		return nil, newAnnotationNotSetError("node %q has no %q annotation for network %s", node.Name, ovnNodeExhaustedSubnets, netName)
+	}
+
+	return subnets, nil
+}
+
+// SetNodeExhaustedSubnetsAnnotation sets a "k8s.ovn.org/node-exhausted-subnets"
+// annotation for the default network using a kube.Annotator
+func SetNodeExhaustedSubnetsAnnotation(nodeAnnotator kube.Annotator, exhaustedSubnets []*net.IPNet) error {
+	return setSubnetAnnotation(nodeAnnotator, ovnNodeExhaustedSubnets, exhaustedSubnets)
+}
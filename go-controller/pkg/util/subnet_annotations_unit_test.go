@@ -359,3 +359,43 @@ func TestParseNodeHostSubnetAnnotation(t *testing.T) {
 		})
 	}
 }
+
+func TestNodeExhaustedSubnetsAnnotation(t *testing.T) {
+	tests := []struct {
+		desc             string
+		inpAnnotations   map[string]string
+		exhaustedSubnets []*net.IPNet
+		outExp           map[string]string
+	}{
+		{
+			desc:             "sets the exhausted subnets of the default network",
+			exhaustedSubnets: ovntest.MustParseIPNets("10.244.0.0/24", "10.245.0.0/24"),
+			outExp: map[string]string{
+				"k8s.ovn.org/node-exhausted-subnets": `{"default":["10.244.0.0/24","10.245.0.0/24"]}`,
+			},
+		},
+		{
+			desc: "removes the annotation when no subnet is exhausted",
+			inpAnnotations: map[string]string{
+				"k8s.ovn.org/node-exhausted-subnets": `{"default":["10.244.0.0/24"]}`,
+			},
+			outExp: map[string]string{},
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			annotations, err := UpdateNodeExhaustedSubnetsAnnotation(tc.inpAnnotations, tc.exhaustedSubnets, types.DefaultNetworkName)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.outExp, annotations)
+
+			node := &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Annotations: annotations}}
+			exhaustedSubnets, err := ParseNodeExhaustedSubnetsAnnotation(node, types.DefaultNetworkName)
+			if len(tc.exhaustedSubnets) == 0 {
+				assert.True(t, IsAnnotationNotSetError(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.exhaustedSubnets, exhaustedSubnets)
+		})
+	}
+}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/urfave/cli/v2"
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
@@ -376,3 +377,14 @@ func GenerateId(length int) string {
 	}
 	return string(b)
 }
+
+// LabelSelectorsDisjoint returns true if no set of labels can match both selectors because they require
+// different values for the same label. Selectors that can't be proven disjoint may match the same objects.
+func LabelSelectorsDisjoint(a, b metav1.LabelSelector) bool {
+	for key, value := range a.MatchLabels {
+		if otherValue, ok := b.MatchLabels[key]; ok && otherValue != value {
+			return true
+		}
+	}
+	return false
+}
@@ -14,6 +14,7 @@ import (
 	mock_k8s_io_utils_exec "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/k8s.io/utils/exec"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/mocks"
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestGetLegacyK8sMgmtIntfName(t *testing.T) {
@@ -246,3 +247,40 @@ func TestGenerateId(t *testing.T) {
 	matchesPattern, _ := regexp.MatchString("([a-zA-Z0-9-]*)", id)
 	assert.True(t, matchesPattern)
 }
+
+func TestLabelSelectorsDisjoint(t *testing.T) {
+	tests := []struct {
+		desc string
+		a    metav1.LabelSelector
+		b    metav1.LabelSelector
+		want bool
+	}{
+		{
+			desc: "empty selectors select the same objects",
+		},
+		{
+			desc: "different values for the same label",
+			a:    metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			b:    metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+			want: true,
+		},
+		{
+			desc: "different labels may match the same objects",
+			a:    metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			b:    metav1.LabelSelector{MatchLabels: map[string]string{"tier": "frontend"}},
+		},
+		{
+			desc: "expressions may match the same objects",
+			a:    metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			b: metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+				{Key: "app", Operator: metav1.LabelSelectorOpExists},
+			}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.want, LabelSelectorsDisjoint(tc.a, tc.b))
+			assert.Equal(t, tc.want, LabelSelectorsDisjoint(tc.b, tc.a))
+		})
+	}
+}
@@ -1576,6 +1576,95 @@ spec:
 		framework.ExpectNoError(err, "27. Check connectivity from pod to an external \"node\" and verify that the IP is the egress IP, failed, err: %v", err)
 	})
 
+	/* This test does the following:
+	   0. Add the "k8s.ovn.org/egress-assignable" label to three nodes
+	   1. Create an EgressIP object with one egress IP defined
+	   2. Check that the status is of length one
+	   3. Drop the BFD packets received by the gateway router of the egress node, which stays reachable
+	   4. Check that the egress IP has been moved to another node
+	   5. Check connectivity from pod to an external "node" and verify that the IP is the egress IP
+	*/
+	ginkgo.It("Should re-assign egress IPs when the BFD sessions towards the egress node go down", func() {
+		if !isEgressIPBFDEnabled() {
+			ginkgo.Skip("Egress IP BFD is not enabled")
+		}
+
+		ginkgo.By("0. Add the \"k8s.ovn.org/egress-assignable\" label to three nodes")
+		e2enode.AddOrUpdateLabelOnNode(f.ClientSet, egress1Node.name, "k8s.ovn.org/egress-assignable", "dummy")
+		e2enode.AddOrUpdateLabelOnNode(f.ClientSet, egress2Node.name, "k8s.ovn.org/egress-assignable", "dummy")
+		e2enode.AddOrUpdateLabelOnNode(f.ClientSet, pod1Node.name, "k8s.ovn.org/egress-assignable", "dummy")
+
+		ginkgo.By("1. Create an EgressIP object with one egress IP defined")
+		// Assign the egress IP without conflicting with any node IP,
+		// the kind subnet is /16 or /64 so the following should be fine.
+		egressNodeIP := net.ParseIP(egress1Node.nodeIP)
+		egressIP1 := dupIP(egressNodeIP)
+		egressIP1[len(egressIP1)-2]++
+
+		podNamespace := f.Namespace
+		podNamespace.Labels = map[string]string{
+			"name": f.Namespace.Name,
+		}
+		updateNamespace(f, podNamespace)
+
+		var egressIPConfig = fmt.Sprintf(`apiVersion: k8s.ovn.org/v1
+kind: EgressIP
+metadata:
+    name: ` + egressIPName + `
+spec:
+    egressIPs:
+    - ` + egressIP1.String() + `
+    podSelector:
+        matchLabels:
+            wants: egress
+    namespaceSelector:
+        matchLabels:
+            name: ` + f.Namespace.Name + `
+`)
+		if err := os.WriteFile(egressIPYaml, []byte(egressIPConfig), 0644); err != nil {
+			framework.Failf("Unable to write CRD config to disk: %v", err)
+		}
+		defer func() {
+			if err := os.Remove(egressIPYaml); err != nil {
+				framework.Logf("Unable to remove the CRD config from disk: %v", err)
+			}
+		}()
+
+		framework.Logf("Applying the EgressIP configuration")
+		e2ekubectl.RunKubectlOrDie("default", "create", "-f", egressIPYaml)
+
+		ginkgo.By("2. Check that the status is of length one")
+		statuses := verifyEgressIPStatusLengthEquals(1, nil)
+		node1 := statuses[0].Node
+		createGenericPodWithLabel(f, pod1Name, pod1Node.name, f.Namespace.Name, command, podEgressLabel)
+
+		ginkgo.By(fmt.Sprintf("3. Drop the BFD packets received by the gateway router of egress node: %s", node1))
+		args := []string{"get", "pods", "--selector=app=ovnkube-node", "--field-selector", fmt.Sprintf("spec.nodeName=%s", node1), "-o", "jsonpath={.items..metadata.name}"}
+		ovnKubePodName := e2ekubectl.RunKubectlOrDie(ovnNamespace, args...)
+		dropBFDFlow := "priority=1000,table=0,udp,tp_dst=3784"
+		if IsIPv6Cluster(f.ClientSet) {
+			dropBFDFlow = "priority=1000,table=0,udp6,tp_dst=3784"
+		}
+		e2ekubectl.RunKubectlOrDie(ovnNamespace, "exec", ovnKubePodName, "-c", getNodeContainerName(), "--",
+			"ovs-ofctl", "add-flow", "breth0", dropBFDFlow+",actions=drop")
+		defer func() {
+			_, err := e2ekubectl.RunKubectl(ovnNamespace, "exec", ovnKubePodName, "-c", getNodeContainerName(), "--",
+				"ovs-ofctl", "--strict", "del-flows", "breth0", dropBFDFlow)
+			if err != nil {
+				framework.Logf("Unable to remove the BFD drop flow from node %s: %v", node1, err)
+			}
+		}()
+
+		ginkgo.By(fmt.Sprintf("4. Check that the egress IP has been moved away from node: %s, which is still reachable", node1))
+		verifyEgressIPStatusLengthEquals(1, func(statuses []egressIPStatus) bool {
+			return statuses[0].Node != node1
+		})
+
+		ginkgo.By("5. Check connectivity from pod to an external \"node\" and verify that the IP is the egress IP")
+		err := wait.PollImmediate(retryInterval, retryTimeout, targetExternalContainerAndTest(targetNode, pod1Name, podNamespace.Name, true, []string{egressIP1.String()}))
+		framework.ExpectNoError(err, "5. Check connectivity from pod to an external \"node\" and verify that the IP is the egress IP, failed, err: %v", err)
+	})
+
 	// Validate the egress IP works with egress firewall by creating two httpd
 	// containers on the kind networking (effectively seen as "outside" the cluster)
 	// and curl them from a pod in the cluster which matches the egress IP stanza.
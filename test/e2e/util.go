@@ -1099,6 +1099,11 @@ func isInterconnectEnabled() bool {
 	return present && val == "true"
 }
 
+func isEgressIPBFDEnabled() bool {
+	val, present := os.LookupEnv("OVN_ENABLE_EGRESSIP_BFD")
+	return present && val == "true"
+}
+
 func isLocalGWModeEnabled() bool {
 	val, present := os.LookupEnv("OVN_GATEWAY_MODE")
 	return present && val == "local"